@@ -214,7 +214,8 @@ STANDARD_BINARIES:=./istioctl/cmd/istioctl \
 # These are binaries that require Linux to build, and should
 # be skipped on other platforms. Notably this includes the current Linux-only Istio CNI plugin
 LINUX_AGENT_BINARIES:=./cni/cmd/istio-cni \
-  ./cni/cmd/install-cni
+  ./cni/cmd/install-cni \
+  ./cni/cmd/ambient-node-agent
 
 BINARIES:=$(STANDARD_BINARIES) $(AGENT_BINARIES) $(LINUX_AGENT_BINARIES)
 
@@ -283,6 +284,7 @@ refresh-goldens:
 		./pkg/bootstrap/... \
 		./pkg/kube/inject/... \
 		./pilot/pkg/security/authz/builder/... \
+		./pilot/pkg/xds/... \
 		./cni/pkg/plugin/...
 
 update-golden: refresh-goldens
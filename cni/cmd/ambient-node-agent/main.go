@@ -0,0 +1,283 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// ambient-node-agent runs the ambient node agent standalone, outside of the istio-cni DaemonSet, for nodes whose CNI
+// plugin chain is managed separately.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"istio.io/istio/cni/pkg/ambient"
+	ambientconstants "istio.io/istio/cni/pkg/ambient/constants"
+	"istio.io/istio/cni/pkg/monitoring"
+	"istio.io/istio/pkg/cmd"
+	"istio.io/pkg/log"
+)
+
+var (
+	logOptions = log.DefaultOptions()
+
+	kubeConfig           string
+	nodeName             string
+	systemNamespace      string
+	revision             string
+	redirectMode         string
+	configFile           string
+	ebpfLogLevel         string
+	conntrackFlush       bool
+	kubeProxyReplacement bool
+	ebpfShadow           bool
+	trimInformers        bool
+	reconcileTimeout     time.Duration
+	workers              int
+	nodeCheckInterval    time.Duration
+	readinessGate        bool
+	namespaces           []string
+	captureDir           string
+	installConfigMap     string
+	auditConfig          ambient.AuditConfig
+	kubeClient           ambient.KubeClientConfig
+	initContainers       string
+	nodeTraffic          string
+	nodeTrafficPorts     []int
+	dnsCapture           ambient.DNSCaptureConfig
+	routing              = ambient.DefaultRoutingConfig()
+	routingAutoResolve   bool
+	monitoringPort       int
+	adminPort            int
+	monitoringTLS        monitoring.TLSOptions
+	privilegedSocket     string
+)
+
+var rootCmd = &cobra.Command{
+	Use:          "ambient-node-agent",
+	Short:        "Add the pods of the node to the ambient mesh and redirect their traffic to ztunnel.",
+	SilenceUsage: true,
+	PreRunE: func(c *cobra.Command, args []string) error {
+		if err := log.Configure(logOptions); err != nil {
+			log.Errorf("Failed to configure log %v", err)
+		}
+		return nil
+	},
+	RunE: func(c *cobra.Command, args []string) error {
+		cmd.PrintFlags(c.Flags())
+		ctx := c.Context()
+
+		agentArgs, err := ambientArgs()
+		if err != nil {
+			return err
+		}
+
+		if len(monitoringTLS.AllowedIdentities) == 0 {
+			monitoringTLS.AllowedIdentities = monitoring.DefaultAllowedIdentities(systemNamespace)
+		}
+		mux := monitoring.SetupMonitoring(monitoringPort, "/metrics", false, monitoringTLS, ctx.Done())
+
+		server, err := ambient.NewServer(ctx, agentArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create ambient node agent: %v", err)
+		}
+		if mux != nil {
+			server.RegisterDebugHandlers(mux)
+		}
+		if admin := monitoring.SetupAdmin(adminPort, ctx.Done()); admin != nil {
+			server.RegisterAdminHandlers(admin)
+		}
+		server.Start()
+		<-ctx.Done()
+		server.Stop()
+		return nil
+	},
+}
+
+var privilegedHelperCmd = &cobra.Command{
+	Use:   "privileged-helper",
+	Short: "Program the redirection of a node agent started with --privileged-socket, which then runs unprivileged.",
+	Long: "Program the redirection of a node agent started with --privileged-socket, so the node agent watching the " +
+		"cluster runs without capabilities. The helper has no access to the cluster. It requires CAP_NET_ADMIN, " +
+		"CAP_NET_RAW and CAP_SYS_ADMIN, and CAP_SYS_RESOURCE in the ebpf redirect mode.",
+	SilenceUsage: true,
+	Args:         cobra.NoArgs,
+	PreRunE: func(c *cobra.Command, args []string) error {
+		if err := log.Configure(logOptions); err != nil {
+			log.Errorf("Failed to configure log %v", err)
+		}
+		return nil
+	},
+	RunE: func(c *cobra.Command, args []string) error {
+		cmd.PrintFlags(c.Flags())
+		if privilegedSocket == "" {
+			return fmt.Errorf("the socket must be set with --privileged-socket")
+		}
+		return ambient.ServePrivileged(c.Context(), privilegedSocket)
+	},
+}
+
+// ambientArgs returns the arguments of the node agent set by the flags.
+func ambientArgs() (ambient.AmbientArgs, error) {
+	mode := ambient.IptablesMode
+	switch redirectMode {
+	case ambient.IptablesMode.String():
+	case ambient.EbpfMode.String():
+		mode = ambient.EbpfMode
+	default:
+		return ambient.AmbientArgs{}, fmt.Errorf("unknown redirect mode %q", redirectMode)
+	}
+	if nodeName != "" {
+		ambient.NodeName = nodeName
+	}
+	if ambient.NodeName == "" {
+		return ambient.AmbientArgs{}, fmt.Errorf("the node name must be set with --node-name or the NODE_NAME environment variable")
+	}
+	return ambient.AmbientArgs{
+		SystemNamespace:      systemNamespace,
+		Revision:             revision,
+		KubeConfig:           kubeConfig,
+		RedirectMode:         mode,
+		ConfigFile:           configFile,
+		LogLevel:             ebpfLogLevel,
+		ConntrackFlush:       conntrackFlush,
+		KubeProxyReplacement: kubeProxyReplacement,
+		EbpfShadow:           ebpfShadow,
+		TrimInformers:        trimInformers,
+		ReconcileTimeout:     reconcileTimeout,
+		Workers:              workers,
+		NodeCheckInterval:    nodeCheckInterval,
+		ReadinessGate:        readinessGate,
+		Namespaces:           namespaces,
+		CaptureDir:           captureDir,
+		InstallConfigMap:     installConfigMap,
+		Audit:                auditConfig,
+		KubeClient:           kubeClient,
+		Routing:              routing,
+		RoutingAutoResolve:   routingAutoResolve,
+		PrivilegedSocket:     privilegedSocket,
+		NodeTraffic: ambient.NodeTrafficConfig{
+			Mode:  ambient.NodeTrafficMode(nodeTraffic),
+			Ports: nodeTrafficPorts,
+		},
+		DNSCapture:             dnsCapture,
+		InitContainerConflicts: ambient.InitContainerConflictPolicy(initContainers),
+	}, nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&privilegedSocket, "privileged-socket", "",
+		"Unix domain socket of the privileged helper programming the redirection; if unset, the agent programs it itself")
+	rootCmd.AddCommand(privilegedHelperCmd)
+
+	f := rootCmd.Flags()
+	f.StringVar(&kubeConfig, "kubeconfig", "", "Path of the kubeconfig file; the in-cluster configuration is used if unset")
+	f.StringVar(&nodeName, "node-name", "", "Name of the node the agent runs on; defaults to the NODE_NAME environment variable")
+	f.StringVar(&systemNamespace, "system-namespace", ambient.PodNamespace, "Namespace Istio is installed in")
+	f.StringVar(&revision, "revision", ambient.Revision, "Revision of the control plane the agent belongs to")
+	f.StringVar(&redirectMode, "redirect-mode", ambient.IptablesMode.String(), "How traffic is redirected to ztunnel: iptables or ebpf")
+	f.StringVar(&configFile, "config-file", "",
+		"Path the ambient configuration read by the istio-cni plugin is written to; defaults to the path used by the istio-cni DaemonSet")
+	f.StringVar(&ebpfLogLevel, "ebpf-log-level", "warn", "Log level of the eBPF redirection")
+	f.BoolVar(&conntrackFlush, "conntrack-flush", false,
+		"Whether to flush the conntrack entries of pods added to or removed from the mesh")
+	f.BoolVar(&ebpfShadow, "ebpf-shadow", false,
+		"Whether to run the ebpf redirection in observe-only mode alongside the iptables redirect mode, exporting the "+
+			"divergences between the pods each captures as metrics")
+	f.BoolVar(&kubeProxyReplacement, "kube-proxy-replacement", false,
+		"Whether services are handled by the CNI with eBPF instead of kube-proxy")
+	f.BoolVar(&trimInformers, "trim-informers", true,
+		"Whether to only cache the metadata of namespaces, and the fields of pods used by the agent")
+	f.DurationVar(&reconcileTimeout, "reconcile-timeout", 30*time.Second,
+		"How long the agent may take to reconcile a pod event before it is retried")
+	f.IntVar(&workers, "workers", 1, "How many pod events the agent reconciles concurrently")
+	f.DurationVar(&nodeCheckInterval, "node-check-interval", 30*time.Second,
+		"How often to check that the node redirection to ztunnel is still configured, and repair it. Set to 0 to disable the check")
+	f.BoolVar(&readinessGate, "readiness-gate", false,
+		"Whether to set the istio.io/ambient-ready condition of pods declaring it as a readiness gate once they are captured")
+	f.StringSliceVar(&namespaces, "namespaces", nil,
+		"Namespaces whose pods the agent may add to the mesh. All namespaces if empty")
+	f.StringVar(&captureDir, "capture-dir", "",
+		"Directory the packet captures of pods requested from the agent are saved to. Captures are disabled if empty")
+	f.StringVar(&installConfigMap, "config-map", "",
+		"ConfigMap in the system namespace holding the ambient settings of the installation, reloaded when they change")
+	f.StringVar(&auditConfig.Path, "audit-log", "",
+		"File the mutations of the host performed by the agent are appended to as JSON lines. Not written if empty")
+	f.IntVar(&auditConfig.MaxSizeMB, "audit-log-max-size", 100, "Size in megabytes at which the audit log is rotated")
+	f.IntVar(&auditConfig.MaxBackups, "audit-log-max-backups", 5, "How many rotated audit logs are retained")
+	f.BoolVar(&auditConfig.Events, "audit-events", false,
+		"Whether to record the mutations of the host as Kubernetes events too, on the pods they are performed for or on the node")
+	f.StringVar(&nodeTraffic, "node-traffic", string(ambient.NodeTrafficExclude),
+		"How the traffic of pods in the mesh to their node is handled by the iptables redirection: exclude, capture, or ports")
+	f.IntSliceVar(&nodeTrafficPorts, "node-traffic-ports", nil,
+		"Ports of the node whose TCP traffic is redirected to ztunnel in the ports node traffic mode")
+	f.StringVar((*string)(&dnsCapture.Backend), "dns-capture-backend", string(ambient.DNSCaptureZtunnel),
+		"Where the DNS requests of pods are redirected to by the iptables redirection: ztunnel, or node-local for a "+
+			"caching DNS shim run by the agent")
+	f.IntVar(&dnsCapture.ShimPort, "dns-shim-port", ambientconstants.DNSShimPort,
+		"UDP port the node-local DNS shim listens on on the node")
+	f.IntVar(&routing.RouteTableBase, "route-table-base", routing.RouteTableBase,
+		"First of the three consecutive route tables used by the iptables redirection")
+	f.IntVar(&routing.RulePriorityBase, "rule-priority-base", routing.RulePriorityBase,
+		"Priority of the first of the four consecutive ip rules used by the iptables redirection")
+	f.IntVar(&routing.FwmarkShift, "fwmark-shift", routing.FwmarkShift,
+		"How many bits the fwmarks used by the iptables redirection are shifted left by")
+	f.BoolVar(&routingAutoResolve, "routing-auto-resolve", true,
+		"Whether to use alternate route tables, rule priorities and fwmarks when the configured ones conflict with "+
+			"the routing configured on the node by others")
+	f.Float32Var(&kubeClient.QPS, "kube-client-qps", ambient.DefaultKubeClientQPS,
+		"Rate of requests per second to the API server, above which they are delayed by the agent")
+	f.IntVar(&kubeClient.Burst, "kube-client-burst", ambient.DefaultKubeClientBurst,
+		"Number of requests which may be sent to the API server above its rate in bursts")
+	f.StringVar(&initContainers, "init-container-conflicts", string(ambient.InitContainerConflictCapture),
+		"How pods whose init containers may program their network, privileged or adding NET_ADMIN, are captured: capture "+
+			"as soon as they are assigned an IP, skip, or serialize once their init containers completed")
+	f.IntVar(&monitoringPort, "monitoring-port", 15014, "HTTP port to serve prometheus metrics")
+	f.IntVar(&adminPort, "admin-port", 15016,
+		"HTTP port of the loopback interface to serve the handlers changing the redirection of the node on, such as its flush")
+	f.IntVar(&monitoringTLS.Port, "monitoring-tls-port", 15015,
+		"Port to serve the debug handlers on over TLS when the monitoring TLS certificate is set, apart from the metrics, "+
+			"which are still served over plain HTTP on the monitoring port")
+	f.StringVar(&monitoringTLS.CertFile, "monitoring-tls-cert-file", "",
+		"Certificate chain to serve the debug handlers over TLS with, reloaded when it changes. "+
+			"They are served on the monitoring port over plain HTTP if unset")
+	f.StringVar(&monitoringTLS.KeyFile, "monitoring-tls-key-file", "", "Private key of the monitoring TLS certificate, reloaded when it changes")
+	f.StringVar(&monitoringTLS.CAFile, "monitoring-tls-ca-file", "",
+		"CA certificates the client certificates of the monitoring port are verified with, reloaded when they change. "+
+			"If set, clients must present a certificate")
+	f.StringSliceVar(&monitoringTLS.AllowedIdentities, "monitoring-tls-allowed-identities", nil,
+		"The <namespace>/<service account> identities of the clients allowed on the monitoring port with mTLS. "+
+			"Defaults to istiod and istioctl in the system namespace")
+	logOptions.AttachCobraFlags(rootCmd)
+}
+
+func main() {
+	// Create context that cancels on termination signal
+	ctx, cancel := context.WithCancel(context.Background())
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func(sigChan chan os.Signal, cancel context.CancelFunc) {
+		sig := <-sigChan
+		log.Infof("Exit signal received: %s", sig)
+		cancel()
+	}(sigChan, cancel)
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
+		os.Exit(1)
+	}
+}
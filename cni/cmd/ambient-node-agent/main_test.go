@@ -0,0 +1,78 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"istio.io/istio/cni/pkg/ambient"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestAmbientArgs(t *testing.T) {
+	t.Cleanup(func() {
+		ambient.NodeName = ""
+	})
+
+	// The node name is required
+	ambient.NodeName = ""
+	_, err := ambientArgs()
+	assert.Error(t, err)
+
+	assert.NoError(t, rootCmd.ParseFlags([]string{
+		"--node-name=node1",
+		"--redirect-mode=ebpf",
+		"--system-namespace=mesh-system",
+		"--revision=canary",
+		"--workers=4",
+		"--reconcile-timeout=10s",
+		"--namespaces=a,b",
+		"--node-traffic=ports",
+		"--node-traffic-ports=8080,9090",
+		"--dns-capture-backend=node-local",
+		"--route-table-base=200",
+		"--routing-auto-resolve=false",
+		"--kube-client-qps=50",
+		"--init-container-conflicts=skip",
+		"--audit-log=/var/log/audit.log",
+		"--privileged-socket=/var/run/helper.sock",
+	}))
+	args, err := ambientArgs()
+	assert.NoError(t, err)
+	assert.Equal(t, ambient.NodeName, "node1")
+	assert.Equal(t, args.RedirectMode, ambient.EbpfMode)
+	assert.Equal(t, args.SystemNamespace, "mesh-system")
+	assert.Equal(t, args.Revision, "canary")
+	assert.Equal(t, args.Workers, 4)
+	assert.Equal(t, args.ReconcileTimeout, 10*time.Second)
+	assert.Equal(t, args.Namespaces, []string{"a", "b"})
+	assert.Equal(t, args.NodeTraffic, ambient.NodeTrafficConfig{Mode: ambient.NodeTrafficPorts, Ports: []int{8080, 9090}})
+	assert.Equal(t, args.DNSCapture.Backend, ambient.DNSCaptureNodeLocal)
+	assert.Equal(t, args.Routing.RouteTableBase, 200)
+	// The other routing settings keep their defaults
+	assert.Equal(t, args.Routing.RulePriorityBase, ambient.DefaultRoutingConfig().RulePriorityBase)
+	assert.Equal(t, args.RoutingAutoResolve, false)
+	assert.Equal(t, args.KubeClient.QPS, float32(50))
+	assert.Equal(t, args.KubeClient.Burst, ambient.DefaultKubeClientBurst)
+	assert.Equal(t, args.InitContainerConflicts, ambient.InitContainerConflictSkip)
+	assert.Equal(t, args.Audit.Path, "/var/log/audit.log")
+	assert.Equal(t, args.PrivilegedSocket, "/var/run/helper.sock")
+	assert.Equal(t, args.LogLevel, "warn")
+
+	assert.NoError(t, rootCmd.ParseFlags([]string{"--redirect-mode=nftables"}))
+	_, err = ambientArgs()
+	assert.Error(t, err)
+}
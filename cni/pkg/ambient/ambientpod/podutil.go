@@ -16,17 +16,23 @@ package ambientpod
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"istio.io/api/annotation"
 	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
 )
 
 // PodZtunnelEnabled determines if a pod is eligible for ztunnel redirection
-func PodZtunnelEnabled(namespace *corev1.Namespace, pod *corev1.Pod) bool {
+func PodZtunnelEnabled(namespace metav1.Object, pod *corev1.Pod) bool {
 	if namespace.GetLabels()[constants.DataplaneMode] != constants.DataplaneModeAmbient {
 		// Namespace does not have ambient mode enabled
 		return false
 	}
+	if kube.DataplaneModeNone(pod) {
+		// Pod is excluded from any dataplane
+		return false
+	}
 	if podHasSidecar(pod) {
 		// Ztunnel and sidecar for a single pod is currently not supported; opt out.
 		return false
@@ -0,0 +1,186 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/istio/cni/pkg/ambient/audit"
+	ebpf "istio.io/istio/cni/pkg/ebpf/server"
+)
+
+// auditEventQueueSize bounds the audit events waiting to be created. Events are dropped once it is reached, so the
+// mutations are not slowed down by the API server.
+const auditEventQueueSize = 1000
+
+// setupAudit configures the sinks the mutations of the host are recorded to. The events are only recorded by the node
+// agent, which has a client of the API server; the file is written by the process performing the mutations.
+func setupAudit(ctx context.Context, client kubernetes.Interface, cfg AuditConfig, file bool) {
+	var sinks []audit.Sink
+	if file && cfg.Path != "" {
+		sinks = append(sinks, audit.NewFileSink(cfg.Path, cfg.MaxSizeMB, cfg.MaxBackups))
+	}
+	if cfg.Events && client != nil {
+		sinks = append(sinks, newEventSink(ctx, client))
+	}
+	if len(sinks) == 0 {
+		return
+	}
+	log.Infof("recording the mutations of the host to the audit log")
+	audit.SetLogger(audit.NewLogger(NodeName, sinks...))
+}
+
+// eventSink records the audit entries as events on the pods they are attributed to, or on the node.
+type eventSink struct {
+	client  kubernetes.Interface
+	entries chan audit.Entry
+}
+
+func newEventSink(ctx context.Context, client kubernetes.Interface) *eventSink {
+	s := &eventSink{client: client, entries: make(chan audit.Entry, auditEventQueueSize)}
+	go s.run(ctx)
+	return s
+}
+
+func (s *eventSink) Write(e audit.Entry) {
+	select {
+	case s.entries <- e:
+	default:
+		auditEventsDropped.Increment()
+	}
+}
+
+func (s *eventSink) run(ctx context.Context) {
+	for {
+		select {
+		case e := <-s.entries:
+			s.create(ctx, e)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *eventSink) create(ctx context.Context, e audit.Entry) {
+	event := auditEvent(e)
+	if _, err := s.client.CoreV1().Events(event.Namespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		log.Debugf("failed to create audit event: %v", err)
+		auditEventsDropped.Increment()
+	}
+}
+
+// auditEvent returns the event recording an audit entry.
+func auditEvent(e audit.Entry) *corev1.Event {
+	// Events of nodes are recorded in the default namespace, like the kubelet does
+	namespace := metav1.NamespaceDefault
+	involved := corev1.ObjectReference{APIVersion: "v1", Kind: "Node", Name: e.Node}
+	if ns, name, ok := strings.Cut(e.Pod, "/"); ok {
+		namespace = ns
+		involved = corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: ns, Name: name}
+	}
+	eventType, reason := corev1.EventTypeNormal, "HostMutation"
+	msg := fmt.Sprintf("%s: %s", e.Kind, e.Effect)
+	if e.Result == audit.ResultFailure {
+		eventType, reason = corev1.EventTypeWarning, "HostMutationFailed"
+		msg += ": " + e.Error
+	}
+	now := metav1.NewTime(e.Time)
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: involved.Name + ".",
+			Namespace:    namespace,
+		},
+		InvolvedObject: involved,
+		Reason:         reason,
+		Message:        msg,
+		Type:           eventType,
+		Source:         corev1.EventSource{Component: "istio-cni", Host: e.Node},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+}
+
+// auditCommand records a command run on the host, if it mutates the host. Commands only reading its state, such as
+// iptables checks, are not recorded.
+func auditCommand(ctx context.Context, cmd string, args []string, err error) {
+	if kind, mutating := classifyCommand(cmd, args); mutating {
+		audit.Record(ctx, kind, strings.Join(append([]string{cmd}, args...), " "), err)
+	}
+}
+
+// classifyCommand returns the kind of mutation performed by a command, and whether it mutates the host.
+func classifyCommand(cmd string, args []string) (string, bool) {
+	name := filepath.Base(cmd)
+	switch {
+	case strings.Contains(name, "tables"):
+		for _, a := range args {
+			switch a {
+			case "-C", "--check", "-L", "--list", "-S", "--list-rules":
+				return audit.KindIptables, false
+			}
+		}
+		return audit.KindIptables, true
+	case name == "ip":
+		// ip [-4|-6] <object> <command> ...
+		var words []string
+		for _, a := range args {
+			if !strings.HasPrefix(a, "-") {
+				words = append(words, a)
+			}
+		}
+		if len(words) < 2 {
+			return "", false
+		}
+		switch words[1] {
+		case "show", "list", "get":
+			return "", false
+		}
+		switch words[0] {
+		case "route":
+			return audit.KindRoute, true
+		case "rule":
+			return audit.KindRule, true
+		case "link":
+			return audit.KindLink, true
+		case "addr", "address":
+			return audit.KindAddr, true
+		}
+		return words[0], true
+	case name == "ipset":
+		return audit.KindIpset, true
+	}
+	return "", false
+}
+
+// auditEBPFRequest records a request of the eBPF redirection once it was handled.
+func auditEBPFRequest(args *ebpf.RedirectArgs, err error) {
+	verb := "add"
+	if args.Remove {
+		verb = "remove"
+	}
+	target := fmt.Sprintf("pod %v", args.IPAddrs)
+	if args.IsZtunnel {
+		target = "ztunnel"
+	}
+	audit.RecordPod(args.Pod, audit.KindBPF, fmt.Sprintf("%s redirection of %s on ifindex %d", verb, target, args.Ifindex), err)
+}
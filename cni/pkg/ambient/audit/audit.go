@@ -0,0 +1,170 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the mutations of the host performed by the ambient node agent, such as the iptables rules,
+// ipset entries, routes and eBPF programs it configures, so the changes of the nodes are attributable.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+	"k8s.io/apimachinery/pkg/types"
+
+	istiolog "istio.io/pkg/log"
+)
+
+var log = istiolog.RegisterScope("audit", "ambient node agent audit log")
+
+// Kinds of mutations.
+const (
+	KindIptables = "iptables"
+	KindIpset    = "ipset"
+	KindRoute    = "route"
+	KindRule     = "rule"
+	KindLink     = "link"
+	KindAddr     = "addr"
+	KindBPF      = "bpf"
+	KindSysctl   = "sysctl"
+)
+
+// Results of mutations.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Entry is the record of a mutation of the host.
+type Entry struct {
+	Time time.Time `json:"time"`
+	Node string    `json:"node"`
+	// Pod is the pod the mutation was performed for, as namespace/name, or empty for the mutations of the node.
+	Pod    string `json:"pod,omitempty"`
+	Kind   string `json:"kind"`
+	Effect string `json:"effect"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Sink receives the audit entries. Write is called as the mutations are performed, so it must not block on remote
+// calls.
+type Sink interface {
+	Write(e Entry)
+}
+
+// Logger records the audit entries of a node to its sinks.
+type Logger struct {
+	node  string
+	sinks []Sink
+}
+
+// NewLogger creates a Logger recording the entries of a node to the sinks.
+func NewLogger(node string, sinks ...Sink) *Logger {
+	return &Logger{node: node, sinks: sinks}
+}
+
+var current atomic.Pointer[Logger]
+
+// SetLogger sets the Logger recording the mutations of the node agent. Mutations are not recorded until it is set.
+func SetLogger(l *Logger) {
+	current.Store(l)
+}
+
+type podKey struct{}
+
+// WithPod returns a context attributing the mutations performed with it to a pod.
+func WithPod(ctx context.Context, pod types.NamespacedName) context.Context {
+	return context.WithValue(ctx, podKey{}, pod)
+}
+
+// PodFrom returns the pod the mutations performed with the context are attributed to, if any.
+func PodFrom(ctx context.Context) (types.NamespacedName, bool) {
+	pod, ok := ctx.Value(podKey{}).(types.NamespacedName)
+	return pod, ok
+}
+
+// Record records a mutation of the host, attributed to the pod of the context, if any.
+func Record(ctx context.Context, kind, effect string, err error) {
+	pod, _ := PodFrom(ctx)
+	RecordPod(pod, kind, effect, err)
+}
+
+// RecordNode records a mutation of the host performed for the node, returning its error.
+func RecordNode(kind, effect string, err error) error {
+	RecordPod(types.NamespacedName{}, kind, effect, err)
+	return err
+}
+
+// RecordPod records a mutation of the host performed for a pod, or for the node if the pod is empty.
+func RecordPod(pod types.NamespacedName, kind, effect string, err error) {
+	l := current.Load()
+	if l == nil {
+		return
+	}
+	e := Entry{
+		Time:   time.Now().UTC(),
+		Node:   l.node,
+		Kind:   kind,
+		Effect: effect,
+		Result: ResultSuccess,
+	}
+	if pod.Name != "" {
+		e.Pod = pod.String()
+	}
+	if err != nil {
+		e.Result = ResultFailure
+		e.Error = err.Error()
+	}
+	for _, s := range l.sinks {
+		s.Write(e)
+	}
+}
+
+// fileSink appends the entries to a file as JSON lines.
+type fileSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFileSink returns a Sink appending the entries to a file, which is rotated once it reaches maxSizeMB megabytes.
+// maxBackups rotated files are retained.
+func NewFileSink(path string, maxSizeMB, maxBackups int) Sink {
+	return newWriterSink(&lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    maxSizeMB,
+		MaxBackups: maxBackups,
+	})
+}
+
+func newWriterSink(w io.Writer) *fileSink {
+	return &fileSink{w: w}
+}
+
+func (s *fileSink) Write(e Entry) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		log.Warnf("failed to marshal audit entry: %v", err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(b, '\n')); err != nil {
+		log.Warnf("failed to write audit entry: %v", err)
+	}
+}
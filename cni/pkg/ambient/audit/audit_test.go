@@ -0,0 +1,68 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+type entries []Entry
+
+func (e *entries) Write(entry Entry) {
+	*e = append(*e, entry)
+}
+
+func TestRecord(t *testing.T) {
+	t.Cleanup(func() { SetLogger(nil) })
+	// Mutations are not recorded until a Logger is set
+	RecordNode(KindRoute, "add route", nil)
+
+	got := &entries{}
+	SetLogger(NewLogger("node1", got))
+	ctx := WithPod(context.Background(), types.NamespacedName{Namespace: "ns", Name: "pod"})
+	Record(ctx, KindIptables, "iptables -A chain", nil)
+	assert.Error(t, RecordNode(KindRoute, "add route", errors.New("exists")))
+
+	assert.Equal(t, len(*got), 2)
+	assert.Equal(t, (*got)[0].Node, "node1")
+	assert.Equal(t, (*got)[0].Pod, "ns/pod")
+	assert.Equal(t, (*got)[0].Result, ResultSuccess)
+	assert.Equal(t, (*got)[1].Pod, "")
+	assert.Equal(t, (*got)[1].Result, ResultFailure)
+	assert.Equal(t, (*got)[1].Error, "exists")
+}
+
+func TestWriterSink(t *testing.T) {
+	buf := &bytes.Buffer{}
+	s := newWriterSink(buf)
+	s.Write(Entry{Node: "node1", Pod: "ns/pod", Kind: KindIpset, Effect: "add 10.0.0.1", Result: ResultSuccess})
+	s.Write(Entry{Node: "node1", Kind: KindBPF, Effect: "update host IP", Result: ResultFailure, Error: "failed"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Equal(t, len(lines), 2)
+	e := Entry{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	assert.Equal(t, e.Kind, KindBPF)
+	assert.Equal(t, e.Error, "failed")
+}
@@ -0,0 +1,64 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/cni/pkg/ambient/audit"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestClassifyCommand(t *testing.T) {
+	cases := []struct {
+		cmd      string
+		args     []string
+		kind     string
+		mutating bool
+	}{
+		{"iptables-nft", []string{"-t", "mangle", "-A", "ztunnel-PREROUTING", "-j", "RETURN"}, audit.KindIptables, true},
+		{"iptables", []string{"-t", "nat", "-C", "PREROUTING", "-j", "ztunnel-PREROUTING"}, audit.KindIptables, false},
+		{"/usr/sbin/ip6tables", []string{"-S"}, audit.KindIptables, false},
+		{"ip", []string{"route", "add", "table", "100", "10.0.0.1"}, audit.KindRoute, true},
+		{"ip", []string{"-4", "rule", "del", "priority", "100"}, audit.KindRule, true},
+		{"ip", []string{"route", "show", "table", "100"}, "", false},
+		{"ip", []string{"link"}, "", false},
+		{"ipset", []string{"add", "ztunnel-pods-ips", "10.0.0.1"}, audit.KindIpset, true},
+		{"nsenter", []string{"-t", "1"}, "", false},
+	}
+	for _, tt := range cases {
+		kind, mutating := classifyCommand(tt.cmd, tt.args)
+		assert.Equal(t, kind, tt.kind)
+		assert.Equal(t, mutating, tt.mutating)
+	}
+}
+
+func TestAuditEvent(t *testing.T) {
+	now := time.Now()
+	e := auditEvent(audit.Entry{Time: now, Node: "node1", Pod: "ns/pod", Kind: audit.KindIptables, Effect: "iptables -A chain", Result: audit.ResultSuccess})
+	assert.Equal(t, e.Namespace, "ns")
+	assert.Equal(t, e.InvolvedObject, corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "ns", Name: "pod"})
+	assert.Equal(t, e.Type, corev1.EventTypeNormal)
+	assert.Equal(t, e.Message, "iptables: iptables -A chain")
+
+	e = auditEvent(audit.Entry{Time: now, Node: "node1", Kind: audit.KindRoute, Effect: "add route", Result: audit.ResultFailure, Error: "exists"})
+	assert.Equal(t, e.Namespace, "default")
+	assert.Equal(t, e.InvolvedObject.Kind, "Node")
+	assert.Equal(t, e.Reason, "HostMutationFailed")
+	assert.Equal(t, e.Message, "route: add route: exists")
+}
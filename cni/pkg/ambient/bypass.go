@@ -0,0 +1,206 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+	"istio.io/istio/cni/pkg/ambient/podbypass"
+	"istio.io/istio/pkg/kube/controllers"
+)
+
+const redirectionBypassedReason = "AmbientRedirectionBypassed"
+
+var (
+	errBypassNotEnrolled = errors.New("pod is not redirected to ztunnel")
+	errBypassInProgress  = errors.New("the redirection of the pod is already bypassed")
+	errBypassNotFound    = errors.New("the redirection of the pod is not bypassed")
+	errBypassFlushed     = errors.New("the redirection of the node was flushed")
+	errBypassNotAllowed  = fmt.Errorf("the namespace of the pod does not allow bypassing the redirection of its pods, "+
+		"annotate it with %s=true", constants.BypassAllowedAnnotation)
+)
+
+// StartBypass temporarily removes a pod redirected to ztunnel from the mesh, for the duration of the request, to debug
+// application issues suspected to be caused by the mesh. The pod is added back to the mesh once the bypass expires,
+// or is stopped. Bypasses are not persisted, so the pods bypassed when the node agent restarts are added back to the
+// mesh when it starts. Only the pods of the namespaces annotated with BypassAllowedAnnotation may be bypassed.
+func (s *Server) StartBypass(req podbypass.Request) (*podbypass.Response, error) {
+	pod := s.pods.Get(req.Name, req.Namespace)
+	if pod == nil || pod.Spec.NodeName != NodeName {
+		return nil, errCaptureNotFound
+	}
+	if !s.bypassAllowed(pod) {
+		return nil, errBypassNotAllowed
+	}
+	s.mu.Lock()
+	_, bypassed := s.bypassedPods[pod.UID]
+	s.mu.Unlock()
+	if bypassed {
+		return nil, errBypassInProgress
+	}
+	if !s.podEnrolled(pod) {
+		return nil, errBypassNotEnrolled
+	}
+
+	// The pod is removed like its other events, so it is not concurrently enrolled again
+	s.reconcileMu.RLock()
+	defer s.reconcileMu.RUnlock()
+	if s.isFlushed() {
+		return nil, errBypassFlushed
+	}
+	ctx, cancel := s.bypassContext()
+	defer cancel()
+	// Tracked before the pod is removed from the mesh, so the updates of the removal do not enroll it again
+	expires := time.Now().Add(req.Duration)
+	s.mu.Lock()
+	s.bypassedPods[pod.UID] = time.AfterFunc(req.Duration, func() {
+		s.restoreBypassedPod(pod)
+	})
+	s.mu.Unlock()
+	if err := s.DelPodFromMesh(ctx, pod); err != nil {
+		s.stopBypass(pod.UID)
+		return nil, fmt.Errorf("failed to remove the redirection of the pod: %v", err)
+	}
+	name := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	log.Warnf("bypassing the redirection of pod %s until %v", name, expires.Format(time.RFC3339))
+	s.recordPodWarning(ctx, pod, redirectionBypassedReason,
+		fmt.Sprintf("The redirection of the pod to ztunnel is bypassed for %v, for debugging", req.Duration))
+	return &podbypass.Response{Node: NodeName, Expires: expires.UTC().Format(time.RFC3339)}, nil
+}
+
+// StopBypass adds a bypassed pod back to the mesh before its bypass expires.
+func (s *Server) StopBypass(req podbypass.Request) (*podbypass.Response, error) {
+	pod := s.pods.Get(req.Name, req.Namespace)
+	if pod == nil || pod.Spec.NodeName != NodeName {
+		return nil, errCaptureNotFound
+	}
+	if !s.stopBypass(pod.UID) {
+		return nil, errBypassNotFound
+	}
+	s.enqueueBypassedPod(pod)
+	return &podbypass.Response{Node: NodeName}, nil
+}
+
+// bypassAllowed returns whether the namespace of the pod allows bypassing the redirection of its pods.
+func (s *Server) bypassAllowed(pod *corev1.Pod) bool {
+	ns := s.namespaces.Get(pod.Namespace, "")
+	return ns != nil && ns.GetAnnotations()[constants.BypassAllowedAnnotation] == "true"
+}
+
+func (s *Server) bypassContext() (context.Context, context.CancelFunc) {
+	if s.reconcileTimeout <= 0 {
+		return context.WithCancel(s.reconcileCtx)
+	}
+	return context.WithTimeout(s.reconcileCtx, s.reconcileTimeout)
+}
+
+// stopBypass stops tracking the bypass of a pod, and returns whether it was bypassed.
+func (s *Server) stopBypass(uid types.UID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	timer, f := s.bypassedPods[uid]
+	if !f {
+		return false
+	}
+	timer.Stop()
+	delete(s.bypassedPods, uid)
+	return true
+}
+
+// bypassed returns whether the redirection of the pod is bypassed, in which case it is not enrolled.
+func (s *Server) bypassed(pod *corev1.Pod) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, f := s.bypassedPods[pod.UID]
+	return f
+}
+
+// restoreBypassedPod adds a pod back to the mesh once its bypass expired.
+func (s *Server) restoreBypassedPod(pod *corev1.Pod) {
+	if !s.stopBypass(pod.UID) {
+		return
+	}
+	log.Infof("the bypass of the redirection of pod %s/%s expired", pod.Namespace, pod.Name)
+	s.enqueueBypassedPod(pod)
+}
+
+// enqueueBypassedPod enqueues the pod whose bypass ended, so it is enrolled again with the other events of the pod,
+// and retried if it fails. Pods which were deleted, or left the mesh, meanwhile are not enrolled again.
+func (s *Server) enqueueBypassedPod(pod *corev1.Pod) {
+	current := s.pods.Get(pod.Name, pod.Namespace)
+	if current == nil || current.UID != pod.UID {
+		return
+	}
+	s.queue.Add(controllers.Event{
+		New:   current,
+		Event: controllers.EventAdd,
+	})
+}
+
+func bypassErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errCaptureNotFound), errors.Is(err, errBypassNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errBypassNotAllowed):
+		return http.StatusForbidden
+	case errors.Is(err, errBypassNotEnrolled), errors.Is(err, errBypassInProgress), errors.Is(err, errBypassFlushed):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+func (s *Server) registerBypassHandler(mux *http.ServeMux) {
+	mux.HandleFunc(podbypass.Path, func(w http.ResponseWriter, r *http.Request) {
+		var bypass func(podbypass.Request) (*podbypass.Response, error)
+		switch r.Method {
+		case http.MethodPost:
+			bypass = s.StartBypass
+		case http.MethodDelete:
+			bypass = s.StopBypass
+		default:
+			w.Header().Set("Allow", http.MethodPost+", "+http.MethodDelete)
+			http.Error(w, "only POST and DELETE are supported", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := podbypass.ParseRequest(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, err := bypass(req)
+		if err != nil {
+			http.Error(w, err.Error(), bypassErrorStatus(err))
+			return
+		}
+		b, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(b); err != nil {
+			log.Debugf("failed to write bypass response: %v", err)
+		}
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	ambientconstants "istio.io/istio/cni/pkg/ambient/constants"
+	"istio.io/istio/cni/pkg/ambient/podbypass"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestBypassHandler(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "ambient",
+		Labels:      map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+		Annotations: map[string]string{ambientconstants.BypassAllowedAnnotation: "true"},
+	}}
+	lockedNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "locked",
+		Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+	}}
+	enrolled := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "enrolled",
+			Namespace:   "ambient",
+			UID:         "enrolled",
+			Annotations: map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+	other := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ambient", UID: "other"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.2"},
+	}
+	locked := enrolled.DeepCopy()
+	locked.Namespace, locked.UID = "locked", "locked"
+	client := kube.NewFakeClient(ns, lockedNs, enrolled, other, locked)
+	redirector := &fakeRedirector{}
+	s := &Server{
+		ctx:          context.Background(),
+		reconcileCtx: context.Background(),
+		kubeClient:   client,
+		pods:         kclient.New[*corev1.Pod](client),
+		namespaces:   kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+		redirectMode: ExternalMode,
+		redirector:   redirector,
+		desiredState: desiredStateOf(enrolled),
+		ztunnelPod:   &corev1.Pod{},
+		bypassedPods: map[types.UID]*time.Timer{},
+	}
+	reconciled := make(chan controllers.Event, 1)
+	s.queue = newWorkQueue(1, func(key any) error {
+		event := key.(controllers.Event)
+		err := s.reconcilePod(s.ctx, event)
+		reconciled <- event
+		return err
+	})
+	stop := test.NewStop(t)
+	client.RunAndWait(stop)
+	go s.queue.Run(stop)
+	mux := http.NewServeMux()
+	s.RegisterAdminHandlers(mux)
+	request := func(method string, req podbypass.Request) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, req.Query(), nil))
+		return rec.Code
+	}
+	bypass := podbypass.Request{Namespace: "ambient", Name: "enrolled", Duration: time.Hour}
+
+	assert.Equal(t, request(http.MethodGet, bypass), http.StatusMethodNotAllowed)
+	assert.Equal(t, request(http.MethodPost, podbypass.Request{Name: "enrolled"}), http.StatusBadRequest)
+	assert.Equal(t, request(http.MethodPost, podbypass.Request{Namespace: "ambient", Name: "missing"}), http.StatusNotFound)
+	assert.Equal(t, request(http.MethodPost, podbypass.Request{Namespace: "ambient", Name: "other"}), http.StatusConflict)
+	assert.Equal(t, request(http.MethodDelete, bypass), http.StatusNotFound)
+	// The namespace of the pod must allow bypassing the redirection of its pods
+	assert.Equal(t, request(http.MethodPost, podbypass.Request{Namespace: "locked", Name: "enrolled", Duration: time.Hour}),
+		http.StatusForbidden)
+
+	assert.Equal(t, request(http.MethodPost, bypass), http.StatusOK)
+	assert.Equal(t, s.desiredState.hasPod(enrolled.UID), false)
+	assert.Equal(t, request(http.MethodPost, bypass), http.StatusConflict)
+	// Bypassed pods are not enrolled again until the bypass ends
+	assert.NoError(t, s.reconcilePod(s.ctx, controllers.Event{New: enrolled, Event: controllers.EventAdd}))
+	assert.Equal(t, len(redirector.added), 0)
+
+	// Stopping the bypass enrolls the pod again
+	assert.Equal(t, request(http.MethodDelete, bypass), http.StatusOK)
+	assert.Equal(t, (<-reconciled).Event, controllers.EventAdd)
+	assert.Equal(t, redirector.added, []string{"enrolled"})
+	assert.Equal(t, s.desiredState.hasPod(enrolled.UID), true)
+	assert.Equal(t, request(http.MethodDelete, bypass), http.StatusNotFound)
+
+	// So does the expiry of the bypass
+	_, err := s.StartBypass(podbypass.Request{Namespace: "ambient", Name: "enrolled", Duration: time.Millisecond})
+	assert.NoError(t, err)
+	<-reconciled
+	assert.Equal(t, redirector.added, []string{"enrolled", "enrolled"})
+	assert.Equal(t, s.bypassed(enrolled), false)
+}
@@ -0,0 +1,96 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var felixConfigurations = schema.GroupVersionResource{Group: "crd.projectcalico.org", Version: "v1", Resource: "felixconfigurations"}
+
+// calicoEBPFDevices are created on the node by the Calico eBPF dataplane, by its current and older releases.
+var calicoEBPFDevices = []string{"bpfout.cali", "bpfnatout"}
+
+// calicoDataplane is the Calico eBPF dataplane of the node, as configured by the FelixConfigurations.
+type calicoDataplane struct {
+	// EBPF is set if Calico runs its eBPF dataplane on the node.
+	EBPF bool
+	// ConnectTimeLoadBalancing is set if Calico translates service VIPs when pods connect, in their socket calls.
+	ConnectTimeLoadBalancing bool
+}
+
+// detectCalicoDataplane detects the Calico eBPF dataplane from the default FelixConfiguration and the one of the node,
+// which overrides it. The dataplane is also detected from the devices it creates on the node, so it is not missed if
+// the FelixConfigurations cannot be read.
+func detectCalicoDataplane(ctx context.Context, client dynamic.Interface, node string) calicoDataplane {
+	// Connect-time load balancing is enabled by default
+	d := calicoDataplane{ConnectTimeLoadBalancing: true}
+	for _, name := range []string{"default", "node." + node} {
+		cfg, err := client.Resource(felixConfigurations).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				log.Debugf("failed to read the FelixConfiguration %s: %v", name, err)
+			}
+			continue
+		}
+		spec, _ := cfg.Object["spec"].(map[string]any)
+		d.apply(spec)
+	}
+	for _, dev := range calicoEBPFDevices {
+		if _, err := netlink.LinkByName(dev); err == nil {
+			d.EBPF = true
+		}
+	}
+	return d
+}
+
+// apply applies the fields of the spec of a FelixConfiguration.
+func (d *calicoDataplane) apply(spec map[string]any) {
+	if v, ok := spec["bpfEnabled"].(bool); ok {
+		d.EBPF = v
+	}
+	if v, ok := spec["bpfConnectTimeLoadBalancing"].(string); ok {
+		d.ConnectTimeLoadBalancing = v != "Disabled"
+	} else if v, ok := spec["bpfConnectTimeLoadBalancingEnabled"].(bool); ok {
+		// Replaced by bpfConnectTimeLoadBalancing in Calico 3.28
+		d.ConnectTimeLoadBalancing = v
+	}
+}
+
+// check returns why the redirection cannot capture the traffic of pods with the Calico eBPF dataplane, if it cannot.
+// Calico translates service VIPs on the veths of pods, which is only captured by the eBPF redirection, whose programs
+// run ahead of the Calico ones, and only if the VIPs are not already translated when pods connect.
+func (d calicoDataplane) check(mode RedirectMode) error {
+	if !d.EBPF {
+		return nil
+	}
+	if d.ConnectTimeLoadBalancing {
+		return fmt.Errorf("the Calico eBPF dataplane translates service VIPs when pods connect, before their traffic can " +
+			"be redirected to ztunnel; set bpfConnectTimeLoadBalancing to Disabled, and bpfHostNetworkedNATWithoutCTLB " +
+			"to Enabled, in the default FelixConfiguration")
+	}
+	if mode != EbpfMode {
+		return fmt.Errorf("the Calico eBPF dataplane translates service VIPs on the veths of pods, before the %v "+
+			"redirection; use the ebpf redirect mode", mode)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func felixConfiguration(name string, spec map[string]any) runtime.Object {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "crd.projectcalico.org/v1",
+		"kind":       "FelixConfiguration",
+		"metadata":   map[string]any{"name": name},
+		"spec":       spec,
+	}}
+}
+
+func TestDetectCalicoDataplane(t *testing.T) {
+	cases := []struct {
+		name     string
+		configs  []runtime.Object
+		expected calicoDataplane
+	}{
+		{
+			name:     "no calico",
+			expected: calicoDataplane{ConnectTimeLoadBalancing: true},
+		},
+		{
+			name:     "iptables dataplane",
+			configs:  []runtime.Object{felixConfiguration("default", map[string]any{"bpfEnabled": false})},
+			expected: calicoDataplane{ConnectTimeLoadBalancing: true},
+		},
+		{
+			name:     "ebpf dataplane",
+			configs:  []runtime.Object{felixConfiguration("default", map[string]any{"bpfEnabled": true})},
+			expected: calicoDataplane{EBPF: true, ConnectTimeLoadBalancing: true},
+		},
+		{
+			name: "connect-time load balancing disabled",
+			configs: []runtime.Object{felixConfiguration("default", map[string]any{
+				"bpfEnabled":                  true,
+				"bpfConnectTimeLoadBalancing": "Disabled",
+			})},
+			expected: calicoDataplane{EBPF: true},
+		},
+		{
+			name: "deprecated connect-time load balancing field",
+			configs: []runtime.Object{felixConfiguration("default", map[string]any{
+				"bpfEnabled":                         true,
+				"bpfConnectTimeLoadBalancingEnabled": false,
+			})},
+			expected: calicoDataplane{EBPF: true},
+		},
+		{
+			name: "node override",
+			configs: []runtime.Object{
+				felixConfiguration("default", map[string]any{"bpfEnabled": true}),
+				felixConfiguration("node.node1", map[string]any{"bpfConnectTimeLoadBalancing": "Disabled"}),
+				felixConfiguration("node.node2", map[string]any{"bpfEnabled": false}),
+			},
+			expected: calicoDataplane{EBPF: true},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), tt.configs...)
+			assert.Equal(t, detectCalicoDataplane(context.Background(), client, "node1"), tt.expected)
+		})
+	}
+}
+
+func TestCalicoDataplaneCheck(t *testing.T) {
+	assert.NoError(t, calicoDataplane{ConnectTimeLoadBalancing: true}.check(IptablesMode))
+	assert.NoError(t, calicoDataplane{EBPF: true}.check(EbpfMode))
+	assert.Error(t, calicoDataplane{EBPF: true}.check(IptablesMode))
+	assert.Error(t, calicoDataplane{EBPF: true, ConnectTimeLoadBalancing: true}.check(EbpfMode))
+}
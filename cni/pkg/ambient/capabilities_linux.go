@@ -0,0 +1,111 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+type capability struct {
+	name string
+	bit  int
+}
+
+var (
+	capNetAdmin    = capability{"CAP_NET_ADMIN", unix.CAP_NET_ADMIN}
+	capNetRaw      = capability{"CAP_NET_RAW", unix.CAP_NET_RAW}
+	capSysAdmin    = capability{"CAP_SYS_ADMIN", unix.CAP_SYS_ADMIN}
+	capSysResource = capability{"CAP_SYS_RESOURCE", unix.CAP_SYS_RESOURCE}
+
+	// iptablesCapabilities are required by the iptables redirection: programming iptables, ipsets, routes, links and
+	// conntrack needs CAP_NET_ADMIN and CAP_NET_RAW, and entering the network namespace of ztunnel needs CAP_SYS_ADMIN.
+	iptablesCapabilities = []capability{capNetAdmin, capNetRaw, capSysAdmin}
+	// ebpfCapabilities are required by the eBPF redirection: loading and attaching the eBPF programs needs
+	// CAP_SYS_ADMIN and CAP_NET_ADMIN, and raising the locked memory limit for the maps needs CAP_SYS_RESOURCE.
+	ebpfCapabilities = []capability{capNetAdmin, capNetRaw, capSysAdmin, capSysResource}
+)
+
+// requiredCapabilities returns the capabilities required to program the redirection of the built-in redirect mode.
+func requiredCapabilities(mode RedirectMode) []capability {
+	switch mode {
+	case IptablesMode:
+		return iptablesCapabilities
+	case EbpfMode:
+		return ebpfCapabilities
+	}
+	return nil
+}
+
+// missingCapabilities returns the names of the capabilities which the process lacks among the given ones.
+func missingCapabilities(caps []capability) ([]string, error) {
+	effective, err := effectiveCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for _, c := range caps {
+		if effective&(1<<c.bit) == 0 {
+			missing = append(missing, c.name)
+		}
+	}
+	return missing, nil
+}
+
+// heldCapabilities returns the names of the capabilities which the process holds among the given ones.
+func heldCapabilities(caps []capability) ([]string, error) {
+	effective, err := effectiveCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	var held []string
+	for _, c := range caps {
+		if effective&(1<<c.bit) != 0 {
+			held = append(held, c.name)
+		}
+	}
+	return held, nil
+}
+
+// effectiveCapabilities returns the effective capability set of the process.
+func effectiveCapabilities() (uint64, error) {
+	f, err := os.Open("/proc/self/status")
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return parseEffectiveCapabilities(bufio.NewScanner(f))
+}
+
+func parseEffectiveCapabilities(scanner *bufio.Scanner) (uint64, error) {
+	for scanner.Scan() {
+		if v, ok := strings.CutPrefix(scanner.Text(), "CapEff:"); ok {
+			caps, err := strconv.ParseUint(strings.TrimSpace(v), 16, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid effective capabilities %q: %v", v, err)
+			}
+			return caps, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	return 0, fmt.Errorf("effective capabilities not found")
+}
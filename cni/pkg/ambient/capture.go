@@ -0,0 +1,238 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/cni/pkg/ambient/podcapture"
+)
+
+var (
+	errCaptureDisabled    = errors.New("packet captures are disabled, the capture directory of the node agent is not set")
+	errCaptureNotFound    = errors.New("pod not found on the node")
+	errCaptureNotEnrolled = errors.New("pod is not redirected to ztunnel")
+	errCaptureInProgress  = errors.New("a capture is already in progress on the node")
+	errCaptureDirFull     = errors.New("the capture directory is full, remove the previous captures")
+)
+
+// captureTarget is a device of the node the traffic of a pod is captured on.
+type captureTarget struct {
+	point  podcapture.Point
+	device string
+	// filter, if valid, restricts the capture to the packets from or to this address.
+	filter netip.Addr
+}
+
+// StartCapture starts capturing the traffic of a pod redirected to ztunnel, for the duration of the request, to pcap
+// files in the capture directory. The traffic is captured on the veth of the pod, and on the veth of ztunnel for the
+// traffic of ztunnel on behalf of the pod, so each side of ztunnel can be inspected. It returns the capture files.
+func (s *Server) StartCapture(req podcapture.Request) (*podcapture.Response, error) {
+	if s.captureDir == "" {
+		return nil, errCaptureDisabled
+	}
+	pod := s.pods.Get(req.Name, req.Namespace)
+	if pod == nil || pod.Spec.NodeName != NodeName {
+		return nil, errCaptureNotFound
+	}
+	if !s.podEnrolled(pod) {
+		return nil, errCaptureNotEnrolled
+	}
+	podIP, err := netip.ParseAddr(pod.Status.PodIP)
+	if err != nil {
+		return nil, ErrNoPodIP
+	}
+	points, err := req.Point.Points()
+	if err != nil {
+		return nil, err
+	}
+
+	// A single capture runs at a time, so the captures of the node are bounded in size along with the capture directory
+	name := types.NamespacedName{Namespace: req.Namespace, Name: req.Name}
+	s.mu.Lock()
+	if s.capture.Name != "" {
+		s.mu.Unlock()
+		return nil, errCaptureInProgress
+	}
+	s.capture = name
+	s.mu.Unlock()
+	started := false
+	release := func() {
+		s.mu.Lock()
+		s.capture = types.NamespacedName{}
+		s.mu.Unlock()
+	}
+	defer func() {
+		if !started {
+			release()
+		}
+	}()
+	size, err := captureDirSize(s.captureDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the size of the capture directory: %v", err)
+	}
+	// Each capture file may grow to its maximum size
+	if size+int64(len(points))*podcapture.MaxFileSize > podcapture.MaxDirSize {
+		return nil, fmt.Errorf("%w: it holds %d MiB of captures, at most %d MiB are kept", errCaptureDirFull, size>>20,
+			podcapture.MaxDirSize>>20)
+	}
+
+	var targets []captureTarget
+	for _, point := range points {
+		target := captureTarget{point: point}
+		switch point {
+		case podcapture.PointPod:
+			target.device, err = getDeviceWithDestinationOf(podIP.String())
+		case podcapture.PointZtunnel:
+			s.mu.Lock()
+			ztunnel := s.ztunnelPod
+			s.mu.Unlock()
+			if ztunnel == nil {
+				return nil, ErrZtunnelNotReady
+			}
+			target.device, err = getDeviceWithDestinationOf(ztunnel.Status.PodIP)
+			target.filter = podIP
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the %s device: %w", point, err)
+		}
+		targets = append(targets, target)
+	}
+
+	// The sockets are opened before responding, so missing permissions are reported to the caller
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	var captures []*packetCapture
+	closeAll := func() {
+		for _, c := range captures {
+			c.Close()
+		}
+	}
+	res := &podcapture.Response{Node: NodeName, Duration: req.Duration.String()}
+	for _, target := range targets {
+		file := filepath.Join(s.captureDir, fmt.Sprintf("%s_%s_%s_%s.pcap", req.Namespace, req.Name, target.point, stamp))
+		c, err := newPacketCapture(target, file)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to capture on %s: %v", target.device, err)
+		}
+		captures = append(captures, c)
+		res.Files = append(res.Files, file)
+	}
+
+	// Captures stop once the node agent stops
+	started = true
+	ctx, cancel := context.WithTimeout(s.reconcileCtx, req.Duration)
+	log.Infof("capturing the traffic of pod %s to %v for %v", name, res.Files, req.Duration)
+	go func() {
+		defer release()
+		defer cancel()
+		done := make(chan struct{})
+		for _, c := range captures {
+			c := c
+			go func() {
+				defer func() { done <- struct{}{} }()
+				if err := c.Run(ctx); err != nil {
+					log.Warnf("capture of pod %s on %s failed: %v", name, c.target.device, err)
+				}
+				c.Close()
+			}()
+		}
+		for range captures {
+			<-done
+		}
+		log.Infof("completed the capture of the traffic of pod %s", name)
+	}()
+	return res, nil
+}
+
+func captureErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, errCaptureDisabled):
+		return http.StatusNotImplemented
+	case errors.Is(err, errCaptureNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errCaptureNotEnrolled), errors.Is(err, errCaptureInProgress), errors.Is(err, ErrZtunnelNotReady):
+		return http.StatusConflict
+	case errors.Is(err, errCaptureDirFull):
+		return http.StatusInsufficientStorage
+	}
+	return http.StatusInternalServerError
+}
+
+func (s *Server) registerCaptureHandler(mux *http.ServeMux) {
+	mux.HandleFunc(podcapture.Path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		req, err := podcapture.ParseRequest(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		res, err := s.StartCapture(req)
+		if err != nil {
+			http.Error(w, err.Error(), captureErrorStatus(err))
+			return
+		}
+		b, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(b); err != nil {
+			log.Debugf("failed to write capture response: %v", err)
+		}
+	})
+}
+
+// captureDirSize returns the size of the capture files in the capture directory.
+func captureDirSize(dir string) (int64, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pcap"))
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		size += info.Size()
+	}
+	return size, nil
+}
+
+// removeCaptureFile removes a capture file which could not be written.
+func removeCaptureFile(file string) {
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		log.Debugf("failed to remove capture file %s: %v", file, err)
+	}
+}
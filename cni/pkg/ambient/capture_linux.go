@@ -0,0 +1,130 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"istio.io/istio/cni/pkg/ambient/podcapture"
+)
+
+// captureReadTimeout bounds the reads of the capture socket, so captures stop soon after their context is done.
+const captureReadTimeout = 250 * time.Millisecond
+
+// packetCapture captures the packets of a device of the node with an AF_PACKET socket, and writes them to a pcap file.
+type packetCapture struct {
+	target captureTarget
+	fd     int
+	file   *os.File
+	buf    *bufio.Writer
+	writer *podcapture.Writer
+	closed bool
+}
+
+func newPacketCapture(target captureTarget, path string) (*packetCapture, error) {
+	iface, err := net.InterfaceByName(target.device)
+	if err != nil {
+		return nil, err
+	}
+	// Opening the socket requires CAP_NET_RAW
+	fd, err := unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(htons(unix.ETH_P_ALL)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open packet socket: %v", err)
+	}
+	if err := unix.Bind(fd, &unix.SockaddrLinklayer{Protocol: htons(unix.ETH_P_ALL), Ifindex: iface.Index}); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to bind packet socket: %v", err)
+	}
+	tv := unix.NsecToTimeval(captureReadTimeout.Nanoseconds())
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		unix.Close(fd)
+		return nil, fmt.Errorf("failed to set packet socket timeout: %v", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	buf := bufio.NewWriter(file)
+	writer, err := podcapture.NewWriter(buf)
+	if err != nil {
+		unix.Close(fd)
+		file.Close()
+		removeCaptureFile(path)
+		return nil, err
+	}
+	return &packetCapture{target: target, fd: fd, file: file, buf: buf, writer: writer}, nil
+}
+
+// Run writes the captured packets until the context is done or the capture file reaches its maximum size.
+func (c *packetCapture) Run(ctx context.Context) error {
+	data := make([]byte, podcapture.SnapLen)
+	for ctx.Err() == nil {
+		// MSG_TRUNC returns the length of the packet on the wire, even if it is truncated
+		n, _, err := unix.Recvfrom(c.fd, data, unix.MSG_TRUNC)
+		if err != nil {
+			if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return err
+		}
+		frame := data[:min(n, len(data))]
+		if c.target.filter.IsValid() && !podcapture.HasAddress(frame, c.target.filter) {
+			continue
+		}
+		if err := c.writer.WritePacket(time.Now(), frame, n); err != nil {
+			return err
+		}
+		if c.writer.Size() >= podcapture.MaxFileSize {
+			log.Infof("capture %s reached its maximum size, stopping it", c.file.Name())
+			return nil
+		}
+	}
+	return nil
+}
+
+// Close closes the socket, and flushes the capture file.
+func (c *packetCapture) Close() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+	unix.Close(c.fd)
+	if err := c.buf.Flush(); err != nil {
+		log.Warnf("failed to write capture %s: %v", c.file.Name(), err)
+	}
+	if err := c.file.Close(); err != nil {
+		log.Warnf("failed to close capture %s: %v", c.file.Name(), err)
+	}
+}
+
+func htons(v uint16) uint16 {
+	return v<<8 | v>>8
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,90 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/cni/pkg/ambient/podcapture"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestCaptureHandler(t *testing.T) {
+	enrolled := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "enrolled", Namespace: "ambient", UID: "enrolled"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+	other := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ambient", UID: "other"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.2"},
+	}
+	client := kube.NewFakeClient(enrolled, other)
+	s := &Server{
+		ctx:          context.Background(),
+		reconcileCtx: context.Background(),
+		kubeClient:   client,
+		pods:         kclient.New[*corev1.Pod](client),
+		desiredState: desiredStateOf(enrolled),
+		captureDir:   t.TempDir(),
+	}
+	client.RunAndWait(test.NewStop(t))
+	mux := http.NewServeMux()
+	s.RegisterAdminHandlers(mux)
+	request := func(method string, req podcapture.Request) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, req.Query(), nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, request(http.MethodGet, podcapture.Request{Namespace: "ambient", Name: "enrolled"}), http.StatusMethodNotAllowed)
+	assert.Equal(t, request(http.MethodPost, podcapture.Request{Name: "enrolled"}), http.StatusBadRequest)
+	assert.Equal(t, request(http.MethodPost, podcapture.Request{Namespace: "ambient", Name: "enrolled", Point: "node"}),
+		http.StatusBadRequest)
+	assert.Equal(t, request(http.MethodPost, podcapture.Request{Namespace: "ambient", Name: "missing"}), http.StatusNotFound)
+	assert.Equal(t, request(http.MethodPost, podcapture.Request{Namespace: "ambient", Name: "other"}), http.StatusConflict)
+	// The ztunnel side cannot be captured until ztunnel runs
+	assert.Equal(t, request(http.MethodPost, podcapture.Request{Namespace: "ambient", Name: "enrolled", Point: podcapture.PointZtunnel}),
+		http.StatusConflict)
+
+	// A single capture runs at a time on the node
+	s.capture = types.NamespacedName{Namespace: "ambient", Name: "other"}
+	assert.Equal(t, request(http.MethodPost, podcapture.Request{Namespace: "ambient", Name: "enrolled"}), http.StatusConflict)
+	s.capture = types.NamespacedName{}
+
+	// Captures are refused once they could fill the capture directory
+	f, err := os.Create(filepath.Join(s.captureDir, "previous.pcap"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Truncate(podcapture.MaxDirSize-podcapture.MaxFileSize))
+	assert.NoError(t, f.Close())
+	assert.Equal(t, request(http.MethodPost, podcapture.Request{Namespace: "ambient", Name: "enrolled"}),
+		http.StatusInsufficientStorage)
+	assert.Equal(t, s.capture, types.NamespacedName{})
+
+	// Captures are disabled without a capture directory
+	s.captureDir = ""
+	assert.Equal(t, request(http.MethodPost, podcapture.Request{Namespace: "ambient", Name: "enrolled"}), http.StatusNotImplemented)
+}
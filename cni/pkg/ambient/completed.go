@@ -0,0 +1,154 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// completedPodCleanupDelay is how long the cleanup of completed pods is delayed, so pods completing together, such
+// as the pods of a Job, are cleaned up in a single batch.
+const completedPodCleanupDelay = time.Second
+
+// podCompleted returns whether all containers of the pod have terminated and will not be restarted, such as the pods
+// of a finished Job. Completed pods have no traffic to capture, and their IPs are released for reuse by other pods.
+func podCompleted(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// completedPods batches the cleanup of pods which completed while enrolled in the mesh.
+type completedPods struct {
+	delay   time.Duration
+	cleanup func(pods []*corev1.Pod)
+
+	mu        sync.Mutex
+	pending   map[types.UID]*corev1.Pod
+	scheduled bool
+}
+
+func newCompletedPods(delay time.Duration, cleanup func(pods []*corev1.Pod)) *completedPods {
+	return &completedPods{
+		delay:   delay,
+		cleanup: cleanup,
+		pending: map[types.UID]*corev1.Pod{},
+	}
+}
+
+// add schedules the cleanup of a completed pod. Pods which were not enrolled have nothing to clean up.
+func (c *completedPods) add(pod *corev1.Pod) {
+	if pod.Annotations[constants.AmbientRedirection] != constants.AmbientRedirectionEnabled {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending[pod.UID] = pod
+	if !c.scheduled {
+		c.scheduled = true
+		time.AfterFunc(c.delay, c.flush)
+	}
+}
+
+func (c *completedPods) flush() {
+	c.mu.Lock()
+	pods := make([]*corev1.Pod, 0, len(c.pending))
+	for _, pod := range c.pending {
+		pods = append(pods, pod)
+	}
+	c.pending = map[types.UID]*corev1.Pod{}
+	c.scheduled = false
+	c.mu.Unlock()
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+	c.cleanup(pods)
+}
+
+// cleanupCompletedPods removes the redirection of a batch of completed pods. Unlike DelPodFromMesh, pods whose IPs
+// were already reused by another pod on the node are skipped, as the redirection now belongs to the new pod, and
+// failing to unannotate pods which were deleted in the meantime is expected.
+func (s *Server) cleanupCompletedPods(pods []*corev1.Pod) {
+	inUse := sets.New[string]()
+	for _, p := range s.pods.List(metav1.NamespaceAll, klabels.Everything()) {
+		if !podCompleted(p) {
+			inUse.InsertAll(podIPs(p)...)
+		}
+	}
+	cleanup := make([]*corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		s.desiredState.deletePod(pod.UID)
+	}
+	for _, pod := range pods {
+		if pod.Spec.HostNetwork {
+			continue
+		}
+		if ipReused(podIPs(pod), inUse) {
+			log.Debugf("IP of completed pod %s/%s was reused, skipping cleanup", pod.Namespace, pod.Name)
+			continue
+		}
+		cleanup = append(cleanup, pod)
+	}
+	if len(cleanup) == 0 {
+		return
+	}
+	log.Infof("Removing %d completed pods from mesh", len(cleanup))
+
+	if r, ok := s.redirector.(batchRedirector); ok {
+		r.delPods(s.ctx, cleanup)
+	} else {
+		for _, pod := range cleanup {
+			if err := s.redirector.DelPod(s.ctx, pod); err != nil {
+				log.Errorf("failed to remove redirection of completed pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+		}
+	}
+	for _, pod := range cleanup {
+		s.flushPodConntrack(pod)
+		if err := AnnotateUnenrollPod(s.ctx, s.kubeClient.Kube(), pod); err != nil && !kerrors.IsNotFound(err) {
+			log.Errorf("failed to annotate pod unenrollment: %v", err)
+		}
+		if err := s.enrollmentHook.notify(s.ctx, EnrollmentEventDelete, pod); err != nil {
+			log.Warnf("enrollment hook failed for completed pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+}
+
+// ipReused returns whether any IP of a completed pod is used by another pod. Pods without IPs are reported as reused,
+// as there is no redirection to remove for them.
+func ipReused(ips []string, inUse sets.String) bool {
+	if len(ips) == 0 {
+		return true
+	}
+	for _, ip := range ips {
+		if inUse.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/util/sets"
+)
+
+func TestCompletedPods(t *testing.T) {
+	flushed := make(chan []string, 10)
+	c := newCompletedPods(10*time.Millisecond, func(pods []*corev1.Pod) {
+		names := []string{}
+		for _, p := range pods {
+			names = append(names, p.Name)
+		}
+		flushed <- names
+	})
+	pod := func(name string, enrolled bool) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", UID: types.UID("uid-" + name)},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		}
+		if enrolled {
+			p.Annotations = map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled}
+		}
+		return p
+	}
+
+	// Pods completing together are cleaned up in a single batch, once each
+	c.add(pod("job-b", true))
+	c.add(pod("job-a", true))
+	c.add(pod("job-a", true))
+	// Pods which were never enrolled have nothing to clean up
+	c.add(pod("job-c", false))
+	assert.Equal(t, <-flushed, []string{"job-a", "job-b"})
+
+	c.add(pod("job-d", true))
+	assert.Equal(t, <-flushed, []string{"job-d"})
+	assert.Equal(t, len(flushed), 0)
+}
+
+func TestPodCompleted(t *testing.T) {
+	for phase, want := range map[corev1.PodPhase]bool{
+		corev1.PodPending:   false,
+		corev1.PodRunning:   false,
+		corev1.PodSucceeded: true,
+		corev1.PodFailed:    true,
+	} {
+		assert.Equal(t, podCompleted(&corev1.Pod{Status: corev1.PodStatus{Phase: phase}}), want)
+	}
+}
+
+func TestIPReused(t *testing.T) {
+	inUse := sets.New("10.0.0.1", "fd00::1")
+	assert.Equal(t, ipReused([]string{"10.0.0.2"}, inUse), false)
+	assert.Equal(t, ipReused([]string{"10.0.0.1"}, inUse), true)
+	assert.Equal(t, ipReused([]string{"10.0.0.2", "fd00::1"}, inUse), true)
+	assert.Equal(t, ipReused(nil, inUse), true)
+}
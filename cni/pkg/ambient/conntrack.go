@@ -0,0 +1,81 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// flushPodConntrack deletes the node's conntrack entries for connections from or to the pod, if enabled.
+// Established connections otherwise keep the path they had before the pod was added to or removed from
+// the mesh, so policy changes would only apply to new connections.
+func (s *Server) flushPodConntrack(pod *corev1.Pod) {
+	if !s.conntrackFlush {
+		return
+	}
+	if pod.Spec.HostNetwork {
+		// The pod IP is the node IP; flushing it would reset every connection of the node
+		log.Debugf("pod(%s/%s) is using host network, skip conntrack flush", pod.Namespace, pod.Name)
+		return
+	}
+	for _, ip := range podIPs(pod) {
+		n, err := flushConntrack(ip)
+		if err != nil {
+			log.Errorf("failed to flush conntrack entries of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			continue
+		}
+		log.Debugf("flushed %d conntrack entries of pod %s/%s (%s)", n, pod.Namespace, pod.Name, ip)
+	}
+}
+
+// flushConntrack deletes the conntrack entries of connections originated by, or destined to, the IP.
+func flushConntrack(ip string) (uint, error) {
+	filters, err := conntrackFilters(ip)
+	if err != nil {
+		return 0, err
+	}
+	family := netlink.InetFamily(familyOf(ip).family)
+	var total uint
+	for _, filter := range filters {
+		n, err := netlink.ConntrackDeleteFilter(netlink.ConntrackTable, family, filter)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// conntrackFilters returns the filters matching connections of the IP. Conditions within a filter must all
+// match, so each direction needs its own filter.
+func conntrackFilters(ip string) ([]*netlink.ConntrackFilter, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("invalid ip %q", ip)
+	}
+	var filters []*netlink.ConntrackFilter
+	for _, tp := range []netlink.ConntrackFilterType{netlink.ConntrackOrigSrcIP, netlink.ConntrackOrigDstIP} {
+		filter := &netlink.ConntrackFilter{}
+		if err := filter.AddIP(tp, parsed); err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
@@ -0,0 +1,57 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"net"
+	"testing"
+
+	"github.com/vishvananda/netlink"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestConntrackFilters(t *testing.T) {
+	flow := func(src, dst string) *netlink.ConntrackFlow {
+		f := &netlink.ConntrackFlow{}
+		f.Forward.SrcIP = net.ParseIP(src)
+		f.Forward.DstIP = net.ParseIP(dst)
+		f.Reverse.SrcIP = net.ParseIP(dst)
+		f.Reverse.DstIP = net.ParseIP(src)
+		return f
+	}
+	matches := func(filters []*netlink.ConntrackFilter, f *netlink.ConntrackFlow) bool {
+		for _, filter := range filters {
+			if filter.MatchConntrackFlow(f) {
+				return true
+			}
+		}
+		return false
+	}
+
+	filters, err := conntrackFilters("10.0.0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, matches(filters, flow("10.0.0.5", "10.0.0.9")), true)
+	assert.Equal(t, matches(filters, flow("10.0.0.9", "10.0.0.5")), true)
+	assert.Equal(t, matches(filters, flow("10.0.0.8", "10.0.0.9")), false)
+
+	filters, err = conntrackFilters("fd00::5")
+	assert.NoError(t, err)
+	assert.Equal(t, matches(filters, flow("fd00::9", "fd00::5")), true)
+	assert.Equal(t, matches(filters, flow("fd00::8", "fd00::9")), false)
+
+	_, err = conntrackFilters("not-an-ip")
+	assert.Error(t, err)
+}
@@ -15,17 +15,14 @@
 package constants
 
 const (
-	// In the below, we add the fwmask to ensure only that mark can match
-	OutboundMask       = "0x100"
-	OutboundMark       = OutboundMask + "/" + OutboundMask
-	SkipMask           = "0x200"
-	SkipMark           = SkipMask + "/" + SkipMask
-	ConnSkipMask       = "0x220"
-	ConnSkipMark       = ConnSkipMask + "/" + ConnSkipMask
-	ProxyMask          = "0x210"
-	ProxyMark          = ProxyMask + "/" + ProxyMask
-	ProxyRetMask       = "0x040"
-	ProxyRetMark       = ProxyRetMask + "/" + ProxyRetMask
+	// The fwmarks used on the node, before they are shifted by the configured fwmark shift. The fwmask of each
+	// mark is the mark itself, to ensure only that mark can match.
+	OutboundMask = 0x100
+	SkipMask     = 0x200
+	ConnSkipMask = 0x220
+	ProxyMask    = 0x210
+	ProxyRetMask = 0x040
+
 	TProxyMark         = 0x400
 	TProxyMask         = 0xfff
 	TProxyMarkPriority = 20000
@@ -44,6 +41,13 @@ const (
 	ZTunnelOutboundTunIP = "192.168.127.2"
 	TunPrefix            = 30
 
+	// The IPv6 tunnel addresses are from a unique local (ULA) range, so they never collide with pod or node addresses.
+	InboundTunIPv6         = "fd69:7374:696f:7e::1"
+	ZTunnelInboundTunIPv6  = "fd69:7374:696f:7e::2"
+	OutboundTunIPv6        = "fd69:7374:696f:7f::1"
+	ZTunnelOutboundTunIPv6 = "fd69:7374:696f:7f::2"
+	TunPrefixV6            = 126
+
 	ChainZTunnelPrerouting  = "ztunnel-PREROUTING"
 	ChainZTunnelPostrouting = "ztunnel-POSTROUTING"
 	ChainZTunnelInput       = "ztunnel-INPUT"
@@ -60,6 +64,7 @@ const (
 	TableNat    = "nat"
 
 	DNSCapturePort              = 15053
+	DNSShimPort                 = 15054
 	ZtunnelInboundPort          = 15008
 	ZtunnelOutboundPort         = 15001
 	ZtunnelInboundPlaintextPort = 15006
@@ -69,8 +74,24 @@ const (
 	RouteTableInbound  = 100
 	RouteTableOutbound = 101
 	RouteTableProxy    = 102
+
+	// RulePriorityBase is the default priority of the first of the ip rules redirecting traffic on the node.
+	RulePriorityBase = 100
 )
 
 const (
 	AmbientConfigFilepath = "/etc/ambient-config/config.json"
+
+	// NodeRoutingAnnotation records the route tables, rule priorities and fwmarks used on a node.
+	NodeRoutingAnnotation = "ambient.istio.io/routing"
+	// NodePlatformAnnotation records what a node supports of the redirection, such as its kernel version and iptables
+	// variant.
+	NodePlatformAnnotation = "ambient.istio.io/platform"
+
+	// BypassAllowedAnnotation allows the redirection of the pods of a namespace to be bypassed for debugging, if set to
+	// true on the namespace.
+	BypassAllowedAnnotation = "ambient.istio.io/bypass-allowed"
+
+	// FlushPath is the path the node agent serves the flush of the redirection of its node on, on its admin port.
+	FlushPath = "/debug/flush"
 )
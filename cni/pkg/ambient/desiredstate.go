@@ -0,0 +1,182 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/cni/pkg/ambient/redirectdump"
+)
+
+// desiredState is the redirection the node agent programmed on the node: the active ztunnel and the artifacts of the
+// node, and the pods redirected to ztunnel, by UID, along with their artifacts. It is what the node agent knows to be
+// redirected, rather than what the pod annotations or the node dataplane report, which lag behind or can be changed by
+// other tooling.
+//
+// Each change increments the generation of the state, which is exported along with its snapshots.
+type desiredState struct {
+	mu         sync.Mutex
+	generation uint64
+	ztunnel    *corev1.Pod
+	node       []string
+	pods       map[types.UID]*programmedPod
+}
+
+// programmedPod is a pod redirected to ztunnel, along with its artifacts and the generation it was programmed at.
+type programmedPod struct {
+	pod        *corev1.Pod
+	artifacts  []string
+	generation uint64
+}
+
+func newDesiredState() *desiredState {
+	return &desiredState{pods: map[types.UID]*programmedPod{}}
+}
+
+// setPod records that the pod was redirected with the artifacts.
+func (d *desiredState) setPod(pod *corev1.Pod, artifacts []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.generation++
+	d.pods[pod.UID] = &programmedPod{pod: pod, artifacts: artifacts, generation: d.generation}
+}
+
+// deletePod records that the redirection of the pod was removed.
+func (d *desiredState) deletePod(uid types.UID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, f := d.pods[uid]; f {
+		d.generation++
+		delete(d.pods, uid)
+	}
+}
+
+// hasPod returns whether the pod is redirected.
+func (d *desiredState) hasPod(uid types.UID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, f := d.pods[uid]
+	return f
+}
+
+// podArtifacts returns the artifacts the pod was redirected with, and whether it is redirected.
+func (d *desiredState) podArtifacts(uid types.UID) ([]string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	p, f := d.pods[uid]
+	if !f {
+		return nil, false
+	}
+	return p.artifacts, true
+}
+
+// namespacePods returns the redirected pods of the namespace.
+func (d *desiredState) namespacePods(namespace string) []*corev1.Pod {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var res []*corev1.Pod
+	for _, p := range d.pods {
+		if p.pod.Namespace == namespace {
+			res = append(res, p.pod)
+		}
+	}
+	return res
+}
+
+// setNode records that the node was redirected to the ztunnel with the artifacts, or that its redirection was removed
+// if ztunnel is nil.
+func (d *desiredState) setNode(ztunnel *corev1.Pod, artifacts []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.generation++
+	d.ztunnel = ztunnel
+	d.node = artifacts
+}
+
+// nodeArtifacts returns the artifacts the node was redirected to the active ztunnel with.
+func (d *desiredState) nodeArtifacts() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.node
+}
+
+// reset records that all the redirection of the node was removed.
+func (d *desiredState) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.generation++
+	d.ztunnel = nil
+	d.node = nil
+	d.pods = map[types.UID]*programmedPod{}
+}
+
+// snapshot returns a copy of the state, sorted.
+func (d *desiredState) snapshot() *redirectdump.State {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	res := &redirectdump.State{
+		Version:       redirectdump.StateVersion,
+		Node:          NodeName,
+		Generation:    d.generation,
+		NodeArtifacts: append([]string{}, d.node...),
+		Pods:          make([]redirectdump.PodState, 0, len(d.pods)),
+	}
+	if d.ztunnel != nil {
+		res.Ztunnel = d.ztunnel.Namespace + "/" + d.ztunnel.Name
+	}
+	for uid, p := range d.pods {
+		res.Pods = append(res.Pods, redirectdump.PodState{
+			UID:        string(uid),
+			Namespace:  p.pod.Namespace,
+			Name:       p.pod.Name,
+			IPs:        podIPs(p.pod),
+			Artifacts:  append([]string{}, p.artifacts...),
+			Generation: p.generation,
+		})
+	}
+	res.Sort()
+	return res
+}
+
+// DesiredState returns a snapshot of the redirection programmed on the node.
+func (s *Server) DesiredState() *redirectdump.State {
+	return s.desiredState.snapshot()
+}
+
+// programmedPodArtifacts returns the artifacts the Redirector programs for the pod, if it can list them.
+func (s *Server) programmedPodArtifacts(pod *corev1.Pod) []string {
+	lister, ok := s.redirector.(artifactLister)
+	if !ok {
+		return nil
+	}
+	var res []string
+	for _, a := range lister.desiredPodArtifacts(pod) {
+		res = append(res, a.value)
+	}
+	return res
+}
+
+// recordNodeProgrammed records that the node was redirected to the ztunnel, or that its redirection was removed if
+// ztunnel is nil.
+func (s *Server) recordNodeProgrammed(ztunnel *corev1.Pod) {
+	var artifacts []string
+	if lister, ok := s.redirector.(artifactLister); ok && ztunnel != nil {
+		artifacts = lister.desiredNodeArtifacts(ztunnel)
+	}
+	s.desiredState.setNode(ztunnel, artifacts)
+}
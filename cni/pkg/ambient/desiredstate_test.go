@@ -0,0 +1,95 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/cni/pkg/ambient/redirectdump"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+// desiredStateOf returns a desired state where the pods are redirected.
+func desiredStateOf(pods ...*corev1.Pod) *desiredState {
+	d := newDesiredState()
+	for _, pod := range pods {
+		d.setPod(pod, nil)
+	}
+	return d
+}
+
+func TestDesiredState(t *testing.T) {
+	pod := func(namespace, name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(namespace + "/" + name)},
+			Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+		}
+	}
+	d := newDesiredState()
+	d.setNode(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ztunnel", Namespace: "istio-system"}}, []string{"link istioin"})
+	d.setPod(pod("b", "pod"), []string{"ipset ztunnel-pods-ips 10.0.0.1"})
+	d.setPod(pod("a", "pod"), nil)
+	assert.Equal(t, d.hasPod("a/pod"), true)
+	assert.Equal(t, len(d.namespacePods("a")), 1)
+
+	assert.Equal(t, d.snapshot(), &redirectdump.State{
+		Version:       redirectdump.StateVersion,
+		Node:          NodeName,
+		Generation:    3,
+		Ztunnel:       "istio-system/ztunnel",
+		NodeArtifacts: []string{"link istioin"},
+		Pods: []redirectdump.PodState{
+			{UID: "a/pod", Namespace: "a", Name: "pod", IPs: []string{"10.0.0.1"}, Artifacts: []string{}, Generation: 3},
+			{UID: "b/pod", Namespace: "b", Name: "pod", IPs: []string{"10.0.0.1"}, Artifacts: []string{"ipset ztunnel-pods-ips 10.0.0.1"}, Generation: 2},
+		},
+	})
+
+	// Removing pods which are not redirected does not change the state
+	d.deletePod("c/pod")
+	assert.Equal(t, d.snapshot().Generation, uint64(3))
+	d.deletePod("a/pod")
+	assert.Equal(t, d.hasPod("a/pod"), false)
+	assert.Equal(t, d.snapshot().Generation, uint64(4))
+
+	d.reset()
+	snapshot := d.snapshot()
+	assert.Equal(t, snapshot.Generation, uint64(5))
+	assert.Equal(t, snapshot.Ztunnel, "")
+	assert.Equal(t, len(snapshot.NodeArtifacts)+len(snapshot.Pods), 0)
+}
+
+func TestDesiredStateHandler(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ambient", UID: "pod"}}
+	s := &Server{desiredState: desiredStateOf(pod)}
+	mux := http.NewServeMux()
+	s.RegisterDebugHandlers(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, redirectdump.StatePath, nil))
+	assert.Equal(t, rec.Code, http.StatusOK)
+
+	got := &redirectdump.State{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), got))
+	assert.Equal(t, got.Version, redirectdump.StateVersion)
+	assert.Equal(t, got.Generation, uint64(1))
+	assert.Equal(t, len(got.Pods), 1)
+	assert.Equal(t, got.Pods[0].Name, "pod")
+}
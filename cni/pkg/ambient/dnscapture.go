@@ -0,0 +1,115 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+	"istio.io/istio/cni/pkg/ambient/dnsshim"
+	"istio.io/istio/cni/pkg/features"
+)
+
+// DNSCaptureBackend selects where the DNS requests of the pods in the mesh are redirected to.
+type DNSCaptureBackend string
+
+const (
+	// DNSCaptureZtunnel redirects the DNS requests to the DNS proxy of ztunnel, if ztunnel proxies DNS.
+	DNSCaptureZtunnel DNSCaptureBackend = "ztunnel"
+	// DNSCaptureNodeLocal redirects the DNS requests to a caching DNS shim run by the node agent on the node, which
+	// forwards them to the resolvers of the node agent. It is used where the DNS proxy of ztunnel is undesirable.
+	DNSCaptureNodeLocal DNSCaptureBackend = "node-local"
+)
+
+// resolvConf is the resolv.conf of the node agent, whose resolvers the DNS shim forwards the requests to.
+const resolvConf = "/etc/resolv.conf"
+
+// DNSCaptureConfig configures where the DNS requests of the pods in the mesh are redirected to, in the iptables
+// redirect mode, when DNS capture is enabled.
+type DNSCaptureConfig struct {
+	// Backend selects where the DNS requests are redirected to. If unset, they are redirected to ztunnel.
+	Backend DNSCaptureBackend `json:"backend,omitempty"`
+	// ShimPort is the UDP port the DNS shim listens on on the node, with the node-local backend. If 0, the default
+	// port is used.
+	ShimPort int `json:"shimPort,omitempty"`
+}
+
+// Validate returns an error if the config is invalid.
+func (c DNSCaptureConfig) Validate() error {
+	switch c.Backend {
+	case "", DNSCaptureZtunnel, DNSCaptureNodeLocal:
+	default:
+		return fmt.Errorf("unknown DNS capture backend %q", c.Backend)
+	}
+	if c.ShimPort < 0 || c.ShimPort > 65535 {
+		return fmt.Errorf("invalid DNS shim port %d", c.ShimPort)
+	}
+	return nil
+}
+
+func (c DNSCaptureConfig) nodeLocal() bool {
+	return c.Backend == DNSCaptureNodeLocal
+}
+
+func (c DNSCaptureConfig) shimPort() int {
+	if c.ShimPort == 0 {
+		return constants.DNSShimPort
+	}
+	return c.ShimPort
+}
+
+// target returns the address the DNS requests are redirected to.
+func (c DNSCaptureConfig) target(ztunnelIP string) string {
+	if c.nodeLocal() {
+		return net.JoinHostPort(HostIP, strconv.Itoa(c.shimPort()))
+	}
+	return fmt.Sprintf("%s:%d", ztunnelIP, constants.DNSCapturePort)
+}
+
+// warnDNSCaptureIgnored warns that the DNS capture backend is ignored by the eBPF redirection, which redirects the
+// DNS requests to ztunnel.
+func warnDNSCaptureIgnored(c DNSCaptureConfig) {
+	if c.nodeLocal() {
+		log.Warnf("the %q DNS capture backend is only supported by the iptables redirect mode, DNS requests are "+
+			"redirected to ztunnel", c.Backend)
+	}
+}
+
+// captureDNS returns whether the DNS requests of pods are redirected with ztunnel active. With the node-local backend,
+// they are redirected whether ztunnel proxies DNS or not.
+func (s *Server) captureDNS(ztunnel *corev1.Pod) bool {
+	if !features.DNSCapture.Enabled() {
+		return false
+	}
+	return s.dnsCapture.nodeLocal() || getEnvFromPod(ztunnel, "ISTIO_META_DNS_CAPTURE") == "true"
+}
+
+// startDNSShim starts the DNS shim of the node-local backend, on the host IP the DNS requests are redirected to only,
+// so it is not exposed as an open resolver on the other addresses of the node.
+func (s *Server) startDNSShim() error {
+	if HostIP == "" {
+		return fmt.Errorf("the DNS shim requires the host IP")
+	}
+	upstreams, err := dnsshim.UpstreamsFromResolvConf(resolvConf)
+	if err != nil {
+		return fmt.Errorf("failed to read the resolvers of the DNS shim: %v", err)
+	}
+	s.dnsShim = dnsshim.New(upstreams)
+	return s.dnsShim.Start(net.JoinHostPort(HostIP, strconv.Itoa(s.dnsCapture.shimPort())))
+}
@@ -0,0 +1,60 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestDNSCaptureConfig(t *testing.T) {
+	assert.NoError(t, DNSCaptureConfig{}.Validate())
+	assert.NoError(t, DNSCaptureConfig{Backend: DNSCaptureNodeLocal, ShimPort: 5353}.Validate())
+	assert.Error(t, DNSCaptureConfig{Backend: "dnsmasq"}.Validate())
+	assert.Error(t, DNSCaptureConfig{ShimPort: 70000}.Validate())
+
+	oldHostIP := HostIP
+	t.Cleanup(func() { HostIP = oldHostIP })
+	HostIP = "10.0.0.1"
+	assert.Equal(t, DNSCaptureConfig{}.target("10.244.0.2"), "10.244.0.2:15053")
+	assert.Equal(t, DNSCaptureConfig{Backend: DNSCaptureNodeLocal}.target("10.244.0.2"), "10.0.0.1:15054")
+	assert.Equal(t, DNSCaptureConfig{Backend: DNSCaptureNodeLocal, ShimPort: 5353}.target("10.244.0.2"), "10.0.0.1:5353")
+}
+
+func TestCaptureDNS(t *testing.T) {
+	ztunnel := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "istio-proxy"}}}}
+	proxyingZtunnel := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name: "istio-proxy",
+		Env:  []corev1.EnvVar{{Name: "ISTIO_META_DNS_CAPTURE", Value: "true"}},
+	}}}}
+	s := &Server{}
+	assert.Equal(t, s.captureDNS(ztunnel), false)
+	assert.Equal(t, s.captureDNS(proxyingZtunnel), true)
+	// The node-local shim does not depend on the DNS proxy of ztunnel
+	s.dnsCapture = DNSCaptureConfig{Backend: DNSCaptureNodeLocal}
+	assert.Equal(t, s.captureDNS(ztunnel), true)
+}
+
+func TestStartDNSShimRequiresHostIP(t *testing.T) {
+	old := HostIP
+	t.Cleanup(func() { HostIP = old })
+	HostIP = ""
+	s := &Server{dnsCapture: DNSCaptureConfig{Backend: DNSCaptureNodeLocal}}
+	// The shim is never bound to all the addresses of the node
+	assert.Error(t, s.startDNSShim())
+}
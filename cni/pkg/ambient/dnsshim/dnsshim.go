@@ -0,0 +1,240 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dnsshim implements the node-local caching DNS shim of the ambient node agent. With the node-local DNS
+// capture backend, the DNS requests of pods are redirected to the shim instead of the DNS proxy of ztunnel, and the
+// shim forwards them to the upstream resolvers of the node agent, caching the answers.
+package dnsshim
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+
+	istiolog "istio.io/pkg/log"
+)
+
+var log = istiolog.RegisterScope("dnsshim", "ambient node-local DNS shim")
+
+const (
+	// maxEntries bounds the answers cached. Answers are not cached once it is reached, until some expire.
+	maxEntries = 10000
+	// maxTTL bounds how long answers are cached, whatever their TTL.
+	maxTTL = 5 * time.Minute
+	// upstreamTimeout bounds the time waited for each upstream resolver.
+	upstreamTimeout = 2 * time.Second
+)
+
+// Shim is a caching DNS forwarder.
+type Shim struct {
+	upstreams []string
+	client    *dns.Client
+	now       func() time.Time
+
+	mu    sync.Mutex
+	cache map[cacheKey]cacheEntry
+
+	server *dns.Server
+}
+
+type cacheKey struct {
+	name   string
+	qtype  uint16
+	qclass uint16
+}
+
+type cacheEntry struct {
+	msg     *dns.Msg
+	stored  time.Time
+	expires time.Time
+}
+
+// New creates a Shim forwarding the requests to the upstream resolvers, as host:port, which are tried in order.
+func New(upstreams []string) *Shim {
+	return &Shim{
+		upstreams: upstreams,
+		client:    &dns.Client{Net: "udp", Timeout: upstreamTimeout},
+		now:       time.Now,
+		cache:     map[cacheKey]cacheEntry{},
+	}
+}
+
+// UpstreamsFromResolvConf returns the resolvers of a resolv.conf file, as host:port.
+func UpstreamsFromResolvConf(path string) ([]string, error) {
+	conf, err := dns.ClientConfigFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(conf.Servers) == 0 {
+		return nil, fmt.Errorf("no nameserver in %s", path)
+	}
+	res := make([]string, 0, len(conf.Servers))
+	for _, s := range conf.Servers {
+		res = append(res, net.JoinHostPort(s, conf.Port))
+	}
+	return res, nil
+}
+
+// Start serves DNS over UDP on the address until Stop is called.
+func (s *Shim) Start(addr string) error {
+	pc, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", addr, err)
+	}
+	s.server = &dns.Server{PacketConn: pc, Handler: s}
+	go func() {
+		if err := s.server.ActivateAndServe(); err != nil {
+			log.Errorf("DNS shim stopped: %v", err)
+		}
+	}()
+	log.Infof("DNS shim serving on %s, forwarding to %v", pc.LocalAddr(), s.upstreams)
+	return nil
+}
+
+// Addr returns the address the Shim serves on, once started.
+func (s *Shim) Addr() net.Addr {
+	return s.server.PacketConn.LocalAddr()
+}
+
+// Stop stops serving DNS.
+func (s *Shim) Stop() {
+	if s.server != nil {
+		_ = s.server.Shutdown()
+	}
+}
+
+// ServeDNS answers a request from the cache, or forwards it to the upstream resolvers.
+func (s *Shim) ServeDNS(w dns.ResponseWriter, req *dns.Msg) {
+	var key cacheKey
+	cacheable := len(req.Question) == 1
+	if cacheable {
+		q := req.Question[0]
+		key = cacheKey{name: strings.ToLower(q.Name), qtype: q.Qtype, qclass: q.Qclass}
+		if resp := s.cached(key); resp != nil {
+			resp.Id = req.Id
+			_ = w.WriteMsg(resp)
+			return
+		}
+	}
+	resp, err := s.forward(req)
+	if err != nil {
+		log.Debugf("failed to forward DNS request: %v", err)
+		resp = new(dns.Msg)
+		resp.SetRcode(req, dns.RcodeServerFailure)
+	} else if cacheable {
+		s.store(key, resp)
+	}
+	_ = w.WriteMsg(resp)
+}
+
+func (s *Shim) forward(req *dns.Msg) (*dns.Msg, error) {
+	var lastErr error
+	for _, upstream := range s.upstreams {
+		resp, _, err := s.client.Exchange(req, upstream)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no upstream resolver")
+	}
+	return nil, lastErr
+}
+
+// cached returns a copy of the cached answer of a question, with the TTLs of its records decreased by the time it was
+// cached for.
+func (s *Shim) cached(key cacheKey) *dns.Msg {
+	now := s.now()
+	s.mu.Lock()
+	e, f := s.cache[key]
+	if f && !now.Before(e.expires) {
+		delete(s.cache, key)
+		f = false
+	}
+	s.mu.Unlock()
+	if !f {
+		return nil
+	}
+	resp := e.msg.Copy()
+	elapsed := uint32(now.Sub(e.stored) / time.Second)
+	for _, rrs := range [][]dns.RR{resp.Answer, resp.Ns, resp.Extra} {
+		for _, rr := range rrs {
+			if h := rr.Header(); h.Rrtype != dns.TypeOPT {
+				h.Ttl -= minOf(h.Ttl, elapsed)
+			}
+		}
+	}
+	return resp
+}
+
+// store caches the successful and negative answers, for their TTL.
+func (s *Shim) store(key cacheKey, resp *dns.Msg) {
+	if resp.Truncated || (resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError) {
+		return
+	}
+	ttl, ok := cacheTTL(resp)
+	if !ok {
+		return
+	}
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache) >= maxEntries {
+		for k, e := range s.cache {
+			if !now.Before(e.expires) {
+				delete(s.cache, k)
+			}
+		}
+		if len(s.cache) >= maxEntries {
+			return
+		}
+	}
+	s.cache[key] = cacheEntry{msg: resp.Copy(), stored: now, expires: now.Add(ttl)}
+}
+
+// cacheTTL returns how long an answer may be cached: the lowest TTL of its answers, or, for negative answers, of
+// the SOA record of the authority section.
+func cacheTTL(resp *dns.Msg) (time.Duration, bool) {
+	rrs := resp.Answer
+	if len(rrs) == 0 {
+		rrs = resp.Ns
+	}
+	var ttl uint32
+	found := false
+	for _, rr := range rrs {
+		t := rr.Header().Ttl
+		if soa, ok := rr.(*dns.SOA); ok && len(resp.Answer) == 0 {
+			t = minOf(t, soa.Minttl)
+		}
+		if !found || t < ttl {
+			ttl, found = t, true
+		}
+	}
+	if !found || ttl == 0 {
+		return 0, false
+	}
+	return minOf(time.Duration(ttl)*time.Second, maxTTL), true
+}
+
+func minOf[T uint32 | time.Duration](a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
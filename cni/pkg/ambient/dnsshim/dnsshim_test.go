@@ -0,0 +1,111 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnsshim
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+// startUpstream starts a resolver answering A requests with 10.0.0.1, and other requests with NXDOMAIN.
+func startUpstream(t *testing.T, queries *atomic.Int32) string {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &dns.Server{PacketConn: pc, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+		queries.Add(1)
+		resp := new(dns.Msg)
+		resp.SetReply(req)
+		q := req.Question[0]
+		if q.Qtype == dns.TypeA {
+			resp.Answer = []dns.RR{&dns.A{
+				Hdr: dns.RR_Header{Name: q.Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 30},
+				A:   net.ParseIP("10.0.0.1"),
+			}}
+		} else {
+			resp.Rcode = dns.RcodeNameError
+		}
+		_ = w.WriteMsg(resp)
+	})}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return pc.LocalAddr().String()
+}
+
+func TestShim(t *testing.T) {
+	queries := &atomic.Int32{}
+	s := New([]string{"127.0.0.1:1", startUpstream(t, queries)})
+	now := &atomic.Int64{}
+	now.Store(time.Now().UnixNano())
+	s.now = func() time.Time { return time.Unix(0, now.Load()) }
+	assert.NoError(t, s.Start("127.0.0.1:0"))
+	t.Cleanup(s.Stop)
+
+	query := func(name string, qtype uint16) *dns.Msg {
+		req := new(dns.Msg)
+		req.SetQuestion(name, qtype)
+		resp, _, err := (&dns.Client{Timeout: 5 * time.Second}).Exchange(req, s.Addr().String())
+		assert.NoError(t, err)
+		return resp
+	}
+
+	resp := query("foo.default.svc.cluster.local.", dns.TypeA)
+	assert.Equal(t, len(resp.Answer), 1)
+	assert.Equal(t, queries.Load(), int32(1))
+
+	// Answered from the cache, with the TTL decreased, whatever the case of the name
+	now.Add(int64(10 * time.Second))
+	resp = query("FOO.default.svc.cluster.local.", dns.TypeA)
+	assert.Equal(t, resp.Answer[0].Header().Ttl, uint32(20))
+	assert.Equal(t, queries.Load(), int32(1))
+
+	// Answers without a TTL to cache them for are not cached
+	resp = query("foo.default.svc.cluster.local.", dns.TypeAAAA)
+	assert.Equal(t, resp.Rcode, dns.RcodeNameError)
+	query("foo.default.svc.cluster.local.", dns.TypeAAAA)
+	assert.Equal(t, queries.Load(), int32(3))
+
+	// Expired answers are forwarded again
+	now.Add(int64(30 * time.Second))
+	query("foo.default.svc.cluster.local.", dns.TypeA)
+	assert.Equal(t, queries.Load(), int32(4))
+}
+
+func TestShimUpstreamFailure(t *testing.T) {
+	s := New([]string{"127.0.0.1:1"})
+	s.client.Timeout = 100 * time.Millisecond
+	assert.NoError(t, s.Start("127.0.0.1:0"))
+	t.Cleanup(s.Stop)
+	req := new(dns.Msg)
+	req.SetQuestion("foo.", dns.TypeA)
+	resp, _, err := (&dns.Client{Timeout: 5 * time.Second}).Exchange(req, s.Addr().String())
+	assert.NoError(t, err)
+	assert.Equal(t, resp.Rcode, dns.RcodeServerFailure)
+}
+
+func TestUpstreamsFromResolvConf(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resolv.conf")
+	assert.NoError(t, os.WriteFile(path, []byte("search default.svc.cluster.local\nnameserver 10.96.0.10\nnameserver fd00::10\n"), 0o644))
+	upstreams, err := UpstreamsFromResolvConf(path)
+	assert.NoError(t, err)
+	assert.Equal(t, upstreams, []string{"10.96.0.10:53", "[fd00::10]:53"})
+}
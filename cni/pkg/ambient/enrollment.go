@@ -0,0 +1,131 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+)
+
+// enrollmentReportInterval is how often the enrollment of the workloads of the node is recorded in metrics.
+const enrollmentReportInterval = 15 * time.Second
+
+// WorkloadEnrollment is the enrollment of the pods of a workload on the node. Workloads are identified by the owner
+// of their pods, such as a Deployment or a StatefulSet, or by the pod itself if it has no owner.
+type WorkloadEnrollment struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	// Pods is the number of running pods of the workload on the node.
+	Pods int `json:"pods"`
+	// Captured is the number of these pods whose traffic is redirected to ztunnel.
+	Captured int `json:"captured"`
+}
+
+// WorkloadEnrollments returns the enrollment of the workloads of the node, sorted by namespace, kind and name.
+func (s *Server) WorkloadEnrollments() []WorkloadEnrollment {
+	return workloadEnrollments(s.pods.List(metav1.NamespaceAll, klabels.Everything()))
+}
+
+func workloadEnrollments(pods []*corev1.Pod) []WorkloadEnrollment {
+	byOwner := map[WorkloadEnrollment]*WorkloadEnrollment{}
+	for _, pod := range pods {
+		if pod.Spec.HostNetwork || ztunnelPod(pod) || podCompleted(pod) {
+			continue
+		}
+		meta, typ := kube.GetDeployMetaFromPod(pod)
+		key := WorkloadEnrollment{Namespace: pod.Namespace, Kind: typ.Kind, Name: meta.Name}
+		e := byOwner[key]
+		if e == nil {
+			e = &key
+			byOwner[key] = e
+		}
+		e.Pods++
+		if pod.Annotations[constants.AmbientRedirection] == constants.AmbientRedirectionEnabled {
+			e.Captured++
+		}
+	}
+	res := make([]WorkloadEnrollment, 0, len(byOwner))
+	for _, e := range byOwner {
+		res = append(res, *e)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Namespace != res[j].Namespace {
+			return res[i].Namespace < res[j].Namespace
+		}
+		if res[i].Kind != res[j].Kind {
+			return res[i].Kind < res[j].Kind
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+// namespaceEnrollments aggregates the enrollment of the workloads by namespace, returning the number of pods and of
+// captured pods of each namespace.
+func namespaceEnrollments(workloads []WorkloadEnrollment) map[string]WorkloadEnrollment {
+	res := map[string]WorkloadEnrollment{}
+	for _, e := range workloads {
+		ns := res[e.Namespace]
+		ns.Namespace = e.Namespace
+		ns.Pods += e.Pods
+		ns.Captured += e.Captured
+		res[e.Namespace] = ns
+	}
+	return res
+}
+
+// reportEnrollment periodically records the enrollment of the namespaces, and the health of the node, in metrics,
+// until stopped. The metrics are aggregated by namespace rather than by workload, as they cannot be removed: the
+// workloads of a node change with every rollout, while its namespaces are bounded.
+func (s *Server) reportEnrollment(stop <-chan struct{}) {
+	ticker := time.NewTicker(enrollmentReportInterval)
+	defer ticker.Stop()
+	// Namespaces which no longer have pods on the node are reported with no pods
+	reported := map[string]struct{}{}
+	for {
+		current := namespaceEnrollments(s.WorkloadEnrollments())
+		for ns, e := range current {
+			recordNamespaceEnrollment(ns, e.Pods, e.Captured)
+		}
+		for ns := range reported {
+			if _, f := current[ns]; !f {
+				recordNamespaceEnrollment(ns, 0, 0)
+			}
+		}
+		reported = map[string]struct{}{}
+		for ns := range current {
+			reported[ns] = struct{}{}
+		}
+		s.recordNodeHealth()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func recordNamespaceEnrollment(namespace string, pods, captured int) {
+	namespacePods.With(namespaceLabel.Value(namespace)).RecordInt(int64(pods))
+	namespacePodsCaptured.With(namespaceLabel.Value(namespace)).RecordInt(int64(captured))
+}
@@ -0,0 +1,77 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestWorkloadEnrollments(t *testing.T) {
+	controller := true
+	pod := func(name, ownerKind, ownerName string, captured bool) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", Labels: map[string]string{}, Annotations: map[string]string{}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ownerKind != "" {
+			p.GenerateName = ownerName + "-"
+			p.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName, Controller: &controller}}
+		}
+		if ownerKind == "ReplicaSet" {
+			p.Labels["pod-template-hash"] = "abc"
+		}
+		if captured {
+			p.Annotations[constants.AmbientRedirection] = constants.AmbientRedirectionEnabled
+		}
+		return p
+	}
+	completed := pod("job-1", "Job", "job", false)
+	completed.Status.Phase = corev1.PodSucceeded
+	ztunnel := pod("ztunnel-1", "DaemonSet", "ztunnel", false)
+	ztunnel.Labels["app"] = "ztunnel"
+
+	got := workloadEnrollments([]*corev1.Pod{
+		pod("web-abc-1", "ReplicaSet", "web-abc", true),
+		pod("web-abc-2", "ReplicaSet", "web-abc", false),
+		pod("web-abc-3", "ReplicaSet", "web-abc", true),
+		pod("db-0", "StatefulSet", "db", true),
+		pod("standalone", "", "", false),
+		completed,
+		ztunnel,
+	})
+	assert.Equal(t, got, []WorkloadEnrollment{
+		{Namespace: "ns", Kind: "Deployment", Name: "web", Pods: 3, Captured: 2},
+		{Namespace: "ns", Kind: "Pod", Name: "standalone", Pods: 1},
+		{Namespace: "ns", Kind: "StatefulSet", Name: "db", Pods: 1, Captured: 1},
+	})
+}
+
+func TestNamespaceEnrollments(t *testing.T) {
+	got := namespaceEnrollments([]WorkloadEnrollment{
+		{Namespace: "a", Kind: "Deployment", Name: "web", Pods: 3, Captured: 2},
+		{Namespace: "a", Kind: "StatefulSet", Name: "db", Pods: 1, Captured: 1},
+		{Namespace: "b", Kind: "Pod", Name: "standalone", Pods: 1},
+	})
+	assert.Equal(t, got, map[string]WorkloadEnrollment{
+		"a": {Namespace: "a", Pods: 4, Captured: 3},
+		"b": {Namespace: "b", Pods: 1},
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The causes of the failures to add pods to the mesh or remove them from it. The errors returned by the redirection
+// wrap them, so the failures are broken down by cause in the istio_cni_ambient_enrollment_failures_total metric, and
+// in the reason of the events recorded on the pods which could not be added to the mesh.
+var (
+	ErrNoPodIP          = errors.New("pod has no IP")
+	ErrZtunnelNotReady  = errors.New("ztunnel is not ready")
+	ErrVethNotFound     = errors.New("veth not found")
+	ErrNetnsNotFound    = errors.New("network namespace not found")
+	ErrSecurityPolicy   = errors.New("denied by security policy")
+	ErrIptablesExec     = errors.New("iptables failed")
+	ErrEbpfProgram      = errors.New("eBPF redirection failed")
+	ErrEnrollmentHook   = errors.New("enrollment hook failed")
+	ErrPrivilegedHelper = errors.New("privileged helper unavailable")
+)
+
+// enrollmentFailureReasons are the reasons of the causes of enrollment failures. Errors may wrap several causes, such
+// as an eBPF redirection failure caused by a missing veth, so the most specific causes are listed first.
+var enrollmentFailureReasons = []struct {
+	err    error
+	reason string
+}{
+	{ErrNoPodIP, "NoPodIP"},
+	{ErrZtunnelNotReady, "ZtunnelNotReady"},
+	{ErrVethNotFound, "VethNotFound"},
+	{ErrNetnsNotFound, "NetnsNotFound"},
+	{ErrSecurityPolicy, "SecurityPolicy"},
+	{ErrIptablesExec, "IptablesExec"},
+	{ErrEbpfProgram, "EbpfProgram"},
+	{ErrEnrollmentHook, "EnrollmentHook"},
+	{ErrPrivilegedHelper, "PrivilegedHelper"},
+}
+
+// unknownEnrollmentFailure is the reason of enrollment failures without a known cause.
+const unknownEnrollmentFailure = "Unknown"
+
+// EnrollmentFailureReason returns the reason of the cause of an enrollment failure, such as VethNotFound.
+func EnrollmentFailureReason(err error) string {
+	for _, r := range enrollmentFailureReasons {
+		if errors.Is(err, r.err) {
+			return r.reason
+		}
+	}
+	return unknownEnrollmentFailure
+}
+
+// recordEnrollmentFailure counts a failure to change the redirection, by cause.
+func recordEnrollmentFailure(ruleType string, err error) {
+	enrollmentFailures.With(typeLabel.Value(ruleType), reasonLabel.Value(EnrollmentFailureReason(err))).Increment()
+}
+
+// reportEnrollmentFailure records the failure to add a pod to the mesh, and an event on the pod with its cause. The
+// event is recorded for every attempt, so the failures of pods which are retried are visible on the pod.
+func (s *Server) reportEnrollmentFailure(ctx context.Context, pod *corev1.Pod, err error) {
+	recordEnrollmentFailure(ruleTypeAddPod, err)
+	s.recordPodWarning(ctx, pod, "Ambient"+EnrollmentFailureReason(err), "Pod could not be added to the ambient mesh: "+err.Error())
+}
+
+// withCause wraps an error with the cause of the failure, unless the error already has a more specific cause. Errors
+// caused by a denied permission are wrapped with ErrSecurityPolicy instead.
+func withCause(err error, cause error) error {
+	if err == nil || EnrollmentFailureReason(err) != unknownEnrollmentFailure {
+		return err
+	}
+	if permissionDenied(err) {
+		cause = ErrSecurityPolicy
+	}
+	return fmt.Errorf("%w: %w", cause, err)
+}
+
+// permissionDenied returns whether an error is caused by a permission denied to the node agent, such as when it
+// mutates a network namespace it is not allowed to by a seccomp profile or an SELinux policy. Errors of commands
+// only have their output, which is matched.
+func permissionDenied(err error) bool {
+	if errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EACCES) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "operation not permitted") || strings.Contains(msg, "permission denied")
+}
+
+// restoredError is an error returned by the privileged helper, which only returns the message of its errors, with
+// the causes found in the message.
+type restoredError struct {
+	msg    string
+	causes []error
+}
+
+func (e *restoredError) Error() string {
+	return e.msg
+}
+
+func (e *restoredError) Unwrap() []error {
+	return e.causes
+}
+
+// restoreEnrollmentError restores the causes of an error returned by the privileged helper from its message.
+func restoreEnrollmentError(msg string) error {
+	err := &restoredError{msg: msg}
+	for _, r := range enrollmentFailureReasons {
+		if strings.Contains(msg, r.err.Error()) {
+			err.causes = append(err.causes, r.err)
+		}
+	}
+	return err
+}
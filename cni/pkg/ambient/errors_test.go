@@ -0,0 +1,58 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestEnrollmentFailureReason(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"no cause", errors.New("failed"), "Unknown"},
+		{"cause", ErrIptablesExec, "IptablesExec"},
+		{"wrapped", fmt.Errorf("failed to add pod: %w", ErrNoPodIP), "NoPodIP"},
+		{"most specific cause", withCause(fmt.Errorf("%w: for 10.0.0.1", ErrVethNotFound), ErrEbpfProgram), "VethNotFound"},
+		{"added cause", withCause(errors.New("map update failed"), ErrEbpfProgram), "EbpfProgram"},
+		{"permission denied", withCause(fmt.Errorf("link add: %w", syscall.EPERM), ErrIptablesExec), "SecurityPolicy"},
+		{"command permission denied", withCause(errors.New("iptables: Permission denied (you must be root)"), ErrIptablesExec), "SecurityPolicy"},
+		{"restored", restoreEnrollmentError("failed to add pod: " + ErrNetnsNotFound.Error() + ": no namespace with id 3"), "NetnsNotFound"},
+		{"restored without cause", restoreEnrollmentError("failed"), "Unknown"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, EnrollmentFailureReason(tt.err), tt.want)
+		})
+	}
+}
+
+func TestWithCause(t *testing.T) {
+	assert.NoError(t, withCause(nil, ErrIptablesExec))
+
+	err := withCause(errors.New("exit status 1"), ErrIptablesExec)
+	assert.Equal(t, err.Error(), "iptables failed: exit status 1")
+	assert.Equal(t, errors.Is(err, ErrIptablesExec), true)
+
+	// An error which already has a cause is returned as is
+	assert.Equal(t, withCause(ErrZtunnelNotReady, ErrIptablesExec), ErrZtunnelNotReady)
+}
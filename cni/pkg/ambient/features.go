@@ -0,0 +1,63 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/cni/pkg/features"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+)
+
+// loadFeatures reads the ConfigMap overriding the feature flags, if any.
+func (s *Server) loadFeatures() {
+	cm, err := s.kubeClient.Kube().CoreV1().ConfigMaps(s.systemNamespace).Get(s.ctx, features.ConfigMapName, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		features.Update(nil)
+	case err != nil:
+		log.Warnf("failed to read the feature flags ConfigMap %s/%s, using the environment: %v",
+			s.systemNamespace, features.ConfigMapName, err)
+		features.Update(nil)
+	default:
+		features.Update(cm.Data)
+	}
+}
+
+// watchFeatures reloads the feature flags when their ConfigMap changes, and reconfigures the redirection to ztunnel
+// when they changed.
+func (s *Server) watchFeatures() {
+	s.configMaps = kclient.NewFiltered[*corev1.ConfigMap](s.kubeClient, kclient.Filter{
+		FieldSelector: "metadata.name=" + features.ConfigMapName + ",metadata.namespace=" + s.systemNamespace,
+	})
+	s.configMaps.AddEventHandler(controllers.FromEventHandler(func(e controllers.Event) {
+		if e.Event == controllers.EventDelete {
+			features.Update(nil)
+			return
+		}
+		features.Update(e.Latest().(*corev1.ConfigMap).Data)
+	}))
+	features.OnChange(func() {
+		s.mu.Lock()
+		ztunnel := s.ztunnelPod
+		s.mu.Unlock()
+		if ztunnel != nil {
+			s.queue.Add(controllers.Event{New: ztunnel, Old: ztunnel, Event: controllers.EventUpdate})
+		}
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+	"net/http"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+)
+
+// Flush removes all the redirection configured on the node, including the eBPF state, and stops redirecting pods
+// until the node agent restarts. It takes Istio out of the datapath of the node in emergencies.
+//
+// The CNI plugin is told ztunnel is not ready, so new pods are not redirected either. The redirection annotations
+// of the pods are left as is, and are fixed up once the node agent restarts.
+func (s *Server) Flush() {
+	// Wait for the reconciliations in progress, so they do not configure redirection after the flush
+	s.reconcileMu.Lock()
+	defer s.reconcileMu.Unlock()
+	s.mu.Lock()
+	s.flushed = true
+	s.ztunnelPod = nil
+	s.ztunnelSettings = ztunnelSettings{}
+	s.mu.Unlock()
+	s.desiredState.reset()
+
+	log.Warnf("flushing the redirection of node %s, pods are no longer redirected to ztunnel until the node agent restarts", NodeName)
+	s.UpdateConfig()
+	s.redirector.Cleanup()
+	rulesProgrammed.With(typeLabel.Value(ruleTypeCleanup), resultLabel.Value(resultSuccess)).Increment()
+}
+
+// isFlushed returns whether the redirection of the node was flushed. It must be called with the reconcile lock held.
+func (s *Server) isFlushed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.flushed
+}
+
+func (s *Server) registerFlushHandler(mux *http.ServeMux) {
+	mux.HandleFunc(constants.FlushPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		s.Flush()
+		if _, err := fmt.Fprintf(w, "flushed the redirection of node %s\n", NodeName); err != nil {
+			log.Debugf("failed to write flush response: %v", err)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+	pconstants "istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestFlush(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "ambient",
+		Labels: map[string]string{pconstants.DataplaneMode: pconstants.DataplaneModeAmbient},
+	}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ambient", UID: "pod"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+	client := kube.NewFakeClient(ns, pod)
+	redirector := &fakeRedirector{}
+	s := &Server{
+		ctx:          context.Background(),
+		kubeClient:   client,
+		pods:         kclient.New[*corev1.Pod](client),
+		namespaces:   kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+		redirectMode: ExternalMode,
+		redirector:   redirector,
+		desiredState: desiredStateOf(pod),
+		ztunnelPod:   &corev1.Pod{},
+		configFile:   filepath.Join(t.TempDir(), "config.json"),
+	}
+	client.RunAndWait(test.NewStop(t))
+	debug := http.NewServeMux()
+	s.RegisterDebugHandlers(debug)
+	mux := http.NewServeMux()
+	s.RegisterAdminHandlers(mux)
+	request := func(method string) int {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, constants.FlushPath, nil))
+		return rec.Code
+	}
+
+	// The flush is not served with the debug handlers, on the monitoring port
+	rec := httptest.NewRecorder()
+	debug.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, constants.FlushPath, nil))
+	assert.Equal(t, rec.Code, http.StatusNotFound)
+
+	assert.Equal(t, request(http.MethodGet), http.StatusMethodNotAllowed)
+	assert.Equal(t, redirector.cleanups, 0)
+	assert.Equal(t, request(http.MethodPost), http.StatusOK)
+	assert.Equal(t, redirector.cleanups, 1)
+	assert.Equal(t, s.isZTunnelRunning(), false)
+	assert.Equal(t, s.podEnrolled(pod), false)
+	b, err := os.ReadFile(s.configFile)
+	assert.NoError(t, err)
+	cfg := &AmbientConfigFile{}
+	assert.NoError(t, json.Unmarshal(b, cfg))
+	assert.Equal(t, cfg.ZTunnelReady, false)
+
+	// Pods are no longer redirected
+	assert.NoError(t, s.reconcilePod(s.ctx, controllers.Event{New: pod, Event: controllers.EventAdd}))
+	assert.Equal(t, len(redirector.added), 0)
+}
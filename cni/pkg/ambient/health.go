@@ -0,0 +1,124 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/cni/pkg/ambient/ambientpod"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// nodeHealth is the capture of the pods of the node, recorded in the node health metrics.
+type nodeHealth struct {
+	// Captured is the number of running pods whose traffic is redirected to ztunnel.
+	Captured int
+	// Pending is the number of running pods which are in the mesh, but whose traffic is not redirected yet, such as
+	// while ztunnel is not ready on the node.
+	Pending int
+	// Failed is the number of pods whose last event failed to be reconciled.
+	Failed int
+}
+
+func podsHealth(pods []*corev1.Pod, captureExpected func(*corev1.Pod) bool, failed sets.Set[types.UID]) nodeHealth {
+	var h nodeHealth
+	for _, pod := range pods {
+		if failed.Contains(pod.UID) {
+			h.Failed++
+			continue
+		}
+		if pod.Spec.HostNetwork || ztunnelPod(pod) || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if pod.Annotations[constants.AmbientRedirection] == constants.AmbientRedirectionEnabled {
+			h.Captured++
+		} else if captureExpected(pod) {
+			h.Pending++
+		}
+	}
+	return h
+}
+
+// recordNodeHealth records the capture of the pods of the node in metrics. Failed pods which are no longer on the node
+// are forgotten.
+func (s *Server) recordNodeHealth() {
+	pods := s.pods.List(metav1.NamespaceAll, klabels.Everything())
+	current := sets.New[types.UID]()
+	for _, pod := range pods {
+		current.Insert(pod.UID)
+	}
+	s.mu.Lock()
+	for uid := range s.failedPods {
+		if !current.Contains(uid) {
+			s.failedPods.Delete(uid)
+		}
+	}
+	failed := s.failedPods.Copy()
+	s.mu.Unlock()
+
+	h := podsHealth(pods, s.podCaptureExpected, failed)
+	podsCaptured.RecordInt(int64(h.Captured))
+	podsPending.RecordInt(int64(h.Pending))
+	podsFailed.RecordInt(int64(h.Failed))
+}
+
+// podCaptureExpected returns whether the traffic of the pod should be redirected to ztunnel.
+func (s *Server) podCaptureExpected(pod *corev1.Pod) bool {
+	ns := s.namespaces.Get(pod.Namespace, "")
+	return ns != nil && ambientpod.PodZtunnelEnabled(ns, pod) && s.hostPortsCaptured(pod) &&
+		s.initContainersCaptured(pod)
+}
+
+// recordReconcileResult tracks the pods whose last event failed to be reconciled, for the node health metrics.
+func (s *Server) recordReconcileResult(input any, err error) {
+	event, ok := input.(controllers.Event)
+	if !ok {
+		return
+	}
+	pod := event.Latest()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil && event.Event != controllers.EventDelete && pod.GetLabels()["app"] != "ztunnel" {
+		if s.failedPods == nil {
+			s.failedPods = sets.New[types.UID]()
+		}
+		s.failedPods.Insert(pod.GetUID())
+	} else {
+		s.failedPods.Delete(pod.GetUID())
+	}
+}
+
+// recordRulesProgrammed counts the changes to the redirection of the node, returning the error of the change.
+func recordRulesProgrammed(ruleType string, err error) error {
+	result := resultSuccess
+	if err != nil {
+		result = resultFail
+	}
+	rulesProgrammed.With(typeLabel.Value(ruleType), resultLabel.Value(result)).Increment()
+	return err
+}
+
+func recordZtunnelReady(ready bool) {
+	if ready {
+		ztunnelReady.Record(1)
+	} else {
+		ztunnelReady.Record(0)
+	}
+}
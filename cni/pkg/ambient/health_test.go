@@ -0,0 +1,76 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/util/sets"
+)
+
+func TestPodsHealth(t *testing.T) {
+	pod := func(name string, phase corev1.PodPhase, captured bool) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID(name), Namespace: "ns", Annotations: map[string]string{}},
+			Status:     corev1.PodStatus{Phase: phase},
+		}
+		if captured {
+			p.Annotations[constants.AmbientRedirection] = constants.AmbientRedirectionEnabled
+		}
+		return p
+	}
+	ztunnel := pod("ztunnel", corev1.PodRunning, false)
+	ztunnel.Labels = map[string]string{"app": "ztunnel"}
+	hostNetwork := pod("host", corev1.PodRunning, false)
+	hostNetwork.Spec.HostNetwork = true
+	pods := []*corev1.Pod{
+		pod("captured", corev1.PodRunning, true),
+		pod("pending", corev1.PodRunning, false),
+		pod("uncaptured", corev1.PodRunning, false),
+		pod("failed", corev1.PodRunning, false),
+		pod("starting", corev1.PodPending, false),
+		pod("completed", corev1.PodSucceeded, true),
+		ztunnel,
+		hostNetwork,
+	}
+	captureExpected := func(pod *corev1.Pod) bool {
+		return pod.Name != "uncaptured"
+	}
+	got := podsHealth(pods, captureExpected, sets.New[types.UID]("failed"))
+	assert.Equal(t, got, nodeHealth{Captured: 1, Pending: 1, Failed: 1})
+}
+
+func TestRecordReconcileResult(t *testing.T) {
+	s := &Server{}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", UID: "uid"}}
+	add := controllers.Event{New: pod, Event: controllers.EventAdd}
+
+	s.recordReconcileResult(add, errors.New("fail"))
+	assert.Equal(t, s.failedPods, sets.New[types.UID]("uid"))
+	s.recordReconcileResult(add, nil)
+	assert.Equal(t, s.failedPods, sets.New[types.UID]())
+	// Deleted pods are no longer failing
+	s.recordReconcileResult(add, errors.New("fail"))
+	s.recordReconcileResult(controllers.Event{Old: pod, Event: controllers.EventDelete}, errors.New("fail"))
+	assert.Equal(t, s.failedPods, sets.New[types.UID]())
+}
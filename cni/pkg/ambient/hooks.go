@@ -0,0 +1,157 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// HookFailurePolicyIgnore logs hook failures, and enrolls or removes the pod regardless.
+	HookFailurePolicyIgnore = "Ignore"
+	// HookFailurePolicyFail retries the enrollment change when the hook fails. For pods being added,
+	// the hook runs first, so the pod is not enrolled until the hook succeeds.
+	HookFailurePolicyFail = "Fail"
+
+	EnrollmentEventAdd    = "add"
+	EnrollmentEventDelete = "delete"
+)
+
+// EnrollmentHookArgs configures an external system to be notified when pods are added to or removed from
+// the mesh, such as a firewall or audit system that must track mesh membership.
+type EnrollmentHookArgs struct {
+	// URL, if set, receives a POST request with the JSON encoded EnrollmentEvent.
+	URL string
+	// Exec, if set, is the path of an executable run with the JSON encoded EnrollmentEvent on stdin.
+	Exec string
+	// Timeout bounds each hook invocation.
+	Timeout time.Duration
+	// FailurePolicy is either HookFailurePolicyIgnore or HookFailurePolicyFail.
+	FailurePolicy string
+}
+
+// EnrollmentEvent is sent to enrollment hooks.
+type EnrollmentEvent struct {
+	// Type is either EnrollmentEventAdd or EnrollmentEventDelete.
+	Type string        `json:"type"`
+	Node string        `json:"node"`
+	Pod  EnrollmentPod `json:"pod"`
+}
+
+// EnrollmentPod is the pod metadata sent to enrollment hooks.
+type EnrollmentPod struct {
+	Name           string            `json:"name"`
+	Namespace      string            `json:"namespace"`
+	UID            string            `json:"uid"`
+	IP             string            `json:"ip,omitempty"`
+	ServiceAccount string            `json:"serviceAccount,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+type enrollmentHook struct {
+	args   EnrollmentHookArgs
+	client *http.Client
+}
+
+// newEnrollmentHook returns the configured hook, or nil if no hook is configured.
+func newEnrollmentHook(args EnrollmentHookArgs) (*enrollmentHook, error) {
+	if args.URL == "" && args.Exec == "" {
+		return nil, nil
+	}
+	switch args.FailurePolicy {
+	case "":
+		args.FailurePolicy = HookFailurePolicyIgnore
+	case HookFailurePolicyIgnore, HookFailurePolicyFail:
+	default:
+		return nil, fmt.Errorf("invalid enrollment hook failure policy %q, expected %s or %s",
+			args.FailurePolicy, HookFailurePolicyIgnore, HookFailurePolicyFail)
+	}
+	if args.Timeout <= 0 {
+		args.Timeout = 5 * time.Second
+	}
+	return &enrollmentHook{args: args, client: &http.Client{}}, nil
+}
+
+// notify invokes the hook for the pod. An error is only returned if the hook fails with HookFailurePolicyFail.
+func (h *enrollmentHook) notify(ctx context.Context, eventType string, pod *corev1.Pod) error {
+	if h == nil {
+		return nil
+	}
+	event := EnrollmentEvent{
+		Type: eventType,
+		Node: NodeName,
+		Pod: EnrollmentPod{
+			Name:           pod.Name,
+			Namespace:      pod.Namespace,
+			UID:            string(pod.UID),
+			IP:             pod.Status.PodIP,
+			ServiceAccount: pod.Spec.ServiceAccountName,
+			Labels:         pod.Labels,
+		},
+	}
+	ctx, cancel := context.WithTimeout(ctx, h.args.Timeout)
+	defer cancel()
+	err := h.run(ctx, event)
+	if err == nil {
+		enrollmentHooks.With(typeLabel.Value(eventType), resultLabel.Value(resultSuccess)).Increment()
+		return nil
+	}
+	enrollmentHooks.With(typeLabel.Value(eventType), resultLabel.Value(resultFail)).Increment()
+	if h.args.FailurePolicy == HookFailurePolicyFail {
+		return fmt.Errorf("%w for %s of pod %s/%s: %v", ErrEnrollmentHook, eventType, pod.Namespace, pod.Name, err)
+	}
+	log.Warnf("enrollment hook failed for %s of pod %s/%s, ignoring: %v", eventType, pod.Namespace, pod.Name, err)
+	return nil
+}
+
+func (h *enrollmentHook) run(ctx context.Context, event EnrollmentEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if h.args.URL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.args.URL, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := h.client.Do(req)
+		if err != nil {
+			return err
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook %s returned status %d", h.args.URL, resp.StatusCode)
+		}
+	}
+	if h.args.Exec != "" {
+		cmd := exec.CommandContext(ctx, h.args.Exec)
+		cmd.Stdin = bytes.NewReader(body)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %v: %s", h.args.Exec, err, out)
+		}
+	}
+	return nil
+}
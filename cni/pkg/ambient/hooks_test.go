@@ -0,0 +1,109 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+var hookPod = &corev1.Pod{
+	ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns", UID: "1234", Labels: map[string]string{"app": "a"}},
+	Spec:       corev1.PodSpec{ServiceAccountName: "sa"},
+	Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+}
+
+func TestEnrollmentHookWebhook(t *testing.T) {
+	var events []EnrollmentEvent
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev EnrollmentEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&ev))
+		events = append(events, ev)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	hook, err := newEnrollmentHook(EnrollmentHookArgs{URL: srv.URL, FailurePolicy: HookFailurePolicyFail})
+	assert.NoError(t, err)
+	assert.NoError(t, hook.notify(context.Background(), EnrollmentEventAdd, hookPod))
+	assert.Equal(t, events, []EnrollmentEvent{{
+		Type: EnrollmentEventAdd,
+		Node: NodeName,
+		Pod: EnrollmentPod{
+			Name:           "pod",
+			Namespace:      "ns",
+			UID:            "1234",
+			IP:             "10.0.0.1",
+			ServiceAccount: "sa",
+			Labels:         map[string]string{"app": "a"},
+		},
+	}})
+
+	status = http.StatusInternalServerError
+	assert.Error(t, hook.notify(context.Background(), EnrollmentEventDelete, hookPod))
+
+	hook, err = newEnrollmentHook(EnrollmentHookArgs{URL: srv.URL, FailurePolicy: HookFailurePolicyIgnore})
+	assert.NoError(t, err)
+	assert.NoError(t, hook.notify(context.Background(), EnrollmentEventDelete, hookPod))
+}
+
+func TestEnrollmentHookExec(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "event.json")
+	script := filepath.Join(dir, "hook.sh")
+	assert.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\ncat > "+out+"\n"), 0o755))
+
+	hook, err := newEnrollmentHook(EnrollmentHookArgs{Exec: script, FailurePolicy: HookFailurePolicyFail})
+	assert.NoError(t, err)
+	assert.NoError(t, hook.notify(context.Background(), EnrollmentEventDelete, hookPod))
+	b, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	var ev EnrollmentEvent
+	assert.NoError(t, json.Unmarshal(b, &ev))
+	assert.Equal(t, ev.Type, EnrollmentEventDelete)
+	assert.Equal(t, ev.Pod.UID, "1234")
+
+	// A hook exceeding the timeout fails
+	slow := filepath.Join(dir, "slow.sh")
+	assert.NoError(t, os.WriteFile(slow, []byte("#!/bin/sh\nexec sleep 5\n"), 0o755))
+	hook, err = newEnrollmentHook(EnrollmentHookArgs{Exec: slow, Timeout: 100 * time.Millisecond, FailurePolicy: HookFailurePolicyFail})
+	assert.NoError(t, err)
+	assert.Error(t, hook.notify(context.Background(), EnrollmentEventAdd, hookPod))
+}
+
+func TestNewEnrollmentHook(t *testing.T) {
+	hook, err := newEnrollmentHook(EnrollmentHookArgs{})
+	assert.NoError(t, err)
+	if hook != nil {
+		t.Fatal("expected no hook without a URL or executable")
+	}
+	// A nil hook is a no-op
+	assert.NoError(t, hook.notify(context.Background(), EnrollmentEventAdd, hookPod))
+
+	_, err = newEnrollmentHook(EnrollmentHookArgs{URL: "http://localhost", FailurePolicy: "Retry"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,110 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+)
+
+// The hostPorts of pods are translated to the pod by the portmap CNI plugin, with DNAT rules on the node. The replies
+// of a captured pod would be sent to ztunnel rather than translated back, so the connection would break. In the
+// iptables redirect mode, the connections to the hostPorts of captured pods skip ztunnel. The eBPF redirection does
+// not see the translation, so pods with hostPorts are not captured in the eBPF redirect mode.
+
+const hostPortNotCapturedReason = "AmbientHostPortNotCaptured"
+
+// PodHostPorts returns the container ports of the pod which are exposed as hostPorts.
+func PodHostPorts(pod *corev1.Pod) []corev1.ContainerPort {
+	var res []corev1.ContainerPort
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.HostPort != 0 {
+				res = append(res, p)
+			}
+		}
+	}
+	return res
+}
+
+// hostPortsCaptured returns whether the pod can be captured with its hostPorts in the redirect mode.
+func (s *Server) hostPortsCaptured(pod *corev1.Pod) bool {
+	return s.redirectMode != EbpfMode || len(PodHostPorts(pod)) == 0
+}
+
+// hostPortRules returns the rules skipping ztunnel for the connections to the hostPorts of the pod. Both directions of
+// the connections are matched on their translation, once the first packet was translated to the pod.
+func hostPortRules(pod *corev1.Pod) []*iptablesRule {
+	if pod.Status.PodIP == "" {
+		return nil
+	}
+	var rules []*iptablesRule
+	for _, p := range PodHostPorts(pod) {
+		protocol := p.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		rules = append(rules, newIptableRule(
+			constants.TableMangle,
+			constants.ChainZTunnelPrerouting,
+			"-p", strings.ToLower(string(protocol)),
+			"-m", "conntrack",
+			"--ctstate", "DNAT",
+			"--ctorigdstport", strconv.Itoa(int(p.HostPort)),
+			"--ctreplsrc", pod.Status.PodIP,
+			"--ctreplsrcport", strconv.Itoa(int(p.ContainerPort)),
+			"-j", "RETURN",
+		))
+	}
+	return rules
+}
+
+// addHostPortRules inserts the rules skipping ztunnel for the hostPorts of the pod, before the rules capturing its
+// traffic. Rules which already exist are not inserted again.
+func (s *Server) addHostPortRules(pod *corev1.Pod) error {
+	for _, rule := range hostPortRules(pod) {
+		if execute(s.IptablesCmd(), append([]string{"-t", rule.Table, "-C", rule.Chain}, rule.RuleSpec...)...) == nil {
+			continue
+		}
+		log.Debugf("Inserting rule: %+v", rule)
+		if err := execute(s.IptablesCmd(), append([]string{"-t", rule.Table, "-I", rule.Chain}, rule.RuleSpec...)...); err != nil {
+			return fmt.Errorf("failed to skip the hostPorts of pod %s/%s: %w: %v", pod.Namespace, pod.Name, ErrIptablesExec, err)
+		}
+	}
+	return nil
+}
+
+// delHostPortRules removes the rules skipping ztunnel for the hostPorts of the pod, on a best effort basis.
+func (s *Server) delHostPortRules(pod *corev1.Pod) {
+	for _, rule := range hostPortRules(pod) {
+		if err := execute(s.IptablesCmd(), append([]string{"-t", rule.Table, "-D", rule.Chain}, rule.RuleSpec...)...); err != nil {
+			log.Debugf("failed to remove hostPort rule of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+}
+
+// reportHostPortsNotCaptured records an event on a pod of an ambient namespace which is not captured because of its
+// hostPorts.
+func (s *Server) reportHostPortsNotCaptured(ctx context.Context, pod *corev1.Pod) {
+	msg := fmt.Sprintf("Pod is not captured by ztunnel: hostPorts are not supported in the %v redirect mode", s.redirectMode)
+	log.Warnf("pod %s/%s: %s", pod.Namespace, pod.Name, msg)
+	s.recordPodWarning(ctx, pod, hostPortNotCapturedReason, msg)
+}
@@ -0,0 +1,100 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func hostPortPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ambient", UID: "uid"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "app",
+			Ports: []corev1.ContainerPort{
+				{ContainerPort: 8080, HostPort: 80},
+				{ContainerPort: 9090},
+				{ContainerPort: 5353, HostPort: 53, Protocol: corev1.ProtocolUDP},
+			},
+		}}},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+}
+
+func TestHostPortRules(t *testing.T) {
+	var got []string
+	for _, r := range hostPortRules(hostPortPod()) {
+		got = append(got, strings.Join(r.RuleSpec, " "))
+	}
+	assert.Equal(t, got, []string{
+		"-p tcp -m conntrack --ctstate DNAT --ctorigdstport 80 --ctreplsrc 10.0.0.1 --ctreplsrcport 8080 -j RETURN",
+		"-p udp -m conntrack --ctstate DNAT --ctorigdstport 53 --ctreplsrc 10.0.0.1 --ctreplsrcport 5353 -j RETURN",
+	})
+
+	// The hostPorts are kept in the informer cache
+	stripped, err := stripPodUnusedFields(hostPortPod())
+	assert.NoError(t, err)
+	assert.Equal(t, PodHostPorts(stripped.(*corev1.Pod)), PodHostPorts(hostPortPod()))
+}
+
+func TestReconcilePodHostPorts(t *testing.T) {
+	for _, mode := range []RedirectMode{EbpfMode, ExternalMode} {
+		t.Run(mode.String(), func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "ambient",
+				Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+			}}
+			pod := hostPortPod()
+			client := kube.NewFakeClient(ns, pod)
+			redirector := &fakeRedirector{}
+			s := &Server{
+				ctx:          context.Background(),
+				kubeClient:   client,
+				pods:         kclient.New[*corev1.Pod](client),
+				namespaces:   kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+				redirectMode: mode,
+				redirector:   redirector,
+				desiredState: newDesiredState(),
+				ztunnelPod:   &corev1.Pod{},
+			}
+			client.RunAndWait(test.NewStop(t))
+
+			assert.NoError(t, s.reconcilePod(s.ctx, controllers.Event{New: pod, Event: controllers.EventAdd}))
+			events, err := client.Kube().CoreV1().Events(pod.Namespace).List(s.ctx, metav1.ListOptions{})
+			assert.NoError(t, err)
+			if mode == EbpfMode {
+				// Pods with hostPorts are not captured with eBPF, which is reported on the pod
+				assert.Equal(t, len(redirector.added), 0)
+				assert.Equal(t, len(events.Items), 1)
+				assert.Equal(t, events.Items[0].Reason, hostPortNotCapturedReason)
+			} else {
+				assert.Equal(t, redirector.added, []string{"pod"})
+				assert.Equal(t, len(events.Items), 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+
+	pconstants "istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvr"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+)
+
+// Reasons of the ambient identity condition.
+const (
+	identityServiceAccountFound    = "ServiceAccountFound"
+	identityServiceAccountNotFound = "ServiceAccountNotFound"
+)
+
+// watchServiceAccounts watches the ServiceAccounts, so the pods in the mesh whose ServiceAccount is deleted, or
+// recreated, are reconciled. Only their existence is used, so only their metadata needs to be cached.
+func (s *Server) watchServiceAccounts() {
+	filter := kclient.Filter{}
+	if len(s.allowedNamespaces) > 0 {
+		filter.ObjectFilter = func(o any) bool {
+			sa := controllers.ExtractObject(o)
+			return sa != nil && s.namespaceAllowed(sa.GetNamespace())
+		}
+	}
+	informer := s.kubeClient.KubeInformer().Core().V1().ServiceAccounts().Informer()
+	if s.trimInformers {
+		informer = s.kubeClient.MetadataInformer().ForResource(gvr.ServiceAccount).Informer()
+	}
+	s.serviceAccounts = kclient.NewUntyped(s.kubeClient, informer, filter)
+	s.serviceAccounts.AddEventHandler(controllers.FromEventHandler(s.handleServiceAccountEvent))
+}
+
+// handleServiceAccountEvent enqueues the pods in the mesh of a ServiceAccount when it is added or deleted. The
+// ServiceAccounts listed when the node agent starts are skipped, as all the pods are reconciled then.
+func (s *Server) handleServiceAccountEvent(e controllers.Event) {
+	if e.Event == controllers.EventUpdate || (e.Event == controllers.EventAdd && !s.serviceAccounts.HasSynced()) {
+		return
+	}
+	sa := e.Latest()
+	for _, pod := range s.pods.List(sa.GetNamespace(), klabels.Everything()) {
+		if podServiceAccount(pod) != sa.GetName() || !s.podEnrolled(pod) {
+			continue
+		}
+		log.Debugf("ServiceAccount %s/%s %s, reconciling pod %s", sa.GetNamespace(), sa.GetName(), e.Event, pod.Name)
+		s.queue.Add(controllers.Event{
+			New:   pod,
+			Old:   pod,
+			Event: controllers.EventUpdate,
+		})
+	}
+}
+
+// reportIdentity sets the ambient identity condition of a pod in the mesh to false while its ServiceAccount does not
+// exist, as ztunnel then fails to issue the certificate of the pod, which only surfaces as connection failures. The
+// condition is set back to true once the ServiceAccount exists; it is not set on pods whose ServiceAccount always
+// existed.
+func (s *Server) reportIdentity(ctx context.Context, pod *corev1.Pod) error {
+	if s.serviceAccounts == nil || !s.podEnrolled(pod) {
+		return nil
+	}
+	sa := podServiceAccount(pod)
+	found := s.serviceAccounts.Get(sa, pod.Namespace) != nil
+	current := podCondition(pod, pconstants.AmbientIdentity)
+	if found && (current == nil || current.Status == corev1.ConditionTrue) {
+		return nil
+	}
+	if !found && current != nil && current.Status == corev1.ConditionFalse {
+		return nil
+	}
+	cond := corev1.PodCondition{
+		Type:    pconstants.AmbientIdentity,
+		Status:  corev1.ConditionTrue,
+		Reason:  identityServiceAccountFound,
+		Message: fmt.Sprintf("ServiceAccount %s exists", sa),
+	}
+	if !found {
+		log.Warnf("ServiceAccount %s of pod %s/%s does not exist, ztunnel cannot issue its identity", sa, pod.Namespace, pod.Name)
+		cond.Status = corev1.ConditionFalse
+		cond.Reason = identityServiceAccountNotFound
+		cond.Message = fmt.Sprintf("ServiceAccount %s does not exist, so ztunnel cannot issue the identity of the pod; "+
+			"restart the pod once it is recreated if the pod uses its token", sa)
+	}
+	return setPodCondition(ctx, s.kubeClient.Kube(), pod, cond)
+}
+
+// podServiceAccount returns the name of the ServiceAccount of a pod.
+func podServiceAccount(pod *corev1.Pod) string {
+	if pod.Spec.ServiceAccountName == "" {
+		return "default"
+	}
+	return pod.Spec.ServiceAccountName
+}
+
+// podCondition returns the condition of a pod of the type, if it is set.
+func podCondition(pod *corev1.Pod, t corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == t {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
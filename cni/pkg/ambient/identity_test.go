@@ -0,0 +1,82 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+)
+
+func TestReportIdentity(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod",
+			Namespace:   "ns",
+			UID:         "uid",
+			Annotations: map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled},
+		},
+		Spec: corev1.PodSpec{ServiceAccountName: "sa"},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+		},
+	}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "ns", UID: "other"}}
+	client := kube.NewFakeClient(pod, other)
+	s := &Server{
+		ctx:             context.Background(),
+		kubeClient:      client,
+		serviceAccounts: kclient.NewUntyped(client, client.KubeInformer().Core().V1().ServiceAccounts().Informer(), kclient.Filter{}),
+		desiredState:    newDesiredState(),
+	}
+	client.RunAndWait(test.NewStop(t))
+	identity := func() *corev1.PodCondition {
+		got, err := client.Kube().CoreV1().Pods(pod.Namespace).Get(s.ctx, pod.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		// Conditions set by others are kept
+		assert.Equal(t, podCondition(got, corev1.PodScheduled) != nil, true)
+		pod = got
+		return podCondition(got, constants.AmbientIdentity)
+	}
+
+	// Pods outside of the mesh are not reported
+	assert.NoError(t, s.reportIdentity(s.ctx, other))
+	assert.NoError(t, s.reportIdentity(s.ctx, pod))
+	cond := identity()
+	assert.Equal(t, cond.Status, corev1.ConditionFalse)
+	assert.Equal(t, cond.Reason, identityServiceAccountNotFound)
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "sa", Namespace: "ns"}}
+	_, err := client.Kube().CoreV1().ServiceAccounts("ns").Create(s.ctx, sa, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	retry.UntilOrFail(t, func() bool { return s.serviceAccounts.Get("sa", "ns") != nil })
+	assert.NoError(t, s.reportIdentity(s.ctx, pod))
+	cond = identity()
+	assert.Equal(t, cond.Status, corev1.ConditionTrue)
+	assert.Equal(t, cond.Reason, identityServiceAccountFound)
+
+	got, err := client.Kube().CoreV1().Pods("ns").Get(s.ctx, "other", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, len(got.Status.Conditions), 0)
+}
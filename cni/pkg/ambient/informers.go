@@ -15,51 +15,143 @@
 package ambient
 
 import (
+	"context"
 	"fmt"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 
+	"istio.io/api/annotation"
 	"istio.io/istio/cni/pkg/ambient/ambientpod"
 	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvr"
+	"istio.io/istio/pkg/kube"
 	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/util/sets"
 )
 
 func (s *Server) setupHandlers() {
-	s.queue = controllers.NewQueue("ambient",
-		controllers.WithGenericReconciler(s.Reconcile),
-		controllers.WithMaxAttempts(5),
-	)
+	s.queue = newWorkQueue(s.workers, s.Reconcile)
 
 	// We only need to handle pods on our node
-	s.pods = kclient.NewFiltered[*corev1.Pod](s.kubeClient, kclient.Filter{FieldSelector: "spec.nodeName=" + NodeName})
+	podFilter := kclient.Filter{FieldSelector: "spec.nodeName=" + NodeName}
+	if s.trimInformers {
+		podFilter.ObjectTransform = stripPodUnusedFields
+	}
+	if len(s.allowedNamespaces) > 0 {
+		// ztunnel runs outside of the namespaces of tenants, but is always needed to redirect their pods
+		podFilter.ObjectFilter = func(o any) bool {
+			pod := controllers.Extract[*corev1.Pod](o)
+			return pod != nil && (ztunnelPod(pod) || s.namespaceAllowed(pod.Namespace))
+		}
+	}
+	s.pods = kclient.NewFiltered[*corev1.Pod](s.kubeClient, podFilter)
 	s.pods.AddEventHandler(controllers.FromEventHandler(func(o controllers.Event) {
 		s.queue.Add(o)
 	}))
 
-	// Namespaces could be anything though, so we watch all of those
-	s.namespaces = kclient.New[*corev1.Namespace](s.kubeClient)
-	s.namespaces.AddEventHandler(controllers.ObjectHandler(s.EnqueueNamespace))
+	s.completedPods = newCompletedPods(completedPodCleanupDelay, s.cleanupCompletedPods)
+	s.quarantinedPods = newQuarantinedPods()
+
+	// Namespaces could be anything though, so we watch all of those. Only their labels are used, so only their
+	// metadata needs to be cached.
+	nsInformer := s.kubeClient.KubeInformer().Core().V1().Namespaces().Informer()
+	if s.trimInformers {
+		nsInformer = s.kubeClient.MetadataInformer().ForResource(gvr.Namespace).Informer()
+	}
+	nsFilter := kclient.Filter{}
+	if len(s.allowedNamespaces) > 0 {
+		nsFilter.ObjectFilter = func(o any) bool {
+			ns := controllers.ExtractObject(o)
+			return ns != nil && s.namespaceAllowed(ns.GetName())
+		}
+	}
+	s.namespaces = kclient.NewUntyped(s.kubeClient, nsInformer, nsFilter)
+	s.namespaces.AddEventHandler(controllers.FromEventHandler(s.handleNamespaceEvent))
+
+	s.watchServiceAccounts()
+	s.watchFeatures()
+	s.watchInstallConfig()
+
+	// Reconcile ztunnel again once its readiness endpoint reports it can accept connections
+	s.ztunnelReadiness = newZtunnelReadiness(s.ctx, func(pod *corev1.Pod) {
+		s.queue.Add(controllers.Event{
+			New:   pod,
+			Old:   pod,
+			Event: controllers.EventUpdate,
+		})
+	})
 }
 
 func (s *Server) Run(stop <-chan struct{}) {
-	go s.queue.Run(stop)
+	go s.runQueue(stop)
 	<-stop
 }
 
+// runQueue reconciles the pod events once the namespaces are synced, until stopped. Whether a pod is enrolled depends
+// on its namespace, so the events of the pods listed when the node agent starts could otherwise be reconciled before
+// their namespace is known, and fail until retried.
+func (s *Server) runQueue(stop <-chan struct{}) {
+	if !kube.WaitForCacheSync(stop, s.namespaces.HasSynced) {
+		return
+	}
+	log.Debug("namespaces synced, reconciling pod events")
+	s.queue.Run(stop)
+}
+
 func (s *Server) ReconcileNamespaces() {
 	for _, ns := range s.namespaces.List(metav1.NamespaceAll, klabels.Everything()) {
 		s.EnqueueNamespace(ns)
 	}
 }
 
-// EnqueueNamespace takes a Namespace and enqueues all Pod objects that make need an update
+// handleNamespaceEvent enqueues the pods of a namespace when it is added or removed, or when its ambient membership
+// changed. Other updates, such as annotation changes, do not affect its pods.
+func (s *Server) handleNamespaceEvent(e controllers.Event) {
+	if e.Event == controllers.EventDelete {
+		s.enqueueNamespaceDeletion(e.Latest().GetName())
+		return
+	}
+	if e.Event != controllers.EventUpdate {
+		s.EnqueueNamespace(e.Latest())
+		return
+	}
+	if !ambientMembershipChanged(e.Old, e.New) {
+		namespaceFanoutsSuppressed.Increment()
+		return
+	}
+	direction := directionDisabled
+	if e.New.GetLabels()[constants.DataplaneMode] == constants.DataplaneModeAmbient {
+		direction = directionEnabled
+	}
+	pods := s.EnqueueNamespace(e.New)
+	namespaceTransitions.With(directionLabel.Value(direction)).Increment()
+	namespaceTransitionPods.With(directionLabel.Value(direction)).Record(float64(pods))
+}
+
+func ambientMembershipChanged(old, cur controllers.Object) bool {
+	return (old.GetLabels()[constants.DataplaneMode] == constants.DataplaneModeAmbient) !=
+		(cur.GetLabels()[constants.DataplaneMode] == constants.DataplaneModeAmbient)
+}
+
+// EnqueueNamespace takes a Namespace and enqueues all Pod objects that make need an update, returning how many were
+// enqueued.
 // TODO it is sort of pointless/confusing/implicit to populate Old and New with the same reference here
-func (s *Server) EnqueueNamespace(o controllers.Object) {
+func (s *Server) EnqueueNamespace(o controllers.Object) int {
 	namespace := o.GetName()
+	if !s.namespaceAllowed(namespace) {
+		return 0
+	}
 	matchAmbient := o.GetLabels()[constants.DataplaneMode] == constants.DataplaneModeAmbient
+	if matchAmbient && s.namespaceExcluded(namespace) {
+		log.Infof("Namespace %s is excluded from ambient mesh by the installation", namespace)
+		matchAmbient = false
+	}
+	enqueued := 0
 	if matchAmbient {
 		log.Infof("Namespace %s is enabled in ambient mesh", namespace)
 		for _, pod := range s.pods.List(namespace, klabels.Everything()) {
@@ -68,6 +160,7 @@ func (s *Server) EnqueueNamespace(o controllers.Object) {
 				Old:   pod,
 				Event: controllers.EventUpdate,
 			})
+			enqueued++
 		}
 	} else {
 		log.Infof("Namespace %s is disabled from ambient mesh", namespace)
@@ -81,20 +174,228 @@ func (s *Server) EnqueueNamespace(o controllers.Object) {
 					Old:   pod,
 					Event: controllers.EventDelete,
 				})
+				enqueued++
 			}
 		}
 	}
+	return enqueued
+}
+
+// enqueueNamespaceDeletion enqueues the removal from the mesh of the enrolled pods of a deleted namespace, returning how
+// many were enqueued. Kubernetes deletes the pods of the namespace as well, but the redirection of pods whose delete
+// event was missed, or reconciled while they were being enrolled, would be left over. The pods this node agent enrolled
+// are removed even if they are no longer in the informer.
+func (s *Server) enqueueNamespaceDeletion(namespace string) int {
+	pods := map[types.UID]*corev1.Pod{}
+	for _, pod := range s.desiredState.namespacePods(namespace) {
+		pods[pod.UID] = pod
+	}
+	// Pods enrolled by a previous node agent are only known by their annotation
+	for _, pod := range s.pods.List(namespace, klabels.Everything()) {
+		if !ztunnelPod(pod) && s.podEnrolled(pod) {
+			pods[pod.UID] = pod
+		}
+	}
+	if len(pods) == 0 {
+		return 0
+	}
+	log.Infof("Namespace %s was deleted, removing its %d enrolled pods from the mesh", namespace, len(pods))
+	for _, pod := range pods {
+		s.queue.Add(controllers.Event{
+			New:   pod,
+			Old:   pod,
+			Event: controllers.EventDelete,
+		})
+	}
+	return len(pods)
 }
 
-func (s *Server) Reconcile(input any) error {
+// namespaceEvent returns whether the pod event was enqueued for the namespace of the pod, which populates Old and New
+// with the same pod. Informer events never do.
+func namespaceEvent(event controllers.Event) bool {
+	return event.Old != nil && event.Old == event.New
+}
+
+// recordNamespaceRedirection counts the redirection changes of pods enqueued for their namespace.
+func recordNamespaceRedirection(event controllers.Event, direction string, err error) error {
+	if err == nil && namespaceEvent(event) {
+		namespaceRedirectionChanges.With(directionLabel.Value(direction)).Increment()
+	}
+	return err
+}
+
+// Reconcile handles a pod event. Each event is given up to the reconcile timeout: the context of the redirection
+// changes is cancelled on timeout, aborting the commands they run, and the event is retried.
+//
+// Panics are recovered and returned as errors, and the pod is quarantined: its events are skipped until it changes.
+func (s *Server) Reconcile(input any) (err error) {
+	defer func() {
+		recordReconcile(input, err)
+		s.recordReconcileResult(input, err)
+	}()
+	defer s.recoverReconcile(input, &err)
 	event := input.(controllers.Event)
+	pod := event.Latest()
+	if event.Event == controllers.EventDelete {
+		s.quarantinedPods.remove(pod)
+	} else if s.quarantinedPods.contains(pod) {
+		log.Debugf("skipping %s of quarantined pod %s/%s", event.Event, pod.GetNamespace(), pod.GetName())
+		return nil
+	}
+	if s.reconcileTimeout <= 0 {
+		return s.reconcilePod(s.reconcileCtx, event)
+	}
+	p := pod.(*corev1.Pod)
+	if !s.startReconcile(p.UID) {
+		// The previous reconciliation of the pod timed out and still runs, so the event is retried once it returns
+		return fmt.Errorf("reconciling %s of pod %s/%s: the previous reconciliation of the pod is still running",
+			event.Event, pod.GetNamespace(), pod.GetName())
+	}
+	unlock := s.lockReconcile(p)
+	var release sync.Once
+	ctx, cancel := context.WithTimeout(s.reconcileCtx, s.reconcileTimeout)
+	defer cancel()
+	// Some operations, such as netlink calls, do not observe the context, so the reconciliation runs separately to
+	// not block the queue when they hang
+	res := make(chan error, 1)
+	s.reconciling.Add(1)
+	go func() {
+		defer s.reconciling.Done()
+		defer s.endReconcile(p.UID)
+		defer release.Do(unlock)
+		var err error
+		defer func() {
+			res <- err
+		}()
+		defer s.recoverReconcile(event, &err)
+		err = s.reconcileLocked(ctx, event)
+	}()
+	select {
+	case err := <-res:
+		return err
+	case <-ctx.Done():
+		// The commands of the reconciliation are aborted with its context, but the operations which do not observe it
+		// may still hang. The reconciliation lock is released, so they do not block the ztunnel changes waiting for it,
+		// which would in turn block the events of all pods.
+		release.Do(unlock)
+		reconcileTimeouts.With(typeLabel.Value(event.Event.String())).Increment()
+		return fmt.Errorf("reconciling %s of pod %s/%s: %v", event.Event, pod.GetNamespace(), pod.GetName(), ctx.Err())
+	}
+}
+
+func recordReconcile(input any, err error) {
+	eventType := "unknown"
+	if event, ok := input.(controllers.Event); ok {
+		eventType = event.Event.String()
+	}
+	result := resultSuccess
+	if err != nil {
+		result = resultFail
+	}
+	reconciles.With(typeLabel.Value(eventType), resultLabel.Value(result)).Increment()
+}
+
+func (s *Server) reconcilePod(ctx context.Context, event controllers.Event) error {
+	defer s.lockReconcile(event.Latest().(*corev1.Pod))()
+	return s.reconcileLocked(ctx, event)
+}
+
+// startReconcile marks the reconciliation of the pod as running, returning false if it already is.
+func (s *Server) startReconcile(uid types.UID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.reconcilingPods == nil {
+		s.reconcilingPods = sets.New[types.UID]()
+	}
+	if s.reconcilingPods.Contains(uid) {
+		return false
+	}
+	s.reconcilingPods.Insert(uid)
+	return true
+}
+
+// endReconcile marks the reconciliation of the pod as done.
+func (s *Server) endReconcile(uid types.UID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reconcilingPods.Delete(uid)
+}
+
+// lockReconcile locks the reconciliation of the pod, and returns the function unlocking it. ztunnel changes reconfigure
+// the redirection of the whole node, so they are not reconciled concurrently with the events of pods.
+func (s *Server) lockReconcile(pod *corev1.Pod) func() {
+	if ztunnelPod(pod) {
+		s.reconcileMu.Lock()
+		return s.reconcileMu.Unlock
+	}
+	s.reconcileMu.RLock()
+	return s.reconcileMu.RUnlock
+}
+
+// reconcileLocked reconciles a pod event, once the reconciliation is locked.
+func (s *Server) reconcileLocked(ctx context.Context, event controllers.Event) error {
 	log := log.WithLabels("type", event.Event)
 	pod := event.Latest().(*corev1.Pod)
 	if ztunnelPod(pod) {
+		if s.isFlushed() {
+			return nil
+		}
 		return s.ReconcileZtunnel()
 	}
+	if s.isFlushed() {
+		log.Debugf("skipping pod %s/%s, the redirection of the node was flushed", pod.Namespace, pod.Name)
+		return nil
+	}
+	if event.Event != controllers.EventDelete {
+		// Short-lived pods, such as the pods of Jobs, may complete before their event is processed, or while their
+		// enrollment is retried. Pending enrollments of completed pods are cancelled.
+		if current := s.pods.Get(pod.Name, pod.Namespace); current != nil && current.UID == pod.UID && podCompleted(current) {
+			pod = current
+		}
+	}
+	if podCompleted(pod) {
+		// Completed pods have no traffic to capture, so they are never enrolled, and the redirection of pods which
+		// completed while enrolled is removed in batches
+		log.Debugf("Pod %s/%s completed", pod.Namespace, pod.Name)
+		s.completedPods.add(pod)
+		return nil
+	}
+	if err := s.reconcilePodEvent(ctx, event, pod); err != nil || event.Event == controllers.EventDelete {
+		return err
+	}
+	if err := s.reportIdentity(ctx, pod); err != nil {
+		return err
+	}
+	return s.releaseReadinessGate(ctx, pod)
+}
+
+func (s *Server) reconcilePodEvent(ctx context.Context, event controllers.Event, pod *corev1.Pod) error {
+	log := log.WithLabels("type", event.Event)
 	switch event.Event {
 	case controllers.EventAdd:
+		// Pods are usually added before they are assigned an IP, and enrolled by the update assigning it. Pods which
+		// already have one, such as pods created running or listed when the node agent starts, are enrolled
+		// immediately. Redirection is idempotent, so pods which are already enrolled are enrolled again.
+		if pod.Status.PodIP == "" || !s.isZTunnelRunning() || s.bypassed(pod) {
+			return nil
+		}
+		ns := s.namespaces.Get(pod.Namespace, "")
+		if ns == nil {
+			return fmt.Errorf("failed to find namespace %v", pod.Namespace)
+		}
+		if ambientpod.PodZtunnelEnabled(ns, pod) && !s.namespaceExcluded(pod.Namespace) {
+			if !s.hostPortsCaptured(pod) {
+				s.reportHostPortsNotCaptured(ctx, pod)
+				return nil
+			}
+			s.reportInitContainerConflict(ctx, pod)
+			if !s.initContainersCaptured(pod) || s.initContainersPending(pod) {
+				// Pods waiting for their init containers are enrolled by the update marking them initialized
+				return nil
+			}
+			log.Debugf("Pod %s added, adding to mesh", pod.Name)
+			return s.AddPodToMesh(ctx, pod)
+		}
 	case controllers.EventUpdate:
 		// For update, we just need to handle opt outs
 		newPod := event.New.(*corev1.Pod)
@@ -104,29 +405,109 @@ func (s *Server) Reconcile(input any) error {
 			return fmt.Errorf("failed to find namespace %v", ns)
 		}
 		wasEnabled := oldPod.Annotations[constants.AmbientRedirection] == constants.AmbientRedirectionEnabled
-		nowEnabled := ambientpod.PodZtunnelEnabled(ns, newPod)
+		nowEnabled := ambientpod.PodZtunnelEnabled(ns, newPod) && !s.namespaceExcluded(newPod.Namespace)
+		if nowEnabled && !s.hostPortsCaptured(newPod) {
+			// Reported once, when the pod is assigned an IP
+			if !wasEnabled && oldPod.Status.PodIP == "" && newPod.Status.PodIP != "" {
+				s.reportHostPortsNotCaptured(ctx, newPod)
+			}
+			nowEnabled = false
+		}
+		if nowEnabled {
+			// Reported once, when the pod is assigned an IP
+			if !wasEnabled && oldPod.Status.PodIP == "" && newPod.Status.PodIP != "" {
+				s.reportInitContainerConflict(ctx, newPod)
+			}
+			nowEnabled = s.initContainersCaptured(newPod) && !s.initContainersPending(newPod)
+		}
 		if wasEnabled && !nowEnabled {
 			log.Debugf("Pod %s no longer matches, removing from mesh", newPod.Name)
-			s.DelPodFromMesh(newPod)
+			return recordNamespaceRedirection(event, directionDisabled, s.DelPodFromMesh(ctx, newPod))
 		}
 
-		if !wasEnabled && nowEnabled {
+		if !wasEnabled && nowEnabled && !s.bypassed(newPod) {
 			log.Debugf("Pod %s now matches, adding to mesh", newPod.Name)
-			s.AddPodToMesh(pod)
+			return recordNamespaceRedirection(event, directionEnabled, s.AddPodToMesh(ctx, pod))
 		}
 	case controllers.EventDelete:
-		if s.redirectMode == IptablesMode && IsPodInIpset(pod) {
-			log.Infof("Pod %s/%s is now stopped... cleaning up.", pod.Namespace, pod.Name)
-			s.DelPodFromMesh(pod)
-		} else if s.redirectMode == EbpfMode {
-			log.Debugf("Pod %s/%s is now stopped or opt out... cleaning up.", pod.Namespace, pod.Name)
-			s.DelPodFromMesh(pod)
+		if s.redirectMode == IptablesMode && !s.podEnrolled(pod) {
+			// The pod was not enrolled, but its redirection may be left over, such as if the node agent restarted
+			// while enrolling it, so it is removed on a best effort basis
+			if err := s.redirector.DelPod(ctx, pod); err != nil {
+				log.Debugf("failed to remove redirection of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+			return nil
 		}
-		return nil
+		log.Debugf("Pod %s/%s is now stopped or opt out... cleaning up.", pod.Namespace, pod.Name)
+		return recordNamespaceRedirection(event, directionDisabled, s.DelPodFromMesh(ctx, pod))
 	}
 	return nil
 }
 
+// namespaceAllowed returns whether the pods of a namespace may be added to the mesh by this node agent.
+func (s *Server) namespaceAllowed(namespace string) bool {
+	return len(s.allowedNamespaces) == 0 || s.allowedNamespaces.Contains(namespace)
+}
+
 func ztunnelPod(pod *corev1.Pod) bool {
 	return pod.GetLabels()["app"] == "ztunnel"
 }
+
+// maxCachedAnnotationSize is the size above which pod annotations, such as kubectl.kubernetes.io/last-applied-configuration,
+// are not cached. The node agent only relies on small annotations, or on the presence of larger ones.
+const maxCachedAnnotationSize = 256
+
+// stripPodUnusedFields is the transform function for the pod informer of the node agent. It removes the fields the node
+// agent does not use before pods are stored in the cache, to save memory.
+func stripPodUnusedFields(obj any) (any, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		// shouldn't happen
+		return obj, nil
+	}
+	// ManagedFields is large and we never use it
+	pod.ManagedFields = nil
+	for k, v := range pod.Annotations {
+		if len(v) <= maxCachedAnnotationSize {
+			continue
+		}
+		if k == annotation.SidecarStatus.Name {
+			// Only the presence of the sidecar status is checked
+			pod.Annotations[k] = ""
+		} else {
+			delete(pod.Annotations, k)
+		}
+	}
+	// The containers of ztunnel are inspected for its configuration
+	if !ztunnelPod(pod) {
+		pod.Spec = corev1.PodSpec{
+			ServiceAccountName: pod.Spec.ServiceAccountName,
+			NodeName:           pod.Spec.NodeName,
+			HostNetwork:        pod.Spec.HostNetwork,
+			ReadinessGates:     pod.Spec.ReadinessGates,
+			Containers:         hostPortContainers(pod),
+			InitContainers:     netAdminInitContainers(pod),
+		}
+	}
+	pod.Status.InitContainerStatuses = nil
+	pod.Status.ContainerStatuses = nil
+	pod.Status.EphemeralContainerStatuses = nil
+	return obj, nil
+}
+
+// hostPortContainers returns the containers of the pod exposing hostPorts, with only these ports.
+func hostPortContainers(pod *corev1.Pod) []corev1.Container {
+	var res []corev1.Container
+	for _, c := range pod.Spec.Containers {
+		var ports []corev1.ContainerPort
+		for _, p := range c.Ports {
+			if p.HostPort != 0 {
+				ports = append(ports, p)
+			}
+		}
+		if len(ports) > 0 {
+			res = append(res, corev1.Container{Name: c.Name, Ports: ports})
+		}
+	}
+	return res
+}
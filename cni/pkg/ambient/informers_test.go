@@ -0,0 +1,413 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/api/annotation"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+	"istio.io/istio/pkg/util/sets"
+)
+
+func TestStripPodUnusedFields(t *testing.T) {
+	large := strings.Repeat("x", maxCachedAnnotationSize+1)
+	pod := func(labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:          "pod",
+				Labels:        labels,
+				ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+				Annotations: map[string]string{
+					constants.AmbientRedirection:                       constants.AmbientRedirectionEnabled,
+					annotation.SidecarStatus.Name:                      large,
+					"kubectl.kubernetes.io/last-applied-configuration": large,
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:           "node1",
+				ServiceAccountName: "sa",
+				Containers:         []corev1.Container{{Name: "app", Env: []corev1.EnvVar{{Name: "FOO", Value: "bar"}}}},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				PodIP:             "10.0.0.1",
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app"}},
+			},
+		}
+	}
+
+	got, err := stripPodUnusedFields(pod(map[string]string{"app": "a"}))
+	assert.NoError(t, err)
+	assert.Equal(t, got.(*corev1.Pod), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "pod",
+			Labels: map[string]string{"app": "a"},
+			Annotations: map[string]string{
+				constants.AmbientRedirection:  constants.AmbientRedirectionEnabled,
+				annotation.SidecarStatus.Name: "",
+			},
+		},
+		Spec:   corev1.PodSpec{NodeName: "node1", ServiceAccountName: "sa"},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	})
+
+	// The containers of ztunnel are kept, as its configuration is read from them
+	got, err = stripPodUnusedFields(pod(map[string]string{"app": "ztunnel"}))
+	assert.NoError(t, err)
+	assert.Equal(t, getEnvFromPod(got.(*corev1.Pod), "FOO"), "bar")
+}
+
+func TestAmbientMembershipChanged(t *testing.T) {
+	ns := func(labels map[string]string, annotations map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns", Labels: labels, Annotations: annotations}}
+	}
+	ambient := map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient}
+
+	assert.Equal(t, ambientMembershipChanged(ns(nil, nil), ns(ambient, nil)), true)
+	assert.Equal(t, ambientMembershipChanged(ns(ambient, nil), ns(nil, nil)), true)
+	// Unrelated changes do not change the membership
+	assert.Equal(t, ambientMembershipChanged(ns(ambient, nil), ns(ambient, map[string]string{"foo": "bar"})), false)
+	assert.Equal(t, ambientMembershipChanged(ns(nil, nil), ns(map[string]string{"foo": "bar"}, nil)), false)
+	assert.Equal(t, ambientMembershipChanged(ns(nil, nil), ns(map[string]string{constants.DataplaneMode: "other"}, nil)), false)
+}
+
+func TestNamespaceEvent(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}
+	updated := pod.DeepCopy()
+
+	assert.Equal(t, namespaceEvent(controllers.Event{Old: pod, New: pod, Event: controllers.EventUpdate}), true)
+	assert.Equal(t, namespaceEvent(controllers.Event{Old: pod, New: pod, Event: controllers.EventDelete}), true)
+	// Informer events
+	assert.Equal(t, namespaceEvent(controllers.Event{Old: pod, New: updated, Event: controllers.EventUpdate}), false)
+	assert.Equal(t, namespaceEvent(controllers.Event{New: pod, Event: controllers.EventAdd}), false)
+	assert.Equal(t, namespaceEvent(controllers.Event{Old: pod, Event: controllers.EventDelete}), false)
+}
+
+func TestPodEnrolled(t *testing.T) {
+	s := &Server{desiredState: desiredStateOf(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{UID: "tracked"}})}
+	pod := func(uid types.UID, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", UID: uid, Annotations: annotations}}
+	}
+	assert.Equal(t, s.podEnrolled(pod("tracked", nil)), true)
+	// Pods enrolled before the node agent restarted are annotated
+	assert.Equal(t, s.podEnrolled(pod("other", map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled})), true)
+	assert.Equal(t, s.podEnrolled(pod("other", nil)), false)
+}
+
+func TestNamespaceAllowed(t *testing.T) {
+	s := &Server{}
+	assert.Equal(t, s.namespaceAllowed("tenant-a"), true)
+
+	s.allowedNamespaces = sets.New("tenant-a")
+	assert.Equal(t, s.namespaceAllowed("tenant-a"), true)
+	assert.Equal(t, s.namespaceAllowed("tenant-b"), false)
+	// The pods of other namespaces are not listed, so the informer of pods is not needed
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "tenant-b",
+		Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+	}}
+	assert.Equal(t, s.EnqueueNamespace(ns), 0)
+}
+
+type fakeRedirector struct {
+	added    []string
+	cleanups int
+}
+
+func (r *fakeRedirector) AddPod(_ context.Context, pod *corev1.Pod) error {
+	r.added = append(r.added, pod.Name)
+	return nil
+}
+
+func (r *fakeRedirector) DelPod(context.Context, *corev1.Pod) error {
+	return nil
+}
+
+func (r *fakeRedirector) SetZtunnel(*corev1.Pod, bool) error {
+	return nil
+}
+
+func (r *fakeRedirector) Cleanup() {
+	r.cleanups++
+}
+
+func TestReconcilePodAdd(t *testing.T) {
+	pod := func(name, ip string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ambient", Annotations: annotations},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: ip},
+		}
+	}
+	cases := []struct {
+		name     string
+		pod      *corev1.Pod
+		ztunnel  bool
+		excluded bool
+		added    bool
+	}{
+		{
+			name:    "running",
+			pod:     pod("running", "10.0.0.1", nil),
+			ztunnel: true,
+			added:   true,
+		},
+		{
+			name:    "already annotated",
+			pod:     pod("annotated", "10.0.0.1", map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled}),
+			ztunnel: true,
+			added:   true,
+		},
+		{
+			name:    "no IP",
+			pod:     pod("pending", "", nil),
+			ztunnel: true,
+		},
+		{
+			name:    "opted out",
+			pod:     pod("disabled", "10.0.0.1", map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionDisabled}),
+			ztunnel: true,
+		},
+		{
+			name: "no ztunnel",
+			pod:  pod("running", "10.0.0.1", nil),
+		},
+		{
+			name:     "excluded namespace",
+			pod:      pod("running", "10.0.0.1", nil),
+			ztunnel:  true,
+			excluded: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "ambient",
+				Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+			}}
+			client := kube.NewFakeClient(ns, tt.pod)
+			redirector := &fakeRedirector{}
+			s := &Server{
+				ctx:          context.Background(),
+				kubeClient:   client,
+				pods:         kclient.New[*corev1.Pod](client),
+				namespaces:   kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+				redirectMode: ExternalMode,
+				redirector:   redirector,
+				desiredState: newDesiredState(),
+			}
+			if tt.excluded {
+				s.excludedNamespaces = sets.New("ambient")
+			}
+			if tt.ztunnel {
+				s.ztunnelPod = &corev1.Pod{}
+			}
+			client.RunAndWait(test.NewStop(t))
+
+			assert.NoError(t, s.reconcilePod(s.ctx, controllers.Event{New: tt.pod, Event: controllers.EventAdd}))
+			assert.Equal(t, len(redirector.added) == 1, tt.added)
+		})
+	}
+}
+
+// blockingRedirector blocks adding pods until released.
+type blockingRedirector struct {
+	recordingRedirector
+	started *atomic.Int32
+	release chan struct{}
+}
+
+func (r *blockingRedirector) AddPod(ctx context.Context, pod *corev1.Pod) error {
+	r.started.Inc()
+	<-r.release
+	return r.recordingRedirector.AddPod(ctx, pod)
+}
+
+func TestStopWaitsForTimedOutReconcile(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "ambient",
+		Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+	}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ambient"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning, PodIP: "10.0.0.1"},
+	}
+	client := kube.NewFakeClient(ns, pod)
+	redirector := &blockingRedirector{started: atomic.NewInt32(0), release: make(chan struct{})}
+	s := &Server{
+		ctx:              context.Background(),
+		kubeClient:       client,
+		pods:             kclient.New[*corev1.Pod](client),
+		namespaces:       kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+		redirectMode:     ExternalMode,
+		redirector:       redirector,
+		desiredState:     newDesiredState(),
+		quarantinedPods:  newQuarantinedPods(),
+		reconcileTimeout: 10 * time.Millisecond,
+		ztunnelPod:       &corev1.Pod{},
+	}
+	s.reconcileCtx, s.cancelReconcile = context.WithCancel(context.Background())
+	s.queue = newWorkQueue(1, s.Reconcile)
+	stop := test.NewStop(t)
+	client.RunAndWait(stop)
+	go s.queue.Run(stop)
+
+	// The reconciliation of the pod times out, while it still adds the pod
+	s.queue.Add(controllers.Event{New: pod, Event: controllers.EventAdd})
+	retry.UntilOrFail(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.failedPods.Len() > 0
+	}, retry.Timeout(10*time.Second))
+	// The reconciliation lock is released on timeout, so ztunnel changes are not blocked by the hung reconciliation
+	retry.UntilOrFail(t, func() bool {
+		if !s.reconcileMu.TryLock() {
+			return false
+		}
+		s.reconcileMu.Unlock()
+		return true
+	}, retry.Timeout(10*time.Second))
+	// The retries of the event do not run concurrently with the hung reconciliation
+	assert.Equal(t, s.startReconcile(pod.UID), false)
+	assert.Error(t, s.Reconcile(controllers.Event{New: pod, Event: controllers.EventAdd}))
+	assert.Equal(t, redirector.started.Load(), int32(1))
+
+	stopped := atomic.NewBool(false)
+	go func() {
+		s.Stop()
+		stopped.Store(true)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped.Load(), false)
+	assert.Equal(t, redirector.recorded(), nil)
+
+	// The node is only cleaned up once the pods being added are done
+	close(redirector.release)
+	retry.UntilOrFail(t, stopped.Load, retry.Timeout(10*time.Second))
+	ops := redirector.recorded()
+	assert.Equal(t, len(ops), int(redirector.started.Load())+1)
+	assert.Equal(t, ops[len(ops)-1], "cleanup")
+	assert.Equal(t, s.startReconcile(pod.UID), true)
+}
+
+func TestStopBoundsTimedOutReconcile(t *testing.T) {
+	old := stopReconcilingTimeout
+	stopReconcilingTimeout = 10 * time.Millisecond
+	t.Cleanup(func() { stopReconcilingTimeout = old })
+	redirector := &recordingRedirector{}
+	s := &Server{redirector: redirector}
+	s.reconcileCtx, s.cancelReconcile = context.WithCancel(context.Background())
+	s.queue = newWorkQueue(1, s.Reconcile)
+	// A reconciliation hangs forever, ignoring its cancellation
+	s.reconciling.Add(1)
+	t.Cleanup(s.reconciling.Done)
+
+	stopped := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Stop did not return")
+	}
+	// The node is cleaned up anyway
+	assert.Equal(t, redirector.recorded(), []string{"cleanup"})
+}
+
+func TestRunQueueAfterNamespaceSync(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ambient"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ambient"}}
+	client := kube.NewFakeClient(ns, pod)
+	found := make(chan bool, 1)
+	s := &Server{
+		namespaces: kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+	}
+	s.queue = newWorkQueue(1, func(e any) error {
+		p := e.(controllers.Event).Latest()
+		found <- s.namespaces.Get(p.GetNamespace(), "") != nil
+		return nil
+	})
+	stop := test.NewStop(t)
+
+	// Pod events added before the namespaces are synced are not reconciled yet
+	s.queue.Add(controllers.Event{New: pod, Event: controllers.EventAdd})
+	go s.runQueue(stop)
+	select {
+	case <-found:
+		t.Fatal("pod event reconciled before the namespaces were synced")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Once synced, the namespace of the pod is found
+	client.RunAndWait(stop)
+	assert.Equal(t, <-found, true)
+}
+
+func TestNamespaceDeletion(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "ambient",
+		Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+	}}
+	annotated := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:        "annotated",
+		Namespace:   "ambient",
+		UID:         "annotated",
+		Annotations: map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled},
+	}}
+	notEnrolled := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "not-enrolled", Namespace: "ambient", UID: "not-enrolled"}}
+	// Pods which are no longer in the informer, such as pods whose delete event was reconciled while enrolling them
+	removed := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "removed", Namespace: "ambient", UID: "removed"}}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other", UID: "other"}}
+	client := kube.NewFakeClient(ns, annotated, notEnrolled)
+	events := make(chan controllers.Event, 10)
+	s := &Server{
+		pods:         kclient.New[*corev1.Pod](client),
+		desiredState: desiredStateOf(removed, other),
+		queue: newWorkQueue(1, func(e any) error {
+			events <- e.(controllers.Event)
+			return nil
+		}),
+	}
+	stop := test.NewStop(t)
+	client.RunAndWait(stop)
+	go s.queue.Run(stop)
+
+	s.handleNamespaceEvent(controllers.Event{Old: ns, Event: controllers.EventDelete})
+	deleted := sets.New[string]()
+	for i := 0; i < 2; i++ {
+		e := <-events
+		assert.Equal(t, e.Event, controllers.EventDelete)
+		deleted.Insert(e.Latest().GetName())
+	}
+	assert.Equal(t, deleted, sets.New("annotated", "removed"))
+
+	assert.Equal(t, s.enqueueNamespaceDeletion("empty"), 0)
+}
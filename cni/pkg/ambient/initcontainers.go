@@ -0,0 +1,145 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Some pods program their own network with init containers, such as VPN clients routing the traffic of the pod through
+// a tunnel, or proxies redirecting it with iptables. Pods are captured when they are assigned an IP, while their init
+// containers run, so these init containers see the redirection to ztunnel half way, and their rules may conflict with
+// it: the traffic they tunnel or translate is redirected to ztunnel with the addresses they rewrote, or not at all.
+// Init containers able to program the network, privileged or adding NET_ADMIN, are detected, and the pods running them
+// are handled according to the InitContainerConflictPolicy.
+
+// InitContainerConflictPolicy selects how the pods in the mesh whose init containers may program their network are
+// captured.
+type InitContainerConflictPolicy string
+
+const (
+	// InitContainerConflictCapture captures the pods as soon as they are assigned an IP, like the other pods.
+	InitContainerConflictCapture InitContainerConflictPolicy = "capture"
+	// InitContainerConflictSkip does not capture the pods.
+	InitContainerConflictSkip InitContainerConflictPolicy = "skip"
+	// InitContainerConflictSerialize captures the pods once their init containers completed, so they program the
+	// network of the pod before it is redirected to ztunnel.
+	InitContainerConflictSerialize InitContainerConflictPolicy = "serialize"
+)
+
+const initContainerConflictReason = "AmbientInitContainerConflict"
+
+// Validate returns an error if the policy is unknown.
+func (p InitContainerConflictPolicy) Validate() error {
+	switch p {
+	case "", InitContainerConflictCapture, InitContainerConflictSkip, InitContainerConflictSerialize:
+		return nil
+	}
+	return fmt.Errorf("unknown init container conflict policy %q", p)
+}
+
+// CaptureOnCreation returns whether the pod may be captured as soon as it is created under the policy, before its init
+// containers run.
+func (p InitContainerConflictPolicy) CaptureOnCreation(pod *corev1.Pod) bool {
+	return p == "" || p == InitContainerConflictCapture || len(NetAdminInitContainers(pod)) == 0
+}
+
+// NetAdminInitContainers returns the names of the init containers of the pod which may program its network, as they
+// are privileged or add the NET_ADMIN capability.
+func NetAdminInitContainers(pod *corev1.Pod) []string {
+	var res []string
+	for _, c := range pod.Spec.InitContainers {
+		if netAdminContainer(c) {
+			res = append(res, c.Name)
+		}
+	}
+	return res
+}
+
+func netAdminContainer(c corev1.Container) bool {
+	sc := c.SecurityContext
+	if sc == nil {
+		return false
+	}
+	if sc.Privileged != nil && *sc.Privileged {
+		return true
+	}
+	return sc.Capabilities != nil && containsCapability(sc.Capabilities.Add, "NET_ADMIN")
+}
+
+// netAdminInitContainers returns the init containers of the pod which may program its network, with only their
+// security context.
+func netAdminInitContainers(pod *corev1.Pod) []corev1.Container {
+	var res []corev1.Container
+	for _, c := range pod.Spec.InitContainers {
+		if !netAdminContainer(c) {
+			continue
+		}
+		sc := &corev1.SecurityContext{Privileged: c.SecurityContext.Privileged}
+		if c.SecurityContext.Capabilities != nil {
+			sc.Capabilities = &corev1.Capabilities{Add: c.SecurityContext.Capabilities.Add}
+		}
+		res = append(res, corev1.Container{Name: c.Name, SecurityContext: sc})
+	}
+	return res
+}
+
+// podInitialized returns whether the init containers of the pod completed.
+func podInitialized(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodInitialized {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// initContainersCaptured returns whether the pod can be captured with its init containers under the policy.
+func (s *Server) initContainersCaptured(pod *corev1.Pod) bool {
+	return s.initContainerConflicts != InitContainerConflictSkip || len(NetAdminInitContainers(pod)) == 0
+}
+
+// initContainersPending returns whether the capture of the pod waits for its init containers to complete.
+func (s *Server) initContainersPending(pod *corev1.Pod) bool {
+	return s.initContainerConflicts == InitContainerConflictSerialize && !podInitialized(pod) &&
+		len(NetAdminInitContainers(pod)) > 0
+}
+
+// reportInitContainerConflict records an event on a pod of an ambient namespace whose init containers may program its
+// network, explaining how it is captured under the policy. It is a no-op for the other pods.
+func (s *Server) reportInitContainerConflict(ctx context.Context, pod *corev1.Pod) {
+	names := NetAdminInitContainers(pod)
+	if len(names) == 0 {
+		return
+	}
+	conflict := fmt.Sprintf("init containers %s may program the network of the pod, which may conflict with the "+
+		"redirection", strings.Join(names, ", "))
+	var msg string
+	switch s.initContainerConflicts {
+	case InitContainerConflictSkip:
+		msg = "Pod is not captured by ztunnel: " + conflict
+	case InitContainerConflictSerialize:
+		msg = "Pod is captured by ztunnel once its init containers completed: " + conflict
+	default:
+		msg = "Pod is captured by ztunnel while its init containers run: " + conflict +
+			", set the init container conflict policy to skip or serialize if its traffic breaks"
+	}
+	log.Warnf("pod %s/%s: %s", pod.Namespace, pod.Name, msg)
+	s.recordPodWarning(ctx, pod, initContainerConflictReason, msg)
+}
@@ -0,0 +1,123 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/ptr"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func vpnPod(initialized bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if initialized {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ambient", UID: "uid"},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{Name: "setup"},
+				{
+					Name:  "vpn",
+					Image: "vpn",
+					SecurityContext: &corev1.SecurityContext{Capabilities: &corev1.Capabilities{
+						Add:  []corev1.Capability{"CAP_NET_ADMIN"},
+						Drop: []corev1.Capability{"ALL"},
+					}},
+				},
+				{Name: "privileged", SecurityContext: &corev1.SecurityContext{Privileged: ptr.Of(true)}},
+			},
+			Containers: []corev1.Container{{Name: "app"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodPending,
+			PodIP:      "10.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodInitialized, Status: status}},
+		},
+	}
+}
+
+func TestNetAdminInitContainers(t *testing.T) {
+	assert.Equal(t, NetAdminInitContainers(vpnPod(false)), []string{"vpn", "privileged"})
+
+	// The init containers programming the network are kept in the informer cache, with their security context
+	stripped, err := stripPodUnusedFields(vpnPod(false))
+	assert.NoError(t, err)
+	assert.Equal(t, NetAdminInitContainers(stripped.(*corev1.Pod)), []string{"vpn", "privileged"})
+	assert.Equal(t, stripped.(*corev1.Pod).Spec.InitContainers[0].Image, "")
+
+	assert.Equal(t, InitContainerConflictCapture.CaptureOnCreation(vpnPod(false)), true)
+	assert.Equal(t, InitContainerConflictSerialize.CaptureOnCreation(vpnPod(false)), false)
+	assert.Equal(t, InitContainerConflictSkip.CaptureOnCreation(hostPortPod()), true)
+	assert.Error(t, InitContainerConflictPolicy("wait").Validate())
+}
+
+func TestReconcilePodInitContainerConflicts(t *testing.T) {
+	for _, policy := range []InitContainerConflictPolicy{InitContainerConflictCapture, InitContainerConflictSkip, InitContainerConflictSerialize} {
+		t.Run(string(policy), func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "ambient",
+				Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+			}}
+			pod := vpnPod(false)
+			client := kube.NewFakeClient(ns, pod)
+			redirector := &fakeRedirector{}
+			s := &Server{
+				ctx:                    context.Background(),
+				kubeClient:             client,
+				pods:                   kclient.New[*corev1.Pod](client),
+				namespaces:             kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+				redirectMode:           ExternalMode,
+				redirector:             redirector,
+				desiredState:           newDesiredState(),
+				ztunnelPod:             &corev1.Pod{},
+				initContainerConflicts: policy,
+			}
+			client.RunAndWait(test.NewStop(t))
+
+			assert.NoError(t, s.reconcilePod(s.ctx, controllers.Event{New: pod, Event: controllers.EventAdd}))
+			// The decision is reported on the pod whatever the policy
+			events, err := client.Kube().CoreV1().Events(pod.Namespace).List(s.ctx, metav1.ListOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, len(events.Items), 1)
+			assert.Equal(t, events.Items[0].Reason, initContainerConflictReason)
+			if policy == InitContainerConflictCapture {
+				assert.Equal(t, redirector.added, []string{"pod"})
+				return
+			}
+			assert.Equal(t, len(redirector.added), 0)
+
+			// Pods are captured once their init containers completed with the serialize policy
+			initialized := vpnPod(true)
+			assert.NoError(t, s.reconcilePod(s.ctx, controllers.Event{Old: pod, New: initialized, Event: controllers.EventUpdate}))
+			if policy == InitContainerConflictSerialize {
+				assert.Equal(t, redirector.added, []string{"pod"})
+			} else {
+				assert.Equal(t, len(redirector.added), 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/cni/pkg/features"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// Keys of the ambient settings in the ConfigMap of the installation. The feature flags, such as AMBIENT_DNS_CAPTURE,
+// are also set from their keys in it.
+const (
+	installExcludeNamespaces = "AMBIENT_EXCLUDE_NAMESPACES"
+	installRedirectMode      = "AMBIENT_REDIRECT_MODE"
+)
+
+// loadInstallConfig reads the ambient settings of the installation, if their ConfigMap is configured.
+func (s *Server) loadInstallConfig() {
+	if s.installConfigMap == "" {
+		return
+	}
+	cm, err := s.kubeClient.Kube().CoreV1().ConfigMaps(s.systemNamespace).Get(s.ctx, s.installConfigMap, metav1.GetOptions{})
+	switch {
+	case kerrors.IsNotFound(err):
+		log.Warnf("the ambient install ConfigMap %s/%s was not found", s.systemNamespace, s.installConfigMap)
+	case err != nil:
+		log.Warnf("failed to read the ambient install ConfigMap %s/%s: %v", s.systemNamespace, s.installConfigMap, err)
+	default:
+		s.applyInstallConfig(cm.Data)
+	}
+}
+
+// watchInstallConfig reloads the ambient settings of the installation when their ConfigMap changes. The pods of the
+// namespaces which are no longer, or newly, excluded are enqueued to update their redirection.
+func (s *Server) watchInstallConfig() {
+	if s.installConfigMap == "" {
+		return
+	}
+	s.installConfigMaps = kclient.NewFiltered[*corev1.ConfigMap](s.kubeClient, kclient.Filter{
+		FieldSelector: "metadata.name=" + s.installConfigMap + ",metadata.namespace=" + s.systemNamespace,
+	})
+	s.installConfigMaps.AddEventHandler(controllers.FromEventHandler(func(e controllers.Event) {
+		var data map[string]string
+		if e.Event != controllers.EventDelete {
+			data = e.Latest().(*corev1.ConfigMap).Data
+		}
+		changed := s.applyInstallConfig(data)
+		if len(changed) == 0 {
+			return
+		}
+		log.Infof("excluded namespaces changed for %v", sets.SortedList(changed))
+		// The CNI plugin skips the pods of the excluded namespaces too
+		s.UpdateConfig()
+		for namespace := range changed {
+			if ns := s.namespaces.Get(namespace, ""); ns != nil {
+				s.EnqueueNamespace(ns)
+			}
+		}
+	}))
+}
+
+// applyInstallConfig applies the ambient settings of the installation, returning the namespaces whose exclusion
+// changed.
+func (s *Server) applyInstallConfig(data map[string]string) sets.String {
+	excluded := parseNamespaceList(data[installExcludeNamespaces])
+	s.mu.Lock()
+	changed := excluded.Difference(s.excludedNamespaces).Union(s.excludedNamespaces.Difference(excluded))
+	s.excludedNamespaces = excluded
+	s.mu.Unlock()
+
+	// The redirect mode is unknown until the redirection is set up, after the settings are first read
+	if mode := data[installRedirectMode]; mode != "" && s.redirector != nil && s.redirectMode != ExternalMode &&
+		mode != s.redirectMode.String() {
+		log.Warnf("the redirect mode of the installation changed to %s, it only takes effect when the node agent "+
+			"restarts; the %v redirection is still used", mode, s.redirectMode)
+	}
+	features.UpdateInstall(data)
+	return changed
+}
+
+// namespaceExcluded returns whether the installation excludes the pods of a namespace from the mesh.
+func (s *Server) namespaceExcluded(namespace string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.excludedNamespaces.Contains(namespace)
+}
+
+// parseNamespaceList parses a comma-separated list of namespaces.
+func parseNamespaceList(v string) sets.String {
+	res := sets.New[string]()
+	for _, ns := range strings.Split(v, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			res.Insert(ns)
+		}
+	}
+	return res
+}
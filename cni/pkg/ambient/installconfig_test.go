@@ -0,0 +1,88 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/cni/pkg/features"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/util/sets"
+)
+
+func TestParseNamespaceList(t *testing.T) {
+	assert.Equal(t, parseNamespaceList(""), sets.New[string]())
+	assert.Equal(t, parseNamespaceList("a, b,,a"), sets.New("a", "b"))
+}
+
+func TestApplyInstallConfig(t *testing.T) {
+	t.Cleanup(func() { features.UpdateInstall(nil) })
+	s := &Server{}
+
+	assert.Equal(t, s.applyInstallConfig(map[string]string{installExcludeNamespaces: "a,b"}), sets.New("a", "b"))
+	assert.Equal(t, s.namespaceExcluded("a"), true)
+	assert.Equal(t, s.namespaceExcluded("c"), false)
+
+	// Only the namespaces whose exclusion changed are returned
+	assert.Equal(t, s.applyInstallConfig(map[string]string{installExcludeNamespaces: "b,c"}), sets.New("a", "c"))
+	assert.Equal(t, s.applyInstallConfig(map[string]string{installExcludeNamespaces: "c,b"}), sets.New[string]())
+	assert.Equal(t, s.applyInstallConfig(nil), sets.New("b", "c"))
+	assert.Equal(t, s.namespaceExcluded("b"), false)
+}
+
+func TestExcludedNamespaceEnqueue(t *testing.T) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   "ambient",
+		Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+	}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ambient"}}
+	client := kube.NewFakeClient(ns, pod)
+	events := make(chan controllers.Event, 1)
+	s := &Server{
+		pods: kclient.New[*corev1.Pod](client),
+		queue: newWorkQueue(1, func(e any) error {
+			events <- e.(controllers.Event)
+			return nil
+		}),
+		excludedNamespaces: sets.New("ambient"),
+	}
+	stop := test.NewStop(t)
+	client.RunAndWait(stop)
+	go s.queue.Run(stop)
+
+	// The pods of excluded namespaces are removed from the mesh
+	assert.Equal(t, s.EnqueueNamespace(ns), 1)
+	assert.Equal(t, (<-events).Event, controllers.EventDelete)
+}
+
+func TestAmbientConfigFileNamespaceAllowed(t *testing.T) {
+	cfg := &AmbientConfigFile{ExcludeNamespaces: []string{"excluded"}}
+	assert.Equal(t, cfg.NamespaceAllowed("a"), true)
+	assert.Equal(t, cfg.NamespaceAllowed("excluded"), false)
+
+	// The CNI plugin only captures the pods of the namespaces the node agent is restricted to
+	cfg.Namespaces = []string{"a", "excluded"}
+	assert.Equal(t, cfg.NamespaceAllowed("a"), true)
+	assert.Equal(t, cfg.NamespaceAllowed("b"), false)
+	assert.Equal(t, cfg.NamespaceAllowed("excluded"), false)
+}
@@ -76,6 +76,11 @@ func (s *Server) detectIptablesCommand() string {
 	return "iptables-nft"
 }
 
+// kubeProxyDetected returns whether kube-proxy, in either iptables or IPVS mode, has programmed the node.
+func (s *Server) kubeProxyDetected() bool {
+	return execute(s.IptablesCmd(), "-t", "nat", "-n", "-L", "KUBE-SERVICES") == nil
+}
+
 func (s *Server) IptablesCmd() string {
 	c, _ := s.iptablesCommand.Get()
 	return c
@@ -0,0 +1,91 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"istio.io/istio/pkg/kube"
+)
+
+const (
+	// DefaultKubeClientQPS is the rate of requests per second of the node agent to the API server, when unset.
+	DefaultKubeClientQPS = 80
+	// DefaultKubeClientBurst is the number of requests the node agent may send above DefaultKubeClientQPS in bursts,
+	// when unset.
+	DefaultKubeClientBurst = 160
+)
+
+// buildKubeClient creates the kube client
+func buildKubeClient(kubeConfig string, cfg KubeClientConfig) (kube.Client, error) {
+	qps, burst := cfg.QPS, cfg.Burst
+	if qps <= 0 {
+		qps = DefaultKubeClientQPS
+	}
+	if burst <= 0 {
+		burst = DefaultKubeClientBurst
+	}
+	// Used by validation
+	kubeRestConfig, err := kube.DefaultRestConfig(kubeConfig, "", func(config *rest.Config) {
+		config.QPS = qps
+		config.Burst = burst
+		// The rate limiter set takes precedence over QPS and Burst, which are only kept for the clients reading them
+		config.RateLimiter = newThrottleRecorder(flowcontrol.NewTokenBucketRateLimiter(qps, burst))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed creating kube config: %v", err)
+	}
+
+	client, err := kube.NewClient(kube.NewClientConfigForRestConfig(kubeRestConfig), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed creating kube client: %v", err)
+	}
+
+	return client, nil
+}
+
+// throttleRecorder records the requests delayed by the client-side rate limiting of the kube client. They are the
+// first sign the limits are too low for the cluster, such as when the informers of the node agent relist all the pods
+// after it restarts, before its caches lag behind the cluster.
+type throttleRecorder struct {
+	flowcontrol.RateLimiter
+}
+
+func newThrottleRecorder(limiter flowcontrol.RateLimiter) flowcontrol.RateLimiter {
+	return throttleRecorder{RateLimiter: limiter}
+}
+
+// Wait admits the request immediately if the rate limiter allows it, or records it as throttled and waits for it.
+// TryAccept does not take a token when it fails, so the request takes a single one either way.
+func (t throttleRecorder) Wait(ctx context.Context) error {
+	if t.RateLimiter.TryAccept() {
+		return nil
+	}
+	start := time.Now()
+	err := t.RateLimiter.Wait(ctx)
+	kubeClientThrottled.Increment()
+	kubeClientThrottleDelay.Record(time.Since(start).Seconds())
+	return err
+}
+
+// Accept is Wait without a context, used by the clients which cannot be cancelled.
+func (t throttleRecorder) Accept() {
+	_ = t.Wait(context.Background())
+}
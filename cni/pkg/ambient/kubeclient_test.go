@@ -0,0 +1,61 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/util/flowcontrol"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+type fakeRateLimiter struct {
+	flowcontrol.RateLimiter
+	tokens int
+	waits  int
+}
+
+func (f *fakeRateLimiter) TryAccept() bool {
+	if f.tokens == 0 {
+		return false
+	}
+	f.tokens--
+	return true
+}
+
+func (f *fakeRateLimiter) Wait(ctx context.Context) error {
+	f.waits++
+	return ctx.Err()
+}
+
+func TestThrottleRecorder(t *testing.T) {
+	limiter := &fakeRateLimiter{tokens: 2}
+	r := newThrottleRecorder(limiter)
+
+	// Requests within the burst are not waited for
+	assert.NoError(t, r.Wait(context.Background()))
+	r.Accept()
+	assert.Equal(t, limiter.waits, 0)
+
+	// Once the tokens are exhausted, the requests wait for the rate limiter
+	assert.NoError(t, r.Wait(context.Background()))
+	assert.Equal(t, limiter.waits, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, r.Wait(ctx))
+	assert.Equal(t, limiter.waits, 2)
+}
@@ -0,0 +1,196 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"istio.io/pkg/monitoring"
+)
+
+var (
+	typeLabel = monitoring.MustCreateLabel("type")
+
+	namespaceLabel = monitoring.MustCreateLabel("namespace")
+
+	directionLabel    = monitoring.MustCreateLabel("direction")
+	directionEnabled  = "enabled"
+	directionDisabled = "disabled"
+
+	reasonLabel = monitoring.MustCreateLabel("reason")
+
+	kernelVersionLabel   = monitoring.MustCreateLabel("kernel_version")
+	redirectModeLabel    = monitoring.MustCreateLabel("redirect_mode")
+	iptablesVariantLabel = monitoring.MustCreateLabel("iptables_variant")
+	ip6tablesLabel       = monitoring.MustCreateLabel("ip6tables")
+	ipsetLabel           = monitoring.MustCreateLabel("ipset")
+	ebpfLabel            = monitoring.MustCreateLabel("ebpf")
+	ebpfTproxyLabel      = monitoring.MustCreateLabel("ebpf_tproxy")
+
+	resultLabel   = monitoring.MustCreateLabel("result")
+	resultSuccess = "success"
+	resultFail    = "fail"
+
+	ruleTypeAddPod  = "add_pod"
+	ruleTypeDelPod  = "delete_pod"
+	ruleTypeZtunnel = "ztunnel"
+	ruleTypeCleanup = "cleanup"
+
+	auditEventsDropped = monitoring.NewSum(
+		"istio_cni_ambient_audit_events_dropped_total",
+		"Total number of audit events of the mutations of the host which could not be recorded",
+	)
+
+	enrollmentHooks = monitoring.NewSum(
+		"istio_cni_ambient_enrollment_hooks_total",
+		"Total number of enrollment hook invocations by the ambient node agent",
+		monitoring.WithLabels(typeLabel, resultLabel),
+	)
+
+	namespaceFanoutsSuppressed = monitoring.NewSum(
+		"istio_cni_ambient_namespace_fanouts_suppressed_total",
+		"Total number of namespace updates not enqueuing the pods of the namespace, as its ambient membership did not change",
+	)
+
+	namespaceTransitions = monitoring.NewSum(
+		"istio_cni_ambient_namespace_transitions_total",
+		"Total number of namespaces enabled in or disabled from the ambient mesh",
+		monitoring.WithLabels(directionLabel),
+	)
+
+	namespaceTransitionPods = monitoring.NewDistribution(
+		"istio_cni_ambient_namespace_transition_pods",
+		"Number of pods of the node enqueued when their namespace is enabled in or disabled from the ambient mesh",
+		[]float64{0, 1, 5, 10, 25, 50, 100, 250},
+		monitoring.WithLabels(directionLabel),
+	)
+
+	namespaceRedirectionChanges = monitoring.NewSum(
+		"istio_cni_ambient_namespace_redirection_changes_total",
+		"Total number of pods added to or removed from the ambient mesh after being enqueued for their namespace",
+		monitoring.WithLabels(directionLabel),
+	)
+
+	reconciles = monitoring.NewSum(
+		"istio_cni_ambient_reconciles_total",
+		"Total number of pod events reconciled by the ambient node agent",
+		monitoring.WithLabels(typeLabel, resultLabel),
+	)
+
+	reconcileTimeouts = monitoring.NewSum(
+		"istio_cni_ambient_reconcile_timeouts_total",
+		"Total number of pod events the ambient node agent failed to reconcile before the reconcile timeout",
+		monitoring.WithLabels(typeLabel),
+	)
+
+	reconcilePanics = monitoring.NewSum(
+		"istio_cni_ambient_reconcile_panics_total",
+		"Total number of pod events whose reconciliation by the ambient node agent panicked",
+		monitoring.WithLabels(typeLabel),
+	)
+
+	quarantinedPodsGauge = monitoring.NewGauge(
+		"istio_cni_ambient_quarantined_pods",
+		"Number of pods whose events are skipped by the ambient node agent until they change, as their reconciliation panicked",
+	)
+
+	namespacePods = monitoring.NewGauge(
+		"istio_cni_ambient_namespace_pods",
+		"Number of running pods of a namespace on the node",
+		monitoring.WithLabels(namespaceLabel),
+	)
+
+	namespacePodsCaptured = monitoring.NewGauge(
+		"istio_cni_ambient_namespace_pods_captured",
+		"Number of running pods of a namespace on the node whose traffic is redirected to ztunnel",
+		monitoring.WithLabels(namespaceLabel),
+	)
+
+	// The node health metrics are meant for a node level dashboard, such as the Ambient Node Health dashboard of the
+	// Grafana addon.
+	podsCaptured = monitoring.NewGauge(
+		"istio_cni_ambient_pods_captured",
+		"Number of running pods of the node whose traffic is redirected to ztunnel",
+	)
+
+	podsPending = monitoring.NewGauge(
+		"istio_cni_ambient_pods_pending",
+		"Number of running pods of the node which are in the ambient mesh, but whose traffic is not redirected to ztunnel yet",
+	)
+
+	podsFailed = monitoring.NewGauge(
+		"istio_cni_ambient_pods_failed",
+		"Number of pods of the node whose last event the ambient node agent failed to reconcile",
+	)
+
+	ztunnelReady = monitoring.NewGauge(
+		"istio_cni_ambient_ztunnel_ready",
+		"Whether a ztunnel pod is ready on the node, so traffic can be redirected to it",
+	)
+
+	enrollmentFailures = monitoring.NewSum(
+		"istio_cni_ambient_enrollment_failures_total",
+		"Total number of failures to add pods to the ambient mesh, remove them from it, or redirect the node to ztunnel, by cause",
+		monitoring.WithLabels(typeLabel, reasonLabel),
+	)
+
+	nodeInfo = monitoring.NewGauge(
+		"istio_cni_ambient_node_info",
+		"What the node supports of the redirection, probed by the ambient node agent at startup; the value is always 1",
+		monitoring.WithLabels(kernelVersionLabel, redirectModeLabel, iptablesVariantLabel, ip6tablesLabel, ipsetLabel,
+			ebpfLabel, ebpfTproxyLabel),
+	)
+
+	nodeRepairs = monitoring.NewSum(
+		"istio_cni_ambient_node_repairs_total",
+		"Total number of repairs of the node redirection to ztunnel, after some of it was found removed by other tooling",
+		monitoring.WithLabels(resultLabel),
+	)
+
+	ebpfShadowDivergences = monitoring.NewSum(
+		"istio_cni_ambient_ebpf_shadow_divergences_total",
+		"Total number of pods redirected by the iptables redirection which the shadow eBPF redirection does not support or failed to program, by reason",
+		monitoring.WithLabels(reasonLabel),
+	)
+
+	ebpfShadowMismatchedPods = monitoring.NewGauge(
+		"istio_cni_ambient_ebpf_shadow_mismatched_pods",
+		"Number of pod IPs in the redirect state of only one of the iptables redirection and the shadow eBPF redirection, by which one redirects them",
+		monitoring.WithLabels(typeLabel),
+	)
+
+	kubeClientThrottled = monitoring.NewSum(
+		"istio_cni_ambient_kube_client_throttled_requests_total",
+		"Total number of requests of the ambient node agent to the API server delayed by its client-side rate limiting",
+	)
+
+	kubeClientThrottleDelay = monitoring.NewDistribution(
+		"istio_cni_ambient_kube_client_throttle_delay_seconds",
+		"How long the requests of the ambient node agent to the API server were delayed by its client-side rate limiting",
+		[]float64{.005, .01, .05, .1, .5, 1, 5, 10, 30},
+	)
+
+	rulesProgrammed = monitoring.NewSum(
+		"istio_cni_ambient_rules_programmed_total",
+		"Total number of changes to the redirection of the node programmed by the ambient node agent",
+		monitoring.WithLabels(typeLabel, resultLabel),
+	)
+)
+
+func init() {
+	monitoring.MustRegister(auditEventsDropped, enrollmentHooks, namespaceFanoutsSuppressed, namespaceTransitions, namespaceTransitionPods,
+		namespaceRedirectionChanges, reconciles, reconcileTimeouts, reconcilePanics, quarantinedPodsGauge,
+		namespacePods, namespacePodsCaptured, podsCaptured, podsPending, podsFailed, ztunnelReady, rulesProgrammed,
+		enrollmentFailures, nodeInfo, nodeRepairs, ebpfShadowDivergences, ebpfShadowMismatchedPods, kubeClientThrottled,
+		kubeClientThrottleDelay)
+}
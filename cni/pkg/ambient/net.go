@@ -16,6 +16,8 @@ package ambient
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/netip"
@@ -27,15 +29,16 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 
+	"istio.io/istio/cni/pkg/ambient/audit"
+	"istio.io/istio/cni/pkg/ambient/constants"
 	pconstants "istio.io/istio/pkg/config/constants"
-	"istio.io/istio/pkg/kube/kclient"
 	istiolog "istio.io/pkg/log"
 )
 
 var log = istiolog.RegisterScope("ambient", "ambient controller")
 
-func RouteExists(rte []string) bool {
-	output, err := executeOutput(
+func RouteExists(ctx context.Context, rte []string) bool {
+	output, err := executeOutputContext(ctx,
 		"bash", "-c",
 		fmt.Sprintf("ip route show %s | wc -l", strings.Join(rte, " ")),
 	)
@@ -48,46 +51,89 @@ func RouteExists(rte []string) bool {
 	return output == "1"
 }
 
-func AddPodToMesh(client kubernetes.Interface, pod *corev1.Pod, ip string) {
-	addPodToMeshWithIptables(pod, ip)
+func AddPodToMesh(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod, ip string) {
+	if err := addPodToMeshWithIptables(ctx, pod, ip); err != nil {
+		log.Errorf("failed to add pod %s/%s to the mesh: %v", pod.Namespace, pod.Name, err)
+		return
+	}
 
-	if err := AnnotateEnrolledPod(client, pod); err != nil {
+	if err := AnnotateEnrolledPod(ctx, client, pod); err != nil {
 		log.Errorf("failed to annotate pod enrollment: %v", err)
 	}
 }
 
-func addPodToMeshWithIptables(pod *corev1.Pod, ip string) {
-	if ip == "" {
-		ip = pod.Status.PodIP
+// errIPv6NotRedirected reports the IPv6 addresses of pods, which the iptables redirection skips: its ipset and mark
+// rules only match IPv4 addresses.
+var errIPv6NotRedirected = errors.New("IPv6 addresses are not redirected by the iptables redirect mode")
+
+// iptablesPodIPs returns the addresses of the pod redirected by iptables, the given one or else all the addresses of
+// the pod, along with an error naming the IPv6 ones, which are skipped.
+func iptablesPodIPs(pod *corev1.Pod, ip string) ([]string, error) {
+	ips := podIPs(pod)
+	if ip != "" {
+		ips = []string{ip}
 	}
-	if ip == "" {
+	var v4, v6 []string
+	for _, ip := range ips {
+		if familyOf(ip).family == ipv6.family {
+			v6 = append(v6, ip)
+		} else {
+			v4 = append(v4, ip)
+		}
+	}
+	if len(v6) > 0 {
+		return v4, fmt.Errorf("%w: %s", errIPv6NotRedirected, strings.Join(v6, ", "))
+	}
+	return v4, nil
+}
+
+// addPodToMeshWithIptables redirects the IPv4 addresses of the pod. The IPv6 ones are skipped with an error, which is
+// only returned if the pod has no IPv4 address to redirect, as it is then not captured at all.
+func addPodToMeshWithIptables(ctx context.Context, pod *corev1.Pod, ip string) error {
+	ips, err := iptablesPodIPs(pod, ip)
+	if err != nil {
+		if len(ips) == 0 {
+			return err
+		}
+		log.Errorf("Skipping addresses of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	if len(ips) == 0 {
 		log.Debugf("skip adding pod %s/%s, IP not yet allocated", pod.Name, pod.Namespace)
-		return
+		return nil
+	}
+	for _, ip := range ips {
+		addPodIPToMeshWithIptables(ctx, pod, ip)
 	}
+	return nil
+}
 
-	if !IsPodInIpset(pod) {
+func addPodIPToMeshWithIptables(ctx context.Context, pod *corev1.Pod, ip string) {
+	ipsetMu.Lock()
+	if !ipInIpset(ip) {
 		log.Infof("Adding pod '%s/%s' (%s) to ipset", pod.Name, pod.Namespace, string(pod.UID))
 		err := Ipset.AddIP(net.ParseIP(ip).To4(), string(pod.UID))
+		audit.Record(ctx, audit.KindIpset, fmt.Sprintf("add %s to %s", ip, Ipset.Name), err)
 		if err != nil {
 			log.Errorf("Failed to add pod %s to ipset list: %v", pod.Name, err)
 		}
 	} else {
 		log.Infof("Pod '%s/%s' (%s) is in ipset", pod.Name, pod.Namespace, string(pod.UID))
 	}
+	ipsetMu.Unlock()
 
 	rte, err := buildRouteFromPod(pod, ip)
 	if err != nil {
 		log.Errorf("Failed to build route for pod %s: %v", pod.Name, err)
 	}
 
-	if !RouteExists(rte) {
+	if !RouteExists(ctx, rte) {
 		log.Infof("Adding route for %s/%s: %+v", pod.Name, pod.Namespace, rte)
 		// @TODO Try and figure out why buildRouteFromPod doesn't return a good route that we can
 		// use err = netlink.RouteAdd(rte):
 		// Error: {"level":"error","time":"2022-06-24T16:30:59.083809Z","msg":"Failed to add route ({Ifindex: 4 Dst: 10.244.2.7/32
 		// Via: Family: 2, Address: 192.168.126.2 Src: 10.244.2.1 Gw: <nil> Flags: [] Table: 100 Realm: 0}) for pod
 		// helloworld-v2-same-node-67b6b764bf-zhmp4: invalid argument"}
-		err = execute("ip", append([]string{"route", "add"}, rte...)...)
+		err = executeContext(ctx, "ip", append([]string{"route", "add"}, rte...)...)
 		if err != nil {
 			log.Warnf("Failed to add route (%s) for pod %s: %v", rte, pod.Name, err)
 		}
@@ -118,11 +164,11 @@ var annotationRemovePatch = []byte(fmt.Sprintf(
 	pconstants.AmbientRedirection,
 ))
 
-func AnnotateEnrolledPod(client kubernetes.Interface, pod *corev1.Pod) error {
+func AnnotateEnrolledPod(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod) error {
 	_, err := client.CoreV1().
 		Pods(pod.Namespace).
 		Patch(
-			context.Background(),
+			ctx,
 			pod.Name,
 			types.MergePatchType,
 			annotationPatch,
@@ -131,7 +177,7 @@ func AnnotateEnrolledPod(client kubernetes.Interface, pod *corev1.Pod) error {
 	return err
 }
 
-func AnnotateUnenrollPod(client kubernetes.Interface, pod *corev1.Pod) error {
+func AnnotateUnenrollPod(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod) error {
 	if pod.Annotations[pconstants.AmbientRedirection] != pconstants.AmbientRedirectionEnabled {
 		return nil
 	}
@@ -139,7 +185,7 @@ func AnnotateUnenrollPod(client kubernetes.Interface, pod *corev1.Pod) error {
 	_, err := client.CoreV1().
 		Pods(pod.Namespace).
 		Patch(
-			context.Background(),
+			ctx,
 			pod.Name,
 			types.MergePatchType,
 			annotationRemovePatch,
@@ -148,47 +194,95 @@ func AnnotateUnenrollPod(client kubernetes.Interface, pod *corev1.Pod) error {
 	return err
 }
 
-func DelPodFromMesh(client kubernetes.Interface, pod *corev1.Pod) {
+// AnnotateNodeRouting records the routing used on the node, so conflicts with the routing configured by others can
+// be diagnosed.
+func AnnotateNodeRouting(ctx context.Context, client kubernetes.Interface, routing RoutingConfig) error {
+	return annotateNode(ctx, client, constants.NodeRoutingAnnotation, routing)
+}
+
+// annotateNode records a value in an annotation of the node, formatted as JSON. The installation only allows the node
+// agent to patch the ambient.istio.io annotations of its own node, and not at all on clusters without
+// ValidatingAdmissionPolicies enforcing that, where the annotations are then missing.
+func annotateNode(ctx context.Context, client kubernetes.Interface, annotation string, v any) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{annotation: string(value)},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Nodes().Patch(ctx, NodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+func DelPodFromMesh(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod) {
+	delPodFromMeshWithIptables(ctx, pod)
+
+	if err := AnnotateUnenrollPod(ctx, client, pod); err != nil {
+		log.Errorf("failed to annotate pod unenrollment: %v", err)
+	}
+}
+
+func delPodFromMeshWithIptables(ctx context.Context, pod *corev1.Pod) {
 	log.Debugf("Removing pod '%s/%s' (%s) from mesh", pod.Name, pod.Namespace, string(pod.UID))
-	if IsPodInIpset(pod) {
+	// The IPv6 addresses of the pod were never redirected
+	ips, _ := iptablesPodIPs(pod, "")
+	ipsetMu.Lock()
+	for _, ip := range ips {
+		inIpset, err := podIPInIpset(pod, ip)
+		if err != nil {
+			// The pod may still be in the ipset, so its removal is attempted anyway
+			log.Warnf("Failed to check if pod %s/%s is in ipset, removing it anyway: %v", pod.Namespace, pod.Name, err)
+			inIpset = true
+		}
+		if !inIpset {
+			log.Infof("Pod '%s/%s' (%s) is not in ipset", pod.Name, pod.Namespace, string(pod.UID))
+			continue
+		}
 		log.Infof("Removing pod '%s' (%s) from ipset", pod.Name, string(pod.UID))
-		err := Ipset.DeleteIP(net.ParseIP(pod.Status.PodIP).To4())
+		err = Ipset.DeleteIP(net.ParseIP(ip).To4())
+		audit.Record(ctx, audit.KindIpset, fmt.Sprintf("delete %s from %s", ip, Ipset.Name), err)
 		if err != nil {
 			log.Errorf("Failed to delete pod %s from ipset list: %v", pod.Name, err)
 		}
-	} else {
-		log.Infof("Pod '%s/%s' (%s) is not in ipset", pod.Name, pod.Namespace, string(pod.UID))
 	}
-	rte, err := buildRouteFromPod(pod, "")
-	if err != nil {
-		log.Errorf("Failed to build route for pod %s: %v", pod.Name, err)
-	}
-	if RouteExists(rte) {
-		log.Infof("Removing route: %+v", rte)
-		// @TODO Try and figure out why buildRouteFromPod doesn't return a good route that we can
-		// use this:
-		// err = netlink.RouteDel(rte)
-		err = execute("ip", append([]string{"route", "del"}, rte...)...)
+	ipsetMu.Unlock()
+	delPodRoute(ctx, pod)
+}
+
+// delPodRoute removes the routes to the addresses of the pod, if any.
+func delPodRoute(ctx context.Context, pod *corev1.Pod) {
+	ips, _ := iptablesPodIPs(pod, "")
+	for _, ip := range ips {
+		rte, err := buildRouteFromPod(pod, ip)
 		if err != nil {
-			log.Warnf("Failed to delete route (%s) for pod %s: %v", rte, pod.Name, err)
+			log.Errorf("Failed to build route for pod %s: %v", pod.Name, err)
+			continue
+		}
+		if RouteExists(ctx, rte) {
+			log.Infof("Removing route: %+v", rte)
+			// @TODO Try and figure out why buildRouteFromPod doesn't return a good route that we can
+			// use this:
+			// err = netlink.RouteDel(rte)
+			err = executeContext(ctx, "ip", append([]string{"route", "del"}, rte...)...)
+			if err != nil {
+				log.Warnf("Failed to delete route (%s) for pod %s: %v", rte, pod.Name, err)
+			}
 		}
-	}
-
-	if err := AnnotateUnenrollPod(client, pod); err != nil {
-		log.Errorf("failed to annotate pod unenrollment: %v", err)
 	}
 }
 
 // GetHostIPByRoute get the automatically chosen host ip to the Pod's CIDR
-func GetHostIPByRoute(pods kclient.Client[*corev1.Pod]) (string, error) {
-	// We assume per node POD's CIDR is the same block, so the route to the POD
-	// from host should be "same". Otherwise, there may multiple host IPs will be
-	// used as source to dial to PODs.
-	for _, pod := range pods.List(metav1.NamespaceAll, ztunnelLabels) {
-		targetIP := pod.Status.PodIP
-		if hostIP := getOutboundIP(targetIP); hostIP != nil {
-			return hostIP.String(), nil
-		}
+func GetHostIPByRoute(ztunnel *corev1.Pod) (string, error) {
+	// We assume per node POD's CIDR is the same block, so the route to the ztunnel
+	// pod from host is the route to any POD of the node.
+	if hostIP := getOutboundIP(ztunnel.Status.PodIP); hostIP != nil {
+		return hostIP.String(), nil
 	}
 	return "", fmt.Errorf("failed to get outbound IP to Pods")
 }
@@ -261,38 +355,62 @@ func GetHostIP(kubeClient kubernetes.Interface) (string, error) {
 	return "", nil
 }
 
-func (s *Server) AddPodToMesh(pod *corev1.Pod) {
-	switch s.redirectMode {
-	case IptablesMode:
-		AddPodToMesh(s.kubeClient.Kube(), pod, "")
-	case EbpfMode:
-		if err := s.updatePodEbpfOnNode(pod); err != nil {
-			log.Errorf("failed to update POD ebpf: %v", err)
-		}
-		if err := AnnotateEnrolledPod(s.kubeClient.Kube(), pod); err != nil {
-			log.Errorf("failed to annotate pod enrollment: %v", err)
+// AddPodToMesh enrolls the pod, after notifying the enrollment hook. An error is returned if the hook
+// fails and its failure policy does not allow enrolling the pod regardless, or if the pod could not be redirected.
+func (s *Server) AddPodToMesh(ctx context.Context, pod *corev1.Pod) (err error) {
+	ctx = audit.WithPod(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+	defer func() {
+		if err != nil {
+			s.reportEnrollmentFailure(ctx, pod, err)
 		}
+	}()
+	if err := s.enrollmentHook.notify(ctx, EnrollmentEventAdd, pod); err != nil {
+		return err
+	}
+	policies := s.checkWorkloadSecurityContext(pod)
+	if err := recordRulesProgrammed(ruleTypeAddPod, s.redirector.AddPod(ctx, pod)); err != nil {
+		return withSecurityPolicies(err, policies)
 	}
+	s.desiredState.setPod(pod, s.programmedPodArtifacts(pod))
+	if err := AnnotateEnrolledPod(ctx, s.kubeClient.Kube(), pod); err != nil {
+		log.Errorf("failed to annotate pod enrollment: %v", err)
+	}
+	s.flushPodConntrack(pod)
+	return nil
 }
 
-func (s *Server) DelPodFromMesh(pod *corev1.Pod) {
-	switch s.redirectMode {
-	case IptablesMode:
-		DelPodFromMesh(s.kubeClient.Kube(), pod)
-	case EbpfMode:
-		if pod.Spec.HostNetwork {
-			log.Debugf("pod(%s/%s) is using host network, skip it", pod.Namespace, pod.Name)
-			return
-		}
-		if err := s.delPodEbpfOnNode(pod.Status.PodIP); err != nil {
-			log.Errorf("failed to del POD ebpf: %v", err)
-		}
-		if err := AnnotateUnenrollPod(s.kubeClient.Kube(), pod); err != nil {
-			log.Errorf("failed to annotate pod unenrollment: %v", err)
+// DelPodFromMesh removes the pod from the mesh, then notifies the enrollment hook.
+func (s *Server) DelPodFromMesh(ctx context.Context, pod *corev1.Pod) (err error) {
+	ctx = audit.WithPod(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+	defer func() {
+		if err != nil {
+			recordEnrollmentFailure(ruleTypeDelPod, err)
 		}
+	}()
+	if s.redirectMode == EbpfMode && pod.Spec.HostNetwork {
+		// The eBPF programs are attached to the interfaces of the pods, so pods using the host network are never
+		// enrolled and have nothing to remove
+		log.Debugf("pod(%s/%s) is using host network, skip it", pod.Namespace, pod.Name)
+		return nil
 	}
+	if err := recordRulesProgrammed(ruleTypeDelPod, s.redirector.DelPod(ctx, pod)); err != nil {
+		return err
+	}
+	s.desiredState.deletePod(pod.UID)
+	if err := AnnotateUnenrollPod(ctx, s.kubeClient.Kube(), pod); err != nil {
+		log.Errorf("failed to annotate pod unenrollment: %v", err)
+	}
+	s.flushPodConntrack(pod)
+	return s.enrollmentHook.notify(ctx, EnrollmentEventDelete, pod)
+}
+
+// podEnrolled returns whether the pod was redirected to ztunnel, by this node agent or, before it restarted, by a
+// previous one, which annotated the pod.
+func (s *Server) podEnrolled(pod *corev1.Pod) bool {
+	return s.desiredState.hasPod(pod.UID) ||
+		pod.Annotations[pconstants.AmbientRedirection] == pconstants.AmbientRedirectionEnabled
 }
 
 func SetProc(path string, value string) error {
-	return os.WriteFile(path, []byte(value), 0o644)
+	return audit.RecordNode(audit.KindSysctl, fmt.Sprintf("write %s to %s", value, path), os.WriteFile(path, []byte(value), 0o644))
 }
@@ -30,40 +30,117 @@ import (
 	"github.com/vishvananda/netlink"
 	"golang.org/x/sys/unix"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 
+	"istio.io/istio/cni/pkg/ambient/audit"
 	"istio.io/istio/cni/pkg/ambient/constants"
 	ebpf "istio.io/istio/cni/pkg/ebpf/server"
+	"istio.io/istio/cni/pkg/features"
+	"istio.io/istio/cni/pkg/util"
+	"istio.io/istio/pkg/util/sets"
 )
 
 func IsPodInIpset(pod *corev1.Pod) bool {
-	ipset, err := Ipset.List()
+	in, err := podInIpset(pod)
 	if err != nil {
 		log.Errorf("Failed to list ipset entries: %v", err)
 		return false
 	}
+	return in
+}
+
+// podInIpset returns whether the pod is in the ipset, or an error if the ipset could not be listed.
+func podInIpset(pod *corev1.Pod) (bool, error) {
+	ipset, err := Ipset.List()
+	if err != nil {
+		return false, err
+	}
 
 	// Since not all kernels support comments in ipset, we should also try and
 	// match against the IP
 	for _, ip := range ipset {
 		if ip.Comment == string(pod.UID) {
-			return true
+			return true, nil
 		}
 		if ip.IP.String() == pod.Status.PodIP {
-			return true
+			return true, nil
 		}
 	}
 
+	return false, nil
+}
+
+// ipInIpset returns whether the address is in the ipset.
+func ipInIpset(ip string) bool {
+	entries, err := Ipset.List()
+	if err != nil {
+		log.Errorf("Failed to list ipset entries: %v", err)
+		return false
+	}
+	for _, e := range entries {
+		if e.IP.String() == ip {
+			return true
+		}
+	}
 	return false
 }
 
-func buildEbpfArgsByIP(ip string, isZtunnel, isRemove bool) (*ebpf.RedirectArgs, error) {
-	ipAddr, err := netip.ParseAddr(ip)
+// podIPInIpset returns whether the address of the pod is in the ipset, or an error if the ipset could not be listed.
+// Entries are matched by the UID of the pod in their comment, if the kernel supports comments, only if the pod has
+// a single address.
+func podIPInIpset(pod *corev1.Pod, ip string) (bool, error) {
+	entries, err := Ipset.List()
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse ip(%s): %v", ip, err)
+		return false, err
 	}
-	veth, err := getVethWithDestinationOf(ip)
+	single := len(podIPs(pod)) <= 1
+	for _, e := range entries {
+		if e.IP.String() == ip || (single && e.Comment == string(pod.UID)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// delPodsFromIpset removes the pods from the ipset, listing its entries only once for all of them.
+func delPodsFromIpset(pods []*corev1.Pod) {
+	ipsetMu.Lock()
+	defer ipsetMu.Unlock()
+	entries, err := Ipset.List()
+	if err != nil {
+		log.Errorf("Failed to list ipset entries: %v", err)
+		return
+	}
+	for _, pod := range pods {
+		ips := sets.New(podIPs(pod)...)
+		for _, e := range entries {
+			if e.Comment != string(pod.UID) && !ips.Contains(e.IP.String()) {
+				continue
+			}
+			log.Infof("Removing pod '%s/%s' (%s) from ipset", pod.Namespace, pod.Name, string(pod.UID))
+			err := Ipset.DeleteIP(e.IP)
+			audit.RecordPod(types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}, audit.KindIpset,
+				fmt.Sprintf("delete %s from %s", e.IP, Ipset.Name), err)
+			if err != nil {
+				log.Errorf("Failed to delete pod %s from ipset list: %v", pod.Name, err)
+			}
+		}
+	}
+}
+
+// buildEbpfArgsByIP builds the redirection args for a pod with the given IPs. The veth is looked up by the
+// primary IP.
+func buildEbpfArgsByIP(ips []string, isZtunnel, isRemove bool) (*ebpf.RedirectArgs, error) {
+	if len(ips) == 0 {
+		return nil, ErrNoPodIP
+	}
+	ipAddrs, err := parseAddrs(ips)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get device: %v", err)
+		return nil, err
+	}
+	veth, err := getVethWithDestinationOf(ips[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to get device: %w", err)
 	}
 	peerIndex, err := getPeerIndex(veth)
 	if err != nil {
@@ -72,7 +149,7 @@ func buildEbpfArgsByIP(ip string, isZtunnel, isRemove bool) (*ebpf.RedirectArgs,
 
 	peerNs, err := getNsNameFromNsID(veth.Attrs().NetNsID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ns name: %v", err)
+		return nil, fmt.Errorf("failed to get ns name: %w", err)
 	}
 
 	mac, err := getMacFromNsIdx(peerNs, peerIndex)
@@ -81,7 +158,7 @@ func buildEbpfArgsByIP(ip string, isZtunnel, isRemove bool) (*ebpf.RedirectArgs,
 	}
 
 	return &ebpf.RedirectArgs{
-		IPAddrs:   []netip.Addr{ipAddr},
+		IPAddrs:   ipAddrs,
 		MacAddr:   mac,
 		Ifindex:   veth.Attrs().Index,
 		PeerIndex: peerIndex,
@@ -93,10 +170,13 @@ func buildEbpfArgsByIP(ip string, isZtunnel, isRemove bool) (*ebpf.RedirectArgs,
 
 func (s *Server) updateNodeProxyEBPF(pod *corev1.Pod, captureDNS bool) error {
 	if s.ebpfServer == nil {
-		return fmt.Errorf("uninitialized ebpf server")
+		return fmt.Errorf("%w: uninitialized ebpf server", ErrEbpfProgram)
 	}
 
 	ip := pod.Status.PodIP
+	if ip == "" {
+		return fmt.Errorf("%w: ztunnel has no IP", ErrZtunnelNotReady)
+	}
 
 	veth, err := getVethWithDestinationOf(ip)
 	if err != nil {
@@ -112,18 +192,19 @@ func (s *Server) updateNodeProxyEBPF(pod *corev1.Pod, captureDNS bool) error {
 		log.Warnf("failed to disable procfs rp_filter for device %s: %v", veth.Attrs().Name, err)
 	}
 
-	args, err := buildEbpfArgsByIP(ip, true, false)
+	args, err := buildEbpfArgsByIP(podIPs(pod), true, false)
 	if err != nil {
 		return err
 	}
 	args.CaptureDNS = captureDNS
+	args.Pod = types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
 	log.Debugf("update ztunnel ebpf args: %+v", args)
 
 	// Now that we have the ip, the veth, and the ztunnel netns,
 	// two things need to happen:
 	// 1. We need to interact with the kernel to jump into the ztunnel net namespace
 	// and create some local rules within that net namespace
-	err = s.CreateEBPFRulesWithinNodeProxyNS(peerIndex, ip, args.PeerNs)
+	err = s.CreateEBPFRulesWithinNodeProxyNS(peerIndex, podIPs(pod), args.PeerNs)
 	if err != nil {
 		return fmt.Errorf("failed to configure ztunnel pod rules: %v", err)
 	}
@@ -134,9 +215,27 @@ func (s *Server) updateNodeProxyEBPF(pod *corev1.Pod, captureDNS bool) error {
 	return nil
 }
 
+// updateNodeProxyEBPFMaps updates the ztunnel the eBPF redirection redirects to, without configuring the rules
+// within the ztunnel network namespace, for the shadow eBPF redirection. The rules are configured by the iptables
+// redirection.
+func (s *Server) updateNodeProxyEBPFMaps(pod *corev1.Pod, captureDNS bool) error {
+	if s.ebpfServer == nil {
+		return fmt.Errorf("%w: uninitialized ebpf server", ErrEbpfProgram)
+	}
+	args, err := buildEbpfArgsByIP(podIPs(pod), true, false)
+	if err != nil {
+		return err
+	}
+	args.CaptureDNS = captureDNS
+	args.Pod = types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
+	log.Debugf("update shadow ztunnel ebpf args: %+v", args)
+	s.ebpfServer.AcceptRequest(args)
+	return nil
+}
+
 func (s *Server) delZtunnelEbpfOnNode() error {
 	if s.ebpfServer == nil {
-		return fmt.Errorf("uninitialized ebpf server")
+		return fmt.Errorf("%w: uninitialized ebpf server", ErrEbpfProgram)
 	}
 
 	args := &ebpf.RedirectArgs{
@@ -151,7 +250,7 @@ func (s *Server) delZtunnelEbpfOnNode() error {
 
 func (s *Server) updatePodEbpfOnNode(pod *corev1.Pod) error {
 	if s.ebpfServer == nil {
-		return fmt.Errorf("uninitialized ebpf server")
+		return fmt.Errorf("%w: uninitialized ebpf server", ErrEbpfProgram)
 	}
 
 	ip := pod.Status.PodIP
@@ -160,66 +259,134 @@ func (s *Server) updatePodEbpfOnNode(pod *corev1.Pod) error {
 		return nil
 	}
 
-	args, err := buildEbpfArgsByIP(ip, false, false)
+	args, err := buildEbpfArgsByIP(podIPs(pod), false, false)
 	if err != nil {
 		return err
 	}
 
+	args.Pod = types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
 	log.Debugf("update POD ebpf args: %+v", args)
 	s.ebpfServer.AcceptRequest(args)
 	return nil
 }
 
-func (s *Server) delPodEbpfOnNode(ip string) error {
+func (s *Server) delPodEbpfOnNode(pod *corev1.Pod) error {
 	if s.ebpfServer == nil {
-		return fmt.Errorf("uninitialized ebpf server")
+		return fmt.Errorf("%w: uninitialized ebpf server", ErrEbpfProgram)
 	}
 
-	if ip == "" {
+	ips := podIPs(pod)
+
+	if len(ips) == 0 {
 		log.Debugf("nothing could be performed to delete ebpf for empty ip")
 		return nil
 	}
-	ipAddr, err := netip.ParseAddr(ip)
+	ipAddrs, err := parseAddrs(ips)
 	if err != nil {
-		return fmt.Errorf("failed to parse ip(%s): %v", ip, err)
+		return err
 	}
 
 	ifIndex := 0
 
-	if veth, err := getVethWithDestinationOf(ip); err != nil {
+	if veth, err := getVethWithDestinationOf(ips[0]); err != nil {
 		log.Debugf("failed to get device: %v", err)
 	} else {
 		ifIndex = veth.Attrs().Index
 	}
 
 	args := &ebpf.RedirectArgs{
-		IPAddrs:   []netip.Addr{ipAddr},
+		IPAddrs:   ipAddrs,
 		Ifindex:   ifIndex,
 		IsZtunnel: false,
 		Remove:    true,
+		Pod:       types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name},
 	}
 	log.Debugf("del POD ebpf args: %+v", args)
 	s.ebpfServer.AcceptRequest(args)
 	return nil
 }
 
+func parseAddrs(ips []string) ([]netip.Addr, error) {
+	res := make([]netip.Addr, 0, len(ips))
+	for _, ip := range ips {
+		ipAddr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ip(%s): %v", ip, err)
+		}
+		// IPv4-mapped addresses are redirected as IPv4.
+		res = append(res, ipAddr.Unmap())
+	}
+	return res, nil
+}
+
 func getLinkWithDestinationOf(ip string) (netlink.Link, error) {
+	f := familyOf(ip)
 	routes, err := netlink.RouteListFiltered(
-		netlink.FAMILY_V4,
-		&netlink.Route{Dst: &net.IPNet{IP: net.ParseIP(ip), Mask: net.CIDRMask(32, 32)}},
+		f.family,
+		&netlink.Route{Dst: &net.IPNet{IP: net.ParseIP(ip), Mask: net.CIDRMask(f.bits, f.bits)}},
 		netlink.RT_FILTER_DST)
 	if err != nil {
 		return nil, err
 	}
 
 	if len(routes) == 0 {
-		return nil, fmt.Errorf("no routes found for %s", ip)
+		// Some CNIs, such as OVN-Kubernetes, attach the pods to a bridge rather than routing each of them on the node
+		return getVethWithPeerAddress(ip)
 	}
 
 	linkIndex := routes[0].LinkIndex
 	return netlink.LinkByIndex(linkIndex)
 }
 
+// getVethWithPeerAddress finds the veth on the node whose peer, in the pod network namespace, has the given IP.
+func getVethWithPeerAddress(ip string) (netlink.Link, error) {
+	addr := net.ParseIP(ip)
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		veth, ok := link.(*netlink.Veth)
+		if !ok || veth.Attrs().NetNsID < 0 {
+			continue
+		}
+		peerIndex, err := netlink.VethPeerIndex(veth)
+		if err != nil {
+			continue
+		}
+		ns, err := getNsNameFromNsID(veth.Attrs().NetNsID)
+		if err != nil {
+			continue
+		}
+		found := false
+		err = netns.WithNetNSPath(netnsPath(ns), func(netns.NetNS) error {
+			peer, err := netlink.LinkByIndex(peerIndex)
+			if err != nil {
+				return err
+			}
+			addrs, err := netlink.AddrList(peer, familyOf(ip).family)
+			if err != nil {
+				return err
+			}
+			for _, a := range addrs {
+				if a.IP.Equal(addr) {
+					found = true
+					break
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			log.Debugf("failed to get the addresses of the peer of %s: %v", veth.Attrs().Name, err)
+			continue
+		}
+		if found {
+			return veth, nil
+		}
+	}
+	return nil, fmt.Errorf("%w for %s", ErrVethNotFound, ip)
+}
+
 func getVethWithDestinationOf(ip string) (*netlink.Veth, error) {
 	link, err := getLinkWithDestinationOf(ip)
 	if err != nil {
@@ -227,7 +394,7 @@ func getVethWithDestinationOf(ip string) (*netlink.Veth, error) {
 	}
 	veth, ok := link.(*netlink.Veth)
 	if !ok {
-		return nil, errors.New("not veth implemented CNI")
+		return nil, fmt.Errorf("%w: not veth implemented CNI", ErrVethNotFound)
 	}
 	return veth, nil
 }
@@ -244,8 +411,7 @@ func GetIndexAndPeerMac(podIfName, ns string) (int, net.HardwareAddr, error) {
 	var hostIfIndex int
 	var hwAddr net.HardwareAddr
 
-	ns = filepath.Base(ns)
-	err := netns.WithNetNSPath(fmt.Sprintf("/var/run/netns/%s", ns), func(netns.NetNS) error {
+	err := netns.WithNetNSPath(netnsPath(ns), func(netns.NetNS) error {
 		link, err := netlink.LinkByName(podIfName)
 		if err != nil {
 			return err
@@ -253,7 +419,7 @@ func GetIndexAndPeerMac(podIfName, ns string) (int, net.HardwareAddr, error) {
 
 		veth, ok := link.(*netlink.Veth)
 		if !ok {
-			return fmt.Errorf("not veth implemented CNI")
+			return fmt.Errorf("%w: not veth implemented CNI", ErrVethNotFound)
 		}
 
 		ifIndex, err := netlink.VethPeerIndex(veth)
@@ -266,7 +432,7 @@ func GetIndexAndPeerMac(podIfName, ns string) (int, net.HardwareAddr, error) {
 		return nil
 	})
 	if err != nil {
-		return 0, nil, fmt.Errorf("failed to get info for if(%s) in ns(%s): %v", podIfName, ns, err)
+		return 0, nil, util.WithSELinuxRemediation(fmt.Errorf("failed to get info for if(%s) in ns(%s): %v", podIfName, ns, err))
 	}
 
 	return hostIfIndex, hwAddr, nil
@@ -274,7 +440,7 @@ func GetIndexAndPeerMac(podIfName, ns string) (int, net.HardwareAddr, error) {
 
 func getMacFromNsIdx(ns string, ifIndex int) (net.HardwareAddr, error) {
 	var hwAddr net.HardwareAddr
-	err := netns.WithNetNSPath(fmt.Sprintf("/var/run/netns/%s", ns), func(netns.NetNS) error {
+	err := netns.WithNetNSPath(netnsPath(ns), func(netns.NetNS) error {
 		link, err := netlink.LinkByIndex(ifIndex)
 		if err != nil {
 			return fmt.Errorf("failed to get link(%d) in ns(%s): %v", ifIndex, ns, err)
@@ -283,15 +449,28 @@ func getMacFromNsIdx(ns string, ifIndex int) (net.HardwareAddr, error) {
 		return nil
 	})
 	if err != nil {
-		return nil, err
+		return nil, util.WithSELinuxRemediation(err)
 	}
 	return hwAddr, nil
 }
 
+// netnsDir is the directory where the container runtime bind mounts the network namespaces of the pods.
+const netnsDir = "/var/run/netns"
+
+// netnsPath returns the path of a network namespace given by its name in the netns directory, or by the path passed
+// by the container runtime to the CNI plugin. The paths are used as is, as they may be out of the netns directory, such
+// as the /proc/<pid>/ns/net paths of some runtimes or the runtime directory of rootless runtimes.
+func netnsPath(ns string) string {
+	if filepath.IsAbs(ns) {
+		return ns
+	}
+	return filepath.Join(netnsDir, ns)
+}
+
 func getNsNameFromNsID(nsid int) (string, error) {
 	foundNs := errors.New("nsid found, stop iterating")
 	nsName := ""
-	err := filepath.WalkDir("/var/run/netns", func(p string, d fs.DirEntry, err error) error {
+	err := filepath.WalkDir(netnsDir, func(p string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -319,7 +498,7 @@ func getNsNameFromNsID(nsid int) (string, error) {
 	if err == foundNs {
 		return nsName, nil
 	}
-	return "", fmt.Errorf("failed to get namespace for %d", nsid)
+	return "", fmt.Errorf("%w: no namespace with id %d", ErrNetnsNotFound, nsid)
 }
 
 func getPeerIndex(veth *netlink.Veth) (int, error) {
@@ -327,10 +506,16 @@ func getPeerIndex(veth *netlink.Veth) (int, error) {
 }
 
 // CreateRulesOnNode initializes the routing, firewall and ipset rules on the node.
-func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS bool) error {
+// Routes and rules are created for every family ztunnel has an address in; the primary address is used
+// as the tunnel endpoint.
+func (s *Server) CreateRulesOnNode(ztunnelVeth string, ztunnelIPs []string, captureDNS bool) error {
 	var err error
 
-	log.Debugf("CreateRulesOnNode: ztunnelVeth=%s, ztunnelIP=%s", ztunnelVeth, ztunnelIP)
+	log.Debugf("CreateRulesOnNode: ztunnelVeth=%s, ztunnelIPs=%v", ztunnelVeth, ztunnelIPs)
+	if len(ztunnelIPs) == 0 {
+		return fmt.Errorf("%w: ztunnel has no IP", ErrZtunnelNotReady)
+	}
+	ztunnelIP := ztunnelIPs[0]
 
 	// Check if chain exists, if it exists flush.. otherwise initialize
 	err = execute(s.IptablesCmd(), "-t", "mangle", "-C", "OUTPUT", "-j", constants.ChainZTunnelOutput)
@@ -348,7 +533,13 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 	// Create ipset of pod members.
 	log.Debug("Creating ipset")
 	err = Ipset.CreateSet()
-	if err != nil && !errors.Is(err, os.ErrExist) {
+	if errors.Is(err, os.ErrExist) {
+		// The ipset is left over by a previous node agent
+		err = nil
+	} else {
+		err = audit.RecordNode(audit.KindIpset, "create "+Ipset.Name, err)
+	}
+	if err != nil {
 		return fmt.Errorf("error creating ipset: %v", err)
 	}
 
@@ -359,7 +550,7 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.ChainZTunnelPrerouting,
 			"-i", constants.InboundTun,
 			"-j", "MARK",
-			"--set-mark", constants.SkipMark,
+			"--set-mark", Routing.fwmark(constants.SkipMask),
 		),
 		newIptableRule(
 			constants.TableMangle,
@@ -372,7 +563,7 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.ChainZTunnelPrerouting,
 			"-i", constants.OutboundTun,
 			"-j", "MARK",
-			"--set-mark", constants.SkipMark,
+			"--set-mark", Routing.fwmark(constants.SkipMask),
 		),
 		newIptableRule(constants.TableMangle,
 			constants.ChainZTunnelPrerouting,
@@ -386,11 +577,11 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.TableMangle,
 			constants.ChainZTunnelForward,
 			"-m", "mark",
-			"--mark", constants.ConnSkipMark,
+			"--mark", Routing.fwmark(constants.ConnSkipMask),
 			"-j", "CONNMARK",
 			"--save-mark",
-			"--nfmask", constants.ConnSkipMask,
-			"--ctmask", constants.ConnSkipMask,
+			"--nfmask", Routing.fwmask(constants.ConnSkipMask),
+			"--ctmask", Routing.fwmask(constants.ConnSkipMask),
 		),
 		// Input chain might be needed for things in host namespace that are skipped.
 		// Place the mark here after routing was done, not sure if conn-tracking will figure
@@ -399,11 +590,11 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.TableMangle,
 			constants.ChainZTunnelInput,
 			"-m", "mark",
-			"--mark", constants.ConnSkipMark,
+			"--mark", Routing.fwmark(constants.ConnSkipMask),
 			"-j", "CONNMARK",
 			"--save-mark",
-			"--nfmask", constants.ConnSkipMask,
-			"--ctmask", constants.ConnSkipMask,
+			"--nfmask", Routing.fwmask(constants.ConnSkipMask),
+			"--ctmask", Routing.fwmask(constants.ConnSkipMask),
 		),
 
 		// For things with the proxy mark, we need different routing just on returning packets
@@ -412,28 +603,28 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.TableMangle,
 			constants.ChainZTunnelForward,
 			"-m", "mark",
-			"--mark", constants.ProxyMark,
+			"--mark", Routing.fwmark(constants.ProxyMask),
 			"-j", "CONNMARK",
 			"--save-mark",
-			"--nfmask", constants.ProxyMask,
-			"--ctmask", constants.ProxyMask,
+			"--nfmask", Routing.fwmask(constants.ProxyMask),
+			"--ctmask", Routing.fwmask(constants.ProxyMask),
 		),
 		newIptableRule(
 			constants.TableMangle,
 			constants.ChainZTunnelInput,
 			"-m", "mark",
-			"--mark", constants.ProxyMark,
+			"--mark", Routing.fwmark(constants.ProxyMask),
 			"-j", "CONNMARK",
 			"--save-mark",
-			"--nfmask", constants.ProxyMask,
-			"--ctmask", constants.ProxyMask,
+			"--nfmask", Routing.fwmask(constants.ProxyMask),
+			"--ctmask", Routing.fwmask(constants.ProxyMask),
 		),
 		newIptableRule(
 			constants.TableMangle,
 			constants.ChainZTunnelOutput,
 			"--source", HostIP,
 			"-j", "MARK",
-			"--set-mark", constants.ConnSkipMask,
+			"--set-mark", Routing.fwmask(constants.ConnSkipMask),
 		),
 
 		// If we have an outbound mark, we don't need kube-proxy to do anything,
@@ -442,14 +633,14 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.TableNat,
 			constants.ChainZTunnelPrerouting,
 			"-m", "mark",
-			"--mark", constants.OutboundMark,
+			"--mark", Routing.fwmark(constants.OutboundMask),
 			"-j", "ACCEPT",
 		),
 		newIptableRule(
 			constants.TableNat,
 			constants.ChainZTunnelPostrouting,
 			"-m", "mark",
-			"--mark", constants.OutboundMark,
+			"--mark", Routing.fwmark(constants.OutboundMask),
 			"-j", "ACCEPT",
 		),
 	}
@@ -464,11 +655,31 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 				"--match-set", Ipset.Name, "src",
 				"--dport", "53",
 				"-j", "DNAT",
-				"--to", fmt.Sprintf("%s:%d", ztunnelIP, constants.DNSCapturePort),
+				"--to", s.dnsCapture.target(ztunnelIP),
 			),
 		)
 	}
 
+	udpSkipRule := newIptableRule(
+		constants.TableMangle,
+		constants.ChainZTunnelPrerouting,
+		"-p", "udp",
+		"-j", "MARK",
+		"--set-mark", Routing.fwmark(constants.ConnSkipMask),
+	)
+	captureUDP := features.UDPCapture.Enabled()
+	if captureUDP {
+		// Only DNS skips ztunnel, the rest of the UDP traffic is marked as outbound below
+		udpSkipRule = newIptableRule(
+			constants.TableMangle,
+			constants.ChainZTunnelPrerouting,
+			"-p", "udp",
+			"--dport", "53",
+			"-j", "MARK",
+			"--set-mark", Routing.fwmark(constants.ConnSkipMask),
+		)
+	}
+
 	appendRules2 := []*iptablesRule{
 		// Don't set anything on the tunnel (geneve port is 6081), as the tunnel copies
 		// the mark to the un-tunneled packet.
@@ -487,15 +698,15 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.TableMangle,
 			constants.ChainZTunnelPrerouting,
 			"-m", "connmark",
-			"--mark", constants.ConnSkipMark,
+			"--mark", Routing.fwmark(constants.ConnSkipMask),
 			"-j", "MARK",
-			"--set-mark", constants.SkipMark,
+			"--set-mark", Routing.fwmark(constants.SkipMask),
 		),
 		newIptableRule(
 			constants.TableMangle,
 			constants.ChainZTunnelPrerouting,
 			"-m", "mark",
-			"--mark", constants.SkipMark,
+			"--mark", Routing.fwmark(constants.SkipMask),
 			"-j", "RETURN",
 		),
 
@@ -505,15 +716,15 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.ChainZTunnelPrerouting,
 			"!", "-i", ztunnelVeth,
 			"-m", "connmark",
-			"--mark", constants.ProxyMark,
+			"--mark", Routing.fwmark(constants.ProxyMask),
 			"-j", "MARK",
-			"--set-mark", constants.ProxyRetMark,
+			"--set-mark", Routing.fwmark(constants.ProxyRetMask),
 		),
 		newIptableRule(
 			constants.TableMangle,
 			constants.ChainZTunnelPrerouting,
 			"-m", "mark",
-			"--mark", constants.ProxyRetMark,
+			"--mark", Routing.fwmark(constants.ProxyRetMask),
 			"-j", "RETURN",
 		),
 
@@ -526,13 +737,13 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			"-i", ztunnelVeth,
 			"!", "--source", ztunnelIP,
 			"-j", "MARK",
-			"--set-mark", constants.ProxyMark,
+			"--set-mark", Routing.fwmark(constants.ProxyMask),
 		),
 		newIptableRule(
 			constants.TableMangle,
 			constants.ChainZTunnelPrerouting,
 			"-m", "mark",
-			"--mark", constants.SkipMark,
+			"--mark", Routing.fwmark(constants.SkipMask),
 			"-j", "RETURN",
 		),
 
@@ -543,17 +754,11 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.ChainZTunnelPrerouting,
 			"-i", ztunnelVeth,
 			"-j", "MARK",
-			"--set-mark", constants.ConnSkipMark,
+			"--set-mark", Routing.fwmark(constants.ConnSkipMask),
 		),
 
 		// skip udp so DNS works. We can make this more granular.
-		newIptableRule(
-			constants.TableMangle,
-			constants.ChainZTunnelPrerouting,
-			"-p", "udp",
-			"-j", "MARK",
-			"--set-mark", constants.ConnSkipMark,
-		),
+		udpSkipRule,
 
 		// Skip things from host ip - these are usually kubectl probes
 		// skip anything with skip mark. This can be used to add features like port exclusions
@@ -561,10 +766,13 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			constants.TableMangle,
 			constants.ChainZTunnelPrerouting,
 			"-m", "mark",
-			"--mark", constants.SkipMark,
+			"--mark", Routing.fwmark(constants.SkipMask),
 			"-j", "RETURN",
 		),
-
+	}
+	// Traffic to the node which is not captured is sent to it before being marked
+	appendRules2 = append(appendRules2, buildNodeTrafficRules(s.nodeTraffic)...)
+	appendRules2 = append(appendRules2,
 		// Mark outbound connections to route them to the proxy using ip rules/route tables
 		// Per Yuval, interface_prefix can be left off this rule... but we should check this (hard to automate
 		// detection).
@@ -575,8 +783,19 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 			"-m", "set",
 			"--match-set", Ipset.Name, "src",
 			"-j", "MARK",
-			"--set-mark", constants.OutboundMark,
+			"--set-mark", Routing.fwmark(constants.OutboundMask),
 		),
+	)
+	if captureUDP {
+		appendRules2 = append(appendRules2, newIptableRule(
+			constants.TableMangle,
+			constants.ChainZTunnelPrerouting,
+			"-p", "udp",
+			"-m", "set",
+			"--match-set", Ipset.Name, "src",
+			"-j", "MARK",
+			"--set-mark", Routing.fwmark(constants.OutboundMask),
+		))
 	}
 
 	err = s.iptablesAppend(appendRules)
@@ -588,6 +807,9 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 	if err != nil {
 		log.Errorf("failed to append iptables rule: %v", err)
 	}
+	s.mu.Lock()
+	s.nodeRules = append(appendRules, appendRules2...)
+	s.mu.Unlock()
 
 	// Need to do some work in procfs
 	// @TODO: This likely needs to be cleaned up, there are a lot of martians in AWS
@@ -614,18 +836,16 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 		Remote: net.ParseIP(ztunnelIP),
 	}
 	log.Debugf("Building inbound tunnel: %+v", inbnd)
-	err = netlink.LinkAdd(inbnd)
+	err = audit.RecordNode(audit.KindLink, "add "+inbnd.Attrs().Name, netlink.LinkAdd(inbnd))
 	if err != nil {
 		log.Errorf("failed to add inbound tunnel: %v", err)
 	}
-	err = netlink.AddrAdd(inbnd, &netlink.Addr{
-		IPNet: &net.IPNet{
-			IP:   net.ParseIP(constants.InboundTunIP),
-			Mask: net.CIDRMask(constants.TunPrefix, 32),
-		},
-	})
-	if err != nil {
-		log.Errorf("failed to add inbound tunnel address: %v", err)
+	for _, f := range familiesOf(ztunnelIPs) {
+		addr := tunnelAddr(f, f.inboundTunIP)
+		err = audit.RecordNode(audit.KindAddr, fmt.Sprintf("add %v to %s", addr, inbnd.Attrs().Name), netlink.AddrAdd(inbnd, addr))
+		if err != nil {
+			log.Errorf("failed to add inbound tunnel address: %v", err)
+		}
 	}
 
 	outbnd := &netlink.Geneve{
@@ -636,25 +856,23 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 		Remote: net.ParseIP(ztunnelIP),
 	}
 	log.Debugf("Building outbound tunnel: %+v", outbnd)
-	err = netlink.LinkAdd(outbnd)
+	err = audit.RecordNode(audit.KindLink, "add "+outbnd.Attrs().Name, netlink.LinkAdd(outbnd))
 	if err != nil {
 		log.Errorf("failed to add outbound tunnel: %v", err)
 	}
-	err = netlink.AddrAdd(outbnd, &netlink.Addr{
-		IPNet: &net.IPNet{
-			IP:   net.ParseIP(constants.OutboundTunIP),
-			Mask: net.CIDRMask(constants.TunPrefix, 32),
-		},
-	})
-	if err != nil {
-		log.Errorf("failed to add outbound tunnel address: %v", err)
+	for _, f := range familiesOf(ztunnelIPs) {
+		addr := tunnelAddr(f, f.outboundTunIP)
+		err = audit.RecordNode(audit.KindAddr, fmt.Sprintf("add %v to %s", addr, outbnd.Attrs().Name), netlink.AddrAdd(outbnd, addr))
+		if err != nil {
+			log.Errorf("failed to add outbound tunnel address: %v", err)
+		}
 	}
 
-	err = netlink.LinkSetUp(inbnd)
+	err = audit.RecordNode(audit.KindLink, "set up "+inbnd.Attrs().Name, netlink.LinkSetUp(inbnd))
 	if err != nil {
 		log.Errorf("failed to set inbound tunnel up: %v", err)
 	}
-	err = netlink.LinkSetUp(outbnd)
+	err = audit.RecordNode(audit.KindLink, "set up "+outbnd.Attrs().Name, netlink.LinkSetUp(outbnd))
 	if err != nil {
 		log.Errorf("failed to set outbound tunnel up: %v", err)
 	}
@@ -687,82 +905,10 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 		}
 	}
 
-	routes := []*ExecList{
-		newExec("ip",
-			[]string{
-				"route", "add", "table", fmt.Sprint(constants.RouteTableOutbound), ztunnelIP,
-				"dev", ztunnelVeth, "scope", "link",
-			},
-		),
-		newExec("ip",
-			[]string{
-				"route", "add", "table", fmt.Sprint(constants.RouteTableOutbound), "0.0.0.0/0",
-				"via", constants.ZTunnelOutboundTunIP, "dev", constants.OutboundTun,
-			},
-		),
-		newExec("ip",
-			[]string{
-				"route", "add", "table", fmt.Sprint(constants.RouteTableProxy), ztunnelIP,
-				"dev", ztunnelVeth, "scope", "link",
-			},
-		),
-		newExec("ip",
-			[]string{
-				"route", "add", "table", fmt.Sprint(constants.RouteTableProxy), "0.0.0.0/0",
-				"via", ztunnelIP, "dev", ztunnelVeth, "onlink",
-			},
-		),
-		newExec("ip",
-			[]string{
-				"route", "add", "table", fmt.Sprint(constants.RouteTableInbound), ztunnelIP,
-				"dev", ztunnelVeth, "scope", "link",
-			},
-		),
-		// Everything with the skip mark goes directly to the main table
-		newExec("ip",
-			[]string{
-				"rule", "add", "priority", "100",
-				"fwmark", fmt.Sprint(constants.SkipMark),
-				"goto", "32766",
-			},
-		),
-		// Everything with the outbound mark goes to the tunnel out device
-		// using the outbound route table
-		newExec("ip",
-			[]string{
-				"rule", "add", "priority", "101",
-				"fwmark", fmt.Sprint(constants.OutboundMark),
-				"lookup", fmt.Sprint(constants.RouteTableOutbound),
-			},
-		),
-		// Things with the proxy return mark go directly to the proxy veth using the proxy
-		// route table (useful for original src)
-		newExec("ip",
-			[]string{
-				"rule", "add", "priority", "102",
-				"fwmark", fmt.Sprint(constants.ProxyRetMark),
-				"lookup", fmt.Sprint(constants.RouteTableProxy),
-			},
-		),
-		// Send all traffic to the inbound table. This table has routes only to pods in the mesh.
-		// It does not have a catch-all route, so if a route is missing, the search will continue
-		// allowing us to override routing just for member pods.
-		newExec("ip",
-			[]string{
-				"rule", "add", "priority", "103",
-				"table", fmt.Sprint(constants.RouteTableInbound),
-			},
-		),
-	}
-
-	for _, route := range routes {
-		err = execute(route.Cmd, route.Args...)
-		if err != nil {
-			log.Errorf(fmt.Errorf("failed to add route (%+v): %v", route, err))
-		}
-	}
+	routes := buildNodeRoutes(ztunnelVeth, ztunnelIPs)
+	routes = append(routes, buildNodeTrafficRoutes(s.nodeTraffic, familiesOf(ztunnelIPs))...)
 
-	return nil
+	return runNodeRoutes(routes, execute, executeOutput)
 }
 
 // CreateEBPFRulesInNodeProxyNS initializes the routes and iptable rules that need to exist WITHIN
@@ -771,10 +917,14 @@ func (s *Server) CreateRulesOnNode(ztunnelVeth, ztunnelIP string, captureDNS boo
 //
 // There is no cleanup required for things we do within the netns, as when the netns is destroyed on pod delete,
 // everything within the netns goes away.
-func (s *Server) CreateEBPFRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztunnelNetNS string) error {
+func (s *Server) CreateEBPFRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIPs []string, ztunnelNetNS string) error {
 	ns := filepath.Base(ztunnelNetNS)
-	log.Debugf("CreateEBPFRulesWithinNodeProxyNS: proxyNsVethIdx=%d, ztunnelIP=%s, from within netns=%s", proxyNsVethIdx, ztunnelIP, ztunnelNetNS)
-	err := netns.WithNetNSPath(fmt.Sprintf("/var/run/netns/%s", ns), func(netns.NetNS) error {
+	log.Debugf("CreateEBPFRulesWithinNodeProxyNS: proxyNsVethIdx=%d, ztunnelIPs=%v, from within netns=%s", proxyNsVethIdx, ztunnelIPs, ztunnelNetNS)
+	if len(ztunnelIPs) == 0 {
+		return fmt.Errorf("%w: ztunnel has no IP", ErrZtunnelNotReady)
+	}
+	families := familiesOf(ztunnelIPs)
+	err := netns.WithNetNSPath(netnsPath(ns), func(netns.NetNS) error {
 		// Make sure we flush table 100 before continuing - it should be empty in a new namespace
 		// but better to ensure that.
 		if err := routeFlushTable(constants.RouteTableInbound); err != nil {
@@ -785,7 +935,7 @@ func (s *Server) CreateEBPFRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP,
 		deleteIPRules([]string{strconv.Itoa(constants.TProxyMarkPriority), strconv.Itoa(constants.OrgSrcPriority)}, false)
 
 		// Set up tproxy marks
-		err := addTProxyMarkRule()
+		err := addTProxyMarkRule(families)
 		if err != nil {
 			return fmt.Errorf("failed to add TPROXY mark rules: %v", err)
 		}
@@ -797,21 +947,20 @@ func (s *Server) CreateEBPFRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP,
 		// In routing table ${INBOUND_TPROXY_ROUTE_TABLE}, create a single default rule to route all traffic to
 		// the loopback interface.
 		// Equiv: "ip route add local 0.0.0.0/0 dev lo table 100"
-		// TODO IPv6, append "0::0/0"
-		cidrs := []string{"0.0.0.0/0"}
-		for _, fullCIDR := range cidrs {
-			_, dst, err := net.ParseCIDR(fullCIDR)
+		for _, f := range families {
+			_, dst, err := net.ParseCIDR(f.defaultRoute)
 			if err != nil {
 				return fmt.Errorf("parse CIDR: %v", err)
 			}
 
-			if err := netlink.RouteAdd(&netlink.Route{
+			route := &netlink.Route{
 				Dst:       dst,
 				Scope:     netlink.SCOPE_HOST,
 				Type:      unix.RTN_LOCAL,
 				Table:     constants.RouteTableInbound,
 				LinkIndex: loopbackLink.Attrs().Index,
-			}); err != nil {
+			}
+			if err := audit.RecordNode(audit.KindRoute, "add "+route.String()+" in the ztunnel netns", netlink.RouteAdd(route)); err != nil {
 				// TODO clear this route every time
 				// Would not expect this if we have properly cleared routes
 				return fmt.Errorf("failed to add route: %v", err)
@@ -832,17 +981,17 @@ func (s *Server) CreateEBPFRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP,
 			return nil
 		}
 		log.Infof("Current kernel doesn't support tproxy in eBPF, fall back to iptables tproxy rules")
-		return s.createTProxyRulesForLegacyEBPF(ztunnelIP, vethLink.Attrs().Name)
+		return s.createTProxyRulesForLegacyEBPF(families, ztunnelIPs[0], vethLink.Attrs().Name)
 	})
 	if err != nil {
-		return fmt.Errorf("failed to configure ztunnel ebpf from within ns(%s): %v", ns, err)
+		return util.WithSELinuxRemediation(fmt.Errorf("failed to configure ztunnel ebpf from within ns(%s): %v", ns, err))
 	}
 
 	return nil
 }
 
-func (s *Server) createTProxyRulesForLegacyEBPF(ztunnelIP, ifName string) error {
-	err := addOrgSrcMarkRule()
+func (s *Server) createTProxyRulesForLegacyEBPF(families []ipFamily, ztunnelIP, ifName string) error {
+	err := addOrgSrcMarkRule(families)
 	if err != nil {
 		return fmt.Errorf("failed to add OrgSrc mark rules: %v", err)
 	}
@@ -918,10 +1067,15 @@ func (s *Server) createTProxyRulesForLegacyEBPF(ztunnelIP, ifName string) error
 //
 // There is no cleanup required for things we do within the netns, as when the netns is destroyed on pod delete,
 // everything within the netns goes away.
-func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztunnelNetNS, hostIP string) error {
+func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIPs []string, ztunnelNetNS, hostIP string) error {
 	ns := filepath.Base(ztunnelNetNS)
-	log.Debugf("CreateRulesWithinNodeProxyNS: proxyNsVethIdx=%d, ztunnelIP=%s, hostIP=%s, from within netns=%s", proxyNsVethIdx, ztunnelIP, hostIP, ztunnelNetNS)
-	err := netns.WithNetNSPath(fmt.Sprintf("/var/run/netns/%s", ns), func(netns.NetNS) error {
+	log.Debugf("CreateRulesWithinNodeProxyNS: proxyNsVethIdx=%d, ztunnelIPs=%v, hostIP=%s, from within netns=%s", proxyNsVethIdx, ztunnelIPs, hostIP, ztunnelNetNS)
+	if len(ztunnelIPs) == 0 {
+		return fmt.Errorf("%w: ztunnel has no IP", ErrZtunnelNotReady)
+	}
+	ztunnelIP := ztunnelIPs[0]
+	families := familiesOf(ztunnelIPs)
+	err := netns.WithNetNSPath(netnsPath(ns), func(netns.NetNS) error {
 		//"p" is just to visually distinguish from the host-side tunnel links in logs
 		inboundGeneveLinkName := "p" + constants.InboundTun
 		outboundGeneveLinkName := "p" + constants.OutboundTun
@@ -930,7 +1084,7 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 		// about to create, ignoring warnings
 		//
 		// TODO not strictly necessary? A harmless correctness check, at least.
-		flushAllRouteTables()
+		flushRouteTables(constants.RouteTableInbound, constants.RouteTableOutbound, constants.RouteTableProxy)
 
 		deleteIPRules([]string{"20000", "20001", "20002", "20003"}, false)
 
@@ -945,18 +1099,17 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 			Remote: net.ParseIP(hostIP),
 		}
 		log.Debugf("Building inbound tunnel: %+v", inbndTunLink)
-		err := netlink.LinkAdd(inbndTunLink)
+		err := audit.RecordNode(audit.KindLink, "add "+inbndTunLink.Attrs().Name+" in the ztunnel netns", netlink.LinkAdd(inbndTunLink))
 		if err != nil {
 			log.Errorf("failed to add inbound tunnel: %v", err)
 		}
-		err = netlink.AddrAdd(inbndTunLink, &netlink.Addr{
-			IPNet: &net.IPNet{
-				IP:   net.ParseIP(constants.ZTunnelInboundTunIP),
-				Mask: net.CIDRMask(constants.TunPrefix, 32),
-			},
-		})
-		if err != nil {
-			log.Errorf("failed to add inbound tunnel address: %v", err)
+		for _, f := range families {
+			addr := tunnelAddr(f, f.ztunnelInboundTunIP)
+			err = audit.RecordNode(audit.KindAddr, fmt.Sprintf("add %v to %s in the ztunnel netns", addr, inbndTunLink.Attrs().Name),
+				netlink.AddrAdd(inbndTunLink, addr))
+			if err != nil {
+				log.Errorf("failed to add inbound tunnel address: %v", err)
+			}
 		}
 
 		// Create OUTBOUND Geneve tunnel (to host)
@@ -968,28 +1121,27 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 			Remote: net.ParseIP(hostIP),
 		}
 		log.Debugf("Building outbound tunnel: %+v", outbndTunLink)
-		err = netlink.LinkAdd(outbndTunLink)
+		err = audit.RecordNode(audit.KindLink, "add "+outbndTunLink.Attrs().Name+" in the ztunnel netns", netlink.LinkAdd(outbndTunLink))
 		if err != nil {
 			log.Errorf("failed to add outbound tunnel: %v", err)
 		}
-		err = netlink.AddrAdd(outbndTunLink, &netlink.Addr{
-			IPNet: &net.IPNet{
-				IP:   net.ParseIP(constants.ZTunnelOutboundTunIP),
-				Mask: net.CIDRMask(constants.TunPrefix, 32),
-			},
-		})
-		if err != nil {
-			log.Errorf("failed to add outbound tunnel address: %v", err)
+		for _, f := range families {
+			addr := tunnelAddr(f, f.ztunnelOutboundTunIP)
+			err = audit.RecordNode(audit.KindAddr, fmt.Sprintf("add %v to %s in the ztunnel netns", addr, outbndTunLink.Attrs().Name),
+				netlink.AddrAdd(outbndTunLink, addr))
+			if err != nil {
+				log.Errorf("failed to add outbound tunnel address: %v", err)
+			}
 		}
 
 		log.Debugf("Bringing up inbound tunnel: %+v", inbndTunLink)
 		// Bring the tunnels up
-		err = netlink.LinkSetUp(inbndTunLink)
+		err = audit.RecordNode(audit.KindLink, "set up "+inbndTunLink.Attrs().Name+" in the ztunnel netns", netlink.LinkSetUp(inbndTunLink))
 		if err != nil {
 			log.Errorf("failed to set inbound tunnel up: %v", err)
 		}
 		log.Debugf("Bringing up outbound tunnel: %+v", outbndTunLink)
-		err = netlink.LinkSetUp(outbndTunLink)
+		err = audit.RecordNode(audit.KindLink, "set up "+outbndTunLink.Attrs().Name+" in the ztunnel netns", netlink.LinkSetUp(outbndTunLink))
 		if err != nil {
 			log.Errorf("failed to set outbound tunnel up: %v", err)
 		}
@@ -1009,11 +1161,11 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 		}
 
 		// Set up tproxy marks
-		err = addTProxyMarkRule()
+		err = addTProxyMarkRule(families)
 		if err != nil {
 			return fmt.Errorf("failed to add TPROXY mark rules: %v", err)
 		}
-		err = addOrgSrcMarkRule()
+		err = addOrgSrcMarkRule(families)
 		if err != nil {
 			return fmt.Errorf("failed to add OrgSrc mark rules: %v", err)
 		}
@@ -1024,10 +1176,8 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 		}
 
 		// Set up netlink routes for localhost
-		// TODO IPv6, append "0::0/0"
-		cidrs := []string{"0.0.0.0/0"}
-		for _, fullCIDR := range cidrs {
-			_, localhostDst, err := net.ParseCIDR(fullCIDR)
+		for _, f := range families {
+			_, localhostDst, err := net.ParseCIDR(f.defaultRoute)
 			if err != nil {
 				return fmt.Errorf("parse CIDR: %v", err)
 			}
@@ -1047,7 +1197,7 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 				// Equiv: "ip route add table 101 0.0.0.0/0 via $OUTBOUND_TUN_IP dev p$OUTBOUND_TUN"
 				{
 					Dst:       localhostDst,
-					Gw:        net.ParseIP(constants.OutboundTunIP),
+					Gw:        net.ParseIP(f.outboundTunIP),
 					Type:      unix.RTN_UNICAST,
 					Table:     constants.RouteTableOutbound,
 					LinkIndex: outbndTunLink.Attrs().Index,
@@ -1056,7 +1206,7 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 				// Equiv: "ip route add table 102 0.0.0.0/0 via $INBOUND_TUN_IP dev p$INBOUND_TUN"
 				{
 					Dst:       localhostDst,
-					Gw:        net.ParseIP(constants.InboundTunIP),
+					Gw:        net.ParseIP(f.inboundTunIP),
 					Type:      unix.RTN_UNICAST,
 					Table:     constants.RouteTableProxy,
 					LinkIndex: inbndTunLink.Attrs().Index,
@@ -1065,7 +1215,7 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 
 			for _, route := range netlinkRoutes {
 				log.Debugf("Adding netlink route : %+v", route)
-				if err := netlink.RouteAdd(route); err != nil {
+				if err := audit.RecordNode(audit.KindRoute, "add "+route.String()+" in the ztunnel netns", netlink.RouteAdd(route)); err != nil {
 					// TODO clear this route every time
 					// Would not expect this if we have properly cleared routes
 					log.Errorf("Failed to add netlink route : %+v", route)
@@ -1075,7 +1225,7 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 		}
 
 		log.Debugf("Finding link and parsing host IP")
-		_, parsedHostIPNet, err := net.ParseCIDR(hostIP + "/32")
+		_, parsedHostIPNet, err := net.ParseCIDR(familyOf(hostIP).hostRoute(hostIP))
 		if err != nil {
 			return fmt.Errorf("could not parse host IP %s: %v", hostIP, err)
 		}
@@ -1107,7 +1257,7 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 
 		for _, route := range netlinkHostRoutes {
 			log.Debugf("Adding netlink HOST_IP routes : %+v", route)
-			if err := netlink.RouteAdd(route); err != nil {
+			if err := audit.RecordNode(audit.KindRoute, "add "+route.String()+" in the ztunnel netns", netlink.RouteAdd(route)); err != nil {
 				// TODO clear this route every time
 				// Would not expect this if we have properly cleared routes
 				return fmt.Errorf("failed to add host route: %v", err)
@@ -1189,43 +1339,43 @@ func (s *Server) CreateRulesWithinNodeProxyNS(proxyNsVethIdx int, ztunnelIP, ztu
 		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("failed to configure ztunnel via iptables from within ns(%s): %v", ns, err)
+		return util.WithSELinuxRemediation(fmt.Errorf("failed to configure ztunnel via iptables from within ns(%s): %v", ns, err))
 	}
 
 	return nil
 }
 
-func (s *Server) cleanupNode() {
+func (s *Server) cleanupIptables() {
 	log.Infof("Node-level network rule cleanup started")
-	if s.redirectMode == EbpfMode {
-		if err := s.delZtunnelEbpfOnNode(); err != nil {
-			log.Error(err)
-		}
-		return
-	}
 	s.cleanRules()
+	s.mu.Lock()
+	s.nodeRules = nil
+	s.mu.Unlock()
 
-	flushAllRouteTables()
+	flushRouteTables(Routing.tables()...)
 
-	deleteIPRules([]string{"100", "101", "102", "103"}, true)
+	restoreLocalRule()
+	var priorities []string
+	for _, p := range Routing.priorities() {
+		priorities = append(priorities, strconv.Itoa(p))
+	}
+	deleteIPRules(priorities, true)
 
 	deleteTunnelLinks(constants.InboundTun, constants.OutboundTun, true)
 
-	err := Ipset.DestroySet()
+	err := audit.RecordNode(audit.KindIpset, "destroy "+Ipset.Name, Ipset.DestroySet())
 	if err != nil {
 		log.Warnf("unable to delete IPSet: %v", err)
 	}
 }
 
-func addTProxyMarkRule() error {
+func addTProxyMarkRule(families []ipFamily) error {
 	// Set up tproxy marks
 	var rules []*netlink.Rule
-	// TODO IPv6, append  unix.AF_INET6
-	families := []int{unix.AF_INET}
-	for _, family := range families {
+	for _, f := range families {
 		// Equiv: "ip rule add priority 20000 fwmark 0x400/0xfff lookup 100"
 		tproxMarkRule := netlink.NewRule()
-		tproxMarkRule.Family = family
+		tproxMarkRule.Family = f.family
 		tproxMarkRule.Table = constants.RouteTableInbound
 		tproxMarkRule.Mark = constants.TProxyMark
 		tproxMarkRule.Mask = constants.TProxyMask
@@ -1235,7 +1385,7 @@ func addTProxyMarkRule() error {
 
 	for _, rule := range rules {
 		log.Debugf("Adding netlink rule : %+v", rule)
-		if err := netlink.RuleAdd(rule); err != nil {
+		if err := audit.RecordNode(audit.KindRule, "add "+rule.String(), netlink.RuleAdd(rule)); err != nil {
 			return fmt.Errorf("failed to configure netlink rule: %v", err)
 		}
 	}
@@ -1243,15 +1393,13 @@ func addTProxyMarkRule() error {
 	return nil
 }
 
-func addOrgSrcMarkRule() error {
+func addOrgSrcMarkRule(families []ipFamily) error {
 	// Set up tproxy marks
 	var rules []*netlink.Rule
-	// TODO IPv6, append  unix.AF_INET6
-	families := []int{unix.AF_INET}
-	for _, family := range families {
+	for _, f := range families {
 		// Equiv: "ip rule add priority 20003 fwmark 0x4d3/0xfff lookup 100"
 		orgSrcRule := netlink.NewRule()
-		orgSrcRule.Family = family
+		orgSrcRule.Family = f.family
 		orgSrcRule.Table = constants.RouteTableInbound
 		orgSrcRule.Mark = constants.OrgSrcRetMark
 		orgSrcRule.Mask = constants.OrgSrcRetMask
@@ -1261,7 +1409,7 @@ func addOrgSrcMarkRule() error {
 
 	for _, rule := range rules {
 		log.Debugf("Adding netlink rule : %+v", rule)
-		if err := netlink.RuleAdd(rule); err != nil {
+		if err := audit.RecordNode(audit.KindRule, "add "+rule.String(), netlink.RuleAdd(rule)); err != nil {
 			return fmt.Errorf("failed to configure netlink rule: %v", err)
 		}
 	}
@@ -1269,6 +1417,16 @@ func addOrgSrcMarkRule() error {
 	return nil
 }
 
+// tunnelAddr returns the address of a tunnel link. Duplicate address detection is skipped for IPv6, as the
+// tunnel addresses are private to the node and would otherwise not be usable until it completes.
+func tunnelAddr(f ipFamily, ip string) *netlink.Addr {
+	addr := &netlink.Addr{IPNet: f.tunAddr(ip)}
+	if f.family == unix.AF_INET6 {
+		addr.Flags = unix.IFA_F_NODAD
+	}
+	return addr
+}
+
 func disableRPFiltersForLink(ifaceName string) error {
 	// Need to do some work in procfs
 	// @TODO: This needs to be cleaned up, there are a lot of martians in AWS
@@ -1291,42 +1449,20 @@ func disableRPFiltersForLink(ifaceName string) error {
 }
 
 func setProc(path string, value string) error {
-	return os.WriteFile(path, []byte(value), 0o644)
-}
-
-func buildRouteFromPod(pod *corev1.Pod, ip string) ([]string, error) {
-	if ip == "" {
-		ip = pod.Status.PodIP
-	}
-
-	if ip == "" {
-		return nil, errors.New("no ip found")
-	}
-
-	return []string{
-		"table",
-		fmt.Sprintf("%d", constants.RouteTableInbound),
-		fmt.Sprintf("%s/32", ip),
-		"via",
-		constants.ZTunnelInboundTunIP,
-		"dev",
-		constants.InboundTun,
-		"src",
-		HostIP,
-	}, nil
+	return audit.RecordNode(audit.KindSysctl, fmt.Sprintf("write %s to %s", value, path), os.WriteFile(path, []byte(value), 0o644))
 }
 
 // This can be called on the node, as part of termination/cleanup,
 // or it can be called from within a pod netns, as a "clean slate" prep.
-func flushAllRouteTables() {
+func flushRouteTables(tables ...int) {
 	// Clean up ip route tables
-	_ = routeFlushTable(constants.RouteTableInbound)
-	_ = routeFlushTable(constants.RouteTableOutbound)
-	_ = routeFlushTable(constants.RouteTableProxy)
+	for _, table := range tables {
+		_ = routeFlushTable(table)
+	}
 }
 
 func routeFlushTable(table int) error {
-	routes, err := netlink.RouteListFiltered(netlink.FAMILY_V4, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: table}, netlink.RT_FILTER_TABLE)
 	if err != nil {
 		return err
 	}
@@ -1335,7 +1471,11 @@ func routeFlushTable(table int) error {
 	// https://github.com/vishvananda/netlink/issues/611
 	for i, route := range routes {
 		if (route.Dst == nil || route.Dst.IP == nil) && route.Src == nil && route.Gw == nil && route.MPLSDst == nil {
-			_, defaultDst, _ := net.ParseCIDR("0.0.0.0/0")
+			f := ipv4
+			if route.Family == unix.AF_INET6 {
+				f = ipv6
+			}
+			_, defaultDst, _ := net.ParseCIDR(f.defaultRoute)
 			routes[i].Dst = defaultDst
 		}
 	}
@@ -1351,15 +1491,22 @@ func routeFlushTable(table int) error {
 //
 // TODO `netlink.RuleDel` SHOULD work here - but it does not. Unsure why.
 // So, for time being, rely on `ip`
+//
+// Rules are deleted in both families. IPv6 rules only exist on dual-stack and IPv6 nodes, so failing to
+// delete them is expected and never warned about.
 func deleteIPRules(prioritiesToDelete []string, warnOnFail bool) {
-	var exec []*ExecList
-	for _, pri := range prioritiesToDelete {
-		exec = append(exec, newExec("ip", []string{"rule", "del", "priority", pri}))
-	}
-	for _, e := range exec {
-		err := execute(e.Cmd, e.Args...)
-		if err != nil && warnOnFail {
-			log.Warnf("Error running command %v %v: %v", e.Cmd, strings.Join(e.Args, " "), err)
+	for _, f := range []ipFamily{ipv4, ipv6} {
+		for _, pri := range prioritiesToDelete {
+			e := newExec("ip", []string{f.ipFlag, "rule", "del", "priority", pri})
+			err := execute(e.Cmd, e.Args...)
+			if err == nil {
+				continue
+			}
+			if warnOnFail && f.family == unix.AF_INET {
+				log.Warnf("Error running command %v %v: %v", e.Cmd, strings.Join(e.Args, " "), err)
+			} else {
+				log.Debugf("Error running command %v %v: %v", e.Cmd, strings.Join(e.Args, " "), err)
+			}
 		}
 	}
 }
@@ -1375,7 +1522,7 @@ func deleteTunnelLinks(inboundName, outboundName string, warnOnFail bool) {
 	if err != nil && warnOnFail {
 		log.Warnf("did not find existing inbound tunnel %s to delete: %v", inboundName, err)
 	} else if inboundTun != nil {
-		err = netlink.LinkDel(inboundTun)
+		err = audit.RecordNode(audit.KindLink, "delete "+inboundName, netlink.LinkDel(inboundTun))
 		if err != nil && warnOnFail {
 			log.Warnf("error deleting inbound tunnel: %v", err)
 		}
@@ -1386,7 +1533,7 @@ func deleteTunnelLinks(inboundName, outboundName string, warnOnFail bool) {
 		// Bail, if we can't find it don't try to delete it
 		return
 	} else if outboundTun != nil {
-		err = netlink.LinkDel(outboundTun)
+		err = audit.RecordNode(audit.KindLink, "delete "+outboundName, netlink.LinkDel(outboundTun))
 		if err != nil && warnOnFail {
 			log.Warnf("error deleting outbound tunnel: %v", err)
 		}
@@ -1395,7 +1542,7 @@ func deleteTunnelLinks(inboundName, outboundName string, warnOnFail bool) {
 
 func routesDelete(routes []netlink.Route) error {
 	for _, r := range routes {
-		err := netlink.RouteDel(&r)
+		err := audit.RecordNode(audit.KindRoute, "delete "+r.String(), netlink.RouteDel(&r))
 		if err != nil {
 			return err
 		}
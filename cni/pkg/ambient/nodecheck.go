@@ -0,0 +1,106 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"istio.io/istio/pkg/util/sets"
+)
+
+// checkNodeRedirection periodically checks the node redirection to ztunnel, until stopped. The redirection is
+// otherwise only configured when ztunnel changes, so artifacts removed by other tooling, such as a flush of the
+// iptables rules or the removal of the tunnel links, would not be noticed.
+func (s *Server) checkNodeRedirection(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.nodeCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.repairNodeRedirection()
+			s.compareEbpfShadow()
+		}
+	}
+}
+
+// repairNodeRedirection configures the redirection to the active ztunnel again if some of the node artifacts it was
+// programmed with are missing, returning whether it was repaired. As after a ztunnel change, the pods are reconciled again, since the
+// redirection of the node is recreated along with the one of its pods.
+func (s *Server) repairNodeRedirection() bool {
+	lister, ok := s.redirector.(artifactLister)
+	if !ok {
+		return false
+	}
+	s.reconcileMu.Lock()
+	defer s.reconcileMu.Unlock()
+	if s.isFlushed() {
+		return false
+	}
+	s.mu.Lock()
+	ztunnel := s.ztunnelPod
+	settings := s.ztunnelSettings
+	s.mu.Unlock()
+	if ztunnel == nil {
+		return false
+	}
+
+	actual, err := lister.actualArtifacts()
+	if err != nil {
+		log.Warnf("failed to check the node redirection: %v", err)
+		return false
+	}
+	missing := missingNodeArtifacts(s.desiredState.nodeArtifacts(), actual)
+	if len(missing) == 0 {
+		return false
+	}
+	log.Warnf("the node redirection to ztunnel %s is missing %d artifacts, repairing it: %s", ztunnel.Name, len(missing),
+		strings.Join(missing, "; "))
+	err = recordRulesProgrammed(ruleTypeZtunnel, s.redirector.SetZtunnel(ztunnel, settings.captureDNS))
+	result := resultSuccess
+	if err != nil {
+		result = resultFail
+	}
+	nodeRepairs.With(resultLabel.Value(result)).Increment()
+	if err != nil {
+		log.Errorf("failed to repair the node redirection: %v", err)
+		recordEnrollmentFailure(ruleTypeZtunnel, err)
+		return false
+	}
+	s.recordNodeProgrammed(ztunnel)
+	s.ReconcileNamespaces()
+	return true
+}
+
+// missingNodeArtifacts returns the desired node artifacts which are not configured, sorted.
+func missingNodeArtifacts(desired []string, actual []artifact) []string {
+	configured := sets.New[string]()
+	for _, a := range actual {
+		if a.ip == "" {
+			configured.Insert(a.value)
+		}
+	}
+	var missing []string
+	for _, d := range desired {
+		if !configured.Contains(d) {
+			missing = append(missing, d)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,111 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+// listingRedirector is a Redirector whose node artifacts are configured by SetZtunnel, and can be removed.
+type listingRedirector struct {
+	fakeRedirector
+	configured []artifact
+	setErr     error
+	sets       int
+}
+
+func (r *listingRedirector) SetZtunnel(*corev1.Pod, bool) error {
+	r.sets++
+	if r.setErr != nil {
+		return r.setErr
+	}
+	r.configured = []artifact{{value: "link istioin"}, {value: "link istioout"}}
+	return nil
+}
+
+func (r *listingRedirector) desiredPodArtifacts(*corev1.Pod) []artifact {
+	return nil
+}
+
+func (r *listingRedirector) desiredNodeArtifacts(*corev1.Pod) []string {
+	return []string{"link istioin", "link istioout"}
+}
+
+func (r *listingRedirector) actualArtifacts() ([]artifact, error) {
+	return r.configured, nil
+}
+
+func TestMissingNodeArtifacts(t *testing.T) {
+	actual := []artifact{
+		{value: "link istioin"},
+		// Pod artifacts do not configure the node
+		{ip: "10.0.0.1", value: "rule -4 priority 100 lookup 100"},
+	}
+	assert.Equal(t, missingNodeArtifacts([]string{"rule -4 priority 100 lookup 100", "link istioin", "link istioout"}, actual),
+		[]string{"link istioout", "rule -4 priority 100 lookup 100"})
+	assert.Equal(t, missingNodeArtifacts([]string{"link istioin"}, actual), nil)
+}
+
+func TestRepairNodeRedirection(t *testing.T) {
+	client := kube.NewFakeClient()
+	r := &listingRedirector{}
+	s := &Server{
+		ctx:          context.Background(),
+		kubeClient:   client,
+		namespaces:   kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+		redirector:   r,
+		desiredState: newDesiredState(),
+	}
+	client.RunAndWait(test.NewStop(t))
+
+	// Nothing is checked without an active ztunnel
+	assert.Equal(t, s.repairNodeRedirection(), false)
+	assert.Equal(t, r.sets, 0)
+
+	s.ztunnelPod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ztunnel"}}
+	assert.NoError(t, r.SetZtunnel(s.ztunnelPod, false))
+	s.recordNodeProgrammed(s.ztunnelPod)
+	assert.Equal(t, s.repairNodeRedirection(), false)
+	assert.Equal(t, r.sets, 1)
+
+	// A link removed by other tooling is repaired
+	r.configured = r.configured[:1]
+	assert.Equal(t, s.repairNodeRedirection(), true)
+	assert.Equal(t, r.sets, 2)
+	assert.Equal(t, len(r.configured), 2)
+
+	// Failed repairs are retried at the next check
+	r.configured = nil
+	r.setErr = fmt.Errorf("iptables failed")
+	assert.Equal(t, s.repairNodeRedirection(), false)
+	assert.Equal(t, s.repairNodeRedirection(), false)
+	assert.Equal(t, r.sets, 4)
+
+	// The redirection of flushed nodes is not repaired
+	r.setErr = nil
+	s.flushed = true
+	assert.Equal(t, s.repairNodeRedirection(), false)
+	assert.Equal(t, r.sets, 4)
+}
@@ -0,0 +1,226 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+)
+
+// NodeTrafficMode selects how the traffic of the pods in the mesh to their node, such as to the kubelet API or to
+// services listening on the node, is handled by the iptables redirection.
+type NodeTrafficMode string
+
+const (
+	// NodeTrafficExclude sends the traffic to the node directly, bypassing ztunnel.
+	NodeTrafficExclude NodeTrafficMode = "exclude"
+	// NodeTrafficCapture redirects all the traffic to the node to ztunnel.
+	NodeTrafficCapture NodeTrafficMode = "capture"
+	// NodeTrafficPorts only redirects the TCP traffic to the listed ports of the node to ztunnel, and sends the rest
+	// of the traffic to the node directly.
+	NodeTrafficPorts NodeTrafficMode = "ports"
+)
+
+// maxNodeTrafficPorts is how many ports an iptables multiport match accepts.
+const maxNodeTrafficPorts = 15
+
+// NodeTrafficConfig configures how the traffic of the pods in the mesh to their node is handled.
+//
+// The traffic to the node is matched by destination address type, so all the addresses of the node are handled alike.
+// It is delivered to the node by the local route table, which is looked up before the rules redirecting traffic to
+// ztunnel, so capturing it moves the lookup of the local table after the rule redirecting it, at the two priorities
+// below the rule priorities of the routing. Rules added by others between these priorities and the local table lookup
+// then take precedence over the delivery of traffic to the node.
+type NodeTrafficConfig struct {
+	// Mode selects how the traffic to the node is handled. If unset, it is excluded.
+	Mode NodeTrafficMode `json:"mode,omitempty"`
+	// Ports are the ports of the node whose traffic is captured, in the ports mode.
+	Ports []int `json:"ports,omitempty"`
+}
+
+// Validate returns an error if the node traffic config cannot be used.
+func (c NodeTrafficConfig) Validate() error {
+	switch c.Mode {
+	case "", NodeTrafficExclude, NodeTrafficCapture:
+		if len(c.Ports) > 0 {
+			return fmt.Errorf("node traffic ports are only used in the %q mode", NodeTrafficPorts)
+		}
+	case NodeTrafficPorts:
+		if len(c.Ports) == 0 {
+			return fmt.Errorf("the %q node traffic mode requires ports", NodeTrafficPorts)
+		}
+		if len(c.Ports) > maxNodeTrafficPorts {
+			return fmt.Errorf("at most %d node traffic ports can be captured, got %d", maxNodeTrafficPorts, len(c.Ports))
+		}
+		for _, p := range c.Ports {
+			if p <= 0 || p > 65535 {
+				return fmt.Errorf("invalid node traffic port %d", p)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown node traffic mode %q", c.Mode)
+	}
+	return nil
+}
+
+// captured returns whether some of the traffic to the node is redirected to ztunnel.
+func (c NodeTrafficConfig) captured() bool {
+	return c.Mode == NodeTrafficCapture || c.Mode == NodeTrafficPorts
+}
+
+// warnNodeTrafficIgnored warns that the node traffic config is ignored by the eBPF redirection.
+func warnNodeTrafficIgnored(c NodeTrafficConfig) {
+	if c.captured() {
+		log.Warnf("the %q node traffic mode is only supported by the iptables redirect mode, and is ignored", c.Mode)
+	}
+}
+
+// nodeTrafficRulePriorities returns the priorities of the rule redirecting the traffic to the node to ztunnel, and of
+// the rule looking up the local route table once it is moved after it.
+func nodeTrafficRulePriorities() []int {
+	return []int{Routing.RulePriorityBase - 2, Routing.RulePriorityBase - 1}
+}
+
+// buildNodeTrafficRules returns the iptables rules sending the traffic of the pods in the mesh to their node directly,
+// instead of marking it to be redirected to ztunnel. They must precede the rules marking outbound traffic.
+func buildNodeTrafficRules(c NodeTrafficConfig) []*iptablesRule {
+	switch c.Mode {
+	case NodeTrafficCapture:
+		return nil
+	case NodeTrafficPorts:
+		ports := make([]string, 0, len(c.Ports))
+		for _, p := range c.Ports {
+			ports = append(ports, strconv.Itoa(p))
+		}
+		return []*iptablesRule{
+			newIptableRule(
+				constants.TableMangle,
+				constants.ChainZTunnelPrerouting,
+				"!", "-p", "tcp",
+				"-m", "set",
+				"--match-set", Ipset.Name, "src",
+				"-m", "addrtype",
+				"--dst-type", "LOCAL",
+				"-j", "RETURN",
+			),
+			newIptableRule(
+				constants.TableMangle,
+				constants.ChainZTunnelPrerouting,
+				"-p", "tcp",
+				"-m", "set",
+				"--match-set", Ipset.Name, "src",
+				"-m", "addrtype",
+				"--dst-type", "LOCAL",
+				"-m", "multiport",
+				"!", "--dports", strings.Join(ports, ","),
+				"-j", "RETURN",
+			),
+		}
+	default:
+		// The local route table delivers the traffic to the node anyway; skipping it keeps it unmarked
+		return []*iptablesRule{
+			newIptableRule(
+				constants.TableMangle,
+				constants.ChainZTunnelPrerouting,
+				"-m", "set",
+				"--match-set", Ipset.Name, "src",
+				"-m", "addrtype",
+				"--dst-type", "LOCAL",
+				"-j", "RETURN",
+			),
+		}
+	}
+}
+
+// buildNodeTrafficRoutes returns the ip(8) commands redirecting the traffic to the node marked as outbound to ztunnel,
+// for each family. The local route table is looked up right after, and only then is its default rule deleted, so the
+// traffic to the node is never left without a route.
+func buildNodeTrafficRoutes(c NodeTrafficConfig, families []ipFamily) []*ExecList {
+	if !c.captured() {
+		return nil
+	}
+	p := nodeTrafficRulePriorities()
+	var routes []*ExecList
+	for _, f := range families {
+		routes = append(routes,
+			newExec("ip",
+				[]string{
+					f.ipFlag, "rule", "add", "priority", fmt.Sprint(p[0]),
+					"fwmark", Routing.fwmark(constants.OutboundMask),
+					"lookup", fmt.Sprint(Routing.outboundTable()),
+				},
+			),
+			newExec("ip",
+				[]string{f.ipFlag, "rule", "add", "priority", fmt.Sprint(p[1]), "lookup", "local"},
+			),
+			newExec("ip",
+				[]string{f.ipFlag, "rule", "del", "priority", "0", "lookup", "local"},
+			),
+		)
+	}
+	return routes
+}
+
+// runNodeRoutes runs the ip(8) commands of buildNodeRoutes and buildNodeTrafficRoutes in order, and aborts on the first
+// failure, as the commands rely on the ones before them. The default rule looking up the local route table is only
+// deleted once the rule replacing it is confirmed, so the traffic to the node is never left without a route.
+func runNodeRoutes(routes []*ExecList, run func(cmd string, args ...string) error, output func(cmd string, args ...string) (string, error)) error {
+	for _, route := range routes {
+		if deletesLocalRule(route) && !localRuleReplaced(route.Args[0], output) {
+			return fmt.Errorf("not deleting the default rule looking up the local table, the rule replacing it is missing")
+		}
+		if err := run(route.Cmd, route.Args...); err != nil {
+			return fmt.Errorf("failed to add route (%+v): %v", route, err)
+		}
+	}
+	return nil
+}
+
+// deletesLocalRule returns whether the command deletes the default rule looking up the local route table.
+func deletesLocalRule(e *ExecList) bool {
+	return e.Cmd == "ip" && len(e.Args) == 7 && strings.Join(e.Args[1:], " ") == "rule del priority 0 lookup local"
+}
+
+// localRuleReplaced returns whether the rule of buildNodeTrafficRoutes looking up the local route table exists for
+// the family.
+func localRuleReplaced(ipFlag string, output func(cmd string, args ...string) (string, error)) bool {
+	out, err := output("ip", ipFlag, "rule", "show", "priority", fmt.Sprint(nodeTrafficRulePriorities()[1]))
+	return err == nil && strings.Contains(out, "lookup local")
+}
+
+// restoreLocalRule restores the default rule looking up the local route table, then deletes the rules of
+// buildNodeTrafficRoutes. Only these rules are deleted, so it is a no-op if the traffic to the node was not captured.
+func restoreLocalRule() {
+	p := nodeTrafficRulePriorities()
+	for _, f := range []ipFamily{ipv4, ipv6} {
+		for _, e := range []*ExecList{
+			newExec("ip", []string{f.ipFlag, "rule", "add", "priority", "0", "lookup", "local"}),
+			newExec("ip", []string{
+				f.ipFlag, "rule", "del", "priority", fmt.Sprint(p[0]),
+				"fwmark", Routing.fwmark(constants.OutboundMask),
+				"lookup", fmt.Sprint(Routing.outboundTable()),
+			}),
+			newExec("ip", []string{f.ipFlag, "rule", "del", "priority", fmt.Sprint(p[1]), "lookup", "local"}),
+		} {
+			// The default rule exists, and the others do not, unless the traffic to the node was captured
+			if err := execute(e.Cmd, e.Args...); err != nil {
+				log.Debugf("Error running command %v %v: %v", e.Cmd, strings.Join(e.Args, " "), err)
+			}
+		}
+	}
+}
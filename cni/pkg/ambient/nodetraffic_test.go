@@ -0,0 +1,120 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestNodeTrafficConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  NodeTrafficConfig
+		wantErr bool
+	}{
+		{"default", NodeTrafficConfig{}, false},
+		{"exclude", NodeTrafficConfig{Mode: NodeTrafficExclude}, false},
+		{"capture", NodeTrafficConfig{Mode: NodeTrafficCapture}, false},
+		{"ports", NodeTrafficConfig{Mode: NodeTrafficPorts, Ports: []int{10250}}, false},
+		{"ports without ports", NodeTrafficConfig{Mode: NodeTrafficPorts}, true},
+		{"ports in capture mode", NodeTrafficConfig{Mode: NodeTrafficCapture, Ports: []int{10250}}, true},
+		{"invalid port", NodeTrafficConfig{Mode: NodeTrafficPorts, Ports: []int{70000}}, true},
+		{"too many ports", NodeTrafficConfig{Mode: NodeTrafficPorts, Ports: make([]int, 16)}, true},
+		{"unknown mode", NodeTrafficConfig{Mode: "allow"}, true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildNodeTrafficRules(t *testing.T) {
+	specs := func(rules []*iptablesRule) []string {
+		var res []string
+		for _, r := range rules {
+			res = append(res, strings.Join(r.RuleSpec, " "))
+		}
+		return res
+	}
+
+	assert.Equal(t, specs(buildNodeTrafficRules(NodeTrafficConfig{})), []string{
+		"-m set --match-set ztunnel-pods-ips src -m addrtype --dst-type LOCAL -j RETURN",
+	})
+	assert.Equal(t, len(buildNodeTrafficRules(NodeTrafficConfig{Mode: NodeTrafficCapture})), 0)
+	assert.Equal(t, specs(buildNodeTrafficRules(NodeTrafficConfig{Mode: NodeTrafficPorts, Ports: []int{10250, 9100}})), []string{
+		"! -p tcp -m set --match-set ztunnel-pods-ips src -m addrtype --dst-type LOCAL -j RETURN",
+		"-p tcp -m set --match-set ztunnel-pods-ips src -m addrtype --dst-type LOCAL -m multiport ! --dports 10250,9100 -j RETURN",
+	})
+}
+
+func TestBuildNodeTrafficRoutes(t *testing.T) {
+	commands := func(routes []*ExecList) []string {
+		var res []string
+		for _, r := range routes {
+			res = append(res, r.Cmd+" "+strings.Join(r.Args, " "))
+		}
+		return res
+	}
+
+	assert.Equal(t, len(buildNodeTrafficRoutes(NodeTrafficConfig{}, []ipFamily{ipv4})), 0)
+	// The local table is looked up after the capture rule before its default rule is deleted
+	assert.Equal(t, commands(buildNodeTrafficRoutes(NodeTrafficConfig{Mode: NodeTrafficCapture}, []ipFamily{ipv4, ipv6})), []string{
+		"ip -4 rule add priority 98 fwmark 0x100/0x100 lookup 101",
+		"ip -4 rule add priority 99 lookup local",
+		"ip -4 rule del priority 0 lookup local",
+		"ip -6 rule add priority 98 fwmark 0x100/0x100 lookup 101",
+		"ip -6 rule add priority 99 lookup local",
+		"ip -6 rule del priority 0 lookup local",
+	})
+}
+
+func TestRunNodeRoutes(t *testing.T) {
+	routes := buildNodeTrafficRoutes(NodeTrafficConfig{Mode: NodeTrafficCapture}, []ipFamily{ipv4})
+	run := func(fail string, rules string) ([]string, error) {
+		var ran []string
+		err := runNodeRoutes(routes, func(cmd string, args ...string) error {
+			c := cmd + " " + strings.Join(args, " ")
+			if c == fail {
+				return errors.New("failed")
+			}
+			ran = append(ran, c)
+			return nil
+		}, func(cmd string, args ...string) (string, error) {
+			return rules, nil
+		})
+		return ran, err
+	}
+
+	ran, err := run("", "99:\tfrom all lookup local")
+	assert.NoError(t, err)
+	assert.Equal(t, len(ran), 3)
+
+	// The remaining commands are aborted on the first failure, so the default rule is kept
+	ran, err = run("ip -4 rule add priority 99 lookup local", "")
+	assert.Error(t, err)
+	assert.Equal(t, ran, []string{"ip -4 rule add priority 98 fwmark 0x100/0x100 lookup 101"})
+
+	// The default rule is only deleted once the rule replacing it is confirmed
+	ran, err = run("", "")
+	assert.Error(t, err)
+	assert.Equal(t, len(ran), 2)
+}
@@ -15,6 +15,9 @@
 package ambient
 
 import (
+	"sync"
+	"time"
+
 	ipsetlib "istio.io/istio/cni/pkg/ipset"
 	"istio.io/istio/pkg/config/constants"
 	"istio.io/pkg/env"
@@ -32,11 +35,17 @@ var Ipset = &ipsetlib.IPSet{
 	Name: "ztunnel-pods-ips",
 }
 
+// ipsetMu serializes the changes of the ipset, which check its entries before changing them, as the workers reconcile
+// pods concurrently.
+var ipsetMu sync.Mutex
+
 type RedirectMode int
 
 const (
 	IptablesMode RedirectMode = iota
 	EbpfMode
+	// ExternalMode is used when the redirection is programmed by a Redirector provided by the embedding node agent.
+	ExternalMode
 )
 
 func (v RedirectMode) String() string {
@@ -45,14 +54,110 @@ func (v RedirectMode) String() string {
 		return "iptables"
 	case EbpfMode:
 		return "ebpf"
+	case ExternalMode:
+		return "external"
 	}
 	return ""
 }
 
+// AmbientArgs configures the ambient node agent.
 type AmbientArgs struct {
+	// SystemNamespace is the namespace Istio is installed in.
 	SystemNamespace string
-	Revision        string
-	KubeConfig      string
-	RedirectMode    RedirectMode
-	LogLevel        string
+	// Revision is the revision of the control plane the node agent belongs to.
+	Revision string
+	// KubeConfig is the path of the kubeconfig file to use. If unset, the in-cluster configuration is used.
+	KubeConfig string
+	// RedirectMode selects the built-in redirection, iptables or eBPF. It is ignored if Redirector is set.
+	RedirectMode RedirectMode
+	// Redirector, if set, programs the redirection instead of the built-in redirect modes.
+	Redirector Redirector
+	// PrivilegedSocket, if set, is the Unix domain socket of the privileged helper programming the built-in
+	// redirection, served with ServePrivileged. The node agent then runs without the capabilities the redirection
+	// requires.
+	PrivilegedSocket string
+	// ConfigFile is the path the ambient configuration read by the CNI plugin is written to. If unset, the path used
+	// by the istio-cni DaemonSet is used.
+	ConfigFile string
+	// Routing holds the route tables, rule priorities and fwmarks used in the iptables redirect mode. If unset, the
+	// defaults are used.
+	Routing RoutingConfig
+	// RoutingAutoResolve selects alternates for the route tables, rule priorities and fwmarks conflicting with the
+	// routing configured on the node by others.
+	RoutingAutoResolve bool
+	// NodeTraffic selects whether the traffic of the pods in the mesh to their node is redirected to ztunnel in the
+	// iptables redirect mode. If unset, it is sent to the node directly.
+	NodeTraffic NodeTrafficConfig
+	// DNSCapture selects where the DNS requests of the pods in the mesh are redirected to in the iptables redirect
+	// mode. If unset, they are redirected to ztunnel.
+	DNSCapture DNSCaptureConfig
+	// EbpfShadow runs the eBPF redirection in observe-only mode alongside the iptables redirect mode, and exports the
+	// divergences between the pods each is programmed to redirect as metrics, before migrating the node to the eBPF
+	// redirect mode. The traffic is not compared, as the eBPF programs are not attached. The pods are compared with
+	// the node checks, every NodeCheckInterval. It is ignored in the other redirect modes, and when the redirection is
+	// programmed by a privileged helper.
+	EbpfShadow bool
+	// LogLevel is the log level of the eBPF redirection.
+	LogLevel       string
+	EnrollmentHook EnrollmentHookArgs
+	// ConntrackFlush flushes the conntrack entries of pods when they are added to or removed from the mesh.
+	ConntrackFlush bool
+	// KubeProxyReplacement is set when services are handled by the CNI with eBPF instead of kube-proxy.
+	KubeProxyReplacement bool
+	// TrimInformers only caches the metadata of namespaces, and the fields of pods used by the node agent.
+	TrimInformers bool
+	// ReconcileTimeout bounds the reconciliation of each pod event. If 0, there is no timeout.
+	ReconcileTimeout time.Duration
+	// Workers is the number of pod events reconciled concurrently. The events of a pod are always reconciled in
+	// order, by the same worker. If 0, events are reconciled by a single worker.
+	Workers int
+	// NodeCheckInterval is how often the node redirection to ztunnel is checked, and repaired if it was removed by
+	// other tooling. If 0, it is only configured when ztunnel changes.
+	NodeCheckInterval time.Duration
+	// ReadinessGate sets the istio.io/ambient-ready condition of pods declaring it as a readiness gate, once their
+	// traffic is redirected to ztunnel or once they are known not to be captured.
+	ReadinessGate bool
+	// Namespaces restricts the node agent to the pods of these namespaces, for example so that the nodes dedicated to
+	// a tenant only program the pods of its namespaces. If empty, the pods of all namespaces are handled.
+	Namespaces []string
+	// CaptureDir is the directory the packet captures of pods requested on the admin endpoint are saved to, usually a
+	// volume mounted from the node. If empty, captures are disabled.
+	CaptureDir string
+	// InstallConfigMap is the ConfigMap in the system namespace holding the ambient settings of the installation, such
+	// as the excluded namespaces, which are reloaded when it changes. If empty, it is not read.
+	InstallConfigMap string
+	// Audit configures the audit log of the mutations of the host performed by the node agent.
+	Audit AuditConfig
+	// KubeClient configures the client-side rate limiting of the requests of the node agent to the API server.
+	KubeClient KubeClientConfig
+	// InitContainerConflicts selects how the pods whose init containers may program their network, privileged or
+	// adding NET_ADMIN, are captured. If unset, they are captured as soon as they are assigned an IP, like other pods.
+	InitContainerConflicts InitContainerConflictPolicy
+}
+
+// KubeClientConfig configures the client-side rate limiting of the kube client of the node agent. On large clusters,
+// the default limits may throttle the node agent when its informers relist the pods after it restarts, or when it
+// patches many pods, so that its caches lag behind the cluster. The requests throttled are exported as metrics. The
+// server-side limits are set with API Priority and Fairness instead, by a FlowSchema matching the service account of
+// the node agent.
+type KubeClientConfig struct {
+	// QPS is the rate of requests per second sent to the API server. If 0, DefaultKubeClientQPS is used.
+	QPS float32
+	// Burst is the number of requests which may be sent above QPS in bursts. If 0, DefaultKubeClientBurst is used.
+	Burst int
+}
+
+// AuditConfig configures the audit log recording the mutations of the host performed by the node agent, such as the
+// iptables rules, ipset entries, routes and eBPF programs it configures, with the pod they are performed for.
+type AuditConfig struct {
+	// Path is the file the mutations are appended to as JSON lines. If empty, the file is not written. With a
+	// privileged helper, it is written by the helper, which performs the mutations.
+	Path string
+	// MaxSizeMB is the size in megabytes at which the file is rotated.
+	MaxSizeMB int
+	// MaxBackups is the number of rotated files which are retained.
+	MaxBackups int
+	// Events records the mutations as Kubernetes events too, on the pods they are performed for, or on the node. They
+	// are not recorded for the mutations performed by a privileged helper.
+	Events bool
 }
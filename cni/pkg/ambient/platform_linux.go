@@ -0,0 +1,109 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+	ebpf "istio.io/istio/cni/pkg/ebpf/server"
+)
+
+// Platform is what the node supports of the redirection, probed by the node agent at startup. It is recorded in the
+// logs, in the istio_cni_ambient_node_info metric and in the ambient.istio.io/platform annotation of the node, so the
+// nodes of a fleet can be audited.
+type Platform struct {
+	KernelVersion string `json:"kernelVersion"`
+	RedirectMode  string `json:"redirectMode"`
+	// UserNamespace is the uid map of the user namespace of the node agent, such as under a rootless kubelet, or empty
+	// if it runs in the initial user namespace of the host.
+	UserNamespace string `json:"userNamespace,omitempty"`
+	// Probed is set if the node agent programs the redirection itself. Otherwise the redirection is programmed by a
+	// privileged helper or by an external Redirector, and the fields below are not probed.
+	Probed bool `json:"probed"`
+	// IptablesVariant is the iptables command used on the node, iptables-legacy or iptables-nft.
+	IptablesVariant string `json:"iptablesVariant,omitempty"`
+	IP6tables       bool   `json:"ip6tables"`
+	Ipset           bool   `json:"ipset"`
+	// EBPF is set if the kernel supports the traffic control programs of the eBPF redirection, and EBPFTProxy if they
+	// can redirect to ztunnel without iptables, which requires Linux 5.7 or later.
+	EBPF       bool `json:"ebpf"`
+	EBPFTProxy bool `json:"ebpfTproxy"`
+}
+
+// probePlatform probes what the node supports of the redirection. The iptables, ipset and eBPF support is only
+// probed if probe is set, as it requires the privileges of the redirection.
+func (s *Server) probePlatform(probe bool) Platform {
+	p := Platform{RedirectMode: s.redirectMode.String(), Probed: probe}
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		log.Warnf("failed to get the kernel version: %v", err)
+	} else {
+		p.KernelVersion = unix.ByteSliceToString(uts.Release[:])
+	}
+	if userns, err := detectUserNamespace(); err != nil {
+		log.Warnf("failed to detect the user namespace: %v", err)
+	} else if !userns.initial {
+		p.UserNamespace = userns.uidMap
+	}
+	if !probe {
+		return p
+	}
+	p.IptablesVariant = s.IptablesCmd()
+	_, err := exec.LookPath(strings.Replace(p.IptablesVariant, "iptables", "ip6tables", 1))
+	p.IP6tables = err == nil
+	_, _, err = netlink.IpsetProtocol()
+	p.Ipset = err == nil
+	p.EBPF = ebpf.EBPFSupport()
+	p.EBPFTProxy = p.EBPF && ebpf.EBPFTProxySupport()
+	return p
+}
+
+// recordPlatform logs what the node supports of the redirection, and records it in the node info metric and in the
+// node annotations.
+func recordPlatform(ctx context.Context, client kubernetes.Interface, p Platform) {
+	log.Infof("node platform: kernel=%s redirectMode=%s userNamespace=%q probed=%v iptables=%s ip6tables=%v ipset=%v "+
+		"ebpf=%v ebpfTproxy=%v", p.KernelVersion, p.RedirectMode, p.UserNamespace, p.Probed, p.IptablesVariant, p.IP6tables,
+		p.Ipset, p.EBPF, p.EBPFTProxy)
+	probed := func(v bool) string {
+		if !p.Probed {
+			return "unknown"
+		}
+		return strconv.FormatBool(v)
+	}
+	iptables := p.IptablesVariant
+	if !p.Probed {
+		iptables = "unknown"
+	}
+	nodeInfo.With(
+		kernelVersionLabel.Value(p.KernelVersion),
+		redirectModeLabel.Value(p.RedirectMode),
+		iptablesVariantLabel.Value(iptables),
+		ip6tablesLabel.Value(probed(p.IP6tables)),
+		ipsetLabel.Value(probed(p.Ipset)),
+		ebpfLabel.Value(probed(p.EBPF)),
+		ebpfTproxyLabel.Value(probed(p.EBPFTProxy)),
+	).Record(1)
+	if err := annotateNode(ctx, client, constants.NodePlatformAnnotation, p); err != nil {
+		log.Warnf("failed to record the platform in the node annotations: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestRecordPlatform(t *testing.T) {
+	test.SetForTest(t, &NodeName, "node1")
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}})
+	s := &Server{redirectMode: ExternalMode}
+
+	// The redirection of external Redirectors is not probed
+	p := s.probePlatform(false)
+	assert.Equal(t, p.KernelVersion != "", true)
+	assert.Equal(t, p, Platform{KernelVersion: p.KernelVersion, RedirectMode: ExternalMode.String(), UserNamespace: p.UserNamespace})
+
+	recordPlatform(context.Background(), client, p)
+	node, err := client.CoreV1().Nodes().Get(context.Background(), "node1", metav1.GetOptions{})
+	assert.NoError(t, err)
+	got := Platform{}
+	assert.NoError(t, json.Unmarshal([]byte(node.Annotations[constants.NodePlatformAnnotation]), &got))
+	assert.Equal(t, got, p)
+}
@@ -0,0 +1,81 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podbypass holds the requests to the ambient node agent temporarily removing the redirection of a pod to
+// ztunnel, to debug application issues suspected to be caused by the mesh.
+package podbypass
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Path is the path the node agent serves bypasses on, on its monitoring port. A POST starts bypassing the capture of
+// a pod, and a DELETE restores it before the bypass expires.
+const Path = "/debug/bypass"
+
+const (
+	// DefaultDuration is the duration of bypasses which do not set one.
+	DefaultDuration = 10 * time.Minute
+	// MaxDuration bounds the duration of bypasses, so a forgotten bypass does not leave a pod outside of the mesh.
+	MaxDuration = time.Hour
+)
+
+// Request is a bypass of the capture of a pod. The Duration is ignored when the capture is restored.
+type Request struct {
+	Namespace string
+	Name      string
+	Duration  time.Duration
+}
+
+// Query returns the path and query of the request to the node agent.
+func (r Request) Query() string {
+	q := url.Values{}
+	q.Set("namespace", r.Namespace)
+	q.Set("pod", r.Name)
+	if r.Duration != 0 {
+		q.Set("duration", r.Duration.String())
+	}
+	return Path + "?" + q.Encode()
+}
+
+// ParseRequest parses the query of a bypass request, and validates it.
+func ParseRequest(q url.Values) (Request, error) {
+	r := Request{
+		Namespace: q.Get("namespace"),
+		Name:      q.Get("pod"),
+		Duration:  DefaultDuration,
+	}
+	if r.Namespace == "" || r.Name == "" {
+		return r, fmt.Errorf("the namespace and pod must be set")
+	}
+	if d := q.Get("duration"); d != "" {
+		var err error
+		if r.Duration, err = time.ParseDuration(d); err != nil {
+			return r, fmt.Errorf("invalid duration: %v", err)
+		}
+	}
+	if r.Duration <= 0 || r.Duration > MaxDuration {
+		return r, fmt.Errorf("the duration must be positive and at most %v", MaxDuration)
+	}
+	return r, nil
+}
+
+// Response describes the bypass of the capture of a pod by the node agent.
+type Response struct {
+	Node string `json:"node"`
+	// Expires is when the capture of the pod is restored, in RFC 3339 format. It is empty once it is restored.
+	Expires string `json:"expires,omitempty"`
+}
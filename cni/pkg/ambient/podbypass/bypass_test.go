@@ -0,0 +1,48 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podbypass
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestParseRequest(t *testing.T) {
+	r := Request{Namespace: "ns", Name: "pod", Duration: time.Minute}
+	u, err := url.Parse(r.Query())
+	assert.NoError(t, err)
+	assert.Equal(t, u.Path, Path)
+	got, err := ParseRequest(u.Query())
+	assert.NoError(t, err)
+	assert.Equal(t, got, r)
+
+	got, err = ParseRequest(url.Values{"namespace": {"ns"}, "pod": {"pod"}})
+	assert.NoError(t, err)
+	assert.Equal(t, got.Duration, DefaultDuration)
+
+	for _, q := range []url.Values{
+		{"pod": {"pod"}},
+		{"namespace": {"ns"}, "pod": {"pod"}, "duration": {"2h"}},
+		{"namespace": {"ns"}, "pod": {"pod"}, "duration": {"-1s"}},
+		{"namespace": {"ns"}, "pod": {"pod"}, "duration": {"soon"}},
+	} {
+		if _, err := ParseRequest(q); err == nil {
+			t.Errorf("expected an error for %v", q)
+		}
+	}
+}
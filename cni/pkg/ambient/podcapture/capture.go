@@ -0,0 +1,196 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package podcapture holds the packet captures of the traffic of pods the ambient node agent takes on request, and the
+// pcap files they are saved to.
+package podcapture
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+// Path is the path the node agent serves captures on, on its admin port.
+const Path = "/debug/capture"
+
+const (
+	// DefaultDuration is the duration of captures which do not set one.
+	DefaultDuration = 30 * time.Second
+	// MaxDuration bounds the duration of captures.
+	MaxDuration = 5 * time.Minute
+	// MaxFileSize bounds the size of each capture file; the capture stops once it is reached.
+	MaxFileSize = 100 << 20
+	// MaxDirSize bounds the size of the capture files kept in the capture directory; captures are refused once they
+	// could exceed it, until previous captures are removed.
+	MaxDirSize = 1 << 30
+	// SnapLen is the maximum number of bytes saved of each packet.
+	SnapLen = 65535
+)
+
+// Point is where the traffic of a pod is captured.
+type Point string
+
+const (
+	// PointPod captures the traffic of the pod on its veth on the node, before it is redirected to ztunnel for outbound
+	// traffic, and after ztunnel for inbound traffic.
+	PointPod Point = "pod"
+	// PointZtunnel captures the traffic of ztunnel to and from the pod IP, on the veth of ztunnel on the node, which is
+	// the other side of ztunnel.
+	PointZtunnel Point = "ztunnel"
+	// PointAll captures the traffic at all points, each saved to its own file.
+	PointAll Point = "all"
+)
+
+// Points returns the points to capture traffic at.
+func (p Point) Points() ([]Point, error) {
+	switch p {
+	case PointPod, PointZtunnel:
+		return []Point{p}, nil
+	case PointAll, "":
+		return []Point{PointPod, PointZtunnel}, nil
+	}
+	return nil, fmt.Errorf("unknown capture point %q, expected %s, %s or %s", p, PointPod, PointZtunnel, PointAll)
+}
+
+// Request is a capture of the traffic of a pod.
+type Request struct {
+	Namespace string
+	Name      string
+	Duration  time.Duration
+	Point     Point
+}
+
+// Query returns the path and query requesting the capture from the node agent.
+func (r Request) Query() string {
+	q := url.Values{}
+	q.Set("namespace", r.Namespace)
+	q.Set("pod", r.Name)
+	if r.Duration != 0 {
+		q.Set("duration", r.Duration.String())
+	}
+	if r.Point != "" {
+		q.Set("point", string(r.Point))
+	}
+	return Path + "?" + q.Encode()
+}
+
+// ParseRequest parses the query of a capture request, and validates it.
+func ParseRequest(q url.Values) (Request, error) {
+	r := Request{
+		Namespace: q.Get("namespace"),
+		Name:      q.Get("pod"),
+		Duration:  DefaultDuration,
+		Point:     Point(q.Get("point")),
+	}
+	if r.Namespace == "" || r.Name == "" {
+		return r, fmt.Errorf("the namespace and pod must be set")
+	}
+	if d := q.Get("duration"); d != "" {
+		var err error
+		if r.Duration, err = time.ParseDuration(d); err != nil {
+			return r, fmt.Errorf("invalid duration: %v", err)
+		}
+	}
+	if r.Duration <= 0 || r.Duration > MaxDuration {
+		return r, fmt.Errorf("the duration must be positive and at most %v", MaxDuration)
+	}
+	if _, err := r.Point.Points(); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Response describes a capture started by the node agent.
+type Response struct {
+	Node     string `json:"node"`
+	Duration string `json:"duration"`
+	// Files are the paths of the capture files on the node, in the capture directory of the node agent.
+	Files []string `json:"files"`
+}
+
+// Writer writes packets to a pcap file, with microsecond timestamps and Ethernet link type.
+type Writer struct {
+	w io.Writer
+	// n is the number of bytes written
+	n int64
+}
+
+// NewWriter writes the pcap file header, and returns a Writer for the packets of the file.
+func NewWriter(w io.Writer) (*Writer, error) {
+	hdr := make([]byte, 24)
+	binary.LittleEndian.PutUint32(hdr[0:4], 0xa1b2c3d4)
+	binary.LittleEndian.PutUint16(hdr[4:6], 2)
+	binary.LittleEndian.PutUint16(hdr[6:8], 4)
+	// The timezone offset and timestamp accuracy are always 0
+	binary.LittleEndian.PutUint32(hdr[16:20], SnapLen)
+	// LINKTYPE_ETHERNET
+	binary.LittleEndian.PutUint32(hdr[20:24], 1)
+	n, err := w.Write(hdr)
+	return &Writer{w: w, n: int64(n)}, err
+}
+
+// WritePacket writes a packet captured at ts, truncated to SnapLen. length is the length of the packet on the wire.
+func (pw *Writer) WritePacket(ts time.Time, data []byte, length int) error {
+	if len(data) > SnapLen {
+		data = data[:SnapLen]
+	}
+	hdr := make([]byte, 16)
+	binary.LittleEndian.PutUint32(hdr[0:4], uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(hdr[4:8], uint32(ts.Nanosecond()/int(time.Microsecond)))
+	binary.LittleEndian.PutUint32(hdr[8:12], uint32(len(data)))
+	binary.LittleEndian.PutUint32(hdr[12:16], uint32(length))
+	n, err := pw.w.Write(hdr)
+	pw.n += int64(n)
+	if err != nil {
+		return err
+	}
+	n, err = pw.w.Write(data)
+	pw.n += int64(n)
+	return err
+}
+
+// Size returns the number of bytes written to the file.
+func (pw *Writer) Size() int64 {
+	return pw.n
+}
+
+// HasAddress returns whether an Ethernet frame is an IPv4 or IPv6 packet from or to ip.
+func HasAddress(frame []byte, ip netip.Addr) bool {
+	if len(frame) < 14 {
+		return false
+	}
+	var src, dst []byte
+	switch binary.BigEndian.Uint16(frame[12:14]) {
+	case 0x0800:
+		if len(frame) < 34 {
+			return false
+		}
+		src, dst = frame[26:30], frame[30:34]
+	case 0x86dd:
+		if len(frame) < 54 {
+			return false
+		}
+		src, dst = frame[22:38], frame[38:54]
+	default:
+		return false
+	}
+	s, _ := netip.AddrFromSlice(src)
+	d, _ := netip.AddrFromSlice(dst)
+	ip = ip.Unmap()
+	return s == ip || d == ip
+}
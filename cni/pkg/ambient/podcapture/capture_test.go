@@ -0,0 +1,100 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podcapture
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestParseRequest(t *testing.T) {
+	r := Request{Namespace: "ns", Name: "pod", Duration: time.Minute, Point: PointZtunnel}
+	u, err := url.Parse(r.Query())
+	assert.NoError(t, err)
+	assert.Equal(t, u.Path, Path)
+	got, err := ParseRequest(u.Query())
+	assert.NoError(t, err)
+	assert.Equal(t, got, r)
+
+	got, err = ParseRequest(url.Values{"namespace": {"ns"}, "pod": {"pod"}})
+	assert.NoError(t, err)
+	assert.Equal(t, got.Duration, DefaultDuration)
+	points, err := got.Point.Points()
+	assert.NoError(t, err)
+	assert.Equal(t, points, []Point{PointPod, PointZtunnel})
+
+	for _, q := range []url.Values{
+		{"pod": {"pod"}},
+		{"namespace": {"ns"}, "pod": {"pod"}, "duration": {"1h"}},
+		{"namespace": {"ns"}, "pod": {"pod"}, "duration": {"-1s"}},
+		{"namespace": {"ns"}, "pod": {"pod"}, "duration": {"soon"}},
+		{"namespace": {"ns"}, "pod": {"pod"}, "point": {"node"}},
+	} {
+		if _, err := ParseRequest(q); err == nil {
+			t.Errorf("expected an error for %v", q)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := NewWriter(buf)
+	assert.NoError(t, err)
+	ts := time.Unix(10, 5000)
+	assert.NoError(t, w.WritePacket(ts, []byte{1, 2, 3}, 60))
+	assert.Equal(t, w.Size(), int64(24+16+3))
+
+	b := buf.Bytes()
+	assert.Equal(t, binary.LittleEndian.Uint32(b[0:4]), uint32(0xa1b2c3d4))
+	assert.Equal(t, binary.LittleEndian.Uint32(b[20:24]), uint32(1))
+	rec := b[24:]
+	assert.Equal(t, binary.LittleEndian.Uint32(rec[0:4]), uint32(10))
+	assert.Equal(t, binary.LittleEndian.Uint32(rec[4:8]), uint32(5))
+	assert.Equal(t, binary.LittleEndian.Uint32(rec[8:12]), uint32(3))
+	assert.Equal(t, binary.LittleEndian.Uint32(rec[12:16]), uint32(60))
+	assert.Equal(t, rec[16:], []byte{1, 2, 3})
+}
+
+func TestHasAddress(t *testing.T) {
+	ipv4 := func(src, dst string) []byte {
+		f := make([]byte, 34)
+		binary.BigEndian.PutUint16(f[12:14], 0x0800)
+		s, d := netip.MustParseAddr(src).As4(), netip.MustParseAddr(dst).As4()
+		copy(f[26:30], s[:])
+		copy(f[30:34], d[:])
+		return f
+	}
+	ipv6 := func(src, dst string) []byte {
+		f := make([]byte, 54)
+		binary.BigEndian.PutUint16(f[12:14], 0x86dd)
+		s, d := netip.MustParseAddr(src).As16(), netip.MustParseAddr(dst).As16()
+		copy(f[22:38], s[:])
+		copy(f[38:54], d[:])
+		return f
+	}
+	pod := netip.MustParseAddr("10.0.0.1")
+	assert.Equal(t, HasAddress(ipv4("10.0.0.1", "10.0.0.2"), pod), true)
+	assert.Equal(t, HasAddress(ipv4("10.0.0.2", "10.0.0.1"), pod), true)
+	assert.Equal(t, HasAddress(ipv4("10.0.0.2", "10.0.0.3"), pod), false)
+	assert.Equal(t, HasAddress(ipv4("10.0.0.1", "10.0.0.2")[:20], pod), false)
+	assert.Equal(t, HasAddress(ipv6("fd00::1", "fd00::2"), netip.MustParseAddr("fd00::2")), true)
+	assert.Equal(t, HasAddress(ipv6("fd00::1", "fd00::2"), pod), false)
+}
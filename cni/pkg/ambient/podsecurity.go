@@ -0,0 +1,171 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/pkg/ptr"
+)
+
+// PodSecurity admission profiles, and policy engines enforcing similar policies, may drop the capabilities of pods or
+// force them to run as non-root users. The redirection sends the traffic of pods to ztunnel, which needs NET_ADMIN in
+// its network namespace to accept and originate the redirected connections with transparent sockets, so a ztunnel
+// denied it is not redirected to. Workload pods need no capabilities to be redirected, but the policies confining them
+// may deny the node agent programming their network namespace, so they are reported when it fails.
+
+// ztunnelCapabilities are the capabilities the containers of ztunnel need.
+var ztunnelCapabilities = []corev1.Capability{"NET_ADMIN"}
+
+const ztunnelSecurityContextReason = "AmbientZtunnelSecurityContext"
+
+// securityContextViolations returns why the effective security context of the containers of a pod does not grant them
+// the capabilities, such as when they are dropped, or not effective because the container does not run as root.
+func securityContextViolations(pod *corev1.Pod, required []corev1.Capability) []string {
+	var res []string
+	for _, c := range pod.Spec.Containers {
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		if sc.Privileged != nil && *sc.Privileged {
+			continue
+		}
+		if uid := effectiveRunAsUser(pod, sc); uid != nil && *uid != 0 {
+			res = append(res, fmt.Sprintf("container %s runs as user %d, so it has no capabilities", c.Name, *uid))
+			continue
+		}
+		var added, dropped []corev1.Capability
+		if sc.Capabilities != nil {
+			added, dropped = sc.Capabilities.Add, sc.Capabilities.Drop
+		}
+		for _, r := range required {
+			if containsCapability(dropped, r) {
+				res = append(res, fmt.Sprintf("container %s drops the %s capability", c.Name, r))
+			} else if !containsCapability(added, r) {
+				res = append(res, fmt.Sprintf("container %s does not add the %s capability", c.Name, r))
+			}
+		}
+	}
+	return res
+}
+
+// effectiveRunAsUser returns the user a container runs as, if set by its security context or the one of its pod.
+func effectiveRunAsUser(pod *corev1.Pod, sc *corev1.SecurityContext) *int64 {
+	if sc.RunAsUser != nil {
+		return sc.RunAsUser
+	}
+	if pod.Spec.SecurityContext != nil {
+		return pod.Spec.SecurityContext.RunAsUser
+	}
+	return nil
+}
+
+// containsCapability returns whether the capabilities contain the capability. Dropping ALL only drops the
+// capabilities which are not explicitly added, so it is not matched.
+func containsCapability(caps []corev1.Capability, capability corev1.Capability) bool {
+	for _, c := range caps {
+		if strings.EqualFold(strings.TrimPrefix(string(c), "CAP_"), string(capability)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkZtunnelSecurityContext returns an error if the containers of ztunnel are denied the capabilities it needs, and
+// records it as an event on the pod.
+func (s *Server) checkZtunnelSecurityContext(ctx context.Context, pod *corev1.Pod) error {
+	violations := securityContextViolations(pod, ztunnelCapabilities)
+	if len(violations) == 0 {
+		return nil
+	}
+	msg := "ztunnel cannot accept the redirected traffic, so pods are not redirected to it: " + strings.Join(violations, "; ")
+	s.recordPodWarning(ctx, pod, ztunnelSecurityContextReason, msg)
+	return fmt.Errorf("%w: %s", ErrSecurityPolicy, msg)
+}
+
+// podSecurityEnforceLabel is the label of namespaces setting the PodSecurity admission level enforced on their pods.
+const podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+
+// workloadSecurityPolicies returns the security policies confining a workload pod, which may deny the node agent
+// programming the redirection in its network namespace, such as entering it to find its interface in the ebpf
+// redirect mode: the PodSecurity admission level enforced in its namespace, and the SELinux, seccomp and AppArmor
+// profiles of its containers, from their effective security context.
+func workloadSecurityPolicies(pod *corev1.Pod, nsLabels map[string]string) []string {
+	var res []string
+	if level := nsLabels[podSecurityEnforceLabel]; level != "" {
+		res = append(res, fmt.Sprintf("the namespace enforces the %s PodSecurity level", level))
+	}
+	psc := pod.Spec.SecurityContext
+	if psc == nil {
+		psc = &corev1.PodSecurityContext{}
+	}
+	for _, c := range pod.Spec.Containers {
+		sc := c.SecurityContext
+		if sc == nil {
+			sc = &corev1.SecurityContext{}
+		}
+		selinux := sc.SELinuxOptions
+		if selinux == nil {
+			selinux = psc.SELinuxOptions
+		}
+		if selinux != nil && (selinux.Type != "" || selinux.Level != "") {
+			res = append(res, fmt.Sprintf("container %s has the SELinux type %q and level %q", c.Name, selinux.Type, selinux.Level))
+		}
+		seccomp := sc.SeccompProfile
+		if seccomp == nil {
+			seccomp = psc.SeccompProfile
+		}
+		if seccomp != nil && seccomp.Type == corev1.SeccompProfileTypeLocalhost {
+			res = append(res, fmt.Sprintf("container %s has the seccomp profile %s", c.Name, ptr.OrEmpty(seccomp.LocalhostProfile)))
+		}
+		if profile := pod.Annotations[corev1.AppArmorBetaContainerAnnotationKeyPrefix+c.Name]; strings.HasPrefix(profile,
+			corev1.AppArmorBetaProfileNamePrefix) {
+			res = append(res, fmt.Sprintf("container %s has the AppArmor profile %s", c.Name,
+				strings.TrimPrefix(profile, corev1.AppArmorBetaProfileNamePrefix)))
+		}
+	}
+	return res
+}
+
+// checkWorkloadSecurityContext checks the security context of a pod before it is added to the mesh, returning the
+// security policies confining it. A failure to program its redirection caused by a denied permission is then reported
+// with them, see withSecurityPolicies.
+func (s *Server) checkWorkloadSecurityContext(pod *corev1.Pod) []string {
+	var nsLabels map[string]string
+	if s.namespaces != nil {
+		if ns := s.namespaces.Get(pod.Namespace, ""); ns != nil {
+			nsLabels = ns.GetLabels()
+		}
+	}
+	return workloadSecurityPolicies(pod, nsLabels)
+}
+
+// withSecurityPolicies adds the security policies confining the pod to an error caused by a denied permission, so the
+// failure names the policies which may deny it.
+func withSecurityPolicies(err error, policies []string) error {
+	if err == nil || !errors.Is(err, ErrSecurityPolicy) {
+		return err
+	}
+	if len(policies) == 0 {
+		return fmt.Errorf("%w; the pod is not confined by security policies, so the ones of the node agent deny it", err)
+	}
+	return fmt.Errorf("%w; the pod is confined by security policies: %s", err, strings.Join(policies, "; "))
+}
@@ -0,0 +1,124 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/ptr"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestSecurityContextViolations(t *testing.T) {
+	netAdmin := &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}, Add: []corev1.Capability{"NET_ADMIN"}}
+	cases := []struct {
+		name       string
+		pod        *corev1.PodSecurityContext
+		container  *corev1.SecurityContext
+		violations int
+	}{
+		{
+			name:      "capability added",
+			container: &corev1.SecurityContext{Capabilities: netAdmin, RunAsUser: ptr.Of(int64(0))},
+		},
+		{
+			name:      "privileged",
+			container: &corev1.SecurityContext{Privileged: ptr.Of(true)},
+		},
+		{
+			name:       "no security context",
+			violations: 1,
+		},
+		{
+			name:       "capability dropped",
+			container:  &corev1.SecurityContext{Capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"CAP_NET_ADMIN"}}},
+			violations: 1,
+		},
+		{
+			name:       "non-root pod",
+			pod:        &corev1.PodSecurityContext{RunAsUser: ptr.Of(int64(1337))},
+			container:  &corev1.SecurityContext{Capabilities: netAdmin},
+			violations: 1,
+		},
+		{
+			name:      "root container in non-root pod",
+			pod:       &corev1.PodSecurityContext{RunAsUser: ptr.Of(int64(1337))},
+			container: &corev1.SecurityContext{Capabilities: netAdmin, RunAsUser: ptr.Of(int64(0))},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{Spec: corev1.PodSpec{
+				SecurityContext: tt.pod,
+				Containers:      []corev1.Container{{Name: "istio-proxy", SecurityContext: tt.container}},
+			}}
+			assert.Equal(t, len(securityContextViolations(pod, ztunnelCapabilities)), tt.violations)
+		})
+	}
+}
+
+func TestWorkloadSecurityPolicies(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			corev1.AppArmorBetaContainerAnnotationKeyPrefix + "app":     corev1.AppArmorBetaProfileNamePrefix + "app-profile",
+			corev1.AppArmorBetaContainerAnnotationKeyPrefix + "sidecar": corev1.AppArmorBetaProfileRuntimeDefault,
+		}},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{SELinuxOptions: &corev1.SELinuxOptions{Level: "s0:c1,c2"}},
+			Containers: []corev1.Container{
+				{Name: "app", SecurityContext: &corev1.SecurityContext{SeccompProfile: &corev1.SeccompProfile{
+					Type:             corev1.SeccompProfileTypeLocalhost,
+					LocalhostProfile: ptr.Of("app.json"),
+				}}},
+				{Name: "sidecar", SecurityContext: &corev1.SecurityContext{
+					SELinuxOptions: &corev1.SELinuxOptions{Type: "container_t"},
+					SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				}},
+			},
+		},
+	}
+	assert.Equal(t, workloadSecurityPolicies(pod, map[string]string{podSecurityEnforceLabel: "restricted"}), []string{
+		"the namespace enforces the restricted PodSecurity level",
+		`container app has the SELinux type "" and level "s0:c1,c2"`,
+		"container app has the seccomp profile app.json",
+		"container app has the AppArmor profile app-profile",
+		// The security context of the container overrides the one of the pod, and the default profiles are not listed
+		`container sidecar has the SELinux type "container_t" and level ""`,
+	})
+	assert.Equal(t, len(workloadSecurityPolicies(&corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}, nil)), 0)
+}
+
+func TestWithSecurityPolicies(t *testing.T) {
+	denied := withCause(fmt.Errorf("failed to enter the network namespace: %w", syscall.EACCES), ErrVethNotFound)
+	assert.Equal(t, errors.Is(denied, ErrSecurityPolicy), true)
+
+	// Failures caused by a denied permission name the policies confining the pod
+	err := withSecurityPolicies(denied, []string{"the namespace enforces the restricted PodSecurity level"})
+	assert.Equal(t, errors.Is(err, ErrSecurityPolicy), true)
+	assert.Equal(t, strings.Contains(err.Error(), "the pod is confined by security policies: the namespace enforces"), true)
+	assert.Equal(t, strings.Contains(withSecurityPolicies(denied, nil).Error(), "not confined by security policies"), true)
+
+	// Other failures are left as is
+	other := withCause(errors.New("no route"), ErrVethNotFound)
+	assert.Equal(t, withSecurityPolicies(other, []string{"policy"}), other)
+	assert.NoError(t, withSecurityPolicies(nil, []string{"policy"}))
+}
@@ -0,0 +1,363 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"reflect"
+	"sync"
+
+	"golang.org/x/sys/unix"
+	corev1 "k8s.io/api/core/v1"
+
+	ebpf "istio.io/istio/cni/pkg/ebpf/server"
+	"istio.io/istio/cni/pkg/features"
+	"istio.io/istio/pkg/lazy"
+)
+
+// The privileged operations of the node agent, programming the iptables rules, ipsets, routes, eBPF programs and
+// conntrack entries of the node and of the ztunnel network namespace, are all done by its Redirector. They can run in
+// a separate helper process, so the node agent watching the cluster runs without capabilities: the helper serves the
+// redirection on a Unix domain socket with ServePrivileged, and the node agent connects to it when
+// AmbientArgs.PrivilegedSocket is set. The helper has no access to the cluster, and only serves the operations of the
+// Redirector. The feature flags are not read from its environment either, but sent by the node agent with the
+// operations which use them, so the redirection follows the flags of the node agent.
+
+const privilegedServiceName = "Redirector"
+
+// PrivilegedConfig configures the redirection programmed by the privileged helper. The node agent resolves it, as
+// the helper has no access to the cluster, and sends it each time it connects to the helper.
+type PrivilegedConfig struct {
+	RedirectMode         RedirectMode
+	HostIP               string
+	Routing              RoutingConfig
+	NodeTraffic          NodeTrafficConfig
+	DNSCapture           DNSCaptureConfig
+	KubeProxyReplacement bool
+	ConntrackFlush       bool
+	LogLevel             string
+	Audit                AuditConfig
+	// Features are the values of the feature flags of the node agent which are only read on startup.
+	Features map[string]string
+}
+
+// PrivilegedPodArgs are the arguments of the AddPod and DelPod operations of the privileged helper.
+type PrivilegedPodArgs struct {
+	Pod *corev1.Pod
+}
+
+// PrivilegedZtunnelArgs are the arguments of the SetZtunnel operation of the privileged helper.
+type PrivilegedZtunnelArgs struct {
+	Ztunnel    *corev1.Pod
+	CaptureDNS bool
+	// Features are the current values of the reloadable feature flags of the node agent.
+	Features map[string]string
+}
+
+// ServePrivileged runs the privileged helper of a node agent, serving the redirection on the Unix domain socket until
+// the context is done. The helper must have the capabilities required by the redirect mode the node agent configures,
+// and fails right away without the ones required by all of them.
+func ServePrivileged(ctx context.Context, socket string) error {
+	missing, err := missingCapabilities(iptablesCapabilities)
+	if err != nil {
+		return fmt.Errorf("failed to check capabilities: %v", err)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("the privileged helper requires the missing capabilities %v", missing)
+	}
+	return servePrivileged(ctx, socket, newPrivilegedServer)
+}
+
+func servePrivileged(ctx context.Context, socket string, build func(context.Context, PrivilegedConfig) (*Server, error)) error {
+	svc := &privilegedService{ctx: ctx, build: build}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(privilegedServiceName, svc); err != nil {
+		return err
+	}
+	// A socket left by a previous helper would make listening fail
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove socket %s: %v", socket, err)
+	}
+	// Only the node agent, running as the same user, may connect. The socket is created with these permissions, rather
+	// than restricted once listening, so it is never reachable by others.
+	mask := unix.Umask(0o177)
+	l, err := net.Listen("unix", socket)
+	unix.Umask(mask)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", socket, err)
+	}
+	var mu sync.Mutex
+	conns := map[net.Conn]struct{}{}
+	go func() {
+		<-ctx.Done()
+		l.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for conn := range conns {
+			conn.Close()
+		}
+	}()
+	log.Infof("serving the privileged operations of the node agent on %s", socket)
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		mu.Lock()
+		conns[conn] = struct{}{}
+		mu.Unlock()
+		go func() {
+			// ServeCodec closes the connection once the node agent disconnects
+			srv.ServeCodec(jsonrpc.NewServerCodec(conn))
+			mu.Lock()
+			delete(conns, conn)
+			mu.Unlock()
+		}()
+	}
+}
+
+// newPrivilegedServer builds the Server programming the redirection in the privileged helper. Only the fields used
+// by the built-in Redirectors are set.
+func newPrivilegedServer(ctx context.Context, cfg PrivilegedConfig) (*Server, error) {
+	missing, err := missingCapabilities(requiredCapabilities(cfg.RedirectMode))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check capabilities: %v", err)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("the %v redirect mode requires the missing capabilities %v", cfg.RedirectMode, missing)
+	}
+	userns, err := detectUserNamespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect the user namespace: %v", err)
+	}
+	if err := userns.unsupported(cfg.RedirectMode); err != nil {
+		return nil, err
+	}
+	s := &Server{
+		ctx:                  ctx,
+		redirectMode:         cfg.RedirectMode,
+		conntrackFlush:       cfg.ConntrackFlush,
+		kubeProxyReplacement: cfg.KubeProxyReplacement,
+	}
+	setupAudit(ctx, nil, cfg.Audit, true)
+	s.iptablesCommand = lazy.New(func() (string, error) {
+		return s.detectIptablesCommand(), nil
+	})
+	switch cfg.RedirectMode {
+	case IptablesMode:
+		s.redirector = &iptablesRedirector{s: s}
+		HostIP = cfg.HostIP
+		Routing = cfg.Routing
+		s.nodeTraffic = cfg.NodeTraffic
+		s.dnsCapture = cfg.DNSCapture
+	case EbpfMode:
+		s.redirector = &ebpfRedirector{s: s}
+		s.ebpfServer = ebpf.NewRedirectServer()
+		s.ebpfServer.SetLogLevel(cfg.LogLevel)
+		s.ebpfServer.SetKubeProxyReplacement(s.kubeProxyReplacement)
+		s.ebpfServer.SetRequestHandler(auditEBPFRequest)
+		s.ebpfServer.Start(ctx.Done())
+	default:
+		return nil, fmt.Errorf("unknown redirect mode %v", cfg.RedirectMode)
+	}
+	if !s.kubeProxyReplacement && !s.kubeProxyDetected() {
+		log.Warnf("kube-proxy rules were not found on this node; if services are handled by the CNI with eBPF, " +
+			"enable the kube-proxy replacement compatibility mode so pods are captured before their traffic is translated")
+	}
+	return s, nil
+}
+
+// privilegedService serves the operations of the Redirector in the privileged helper.
+type privilegedService struct {
+	ctx   context.Context
+	build func(context.Context, PrivilegedConfig) (*Server, error)
+
+	mu     sync.Mutex
+	config *PrivilegedConfig
+	server *Server
+}
+
+// Configure configures the redirection. The node agent configures the helper each time it connects, so the same
+// configuration is accepted again; the redirection cannot be reconfigured differently without restarting the helper.
+func (p *privilegedService) Configure(cfg *PrivilegedConfig, _ *struct{}) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.config != nil {
+		if !reflect.DeepEqual(*p.config, *cfg) {
+			return fmt.Errorf("the privileged helper is already configured differently, it must be restarted")
+		}
+		return nil
+	}
+	// The first update applies all the flags, so the ones only read on startup are set before building the redirection
+	features.Update(cfg.Features)
+	s, err := p.build(p.ctx, *cfg)
+	if err != nil {
+		return err
+	}
+	log.Infof("privileged helper configured for the %v redirect mode", cfg.RedirectMode)
+	p.config, p.server = cfg, s
+	return nil
+}
+
+func (p *privilegedService) configured() (*Server, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.server == nil {
+		return nil, fmt.Errorf("the privileged helper is not configured")
+	}
+	return p.server, nil
+}
+
+func (p *privilegedService) AddPod(args *PrivilegedPodArgs, _ *struct{}) error {
+	s, err := p.configured()
+	if err != nil {
+		return err
+	}
+	if err := s.redirector.AddPod(p.ctx, args.Pod); err != nil {
+		return err
+	}
+	s.flushPodConntrack(args.Pod)
+	return nil
+}
+
+func (p *privilegedService) DelPod(args *PrivilegedPodArgs, _ *struct{}) error {
+	s, err := p.configured()
+	if err != nil {
+		return err
+	}
+	if err := s.redirector.DelPod(p.ctx, args.Pod); err != nil {
+		return err
+	}
+	s.flushPodConntrack(args.Pod)
+	return nil
+}
+
+func (p *privilegedService) SetZtunnel(args *PrivilegedZtunnelArgs, _ *struct{}) error {
+	s, err := p.configured()
+	if err != nil {
+		return err
+	}
+	features.Update(args.Features)
+	return s.redirector.SetZtunnel(args.Ztunnel, args.CaptureDNS)
+}
+
+func (p *privilegedService) Cleanup(_ *struct{}, _ *struct{}) error {
+	s, err := p.configured()
+	if err != nil {
+		return err
+	}
+	s.redirector.Cleanup()
+	return nil
+}
+
+// privilegedClient is the Redirector of a node agent whose redirection is programmed by the privileged helper.
+type privilegedClient struct {
+	socket string
+	config PrivilegedConfig
+
+	mu     sync.Mutex
+	client *rpc.Client
+}
+
+var _ Redirector = &privilegedClient{}
+
+func newPrivilegedClient(socket string, config PrivilegedConfig) *privilegedClient {
+	return &privilegedClient{socket: socket, config: config}
+}
+
+func (c *privilegedClient) AddPod(ctx context.Context, pod *corev1.Pod) error {
+	return c.call(ctx, "AddPod", &PrivilegedPodArgs{Pod: pod})
+}
+
+func (c *privilegedClient) DelPod(ctx context.Context, pod *corev1.Pod) error {
+	return c.call(ctx, "DelPod", &PrivilegedPodArgs{Pod: pod})
+}
+
+func (c *privilegedClient) SetZtunnel(ztunnel *corev1.Pod, captureDNS bool) error {
+	return c.call(context.Background(), "SetZtunnel", &PrivilegedZtunnelArgs{
+		Ztunnel:    ztunnel,
+		CaptureDNS: captureDNS,
+		Features:   features.Values(true),
+	})
+}
+
+func (c *privilegedClient) Cleanup() {
+	if err := c.call(context.Background(), "Cleanup", &struct{}{}); err != nil {
+		log.Errorf("failed to clean up the redirection: %v", err)
+	}
+}
+
+func (c *privilegedClient) call(ctx context.Context, method string, args any) error {
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+	call := client.Go(privilegedServiceName+"."+method, args, &struct{}{}, make(chan *rpc.Call, 1))
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-call.Done:
+		var serverErr rpc.ServerError
+		if res.Error != nil && !errors.As(res.Error, &serverErr) {
+			// The connection to the helper failed, it is reconnected on the next call
+			c.disconnect(client)
+			return fmt.Errorf("%w: %s failed: %v", ErrPrivilegedHelper, method, res.Error)
+		}
+		if res.Error != nil {
+			// Errors returned by the helper only have their message
+			return restoreEnrollmentError(res.Error.Error())
+		}
+		return nil
+	}
+}
+
+// connect returns the connection to the helper, connecting and configuring it if needed. The helper may have
+// restarted since the last connection, so it is configured on every connection.
+func (c *privilegedClient) connect() (*rpc.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil {
+		return c.client, nil
+	}
+	conn, err := net.Dial("unix", c.socket)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to connect to %s: %v", ErrPrivilegedHelper, c.socket, err)
+	}
+	client := jsonrpc.NewClient(conn)
+	if err := client.Call(privilegedServiceName+".Configure", &c.config, &struct{}{}); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("%w: failed to configure it: %v", ErrPrivilegedHelper, err)
+	}
+	c.client = client
+	return client, nil
+}
+
+func (c *privilegedClient) disconnect(client *rpc.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client == client {
+		c.client.Close()
+		c.client = nil
+	}
+}
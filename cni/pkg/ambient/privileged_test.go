@@ -0,0 +1,142 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/cni/pkg/features"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+)
+
+// recordingRedirector records the operations of the Redirector, which may be called concurrently.
+type recordingRedirector struct {
+	mu  sync.Mutex
+	ops []string
+}
+
+func (r *recordingRedirector) record(op string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ops = append(r.ops, op)
+}
+
+func (r *recordingRedirector) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.ops...)
+}
+
+func (r *recordingRedirector) AddPod(_ context.Context, pod *corev1.Pod) error {
+	r.record("add " + pod.Name)
+	return nil
+}
+
+func (r *recordingRedirector) DelPod(_ context.Context, pod *corev1.Pod) error {
+	r.record("del " + pod.Name)
+	return nil
+}
+
+func (r *recordingRedirector) SetZtunnel(ztunnel *corev1.Pod, _ bool) error {
+	r.record("ztunnel " + ztunnel.Name)
+	return nil
+}
+
+func (r *recordingRedirector) Cleanup() {
+	r.record("cleanup")
+}
+
+func TestPrivilegedHelper(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "privileged.sock")
+	pinning := features.Values(false)
+	serve := func() (*recordingRedirector, context.CancelFunc) {
+		redirector := &recordingRedirector{}
+		ctx, cancel := context.WithCancel(context.Background())
+		build := func(ctx context.Context, cfg PrivilegedConfig) (*Server, error) {
+			// The feature flags of the node agent are sent with the configuration
+			if !reflect.DeepEqual(cfg.Features, pinning) {
+				return nil, fmt.Errorf("unexpected feature flags %v", cfg.Features)
+			}
+			return &Server{ctx: ctx, redirectMode: cfg.RedirectMode, redirector: redirector}, nil
+		}
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if err := servePrivileged(ctx, socket, build); err != nil {
+				t.Error(err)
+			}
+		}()
+		return redirector, func() {
+			cancel()
+			<-done
+		}
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"}}
+	ztunnel := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ztunnel", Namespace: "istio-system"}}
+	ctx := context.Background()
+
+	redirector, stop := serve()
+	client := newPrivilegedClient(socket, PrivilegedConfig{RedirectMode: IptablesMode, HostIP: "10.0.0.1", Features: pinning})
+	retry.UntilSuccessOrFail(t, func() error {
+		return client.AddPod(ctx, pod)
+	})
+	// The socket is only accessible to the user of the helper
+	info, err := os.Stat(socket)
+	assert.NoError(t, err)
+	assert.Equal(t, info.Mode().Perm(), os.FileMode(0o600))
+	assert.NoError(t, client.SetZtunnel(ztunnel, true))
+	assert.NoError(t, client.DelPod(ctx, pod))
+	client.Cleanup()
+	assert.Equal(t, redirector.recorded(), []string{"add pod", "ztunnel ztunnel", "del pod", "cleanup"})
+
+	// The helper cannot be reconfigured differently
+	other := newPrivilegedClient(socket, PrivilegedConfig{RedirectMode: EbpfMode})
+	err = other.AddPod(ctx, pod)
+	assert.Error(t, err)
+	assert.Equal(t, strings.Contains(err.Error(), "configured differently"), true)
+
+	// The client reconnects to, and configures, a restarted helper
+	stop()
+	redirector, stop = serve()
+	defer stop()
+	retry.UntilSuccessOrFail(t, func() error {
+		return client.AddPod(ctx, pod)
+	})
+	assert.Equal(t, redirector.recorded(), []string{"add pod"})
+}
+
+func TestParseEffectiveCapabilities(t *testing.T) {
+	status := "Name:\tistio-cni\nCapInh:\t0000000000000000\nCapEff:\t0000000000003000\nCapBnd:\t000001ffffffffff\n"
+	caps, err := parseEffectiveCapabilities(bufio.NewScanner(strings.NewReader(status)))
+	assert.NoError(t, err)
+	assert.Equal(t, caps&(1<<capNetAdmin.bit) != 0, true)
+	assert.Equal(t, caps&(1<<capNetRaw.bit) != 0, true)
+	assert.Equal(t, caps&(1<<capSysAdmin.bit) != 0, false)
+
+	_, err = parseEffectiveCapabilities(bufio.NewScanner(strings.NewReader("Name:\tistio-cni\n")))
+	assert.Error(t, err)
+}
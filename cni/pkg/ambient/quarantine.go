@@ -0,0 +1,90 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pkg/kube/controllers"
+)
+
+// quarantinedPods are the pods whose reconciliation panicked. Their events are skipped until the pod changes, so a
+// pod the node agent cannot handle is not retried in a loop, while the other pods of the node are still enrolled.
+type quarantinedPods struct {
+	mu   sync.Mutex
+	pods map[types.UID]string // pod UID -> resource version which panicked
+}
+
+func newQuarantinedPods() *quarantinedPods {
+	return &quarantinedPods{pods: map[types.UID]string{}}
+}
+
+func (q *quarantinedPods) add(o controllers.Object) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pods[o.GetUID()] = o.GetResourceVersion()
+	quarantinedPodsGauge.Record(float64(len(q.pods)))
+}
+
+func (q *quarantinedPods) remove(o controllers.Object) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.pods, o.GetUID())
+	quarantinedPodsGauge.Record(float64(len(q.pods)))
+}
+
+// contains returns whether the pod is quarantined. A new version of a quarantined pod is released from quarantine.
+func (q *quarantinedPods) contains(o controllers.Object) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	version, ok := q.pods[o.GetUID()]
+	if !ok {
+		return false
+	}
+	if version != o.GetResourceVersion() {
+		delete(q.pods, o.GetUID())
+		quarantinedPodsGauge.Record(float64(len(q.pods)))
+		return false
+	}
+	return true
+}
+
+// recoverReconcile recovers from a panic reconciling an item of the queue, converting it to an error, and quarantines
+// the pod of the event. It must be deferred directly.
+func (s *Server) recoverReconcile(input any, err *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	event, ok := input.(controllers.Event)
+	if !ok {
+		reconcilePanics.With(typeLabel.Value("unknown")).Increment()
+		log.Errorf("panic reconciling %T: %v\n%s", input, r, debug.Stack())
+		*err = fmt.Errorf("panic reconciling %T: %v", input, r)
+		return
+	}
+	reconcilePanics.With(typeLabel.Value(event.Event.String())).Increment()
+	pod := event.Latest()
+	log.Errorf("panic reconciling %s of pod %s/%s: %v\n%s", event.Event, pod.GetNamespace(), pod.GetName(), r, debug.Stack())
+	// Deleted pods will not change anymore, so they are not quarantined
+	if event.Event != controllers.EventDelete {
+		s.quarantinedPods.add(pod)
+	}
+	*err = fmt.Errorf("panic reconciling %s of pod %s/%s: %v", event.Event, pod.GetNamespace(), pod.GetName(), r)
+}
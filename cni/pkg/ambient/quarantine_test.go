@@ -0,0 +1,56 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestReconcilePanic(t *testing.T) {
+	for _, timeout := range []time.Duration{0, time.Minute} {
+		t.Run(timeout.String(), func(t *testing.T) {
+			s := &Server{ctx: context.Background(), reconcileCtx: context.Background(), quarantinedPods: newQuarantinedPods(), reconcileTimeout: timeout}
+			// Events of objects other than pods panic
+			bad := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "bad", UID: "uid", ResourceVersion: "1"}}
+			event := controllers.Event{New: bad, Event: controllers.EventAdd}
+
+			assert.Error(t, s.Reconcile(event))
+			assert.Equal(t, s.quarantinedPods.contains(bad), true)
+			// Retries of a quarantined pod are skipped
+			assert.NoError(t, s.Reconcile(event))
+
+			// A new version of the pod is reconciled again
+			changed := bad.DeepCopy()
+			changed.ResourceVersion = "2"
+			assert.Error(t, s.Reconcile(controllers.Event{Old: bad, New: changed, Event: controllers.EventUpdate}))
+			assert.Equal(t, s.quarantinedPods.contains(changed), true)
+
+			// Deleting the pod releases it from quarantine
+			assert.Error(t, s.Reconcile(controllers.Event{Old: changed, Event: controllers.EventDelete}))
+			assert.Equal(t, s.quarantinedPods.contains(changed), false)
+
+			// Items which are not events are reported as errors
+			assert.Error(t, s.Reconcile("bad"))
+		})
+	}
+}
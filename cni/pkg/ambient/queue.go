@@ -0,0 +1,88 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"istio.io/istio/pkg/kube/controllers"
+)
+
+// workQueue reconciles pod events with several workers. Each worker has its own queue, and the events of a pod are
+// always added to the queue of the same worker, so they are reconciled in order, and never concurrently.
+type workQueue struct {
+	queues []controllers.Queue
+}
+
+func newWorkQueue(workers int, reconcile func(key any) error) *workQueue {
+	if workers < 1 {
+		workers = 1
+	}
+	q := &workQueue{}
+	for i := 0; i < workers; i++ {
+		name := "ambient"
+		if workers > 1 {
+			name = fmt.Sprintf("ambient-%d", i)
+		}
+		q.queues = append(q.queues, controllers.NewQueue(name,
+			controllers.WithGenericReconciler(reconcile),
+			controllers.WithMaxAttempts(5),
+		))
+	}
+	return q
+}
+
+// Add adds a pod event to the queue of the worker of the pod.
+func (q *workQueue) Add(e controllers.Event) {
+	if len(q.queues) == 1 {
+		q.queues[0].Add(e)
+		return
+	}
+	o := e.Latest()
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(o.GetNamespace() + "/" + o.GetName()))
+	q.queues[h.Sum32()%uint32(len(q.queues))].Add(e)
+}
+
+// Run runs the workers until stopped.
+func (q *workQueue) Run(stop <-chan struct{}) {
+	var wg sync.WaitGroup
+	for _, queue := range q.queues {
+		queue := queue
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			queue.Run(stop)
+		}()
+	}
+	wg.Wait()
+}
+
+// ShutDownWithDrain shuts down the workers, and waits until the events being reconciled are done. The events which are
+// not reconciled yet are dropped.
+func (q *workQueue) ShutDownWithDrain() {
+	var wg sync.WaitGroup
+	for _, queue := range q.queues {
+		queue := queue
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			queue.ShutDownWithDrain()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,128 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+)
+
+func podEvent(name string, version int) controllers.Event {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", ResourceVersion: fmt.Sprint(version)}}
+	return controllers.Event{New: pod, Event: controllers.EventUpdate}
+}
+
+func TestWorkQueuePerPodOrdering(t *testing.T) {
+	var mu sync.Mutex
+	running := map[string]bool{}
+	versions := map[string][]string{}
+	concurrent := atomic.NewBool(false)
+	done := atomic.NewInt32(0)
+	q := newWorkQueue(4, func(key any) error {
+		pod := key.(controllers.Event).Latest()
+		mu.Lock()
+		if running[pod.GetName()] {
+			concurrent.Store(true)
+		}
+		running[pod.GetName()] = true
+		versions[pod.GetName()] = append(versions[pod.GetName()], pod.GetResourceVersion())
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		running[pod.GetName()] = false
+		mu.Unlock()
+		done.Inc()
+		return nil
+	})
+	for v := 0; v < 5; v++ {
+		for p := 0; p < 10; p++ {
+			q.Add(podEvent(fmt.Sprintf("pod-%d", p), v))
+		}
+	}
+	go q.Run(test.NewStop(t))
+	retry.UntilOrFail(t, func() bool { return done.Load() == 50 }, retry.Timeout(10*time.Second))
+
+	assert.Equal(t, concurrent.Load(), false)
+	for p := 0; p < 10; p++ {
+		assert.Equal(t, versions[fmt.Sprintf("pod-%d", p)], []string{"0", "1", "2", "3", "4"})
+	}
+}
+
+func TestWorkQueueShutDownWithDrain(t *testing.T) {
+	started := atomic.NewInt32(0)
+	done := atomic.NewInt32(0)
+	release := make(chan struct{})
+	q := newWorkQueue(4, func(key any) error {
+		started.Inc()
+		<-release
+		done.Inc()
+		return nil
+	})
+	for p := 0; p < 10; p++ {
+		q.Add(podEvent(fmt.Sprintf("pod-%d", p), 0))
+	}
+	go q.Run(test.NewStop(t))
+	retry.UntilOrFail(t, func() bool { return started.Load() > 0 }, retry.Timeout(10*time.Second))
+
+	drained := atomic.NewBool(false)
+	go func() {
+		q.ShutDownWithDrain()
+		drained.Store(true)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, drained.Load(), false)
+	close(release)
+	retry.UntilOrFail(t, drained.Load, retry.Timeout(10*time.Second))
+	// The events being reconciled were done, and the others were dropped
+	assert.Equal(t, done.Load(), started.Load())
+	assert.Equal(t, started.Load() < 10, true)
+}
+
+// BenchmarkWorkQueue measures the time to enroll all the pods of a node, such as after the node agent restarts, with
+// each pod taking a millisecond to enroll.
+func BenchmarkWorkQueue(b *testing.B) {
+	const pods = 200
+	for _, workers := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("workers-%d", workers), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				var wg sync.WaitGroup
+				wg.Add(pods)
+				q := newWorkQueue(workers, func(any) error {
+					time.Sleep(time.Millisecond)
+					wg.Done()
+					return nil
+				})
+				for p := 0; p < pods; p++ {
+					q.Add(podEvent(fmt.Sprintf("pod-%d", p), 0))
+				}
+				stop := make(chan struct{})
+				go q.Run(stop)
+				wg.Wait()
+				close(stop)
+			}
+		})
+	}
+}
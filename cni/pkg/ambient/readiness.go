@@ -0,0 +1,147 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ztunnelReadinessPort is the port ztunnel serves its readiness endpoint on.
+	ztunnelReadinessPort = 15021
+	// ztunnelReadinessPath reports ready once ztunnel has its certificates and workloads, and accepts connections.
+	ztunnelReadinessPath = "/healthz/ready"
+)
+
+// ztunnelReadiness tracks whether ztunnel pods can actually accept connections. The pod Ready condition
+// lags behind, or may not reflect, what the node sees: it is only updated on the kubelet probe period, and
+// is checked from the kubelet rather than through the node routes. Pods are only considered ready once their
+// readiness endpoint has succeeded; until then, the endpoint is watched and onReady is called when it does.
+type ztunnelReadiness struct {
+	ctx      context.Context
+	probe    func(ctx context.Context, pod *corev1.Pod) error
+	interval time.Duration
+	onReady  func(pod *corev1.Pod)
+
+	mu       sync.Mutex
+	ready    map[types.UID]bool
+	watching map[types.UID]context.CancelFunc
+}
+
+func newZtunnelReadiness(ctx context.Context, onReady func(pod *corev1.Pod)) *ztunnelReadiness {
+	client := &http.Client{Timeout: time.Second}
+	return &ztunnelReadiness{
+		ctx: ctx,
+		probe: func(ctx context.Context, pod *corev1.Pod) error {
+			return probeZtunnelReadiness(ctx, client, pod)
+		},
+		interval: time.Second,
+		onReady:  onReady,
+		ready:    map[types.UID]bool{},
+		watching: map[types.UID]context.CancelFunc{},
+	}
+}
+
+// Ready returns whether the pod's readiness endpoint has succeeded. If it has not, the endpoint is watched
+// until it does.
+func (r *ztunnelReadiness) Ready(pod *corev1.Pod) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ready[pod.UID] {
+		return true
+	}
+	if _, f := r.watching[pod.UID]; !f {
+		ctx, cancel := context.WithCancel(r.ctx)
+		r.watching[pod.UID] = cancel
+		go r.watch(ctx, pod)
+	}
+	return false
+}
+
+// Retain forgets all pods but the given ones, and stops watching them.
+func (r *ztunnelReadiness) Retain(pods []*corev1.Pod) {
+	keep := map[types.UID]bool{}
+	for _, p := range pods {
+		keep[p.UID] = true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for uid := range r.ready {
+		if !keep[uid] {
+			delete(r.ready, uid)
+		}
+	}
+	for uid, cancel := range r.watching {
+		if !keep[uid] {
+			cancel()
+			delete(r.watching, uid)
+		}
+	}
+}
+
+func (r *ztunnelReadiness) watch(ctx context.Context, pod *corev1.Pod) {
+	for {
+		err := r.probe(ctx, pod)
+		if err == nil {
+			break
+		}
+		log.Debugf("ztunnel pod %s not ready: %v", pod.Name, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+	r.mu.Lock()
+	if _, f := r.watching[pod.UID]; !f {
+		// Forgotten while probing
+		r.mu.Unlock()
+		return
+	}
+	delete(r.watching, pod.UID)
+	r.ready[pod.UID] = true
+	r.mu.Unlock()
+	log.Infof("ztunnel pod %s is ready to accept connections", pod.Name)
+	r.onReady(pod)
+}
+
+func probeZtunnelReadiness(ctx context.Context, client *http.Client, pod *corev1.Pod) error {
+	if pod.Status.PodIP == "" {
+		return fmt.Errorf("no pod IP")
+	}
+	url := "http://" + net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(ztunnelReadinessPort)) + ztunnelReadinessPath
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("readiness endpoint returned %v", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/atomic"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestZtunnelReadiness(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	healthy := atomic.NewBool(false)
+	notified := make(chan string, 10)
+	r := newZtunnelReadiness(ctx, func(pod *corev1.Pod) {
+		notified <- pod.Name
+	})
+	r.interval = time.Millisecond
+	r.probe = func(ctx context.Context, pod *corev1.Pod) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !healthy.Load() {
+			return errors.New("not ready")
+		}
+		return nil
+	}
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, UID: types.UID("uid-" + name)}}
+	}
+
+	ztunnel := pod("ztunnel")
+	assert.Equal(t, r.Ready(ztunnel), false)
+	// Calling again does not start another watch
+	assert.Equal(t, r.Ready(ztunnel), false)
+
+	healthy.Store(true)
+	assert.Equal(t, <-notified, "ztunnel")
+	assert.Equal(t, r.Ready(ztunnel), true)
+	assert.ChannelIsEmpty(t, notified)
+
+	// Forgotten pods have to be probed again, and stop being watched
+	r.Retain(nil)
+	healthy.Store(false)
+	assert.Equal(t, r.Ready(ztunnel), false)
+	r.Retain(nil)
+	healthy.Store(true)
+	time.Sleep(time.Millisecond * 10)
+	assert.ChannelIsEmpty(t, notified)
+
+	// Watches end when the agent stops
+	other := pod("other")
+	healthy.Store(false)
+	assert.Equal(t, r.Ready(other), false)
+	cancel()
+	healthy.Store(true)
+	time.Sleep(time.Millisecond * 10)
+	assert.ChannelIsEmpty(t, notified)
+}
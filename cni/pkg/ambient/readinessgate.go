@@ -0,0 +1,102 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/istio/cni/pkg/ambient/ambientpod"
+	pconstants "istio.io/istio/pkg/config/constants"
+)
+
+// Reasons of the ambient readiness gate condition.
+const (
+	readinessGateCaptured    = "Captured"
+	readinessGateNotCaptured = "NotCaptured"
+)
+
+// releaseReadinessGate sets the ambient readiness gate condition of a pod declaring it, once the pod is redirected to
+// ztunnel, or once it is known not to be captured. Pods start before the node agent redirects them when ztunnel is not
+// ready on the node, so declaring the readiness gate keeps such pods from receiving traffic until they are captured.
+// The condition is only set once: pods stay ready if ztunnel restarts, or if they are later removed from the mesh.
+func (s *Server) releaseReadinessGate(ctx context.Context, pod *corev1.Pod) error {
+	if !s.readinessGate || !hasReadinessGate(pod) || readinessGateReleased(pod) {
+		return nil
+	}
+	ns := s.namespaces.Get(pod.Namespace, "")
+	if ns == nil {
+		return fmt.Errorf("failed to find namespace %v", pod.Namespace)
+	}
+	reason := readinessGateNotCaptured
+	if ambientpod.PodZtunnelEnabled(ns, pod) && s.hostPortsCaptured(pod) && s.initContainersCaptured(pod) {
+		if !s.isZTunnelRunning() || !s.podEnrolled(pod) {
+			// Released by the event of the pod once it is enrolled
+			return nil
+		}
+		reason = readinessGateCaptured
+	}
+	log.Debugf("releasing readiness gate of pod %s/%s: %s", pod.Namespace, pod.Name, reason)
+	return setReadinessGateCondition(ctx, s.kubeClient.Kube(), pod, reason)
+}
+
+func hasReadinessGate(pod *corev1.Pod) bool {
+	for _, g := range pod.Spec.ReadinessGates {
+		if g.ConditionType == pconstants.AmbientReadinessGate {
+			return true
+		}
+	}
+	return false
+}
+
+func readinessGateReleased(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == pconstants.AmbientReadinessGate {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func setReadinessGateCondition(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod, reason string) error {
+	return setPodCondition(ctx, client, pod, corev1.PodCondition{
+		Type:   pconstants.AmbientReadinessGate,
+		Status: corev1.ConditionTrue,
+		Reason: reason,
+	})
+}
+
+// setPodCondition sets a condition of a pod, as of now.
+func setPodCondition(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod, cond corev1.PodCondition) error {
+	cond.LastTransitionTime = metav1.Now()
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"conditions": []corev1.PodCondition{cond},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	// Conditions are merged by type, so the conditions set by the kubelet are kept
+	_, err = client.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.StrategicMergePatchType, patch,
+		metav1.PatchOptions{}, "status")
+	return err
+}
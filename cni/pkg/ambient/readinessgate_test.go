@@ -0,0 +1,113 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestReleaseReadinessGate(t *testing.T) {
+	gate := []corev1.PodReadinessGate{{ConditionType: constants.AmbientReadinessGate}}
+	cases := []struct {
+		name      string
+		namespace string
+		gates     []corev1.PodReadinessGate
+		ztunnel   bool
+		reason    string
+	}{
+		{
+			name:      "captured",
+			namespace: "ambient",
+			gates:     gate,
+			ztunnel:   true,
+			reason:    readinessGateCaptured,
+		},
+		{
+			name:      "no ztunnel",
+			namespace: "ambient",
+			gates:     gate,
+		},
+		{
+			name:      "not captured",
+			namespace: "default",
+			gates:     gate,
+			reason:    readinessGateNotCaptured,
+		},
+		{
+			name:      "no readiness gate",
+			namespace: "ambient",
+			ztunnel:   true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ambientNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   "ambient",
+				Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+			}}
+			defaultNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: tt.namespace, UID: "uid"},
+				Spec:       corev1.PodSpec{ReadinessGates: tt.gates},
+				Status: corev1.PodStatus{
+					Phase:      corev1.PodRunning,
+					PodIP:      "10.0.0.1",
+					Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}},
+				},
+			}
+			client := kube.NewFakeClient(ambientNs, defaultNs, pod)
+			s := &Server{
+				ctx:           context.Background(),
+				kubeClient:    client,
+				pods:          kclient.New[*corev1.Pod](client),
+				namespaces:    kclient.NewUntyped(client, client.KubeInformer().Core().V1().Namespaces().Informer(), kclient.Filter{}),
+				redirectMode:  ExternalMode,
+				redirector:    &fakeRedirector{},
+				desiredState:  newDesiredState(),
+				readinessGate: true,
+			}
+			if tt.ztunnel {
+				s.ztunnelPod = &corev1.Pod{}
+			}
+			client.RunAndWait(test.NewStop(t))
+
+			assert.NoError(t, s.reconcilePod(s.ctx, controllers.Event{New: pod, Event: controllers.EventAdd}))
+			got, err := client.Kube().CoreV1().Pods(pod.Namespace).Get(s.ctx, pod.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			reason := ""
+			scheduled := false
+			for _, c := range got.Status.Conditions {
+				if c.Type == constants.AmbientReadinessGate && c.Status == corev1.ConditionTrue {
+					reason = c.Reason
+				}
+				scheduled = scheduled || c.Type == corev1.PodScheduled
+			}
+			assert.Equal(t, reason, tt.reason)
+			// Conditions set by others are kept
+			assert.Equal(t, scheduled, true)
+		})
+	}
+}
@@ -0,0 +1,354 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+
+	"istio.io/istio/cni/pkg/ambient/ambientpod"
+	"istio.io/istio/cni/pkg/ambient/constants"
+	"istio.io/istio/cni/pkg/ambient/redirectdump"
+	"istio.io/istio/cni/pkg/features"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// artifact is a redirection artifact, along with the pod IP it redirects, if any.
+type artifact struct {
+	ip    string
+	value string
+}
+
+// artifactLister is implemented by the Redirectors which can list the artifacts they configure, so the redirection
+// configured on the node can be compared with the desired one.
+type artifactLister interface {
+	// desiredPodArtifacts returns the artifacts redirecting the traffic of a pod in the mesh.
+	desiredPodArtifacts(pod *corev1.Pod) []artifact
+	// desiredNodeArtifacts returns the artifacts configured on the node for the active ztunnel.
+	desiredNodeArtifacts(ztunnel *corev1.Pod) []string
+	// actualArtifacts returns the artifacts configured on the node, attributed to the pod IPs they redirect, if any.
+	actualArtifacts() ([]artifact, error)
+}
+
+// RegisterAdminHandlers serves the handlers changing the redirection of the node on the mux, such as its flush and
+// the bypass of its pods, and the captures of the traffic of its pods. They must only be served to the administrators
+// of the node, as they take pods out of the mesh or read their traffic.
+func (s *Server) RegisterAdminHandlers(mux *http.ServeMux) {
+	s.registerFlushHandler(mux)
+	s.registerBypassHandler(mux)
+	s.registerCaptureHandler(mux)
+}
+
+// RegisterDebugHandlers serves the redirect dump and the desired state of the node, the enrollment of its workloads,
+// and the state of the feature flags, on the mux.
+func (s *Server) RegisterDebugHandlers(mux *http.ServeMux) {
+	mux.HandleFunc(redirectdump.Path, func(w http.ResponseWriter, _ *http.Request) {
+		dump, err := s.RedirectDump()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		b, err := json.MarshalIndent(dump, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(b); err != nil {
+			log.Debugf("failed to write redirect dump response: %v", err)
+		}
+	})
+	mux.HandleFunc(redirectdump.StatePath, func(w http.ResponseWriter, _ *http.Request) {
+		b, err := json.MarshalIndent(s.DesiredState(), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(b); err != nil {
+			log.Debugf("failed to write desired state response: %v", err)
+		}
+	})
+	mux.HandleFunc("/debug/enrollment", func(w http.ResponseWriter, _ *http.Request) {
+		b, err := json.MarshalIndent(s.WorkloadEnrollments(), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(b); err != nil {
+			log.Debugf("failed to write workload enrollment response: %v", err)
+		}
+	})
+	mux.HandleFunc("/debug/features", func(w http.ResponseWriter, _ *http.Request) {
+		b, err := json.MarshalIndent(features.States(), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(b); err != nil {
+			log.Debugf("failed to write feature flags response: %v", err)
+		}
+	})
+}
+
+// RedirectDump returns the desired and actual redirection of the pods of the node.
+func (s *Server) RedirectDump() (*redirectdump.Dump, error) {
+	lister, ok := s.redirector.(artifactLister)
+	if !ok {
+		return nil, fmt.Errorf("the %v redirect mode does not support dumping the redirection", s.redirectMode)
+	}
+	actual, err := lister.actualArtifacts()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	ztunnel := s.ztunnelPod
+	s.mu.Unlock()
+
+	dump := &redirectdump.Dump{Node: NodeName, RedirectMode: s.redirectMode.String()}
+	if ztunnel != nil {
+		dump.NodeArtifacts.Desired = lister.desiredNodeArtifacts(ztunnel)
+	}
+	nodeDesired := sets.New(dump.NodeArtifacts.Desired...)
+	byIP := map[string]*redirectdump.Pod{}
+	for _, pod := range s.pods.List(metav1.NamespaceAll, klabels.Everything()) {
+		if pod.Spec.HostNetwork || ztunnelPod(pod) || podCompleted(pod) {
+			continue
+		}
+		programmed, enrolled := s.desiredState.podArtifacts(pod.UID)
+		inMesh := false
+		if ns := s.namespaces.Get(pod.Namespace, ""); ns != nil {
+			inMesh = ztunnel != nil && ambientpod.PodZtunnelEnabled(ns, pod) && s.hostPortsCaptured(pod) &&
+				s.initContainersCaptured(pod)
+		}
+		if !enrolled && !inMesh {
+			continue
+		}
+		p := redirectdump.Pod{Namespace: pod.Namespace, Name: pod.Name, IPs: podIPs(pod)}
+		switch {
+		case inMesh && enrolled:
+			p.Desired = programmed
+		case inMesh:
+			// Pods which are not enrolled yet, or whose enrollment failed
+			for _, a := range lister.desiredPodArtifacts(pod) {
+				p.Desired = append(p.Desired, a.value)
+			}
+		}
+		dump.Pods = append(dump.Pods, p)
+		idx := len(dump.Pods) - 1
+		for _, ip := range p.IPs {
+			byIP[ip] = &dump.Pods[idx]
+		}
+	}
+	// Artifacts are attributed to the pods by IP, so artifacts of a pod which are not desired are reported as extra
+	for _, a := range actual {
+		switch {
+		case a.ip != "" && byIP[a.ip] != nil:
+			byIP[a.ip].Actual = append(byIP[a.ip].Actual, a.value)
+		case a.ip == "" && nodeDesired.Contains(a.value):
+			dump.NodeArtifacts.Actual = append(dump.NodeArtifacts.Actual, a.value)
+		default:
+			dump.Unowned = append(dump.Unowned, a.value)
+		}
+	}
+	dump.Sort()
+	return dump, nil
+}
+
+var _ artifactLister = &iptablesRedirector{}
+
+func (r *iptablesRedirector) desiredPodArtifacts(pod *corev1.Pod) []artifact {
+	// Only the IPv4 addresses of pods are redirected
+	ips, _ := iptablesPodIPs(pod, "")
+	var res []artifact
+	for _, ip := range ips {
+		res = append(res, artifact{ip: ip, value: ipsetArtifact(ip)})
+		if rte, err := buildRouteFromPod(pod, ip); err == nil {
+			res = append(res, artifact{ip: ip, value: "route " + strings.Join(rte, " ")})
+		}
+	}
+	return res
+}
+
+func (r *iptablesRedirector) desiredNodeArtifacts(ztunnel *corev1.Pod) []string {
+	res := []string{"link " + constants.InboundTun, "link " + constants.OutboundTun}
+	r.s.mu.Lock()
+	for _, rule := range r.s.nodeRules {
+		res = append(res, iptablesArtifact(rule))
+	}
+	r.s.mu.Unlock()
+	for _, f := range familiesOf(podIPs(ztunnel)) {
+		res = append(res, desiredRuleArtifacts(f)...)
+		if r.s.nodeTraffic.captured() {
+			res = append(res, desiredNodeTrafficRuleArtifacts(f)...)
+		}
+	}
+	return res
+}
+
+func (r *iptablesRedirector) actualArtifacts() ([]artifact, error) {
+	var res []artifact
+	entries, err := Ipset.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		res = append(res, artifact{ip: e.IP.String(), value: ipsetArtifact(e.IP.String())})
+	}
+
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: Routing.inboundTable()}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes: %v", err)
+	}
+	inbound, err := netlink.LinkByName(constants.InboundTun)
+	if err == nil {
+		for _, rte := range routes {
+			// The inbound table also routes to ztunnel itself, which is not a pod route
+			if rte.LinkIndex != inbound.Attrs().Index || rte.Dst == nil {
+				continue
+			}
+			res = append(res, artifact{ip: rte.Dst.IP.String(), value: routeArtifact(rte)})
+		}
+	}
+
+	for _, name := range []string{constants.InboundTun, constants.OutboundTun} {
+		if _, err := netlink.LinkByName(name); err == nil {
+			res = append(res, artifact{value: "link " + name})
+		}
+	}
+	// iptables rules are formatted differently when listed, so they are checked instead
+	r.s.mu.Lock()
+	rules := r.s.nodeRules
+	r.s.mu.Unlock()
+	for _, rule := range rules {
+		if execute(r.s.IptablesCmd(), append([]string{"-t", rule.Table, "-C", rule.Chain}, rule.RuleSpec...)...) == nil {
+			res = append(res, artifact{value: iptablesArtifact(rule)})
+		}
+	}
+
+	ipRules, err := netlink.RuleList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rules: %v", err)
+	}
+	priorities := sets.New(Routing.priorities()...)
+	if r.s.nodeTraffic.captured() {
+		priorities.InsertAll(nodeTrafficRulePriorities()...)
+	}
+	for _, rule := range ipRules {
+		if priorities.Contains(rule.Priority) {
+			res = append(res, artifact{value: ruleArtifact(rule)})
+		}
+	}
+	return res, nil
+}
+
+var _ artifactLister = &ebpfRedirector{}
+
+func (r *ebpfRedirector) desiredPodArtifacts(pod *corev1.Pod) []artifact {
+	// Only the first IPv4 address of pods is redirected
+	for _, ip := range podIPs(pod) {
+		if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() != nil {
+			return []artifact{{ip: ip, value: "ebpf app " + ip}}
+		}
+	}
+	return nil
+}
+
+func (r *ebpfRedirector) desiredNodeArtifacts(*corev1.Pod) []string {
+	return []string{"ebpf ztunnel"}
+}
+
+func (r *ebpfRedirector) actualArtifacts() ([]artifact, error) {
+	var res []artifact
+	addrs, err := r.s.ebpfServer.AppAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, addr := range addrs {
+		res = append(res, artifact{ip: addr.String(), value: "ebpf app " + addr.String()})
+	}
+	if r.s.ebpfServer.HasZtunnel() {
+		res = append(res, artifact{value: "ebpf ztunnel"})
+	}
+	return res, nil
+}
+
+func ipsetArtifact(ip string) string {
+	return "ipset " + Ipset.Name + " " + ip
+}
+
+func iptablesArtifact(rule *iptablesRule) string {
+	return "iptables -t " + rule.Table + " -A " + rule.Chain + " " + strings.Join(rule.RuleSpec, " ")
+}
+
+// routeArtifact formats a pod route like buildRouteFromPod.
+func routeArtifact(rte netlink.Route) string {
+	res := fmt.Sprintf("route table %d %s via %s dev %s", rte.Table, rte.Dst, rte.Gw, constants.InboundTun)
+	if rte.Src != nil {
+		res += " src " + rte.Src.String()
+	}
+	return res
+}
+
+// desiredRuleArtifacts returns the ip rules of buildNodeRoutes for the family, formatted like ruleArtifact.
+func desiredRuleArtifacts(f ipFamily) []string {
+	p := Routing.priorities()
+	return []string{
+		fmt.Sprintf("rule %s priority %d fwmark %s goto %d", f.ipFlag, p[0], Routing.fwmark(constants.SkipMask), mainRulePriority),
+		fmt.Sprintf("rule %s priority %d fwmark %s lookup %d", f.ipFlag, p[1], Routing.fwmark(constants.OutboundMask), Routing.outboundTable()),
+		fmt.Sprintf("rule %s priority %d fwmark %s lookup %d", f.ipFlag, p[2], Routing.fwmark(constants.ProxyRetMask), Routing.proxyTable()),
+		fmt.Sprintf("rule %s priority %d lookup %d", f.ipFlag, p[3], Routing.inboundTable()),
+	}
+}
+
+// desiredNodeTrafficRuleArtifacts returns the ip rules of buildNodeTrafficRoutes for the family, formatted like
+// ruleArtifact.
+func desiredNodeTrafficRuleArtifacts(f ipFamily) []string {
+	p := nodeTrafficRulePriorities()
+	return []string{
+		fmt.Sprintf("rule %s priority %d fwmark %s lookup %d", f.ipFlag, p[0], Routing.fwmark(constants.OutboundMask), Routing.outboundTable()),
+		fmt.Sprintf("rule %s priority %d lookup %d", f.ipFlag, p[1], unix.RT_TABLE_LOCAL),
+	}
+}
+
+func ruleArtifact(rule netlink.Rule) string {
+	flag := ipv4.ipFlag
+	if rule.Family == unix.AF_INET6 {
+		flag = ipv6.ipFlag
+	}
+	res := fmt.Sprintf("rule %s priority %d", flag, rule.Priority)
+	if rule.Mark > 0 {
+		mask := rule.Mask
+		if mask <= 0 {
+			mask = 0xffffffff
+		}
+		res += fmt.Sprintf(" fwmark 0x%03x/0x%03x", rule.Mark, mask)
+	}
+	if rule.Goto > 0 {
+		return res + fmt.Sprintf(" goto %d", rule.Goto)
+	}
+	return res + fmt.Sprintf(" lookup %d", rule.Table)
+}
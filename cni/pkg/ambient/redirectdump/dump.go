@@ -0,0 +1,83 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redirectdump holds the redirect dump served by the ambient node agent, comparing the redirection it
+// wants configured on the node with the one actually configured.
+package redirectdump
+
+import (
+	"sort"
+
+	"istio.io/istio/pkg/util/sets"
+)
+
+// Path is the path the node agent serves the dump on, on its monitoring port.
+const Path = "/debug/redirect-dump"
+
+// Dump is the redirection of a node.
+type Dump struct {
+	Node         string `json:"node"`
+	RedirectMode string `json:"redirectMode"`
+	// NodeArtifacts holds the artifacts configured for the node as a whole, such as ip rules.
+	NodeArtifacts Artifacts `json:"nodeArtifacts"`
+	// Pods holds the artifacts of each pod on the node, which is or should be in the mesh.
+	Pods []Pod `json:"pods"`
+	// Unowned holds the artifacts configured on the node which do not belong to any pod on the node, such as
+	// leftovers of deleted pods.
+	Unowned []string `json:"unowned,omitempty"`
+}
+
+// Pod is the redirection of a pod.
+type Pod struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	IPs       []string `json:"ips"`
+	Artifacts
+}
+
+// Artifacts are the iptables rules, ipset entries, routes or eBPF map entries redirecting traffic, formatted so
+// equal artifacts are equal strings.
+type Artifacts struct {
+	// Desired are the artifacts the node agent wants configured.
+	Desired []string `json:"desired"`
+	// Actual are the artifacts configured.
+	Actual []string `json:"actual"`
+}
+
+// Diff returns the desired artifacts which are not configured, and the configured artifacts which are not desired,
+// both sorted.
+func (a Artifacts) Diff() (missing, extra []string) {
+	desired := sets.New(a.Desired...)
+	actual := sets.New(a.Actual...)
+	missing = sets.SortedList(desired.Difference(actual))
+	extra = sets.SortedList(actual.Difference(desired))
+	return missing, extra
+}
+
+// InSync returns whether exactly the desired artifacts are configured.
+func (a Artifacts) InSync() bool {
+	missing, extra := a.Diff()
+	return len(missing) == 0 && len(extra) == 0
+}
+
+// Sort sorts the pods of the dump by namespace and name.
+func (d *Dump) Sort() {
+	sort.Slice(d.Pods, func(i, j int) bool {
+		if d.Pods[i].Namespace != d.Pods[j].Namespace {
+			return d.Pods[i].Namespace < d.Pods[j].Namespace
+		}
+		return d.Pods[i].Name < d.Pods[j].Name
+	})
+	sort.Strings(d.Unowned)
+}
@@ -0,0 +1,35 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirectdump
+
+import (
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestArtifactsDiff(t *testing.T) {
+	a := Artifacts{
+		Desired: []string{"ipset ztunnel-pods-ips 10.0.0.2", "ipset ztunnel-pods-ips 10.0.0.1", "route a"},
+		Actual:  []string{"route b", "ipset ztunnel-pods-ips 10.0.0.1", "ipset ztunnel-pods-ips 10.0.0.2"},
+	}
+	missing, extra := a.Diff()
+	assert.Equal(t, missing, []string{"route a"})
+	assert.Equal(t, extra, []string{"route b"})
+	assert.Equal(t, a.InSync(), false)
+
+	assert.Equal(t, Artifacts{Desired: []string{"a", "b"}, Actual: []string{"b", "a"}}.InSync(), true)
+	assert.Equal(t, Artifacts{}.InSync(), true)
+}
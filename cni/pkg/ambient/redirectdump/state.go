@@ -0,0 +1,60 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redirectdump
+
+import "sort"
+
+// StatePath serves the desired state of the node agent.
+const StatePath = "/debug/desired-state"
+
+// StateVersion is the version of the State format. It is incremented on incompatible changes, so clients can tell
+// whether they understand a snapshot.
+const StateVersion = 1
+
+// State is a snapshot of the redirection the node agent programmed on the node.
+type State struct {
+	Version int    `json:"version"`
+	Node    string `json:"node"`
+	// Generation is incremented on each change of the state, so two snapshots can be told apart without comparing
+	// them.
+	Generation uint64 `json:"generation"`
+	// Ztunnel is the active ztunnel pod the node is redirected to, if any, as namespace/name.
+	Ztunnel string `json:"ztunnel,omitempty"`
+	// NodeArtifacts are the artifacts configured for the node as a whole, for the active ztunnel.
+	NodeArtifacts []string `json:"nodeArtifacts"`
+	// Pods are the pods redirected to ztunnel.
+	Pods []PodState `json:"pods"`
+}
+
+type PodState struct {
+	UID       string   `json:"uid"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	IPs       []string `json:"ips"`
+	// Artifacts are the artifacts programmed for the pod. They are only known for the redirect modes which can list
+	// their artifacts.
+	Artifacts []string `json:"artifacts,omitempty"`
+	// Generation is the generation of the state at which the pod was last programmed.
+	Generation uint64 `json:"generation"`
+}
+
+func (s *State) Sort() {
+	sort.Slice(s.Pods, func(i, j int) bool {
+		if s.Pods[i].Namespace != s.Pods[j].Namespace {
+			return s.Pods[i].Namespace < s.Pods[j].Namespace
+		}
+		return s.Pods[i].Name < s.Pods[j].Name
+	})
+}
@@ -0,0 +1,167 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/cni/pkg/ambient/audit"
+)
+
+// Redirector programs the node dataplane redirecting the traffic of pods to ztunnel. The Server decides which pods
+// are part of the mesh and which ztunnel pod is active on the node, and the Redirector applies these decisions.
+//
+// The iptables and eBPF redirect modes are built in. Node agents embedding the Server can provide their own
+// Redirector in AmbientArgs, to program the redirection with their own dataplane.
+type Redirector interface {
+	// AddPod redirects the traffic of a pod to ztunnel. It is called again for pods which are already redirected, such
+	// as after a restart of the node agent, so it must be idempotent.
+	AddPod(ctx context.Context, pod *corev1.Pod) error
+	// DelPod removes the redirection of a pod. It is called for pods which may not be redirected.
+	DelPod(ctx context.Context, pod *corev1.Pod) error
+	// SetZtunnel configures the node to redirect traffic to a new active ztunnel pod. captureDNS is set when ztunnel
+	// proxies DNS requests.
+	SetZtunnel(ztunnel *corev1.Pod, captureDNS bool) error
+	// Cleanup removes all the redirection configured on the node. It is called when no ztunnel is running on the node,
+	// and when the Server stops.
+	Cleanup()
+}
+
+// batchRedirector is implemented by Redirectors which remove the redirection of several pods more efficiently at once.
+type batchRedirector interface {
+	delPods(ctx context.Context, pods []*corev1.Pod)
+}
+
+type iptablesRedirector struct {
+	s *Server
+}
+
+var _ Redirector = &iptablesRedirector{}
+
+func (r *iptablesRedirector) AddPod(ctx context.Context, pod *corev1.Pod) error {
+	if err := addPodToMeshWithIptables(ctx, pod, ""); err != nil {
+		return err
+	}
+	return r.s.addHostPortRules(pod)
+}
+
+func (r *iptablesRedirector) DelPod(ctx context.Context, pod *corev1.Pod) error {
+	delPodFromMeshWithIptables(ctx, pod)
+	r.s.delHostPortRules(pod)
+	return nil
+}
+
+func (r *iptablesRedirector) delPods(ctx context.Context, pods []*corev1.Pod) {
+	delPodsFromIpset(pods)
+	for _, pod := range pods {
+		delPodRoute(ctx, pod)
+		r.s.delHostPortRules(pod)
+	}
+}
+
+func (r *iptablesRedirector) SetZtunnel(ztunnel *corev1.Pod, captureDNS bool) error {
+	s := r.s
+	// TODO: we should not cleanup and recreate; this has downtime. We should mutate the existing rules in place
+	r.Cleanup()
+	// TODO: this will fail for any networking setup that doesn't create veths for host<->pod networking.
+	// Do we care about that?
+	veth, err := getVethWithDestinationOf(ztunnel.Status.PodIP)
+	if err != nil {
+		return fmt.Errorf("failed to get veth device: %w", err)
+	}
+	// Create node-level networking rules for redirection
+	err = s.CreateRulesOnNode(veth.Attrs().Name, podIPs(ztunnel), captureDNS)
+	if err != nil {
+		return fmt.Errorf("failed to configure node for ztunnel: %w", withCause(err, ErrIptablesExec))
+	}
+	// Collect info needed to jump into node proxy netns and configure it.
+	peerNs, err := getNsNameFromNsID(veth.Attrs().NetNsID)
+	if err != nil {
+		return fmt.Errorf("failed to get ns name: %w", err)
+	}
+	hostIP, err := GetHostIPByRoute(ztunnel)
+	if err != nil || hostIP == "" {
+		log.Warnf("failed to getting host IP: %v", err)
+	}
+	peerIndex, err := getPeerIndex(veth)
+	if err != nil {
+		return fmt.Errorf("failed to get veth peerIndex: %v", err)
+	}
+	// Create pod-level networking rules for redirection (from within pod netns)
+	err = s.CreateRulesWithinNodeProxyNS(peerIndex, podIPs(ztunnel), peerNs, hostIP)
+	if err != nil {
+		return fmt.Errorf("failed to configure node for ztunnel: %w", withCause(err, ErrIptablesExec))
+	}
+	return nil
+}
+
+func (r *iptablesRedirector) Cleanup() {
+	r.s.cleanupIptables()
+}
+
+type ebpfRedirector struct {
+	s *Server
+}
+
+var _ Redirector = &ebpfRedirector{}
+
+func (r *ebpfRedirector) AddPod(_ context.Context, pod *corev1.Pod) error {
+	if err := r.s.updatePodEbpfOnNode(pod); err != nil {
+		return fmt.Errorf("failed to update POD ebpf: %w", withCause(err, ErrEbpfProgram))
+	}
+	return nil
+}
+
+func (r *ebpfRedirector) DelPod(_ context.Context, pod *corev1.Pod) error {
+	if pod.Spec.HostNetwork {
+		log.Debugf("pod(%s/%s) is using host network, skip it", pod.Namespace, pod.Name)
+		return nil
+	}
+	if err := r.s.delPodEbpfOnNode(pod); err != nil {
+		return fmt.Errorf("failed to del POD ebpf: %w", withCause(err, ErrEbpfProgram))
+	}
+	return nil
+}
+
+func (r *ebpfRedirector) SetZtunnel(ztunnel *corev1.Pod, captureDNS bool) error {
+	s := r.s
+	h, err := GetHostIPByRoute(ztunnel)
+	if err != nil || h == "" {
+		log.Warnf("failed to getting host IP: %v", err)
+	} else if HostIP != h {
+		log.Infof("HostIP changed: (%v) -> (%v)", HostIP, h)
+		HostIP = h
+		if err := audit.RecordNode(audit.KindBPF, "update host IP to "+HostIP, s.ebpfServer.UpdateHostIP([]string{HostIP})); err != nil {
+			log.Errorf("failed to update host IP: %v", err)
+		}
+	}
+
+	// TODO: this will fail for any networking setup that doesn't create veths for host<->pod networking.
+	// Do we care about that?
+	if err := s.updateNodeProxyEBPF(ztunnel, captureDNS); err != nil {
+		return fmt.Errorf("failed to configure ztunnel: %w", withCause(err, ErrEbpfProgram))
+	}
+	return nil
+}
+
+func (r *ebpfRedirector) Cleanup() {
+	log.Infof("Node-level network rule cleanup started")
+	if err := r.s.delZtunnelEbpfOnNode(); err != nil {
+		log.Error(err)
+	}
+}
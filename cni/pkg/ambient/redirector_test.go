@@ -0,0 +1,151 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestEbpfRedirector(t *testing.T) {
+	r := &ebpfRedirector{s: &Server{}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"},
+		Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+	ctx := context.Background()
+
+	// The errors of the eBPF programs are reported as such, here as the eBPF server is not started
+	err := r.AddPod(ctx, pod)
+	assert.Equal(t, errors.Is(err, ErrEbpfProgram), true)
+	err = r.DelPod(ctx, pod)
+	assert.Equal(t, errors.Is(err, ErrEbpfProgram), true)
+
+	// Pods using the host network have no interface the programs could be attached to
+	hostNetwork := pod.DeepCopy()
+	hostNetwork.Spec.HostNetwork = true
+	assert.NoError(t, r.DelPod(ctx, hostNetwork))
+}
+
+func TestIptablesRedirectorArtifacts(t *testing.T) {
+	var r Redirector = &iptablesRedirector{s: &Server{}}
+	// The iptables redirection removes the pods of the node at once from the ipset
+	_, ok := r.(batchRedirector)
+	assert.Equal(t, ok, true)
+	_, ok = Redirector(&ebpfRedirector{}).(batchRedirector)
+	assert.Equal(t, ok, false)
+
+	lister := r.(artifactLister)
+	pod := func(ip string) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{PodIP: ip}}
+	}
+	assert.Equal(t, len(lister.desiredPodArtifacts(pod(""))), 0)
+	artifacts := lister.desiredPodArtifacts(pod("10.0.0.1"))
+	assert.Equal(t, artifacts[0].ip, "10.0.0.1")
+	assert.Equal(t, artifacts[0].value, ipsetArtifact("10.0.0.1"))
+	// Only IPv4 addresses are redirected
+	assert.Equal(t, len(lister.desiredPodArtifacts(pod("fd00::1"))), 0)
+	dualStack := &corev1.Pod{Status: corev1.PodStatus{
+		PodIP:  "10.0.0.1",
+		PodIPs: []corev1.PodIP{{IP: "10.0.0.1"}, {IP: "fd00::1"}, {IP: "10.0.0.2"}},
+	}}
+	ips := map[string]bool{}
+	for _, a := range lister.desiredPodArtifacts(dualStack) {
+		ips[a.ip] = true
+	}
+	assert.Equal(t, ips, map[string]bool{"10.0.0.1": true, "10.0.0.2": true})
+}
+
+func TestIptablesPodIPs(t *testing.T) {
+	pod := &corev1.Pod{Status: corev1.PodStatus{
+		PodIP:  "10.0.0.1",
+		PodIPs: []corev1.PodIP{{IP: "10.0.0.1"}, {IP: "fd00::1"}},
+	}}
+
+	// The IPv6 addresses of pods are skipped with an error
+	ips, err := iptablesPodIPs(pod, "")
+	assert.Equal(t, ips, []string{"10.0.0.1"})
+	assert.Equal(t, errors.Is(err, errIPv6NotRedirected), true)
+
+	// Unless only the given address is redirected
+	ips, err = iptablesPodIPs(pod, "10.0.0.1")
+	assert.Equal(t, ips, []string{"10.0.0.1"})
+	assert.NoError(t, err)
+
+	// Pods with only IPv6 addresses are not redirected at all
+	v6 := &corev1.Pod{Status: corev1.PodStatus{PodIP: "fd00::1"}}
+	assert.Equal(t, errors.Is(addPodToMeshWithIptables(context.Background(), v6, ""), errIPv6NotRedirected), true)
+}
+
+func TestServerRedirector(t *testing.T) {
+	enrolled := map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns", UID: "pod"},
+		Status:     corev1.PodStatus{PodIP: "10.0.0.1"},
+	}
+	hostNetwork := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "host", Namespace: "ns", UID: "host", Annotations: enrolled},
+		Spec:       corev1.PodSpec{HostNetwork: true},
+		Status:     corev1.PodStatus{PodIP: "192.168.0.1"},
+	}
+	annotation := func(client kube.Client, name string) string {
+		p, err := client.Kube().CoreV1().Pods("ns").Get(context.Background(), name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		return p.Annotations[constants.AmbientRedirection]
+	}
+
+	for _, mode := range []RedirectMode{IptablesMode, EbpfMode} {
+		t.Run(mode.String(), func(t *testing.T) {
+			client := kube.NewFakeClient(pod, hostNetwork)
+			redirector := &recordingRedirector{}
+			s := &Server{
+				ctx:          context.Background(),
+				kubeClient:   client,
+				redirectMode: mode,
+				redirector:   redirector,
+				desiredState: newDesiredState(),
+			}
+
+			// Pods are redirected, then annotated
+			assert.NoError(t, s.AddPodToMesh(s.ctx, pod))
+			assert.Equal(t, s.desiredState.hasPod(pod.UID), true)
+			assert.Equal(t, annotation(client, pod.Name), constants.AmbientRedirectionEnabled)
+
+			enrolledPod := pod.DeepCopy()
+			enrolledPod.Annotations = enrolled
+			assert.NoError(t, s.DelPodFromMesh(s.ctx, enrolledPod))
+			assert.Equal(t, s.desiredState.hasPod(pod.UID), false)
+			assert.Equal(t, annotation(client, pod.Name), "")
+
+			// Pods using the host network are never redirected by the eBPF programs, so they are left untouched
+			assert.NoError(t, s.DelPodFromMesh(s.ctx, hostNetwork))
+			if mode == EbpfMode {
+				assert.Equal(t, redirector.recorded(), []string{"add pod", "del pod"})
+				assert.Equal(t, annotation(client, hostNetwork.Name), constants.AmbientRedirectionEnabled)
+			} else {
+				assert.Equal(t, redirector.recorded(), []string{"add pod", "del pod", "del host"})
+				assert.Equal(t, annotation(client, hostNetwork.Name), "")
+			}
+		})
+	}
+}
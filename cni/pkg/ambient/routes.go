@@ -0,0 +1,230 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+)
+
+// ipFamily holds the family specific parameters of the routes, rules and tunnel addresses used to redirect
+// traffic through ztunnel.
+type ipFamily struct {
+	// family is the address family used for netlink routes and rules.
+	family int
+	// ipFlag selects the family in ip(8) commands.
+	ipFlag string
+	// defaultRoute matches every destination in the family.
+	defaultRoute string
+	// bits is the length of an address in the family.
+	bits int
+
+	tunPrefix            int
+	inboundTunIP         string
+	ztunnelInboundTunIP  string
+	outboundTunIP        string
+	ztunnelOutboundTunIP string
+}
+
+var (
+	ipv4 = ipFamily{
+		family:               unix.AF_INET,
+		ipFlag:               "-4",
+		defaultRoute:         "0.0.0.0/0",
+		bits:                 32,
+		tunPrefix:            constants.TunPrefix,
+		inboundTunIP:         constants.InboundTunIP,
+		ztunnelInboundTunIP:  constants.ZTunnelInboundTunIP,
+		outboundTunIP:        constants.OutboundTunIP,
+		ztunnelOutboundTunIP: constants.ZTunnelOutboundTunIP,
+	}
+	ipv6 = ipFamily{
+		family:               unix.AF_INET6,
+		ipFlag:               "-6",
+		defaultRoute:         "::/0",
+		bits:                 128,
+		tunPrefix:            constants.TunPrefixV6,
+		inboundTunIP:         constants.InboundTunIPv6,
+		ztunnelInboundTunIP:  constants.ZTunnelInboundTunIPv6,
+		outboundTunIP:        constants.OutboundTunIPv6,
+		ztunnelOutboundTunIP: constants.ZTunnelOutboundTunIPv6,
+	}
+)
+
+// familyOf returns the family of the given IP address. Anything that is not a valid IPv6 address is
+// treated as IPv4, which was the only family supported originally.
+func familyOf(ip string) ipFamily {
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		return ipv6
+	}
+	return ipv4
+}
+
+// familiesOf returns the distinct families of the given IP addresses, in the order they first appear.
+func familiesOf(ips []string) []ipFamily {
+	var res []ipFamily
+	seen := map[int]bool{}
+	for _, ip := range ips {
+		f := familyOf(ip)
+		if !seen[f.family] {
+			seen[f.family] = true
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// hostRoute returns the destination matching only the given address.
+func (f ipFamily) hostRoute(ip string) string {
+	return fmt.Sprintf("%s/%d", ip, f.bits)
+}
+
+// tunAddr returns the given tunnel address, with the tunnel prefix of the family.
+func (f ipFamily) tunAddr(ip string) *net.IPNet {
+	return &net.IPNet{
+		IP:   net.ParseIP(ip),
+		Mask: net.CIDRMask(f.tunPrefix, f.bits),
+	}
+}
+
+// podIPs returns all IPs of the pod, primary first. Pods on single stack clusters may only report PodIP.
+func podIPs(pod *corev1.Pod) []string {
+	if len(pod.Status.PodIPs) == 0 {
+		if pod.Status.PodIP == "" {
+			return nil
+		}
+		return []string{pod.Status.PodIP}
+	}
+	res := make([]string, 0, len(pod.Status.PodIPs))
+	for _, ip := range pod.Status.PodIPs {
+		res = append(res, ip.IP)
+	}
+	return res
+}
+
+// buildNodeRoutes returns the ip(8) commands setting up the node routes and rules redirecting traffic to
+// ztunnel, for every family ztunnel has an address in.
+func buildNodeRoutes(ztunnelVeth string, ztunnelIPs []string) []*ExecList {
+	var routes []*ExecList
+	for _, ztunnelIP := range ztunnelIPs {
+		f := familyOf(ztunnelIP)
+		routes = append(routes,
+			newExec("ip",
+				[]string{
+					f.ipFlag, "route", "add", "table", fmt.Sprint(Routing.outboundTable()), ztunnelIP,
+					"dev", ztunnelVeth, "scope", "link",
+				},
+			),
+			newExec("ip",
+				[]string{
+					f.ipFlag, "route", "add", "table", fmt.Sprint(Routing.outboundTable()), f.defaultRoute,
+					"via", f.ztunnelOutboundTunIP, "dev", constants.OutboundTun,
+				},
+			),
+			newExec("ip",
+				[]string{
+					f.ipFlag, "route", "add", "table", fmt.Sprint(Routing.proxyTable()), ztunnelIP,
+					"dev", ztunnelVeth, "scope", "link",
+				},
+			),
+			newExec("ip",
+				[]string{
+					f.ipFlag, "route", "add", "table", fmt.Sprint(Routing.proxyTable()), f.defaultRoute,
+					"via", ztunnelIP, "dev", ztunnelVeth, "onlink",
+				},
+			),
+			newExec("ip",
+				[]string{
+					f.ipFlag, "route", "add", "table", fmt.Sprint(Routing.inboundTable()), ztunnelIP,
+					"dev", ztunnelVeth, "scope", "link",
+				},
+			),
+		)
+	}
+	priorities := Routing.priorities()
+	for _, f := range familiesOf(ztunnelIPs) {
+		routes = append(routes,
+			// Everything with the skip mark goes directly to the main table
+			newExec("ip",
+				[]string{
+					f.ipFlag, "rule", "add", "priority", fmt.Sprint(priorities[0]),
+					"fwmark", Routing.fwmark(constants.SkipMask),
+					"goto", fmt.Sprint(mainRulePriority),
+				},
+			),
+			// Everything with the outbound mark goes to the tunnel out device
+			// using the outbound route table
+			newExec("ip",
+				[]string{
+					f.ipFlag, "rule", "add", "priority", fmt.Sprint(priorities[1]),
+					"fwmark", Routing.fwmark(constants.OutboundMask),
+					"lookup", fmt.Sprint(Routing.outboundTable()),
+				},
+			),
+			// Things with the proxy return mark go directly to the proxy veth using the proxy
+			// route table (useful for original src)
+			newExec("ip",
+				[]string{
+					f.ipFlag, "rule", "add", "priority", fmt.Sprint(priorities[2]),
+					"fwmark", Routing.fwmark(constants.ProxyRetMask),
+					"lookup", fmt.Sprint(Routing.proxyTable()),
+				},
+			),
+			// Send all traffic to the inbound table. This table has routes only to pods in the mesh.
+			// It does not have a catch-all route, so if a route is missing, the search will continue
+			// allowing us to override routing just for member pods.
+			newExec("ip",
+				[]string{
+					f.ipFlag, "rule", "add", "priority", fmt.Sprint(priorities[3]),
+					"table", fmt.Sprint(Routing.inboundTable()),
+				},
+			),
+		)
+	}
+	return routes
+}
+
+// buildRouteFromPod returns the inbound route sending traffic for the pod through the inbound tunnel.
+// The host IP is only used as source if it is in the same family as the pod IP.
+func buildRouteFromPod(pod *corev1.Pod, ip string) ([]string, error) {
+	if ip == "" {
+		ip = pod.Status.PodIP
+	}
+
+	if ip == "" {
+		return nil, errors.New("no ip found")
+	}
+
+	f := familyOf(ip)
+	rte := []string{
+		"table",
+		fmt.Sprint(Routing.inboundTable()),
+		f.hostRoute(ip),
+		"via",
+		f.ztunnelInboundTunIP,
+		"dev",
+		constants.InboundTun,
+	}
+	if HostIP != "" && familyOf(HostIP).family == f.family {
+		rte = append(rte, "src", HostIP)
+	}
+	return rte, nil
+}
@@ -0,0 +1,93 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestBuildNodeRoutes(t *testing.T) {
+	commands := func(routes []*ExecList) []string {
+		var res []string
+		for _, r := range routes {
+			res = append(res, r.Cmd+" "+strings.Join(r.Args, " "))
+		}
+		return res
+	}
+
+	assert.Equal(t, commands(buildNodeRoutes("veth0", []string{"10.0.0.2"})), []string{
+		"ip -4 route add table 101 10.0.0.2 dev veth0 scope link",
+		"ip -4 route add table 101 0.0.0.0/0 via 192.168.127.2 dev istioout",
+		"ip -4 route add table 102 10.0.0.2 dev veth0 scope link",
+		"ip -4 route add table 102 0.0.0.0/0 via 10.0.0.2 dev veth0 onlink",
+		"ip -4 route add table 100 10.0.0.2 dev veth0 scope link",
+		"ip -4 rule add priority 100 fwmark 0x200/0x200 goto 32766",
+		"ip -4 rule add priority 101 fwmark 0x100/0x100 lookup 101",
+		"ip -4 rule add priority 102 fwmark 0x040/0x040 lookup 102",
+		"ip -4 rule add priority 103 table 100",
+	})
+
+	assert.Equal(t, commands(buildNodeRoutes("veth0", []string{"fd00::2"})), []string{
+		"ip -6 route add table 101 fd00::2 dev veth0 scope link",
+		"ip -6 route add table 101 ::/0 via fd69:7374:696f:7f::2 dev istioout",
+		"ip -6 route add table 102 fd00::2 dev veth0 scope link",
+		"ip -6 route add table 102 ::/0 via fd00::2 dev veth0 onlink",
+		"ip -6 route add table 100 fd00::2 dev veth0 scope link",
+		"ip -6 rule add priority 100 fwmark 0x200/0x200 goto 32766",
+		"ip -6 rule add priority 101 fwmark 0x100/0x100 lookup 101",
+		"ip -6 rule add priority 102 fwmark 0x040/0x040 lookup 102",
+		"ip -6 rule add priority 103 table 100",
+	})
+
+	// Dual-stack gets routes for each address, and rules for each family once
+	dual := commands(buildNodeRoutes("veth0", []string{"10.0.0.2", "fd00::2"}))
+	assert.Equal(t, len(dual), 18)
+	assert.Equal(t, dual[5], "ip -6 route add table 101 fd00::2 dev veth0 scope link")
+	assert.Equal(t, dual[10], "ip -4 rule add priority 100 fwmark 0x200/0x200 goto 32766")
+	assert.Equal(t, dual[14], "ip -6 rule add priority 100 fwmark 0x200/0x200 goto 32766")
+}
+
+func TestBuildRouteFromPod(t *testing.T) {
+	old := HostIP
+	t.Cleanup(func() { HostIP = old })
+	HostIP = "10.0.0.1"
+
+	pod := &corev1.Pod{Status: corev1.PodStatus{PodIP: "10.0.0.5"}}
+	rte, err := buildRouteFromPod(pod, "")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join(rte, " "), "table 100 10.0.0.5/32 via 192.168.126.2 dev istioin src 10.0.0.1")
+
+	// The IPv4 host IP cannot be the source of an IPv6 route
+	rte, err = buildRouteFromPod(pod, "fd00::5")
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Join(rte, " "), "table 100 fd00::5/128 via fd69:7374:696f:7e::2 dev istioin")
+
+	_, err = buildRouteFromPod(&corev1.Pod{}, "")
+	assert.Error(t, err)
+}
+
+func TestPodIPs(t *testing.T) {
+	assert.Equal(t, podIPs(&corev1.Pod{}), nil)
+	assert.Equal(t, podIPs(&corev1.Pod{Status: corev1.PodStatus{PodIP: "10.0.0.5"}}), []string{"10.0.0.5"})
+	assert.Equal(t, podIPs(&corev1.Pod{Status: corev1.PodStatus{
+		PodIP:  "10.0.0.5",
+		PodIPs: []corev1.PodIP{{IP: "10.0.0.5"}, {IP: "fd00::5"}},
+	}}), []string{"10.0.0.5", "fd00::5"})
+}
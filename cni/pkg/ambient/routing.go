@@ -0,0 +1,208 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+)
+
+const (
+	// mainRulePriority is the priority of the rule looking up the main route table.
+	mainRulePriority = 32766
+	// routingAlternates is how many alternates are tried for each of the route tables, rule priorities and fwmarks
+	// when they conflict with the routing configured on the node by others.
+	routingAlternates = 32
+	// fwmarkShiftStep is how many bits the fwmarks are shifted by between alternates, so they stay readable in hex.
+	fwmarkShiftStep = 4
+	// allFwmarks are the bits of all the fwmarks used on the node, before they are shifted.
+	allFwmarks = constants.OutboundMask | constants.SkipMask | constants.ConnSkipMask | constants.ProxyMask | constants.ProxyRetMask
+)
+
+// RoutingConfig holds the route tables, rule priorities and fwmarks used to redirect traffic to ztunnel on the node.
+// They can conflict with the ones used by systemd-networkd or cloud provider agents, so they are configurable.
+type RoutingConfig struct {
+	// RouteTableBase is the first of the inbound, outbound and proxy route tables, which are consecutive.
+	RouteTableBase int `json:"routeTableBase"`
+	// RulePriorityBase is the priority of the first of the four ip rules, which have consecutive priorities.
+	RulePriorityBase int `json:"rulePriorityBase"`
+	// FwmarkShift is how many bits the fwmarks are shifted left by.
+	FwmarkShift int `json:"fwmarkShift"`
+}
+
+// DefaultRoutingConfig returns the routing used when it is not configured.
+func DefaultRoutingConfig() RoutingConfig {
+	return RoutingConfig{
+		RouteTableBase:   constants.RouteTableInbound,
+		RulePriorityBase: constants.RulePriorityBase,
+	}
+}
+
+// Routing is the routing used on the node. It is set by the node agent, and read by the CNI plugin from the ambient
+// config file.
+var Routing = DefaultRoutingConfig()
+
+func (c RoutingConfig) inboundTable() int {
+	return c.RouteTableBase
+}
+
+func (c RoutingConfig) outboundTable() int {
+	return c.RouteTableBase + 1
+}
+
+func (c RoutingConfig) proxyTable() int {
+	return c.RouteTableBase + 2
+}
+
+func (c RoutingConfig) tables() []int {
+	return []int{c.inboundTable(), c.outboundTable(), c.proxyTable()}
+}
+
+// priorities returns the priorities of the skip, outbound, proxy return and inbound rules, in that order.
+func (c RoutingConfig) priorities() []int {
+	return []int{c.RulePriorityBase, c.RulePriorityBase + 1, c.RulePriorityBase + 2, c.RulePriorityBase + 3}
+}
+
+// fwmask returns the shifted fwmask.
+func (c RoutingConfig) fwmask(mask int) string {
+	return fmt.Sprintf("0x%03x", mask<<c.FwmarkShift)
+}
+
+// fwmark returns the shifted fwmark, with its fwmask.
+func (c RoutingConfig) fwmark(mask int) string {
+	return c.fwmask(mask) + "/" + c.fwmask(mask)
+}
+
+// Validate returns an error if the routing cannot be used.
+func (c RoutingConfig) Validate() error {
+	for _, t := range c.tables() {
+		// 0 is unspecified, and 253 to 255 are the default, main and local tables
+		if t <= 0 || (t >= 253 && t <= 255) {
+			return fmt.Errorf("route table %d is reserved", t)
+		}
+	}
+	if c.RulePriorityBase <= 0 || c.RulePriorityBase+3 >= mainRulePriority {
+		return fmt.Errorf("rule priorities %d to %d must be between 0 and %d", c.RulePriorityBase, c.RulePriorityBase+3, mainRulePriority)
+	}
+	if c.FwmarkShift < 0 || uint64(allFwmarks)<<c.FwmarkShift > 0xffffffff {
+		return fmt.Errorf("fwmark shift %d does not fit the fwmarks in 32 bits", c.FwmarkShift)
+	}
+	return nil
+}
+
+// ownRule returns whether the rule is one of the rules redirecting traffic to ztunnel with this routing.
+func (c RoutingConfig) ownRule(r netlink.Rule) bool {
+	p := c.priorities()
+	switch r.Priority {
+	case p[0]:
+		return r.Goto == mainRulePriority && r.Mark == constants.SkipMask<<c.FwmarkShift
+	case p[1]:
+		return r.Table == c.outboundTable() && r.Mark == constants.OutboundMask<<c.FwmarkShift
+	case p[2]:
+		return r.Table == c.proxyTable() && r.Mark == constants.ProxyRetMask<<c.FwmarkShift
+	case p[3]:
+		return r.Table == c.inboundTable() && r.Mark == 0
+	}
+	return false
+}
+
+// nodeRouting is the routing configured on the node by others, and by previous runs of the node agent.
+type nodeRouting struct {
+	rules []netlink.Rule
+	// foreignTables are the route tables with routes through other links than the tunnels and veths used to
+	// redirect traffic to ztunnel.
+	foreignTables map[int]bool
+}
+
+// conflicts returns the conflicts of the route tables, rule priorities and fwmarks of the routing with the routing
+// configured on the node.
+func (n nodeRouting) conflicts(c RoutingConfig) (tables, priorities, fwmarks []string) {
+	ownTables := map[int]bool{}
+	for _, t := range c.tables() {
+		ownTables[t] = true
+		if n.foreignTables[t] {
+			tables = append(tables, fmt.Sprintf("route table %d has routes of other links", t))
+		}
+	}
+	ownPriorities := map[int]bool{}
+	for _, p := range c.priorities() {
+		ownPriorities[p] = true
+	}
+	for _, r := range n.rules {
+		if c.ownRule(r) {
+			continue
+		}
+		if ownPriorities[r.Priority] {
+			priorities = append(priorities, fmt.Sprintf("rule priority %d is used by a rule looking up table %d", r.Priority, r.Table))
+		}
+		if ownTables[r.Table] {
+			tables = append(tables, fmt.Sprintf("route table %d is looked up by a rule with priority %d", r.Table, r.Priority))
+		}
+		mask := r.Mask
+		if mask <= 0 {
+			mask = 0xffffffff
+		}
+		if r.Mark > 0 && r.Mark&mask&(allFwmarks<<c.FwmarkShift) != 0 {
+			fwmarks = append(fwmarks, fmt.Sprintf("fwmark 0x%x/0x%x of the rule with priority %d overlaps", r.Mark, mask, r.Priority))
+		}
+	}
+	return tables, priorities, fwmarks
+}
+
+// resolve returns the routing to use: the configured one if it has no conflicts, or else the first alternate without
+// conflicts for each of the route tables, rule priorities and fwmarks. The conflicts found are returned, so they can be
+// reported.
+func (n nodeRouting) resolve(c RoutingConfig) (RoutingConfig, []string) {
+	tables, priorities, fwmarks := n.conflicts(c)
+	res := c
+	if len(tables) > 0 {
+		for i := 1; i <= routingAlternates; i++ {
+			alt := res
+			alt.RouteTableBase = c.RouteTableBase + 3*i
+			if t, _, _ := n.conflicts(alt); len(t) == 0 && alt.Validate() == nil {
+				res = alt
+				break
+			}
+		}
+	}
+	if len(priorities) > 0 {
+		for i := 1; i <= routingAlternates; i++ {
+			alt := res
+			alt.RulePriorityBase = c.RulePriorityBase + 4*i
+			if _, p, _ := n.conflicts(alt); len(p) == 0 && alt.Validate() == nil {
+				res = alt
+				break
+			}
+		}
+	}
+	if len(fwmarks) > 0 {
+		for i := 1; i <= routingAlternates; i++ {
+			alt := res
+			alt.FwmarkShift = c.FwmarkShift + fwmarkShiftStep*i
+			if alt.Validate() != nil {
+				break
+			}
+			if _, _, f := n.conflicts(alt); len(f) == 0 {
+				res = alt
+				break
+			}
+		}
+	}
+	conflicts := append(append(tables, priorities...), fwmarks...)
+	return res, conflicts
+}
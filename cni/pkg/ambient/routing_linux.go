@@ -0,0 +1,86 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"istio.io/istio/cni/pkg/ambient/constants"
+)
+
+// listNodeRouting lists the ip rules and route tables configured on the node.
+func listNodeRouting() (nodeRouting, error) {
+	rules, err := netlink.RuleList(netlink.FAMILY_ALL)
+	if err != nil {
+		return nodeRouting{}, fmt.Errorf("failed to list rules: %v", err)
+	}
+	// Filtering on the unspecified table lists the routes of all tables
+	routes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, &netlink.Route{Table: unix.RT_TABLE_UNSPEC}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nodeRouting{}, fmt.Errorf("failed to list routes: %v", err)
+	}
+	n := nodeRouting{rules: rules, foreignTables: map[int]bool{}}
+	redirectLinks := map[int]bool{}
+	for _, route := range routes {
+		switch route.Table {
+		case unix.RT_TABLE_MAIN, unix.RT_TABLE_LOCAL, unix.RT_TABLE_DEFAULT:
+			continue
+		}
+		if _, f := redirectLinks[route.LinkIndex]; !f {
+			redirectLinks[route.LinkIndex] = isRedirectLink(route.LinkIndex)
+		}
+		if !redirectLinks[route.LinkIndex] {
+			n.foreignTables[route.Table] = true
+		}
+	}
+	return n, nil
+}
+
+// isRedirectLink returns whether the link is one of the tunnels or veths the routes redirecting traffic to ztunnel go
+// through.
+func isRedirectLink(index int) bool {
+	if index == 0 {
+		return false
+	}
+	link, err := netlink.LinkByIndex(index)
+	if err != nil {
+		return false
+	}
+	name := link.Attrs().Name
+	return name == constants.InboundTun || name == constants.OutboundTun || link.Type() == "veth"
+}
+
+// resolveRouting returns the routing to use on the node. Conflicts with the routing configured by others are reported,
+// and if autoResolve is set, alternates without conflicts are used instead.
+func resolveRouting(cfg RoutingConfig, autoResolve bool) RoutingConfig {
+	n, err := listNodeRouting()
+	if err != nil {
+		log.Warnf("failed to detect routing conflicts: %v", err)
+		return cfg
+	}
+	res, conflicts := n.resolve(cfg)
+	for _, c := range conflicts {
+		log.Warnf("routing conflict: %s", c)
+	}
+	if !autoResolve || res == cfg {
+		return cfg
+	}
+	log.Infof("using alternate routing to avoid conflicts: route tables %v, rule priorities %v, fwmark shift %d",
+		res.tables(), res.priorities(), res.FwmarkShift)
+	return res
+}
@@ -0,0 +1,99 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+
+	"github.com/vishvananda/netlink"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestResolveRouting(t *testing.T) {
+	def := DefaultRoutingConfig()
+	rule := func(priority, table, mark int) netlink.Rule {
+		return netlink.Rule{Priority: priority, Table: table, Mark: mark, Mask: -1}
+	}
+	// The rules installed by a previous run of the node agent
+	own := []netlink.Rule{
+		{Priority: 100, Goto: mainRulePriority, Mark: 0x200, Mask: 0x200},
+		{Priority: 101, Table: 101, Mark: 0x100, Mask: 0x100},
+		{Priority: 102, Table: 102, Mark: 0x040, Mask: 0x040},
+		{Priority: 103, Table: 100},
+	}
+
+	cases := []struct {
+		name      string
+		node      nodeRouting
+		want      RoutingConfig
+		conflicts int
+	}{
+		{
+			name: "no conflicts",
+			node: nodeRouting{rules: own},
+			want: def,
+		},
+		{
+			name:      "table used by systemd-networkd",
+			node:      nodeRouting{rules: own, foreignTables: map[int]bool{101: true, 104: true}},
+			want:      RoutingConfig{RouteTableBase: 106, RulePriorityBase: 100},
+			conflicts: 1,
+		},
+		{
+			name:      "table looked up by another rule",
+			node:      nodeRouting{rules: append(own, rule(1000, 102, 0))},
+			want:      RoutingConfig{RouteTableBase: 103, RulePriorityBase: 100},
+			conflicts: 1,
+		},
+		{
+			name:      "priority used by another rule",
+			node:      nodeRouting{rules: []netlink.Rule{rule(102, 200, 0)}},
+			want:      RoutingConfig{RouteTableBase: 100, RulePriorityBase: 104},
+			conflicts: 1,
+		},
+		{
+			name:      "overlapping fwmark",
+			node:      nodeRouting{rules: []netlink.Rule{{Priority: 1000, Table: 200, Mark: 0x200, Mask: 0xf00}}},
+			want:      RoutingConfig{RouteTableBase: 100, RulePriorityBase: 100, FwmarkShift: 8},
+			conflicts: 1,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := tt.node.resolve(def)
+			assert.Equal(t, got, tt.want)
+			assert.Equal(t, len(conflicts), tt.conflicts)
+		})
+	}
+}
+
+func TestRoutingConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultRoutingConfig().Validate())
+	assert.Error(t, RoutingConfig{RouteTableBase: 252, RulePriorityBase: 100}.Validate())
+	assert.Error(t, RoutingConfig{RouteTableBase: 100, RulePriorityBase: 32764}.Validate())
+	assert.Error(t, RoutingConfig{RouteTableBase: 100, RulePriorityBase: 100, FwmarkShift: 24}.Validate())
+}
+
+func TestBuildNodeRoutesWithRouting(t *testing.T) {
+	old := Routing
+	t.Cleanup(func() { Routing = old })
+	Routing = RoutingConfig{RouteTableBase: 200, RulePriorityBase: 5000, FwmarkShift: 8}
+
+	routes := buildNodeRoutes("veth0", []string{"10.0.0.2"})
+	assert.Equal(t, routes[0].Args, []string{"-4", "route", "add", "table", "201", "10.0.0.2", "dev", "veth0", "scope", "link"})
+	assert.Equal(t, routes[5].Args, []string{"-4", "rule", "add", "priority", "5000", "fwmark", "0x20000/0x20000", "goto", "32766"})
+	assert.Equal(t, routes[8].Args, []string{"-4", "rule", "add", "priority", "5003", "table", "200"})
+}
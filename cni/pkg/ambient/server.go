@@ -20,60 +20,200 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
+	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/rest"
 
 	"istio.io/istio/cni/pkg/ambient/constants"
+	"istio.io/istio/cni/pkg/ambient/dnsshim"
 	ebpf "istio.io/istio/cni/pkg/ebpf/server"
+	"istio.io/istio/cni/pkg/features"
+	"istio.io/istio/cni/pkg/util"
 	"istio.io/istio/pkg/kube"
-	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/lazy"
+	"istio.io/istio/pkg/util/sets"
 )
 
 type Server struct {
 	kubeClient kube.Client
 	ctx        context.Context
-	queue      controllers.Queue
-
-	namespaces kclient.Client[*corev1.Namespace]
+	// reconcileCtx is the context of the reconciliation of pod events. It is only cancelled once the events being
+	// reconciled are drained on Stop, so the termination of the node agent does not abort them halfway.
+	reconcileCtx    context.Context
+	cancelReconcile context.CancelFunc
+	// reconciling tracks the reconciliations of pod events run separately from the queue, which keep running once
+	// Reconcile gave up on them on timeout, so Stop waits for them before cleaning up.
+	reconciling sync.WaitGroup
+	queue       *workQueue
+	// reconcileMu serializes the reconciliation of ztunnel with the concurrent reconciliation of pods.
+	reconcileMu sync.RWMutex
+
+	namespaces kclient.Untyped
 	pods       kclient.Client[*corev1.Pod]
+	// serviceAccounts watches the ServiceAccounts of pods, whose identity ztunnel issues.
+	serviceAccounts kclient.Untyped
+	configMaps      kclient.Client[*corev1.ConfigMap]
+	// installConfigMaps watches the ConfigMap holding the ambient settings of the installation.
+	installConfigMaps kclient.Client[*corev1.ConfigMap]
 
 	mu         sync.Mutex
 	ztunnelPod *corev1.Pod
+	// ztunnelSettings are the settings the redirection to the active ztunnel was configured with.
+	ztunnelSettings ztunnelSettings
+	// nodeRules are the iptables rules appended on the node for the active ztunnel, in the iptables redirect mode.
+	nodeRules []*iptablesRule
+	// desiredState is the redirection this node agent programmed, so the redirection of pods is removed when they or
+	// their namespace are deleted, and the node redirection is repaired, without relying on the node dataplane.
+	desiredState *desiredState
+	// failedPods are the pods whose last event failed to be reconciled.
+	failedPods sets.Set[types.UID]
+	// reconcilingPods are the pods whose reconciliation runs separately from the queue, until it returns even once
+	// Reconcile gave up on it on timeout, so the retries of their events do not run concurrently with it.
+	reconcilingPods sets.Set[types.UID]
+	// flushed is set once the redirection of the node was flushed, after which pods are no longer redirected until the
+	// node agent restarts.
+	flushed bool
+	// capture is the pod whose traffic is being captured, if any. Only one capture runs at a time.
+	capture types.NamespacedName
+	// bypassedPods are the pods temporarily removed from the mesh, with the timers adding them back.
+	bypassedPods map[types.UID]*time.Timer
+	// excludedNamespaces are the namespaces whose pods are never added to the mesh, set by the installation.
+	excludedNamespaces sets.String
+
+	ztunnelReadiness *ztunnelReadiness
 
 	iptablesCommand lazy.Lazy[string]
 	redirectMode    RedirectMode
+	redirector      Redirector
 	ebpfServer      *ebpf.RedirectServer
+	configFile      string
+	systemNamespace string
+
+	enrollmentHook *enrollmentHook
+	conntrackFlush bool
+	completedPods  *completedPods
+	// quarantinedPods are the pods whose reconciliation panicked.
+	quarantinedPods *quarantinedPods
+
+	kubeProxyReplacement bool
+	trimInformers        bool
+	reconcileTimeout     time.Duration
+	workers              int
+	nodeCheckInterval    time.Duration
+	readinessGate        bool
+	// allowedNamespaces are the namespaces whose pods may be added to the mesh, or all of them if empty.
+	allowedNamespaces sets.String
+	nodeTraffic       NodeTrafficConfig
+	dnsCapture        DNSCaptureConfig
+	captureDir        string
+	installConfigMap  string
+	// initContainerConflicts is how the pods whose init containers may program their network are captured.
+	initContainerConflicts InitContainerConflictPolicy
+
+	// dnsShim is the DNS shim the DNS requests are redirected to with the node-local DNS capture backend.
+	dnsShim *dnsshim.Shim
 }
 
 type AmbientConfigFile struct {
-	ZTunnelReady bool   `json:"ztunnelReady"`
-	RedirectMode string `json:"redirectMode"`
+	ZTunnelReady         bool   `json:"ztunnelReady"`
+	RedirectMode         string `json:"redirectMode"`
+	KubeProxyReplacement bool   `json:"kubeProxyReplacement,omitempty"`
+	// ExcludeNamespaces are the namespaces whose pods are never added to the mesh.
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// Namespaces are the namespaces whose pods may be added to the mesh, or all of them if empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Routing is the routing used on the node in the iptables redirect mode.
+	Routing *RoutingConfig `json:"routing,omitempty"`
+	// InitContainerConflicts is how the pods whose init containers may program their network are captured. The CNI
+	// plugin leaves the pods which are not captured on creation to the node agent.
+	InitContainerConflicts InitContainerConflictPolicy `json:"initContainerConflicts,omitempty"`
 }
 
+// NewServer creates the ambient node agent, which adds the pods of the node to the ambient mesh and removes them,
+// according to the dataplane mode of their namespace, and redirects their traffic to the ztunnel pod running on the
+// node. The Server watches the pods and namespaces until the context is done; Start must be called to run it, and
+// Stop to remove the node redirection once it is no longer needed.
+//
+// The Server is normally run by the istio-cni DaemonSet, but it can also run standalone, or be embedded into other node
+// agents, which can provide their own Redirector to program the redirection with their dataplane.
 func NewServer(ctx context.Context, args AmbientArgs) (*Server, error) {
-	client, err := buildKubeClient(args.KubeConfig)
+	client, err := buildKubeClient(args.KubeConfig, args.KubeClient)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing kube client: %v", err)
 	}
+	hook, err := newEnrollmentHook(args.EnrollmentHook)
+	if err != nil {
+		return nil, err
+	}
+	if err := args.InitContainerConflicts.Validate(); err != nil {
+		return nil, err
+	}
 	// Set some defaults
 	s := &Server{
-		ctx:        ctx,
-		kubeClient: client,
+		ctx:            ctx,
+		kubeClient:     client,
+		enrollmentHook: hook,
+		conntrackFlush: args.ConntrackFlush,
+
+		kubeProxyReplacement: args.KubeProxyReplacement,
+		trimInformers:        args.TrimInformers,
+		reconcileTimeout:     args.ReconcileTimeout,
+		workers:              args.Workers,
+		nodeCheckInterval:    args.NodeCheckInterval,
+		readinessGate:        args.ReadinessGate,
+		configFile:           args.ConfigFile,
+		systemNamespace:      args.SystemNamespace,
+		desiredState:         newDesiredState(),
+		failedPods:           sets.New[types.UID](),
+		allowedNamespaces:    sets.New(args.Namespaces...),
+		bypassedPods:         map[types.UID]*time.Timer{},
+		captureDir:           args.CaptureDir,
+		installConfigMap:     args.InstallConfigMap,
+		excludedNamespaces:   sets.New[string](),
+
+		initContainerConflicts: args.InitContainerConflicts,
+	}
+	if s.configFile == "" {
+		s.configFile = constants.AmbientConfigFilepath
 	}
+	// The reconciliation is bounded by the reconcile timeout, and drained on Stop, rather than cancelled with ctx
+	s.reconcileCtx, s.cancelReconcile = context.WithCancel(context.Background())
+
+	// Flags which are not reloadable are read below, so the ConfigMaps setting them are read before the informers run
+	s.loadInstallConfig()
+	s.loadFeatures()
+
+	// With a privileged helper, the mutations of the host are performed, and written to the audit log, by the helper
+	setupAudit(ctx, s.kubeClient.Kube(), args.Audit, args.PrivilegedSocket == "" || args.RedirectMode == ExternalMode)
 
 	s.iptablesCommand = lazy.New(func() (string, error) {
 		return s.detectIptablesCommand(), nil
 	})
 
-	switch args.RedirectMode {
-	case IptablesMode:
+	if args.Redirector == nil {
+		calico := detectCalicoDataplane(ctx, s.kubeClient.Dynamic(), NodeName)
+		if err := calico.check(args.RedirectMode); err != nil {
+			return nil, fmt.Errorf("unsupported Calico eBPF dataplane: %v", err)
+		}
+		if calico.EBPF && !s.kubeProxyReplacement {
+			// Calico attaches its programs to the veths of pods, like CNIs replacing kube-proxy
+			log.Infof("Calico eBPF dataplane detected, enabling the kube-proxy replacement compatibility mode")
+			s.kubeProxyReplacement = true
+		}
+	}
+
+	switch {
+	case args.Redirector != nil:
+		s.redirectMode = ExternalMode
+		s.redirector = args.Redirector
+	case args.RedirectMode == IptablesMode:
 		s.redirectMode = IptablesMode
+		s.redirector = &iptablesRedirector{s: s}
 		// We need to find our Host IP -- is there a better way to do this?
 		h, err := GetHostIP(s.kubeClient.Kube())
 		if err != nil || h == "" {
@@ -81,11 +221,108 @@ func NewServer(ctx context.Context, args AmbientArgs) (*Server, error) {
 		}
 		HostIP = h
 		log.Infof("HostIP=%v", HostIP)
-	case EbpfMode:
+		routing := args.Routing
+		if routing == (RoutingConfig{}) {
+			routing = DefaultRoutingConfig()
+		}
+		if err := routing.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid routing: %v", err)
+		}
+		Routing = resolveRouting(routing, args.RoutingAutoResolve)
+		if err := args.NodeTraffic.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid node traffic config: %v", err)
+		}
+		if args.NodeTraffic.captured() && Routing.RulePriorityBase <= 2 {
+			return nil, fmt.Errorf("capturing the traffic to the node requires a rule priority base above 2, got %d",
+				Routing.RulePriorityBase)
+		}
+		s.nodeTraffic = args.NodeTraffic
+		if err := args.DNSCapture.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid DNS capture config: %v", err)
+		}
+		s.dnsCapture = args.DNSCapture
+		if err := AnnotateNodeRouting(ctx, s.kubeClient.Kube(), Routing); err != nil {
+			log.Warnf("failed to record the routing in the node annotations: %v", err)
+		}
+		if args.EbpfShadow && args.PrivilegedSocket == "" {
+			s.startEbpfShadow(ctx, args.LogLevel)
+		} else if args.EbpfShadow {
+			log.Warnf("the shadow ebpf redirection is not supported with a privileged helper, ignoring it")
+		}
+		if s.kubeProxyReplacement {
+			log.Warnf("pods are captured by iptables after the CNI has translated their traffic to service VIPs, " +
+				"so ztunnel only sees the addresses of the service endpoints; use the ebpf redirect mode to capture " +
+				"traffic before it is translated")
+		}
+	case args.RedirectMode == EbpfMode && args.PrivilegedSocket != "":
+		s.redirectMode = EbpfMode
+		warnEbpfShadowIgnored(args.EbpfShadow)
+		warnNodeTrafficIgnored(args.NodeTraffic)
+		warnDNSCaptureIgnored(args.DNSCapture)
+	case args.RedirectMode == EbpfMode:
 		s.redirectMode = EbpfMode
+		s.redirector = &ebpfRedirector{s: s}
+		warnEbpfShadowIgnored(args.EbpfShadow)
+		warnNodeTrafficIgnored(args.NodeTraffic)
+		warnDNSCaptureIgnored(args.DNSCapture)
 		s.ebpfServer = ebpf.NewRedirectServer()
 		s.ebpfServer.SetLogLevel(args.LogLevel)
+		s.ebpfServer.SetKubeProxyReplacement(s.kubeProxyReplacement)
+		s.ebpfServer.SetRequestHandler(auditEBPFRequest)
 		s.ebpfServer.Start(ctx.Done())
+	default:
+		return nil, fmt.Errorf("unknown redirect mode %v", args.RedirectMode)
+	}
+	if s.dnsCapture.nodeLocal() {
+		// The shim runs in the node agent, also when the redirection is programmed by the privileged helper
+		if err := s.startDNSShim(); err != nil {
+			return nil, err
+		}
+	}
+	privileged := s.redirectMode != ExternalMode && args.PrivilegedSocket == ""
+	if s.redirectMode != ExternalMode && !privileged {
+		// The redirection is programmed by the privileged helper, with the settings resolved above
+		s.redirector = newPrivilegedClient(args.PrivilegedSocket, PrivilegedConfig{
+			RedirectMode:         s.redirectMode,
+			HostIP:               HostIP,
+			Routing:              Routing,
+			NodeTraffic:          s.nodeTraffic,
+			DNSCapture:           s.dnsCapture,
+			KubeProxyReplacement: s.kubeProxyReplacement,
+			ConntrackFlush:       s.conntrackFlush,
+			LogLevel:             args.LogLevel,
+			Audit:                args.Audit,
+			Features:             features.Values(false),
+		})
+		// Conntrack entries are flushed by the helper
+		s.conntrackFlush = false
+		if held, err := heldCapabilities(ebpfCapabilities); err == nil && len(held) > 0 {
+			log.Warnf("the node agent holds the capabilities %v, which are only required by its privileged helper", held)
+		}
+	}
+	if privileged {
+		if userns, err := detectUserNamespace(); err != nil {
+			log.Warnf("failed to detect the user namespace of the node agent: %v", err)
+		} else if err := userns.unsupported(s.redirectMode); err != nil {
+			return nil, err
+		}
+		if missing, err := missingCapabilities(requiredCapabilities(s.redirectMode)); err == nil && len(missing) > 0 {
+			log.Warnf("the %v redirect mode requires the missing capabilities %v; run the node agent privileged, "+
+				"or program the redirection with a privileged helper", s.redirectMode, missing)
+		}
+	}
+	recordPlatform(ctx, s.kubeClient.Kube(), s.probePlatform(privileged))
+	if privileged && !s.kubeProxyReplacement && !s.kubeProxyDetected() {
+		log.Warnf("kube-proxy rules were not found on this node; if services are handled by the CNI with eBPF, " +
+			"enable the kube-proxy replacement compatibility mode so pods are captured before their traffic is translated")
+	}
+	if privileged && util.SELinuxEnforcing() {
+		if util.SELinuxConfined() {
+			log.Warnf("SELinux is enforcing and confines the node agent with the %q type, so configuring the pods "+
+				"network namespaces may be denied; %s", util.SELinuxType(), util.SELinuxRemediation)
+		} else {
+			log.Infof("SELinux is enforcing, the node agent runs with the %q type", util.SELinuxType())
+		}
 	}
 
 	s.setupHandlers()
@@ -101,47 +338,68 @@ func (s *Server) isZTunnelRunning() bool {
 	return s.ztunnelPod != nil
 }
 
-// buildKubeClient creates the kube client
-func buildKubeClient(kubeConfig string) (kube.Client, error) {
-	// Used by validation
-	kubeRestConfig, err := kube.DefaultRestConfig(kubeConfig, "", func(config *rest.Config) {
-		config.QPS = 80
-		config.Burst = 160
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed creating kube config: %v", err)
-	}
-
-	client, err := kube.NewClient(kube.NewClientConfigForRestConfig(kubeRestConfig), "")
-	if err != nil {
-		return nil, fmt.Errorf("failed creating kube client: %v", err)
-	}
-
-	return client, nil
-}
-
+// Start starts watching the pods and namespaces, and adding the pods of the node to the mesh.
 func (s *Server) Start() {
 	log.Debug("CNI ambient server starting")
 	s.kubeClient.RunAndWait(s.ctx.Done())
-	go func() {
-		s.queue.Run(s.ctx.Done())
-	}()
+	go s.runQueue(s.ctx.Done())
+	go s.reportEnrollment(s.ctx.Done())
+	if s.nodeCheckInterval > 0 {
+		go s.checkNodeRedirection(s.ctx.Done())
+	}
 }
 
+// stopReconcilingTimeout bounds how long Stop waits for the cancelled reconciliations of pods, as the operations which
+// do not observe their context, such as netlink calls, may hang forever.
+var stopReconcilingTimeout = 10 * time.Second
+
+// Stop waits for the pod events being reconciled, and removes the redirection configured on the node. The events
+// which are not reconciled yet are dropped, as their redirection would be removed anyway. The reconciliations which
+// timed out are cancelled, and waited for too, so they do not configure the node while or after it is cleaned up,
+// unless they still hang after stopReconcilingTimeout, in which case the node is cleaned up anyway.
 func (s *Server) Stop() {
-	log.Info("CNI ambient server terminating, cleaning up node net rules")
-	s.cleanupNode()
+	log.Info("CNI ambient server terminating, waiting for the pod events being reconciled")
+	s.queue.ShutDownWithDrain()
+	s.cancelReconcile()
+	done := make(chan struct{})
+	go func() {
+		s.reconciling.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(stopReconcilingTimeout):
+		log.Warnf("pod reconciliations still running after %v, cleaning up the node anyway", stopReconcilingTimeout)
+	}
+	log.Info("cleaning up node net rules")
+	s.redirector.Cleanup()
+	if s.dnsShim != nil {
+		s.dnsShim.Stop()
+	}
 }
 
 func (s *Server) UpdateConfig() {
 	log.Debug("Generating new ambient config file")
 
 	cfg := &AmbientConfigFile{
-		ZTunnelReady: s.isZTunnelRunning(),
-		RedirectMode: s.redirectMode.String(),
+		ZTunnelReady:         s.isZTunnelRunning(),
+		RedirectMode:         s.redirectMode.String(),
+		KubeProxyReplacement: s.kubeProxyReplacement,
+
+		InitContainerConflicts: s.initContainerConflicts,
 	}
+	s.mu.Lock()
+	cfg.ExcludeNamespaces = sets.SortedList(s.excludedNamespaces)
+	s.mu.Unlock()
+	cfg.Namespaces = sets.SortedList(s.allowedNamespaces)
+	if s.redirectMode == IptablesMode {
+		routing := Routing
+		cfg.Routing = &routing
+	}
+
+	recordZtunnelReady(cfg.ZTunnelReady)
 
-	if err := cfg.write(); err != nil {
+	if err := cfg.write(s.configFile); err != nil {
 		log.Errorf("Failed to write config file: %v", err)
 	}
 	log.Debug("Done")
@@ -151,11 +409,22 @@ var ztunnelLabels = labels.ValidatedSetSelector(labels.Set{"app": "ztunnel"})
 
 func (s *Server) ReconcileZtunnel() error {
 	pods := s.pods.List(metav1.NamespaceAll, ztunnelLabels)
+	s.ztunnelReadiness.Retain(pods)
 	var activePod *corev1.Pod
 	for _, p := range pods {
-		ready := kube.CheckPodReady(p) == nil
-		if !ready {
-
+		if p.Status.Phase != corev1.PodRunning {
+			log.Debugf("ztunnel pod not running")
+			continue
+		}
+		if err := s.checkZtunnelSecurityContext(s.ctx, p); err != nil {
+			log.Warnf("ztunnel pod %s: %v", p.Name, err)
+			recordEnrollmentFailure(ruleTypeZtunnel, err)
+			continue
+		}
+		// The readiness endpoint is checked as soon as the pod runs, while the pod Ready condition tracks
+		// container restarts after that.
+		ready := s.ztunnelReadiness.Ready(p)
+		if kube.CheckPodReady(p) != nil || !ready {
 			log.Debugf("ztunnel pod not ready")
 			continue
 		}
@@ -171,11 +440,20 @@ func (s *Server) ReconcileZtunnel() error {
 		}
 	}
 
+	var settings ztunnelSettings
+	if activePod != nil {
+		settings = ztunnelSettings{
+			captureDNS: s.captureDNS(activePod),
+			captureUDP: features.UDPCapture.Enabled(),
+		}
+	}
+
 	needsUpdate := false
 	s.mu.Lock()
-	if getUID(s.ztunnelPod) != getUID(activePod) {
-		// Active pod change
+	if getUID(s.ztunnelPod) != getUID(activePod) || s.ztunnelSettings != settings {
+		// Active pod change, or feature flags changed how it is redirected to
 		s.ztunnelPod = activePod
+		s.ztunnelSettings = settings
 		needsUpdate = true
 	}
 	s.mu.Unlock()
@@ -187,64 +465,18 @@ func (s *Server) ReconcileZtunnel() error {
 	s.UpdateConfig()
 	if activePod == nil {
 		log.Infof("active ztunnel updated, no ztunnel running on the node")
-		s.cleanupNode()
+		s.redirector.Cleanup()
+		s.recordNodeProgrammed(nil)
+		rulesProgrammed.With(typeLabel.Value(ruleTypeCleanup), resultLabel.Value(resultSuccess)).Increment()
 		return nil
 	}
 	log.Infof("active ztunnel updated to %v", activePod.Name)
 
-	captureDNS := getEnvFromPod(activePod, "ISTIO_META_DNS_CAPTURE") == "true"
-
-	switch s.redirectMode {
-	case IptablesMode:
-		// TODO: we should not cleanup and recreate; this has downtime. We should mutate the existing rules in place
-		s.cleanupNode()
-		// TODO: this will fail for any networking setup that doesn't create veths for host<->pod networking.
-		// Do we care about that?
-		veth, err := getVethWithDestinationOf(activePod.Status.PodIP)
-		if err != nil {
-			return fmt.Errorf("failed to get veth device: %v", err)
-		}
-		// Create node-level networking rules for redirection
-		err = s.CreateRulesOnNode(veth.Attrs().Name, activePod.Status.PodIP, captureDNS)
-		if err != nil {
-			return fmt.Errorf("failed to configure node for ztunnel: %v", err)
-		}
-		// Collect info needed to jump into node proxy netns and configure it.
-		peerNs, err := getNsNameFromNsID(veth.Attrs().NetNsID)
-		if err != nil {
-			return fmt.Errorf("failed to get ns name: %v", err)
-		}
-		hostIP, err := GetHostIPByRoute(s.pods)
-		if err != nil || hostIP == "" {
-			log.Warnf("failed to getting host IP: %v", err)
-		}
-		peerIndex, err := getPeerIndex(veth)
-		if err != nil {
-			return fmt.Errorf("failed to get veth peerIndex: %v", err)
-		}
-		// Create pod-level networking rules for redirection (from within pod netns)
-		err = s.CreateRulesWithinNodeProxyNS(peerIndex, activePod.Status.PodIP, peerNs, hostIP)
-		if err != nil {
-			return fmt.Errorf("failed to configure node for ztunnel: %v", err)
-		}
-	case EbpfMode:
-		h, err := GetHostIPByRoute(s.pods)
-		if err != nil || h == "" {
-			log.Warnf("failed to getting host IP: %v", err)
-		} else if HostIP != h {
-			log.Infof("HostIP changed: (%v) -> (%v)", HostIP, h)
-			HostIP = h
-			if err := s.ebpfServer.UpdateHostIP([]string{HostIP}); err != nil {
-				log.Errorf("failed to update host IP: %v", err)
-			}
-		}
-
-		// TODO: this will fail for any networking setup that doesn't create veths for host<->pod networking.
-		// Do we care about that?
-		if err := s.updateNodeProxyEBPF(activePod, captureDNS); err != nil {
-			return fmt.Errorf("failed to configure ztunnel: %v", err)
-		}
+	if err := recordRulesProgrammed(ruleTypeZtunnel, s.redirector.SetZtunnel(activePod, settings.captureDNS)); err != nil {
+		recordEnrollmentFailure(ruleTypeZtunnel, err)
+		return err
 	}
+	s.recordNodeProgrammed(activePod)
 
 	// Reconcile namespaces, as it is possible for the original reconciliation to have failed, and a
 	// small pod to have started up before ztunnel is running... so we need to go back and make sure we
@@ -253,6 +485,12 @@ func (s *Server) ReconcileZtunnel() error {
 	return nil
 }
 
+// ztunnelSettings are the settings of the redirection to ztunnel, which depend on ztunnel and the feature flags.
+type ztunnelSettings struct {
+	captureDNS bool
+	captureUDP bool
+}
+
 // getUID is a nil safe UID accessor
 func getUID(o *corev1.Pod) types.UID {
 	if o == nil {
@@ -261,9 +499,16 @@ func getUID(o *corev1.Pod) types.UID {
 	return o.GetUID()
 }
 
-func (c *AmbientConfigFile) write() error {
-	configFile := constants.AmbientConfigFilepath
+// NamespaceAllowed returns whether the pods of the namespace may be added to the mesh, so the CNI plugin does not
+// capture the pods the node agent would not.
+func (c *AmbientConfigFile) NamespaceAllowed(namespace string) bool {
+	if slices.Contains(c.ExcludeNamespaces, namespace) {
+		return false
+	}
+	return len(c.Namespaces) == 0 || slices.Contains(c.Namespaces, namespace)
+}
 
+func (c *AmbientConfigFile) write(configFile string) error {
 	data, err := json.Marshal(c)
 	if err != nil {
 		return err
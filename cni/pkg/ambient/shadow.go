@@ -0,0 +1,214 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"net"
+	"net/netip"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/cni/pkg/ambient/audit"
+	ebpf "istio.io/istio/cni/pkg/ebpf/server"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// Reasons the eBPF redirection would not capture a pod the iptables redirection captures.
+const (
+	shadowReasonHostPorts = "host_ports"
+	shadowReasonNoIPv4    = "no_ipv4"
+	shadowReasonError     = "error"
+)
+
+// Pods redirected by only one of the redirect modes, compared by compareEbpfShadow.
+const (
+	shadowIptablesOnly = "iptables_only"
+	shadowEbpfOnly     = "ebpf_only"
+)
+
+// ebpfShadowRedirector redirects the pods with iptables, and programs the eBPF redirection alongside it in
+// observe-only mode: the eBPF programs are loaded and their maps updated, but they are not attached, so they do not
+// affect traffic. The redirect state each redirect mode is programmed with, the ipset and the eBPF maps, is compared,
+// and their divergences exported as metrics, to build confidence in the migration of the node to the eBPF redirect
+// mode before performing it. As the programs are not attached, the traffic they would capture is not compared: only
+// the pods the eBPF redirection does not support, and the ones it failed or forgot to program, are reported.
+//
+// The shadow redirection never fails the iptables one: its errors are logged, and counted as divergences.
+type ebpfShadowRedirector struct {
+	*iptablesRedirector
+
+	// iptablesOnly and ebpfOnly are the mismatched pod IPs found by the previous comparison. Only the ones found by
+	// consecutive comparisons are reported, so the pods being reconciled while the redirections are listed are not.
+	iptablesOnly, ebpfOnly sets.String
+}
+
+var _ Redirector = &ebpfShadowRedirector{}
+
+// startEbpfShadow starts the observe-only eBPF redirection, and wraps the iptables redirector to program it alongside.
+// The shadow redirection is skipped if the node cannot run it, rather than failing the node agent.
+func (s *Server) startEbpfShadow(ctx context.Context, logLevel string) {
+	if !ebpf.EBPFSupport() {
+		log.Warnf("the kernel does not support the ebpf redirection, ignoring the shadow ebpf redirection")
+		return
+	}
+	if missing, err := missingCapabilities(ebpfCapabilities); err == nil && len(missing) > 0 {
+		log.Warnf("the shadow ebpf redirection requires the missing capabilities %v, ignoring it", missing)
+		return
+	}
+	log.Infof("running the ebpf redirection in observe-only mode alongside the iptables redirection")
+	if s.nodeTraffic.captured() || s.dnsCapture.nodeLocal() {
+		log.Infof("the node traffic and DNS capture settings are only supported by the iptables redirect mode, " +
+			"so they are not compared")
+	}
+	s.ebpfServer = ebpf.NewRedirectServer()
+	s.ebpfServer.SetObserveOnly(true)
+	s.ebpfServer.SetLogLevel(logLevel)
+	s.ebpfServer.SetKubeProxyReplacement(s.kubeProxyReplacement)
+	s.ebpfServer.SetRequestHandler(auditEBPFRequest)
+	s.ebpfServer.Start(ctx.Done())
+	if err := audit.RecordNode(audit.KindBPF, "update host IP to "+HostIP, s.ebpfServer.UpdateHostIP([]string{HostIP})); err != nil {
+		log.Warnf("failed to update the host IP of the shadow ebpf redirection: %v", err)
+	}
+	s.redirector = &ebpfShadowRedirector{iptablesRedirector: s.redirector.(*iptablesRedirector)}
+}
+
+func warnEbpfShadowIgnored(enabled bool) {
+	if enabled {
+		log.Warnf("the shadow ebpf redirection is only supported by the iptables redirect mode, and is ignored")
+	}
+}
+
+func (r *ebpfShadowRedirector) AddPod(ctx context.Context, pod *corev1.Pod) error {
+	if err := r.iptablesRedirector.AddPod(ctx, pod); err != nil {
+		return err
+	}
+	if reason := ebpfUncaptured(pod); reason != "" {
+		log.Debugf("pod %s/%s would not be captured in the ebpf redirect mode: %s", pod.Namespace, pod.Name, reason)
+		ebpfShadowDivergences.With(reasonLabel.Value(reason)).Increment()
+		return nil
+	}
+	if err := r.s.updatePodEbpfOnNode(pod); err != nil {
+		log.Warnf("failed to update the shadow ebpf redirection of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		ebpfShadowDivergences.With(reasonLabel.Value(shadowReasonError)).Increment()
+	}
+	return nil
+}
+
+func (r *ebpfShadowRedirector) DelPod(ctx context.Context, pod *corev1.Pod) error {
+	if err := r.iptablesRedirector.DelPod(ctx, pod); err != nil {
+		return err
+	}
+	r.delShadowPod(pod)
+	return nil
+}
+
+func (r *ebpfShadowRedirector) delPods(ctx context.Context, pods []*corev1.Pod) {
+	r.iptablesRedirector.delPods(ctx, pods)
+	for _, pod := range pods {
+		r.delShadowPod(pod)
+	}
+}
+
+func (r *ebpfShadowRedirector) delShadowPod(pod *corev1.Pod) {
+	if pod.Spec.HostNetwork {
+		return
+	}
+	if err := r.s.delPodEbpfOnNode(pod); err != nil {
+		log.Warnf("failed to remove the shadow ebpf redirection of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+}
+
+func (r *ebpfShadowRedirector) SetZtunnel(ztunnel *corev1.Pod, captureDNS bool) error {
+	if err := r.iptablesRedirector.SetZtunnel(ztunnel, captureDNS); err != nil {
+		return err
+	}
+	if err := r.s.updateNodeProxyEBPFMaps(ztunnel, captureDNS); err != nil {
+		log.Warnf("failed to update the shadow ebpf redirection of ztunnel: %v", err)
+	}
+	return nil
+}
+
+func (r *ebpfShadowRedirector) Cleanup() {
+	r.iptablesRedirector.Cleanup()
+	if err := r.s.delZtunnelEbpfOnNode(); err != nil {
+		log.Warnf("failed to remove the shadow ebpf redirection of ztunnel: %v", err)
+	}
+}
+
+// ebpfUncaptured returns why the eBPF redirection would not capture a pod, or an empty string if it would. The eBPF
+// redirection cannot skip ztunnel for the connections to hostPorts, and only redirects the IPv4 address of pods.
+func ebpfUncaptured(pod *corev1.Pod) string {
+	if len(PodHostPorts(pod)) > 0 {
+		return shadowReasonHostPorts
+	}
+	for _, ip := range podIPs(pod) {
+		if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() != nil {
+			return ""
+		}
+	}
+	return shadowReasonNoIPv4
+}
+
+// compareEbpfShadow records the number of pods whose IPv4 address is in the redirect state of only one of the
+// iptables redirection and the shadow eBPF redirection, in consecutive comparisons. It is run with the node checks,
+// without blocking the reconciliation of pods.
+func (s *Server) compareEbpfShadow() {
+	r, ok := s.redirector.(*ebpfShadowRedirector)
+	if !ok {
+		return
+	}
+	actual, err := r.iptablesRedirector.actualArtifacts()
+	if err != nil {
+		log.Warnf("failed to list the iptables redirection: %v", err)
+		return
+	}
+	addrs, err := s.ebpfServer.AppAddrs()
+	if err != nil {
+		log.Warnf("failed to list the shadow ebpf redirection: %v", err)
+		return
+	}
+	iptablesOnly, ebpfOnly := r.persistentMismatches(shadowMismatches(actual, addrs))
+	if iptablesOnly.Len() > 0 || ebpfOnly.Len() > 0 {
+		log.Debugf("the shadow ebpf redirection diverges: redirected by iptables only %v, by ebpf only %v",
+			sets.SortedList(iptablesOnly), sets.SortedList(ebpfOnly))
+	}
+	ebpfShadowMismatchedPods.With(typeLabel.Value(shadowIptablesOnly)).Record(float64(iptablesOnly.Len()))
+	ebpfShadowMismatchedPods.With(typeLabel.Value(shadowEbpfOnly)).Record(float64(ebpfOnly.Len()))
+}
+
+// persistentMismatches returns the mismatches which were also found by the previous comparison, and records them for
+// the next one. The mismatches which are not found again were pods being added or removed.
+func (r *ebpfShadowRedirector) persistentMismatches(iptablesOnly, ebpfOnly sets.String) (sets.String, sets.String) {
+	persistentIptablesOnly, persistentEbpfOnly := iptablesOnly.Intersection(r.iptablesOnly), ebpfOnly.Intersection(r.ebpfOnly)
+	r.iptablesOnly, r.ebpfOnly = iptablesOnly, ebpfOnly
+	return persistentIptablesOnly, persistentEbpfOnly
+}
+
+// shadowMismatches returns the pod IPs in the ipset of the iptables redirection but not in the eBPF maps, and the
+// ones in the eBPF maps but not in the ipset.
+func shadowMismatches(iptables []artifact, ebpf []netip.Addr) (iptablesOnly, ebpfOnly sets.String) {
+	inIpset := sets.New[string]()
+	for _, a := range iptables {
+		if a.ip != "" && a.value == ipsetArtifact(a.ip) {
+			inIpset.Insert(a.ip)
+		}
+	}
+	inEbpf := sets.New[string]()
+	for _, addr := range ebpf {
+		inEbpf.Insert(addr.String())
+	}
+	return inIpset.Difference(inEbpf), inEbpf.Difference(inIpset)
+}
@@ -0,0 +1,89 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"net/netip"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/util/sets"
+)
+
+func TestEbpfUncaptured(t *testing.T) {
+	cases := []struct {
+		name string
+		pod  *corev1.Pod
+		want string
+	}{
+		{
+			name: "captured",
+			pod:  &corev1.Pod{Status: corev1.PodStatus{PodIP: "10.0.0.1"}},
+		},
+		{
+			name: "dual stack",
+			pod: &corev1.Pod{Status: corev1.PodStatus{
+				PodIP:  "fd00::1",
+				PodIPs: []corev1.PodIP{{IP: "fd00::1"}, {IP: "10.0.0.1"}},
+			}},
+		},
+		{
+			name: "IPv6 only",
+			pod:  &corev1.Pod{Status: corev1.PodStatus{PodIP: "fd00::1"}},
+			want: shadowReasonNoIPv4,
+		},
+		{
+			name: "hostPorts",
+			pod: &corev1.Pod{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Ports: []corev1.ContainerPort{{ContainerPort: 8080, HostPort: 80}},
+				}}},
+				Status: corev1.PodStatus{PodIP: "10.0.0.1"},
+			},
+			want: shadowReasonHostPorts,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, ebpfUncaptured(tt.pod), tt.want)
+		})
+	}
+}
+
+func TestShadowMismatches(t *testing.T) {
+	iptables := []artifact{
+		{ip: "10.0.0.1", value: ipsetArtifact("10.0.0.1")},
+		{ip: "10.0.0.1", value: "route table 100 10.0.0.1/32 via 192.168.126.2 dev istioin"},
+		{ip: "10.0.0.2", value: ipsetArtifact("10.0.0.2")},
+		{value: "link istioin"},
+	}
+	ebpf := []netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.3")}
+	iptablesOnly, ebpfOnly := shadowMismatches(iptables, ebpf)
+	assert.Equal(t, sets.SortedList(iptablesOnly), []string{"10.0.0.2"})
+	assert.Equal(t, sets.SortedList(ebpfOnly), []string{"10.0.0.3"})
+}
+
+func TestPersistentMismatches(t *testing.T) {
+	r := &ebpfShadowRedirector{}
+	// The mismatches found once may be pods being reconciled
+	iptablesOnly, ebpfOnly := r.persistentMismatches(sets.New("10.0.0.1", "10.0.0.2"), sets.New("10.0.0.3"))
+	assert.Equal(t, iptablesOnly.Len(), 0)
+	assert.Equal(t, ebpfOnly.Len(), 0)
+	iptablesOnly, ebpfOnly = r.persistentMismatches(sets.New("10.0.0.2"), sets.New("10.0.0.3", "10.0.0.4"))
+	assert.Equal(t, sets.SortedList(iptablesOnly), []string{"10.0.0.2"})
+	assert.Equal(t, sets.SortedList(ebpfOnly), []string{"10.0.0.3"})
+}
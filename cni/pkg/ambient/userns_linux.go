@@ -0,0 +1,116 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Rootless kubelets, such as the ones of Usernetes or of kind with rootless Docker or Podman, run the node and its pods
+// in a user namespace. The capabilities of the node agent are then only held in this user namespace: they allow
+// programming iptables and entering the network namespaces owned by it, as the ones of the node and of the pods, but
+// not loading eBPF programs, which requires capabilities in the initial user namespace of the host.
+
+// userNamespace is the user namespace the node agent runs in.
+type userNamespace struct {
+	// initial is set if the node agent runs in the initial user namespace of the host.
+	initial bool
+	// uidMap is the mapping of the user IDs of the user namespace to the ones of its parent, such as "0 100000 65536".
+	uidMap string
+	// ownsNetwork is set if the user namespace owns the network namespace of the node agent, which is the one of the
+	// node, so its capabilities apply to it.
+	ownsNetwork bool
+}
+
+// detectUserNamespace detects the user namespace the node agent runs in.
+func detectUserNamespace() (userNamespace, error) {
+	b, err := os.ReadFile("/proc/self/uid_map")
+	if err != nil {
+		return userNamespace{}, err
+	}
+	u := userNamespace{uidMap: formatUIDMap(string(b))}
+	u.initial = u.uidMap == initialUIDMap
+	if u.initial {
+		u.ownsNetwork = true
+		return u, nil
+	}
+	u.ownsNetwork, err = ownsNetworkNamespace()
+	if err != nil {
+		return u, fmt.Errorf("failed to get the owner of the network namespace: %v", err)
+	}
+	return u, nil
+}
+
+// initialUIDMap is the mapping of the user IDs of the initial user namespace, which maps all of them to themselves.
+const initialUIDMap = "0 0 4294967295"
+
+// formatUIDMap formats the content of a uid_map file on one line, with the ranges separated by commas.
+func formatUIDMap(m string) string {
+	var ranges []string
+	for _, l := range strings.Split(m, "\n") {
+		if f := strings.Fields(l); len(f) > 0 {
+			ranges = append(ranges, strings.Join(f, " "))
+		}
+	}
+	return strings.Join(ranges, ",")
+}
+
+// ownsNetworkNamespace returns whether the user namespace of the process owns its network namespace.
+func ownsNetworkNamespace() (bool, error) {
+	netns, err := os.Open("/proc/self/ns/net")
+	if err != nil {
+		return false, err
+	}
+	defer netns.Close()
+	fd, err := unix.IoctlRetInt(int(netns.Fd()), unix.NS_GET_USERNS)
+	if err == unix.EPERM {
+		// The owner is an ancestor of the user namespace of the process
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer unix.Close(fd)
+	var owner, self unix.Stat_t
+	if err := unix.Fstat(fd, &owner); err != nil {
+		return false, err
+	}
+	if err := unix.Stat("/proc/self/ns/user", &self); err != nil {
+		return false, err
+	}
+	return owner.Dev == self.Dev && owner.Ino == self.Ino, nil
+}
+
+// unsupported returns why the redirect mode cannot be programmed by the node agent in the user namespace, or nil if it
+// can.
+func (u userNamespace) unsupported(mode RedirectMode) error {
+	if u.initial || mode == ExternalMode {
+		return nil
+	}
+	if !u.ownsNetwork {
+		return fmt.Errorf("the node agent runs in a user namespace (uid map %s) which does not own the network "+
+			"namespace of the node, so its capabilities do not allow programming the redirection; run it in the user "+
+			"namespace of the node, or program the redirection with an external redirector", u.uidMap)
+	}
+	if mode == EbpfMode {
+		return fmt.Errorf("the node agent runs in a user namespace (uid map %s), such as under a rootless kubelet, "+
+			"where eBPF programs cannot be loaded; use the iptables redirect mode", u.uidMap)
+	}
+	return nil
+}
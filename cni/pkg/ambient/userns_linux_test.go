@@ -0,0 +1,61 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestFormatUIDMap(t *testing.T) {
+	assert.Equal(t, formatUIDMap("         0          0 4294967295\n"), initialUIDMap)
+	assert.Equal(t, formatUIDMap("         0       1000          1\n         1     100000      65536\n"),
+		"0 1000 1,1 100000 65536")
+}
+
+func TestUserNamespaceUnsupported(t *testing.T) {
+	initial := userNamespace{initial: true, uidMap: initialUIDMap, ownsNetwork: true}
+	rootless := userNamespace{uidMap: "0 1000 1,1 100000 65536", ownsNetwork: true}
+	foreign := userNamespace{uidMap: "0 100000 65536"}
+	for _, mode := range []RedirectMode{IptablesMode, EbpfMode, ExternalMode} {
+		assert.NoError(t, initial.unsupported(mode))
+		assert.NoError(t, foreign.unsupported(ExternalMode))
+		if mode != ExternalMode {
+			assert.Error(t, foreign.unsupported(mode))
+		}
+	}
+	// Rootless kubelets are supported with the iptables redirection, their network namespaces being owned by the user
+	// namespace of the node agent
+	assert.NoError(t, rootless.unsupported(IptablesMode))
+	assert.Error(t, rootless.unsupported(EbpfMode))
+}
+
+func TestDetectUserNamespace(t *testing.T) {
+	u, err := detectUserNamespace()
+	assert.NoError(t, err)
+	assert.Equal(t, u.initial, u.uidMap == initialUIDMap)
+	if u.initial {
+		assert.Equal(t, u.ownsNetwork, true)
+	}
+}
+
+func TestNetnsPath(t *testing.T) {
+	assert.Equal(t, netnsPath("cni-1234"), "/var/run/netns/cni-1234")
+	// The paths passed by the container runtime are used as is
+	assert.Equal(t, netnsPath("/var/run/netns/cni-1234"), "/var/run/netns/cni-1234")
+	assert.Equal(t, netnsPath("/run/user/1000/netns/cni-1234"), "/run/user/1000/netns/cni-1234")
+	assert.Equal(t, netnsPath("/proc/42/ns/net"), "/proc/42/ns/net")
+}
@@ -16,11 +16,14 @@ package ambient
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ExecList struct {
@@ -36,7 +39,12 @@ func newExec(cmd string, args []string) *ExecList {
 }
 
 func executeOutput(cmd string, args ...string) (string, error) {
-	externalCommand := exec.Command(cmd, args...)
+	return executeOutputContext(context.Background(), cmd, args...)
+}
+
+// executeOutputContext is like executeOutput, but kills the command if the context is done before it completes.
+func executeOutputContext(ctx context.Context, cmd string, args ...string) (string, error) {
+	externalCommand := exec.CommandContext(ctx, cmd, args...)
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	externalCommand.Stdout = stdout
@@ -52,8 +60,14 @@ func executeOutput(cmd string, args ...string) (string, error) {
 }
 
 func execute(cmd string, args ...string) error {
+	return executeContext(context.Background(), cmd, args...)
+}
+
+// executeContext is like execute, but kills the command if the context is done before it completes.
+func executeContext(ctx context.Context, cmd string, args ...string) error {
+	args = iptablesWait(cmd, args)
 	log.Debugf("Running command: %s %s", cmd, strings.Join(args, " "))
-	externalCommand := exec.Command(cmd, args...)
+	externalCommand := exec.CommandContext(ctx, cmd, args...)
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
 	externalCommand.Stdout = stdout
@@ -67,10 +81,53 @@ func execute(cmd string, args ...string) error {
 
 	if err != nil || len(stderr.Bytes()) != 0 {
 		log.Debugf("Command error output: \n%v", stderr.String())
-		return errors.New(stderr.String())
+		err = errors.New(stderr.String())
+	}
+	auditCommand(ctx, cmd, args, err)
+	return err
+}
+
+// iptablesWait makes the iptables commands wait for the xtables lock rather than fail when it is held, as the workers
+// reconcile pods concurrently, and kube-proxy or other agents may change the rules of the node at the same time.
+func iptablesWait(cmd string, args []string) []string {
+	name := filepath.Base(cmd)
+	if !strings.HasPrefix(name, "iptables") && !strings.HasPrefix(name, "ip6tables") || strings.Contains(name, "-save") {
+		return args
+	}
+	for _, a := range args {
+		if a == "-w" || a == "--wait" || strings.HasPrefix(a, "--wait=") {
+			return args
+		}
 	}
+	return append([]string{"-w"}, args...)
+}
 
-	return nil
+// recordPodWarning records a warning event on a pod.
+func (s *Server) recordPodWarning(ctx context.Context, pod *corev1.Pod, reason, msg string) {
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pod.Name + ".",
+			Namespace:    pod.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Name:       pod.Name,
+			Namespace:  pod.Namespace,
+			UID:        pod.UID,
+		},
+		Reason:         reason,
+		Message:        msg,
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: "istio-cni", Host: NodeName},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if _, err := s.kubeClient.Kube().CoreV1().Events(pod.Namespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+		log.Warnf("failed to create event for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
 }
 
 func getEnvFromPod(pod *corev1.Pod, envName string) string {
@@ -0,0 +1,45 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ambient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestExecuteContext(t *testing.T) {
+	assert.NoError(t, executeContext(context.Background(), "true"))
+
+	// Hung commands are killed once the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	assert.Error(t, executeContext(ctx, "sleep", "10"))
+	if d := time.Since(start); d > 5*time.Second {
+		t.Fatalf("command was not killed on timeout, took %v", d)
+	}
+}
+
+func TestIptablesWait(t *testing.T) {
+	assert.Equal(t, iptablesWait("iptables", []string{"-t", "nat", "-F"}), []string{"-w", "-t", "nat", "-F"})
+	assert.Equal(t, iptablesWait("/usr/sbin/ip6tables-legacy", []string{"-L"}), []string{"-w", "-L"})
+	// Commands already waiting, the commands without the lock option and the other commands are left untouched
+	assert.Equal(t, iptablesWait("iptables-nft", []string{"--wait=5", "-L"}), []string{"--wait=5", "-L"})
+	assert.Equal(t, iptablesWait("iptables-save", []string{"-t", "nat"}), []string{"-t", "nat"})
+	assert.Equal(t, iptablesWait("ip", []string{"route", "show"}), []string{"route", "show"})
+}
@@ -20,12 +20,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
 	"github.com/spf13/viper"
 
 	"istio.io/istio/cni/pkg/ambient"
+	ambientconstants "istio.io/istio/cni/pkg/ambient/constants"
 	"istio.io/istio/cni/pkg/config"
 	"istio.io/istio/cni/pkg/constants"
 	"istio.io/istio/cni/pkg/install"
@@ -71,7 +73,17 @@ var rootCmd = &cobra.Command{
 		log.Infof("CNI race repair configuration: \n%+v", cfg.RepairConfig)
 
 		// Start metrics server
-		monitoring.SetupMonitoring(cfg.InstallConfig.MonitoringPort, "/metrics", ctx.Done())
+		tlsOpts := monitoring.TLSOptions{
+			Port:              cfg.InstallConfig.MonitoringTLSPort,
+			CertFile:          cfg.InstallConfig.MonitoringTLSCertFile,
+			KeyFile:           cfg.InstallConfig.MonitoringTLSKeyFile,
+			CAFile:            cfg.InstallConfig.MonitoringTLSCAFile,
+			AllowedIdentities: cfg.InstallConfig.MonitoringTLSAllowedIdentities,
+		}
+		if len(tlsOpts.AllowedIdentities) == 0 {
+			tlsOpts.AllowedIdentities = monitoring.DefaultAllowedIdentities(ambient.PodNamespace)
+		}
+		mux := monitoring.SetupMonitoring(cfg.InstallConfig.MonitoringPort, "/metrics", cfg.InstallConfig.EnableProfiling, tlsOpts, ctx.Done())
 
 		// Start UDS log server
 		udsLogger := udsLog.NewUDSLogger()
@@ -91,10 +103,58 @@ var rootCmd = &cobra.Command{
 				Revision:        ambient.Revision,
 				RedirectMode:    redirectMode,
 				LogLevel:        cfg.InstallConfig.LogLevel,
+				EnrollmentHook: ambient.EnrollmentHookArgs{
+					URL:           cfg.InstallConfig.EnrollmentHookURL,
+					Exec:          cfg.InstallConfig.EnrollmentHookExec,
+					Timeout:       cfg.InstallConfig.EnrollmentHookTimeout,
+					FailurePolicy: cfg.InstallConfig.EnrollmentHookFailurePolicy,
+				},
+				ConntrackFlush:       cfg.InstallConfig.AmbientConntrackFlush,
+				KubeProxyReplacement: cfg.InstallConfig.AmbientKubeProxyReplacement,
+				EbpfShadow:           cfg.InstallConfig.EbpfShadow,
+				TrimInformers:        cfg.InstallConfig.AmbientTrimInformers,
+				ReconcileTimeout:     cfg.InstallConfig.AmbientReconcileTimeout,
+				Workers:              cfg.InstallConfig.AmbientWorkers,
+				NodeCheckInterval:    cfg.InstallConfig.AmbientNodeCheckInterval,
+				ReadinessGate:        cfg.InstallConfig.AmbientReadinessGate,
+				Namespaces:           cfg.InstallConfig.AmbientNamespaces,
+				CaptureDir:           cfg.InstallConfig.AmbientCaptureDir,
+				InstallConfigMap:     cfg.InstallConfig.AmbientConfigMap,
+				Audit: ambient.AuditConfig{
+					Path:       cfg.InstallConfig.AmbientAuditLog,
+					MaxSizeMB:  cfg.InstallConfig.AmbientAuditLogMaxSize,
+					MaxBackups: cfg.InstallConfig.AmbientAuditLogMaxBackups,
+					Events:     cfg.InstallConfig.AmbientAuditEvents,
+				},
+				NodeTraffic: ambient.NodeTrafficConfig{
+					Mode:  ambient.NodeTrafficMode(cfg.InstallConfig.AmbientNodeTraffic),
+					Ports: cfg.InstallConfig.AmbientNodeTrafficPorts,
+				},
+				DNSCapture: ambient.DNSCaptureConfig{
+					Backend:  ambient.DNSCaptureBackend(cfg.InstallConfig.AmbientDNSCaptureBackend),
+					ShimPort: cfg.InstallConfig.AmbientDNSShimPort,
+				},
+				Routing: ambient.RoutingConfig{
+					RouteTableBase:   cfg.InstallConfig.AmbientRouteTableBase,
+					RulePriorityBase: cfg.InstallConfig.AmbientRulePriorityBase,
+					FwmarkShift:      cfg.InstallConfig.AmbientFwmarkShift,
+				},
+				RoutingAutoResolve: cfg.InstallConfig.AmbientRoutingAutoResolve,
+				KubeClient: ambient.KubeClientConfig{
+					QPS:   float32(cfg.InstallConfig.AmbientKubeClientQPS),
+					Burst: cfg.InstallConfig.AmbientKubeClientBurst,
+				},
+				InitContainerConflicts: ambient.InitContainerConflictPolicy(cfg.InstallConfig.AmbientInitContainerConflicts),
 			})
 			if err != nil {
 				return fmt.Errorf("failed to create ambient informer service: %v", err)
 			}
+			if mux != nil {
+				server.RegisterDebugHandlers(mux)
+			}
+			if admin := monitoring.SetupAdmin(cfg.InstallConfig.AdminPort, ctx.Done()); admin != nil {
+				server.RegisterAdminHandlers(admin)
+			}
 			server.Start()
 			defer server.Stop()
 		}
@@ -149,6 +209,8 @@ func init() {
 	registerStringParameter(constants.CNINetDir, "/etc/cni/net.d", "Directory on the host where CNI network plugins are installed")
 	registerStringParameter(constants.CNIConfName, "", "Name of the CNI configuration file")
 	registerBooleanParameter(constants.ChainedCNIPlugin, true, "Whether to install CNI plugin as a chained or standalone")
+	registerBooleanParameter(constants.CiliumChaining, false,
+		"Whether to chain into a Cilium-managed CNI config, such as on GKE Dataplane V2, and verify istio-cni stays in the chain")
 	registerStringParameter(constants.CNINetworkConfig, "", "CNI configuration template as a string")
 	registerBooleanParameter(constants.CNIEnableInstall, true, "Whether to install CNI configuration and binary files")
 	registerBooleanParameter(constants.CNIEnableReinstall, true, "Whether to reinstall CNI configuration and binary files")
@@ -166,9 +228,108 @@ func init() {
 	registerStringArrayParameter(constants.SkipCNIBinaries, []string{},
 		"Binaries that should not be installed. Currently Istio only installs one binary `istio-cni`")
 	registerIntegerParameter(constants.MonitoringPort, 15014, "HTTP port to serve prometheus metrics")
+	registerIntegerParameter(constants.AdminPort, 15016,
+		"HTTP port of the loopback interface to serve the handlers changing the redirection of the node on, such as its flush")
+	registerBooleanParameter(constants.EnableProfiling, false,
+		"Whether to serve pprof profiles, including goroutine dumps, and memory stats under /debug/ on the monitoring port")
+	registerIntegerParameter(constants.MonitoringTLSPort, 15015,
+		"Port to serve the debug handlers on over TLS when the monitoring TLS certificate is set, apart from the metrics, "+
+			"which are still served over plain HTTP on the monitoring port")
+	registerStringParameter(constants.MonitoringTLSCertFile, "",
+		"Certificate chain to serve the debug handlers over TLS with, reloaded when it changes. "+
+			"They are served on the monitoring port over plain HTTP if unset")
+	registerStringParameter(constants.MonitoringTLSKeyFile, "", "Private key of the monitoring TLS certificate, reloaded when it changes")
+	registerStringParameter(constants.MonitoringTLSCAFile, "",
+		"CA certificates the client certificates of the monitoring port are verified with, reloaded when they change. "+
+			"If set, clients must present a certificate")
+	registerStringArrayParameter(constants.MonitoringTLSAllowedIdentities, []string{},
+		"The <namespace>/<service account> identities of the clients allowed on the monitoring port with mTLS. "+
+			"Defaults to istiod and istioctl in the system namespace")
 	registerStringParameter(constants.LogUDSAddress, "/var/run/istio-cni/log.sock", "The UDS server address which CNI plugin will copy log ouptut to")
 	registerBooleanParameter(constants.AmbientEnabled, false, "Whether ambient controller is enabled")
 	registerBooleanParameter(constants.EbpfEnabled, false, "Whether ebpf redirection is enabled")
+	registerBooleanParameter(constants.EbpfShadow, false,
+		"Whether to run the ebpf redirection in observe-only mode alongside the iptables redirection, exporting the "+
+			"divergences between the pods each is programmed to redirect as metrics, before migrating to the ebpf "+
+			"redirection. The ebpf programs are not attached, so the traffic they would capture is not compared")
+	registerStringParameter(constants.EnrollmentHookURL, "",
+		"URL sent a POST request with the pod metadata when a pod is added to or removed from the ambient mesh")
+	registerStringParameter(constants.EnrollmentHookExec, "",
+		"Executable run with the pod metadata on stdin when a pod is added to or removed from the ambient mesh")
+	registerDurationParameter(constants.EnrollmentHookTimeout, 5*time.Second, "Timeout for each ambient enrollment hook invocation")
+	registerStringParameter(constants.EnrollmentHookFailurePolicy, ambient.HookFailurePolicyIgnore,
+		"What to do when an ambient enrollment hook fails: Ignore enrolls or removes the pod regardless, "+
+			"Fail retries and does not enroll the pod until the hook succeeds")
+	registerBooleanParameter(constants.AmbientConntrackFlush, false,
+		"Whether to flush the conntrack entries of pods added to or removed from the ambient mesh, so existing "+
+			"connections are reset instead of keeping their previous path")
+	registerBooleanParameter(constants.AmbientKubeProxyReplacement, false,
+		"Whether services are handled by the CNI with eBPF programs instead of kube-proxy, such as with Cilium or Calico eBPF, "+
+			"in which case pods are captured before their traffic to service VIPs is translated")
+	registerBooleanParameter(constants.AmbientTrimInformers, true,
+		"Whether to only cache the metadata of namespaces, and the fields of pods used by the ambient node agent, "+
+			"to reduce its memory usage on large clusters")
+	registerDurationParameter(constants.AmbientReconcileTimeout, 30*time.Second,
+		"How long the ambient node agent may take to reconcile a pod event, such as adding it to the mesh, before "+
+			"aborting and retrying it. Set to 0 to disable the timeout")
+	registerIntegerParameter(constants.AmbientWorkers, 1,
+		"How many pod events the ambient node agent reconciles concurrently, such as when enrolling all the pods of the "+
+			"node after it restarts. The events of a pod are always reconciled in order")
+	registerDurationParameter(constants.AmbientNodeCheckInterval, 30*time.Second,
+		"How often the ambient node agent checks that the node redirection to ztunnel, such as its links, ip rules and "+
+			"iptables rules, is still configured, and repairs it if it was removed by other tooling. Set to 0 to disable the check")
+	registerBooleanParameter(constants.AmbientReadinessGate, false,
+		"Whether the ambient node agent sets the istio.io/ambient-ready condition of pods declaring it as a readiness "+
+			"gate once their traffic is redirected to ztunnel, so they are only ready once they are captured")
+	registerStringArrayParameter(constants.AmbientNamespaces, []string{},
+		"Namespaces whose pods the ambient node agent may add to the mesh, so that the nodes of a tenant only program "+
+			"the pods of its namespaces. All namespaces if empty")
+	registerStringParameter(constants.AmbientCaptureDir, "",
+		"Directory the packet captures of pods requested from the ambient node agent are saved to, usually a volume "+
+			"mounted from the node. Captures are disabled if empty")
+	registerStringParameter(constants.AmbientConfigMap, "",
+		"ConfigMap in the system namespace holding the ambient settings of the installation, such as the excluded "+
+			"namespaces, which are reloaded when they change. Not read if empty")
+	registerStringParameter(constants.AmbientAuditLog, "",
+		"File the ambient node agent appends the mutations of the host it performs to, such as the iptables rules, "+
+			"routes and eBPF programs it configures for each pod, as JSON lines. Not written if empty")
+	registerIntegerParameter(constants.AmbientAuditLogMaxSize, 100,
+		"Size in megabytes at which the audit log of the ambient node agent is rotated")
+	registerIntegerParameter(constants.AmbientAuditLogMaxBackups, 5,
+		"How many rotated audit logs of the ambient node agent are retained")
+	registerBooleanParameter(constants.AmbientAuditEvents, false,
+		"Whether the ambient node agent records the mutations of the host as Kubernetes events too, on the pods they "+
+			"are performed for or on the node")
+	registerStringParameter(constants.AmbientNodeTraffic, string(ambient.NodeTrafficExclude),
+		"How the traffic of the pods in the ambient mesh to their node, such as to the kubelet API, is handled by the "+
+			"iptables redirection: exclude sends it to the node directly, capture redirects it to ztunnel, and ports only "+
+			"redirects the TCP traffic to the ports listed in ambient-node-traffic-ports")
+	registerStringArrayParameter(constants.AmbientNodeTrafficPorts, []string{},
+		"Ports of the node whose traffic is redirected to ztunnel in the ports node traffic mode, at most 15")
+	registerStringParameter(constants.AmbientDNSCaptureBackend, string(ambient.DNSCaptureZtunnel),
+		"Where the DNS requests of the pods in the ambient mesh are redirected to by the iptables redirection, when "+
+			"DNS capture is enabled: ztunnel redirects them to the DNS proxy of ztunnel, and node-local to a caching DNS "+
+			"shim run by the node agent, which forwards them to the resolvers of the node agent")
+	registerIntegerParameter(constants.AmbientDNSShimPort, ambientconstants.DNSShimPort,
+		"UDP port the node-local DNS shim of the ambient node agent listens on on the node")
+	registerIntegerParameter(constants.AmbientRouteTableBase, ambientconstants.RouteTableInbound,
+		"First of the three consecutive route tables used by the ambient iptables redirection on the node")
+	registerIntegerParameter(constants.AmbientRulePriorityBase, ambientconstants.RulePriorityBase,
+		"Priority of the first of the four consecutive ip rules used by the ambient iptables redirection on the node")
+	registerIntegerParameter(constants.AmbientFwmarkShift, 0,
+		"How many bits the fwmarks used by the ambient iptables redirection on the node are shifted left by")
+	registerBooleanParameter(constants.AmbientRoutingAutoResolve, true,
+		"Whether to use alternate route tables, rule priorities and fwmarks when the configured ones conflict with "+
+			"the routing configured on the node by others, such as systemd-networkd")
+	registerIntegerParameter(constants.AmbientKubeClientQPS, ambient.DefaultKubeClientQPS,
+		"Rate of requests per second of the ambient node agent to the API server, above which they are delayed by the "+
+			"node agent. Raise it on large clusters, where the node agent may lag behind the cluster when throttled")
+	registerIntegerParameter(constants.AmbientKubeClientBurst, ambient.DefaultKubeClientBurst,
+		"Number of requests the ambient node agent may send to the API server above its rate in bursts")
+	registerStringParameter(constants.AmbientInitContainerConflicts, string(ambient.InitContainerConflictCapture),
+		"How the pods in the ambient mesh whose init containers may program their network, privileged or adding "+
+			"NET_ADMIN, such as VPN clients, are captured: capture captures them as soon as they are assigned an IP, skip "+
+			"does not capture them, and serialize captures them once their init containers completed")
 	// Repair
 	registerBooleanParameter(constants.RepairEnabled, true, "Whether to enable race condition repair or not")
 	registerBooleanParameter(constants.RepairDeletePods, false, "Controller will delete pods when detecting pod broken by race condition")
@@ -214,6 +375,11 @@ func registerBooleanParameter(name string, value bool, usage string) {
 	registerEnvironment(name, value, usage)
 }
 
+func registerDurationParameter(name string, value time.Duration, usage string) {
+	rootCmd.Flags().Duration(name, value, usage)
+	registerEnvironment(name, value, usage)
+}
+
 func registerEnvironment[T env.Parseable](name string, defaultValue T, usage string) {
 	envName := strings.Replace(strings.ToUpper(name), "-", "_", -1)
 	// Note: we do not rely on istio env package to retrieve configuration. We relies on viper.
@@ -235,6 +401,7 @@ func constructConfig() (*config.Config, error) {
 		MountedCNINetDir: viper.GetString(constants.MountedCNINetDir),
 		CNIConfName:      viper.GetString(constants.CNIConfName),
 		ChainedCNIPlugin: viper.GetBool(constants.ChainedCNIPlugin),
+		CiliumChaining:   viper.GetBool(constants.CiliumChaining),
 
 		CNINetworkConfigFile: viper.GetString(constants.CNINetworkConfigFile),
 		CNINetworkConfig:     viper.GetString(constants.CNINetworkConfig),
@@ -256,10 +423,50 @@ func constructConfig() (*config.Config, error) {
 		UpdateCNIBinaries: viper.GetBool(constants.UpdateCNIBinaries),
 		SkipCNIBinaries:   viper.GetStringSlice(constants.SkipCNIBinaries),
 		MonitoringPort:    viper.GetInt(constants.MonitoringPort),
+		AdminPort:         viper.GetInt(constants.AdminPort),
+		EnableProfiling:   viper.GetBool(constants.EnableProfiling),
 		LogUDSAddress:     viper.GetString(constants.LogUDSAddress),
 
+		MonitoringTLSPort:              viper.GetInt(constants.MonitoringTLSPort),
+		MonitoringTLSCertFile:          viper.GetString(constants.MonitoringTLSCertFile),
+		MonitoringTLSKeyFile:           viper.GetString(constants.MonitoringTLSKeyFile),
+		MonitoringTLSCAFile:            viper.GetString(constants.MonitoringTLSCAFile),
+		MonitoringTLSAllowedIdentities: viper.GetStringSlice(constants.MonitoringTLSAllowedIdentities),
+
 		AmbientEnabled: viper.GetBool(constants.AmbientEnabled),
 		EbpfEnabled:    viper.GetBool(constants.EbpfEnabled),
+		EbpfShadow:     viper.GetBool(constants.EbpfShadow),
+
+		EnrollmentHookURL:           viper.GetString(constants.EnrollmentHookURL),
+		EnrollmentHookExec:          viper.GetString(constants.EnrollmentHookExec),
+		EnrollmentHookTimeout:       viper.GetDuration(constants.EnrollmentHookTimeout),
+		EnrollmentHookFailurePolicy: viper.GetString(constants.EnrollmentHookFailurePolicy),
+		AmbientConntrackFlush:       viper.GetBool(constants.AmbientConntrackFlush),
+		AmbientKubeProxyReplacement: viper.GetBool(constants.AmbientKubeProxyReplacement),
+		AmbientTrimInformers:        viper.GetBool(constants.AmbientTrimInformers),
+		AmbientReconcileTimeout:     viper.GetDuration(constants.AmbientReconcileTimeout),
+		AmbientWorkers:              viper.GetInt(constants.AmbientWorkers),
+		AmbientNodeCheckInterval:    viper.GetDuration(constants.AmbientNodeCheckInterval),
+		AmbientReadinessGate:        viper.GetBool(constants.AmbientReadinessGate),
+		AmbientNamespaces:           viper.GetStringSlice(constants.AmbientNamespaces),
+		AmbientCaptureDir:           viper.GetString(constants.AmbientCaptureDir),
+		AmbientConfigMap:            viper.GetString(constants.AmbientConfigMap),
+		AmbientAuditLog:             viper.GetString(constants.AmbientAuditLog),
+		AmbientAuditLogMaxSize:      viper.GetInt(constants.AmbientAuditLogMaxSize),
+		AmbientAuditLogMaxBackups:   viper.GetInt(constants.AmbientAuditLogMaxBackups),
+		AmbientAuditEvents:          viper.GetBool(constants.AmbientAuditEvents),
+		AmbientNodeTraffic:          viper.GetString(constants.AmbientNodeTraffic),
+		AmbientNodeTrafficPorts:     viper.GetIntSlice(constants.AmbientNodeTrafficPorts),
+		AmbientDNSCaptureBackend:    viper.GetString(constants.AmbientDNSCaptureBackend),
+		AmbientDNSShimPort:          viper.GetInt(constants.AmbientDNSShimPort),
+		AmbientRouteTableBase:       viper.GetInt(constants.AmbientRouteTableBase),
+		AmbientRulePriorityBase:     viper.GetInt(constants.AmbientRulePriorityBase),
+		AmbientFwmarkShift:          viper.GetInt(constants.AmbientFwmarkShift),
+		AmbientRoutingAutoResolve:   viper.GetBool(constants.AmbientRoutingAutoResolve),
+		AmbientKubeClientQPS:        viper.GetInt(constants.AmbientKubeClientQPS),
+		AmbientKubeClientBurst:      viper.GetInt(constants.AmbientKubeClientBurst),
+
+		AmbientInitContainerConflicts: viper.GetString(constants.AmbientInitContainerConflicts),
 	}
 
 	if len(installCfg.K8sNodeName) == 0 {
@@ -17,6 +17,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 type Config struct {
@@ -34,6 +35,8 @@ type InstallConfig struct {
 	CNIConfName string
 	// Whether to install CNI plugin as a chained or standalone
 	ChainedCNIPlugin bool
+	// Whether to chain into a Cilium-managed CNI config, such as on GKE Dataplane V2
+	CiliumChaining bool
 
 	// CNI config template file
 	CNINetworkConfigFile string
@@ -76,6 +79,18 @@ type InstallConfig struct {
 
 	// The HTTP port for monitoring
 	MonitoringPort int
+	// The HTTP port of the loopback interface the administrative handlers are served on, such as the flush of the node
+	AdminPort int
+	// Whether to serve pprof and runtime diagnostics on the monitoring port
+	EnableProfiling bool
+	// The port to serve the debug handlers of the monitoring port on over TLS, and the certificate, key and CA files to
+	// serve them with. They are served on the monitoring port over plain HTTP if unset
+	MonitoringTLSPort     int
+	MonitoringTLSCertFile string
+	MonitoringTLSKeyFile  string
+	MonitoringTLSCAFile   string
+	// The <namespace>/<service account> identities of the clients allowed on the monitoring port with mTLS
+	MonitoringTLSAllowedIdentities []string
 
 	// The UDS server address that CNI plugin will send log to.
 	LogUDSAddress string
@@ -85,6 +100,73 @@ type InstallConfig struct {
 
 	// Whether ebpf is enabled
 	EbpfEnabled bool
+	// Whether the ebpf redirection runs in observe-only mode alongside the iptables redirection
+	EbpfShadow bool
+
+	// The URL notified when pods are added to or removed from the ambient mesh
+	EnrollmentHookURL string
+	// The executable run when pods are added to or removed from the ambient mesh
+	EnrollmentHookExec string
+	// The timeout for each enrollment hook invocation
+	EnrollmentHookTimeout time.Duration
+	// Whether enrollment changes proceed ("Ignore") or are retried ("Fail") when the enrollment hook fails
+	EnrollmentHookFailurePolicy string
+
+	// Whether to flush the conntrack entries of pods added to or removed from the ambient mesh
+	AmbientConntrackFlush bool
+
+	// Whether services are handled by the CNI with eBPF, replacing kube-proxy
+	AmbientKubeProxyReplacement bool
+
+	// Whether to only cache the fields of namespaces and pods used by the ambient node agent
+	AmbientTrimInformers bool
+
+	// How long the ambient node agent may take to reconcile a pod event before retrying it
+	AmbientReconcileTimeout time.Duration
+	// How many pod events the ambient node agent reconciles concurrently
+	AmbientWorkers int
+	// How often the ambient node agent checks the node redirection to ztunnel, and repairs it
+	AmbientNodeCheckInterval time.Duration
+	// Whether the ambient node agent releases the ambient readiness gate of pods once they are captured
+	AmbientReadinessGate bool
+	// The namespaces whose pods the ambient node agent may add to the mesh, or all of them if empty
+	AmbientNamespaces []string
+	// The directory the packet captures of pods requested from the ambient node agent are saved to, or empty to disable them
+	AmbientCaptureDir string
+	// The ConfigMap in the system namespace holding the ambient settings of the installation, reloaded when they change
+	AmbientConfigMap string
+	// The file the ambient node agent appends the mutations of the host it performs to, or empty to not write it
+	AmbientAuditLog string
+	// The size in megabytes at which the audit log is rotated
+	AmbientAuditLogMaxSize int
+	// How many rotated audit logs are retained
+	AmbientAuditLogMaxBackups int
+	// Whether the ambient node agent records the mutations of the host as Kubernetes events too
+	AmbientAuditEvents bool
+	// How the traffic of the pods in the ambient mesh to their node is handled: exclude, capture or ports
+	AmbientNodeTraffic string
+	// The ports of the node whose traffic is captured in the ports node traffic mode
+	AmbientNodeTrafficPorts []int
+	// Where the DNS requests of the pods in the ambient mesh are redirected to: ztunnel or node-local
+	AmbientDNSCaptureBackend string
+	// The UDP port of the node-local DNS shim of the ambient node agent
+	AmbientDNSShimPort int
+
+	// The first of the route tables used by the ambient redirection on the node
+	AmbientRouteTableBase int
+	// The priority of the first of the ip rules used by the ambient redirection on the node
+	AmbientRulePriorityBase int
+	// How many bits the fwmarks used by the ambient redirection on the node are shifted by
+	AmbientFwmarkShift int
+	// Whether to pick alternate route tables, rule priorities and fwmarks when they conflict with others on the node
+	AmbientRoutingAutoResolve bool
+
+	// The rate of requests per second of the ambient node agent to the API server
+	AmbientKubeClientQPS int
+	// The number of requests the ambient node agent may send above its rate in bursts
+	AmbientKubeClientBurst int
+	// How the pods whose init containers may program their network are captured: capture, skip or serialize
+	AmbientInitContainerConflicts string
 
 	// Use the external nsenter command for network namespace switching
 	HostNSEnterExec bool
@@ -130,6 +212,7 @@ func (c InstallConfig) String() string {
 	b.WriteString("MountedCNINetDir: " + c.MountedCNINetDir + "\n")
 	b.WriteString("CNIConfName: " + c.CNIConfName + "\n")
 	b.WriteString("ChainedCNIPlugin: " + fmt.Sprint(c.ChainedCNIPlugin) + "\n")
+	b.WriteString("CiliumChaining: " + fmt.Sprint(c.CiliumChaining) + "\n")
 	b.WriteString("CNINetworkConfigFile: " + c.CNINetworkConfigFile + "\n")
 	b.WriteString("CNINetworkConfig: " + c.CNINetworkConfig + "\n")
 	b.WriteString("CNIEnableInstall: " + fmt.Sprint(c.CNIEnableInstall) + "\n")
@@ -148,10 +231,47 @@ func (c InstallConfig) String() string {
 	b.WriteString("UpdateCNIBinaries: " + fmt.Sprint(c.UpdateCNIBinaries) + "\n")
 	b.WriteString("SkipCNIBinaries: " + fmt.Sprint(c.SkipCNIBinaries) + "\n")
 	b.WriteString("MonitoringPort: " + fmt.Sprint(c.MonitoringPort) + "\n")
+	b.WriteString("AdminPort: " + fmt.Sprint(c.AdminPort) + "\n")
+	b.WriteString("EnableProfiling: " + fmt.Sprint(c.EnableProfiling) + "\n")
+	b.WriteString("MonitoringTLSPort: " + fmt.Sprint(c.MonitoringTLSPort) + "\n")
+	b.WriteString("MonitoringTLSCertFile: " + c.MonitoringTLSCertFile + "\n")
+	b.WriteString("MonitoringTLSKeyFile: " + c.MonitoringTLSKeyFile + "\n")
+	b.WriteString("MonitoringTLSCAFile: " + c.MonitoringTLSCAFile + "\n")
+	b.WriteString("MonitoringTLSAllowedIdentities: " + fmt.Sprint(c.MonitoringTLSAllowedIdentities) + "\n")
 	b.WriteString("LogUDSAddress: " + fmt.Sprint(c.LogUDSAddress) + "\n")
 	b.WriteString("HostNSEnterExec: " + fmt.Sprint(c.HostNSEnterExec) + "\n")
 
 	b.WriteString("AmbientEnabled: " + fmt.Sprint(c.AmbientEnabled) + "\n")
+	b.WriteString("EbpfShadow: " + fmt.Sprint(c.EbpfShadow) + "\n")
+	b.WriteString("EnrollmentHookURL: " + c.EnrollmentHookURL + "\n")
+	b.WriteString("EnrollmentHookExec: " + c.EnrollmentHookExec + "\n")
+	b.WriteString("EnrollmentHookTimeout: " + fmt.Sprint(c.EnrollmentHookTimeout) + "\n")
+	b.WriteString("EnrollmentHookFailurePolicy: " + c.EnrollmentHookFailurePolicy + "\n")
+	b.WriteString("AmbientConntrackFlush: " + fmt.Sprint(c.AmbientConntrackFlush) + "\n")
+	b.WriteString("AmbientKubeProxyReplacement: " + fmt.Sprint(c.AmbientKubeProxyReplacement) + "\n")
+	b.WriteString("AmbientTrimInformers: " + fmt.Sprint(c.AmbientTrimInformers) + "\n")
+	b.WriteString("AmbientReconcileTimeout: " + fmt.Sprint(c.AmbientReconcileTimeout) + "\n")
+	b.WriteString("AmbientWorkers: " + fmt.Sprint(c.AmbientWorkers) + "\n")
+	b.WriteString("AmbientNodeCheckInterval: " + fmt.Sprint(c.AmbientNodeCheckInterval) + "\n")
+	b.WriteString("AmbientReadinessGate: " + fmt.Sprint(c.AmbientReadinessGate) + "\n")
+	b.WriteString("AmbientNamespaces: " + fmt.Sprint(c.AmbientNamespaces) + "\n")
+	b.WriteString("AmbientCaptureDir: " + c.AmbientCaptureDir + "\n")
+	b.WriteString("AmbientConfigMap: " + c.AmbientConfigMap + "\n")
+	b.WriteString("AmbientAuditLog: " + c.AmbientAuditLog + "\n")
+	b.WriteString("AmbientAuditLogMaxSize: " + fmt.Sprint(c.AmbientAuditLogMaxSize) + "\n")
+	b.WriteString("AmbientAuditLogMaxBackups: " + fmt.Sprint(c.AmbientAuditLogMaxBackups) + "\n")
+	b.WriteString("AmbientAuditEvents: " + fmt.Sprint(c.AmbientAuditEvents) + "\n")
+	b.WriteString("AmbientNodeTraffic: " + c.AmbientNodeTraffic + "\n")
+	b.WriteString("AmbientNodeTrafficPorts: " + fmt.Sprint(c.AmbientNodeTrafficPorts) + "\n")
+	b.WriteString("AmbientDNSCaptureBackend: " + c.AmbientDNSCaptureBackend + "\n")
+	b.WriteString("AmbientDNSShimPort: " + fmt.Sprint(c.AmbientDNSShimPort) + "\n")
+	b.WriteString("AmbientRouteTableBase: " + fmt.Sprint(c.AmbientRouteTableBase) + "\n")
+	b.WriteString("AmbientRulePriorityBase: " + fmt.Sprint(c.AmbientRulePriorityBase) + "\n")
+	b.WriteString("AmbientFwmarkShift: " + fmt.Sprint(c.AmbientFwmarkShift) + "\n")
+	b.WriteString("AmbientRoutingAutoResolve: " + fmt.Sprint(c.AmbientRoutingAutoResolve) + "\n")
+	b.WriteString("AmbientKubeClientQPS: " + fmt.Sprint(c.AmbientKubeClientQPS) + "\n")
+	b.WriteString("AmbientKubeClientBurst: " + fmt.Sprint(c.AmbientKubeClientBurst) + "\n")
+	b.WriteString("AmbientInitContainerConflicts: " + c.AmbientInitContainerConflicts + "\n")
 
 	return b.String()
 }
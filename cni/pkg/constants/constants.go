@@ -21,6 +21,7 @@ const (
 	CNINetDir            = "cni-net-dir"
 	CNIConfName          = "cni-conf-name"
 	ChainedCNIPlugin     = "chained-cni-plugin"
+	CiliumChaining       = "cilium-chaining"
 	CNINetworkConfigFile = "cni-network-config-file"
 	CNINetworkConfig     = "cni-network-config"
 	CNIEnableInstall     = "cni-enable-install"
@@ -33,9 +34,51 @@ const (
 	SkipCNIBinaries      = "skip-cni-binaries"
 	UpdateCNIBinaries    = "update-cni-binaries"
 	MonitoringPort       = "monitoring-port"
+	AdminPort            = "admin-port"
+	EnableProfiling      = "enable-profiling"
 	LogUDSAddress        = "log-uds-address"
 	AmbientEnabled       = "ambient-enabled"
 	EbpfEnabled          = "ebpf-enabled"
+	EbpfShadow           = "ebpf-shadow"
+
+	// TLS of the debug handlers of the monitoring port
+	MonitoringTLSPort              = "monitoring-tls-port"
+	MonitoringTLSCertFile          = "monitoring-tls-cert-file"
+	MonitoringTLSKeyFile           = "monitoring-tls-key-file"
+	MonitoringTLSCAFile            = "monitoring-tls-ca-file"
+	MonitoringTLSAllowedIdentities = "monitoring-tls-allowed-identities"
+
+	// Ambient enrollment hooks
+	EnrollmentHookURL           = "ambient-enrollment-hook-url"
+	EnrollmentHookExec          = "ambient-enrollment-hook-exec"
+	EnrollmentHookTimeout       = "ambient-enrollment-hook-timeout"
+	EnrollmentHookFailurePolicy = "ambient-enrollment-hook-failure-policy"
+
+	AmbientConntrackFlush         = "ambient-conntrack-flush"
+	AmbientKubeProxyReplacement   = "ambient-kube-proxy-replacement"
+	AmbientTrimInformers          = "ambient-trim-informers"
+	AmbientReconcileTimeout       = "ambient-reconcile-timeout"
+	AmbientWorkers                = "ambient-workers"
+	AmbientNodeCheckInterval      = "ambient-node-check-interval"
+	AmbientReadinessGate          = "ambient-readiness-gate"
+	AmbientNamespaces             = "ambient-namespaces"
+	AmbientCaptureDir             = "ambient-capture-dir"
+	AmbientConfigMap              = "ambient-config-map"
+	AmbientAuditLog               = "ambient-audit-log"
+	AmbientAuditLogMaxSize        = "ambient-audit-log-max-size"
+	AmbientAuditLogMaxBackups     = "ambient-audit-log-max-backups"
+	AmbientAuditEvents            = "ambient-audit-events"
+	AmbientNodeTraffic            = "ambient-node-traffic"
+	AmbientNodeTrafficPorts       = "ambient-node-traffic-ports"
+	AmbientDNSCaptureBackend      = "ambient-dns-capture-backend"
+	AmbientDNSShimPort            = "ambient-dns-shim-port"
+	AmbientRouteTableBase         = "ambient-route-table-base"
+	AmbientRulePriorityBase       = "ambient-rule-priority-base"
+	AmbientFwmarkShift            = "ambient-fwmark-shift"
+	AmbientRoutingAutoResolve     = "ambient-routing-auto-resolve"
+	AmbientKubeClientQPS          = "ambient-kube-client-qps"
+	AmbientKubeClientBurst        = "ambient-kube-client-burst"
+	AmbientInitContainerConflicts = "ambient-init-container-conflicts"
 
 	// Repair
 	RepairEnabled            = "repair-enabled"
@@ -0,0 +1,48 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+	"net/netip"
+)
+
+// The eBPF programs match the IPv4 packets of pods. Applications using IPv6 sockets with IPv4-mapped addresses, such
+// as dual-stack listeners, send IPv4 packets, but their addresses may be reported in the IPv4-mapped form, such as
+// ::ffff:10.0.0.1. Addresses are normalized to their IPv4 form before they are used as map keys, so such pods are
+// attributed the right entries.
+
+// appInfoKey returns the key of the app_info map of a pod: its first IPv4 address, in network byte order. The IPv6
+// addresses of dual-stack pods are skipped, as only IPv4 is redirected.
+func appInfoKey(ips []netip.Addr) ([]byte, error) {
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("nil ips inputed")
+	}
+	for _, ip := range ips {
+		if ip = ip.Unmap(); ip.Is4() {
+			return ip.AsSlice(), nil
+		}
+	}
+	return nil, fmt.Errorf("invalid ip addrs(%v), ipv4 is supported", ips)
+}
+
+// hostIPKey returns the index of the host_ip_info map of an address of the node, and the address as stored. IPv4
+// addresses, including IPv4-mapped ones, are stored at index 0, in their IPv4-mapped form.
+func hostIPKey(ip netip.Addr) (uint32, [16]byte) {
+	if ip.Unmap().Is4() {
+		return 0, ip.Unmap().As16()
+	}
+	return 1, ip.As16()
+}
@@ -0,0 +1,74 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"net/netip"
+	"reflect"
+	"testing"
+)
+
+func TestAppInfoKey(t *testing.T) {
+	cases := []struct {
+		name    string
+		ips     []string
+		want    []byte
+		wantErr bool
+	}{
+		{name: "ipv4", ips: []string{"10.0.0.1"}, want: []byte{10, 0, 0, 1}},
+		{name: "ipv4-mapped", ips: []string{"::ffff:10.0.0.1"}, want: []byte{10, 0, 0, 1}},
+		{name: "ipv6 first", ips: []string{"fd00::1", "10.0.0.2"}, want: []byte{10, 0, 0, 2}},
+		{name: "ipv6 only", ips: []string{"fd00::1"}, wantErr: true},
+		{name: "empty", wantErr: true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ips := make([]netip.Addr, 0, len(tt.ips))
+			for _, ip := range tt.ips {
+				ips = append(ips, netip.MustParseAddr(ip))
+			}
+			got, err := appInfoKey(ips)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostIPKey(t *testing.T) {
+	cases := []struct {
+		ip      string
+		wantKey uint32
+		want    netip.Addr
+	}{
+		{ip: "10.0.0.1", wantKey: 0, want: netip.MustParseAddr("::ffff:10.0.0.1")},
+		{ip: "::ffff:10.0.0.1", wantKey: 0, want: netip.MustParseAddr("::ffff:10.0.0.1")},
+		{ip: "fd00::1", wantKey: 1, want: netip.MustParseAddr("fd00::1")},
+	}
+	for _, tt := range cases {
+		t.Run(tt.ip, func(t *testing.T) {
+			key, addr := hostIPKey(netip.MustParseAddr(tt.ip))
+			if key != tt.wantKey {
+				t.Fatalf("got key %d, want %d", key, tt.wantKey)
+			}
+			if got := netip.AddrFrom16(addr); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ package server
 import (
 	"net"
 	"net/netip"
+
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // RedirectArgs provides all the configuration parameters for the redirection.
@@ -44,4 +46,7 @@ type RedirectArgs struct {
 
 	// CaptureDNS indicates if CaptureDNS enabled. Only valid for ztunnel
 	CaptureDNS bool
+
+	// Pod is the pod the request is for, if known. It is only used to attribute the request.
+	Pod types.NamespacedName
 }
@@ -47,6 +47,8 @@ import (
 	"github.com/josharian/native"
 	"golang.org/x/sys/unix"
 
+	cnifeatures "istio.io/istio/cni/pkg/features"
+	"istio.io/istio/cni/pkg/util"
 	"istio.io/istio/pkg/util/istiomultierror"
 	istiolog "istio.io/pkg/log"
 )
@@ -62,6 +64,10 @@ const (
 	QdiscKind            = "clsact"
 	TcaBpfFlagActDiretct = 1 << 0 // refer to include/uapi/linux/pkt_cls.h TCA_BPF_FLAG_ACT_DIRECT
 	TcPrioFilter         = 1      // refer to include/uapi/linux/pkt_sched.h TC_PRIO_FILLER
+	TcFilterHandle       = 1
+	// TcSharedFilterHandle is used instead of TcFilterHandle when the veths are shared with the eBPF programs of
+	// another CNI. It does not collide with the handle those CNIs attach their programs with, such as Cilium.
+	TcSharedFilterHandle = 0x1571
 )
 
 const (
@@ -83,6 +89,13 @@ type RedirectServer struct {
 	inboundProgName            string
 	outboundFd                 uint32
 	outboundProgName           string
+	// kubeProxyReplacement is set when the CNI handles services with eBPF programs attached to the pod veths,
+	// replacing kube-proxy.
+	kubeProxyReplacement bool
+	// observeOnly is set when the programs are not attached, and only the maps are updated.
+	observeOnly bool
+	// onHandled is called with each request once it is handled.
+	onHandled func(args *RedirectArgs, err error)
 }
 
 var stringToLevel = map[string]uint32{
@@ -124,6 +137,18 @@ type eBPFObjectsImplNew struct {
 	ambient_redirectMaps
 }
 
+// EBPFSupport returns whether the kernel supports the traffic control programs redirecting traffic.
+func EBPFSupport() bool {
+	err := features.HaveProgramType(ebpf.SchedCLS)
+	if err == nil {
+		return true
+	}
+	if !errors.Is(err, ebpf.ErrNotSupported) {
+		log.Errorf("failed to query ebpf program type availability: %v", err)
+	}
+	return false
+}
+
 func EBPFTProxySupport() bool {
 	err := features.HaveProgramHelper(ebpf.SchedCLS, asm.FnSkAssign)
 	if err == nil {
@@ -144,6 +169,26 @@ func (r *RedirectServer) SetLogLevel(level string) {
 	}
 }
 
+// SetKubeProxyReplacement makes the redirection coexist with a CNI which translates service VIPs with eBPF programs
+// attached to the pod veths, such as Cilium or Calico eBPF replacing kube-proxy. The redirection programs are then
+// attached alongside the CNI programs, rather than failing to attach, and ahead of them, so traffic is captured before
+// its destination is translated. They are also detached on their own, leaving the CNI programs in place.
+func (r *RedirectServer) SetKubeProxyReplacement(enabled bool) {
+	r.kubeProxyReplacement = enabled
+}
+
+// SetObserveOnly makes the redirection observe-only: the requests update the maps, but the programs are never
+// attached to or detached from the veths, so traffic is not affected. The maps then record the redirection decisions,
+// which can be compared with the ones of another redirect mode. It must be set before Start.
+func (r *RedirectServer) SetObserveOnly(enabled bool) {
+	r.observeOnly = enabled
+}
+
+// SetRequestHandler sets the function called with each request once it is handled. It must be set before Start.
+func (r *RedirectServer) SetRequestHandler(f func(args *RedirectArgs, err error)) {
+	r.onHandled = f
+}
+
 func (r *RedirectServer) UpdateHostIP(ips []string) error {
 	if len(ips) > 2 {
 		return fmt.Errorf("too may ips inputed: %d", len(ips))
@@ -153,20 +198,16 @@ func (r *RedirectServer) UpdateHostIP(ips []string) error {
 		if err != nil {
 			return err
 		}
-		if ip.Is4() {
-			err = r.obj.HostIpInfo.Update(uint32(0), ip.As16(), ebpf.UpdateAny)
-		} else {
-			err = r.obj.HostIpInfo.Update(uint32(1), ip.As16(), ebpf.UpdateAny)
-		}
-		if err != nil {
+		key, addr := hostIPKey(ip)
+		if err := r.obj.HostIpInfo.Update(key, addr, ebpf.UpdateAny); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func AddPodToMesh(ifIndex uint32, macAddr net.HardwareAddr, ips []netip.Addr) error {
-	r := RedirectServer{}
+func AddPodToMesh(ifIndex uint32, macAddr net.HardwareAddr, ips []netip.Addr, kubeProxyReplacement bool) error {
+	r := RedirectServer{kubeProxyReplacement: kubeProxyReplacement}
 
 	if err := setLimit(); err != nil {
 		return err
@@ -195,15 +236,10 @@ func AddPodToMesh(ifIndex uint32, macAddr net.HardwareAddr, ips []netip.Addr) er
 	}
 	copy(mapInfo.MacAddr[:], macAddr)
 
-	if len(ips) == 0 {
-		return fmt.Errorf("nil ips inputed")
-	}
-	// TODO: support multiple IPs and IPv6
-	ipAddr := ips[0]
-	// ip slice is just in network endian
-	ip := ipAddr.AsSlice()
-	if len(ip) != 4 {
-		return fmt.Errorf("invalid ip addr(%s), ipv4 is supported", ipAddr.String())
+	// TODO: support IPv6
+	ip, err := appInfoKey(ips)
+	if err != nil {
+		return err
 	}
 	if err := r.obj.AppInfo.Update(ip, mapInfo, ebpf.UpdateAny); err != nil {
 		multiErr = multierror.Append(multiErr, err)
@@ -217,14 +253,18 @@ func AddPodToMesh(ifIndex uint32, macAddr net.HardwareAddr, ips []netip.Addr) er
 
 func (r *RedirectServer) initBpfObjects() error {
 	var options ebpf.CollectionOptions
-	if _, err := os.Stat(MapsPinpath); err != nil {
-		if os.IsNotExist(err) {
-			if err := os.Mkdir(MapsPinpath, os.ModePerm); err != nil {
-				return fmt.Errorf("unable to create ambient bpf mount directory: %v", err)
+	if cnifeatures.EBPFMapPinning.Enabled() {
+		if _, err := os.Stat(MapsPinpath); err != nil {
+			if os.IsNotExist(err) {
+				if err := os.Mkdir(MapsPinpath, os.ModePerm); err != nil {
+					return fmt.Errorf("unable to create ambient bpf mount directory: %v", err)
+				}
 			}
 		}
+		options.Maps.PinPath = MapsPinpath
+	} else {
+		log.Warnf("eBPF maps are not pinned, the redirection is lost when the node agent restarts")
 	}
-	options.Maps.PinPath = MapsPinpath
 	// load ebpf program
 	if EBPFTProxySupport() {
 		obj := eBPFObjectsImplNew{}
@@ -290,7 +330,7 @@ type mapInfo struct {
 
 func NewRedirectServer() *RedirectServer {
 	if err := checkOrMountBPFFSDefault(); err != nil {
-		log.Fatalf("BPF filesystem mounting on /sys/fs/bpf failed: %v", err)
+		log.Fatalf("BPF filesystem mounting on /sys/fs/bpf failed: %v", util.WithSELinuxRemediation(err))
 	}
 
 	if err := setLimit(); err != nil {
@@ -302,7 +342,7 @@ func NewRedirectServer() *RedirectServer {
 	}
 
 	if err := r.initBpfObjects(); err != nil {
-		log.Fatalf("Init bpf objects failed: %v", err)
+		log.Fatalf("Init bpf objects failed: %v", util.WithSELinuxRemediation(err))
 	}
 
 	return r
@@ -350,9 +390,13 @@ func (r *RedirectServer) Start(stop <-chan struct{}) {
 		for {
 			select {
 			case arg := <-r.redirectArgsChan:
-				if err := r.handleRequest(arg); err != nil {
+				err := r.handleRequest(arg)
+				if err != nil {
 					log.Errorf("failed to handle request: %v", err)
 				}
+				if r.onHandled != nil {
+					r.onHandled(arg, err)
+				}
 
 			case <-stop:
 				r.obj.Close()
@@ -417,15 +461,10 @@ func (r *RedirectServer) handleRequest(args *RedirectArgs) error {
 			}
 		}
 	} else {
-		if len(ipAddrs) == 0 {
-			return fmt.Errorf("nil ipAddrs inputed")
-		}
-		// TODO: support multiple IPs and IPv6
-		ipAddr := ipAddrs[0]
-		// ip slice is just in network endian
-		ip := ipAddr.AsSlice()
-		if len(ip) != 4 {
-			return fmt.Errorf("invalid ip addr(%s), ipv4 is supported", ipAddr.String())
+		// TODO: support IPv6
+		ip, err := appInfoKey(ipAddrs)
+		if err != nil {
+			return err
 		}
 		if remove {
 			if ifindex != 0 {
@@ -462,6 +501,9 @@ func (r *RedirectServer) AcceptRequest(redirectArgs *RedirectArgs) {
 }
 
 func (r *RedirectServer) attachTCForZtunnel(ifindex, peerIndex uint32, namespace string) error {
+	if r.observeOnly {
+		return nil
+	}
 	// attach to ztunnel host veth's ingress
 	if err := r.attachTC("", ifindex, "ingress", r.ztunnelHostingressFd, r.ztunnelHostingressProgName); err != nil {
 		return err
@@ -474,27 +516,54 @@ func (r *RedirectServer) attachTCForZtunnel(ifindex, peerIndex uint32, namespace
 }
 
 func (r *RedirectServer) detachTCForZtunnel(ifindex, peerIndex uint32, namespace string) error {
-	// delete ztunnel veth's clsact qdisc (in host namespace)
-	if err := r.delClsactQdisc("", ifindex); err != nil {
+	if r.observeOnly {
+		return nil
+	}
+	// detach from ztunnel veth (in host namespace)
+	if err := r.detachTC("", ifindex); err != nil {
 		return err
 	}
-	// delete ztunnel veth's clsact qdisc (in POD namespace)
-	if err := r.delClsactQdisc(namespace, peerIndex); err != nil {
+	// detach from ztunnel veth (in POD namespace)
+	if err := r.detachTC(namespace, peerIndex); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (r *RedirectServer) detachTCForWorkload(ifindex uint32) error {
-	// delete workload veth's clsact qdisc (in host namespace)
-	if err := r.delClsactQdisc("", ifindex); err != nil {
+	if r.observeOnly {
+		return nil
+	}
+	// detach from workload veth (in host namespace)
+	if err := r.detachTC("", ifindex); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// detachTC removes the redirection programs from the interface. The clsact qdisc is deleted along with them, unless
+// it is shared with the programs of another CNI.
+func (r *RedirectServer) detachTC(namespace string, ifindex uint32) error {
+	if r.kubeProxyReplacement {
+		return r.delFilters(namespace, ifindex)
+	}
+	return r.delClsactQdisc(namespace, ifindex)
+}
+
+func (r *RedirectServer) filterHandle() uint32 {
+	if r.kubeProxyReplacement {
+		// Filters with the same priority and protocol are run most recently attached first, so a distinct handle
+		// puts the redirection ahead of the programs already attached by the CNI.
+		return TcSharedFilterHandle
+	}
+	return TcFilterHandle
+}
+
 func (r *RedirectServer) attachTCForWorkLoad(ifindex uint32) error {
+	if r.observeOnly {
+		return nil
+	}
 	// attach to workload host veth's egress
 	if err := r.attachTC("", ifindex, "egress", r.inboundFd, r.inboundProgName); err != nil {
 		return err
@@ -550,7 +619,7 @@ func (r *RedirectServer) attachTC(namespace string, ifindex uint32, direction st
 			Msg: tc.Msg{
 				Family:  unix.AF_UNSPEC,
 				Ifindex: ifindex,
-				Handle:  1,
+				Handle:  r.filterHandle(),
 				Parent:  core.BuildHandle(tc.HandleRoot, tc.HandleMinIngress),
 				// Info definition and usage could be referred from net/sched/cls_api.c 'tc_new_tfilter'
 				// higher 16bits are used as priority, lower 16bits are used as protocol
@@ -578,7 +647,7 @@ func (r *RedirectServer) attachTC(namespace string, ifindex uint32, direction st
 			Msg: tc.Msg{
 				Family:  unix.AF_UNSPEC,
 				Ifindex: ifindex,
-				Handle:  1,
+				Handle:  r.filterHandle(),
 				Parent:  core.BuildHandle(tc.HandleRoot, tc.HandleMinEgress),
 				Info:    core.BuildHandle(uint32(TcPrioFilter), uint32(htons(unix.ETH_P_ALL))),
 			},
@@ -641,7 +710,51 @@ func (r *RedirectServer) delClsactQdisc(namespace string, ifindex uint32) error
 	return err
 }
 
-//nolint:unused
+// delFilters deletes the redirection filters from the interface, leaving the clsact qdisc and any other filter in place.
+func (r *RedirectServer) delFilters(namespace string, ifindex uint32) error {
+	config := &tc.Config{}
+	if namespace != "" {
+		nsHdlr, err := ns.GetNS(fmt.Sprintf("/var/run/netns/%s", namespace))
+		if err != nil {
+			return err
+		}
+		defer nsHdlr.Close()
+		config.NetNS = int(nsHdlr.Fd())
+	}
+	rtnl, err := tc.Open(config)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := rtnl.Close(); err != nil {
+			log.Warnf("could not close rtnetlink socket: %v", err)
+		}
+	}()
+
+	for _, parent := range []uint32{tc.HandleMinIngress, tc.HandleMinEgress} {
+		filter := tc.Object{
+			Msg: tc.Msg{
+				Family:  unix.AF_UNSPEC,
+				Ifindex: ifindex,
+				Handle:  r.filterHandle(),
+				Parent:  core.BuildHandle(tc.HandleRoot, parent),
+				Info:    core.BuildHandle(uint32(TcPrioFilter), uint32(htons(unix.ETH_P_ALL))),
+			},
+			Attribute: tc.Attribute{
+				Kind: "bpf",
+			},
+		}
+		err := rtnl.Filter().Delete(&filter)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			log.Debugf("No redirection filter configured for Ifindex: %d, %v", ifindex, err)
+		}
+	}
+	return nil
+}
+
 func (r *RedirectServer) dumpZtunnelInfo() (*mapInfo, error) {
 	var info mapInfo
 	if err := r.obj.ZtunnelInfo.Lookup(uint32(0), &info); err != nil {
@@ -665,6 +778,28 @@ func (r *RedirectServer) dumpAppInfo() ([]uint32, []mapInfo) {
 	return keys, values
 }
 
+// HasZtunnel returns whether traffic is redirected to a ztunnel.
+func (r *RedirectServer) HasZtunnel() bool {
+	_, err := r.dumpZtunnelInfo()
+	return err == nil
+}
+
+// AppAddrs returns the IPs of the pods whose traffic is redirected to ztunnel.
+func (r *RedirectServer) AppAddrs() ([]netip.Addr, error) {
+	// The keys are IPv4 addresses, in network order
+	var key [4]byte
+	var value mapInfo
+	var addrs []netip.Addr
+	iter := r.obj.AppInfo.Iterate()
+	for iter.Next(&key, &value) {
+		addrs = append(addrs, netip.AddrFrom4(key))
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate app info: %w", err)
+	}
+	return addrs, nil
+}
+
 func htons(a uint16) uint16 {
 	if isBigEndian {
 		return a
@@ -0,0 +1,236 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package features holds the feature flags of the CNI node agent, which toggle experimental ambient behaviors.
+//
+// Flags default to the value of their environment variable, or to the value set by the installation in the ConfigMap
+// of the node agent. They can be overridden per cluster, without restarting the node agent, with the keys of the
+// ConfigMap named ConfigMapName in the system namespace.
+package features
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"istio.io/pkg/env"
+	"istio.io/pkg/log"
+)
+
+// ConfigMapName is the name of the ConfigMap overriding the flags, in the system namespace.
+const ConfigMapName = "istio-cni-features"
+
+// Sources of the value of a flag.
+const (
+	SourceDefault   = "default"
+	SourceEnv       = "env"
+	SourceInstall   = "install"
+	SourceConfigMap = "configmap"
+)
+
+var (
+	DNSCapture = register(
+		"AMBIENT_DNS_CAPTURE",
+		true,
+		true,
+		"If disabled, DNS requests of pods are not redirected to ztunnel, even if ztunnel proxies DNS.")
+
+	UDPCapture = register(
+		"AMBIENT_UDP_CAPTURE",
+		false,
+		true,
+		"If enabled, UDP traffic of pods is redirected to ztunnel, instead of bypassing it. Only used in the iptables "+
+			"redirect mode.")
+
+	EBPFMapPinning = register(
+		"AMBIENT_EBPF_MAP_PINNING",
+		true,
+		false,
+		"If enabled, the eBPF maps are pinned to the BPF filesystem, so the redirection survives restarts of the node "+
+			"agent. Only used in the ebpf redirect mode.")
+)
+
+// Flag is a feature flag.
+type Flag struct {
+	Name        string
+	Description string
+	// Reloadable flags take effect when the ConfigMap changes; others are only read when the node agent starts.
+	Reloadable bool
+
+	envValue  bool
+	envSource string
+
+	mu     sync.RWMutex
+	value  bool
+	source string
+}
+
+// State is the current state of a flag.
+type State struct {
+	Name        string `json:"name"`
+	Enabled     bool   `json:"enabled"`
+	Source      string `json:"source"`
+	Reloadable  bool   `json:"reloadable"`
+	Description string `json:"description"`
+}
+
+var (
+	flagsMu  sync.Mutex
+	flags    []*Flag
+	started  bool
+	handlers []func()
+	// overrides are the data of the ConfigMap overriding the flags, and installed the data of the ConfigMap of the
+	// installation, which sets their defaults.
+	overrides map[string]string
+	installed map[string]string
+)
+
+func register(name string, defaultValue, reloadable bool, description string) *Flag {
+	f := &Flag{
+		Name:        name,
+		Description: description,
+		Reloadable:  reloadable,
+		envValue:    defaultValue,
+		envSource:   SourceDefault,
+	}
+	if v, ok := env.Register(name, defaultValue, description).Lookup(); ok {
+		f.envValue = v
+		f.envSource = SourceEnv
+	}
+	f.value, f.source = f.envValue, f.envSource
+	flagsMu.Lock()
+	flags = append(flags, f)
+	flagsMu.Unlock()
+	return f
+}
+
+// Enabled returns whether the flag is enabled.
+func (f *Flag) Enabled() bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.value
+}
+
+func (f *Flag) state() State {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return State{Name: f.Name, Enabled: f.value, Source: f.source, Reloadable: f.Reloadable, Description: f.Description}
+}
+
+// set sets the flag, returning whether its value changed.
+func (f *Flag) set(value bool, source string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	changed := f.value != value
+	f.value, f.source = value, source
+	return changed
+}
+
+// Update applies the data of the ConfigMap overriding the flags; flags missing from it revert to their installed or
+// environment value. The first update applies to all flags, and later ones only to the reloadable flags, notifying the
+// OnChange handlers if any changed.
+func Update(data map[string]string) {
+	flagsMu.Lock()
+	overrides = data
+	notify := applyLocked()
+	flagsMu.Unlock()
+	for _, h := range notify {
+		h()
+	}
+}
+
+// UpdateInstall applies the data of the ConfigMap of the installation, which sets the defaults of the flags. It only
+// takes effect with the first Update, and with the later ones for the reloadable flags.
+func UpdateInstall(data map[string]string) {
+	flagsMu.Lock()
+	installed = data
+	var notify []func()
+	if started {
+		notify = applyLocked()
+	}
+	flagsMu.Unlock()
+	for _, h := range notify {
+		h()
+	}
+}
+
+// applyLocked sets the flags from the ConfigMaps, and returns the handlers to notify if any changed.
+func applyLocked() []func() {
+	changed := false
+	for _, f := range flags {
+		if started && !f.Reloadable {
+			continue
+		}
+		value, source := f.envValue, f.envSource
+		for _, src := range []struct {
+			data   map[string]string
+			source string
+		}{{installed, SourceInstall}, {overrides, SourceConfigMap}} {
+			raw, ok := src.data[f.Name]
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				log.Warnf("invalid value %q of feature flag %s from %s, ignoring it", raw, f.Name, src.source)
+				continue
+			}
+			value, source = v, src.source
+		}
+		if f.set(value, source) {
+			log.Infof("feature flag %s set to %v from %s", f.Name, value, source)
+			changed = changed || started
+		}
+	}
+	started = true
+	if !changed {
+		return nil
+	}
+	return append([]func(){}, handlers...)
+}
+
+// OnChange registers a handler called when reloadable flags change.
+func OnChange(h func()) {
+	flagsMu.Lock()
+	defer flagsMu.Unlock()
+	handlers = append(handlers, h)
+}
+
+// Values returns the current values of the flags which are reloadable, or not, as the data of a ConfigMap which can
+// be applied with Update, such as by a process of the node agent which does not watch the ConfigMaps.
+func Values(reloadable bool) map[string]string {
+	flagsMu.Lock()
+	defer flagsMu.Unlock()
+	res := map[string]string{}
+	for _, f := range flags {
+		if f.Reloadable == reloadable {
+			res[f.Name] = strconv.FormatBool(f.Enabled())
+		}
+	}
+	return res
+}
+
+// States returns the current state of all flags, sorted by name.
+func States() []State {
+	flagsMu.Lock()
+	res := make([]State, 0, len(flags))
+	for _, f := range flags {
+		res = append(res, f.state())
+	}
+	flagsMu.Unlock()
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
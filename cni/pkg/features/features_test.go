@@ -0,0 +1,102 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package features
+
+import (
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+// reset reverts the flags to their environment value.
+func reset() {
+	started = false
+	handlers = nil
+	installed = nil
+	Update(nil)
+	started = false
+}
+
+func TestUpdate(t *testing.T) {
+	t.Cleanup(reset)
+	changes := 0
+	OnChange(func() { changes++ })
+
+	// The first update applies to all flags, without notifying the handlers
+	Update(map[string]string{"AMBIENT_EBPF_MAP_PINNING": "false", "AMBIENT_UDP_CAPTURE": "true"})
+	assert.Equal(t, EBPFMapPinning.Enabled(), false)
+	assert.Equal(t, UDPCapture.Enabled(), true)
+	assert.Equal(t, changes, 0)
+
+	// Later updates only apply to reloadable flags
+	Update(map[string]string{"AMBIENT_DNS_CAPTURE": "false"})
+	assert.Equal(t, EBPFMapPinning.Enabled(), false)
+	assert.Equal(t, DNSCapture.Enabled(), false)
+	assert.Equal(t, UDPCapture.Enabled(), false)
+	assert.Equal(t, changes, 1)
+
+	// Unchanged flags do not notify the handlers
+	Update(map[string]string{"AMBIENT_DNS_CAPTURE": "false"})
+	assert.Equal(t, changes, 1)
+
+	// Invalid values are ignored, so the flags revert to their environment value
+	Update(map[string]string{"AMBIENT_DNS_CAPTURE": "no", "AMBIENT_UDP_CAPTURE": "invalid"})
+	assert.Equal(t, DNSCapture.Enabled(), true)
+	assert.Equal(t, UDPCapture.Enabled(), false)
+	assert.Equal(t, changes, 2)
+
+	assert.Equal(t, States(), []State{
+		{Name: "AMBIENT_DNS_CAPTURE", Enabled: true, Source: SourceDefault, Reloadable: true, Description: DNSCapture.Description},
+		{Name: "AMBIENT_EBPF_MAP_PINNING", Enabled: false, Source: SourceConfigMap, Description: EBPFMapPinning.Description},
+		{Name: "AMBIENT_UDP_CAPTURE", Enabled: false, Source: SourceDefault, Reloadable: true, Description: UDPCapture.Description},
+	})
+	assert.Equal(t, Values(false), map[string]string{"AMBIENT_EBPF_MAP_PINNING": "false"})
+	assert.Equal(t, Values(true), map[string]string{"AMBIENT_DNS_CAPTURE": "true", "AMBIENT_UDP_CAPTURE": "false"})
+}
+
+func TestUpdateInstall(t *testing.T) {
+	t.Cleanup(reset)
+	changes := 0
+	OnChange(func() { changes++ })
+
+	// The installation only sets the flags with the first update
+	UpdateInstall(map[string]string{"AMBIENT_DNS_CAPTURE": "false", "AMBIENT_EBPF_MAP_PINNING": "false"})
+	assert.Equal(t, DNSCapture.Enabled(), true)
+	Update(map[string]string{"AMBIENT_UDP_CAPTURE": "true"})
+	assert.Equal(t, DNSCapture.Enabled(), false)
+	assert.Equal(t, EBPFMapPinning.Enabled(), false)
+	assert.Equal(t, UDPCapture.Enabled(), true)
+	assert.Equal(t, changes, 0)
+
+	// Overrides take precedence over the installation
+	Update(map[string]string{"AMBIENT_DNS_CAPTURE": "true"})
+	assert.Equal(t, DNSCapture.Enabled(), true)
+	assert.Equal(t, UDPCapture.Enabled(), false)
+	assert.Equal(t, changes, 1)
+
+	// Later installation changes apply to the reloadable flags
+	UpdateInstall(map[string]string{"AMBIENT_UDP_CAPTURE": "true", "AMBIENT_EBPF_MAP_PINNING": "true"})
+	assert.Equal(t, DNSCapture.Enabled(), true)
+	assert.Equal(t, UDPCapture.Enabled(), true)
+	assert.Equal(t, EBPFMapPinning.Enabled(), false)
+	assert.Equal(t, changes, 2)
+	Update(nil)
+	assert.Equal(t, States()[0], State{
+		Name: "AMBIENT_DNS_CAPTURE", Enabled: true, Source: SourceDefault, Reloadable: true, Description: DNSCapture.Description,
+	})
+	assert.Equal(t, States()[2], State{
+		Name: "AMBIENT_UDP_CAPTURE", Enabled: true, Source: SourceInstall, Reloadable: true, Description: UDPCapture.Description,
+	})
+}
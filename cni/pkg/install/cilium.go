@@ -0,0 +1,118 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/exp/slices"
+
+	"istio.io/istio/cni/pkg/util"
+)
+
+const (
+	// ciliumPluginType is the type of the Cilium CNI plugin, which is also used by GKE Dataplane V2.
+	ciliumPluginType = "cilium-cni"
+	// ciliumBackupSuffix is appended by Cilium to the CNI configs it disables when running with cni-exclusive.
+	ciliumBackupSuffix = ".cilium_bak"
+)
+
+// checkCiliumConfig verifies that istio-cni can be chained into the existing Cilium-managed CNI config.
+func checkCiliumConfig(mountedCNINetDir, cniConfigFilepath string, existingCNIConfig []byte) error {
+	var existingMap map[string]any
+	if err := json.Unmarshal(existingCNIConfig, &existingMap); err != nil {
+		return fmt.Errorf("error loading existing CNI config (JSON error): %v", err)
+	}
+	types, err := pluginTypes(existingMap)
+	if err != nil {
+		return fmt.Errorf("%s: %v", cniConfigFilepath, err)
+	}
+	if slices.Index(types, ciliumPluginType) < 0 {
+		return fmt.Errorf("cilium chaining is enabled, but CNI config file %s does not contain the %s plugin; "+
+			"set CNI_CONF_NAME to the CNI config file written by Cilium", cniConfigFilepath, ciliumPluginType)
+	}
+	if !ciliumExclusive(mountedCNINetDir) {
+		return nil
+	}
+	if strings.HasSuffix(cniConfigFilepath, ".conf") {
+		// Chaining converts the file to a .conflist, which Cilium would then disable in favour of its own .conf.
+		return fmt.Errorf("cannot chain istio-cni into %s: Cilium is running with cni-exclusive and writes a "+
+			"single plugin .conf file, which cannot hold a plugin chain; disable cni.exclusive in Cilium", cniConfigFilepath)
+	}
+	installLog.Warnf("Cilium is running with cni-exclusive; istio-cni will be re-added to %s whenever Cilium rewrites it",
+		cniConfigFilepath)
+	return nil
+}
+
+// validateCiliumChain verifies that kubelet will invoke istio-cni after Cilium, so the ambient hooks run for new pods.
+func validateCiliumChain(mountedCNINetDir, cniConfigFilepath string) error {
+	defaultCNIConfigFilename, err := getDefaultCNINetwork(mountedCNINetDir)
+	if err != nil {
+		return err
+	}
+	if defaultCNIConfigFilename != filepath.Base(cniConfigFilepath) {
+		return fmt.Errorf("istio-cni is chained into %s, but kubelet uses %s; istio-cni will not be invoked for new pods",
+			cniConfigFilepath, defaultCNIConfigFilename)
+	}
+	cniConfigMap, err := util.ReadCNIConfigMap(cniConfigFilepath)
+	if err != nil {
+		return err
+	}
+	types, err := pluginTypes(cniConfigMap)
+	if err != nil {
+		return fmt.Errorf("%s: %v", cniConfigFilepath, err)
+	}
+	cilium, istio := slices.Index(types, ciliumPluginType), slices.Index(types, "istio-cni")
+	switch {
+	case cilium < 0:
+		return fmt.Errorf("%s plugin removed from CNI config file: %s", ciliumPluginType, cniConfigFilepath)
+	case istio < 0:
+		return fmt.Errorf("istio-cni CNI config removed from CNI config file: %s", cniConfigFilepath)
+	case istio < cilium:
+		return fmt.Errorf("istio-cni must be chained after %s in CNI config file: %s", ciliumPluginType, cniConfigFilepath)
+	}
+	return nil
+}
+
+// ciliumExclusive returns whether Cilium has disabled other CNI configs in the directory.
+func ciliumExclusive(mountedCNINetDir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(mountedCNINetDir, "*"+ciliumBackupSuffix))
+	return len(matches) > 0
+}
+
+// pluginTypes returns the plugin types of a CNI config, in the order they are invoked.
+func pluginTypes(cniConfigMap map[string]any) ([]string, error) {
+	if t, ok := cniConfigMap["type"].(string); ok {
+		// A regular network conf file
+		return []string{t}, nil
+	}
+	plugins, err := util.GetPlugins(cniConfigMap)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]string, 0, len(plugins))
+	for _, rawPlugin := range plugins {
+		plugin, err := util.GetPlugin(rawPlugin)
+		if err != nil {
+			return nil, err
+		}
+		t, _ := plugin["type"].(string)
+		types = append(types, t)
+	}
+	return types, nil
+}
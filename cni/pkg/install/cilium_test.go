@@ -0,0 +1,169 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package install
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"istio.io/istio/cni/pkg/config"
+	testutils "istio.io/istio/pilot/test/util"
+	"istio.io/istio/pkg/file"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestCiliumChaining(t *testing.T) {
+	cases := []struct {
+		name              string
+		chainedCNIPlugin  bool
+		specifiedConfName string
+		expectedConfName  string
+		expectedFailure   bool
+		existingConfFiles map[string]string // {srcFilename: targetFilename, ...}
+	}{
+		{
+			name:              "cilium conflist",
+			chainedCNIPlugin:  true,
+			expectedConfName:  "05-cilium.conflist",
+			existingConfFiles: map[string]string{"cilium.conflist": "05-cilium.conflist", "list.conflist": "10-list.conflist"},
+		},
+		{
+			name:              "cilium conflist with cni-exclusive",
+			chainedCNIPlugin:  true,
+			expectedConfName:  "05-cilium.conflist",
+			existingConfFiles: map[string]string{"cilium.conflist": "05-cilium.conflist", "list.conflist": "10-list.conflist.cilium_bak"},
+		},
+		{
+			name:              "standalone plugin",
+			expectedFailure:   true,
+			existingConfFiles: map[string]string{"cilium.conflist": "05-cilium.conflist"},
+		},
+		{
+			name:              "default network is not managed by cilium",
+			chainedCNIPlugin:  true,
+			expectedFailure:   true,
+			existingConfFiles: map[string]string{"list.conflist": "01-list.conflist", "cilium.conflist": "05-cilium.conflist"},
+		},
+		{
+			name:              "specified cilium config preempted",
+			chainedCNIPlugin:  true,
+			specifiedConfName: "05-cilium.conflist",
+			expectedFailure:   true,
+			existingConfFiles: map[string]string{"list.conflist": "01-list.conflist", "cilium.conflist": "05-cilium.conflist"},
+		},
+		{
+			name:              "cilium conf with cni-exclusive",
+			chainedCNIPlugin:  true,
+			expectedFailure:   true,
+			existingConfFiles: map[string]string{"cilium.conf": "05-cilium.conf", "list.conflist": "10-list.conflist.cilium_bak"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			for srcFilename, targetFilename := range c.existingConfFiles {
+				if err := file.AtomicCopy(filepath.Join("testdata", srcFilename), tempDir, targetFilename); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg := &config.InstallConfig{
+				MountedCNINetDir:   tempDir,
+				CNIConfName:        c.specifiedConfName,
+				ChainedCNIPlugin:   c.chainedCNIPlugin,
+				CiliumChaining:     true,
+				CNIEnableInstall:   true,
+				CNINetworkConfig:   cniNetworkConfig,
+				LogLevel:           "debug",
+				KubeconfigFilename: kubeconfigFilename,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			resultFilepath, err := createCNIConfigFile(ctx, cfg, "")
+			if c.expectedFailure {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, resultFilepath, filepath.Join(tempDir, c.expectedConfName))
+
+			goldenFilepath := filepath.Join("testdata", "cilium.conflist.golden")
+			testutils.CompareBytes(t, testutils.ReadFile(t, resultFilepath), testutils.ReadFile(t, goldenFilepath), goldenFilepath)
+			assert.NoError(t, checkInstall(cfg, resultFilepath))
+		})
+	}
+}
+
+func TestValidateCiliumChain(t *testing.T) {
+	cases := []struct {
+		name            string
+		srcFilename     string
+		expectedFailure bool
+	}{
+		{
+			name:        "istio-cni chained after cilium",
+			srcFilename: "cilium.conflist.golden",
+		},
+		{
+			name:            "istio-cni removed",
+			srcFilename:     "cilium.conflist",
+			expectedFailure: true,
+		},
+		{
+			name:            "istio-cni before cilium",
+			srcFilename:     "cilium-misordered.conflist",
+			expectedFailure: true,
+		},
+		{
+			name:            "cilium removed",
+			srcFilename:     "list.conflist.golden",
+			expectedFailure: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			if err := file.AtomicCopy(filepath.Join("testdata", c.srcFilename), tempDir, "05-cilium.conflist"); err != nil {
+				t.Fatal(err)
+			}
+			err := validateCiliumChain(tempDir, filepath.Join(tempDir, "05-cilium.conflist"))
+			if c.expectedFailure {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// A config written by Cilium on a restart drops istio-cni, so the installer must reinstall
+	t.Run("rewritten by cilium", func(t *testing.T) {
+		tempDir := t.TempDir()
+		cniConfigFilepath := filepath.Join(tempDir, "05-cilium.conflist")
+		if err := file.AtomicCopy(filepath.Join("testdata", "cilium.conflist.golden"), tempDir, "05-cilium.conflist"); err != nil {
+			t.Fatal(err)
+		}
+		cfg := &config.InstallConfig{MountedCNINetDir: tempDir, ChainedCNIPlugin: true, CiliumChaining: true, CNIEnableInstall: true}
+		assert.NoError(t, checkInstall(cfg, cniConfigFilepath))
+		if err := os.WriteFile(cniConfigFilepath, testutils.ReadFile(t, filepath.Join("testdata", "cilium.conflist")), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		assert.Error(t, checkInstall(cfg, cniConfigFilepath))
+	})
+}
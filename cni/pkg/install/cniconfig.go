@@ -34,6 +34,7 @@ type pluginConfig struct {
 	mountedCNINetDir string
 	cniConfName      string
 	chainedCNIPlugin bool
+	ciliumChaining   bool
 }
 
 type cniConfigTemplate struct {
@@ -56,6 +57,7 @@ func getPluginConfig(cfg *config.InstallConfig) pluginConfig {
 		mountedCNINetDir: cfg.MountedCNINetDir,
 		cniConfName:      cfg.CNIConfName,
 		chainedCNIPlugin: cfg.ChainedCNIPlugin,
+		ciliumChaining:   cfg.CiliumChaining,
 	}
 }
 
@@ -128,6 +130,11 @@ func replaceCNIConfigVars(cniConfig []byte, vars cniConfigVars, saToken string)
 }
 
 func writeCNIConfig(ctx context.Context, cniConfig []byte, cfg pluginConfig) (string, error) {
+	if cfg.ciliumChaining && !cfg.chainedCNIPlugin {
+		// Cilium disables any CNI config it does not own when running with cni-exclusive.
+		return "", fmt.Errorf("cilium chaining requires istio-cni to be installed as a chained CNI plugin")
+	}
+
 	cniConfigFilepath, err := getCNIConfigFilepath(ctx, cfg)
 	if err != nil {
 		return "", err
@@ -142,6 +149,11 @@ func writeCNIConfig(ctx context.Context, cniConfig []byte, cfg pluginConfig) (st
 		if err != nil {
 			return "", err
 		}
+		if cfg.ciliumChaining {
+			if err := checkCiliumConfig(cfg.mountedCNINetDir, cniConfigFilepath, existingCNIConfig); err != nil {
+				return "", err
+			}
+		}
 		cniConfig, err = insertCNIConfig(cniConfig, existingCNIConfig)
 		if err != nil {
 			return "", err
@@ -164,6 +176,12 @@ func writeCNIConfig(ctx context.Context, cniConfig []byte, cfg pluginConfig) (st
 		cniConfigFilepath += "list"
 	}
 
+	if cfg.ciliumChaining {
+		if err := validateCiliumChain(cfg.mountedCNINetDir, cniConfigFilepath); err != nil {
+			return cniConfigFilepath, err
+		}
+	}
+
 	installLog.Infof("Created CNI config %s", cniConfigFilepath)
 	return cniConfigFilepath, nil
 }
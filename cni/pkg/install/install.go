@@ -246,6 +246,11 @@ func checkInstall(cfg *config.InstallConfig, cniConfigFilepath string) error {
 		return fmt.Errorf("CNI config file removed: %s", cniConfigFilepath)
 	}
 
+	if cfg.CiliumChaining {
+		// Verify that Cilium has not reordered or dropped istio-cni when rewriting its config
+		return validateCiliumChain(cfg.MountedCNINetDir, cniConfigFilepath)
+	}
+
 	if cfg.ChainedCNIPlugin {
 		// Verify that Istio CNI config exists in the CNI config plugin list
 		cniConfigMap, err := util.ReadCNIConfigMap(cniConfigFilepath)
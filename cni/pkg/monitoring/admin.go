@@ -0,0 +1,54 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"istio.io/istio/pkg/network"
+	"istio.io/pkg/log"
+)
+
+// SetupAdmin serves the administrative handlers, which change the state of the node, on the port of the loopback
+// interface. The node agent runs with the network of the node, so the monitoring port is reachable by the pods, while
+// the loopback port is only reachable from the node, such as with kubectl port-forward. The returned mux is nil if the
+// port is not set or failed to be listened on.
+func SetupAdmin(port int, stop <-chan struct{}) *http.ServeMux {
+	if port <= 0 {
+		return nil
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		log.Errorf("unable to listen on the admin socket: %v", err)
+		return nil
+	}
+	mux := http.NewServeMux()
+	adminServer := &http.Server{
+		Handler: mux,
+	}
+	go func() {
+		if err := adminServer.Serve(listener); network.IsUnexpectedListenerError(err) {
+			log.Errorf("error running admin http server: %s", err)
+		}
+	}()
+	go func() {
+		<-stop
+		err := adminServer.Close()
+		log.Debugf("admin server terminated: %v", err)
+	}()
+	return mux
+}
@@ -0,0 +1,58 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestSetupAdmin(t *testing.T) {
+	assert.Equal(t, SetupAdmin(0, test.NewStop(t)) == nil, true)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	assert.NoError(t, l.Close())
+
+	mux := SetupAdmin(port, test.NewStop(t))
+	if mux == nil {
+		t.Fatalf("expected the admin server to start on port %d", port)
+	}
+	mux.HandleFunc("/admin", func(w http.ResponseWriter, _ *http.Request) {})
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/admin", port))
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusOK)
+
+	// The admin port is only served on the loopback interface
+	addrs, err := net.InterfaceAddrs()
+	assert.NoError(t, err)
+	for _, a := range addrs {
+		ip, ok := a.(*net.IPNet)
+		if !ok || ip.IP.IsLoopback() || ip.IP.To4() == nil {
+			continue
+		}
+		if conn, err := net.Dial("tcp", net.JoinHostPort(ip.IP.String(), fmt.Sprint(port))); err == nil {
+			conn.Close()
+			t.Fatalf("expected the admin port not to be served on %s", ip.IP)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+
+	"istio.io/pkg/log"
+)
+
+// registerDebugHandlers adds the pprof endpoints and runtime stats to the mux. Goroutine dumps are served
+// by pprof, at /debug/pprof/goroutine?debug=2.
+func registerDebugHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("/debug/memstats", memStats)
+}
+
+// runtimeStats is the output of /debug/memstats.
+type runtimeStats struct {
+	Goroutines int              `json:"goroutines"`
+	MemStats   runtime.MemStats `json:"memStats"`
+}
+
+func memStats(w http.ResponseWriter, _ *http.Request) {
+	stats := runtimeStats{Goroutines: runtime.NumGoroutine()}
+	runtime.ReadMemStats(&stats.MemStats)
+	b, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(b); err != nil {
+		log.Debugf("failed to write memstats response: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestDebugHandlers(t *testing.T) {
+	mux := http.NewServeMux()
+	registerDebugHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/memstats", nil))
+	assert.Equal(t, rec.Code, http.StatusOK)
+	var stats runtimeStats
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	if stats.Goroutines == 0 || stats.MemStats.HeapAlloc == 0 {
+		t.Fatalf("expected runtime stats, got %s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/pprof/goroutine?debug=2", nil))
+	assert.Equal(t, rec.Code, http.StatusOK)
+}
@@ -15,6 +15,7 @@
 package monitoring
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
@@ -27,35 +28,79 @@ import (
 	"istio.io/pkg/log"
 )
 
-func SetupMonitoring(port int, path string, stop <-chan struct{}) {
+// SetupMonitoring serves the prometheus metrics on the port over plain HTTP, so they are scraped like the metrics of
+// the other components. The runtime diagnostics, if enableProfiling is set, and the debug handlers registered on the
+// returned mux are served along with them, unless TLS is enabled by tlsOpts: they are then served over TLS on the
+// port of tlsOpts instead. The returned mux is nil if monitoring is disabled or failed to start.
+func SetupMonitoring(port int, path string, enableProfiling bool, tlsOpts TLSOptions, stop <-chan struct{}) *http.ServeMux {
 	if port <= 0 {
-		return
+		return nil
 	}
-	mux := http.NewServeMux()
-	var listener net.Listener
-	var err error
-	if listener, err = net.Listen("tcp", fmt.Sprintf(":%d", port)); err != nil {
+	var reloader *certReloader
+	if tlsOpts.enabled() {
+		if tlsOpts.Port <= 0 || tlsOpts.Port == port {
+			log.Errorf("the monitoring TLS port %d must be set apart from the monitoring port", tlsOpts.Port)
+			return nil
+		}
+		var err error
+		if reloader, err = newCertReloader(tlsOpts); err != nil {
+			log.Errorf("unable to set up monitoring TLS: %v", err)
+			return nil
+		}
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
 		log.Errorf("unable to listen on socket: %v", err)
-		return
+		return nil
+	}
+	var debugListener net.Listener
+	if reloader != nil {
+		if debugListener, err = net.Listen("tcp", fmt.Sprintf(":%d", tlsOpts.Port)); err != nil {
+			_ = listener.Close()
+			log.Errorf("unable to listen on the monitoring TLS socket: %v", err)
+			return nil
+		}
+		if err := reloader.watch(stop); err != nil {
+			_ = listener.Close()
+			_ = debugListener.Close()
+			log.Errorf("unable to watch the monitoring TLS files: %v", err)
+			return nil
+		}
 	}
 	exporter, err := ocprom.NewExporter(ocprom.Options{Registry: prometheus.DefaultRegisterer.(*prometheus.Registry)})
 	if err != nil {
 		log.Errorf("could not set up prometheus exporter: %v", err)
-		return
+		return nil
 	}
 	view.RegisterExporter(exporter)
+	mux := http.NewServeMux()
 	mux.Handle(path, exporter)
-	monitoringServer := &http.Server{
-		Handler: mux,
+	serve("monitoring", mux, listener, stop)
+
+	debugMux := mux
+	if debugListener != nil {
+		debugMux = http.NewServeMux()
+		serve("monitoring TLS", debugMux, tls.NewListener(debugListener, reloader.tlsConfig()), stop)
+	}
+	if enableProfiling {
+		registerDebugHandlers(debugMux)
+	}
+	return debugMux
+}
+
+// serve serves the handler on the listener until stop is closed.
+func serve(name string, handler http.Handler, listener net.Listener, stop <-chan struct{}) {
+	server := &http.Server{
+		Handler: handler,
 	}
 	go func() {
-		if err = monitoringServer.Serve(listener); network.IsUnexpectedListenerError(err) {
-			log.Errorf("error running monitoring http server: %s", err)
+		if err := server.Serve(listener); network.IsUnexpectedListenerError(err) {
+			log.Errorf("error running %s http server: %s", name, err)
 		}
 	}()
 	go func() {
 		<-stop
-		err := monitoringServer.Close()
-		log.Debugf("monitoring server terminated: %v", err)
+		err := server.Close()
+		log.Debugf("%s server terminated: %v", name, err)
 	}()
 }
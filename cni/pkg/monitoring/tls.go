@@ -0,0 +1,223 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"istio.io/istio/pkg/spiffe"
+	"istio.io/istio/pkg/util/sets"
+	"istio.io/pkg/filewatcher"
+	"istio.io/pkg/log"
+)
+
+// TLSOptions configure the TLS of the debug handlers of the monitoring server, which are served along with the
+// metrics over plain HTTP if CertFile is empty.
+type TLSOptions struct {
+	// Port is the port the debug handlers are served on over TLS. The metrics are still served over plain HTTP on the
+	// monitoring port, so prometheus keeps scraping them as usual.
+	Port int
+	// CertFile and KeyFile hold the certificate chain and the private key of the server.
+	CertFile string
+	KeyFile  string
+	// CAFile holds the CA certificates client certificates are verified with. If set, clients must present a
+	// certificate signed by one of them.
+	CAFile string
+	// AllowedIdentities are the identities of the clients allowed, as <namespace>/<service account>, matched against
+	// the SPIFFE identity of their certificate in any trust domain.
+	AllowedIdentities []string
+}
+
+// DefaultAllowedIdentities returns the identities of istiod and istioctl in the system namespace.
+func DefaultAllowedIdentities(systemNamespace string) []string {
+	return []string{systemNamespace + "/istiod", systemNamespace + "/istioctl"}
+}
+
+func (o TLSOptions) enabled() bool {
+	return o.CertFile != ""
+}
+
+func (o TLSOptions) files() []string {
+	files := []string{o.CertFile, o.KeyFile}
+	if o.CAFile != "" {
+		files = append(files, o.CAFile)
+	}
+	return files
+}
+
+// certReloader serves the certificate of the server and verifies the client certificates, with the files of the
+// options reloaded when they change, such as when the secret they are mounted from is rotated.
+type certReloader struct {
+	opts    TLSOptions
+	allowed sets.String
+	watcher filewatcher.FileWatcher
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+	cas  *x509.CertPool
+}
+
+func newCertReloader(opts TLSOptions) (*certReloader, error) {
+	r := &certReloader{
+		opts:    opts,
+		allowed: sets.New(opts.AllowedIdentities...),
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// load reads the files of the options, keeping the previous ones if any fails to be read.
+func (r *certReloader) load() error {
+	cert, err := tls.LoadX509KeyPair(r.opts.CertFile, r.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the monitoring certificate: %v", err)
+	}
+	var cas *x509.CertPool
+	if r.opts.CAFile != "" {
+		b, err := os.ReadFile(r.opts.CAFile)
+		if err != nil {
+			return fmt.Errorf("failed to read the monitoring CA certificates: %v", err)
+		}
+		cas = x509.NewCertPool()
+		if !cas.AppendCertsFromPEM(b) {
+			return fmt.Errorf("no CA certificates found in %s", r.opts.CAFile)
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.cas = cas
+	return nil
+}
+
+// watch reloads the files when they change, until stop is closed.
+func (r *certReloader) watch(stop <-chan struct{}) error {
+	r.watcher = filewatcher.NewWatcher()
+	for _, f := range r.opts.files() {
+		if err := r.watcher.Add(f); err != nil {
+			_ = r.watcher.Close()
+			return fmt.Errorf("failed to watch %s: %v", f, err)
+		}
+	}
+	for _, f := range r.opts.files() {
+		f := f
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				case _, ok := <-r.watcher.Events(f):
+					if !ok {
+						return
+					}
+					if err := r.load(); err != nil {
+						log.Warnf("failed to reload the monitoring TLS files after %s changed, keeping the previous ones: %v", f, err)
+						continue
+					}
+					log.Infof("reloaded the monitoring TLS files after %s changed", f)
+				case err, ok := <-r.watcher.Errors(f):
+					if !ok {
+						return
+					}
+					log.Warnf("error watching %s: %v", f, err)
+				}
+			}
+		}()
+	}
+	go func() {
+		<-stop
+		_ = r.watcher.Close()
+	}()
+	return nil
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// verifyPeerCertificate verifies the client certificate against the current CA certificates, and its identity
+// against the allowed ones. The verification of the TLS stack is skipped, as its CA certificates cannot be reloaded.
+func (r *certReloader) verifyPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("no client certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse the client certificate: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	r.mu.RLock()
+	cas := r.cas
+	r.mu.RUnlock()
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         cas,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return fmt.Errorf("failed to verify the client certificate: %v", err)
+	}
+	if r.allowed.Len() == 0 {
+		return nil
+	}
+	for _, uri := range certs[0].URIs {
+		id, err := spiffe.ParseIdentity(uri.String())
+		if err != nil {
+			continue
+		}
+		if r.allowed.Contains(id.Namespace + "/" + id.ServiceAccount) {
+			return nil
+		}
+	}
+	ids := make([]string, 0, len(certs[0].URIs))
+	for _, uri := range certs[0].URIs {
+		ids = append(ids, uri.String())
+	}
+	return fmt.Errorf("client identity [%s] is not allowed", strings.Join(ids, ", "))
+}
+
+// tlsConfig returns the TLS config of the server, requiring client certificates if the CA certificates are set.
+func (r *certReloader) tlsConfig() *tls.Config {
+	cfg := &tls.Config{
+		GetCertificate: r.getCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+	if r.opts.CAFile != "" {
+		cfg.ClientAuth = tls.RequireAnyClientCert
+		cfg.VerifyPeerCertificate = func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+			err := r.verifyPeerCertificate(rawCerts, verifiedChains)
+			if err != nil {
+				log.Infof("rejected monitoring client: %v", err)
+			}
+			return err
+		}
+	}
+	return cfg
+}
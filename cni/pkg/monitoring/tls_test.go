@@ -0,0 +1,261 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitoring
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) pem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+}
+
+// issue returns the PEM certificate and key of a leaf signed by the CA, with the SPIFFE identity if set.
+func (ca *testCA) issue(t *testing.T, serial int64, identity string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	if identity != "" {
+		u, err := url.Parse(identity)
+		assert.NoError(t, err)
+		tmpl.URIs = []*url.URL{u}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func writeFile(t *testing.T, path string, b []byte) {
+	assert.NoError(t, os.WriteFile(path, b, 0o600))
+}
+
+func TestCertReloader(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	opts := TLSOptions{
+		CertFile:          filepath.Join(dir, "cert.pem"),
+		KeyFile:           filepath.Join(dir, "key.pem"),
+		CAFile:            filepath.Join(dir, "ca.pem"),
+		AllowedIdentities: DefaultAllowedIdentities("istio-system"),
+	}
+	cert, key := ca.issue(t, 10, "")
+	writeFile(t, opts.CertFile, cert)
+	writeFile(t, opts.KeyFile, key)
+	writeFile(t, opts.CAFile, ca.pem())
+
+	r, err := newCertReloader(opts)
+	assert.NoError(t, err)
+	stop := make(chan struct{})
+	defer close(stop)
+	assert.NoError(t, r.watch(stop))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})}
+	go func() {
+		_ = srv.Serve(tls.NewListener(listener, r.tlsConfig()))
+	}()
+	defer srv.Close()
+	srvURL := "https://" + listener.Addr().String()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	get := func(identity string) (*http.Response, error) {
+		cfg := &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+		if identity != "-" {
+			cert, key := ca.issue(t, 20, identity)
+			pair, err := tls.X509KeyPair(cert, key)
+			assert.NoError(t, err)
+			cfg.Certificates = []tls.Certificate{pair}
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+		defer client.CloseIdleConnections()
+		res, err := client.Get(srvURL)
+		if err == nil {
+			res.Body.Close()
+		}
+		return res, err
+	}
+
+	cases := []struct {
+		name     string
+		identity string
+		allowed  bool
+	}{
+		{name: "istiod", identity: "spiffe://cluster.local/ns/istio-system/sa/istiod", allowed: true},
+		{name: "istioctl in another trust domain", identity: "spiffe://example.com/ns/istio-system/sa/istioctl", allowed: true},
+		{name: "other service account", identity: "spiffe://cluster.local/ns/default/sa/istiod"},
+		{name: "no identity", identity: ""},
+		{name: "no certificate", identity: "-"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := get(tt.identity)
+			if tt.allowed {
+				assert.NoError(t, err)
+				assert.Equal(t, res.StatusCode, http.StatusOK)
+			} else if err == nil {
+				t.Fatalf("expected the connection to be rejected, got %v", res.Status)
+			}
+		})
+	}
+
+	// A client certificate from another CA is rejected
+	other := newTestCA(t)
+	cert, key = other.issue(t, 30, "spiffe://cluster.local/ns/istio-system/sa/istiod")
+	pair, err := tls.X509KeyPair(cert, key)
+	assert.NoError(t, err)
+	_, err = (&http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs: roots, Certificates: []tls.Certificate{pair}, MinVersion: tls.VersionTLS12,
+	}}}).Get(srvURL)
+	if err == nil {
+		t.Fatalf("expected a client certificate from another CA to be rejected")
+	}
+
+	// The rotated certificate is served without restart
+	cert, key = ca.issue(t, 11, "")
+	writeFile(t, opts.KeyFile, key)
+	writeFile(t, opts.CertFile, cert)
+	retry.UntilSuccessOrFail(t, func() error {
+		c, _ := r.getCertificate(nil)
+		leaf, err := x509.ParseCertificate(c.Certificate[0])
+		if err != nil {
+			return err
+		}
+		if leaf.SerialNumber.Int64() != 11 {
+			return fmt.Errorf("serving certificate %v", leaf.SerialNumber)
+		}
+		return nil
+	}, retry.Timeout(10*time.Second))
+
+	// Invalid files keep the previous certificate
+	writeFile(t, opts.CertFile, []byte("invalid"))
+	assert.Error(t, r.load())
+	res, err := get("spiffe://cluster.local/ns/istio-system/sa/istiod")
+	assert.NoError(t, err)
+	assert.Equal(t, res.StatusCode, http.StatusOK)
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+func TestSetupMonitoringTLS(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	opts := TLSOptions{
+		Port:              freePort(t),
+		CertFile:          filepath.Join(dir, "cert.pem"),
+		KeyFile:           filepath.Join(dir, "key.pem"),
+		CAFile:            filepath.Join(dir, "ca.pem"),
+		AllowedIdentities: DefaultAllowedIdentities("istio-system"),
+	}
+	cert, key := ca.issue(t, 10, "")
+	writeFile(t, opts.CertFile, cert)
+	writeFile(t, opts.KeyFile, key)
+	writeFile(t, opts.CAFile, ca.pem())
+	port := freePort(t)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	// The monitoring port must be set apart from the TLS port
+	assert.Equal(t, SetupMonitoring(port, "/metrics", false, TLSOptions{Port: port, CertFile: opts.CertFile}, stop) == nil, true)
+
+	mux := SetupMonitoring(port, "/metrics", false, opts, stop)
+	assert.Equal(t, mux != nil, true)
+	mux.HandleFunc("/debug/test", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	status := func(client *http.Client, url string) int {
+		res, err := client.Get(url)
+		assert.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+	// The metrics are still served over plain HTTP, without the debug handlers
+	plain := &http.Client{}
+	assert.Equal(t, status(plain, fmt.Sprintf("http://127.0.0.1:%d/metrics", port)), http.StatusOK)
+	assert.Equal(t, status(plain, fmt.Sprintf("http://127.0.0.1:%d/debug/test", port)), http.StatusNotFound)
+
+	// The debug handlers are served over mTLS on their own port
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	cert, key = ca.issue(t, 20, "spiffe://cluster.local/ns/istio-system/sa/istioctl")
+	pair, err := tls.X509KeyPair(cert, key)
+	assert.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs: roots, Certificates: []tls.Certificate{pair}, MinVersion: tls.VersionTLS12,
+	}}}
+	defer client.CloseIdleConnections()
+	assert.Equal(t, status(client, fmt.Sprintf("https://127.0.0.1:%d/debug/test", opts.Port)), http.StatusOK)
+}
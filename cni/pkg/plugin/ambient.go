@@ -51,8 +51,22 @@ func checkAmbient(conf Config, ambientConfig ambient.AmbientConfigFile, podName,
 		return false, err
 	}
 
-	if ambientpod.PodZtunnelEnabled(ns, pod) {
+	if ambientConfig.RedirectMode == ambient.ExternalMode.String() {
+		// The redirection is programmed by the node agent once it sees the pod
+		return false, nil
+	}
+
+	if ambientpod.PodZtunnelEnabled(ns, pod) && ambientConfig.NamespaceAllowed(podNamespace) {
+		if !ambientConfig.InitContainerConflicts.CaptureOnCreation(pod) {
+			// Pods whose init containers may program their network are left to the node agent, which skips them or
+			// captures them once their init containers completed, and reports them
+			return false, nil
+		}
 		if ambientConfig.RedirectMode == ambient.EbpfMode.String() {
+			if len(ambient.PodHostPorts(pod)) > 0 {
+				// Pods with hostPorts are not captured in the eBPF redirect mode, the node agent reports them
+				return false, nil
+			}
 			ifIndex, mac, err := ambient.GetIndexAndPeerMac(podIfname, podNetNs)
 			if err != nil {
 				return false, err
@@ -63,15 +77,18 @@ func checkAmbient(conf Config, ambientConfig ambient.AmbientConfigFile, podName,
 					ips = append(ips, v)
 				}
 			}
-			err = ebpf.AddPodToMesh(uint32(ifIndex), mac, ips)
+			err = ebpf.AddPodToMesh(uint32(ifIndex), mac, ips, ambientConfig.KubeProxyReplacement)
 			if err != nil {
 				return false, err
 			}
-			if err := ambient.AnnotateEnrolledPod(client, pod); err != nil {
+			if err := ambient.AnnotateEnrolledPod(context.Background(), client, pod); err != nil {
 				log.Errorf("failed to annotate pod enrollment: %v", err)
 			}
 		} else {
 			ambient.NodeName = pod.Spec.NodeName
+			if ambientConfig.Routing != nil {
+				ambient.Routing = *ambientConfig.Routing
+			}
 
 			ambient.HostIP, err = ambient.GetHostIP(client)
 			if err != nil || ambient.HostIP == "" {
@@ -82,7 +99,7 @@ func checkAmbient(conf Config, ambientConfig ambient.AmbientConfigFile, podName,
 			_ = ambient.SetProc("/proc/sys/net/ipv4/conf/"+podIfname+"/rp_filter", "0")
 
 			for _, ip := range podIPs {
-				ambient.AddPodToMesh(client, pod, ip.IP.String())
+				ambient.AddPodToMesh(context.Background(), client, pod, ip.IP.String())
 			}
 			return true, nil
 		}
@@ -220,7 +220,11 @@ func CmdAdd(args *skel.CmdArgs) (err error) {
 
 			log.Debugf("ambientConf.ZTunnelReady: %v", ambientConf.ZTunnelReady)
 			added := false
-			if !excludePod && ambientConf.ZTunnelReady {
+			if !excludePod && ambientConf.ZTunnelReady && conf.PrevResult == nil {
+				// Multus runs the plugin as an additional network of the pod, without the result of the primary
+				// network: the node agent captures the pod once it sees its IP.
+				log.Infof("istio-cni cmdAdd podName: %s: no previous result, leaving ambient capture to the node agent", podName)
+			} else if !excludePod && ambientConf.ZTunnelReady {
 				podIPs, err := getPodIPs(args.IfName, conf.PrevResult)
 				if err != nil {
 					log.Errorf("istio-cni cmdAdd failed to get pod IPs: %s", err)
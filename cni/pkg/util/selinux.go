@@ -0,0 +1,86 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+var (
+	seLinuxEnforcePath = "/sys/fs/selinux/enforce"
+	seLinuxContextPath = "/proc/self/attr/current"
+)
+
+// SELinuxRemediation explains how to run the CNI node agent on SELinux enforcing nodes.
+const SELinuxRemediation = "the CNI node agent must run privileged with the spc_t SELinux type on SELinux enforcing " +
+	"nodes: set cni.privileged=true and cni.seLinuxOptions.type=spc_t, or install with the openshift-ambient profile"
+
+// SELinuxEnforcing returns whether SELinux is enforcing on the node.
+func SELinuxEnforcing() bool {
+	data, err := os.ReadFile(seLinuxEnforcePath)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) == "1"
+}
+
+// SELinuxType returns the SELinux type the process runs with, such as spc_t, or an empty string if it is unknown.
+func SELinuxType() string {
+	data, err := os.ReadFile(seLinuxContextPath)
+	if err != nil {
+		return ""
+	}
+	// The context is user:role:type:level, and may be NUL terminated
+	parts := strings.Split(strings.TrimRight(string(data), "\x00\n"), ":")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[2]
+}
+
+// SELinuxConfined returns whether SELinux is enforcing and confines the process, so it may be denied access to
+// network namespaces and the BPF filesystem of the node.
+func SELinuxConfined() bool {
+	if !SELinuxEnforcing() {
+		return false
+	}
+	switch SELinuxType() {
+	case "spc_t", "unconfined_t":
+		return false
+	}
+	return true
+}
+
+// WithSELinuxRemediation adds the SELinux remediation to permission errors when SELinux confines the process, as the
+// denials are otherwise reported as plain permission errors.
+func WithSELinuxRemediation(err error) error {
+	if err == nil {
+		return nil
+	}
+	// EACCES and EPERM errors are permission errors. Some libraries do not wrap them, so the message is matched too.
+	msg := err.Error()
+	if !errors.Is(err, fs.ErrPermission) &&
+		!strings.Contains(msg, "permission denied") && !strings.Contains(msg, "operation not permitted") {
+		return err
+	}
+	if !SELinuxConfined() {
+		return err
+	}
+	return fmt.Errorf("%w; SELinux may be denying access with the %s type, %s", err, SELinuxType(), SELinuxRemediation)
+}
@@ -0,0 +1,60 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestWithSELinuxRemediation(t *testing.T) {
+	dir := t.TempDir()
+	oldEnforce, oldContext := seLinuxEnforcePath, seLinuxContextPath
+	t.Cleanup(func() { seLinuxEnforcePath, seLinuxContextPath = oldEnforce, oldContext })
+	seLinuxEnforcePath = filepath.Join(dir, "enforce")
+	seLinuxContextPath = filepath.Join(dir, "current")
+	setup := func(enforce, context string) {
+		assert.NoError(t, os.WriteFile(seLinuxEnforcePath, []byte(enforce), 0o644))
+		assert.NoError(t, os.WriteFile(seLinuxContextPath, []byte(context), 0o644))
+	}
+	denied := fmt.Errorf("failed to open netns: %w", syscall.EACCES)
+	unwrapped := errors.New("failed to Statfs \"/var/run/netns/cni-1\": permission denied")
+
+	setup("1\n", "system_u:system_r:container_t:s0:c1,c2\x00")
+	assert.Equal(t, SELinuxType(), "container_t")
+	assert.Equal(t, SELinuxConfined(), true)
+	assert.Equal(t, strings.Contains(WithSELinuxRemediation(denied).Error(), "spc_t"), true)
+	assert.Equal(t, errors.Is(WithSELinuxRemediation(denied), syscall.EACCES), true)
+	assert.Equal(t, strings.Contains(WithSELinuxRemediation(unwrapped).Error(), "spc_t"), true)
+	// Other errors are not caused by SELinux
+	assert.Equal(t, WithSELinuxRemediation(syscall.ENOENT), error(syscall.ENOENT))
+	assert.Equal(t, WithSELinuxRemediation(nil), nil)
+
+	// Super privileged containers are not confined
+	setup("1\n", "system_u:system_r:spc_t:s0")
+	assert.Equal(t, WithSELinuxRemediation(denied), denied)
+
+	// Permissive mode does not deny anything
+	setup("0\n", "system_u:system_r:container_t:s0")
+	assert.Equal(t, SELinuxEnforcing(), false)
+	assert.Equal(t, WithSELinuxRemediation(denied), denied)
+}
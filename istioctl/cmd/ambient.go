@@ -0,0 +1,1380 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+
+	"istio.io/api/annotation"
+	cniconstants "istio.io/istio/cni/pkg/ambient/constants"
+	"istio.io/istio/cni/pkg/ambient/podbypass"
+	"istio.io/istio/cni/pkg/ambient/podcapture"
+	"istio.io/istio/cni/pkg/ambient/redirectdump"
+	"istio.io/istio/istioctl/pkg/authz"
+	"istio.io/istio/istioctl/pkg/util/handlers"
+	"istio.io/istio/operator/cmd/mesh"
+	"istio.io/istio/pilot/pkg/config/kube/crd"
+	"istio.io/istio/pilot/pkg/config/kube/crdclient"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/spiffe"
+	"istio.io/istio/pkg/util/sets"
+)
+
+const (
+	// ztunnelStatsPort is the port ztunnel serves Prometheus metrics on.
+	ztunnelStatsPort = 15020
+	// ztunnelConnectionsMetric counts TCP connections handled by ztunnel.
+	ztunnelConnectionsMetric = "istio_tcp_connections_opened_total"
+	// cniMonitoringPort is the port the CNI node agent serves its metrics on, along with its debug handlers unless
+	// they are served over mTLS on their own port.
+	cniMonitoringPort = 15014
+	// cniAdminPort is the port of the loopback interface the CNI node agent serves the handlers changing the
+	// redirection of its node on.
+	cniAdminPort = 15016
+	// cniNodeLabel selects the CNI node agent pods.
+	cniNodeLabel = "k8s-app=istio-cni-node"
+	// cniCapturedPodsMetric is the number of pods of each namespace of the node redirected to ztunnel.
+	cniCapturedPodsMetric = "istio_cni_ambient_namespace_pods_captured"
+	// cniReconcilesMetric counts the pod events reconciled by the CNI node agent.
+	cniReconcilesMetric = "istio_cni_ambient_reconciles_total"
+	// ztunnelClosedConnectionsMetric counts TCP connections closed by ztunnel.
+	ztunnelClosedConnectionsMetric = "istio_tcp_connections_closed_total"
+	// ztunnelSentBytesMetric counts the bytes sent by ztunnel.
+	ztunnelSentBytesMetric = "istio_tcp_sent_bytes_total"
+	// ztunnelReceivedBytesMetric counts the bytes received by ztunnel.
+	ztunnelReceivedBytesMetric = "istio_tcp_received_bytes_total"
+)
+
+func ambientCmd() *cobra.Command {
+	ambientCmd := &cobra.Command{
+		Use:   "ambient",
+		Short: "Inspect and debug ambient mode",
+		Long:  "A group of commands used to inspect and debug workloads running in ambient mode",
+		Example: `  # Verify that traffic from a pod is captured by ztunnel
+  istioctl x ambient verify-capture productpage-v1-1234567890-abcde.default --to reviews.default:9080
+
+  # Compare the redirection configured for a pod with the one the CNI node agent wants
+  istioctl x ambient redirect-dump productpage-v1-1234567890-abcde.default
+
+  # Check whether the authorization policies allow a pod to connect to a Service
+  istioctl x ambient policy simulate productpage-v1-1234567890-abcde.default --to reviews.default:9080
+
+  # Watch the ambient stats of each node
+  istioctl x ambient top
+
+  # Remove all the redirection to ztunnel from a node in an emergency
+  istioctl x ambient flush-node worker-1
+
+  # Capture the traffic of a pod on both sides of ztunnel for a minute
+  istioctl x ambient capture productpage-v1-1234567890-abcde.default --duration 1m
+
+  # Remove a pod from the mesh for ten minutes, to debug it without ztunnel
+  istioctl x ambient bypass productpage-v1-1234567890-abcde.default --duration 10m
+
+  # Audit which namespaces are captured by ambient
+  istioctl x ambient namespaces`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("unknown subcommand %q", args[0])
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.HelpFunc()(cmd, args)
+			return nil
+		},
+	}
+	ambientCmd.AddCommand(verifyCaptureCmd())
+	ambientCmd.AddCommand(redirectDumpCmd())
+	ambientCmd.AddCommand(policyCmd())
+	ambientCmd.AddCommand(topCmd())
+	ambientCmd.AddCommand(flushNodeCmd())
+	ambientCmd.AddCommand(captureCmd())
+	ambientCmd.AddCommand(bypassCmd())
+	ambientCmd.AddCommand(namespacesCmd())
+	ambientCmd.PersistentFlags().StringVar(&cniCertDir, "cni-cert-dir", "",
+		"The directory holding the cert-chain.pem, key.pem and root-cert.pem files presented to the CNI node agents serving their "+
+			"debug handlers over mTLS. A certificate is requested from istiod for the istioctl ServiceAccount of the Istio namespace if unset")
+	return ambientCmd
+}
+
+func verifyCaptureCmd() *cobra.Command {
+	var (
+		target  string
+		image   string
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "verify-capture <pod>[.<namespace>]",
+		Short: "Verify that traffic from a pod is captured by ztunnel",
+		Long: `Verify that traffic from a pod is captured by ztunnel.
+
+A test connection is sent from the pod, using an ephemeral debug container, and the connections opened by
+the ztunnel on the pod's node for the pod's workload are compared before and after, from the metrics of
+ztunnel. If ztunnel did not open a connection, the pod's traffic is not being redirected through the tunnel.
+The connections are counted per workload, so the ones of other replicas of the workload on the same node are
+counted as well. The capture cannot be verified if the test connection fails.`,
+		Example: `  # Verify capture by connecting to a Service from the pod
+  istioctl x ambient verify-capture productpage-v1-1234567890-abcde.default --to reviews.default:9080`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("expected a single pod name")
+			}
+			if _, _, err := net.SplitHostPort(target); err != nil {
+				return fmt.Errorf("invalid --to %q, expected host:port: %v", target, err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := cmd.OutOrStdout()
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			podName, ns := handlers.InferPodInfo(args[0], handlers.HandleNamespace(namespace, defaultNamespace))
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			pod, err := client.Kube().CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if reason := captureIneligibleReason(ctx, client, pod); reason != "" {
+				return fmt.Errorf("pod %s/%s is not captured by ztunnel: %s", ns, podName, reason)
+			}
+			ztunnel, err := ztunnelForNode(ctx, client, pod.Spec.NodeName)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(w, "Using ztunnel %s/%s on node %s\n", ztunnel.Namespace, ztunnel.Name, pod.Spec.NodeName)
+
+			before, err := ztunnelWorkloadConnections(ctx, client, ztunnel, pod)
+			if err != nil {
+				return err
+			}
+			exitCode, err := runCaptureProbe(ctx, client, pod, image, target)
+			if err != nil {
+				return err
+			}
+			if exitCode != 0 {
+				// ztunnel may not open a connection for a failed test connection, so nothing can be told from it
+				return fmt.Errorf("test connection to %s failed with exit code %d, the capture of pod %s/%s is unknown",
+					target, exitCode, ns, podName)
+			}
+			fmt.Fprintf(w, "Test connection to %s succeeded\n", target)
+			after, err := ztunnelWorkloadConnections(ctx, client, ztunnel, pod)
+			if err != nil {
+				return err
+			}
+			connections := int(after - before)
+			if connections <= 0 {
+				return fmt.Errorf("traffic from pod %s/%s was not seen by ztunnel %s", ns, podName, ztunnel.Name)
+			}
+			fmt.Fprintf(w, "Traffic from pod %s/%s is captured by ztunnel (%d new connection(s))\n", ns, podName, connections)
+			return nil
+		},
+	}
+	cmd.PersistentFlags().StringVar(&target, "to", "", "The host:port to send the test connection to")
+	cmd.PersistentFlags().StringVar(&image, "image", "busybox", "The image used for the ephemeral debug container. It must provide nc")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 60*time.Second, "The maximum time to wait for the verification to complete")
+	return cmd
+}
+
+func redirectDumpCmd() *cobra.Command {
+	var (
+		node    string
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "redirect-dump [<pod>[.<namespace>]]",
+		Short: "Compare the traffic redirection configured on a node with the desired one",
+		Long: `Compare the traffic redirection configured on a node with the desired one.
+
+The CNI node agent of the node reports the iptables rules, ipset entries, routes or eBPF map entries it
+wants configured for each pod in the mesh, along with the ones actually configured on the node. The
+missing and extra artifacts are printed per pod, and the command fails if any are found.
+
+iptables rules are only checked for presence: rules added by others to the chains used for the
+redirection are not reported.`,
+		Example: `  # Check the redirection of a pod
+  istioctl x ambient redirect-dump productpage-v1-1234567890-abcde.default
+
+  # Check the redirection of all pods on a node
+  istioctl x ambient redirect-dump --node worker-1`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 || (len(args) == 1) == (node != "") {
+				return fmt.Errorf("expected either a single pod name or --node")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			var podName, ns string
+			if len(args) == 1 {
+				podName, ns = handlers.InferPodInfo(args[0], handlers.HandleNamespace(namespace, defaultNamespace))
+				pod, err := client.Kube().CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				node = pod.Spec.NodeName
+			}
+			agent, err := cniForNode(ctx, client, node)
+			if err != nil {
+				return err
+			}
+			out, err := newCNIDebugClient(client).do(ctx, agent, strings.TrimPrefix(redirectdump.Path, "/"))
+			if err != nil {
+				return fmt.Errorf("failed to fetch the redirect dump from %s/%s: %v", agent.Namespace, agent.Name, err)
+			}
+			dump := &redirectdump.Dump{}
+			if err := json.Unmarshal(out, dump); err != nil {
+				return fmt.Errorf("failed to parse the redirect dump: %v", err)
+			}
+			if !printRedirectDiff(cmd.OutOrStdout(), dump, ns, podName) {
+				return fmt.Errorf("the redirection on node %s is out of sync", node)
+			}
+			return nil
+		},
+	}
+	cmd.PersistentFlags().StringVar(&node, "node", "", "The node to check the redirection of all pods of")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "The maximum time to wait for the dump")
+	return cmd
+}
+
+func policyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Inspect the policies enforced by ztunnel",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return fmt.Errorf("unknown subcommand %q", args[0])
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.HelpFunc()(cmd, args)
+			return nil
+		},
+	}
+	cmd.AddCommand(policySimulateCmd())
+	return cmd
+}
+
+func policySimulateCmd() *cobra.Command {
+	var (
+		target  string
+		files   []string
+		timeout time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "simulate <pod>[.<namespace>] --to <service>[.<namespace>]:<port>",
+		Short: "Simulate the L4 authorization of a connection from a pod to a Service",
+		Long: `Simulate the L4 authorization of a connection from a pod to a Service.
+
+The AuthorizationPolicies and PeerAuthentications of the cluster are evaluated for each pod of the
+Service, as the ztunnel of the pod would, and whether the connection is allowed or denied is printed,
+along with the policy and rule deciding it. Policies from files are evaluated along with the ones of the
+cluster, replacing the ones with the same name, so they can be tested before they are applied.
+
+Only L4 attributes are evaluated: rules of ALLOW policies with L7 attributes never match, and L7
+attributes of DENY policies are ignored, like ztunnel does. Policies enforced by waypoints are not
+simulated.`,
+		Example: `  # Check whether a pod may connect to a Service
+  istioctl x ambient policy simulate productpage-v1-1234567890-abcde.default --to reviews.default:9080
+
+  # Check a new policy before applying it
+  istioctl x ambient policy simulate productpage-v1-1234567890-abcde.default --to reviews.default:9080 -f policy.yaml`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("expected a single pod name")
+			}
+			if _, _, err := net.SplitHostPort(target); err != nil {
+				return fmt.Errorf("invalid --to %q, expected service:port: %v", target, err)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			defaultNs := handlers.HandleNamespace(namespace, defaultNamespace)
+			podName, ns := handlers.InferPodInfo(args[0], defaultNs)
+			host, portStr, _ := net.SplitHostPort(target)
+			svcName, svcNs := handlers.InferPodInfo(host, defaultNs)
+			port, err := strconv.Atoi(portStr)
+			if err != nil {
+				return fmt.Errorf("invalid port %q: %v", portStr, err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			meshCfg, err := getMeshConfig(client)
+			if err != nil {
+				return fmt.Errorf("failed to fetch mesh config: %v", err)
+			}
+			pod, err := client.Kube().CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			svc, err := client.Kube().CoreV1().Services(svcNs).Get(ctx, svcName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			var svcPort *corev1.ServicePort
+			for i, p := range svc.Spec.Ports {
+				if int(p.Port) == port {
+					svcPort = &svc.Spec.Ports[i]
+				}
+			}
+			if svcPort == nil {
+				return fmt.Errorf("service %s/%s has no port %d", svcNs, svcName, port)
+			}
+			if len(svc.Spec.Selector) == 0 {
+				return fmt.Errorf("service %s/%s has no selector", svcNs, svcName)
+			}
+			dsts, err := client.Kube().CoreV1().Pods(svcNs).List(ctx, metav1.ListOptions{
+				LabelSelector: klabels.SelectorFromSet(svc.Spec.Selector).String(),
+			})
+			if err != nil {
+				return err
+			}
+
+			authzPolicies, peerAuthentications, err := simulatedPolicies(ctx, client, meshCfg.RootNamespace, svcNs, files, defaultNs)
+			if err != nil {
+				return err
+			}
+			_, sidecar := pod.Annotations[annotation.SidecarStatus.Name]
+			identity := spiffe.Identity{TrustDomain: meshCfg.TrustDomain, Namespace: pod.Namespace, ServiceAccount: pod.Spec.ServiceAccountName}
+			conn := authz.AmbientConnection{
+				MutualTLS:       sidecar || captureIneligibleReason(ctx, client, pod) == "",
+				SourceNamespace: pod.Namespace,
+				SourcePrincipal: strings.TrimPrefix(identity.String(), spiffe.URIPrefix),
+			}
+			conn.SourceIP, _ = netip.ParseAddr(pod.Status.PodIP)
+
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Source: %s/%s (%s, mTLS: %v)\n", pod.Namespace, pod.Name, conn.SourcePrincipal, conn.MutualTLS)
+			fmt.Fprintf(w, "Destination: %s/%s:%d\n", svcNs, svcName, port)
+			found := false
+			for i := range dsts.Items {
+				dst := &dsts.Items[i]
+				if dst.Status.PodIP == "" || dst.Status.Phase != corev1.PodRunning {
+					continue
+				}
+				found = true
+				dstIP, _ := netip.ParseAddr(dst.Status.PodIP)
+				dstPort := targetPort(dst, svcPort)
+				c := conn
+				c.DestinationPort = dstPort
+				decision := authz.SimulateAmbient(meshCfg.RootNamespace, authzPolicies, peerAuthentications,
+					authz.AmbientWorkload{Namespace: dst.Namespace, Labels: dst.Labels, IP: dstIP}, c)
+				fmt.Fprintf(w, "  %s/%s (%s:%d): %s\n", dst.Namespace, dst.Name, dst.Status.PodIP, dstPort, formatDecision(decision))
+			}
+			if !found {
+				return fmt.Errorf("service %s/%s has no running pods", svcNs, svcName)
+			}
+			return nil
+		},
+	}
+	cmd.PersistentFlags().StringVar(&target, "to", "", "The <service>[.<namespace>]:<port> the connection is sent to")
+	cmd.PersistentFlags().StringSliceVarP(&files, "filename", "f", nil,
+		"Files with AuthorizationPolicies and PeerAuthentications to evaluate along with the ones of the cluster")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "The maximum time to wait for the simulation")
+	return cmd
+}
+
+func topCmd() *cobra.Command {
+	var (
+		interval time.Duration
+		once     bool
+	)
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Display the ambient stats of each node",
+		Long: `Display the ambient stats of each node, refreshed at each interval until interrupted.
+
+The stats are aggregated from the metrics of the CNI node agent and of ztunnel on each node:
+the pods redirected to ztunnel, the rates of pod events reconciled by the node agent and of
+reconciliation errors, the rate of connections opened by ztunnel and the connections currently
+open, and the rates of bytes sent and received by ztunnel. The connections and bytes of ztunnel
+are counted for both the source and the destination workloads it reports them for.
+
+Rates are computed between two refreshes, so they are only displayed from the second one.`,
+		Example: `  # Watch the ambient stats of each node
+  istioctl x ambient top
+
+  # Display the stats once, with the rates over 10 seconds
+  istioctl x ambient top --once --interval 10s`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("invalid --interval %v, expected a positive duration", interval)
+			}
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			w := cmd.OutOrStdout()
+			var prev []nodeAmbientStats
+			for {
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				cur, err := collectAmbientStats(ctx, client)
+				cancel()
+				if err != nil {
+					return err
+				}
+				if !once {
+					// Clear the terminal, so the stats are displayed in place
+					fmt.Fprint(w, "\033[H\033[2J")
+				}
+				if !once || prev != nil {
+					printAmbientTop(w, prev, cur, interval)
+					if once {
+						return nil
+					}
+				}
+				prev = cur
+				time.Sleep(interval)
+			}
+		},
+	}
+	cmd.PersistentFlags().DurationVar(&interval, "interval", 5*time.Second,
+		"The interval between refreshes, over which the rates are computed")
+	cmd.PersistentFlags().BoolVar(&once, "once", false, "Display the stats once, after a single interval, rather than refreshing them")
+	return cmd
+}
+
+func flushNodeCmd() *cobra.Command {
+	var (
+		skipConfirmation bool
+		timeout          time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "flush-node <node>",
+		Short: "Remove all the redirection to ztunnel from a node",
+		Long: `Remove all the redirection to ztunnel from a node, taking Istio out of its datapath.
+
+The CNI node agent of the node removes all the iptables rules, ipsets, routes and eBPF state it
+configured, and stops redirecting pods to ztunnel, including new pods. The traffic of the pods of
+the node is then sent directly, without mTLS or authorization policies. This is meant for incident
+response, when ztunnel or the redirection is suspected to break the traffic of the node.
+
+The node is flushed until the node agent restarts, which redirects the pods of the node again:
+restart the node agent pod to restore the redirection once the incident is resolved.`,
+		Example: `  # Remove all the redirection to ztunnel from a node
+  istioctl x ambient flush-node worker-1
+
+  # Restore the redirection of the node
+  kubectl delete pod -n istio-system -l k8s-app=istio-cni-node --field-selector spec.nodeName=worker-1`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("expected a single node name")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			node := args[0]
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			if _, err := client.Kube().CoreV1().Nodes().Get(ctx, node, metav1.GetOptions{}); err != nil {
+				return err
+			}
+			agent, err := cniForNode(ctx, client, node)
+			if err != nil {
+				return err
+			}
+			w := cmd.OutOrStdout()
+			if !skipConfirmation && !mesh.Confirm(fmt.Sprintf("The traffic of all the pods of node %s will bypass ztunnel, "+
+				"without mTLS or authorization policies, until the CNI node agent %s/%s restarts. Proceed? [y/N]",
+				node, agent.Namespace, agent.Name), w) {
+				fmt.Fprintf(w, "Aborting operation.\n")
+				return nil
+			}
+			if _, err := client.EnvoyDoWithPort(ctx, agent.Name, agent.Namespace, "POST", strings.TrimPrefix(cniconstants.FlushPath, "/"),
+				cniAdminPort); err != nil {
+				return fmt.Errorf("failed to flush node %s with %s/%s: %v", node, agent.Namespace, agent.Name, err)
+			}
+			fmt.Fprintf(w, "Flushed the redirection of node %s. Restart the CNI node agent %s/%s to restore it.\n",
+				node, agent.Namespace, agent.Name)
+			return nil
+		},
+	}
+	cmd.PersistentFlags().BoolVarP(&skipConfirmation, "skip-confirmation", "y", false, skipConfirmationFlagHelpStr)
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "The maximum time to wait for the flush")
+	return cmd
+}
+
+func captureCmd() *cobra.Command {
+	var (
+		duration time.Duration
+		point    string
+		timeout  time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "capture <pod>[.<namespace>]",
+		Short: "Capture the traffic of a pod redirected to ztunnel",
+		Long: fmt.Sprintf(`Capture the traffic of a pod redirected to ztunnel, for a bounded duration.
+
+The CNI node agent of the node of the pod captures its traffic to pcap files, in the capture directory
+of the node agent, which must be set with the ambient-capture-dir option, usually to a volume mounted
+from the node. The traffic is captured on both sides of ztunnel: the pod point captures the traffic on
+the veth of the pod, before ztunnel for outbound traffic, and the ztunnel point captures the traffic
+of ztunnel to and from the pod, on the veth of ztunnel. Each point is saved to its own file.
+
+The command returns once the capture starts; the files are complete once the duration elapses. A
+capture lasts at most %v, and each file is bounded to %d MiB.`, podcapture.MaxDuration, podcapture.MaxFileSize>>20),
+		Example: `  # Capture the traffic of a pod on both sides of ztunnel
+  istioctl x ambient capture productpage-v1-1234567890-abcde.default
+
+  # Capture the traffic of a pod on the side of ztunnel for two minutes
+  istioctl x ambient capture productpage-v1-1234567890-abcde.default --point ztunnel --duration 2m`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("expected a single pod name")
+			}
+			if duration <= 0 || duration > podcapture.MaxDuration {
+				return fmt.Errorf("the duration must be positive and at most %v", podcapture.MaxDuration)
+			}
+			if _, err := podcapture.Point(point).Points(); err != nil {
+				return err
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			podName, ns := handlers.InferPodInfo(args[0], handlers.HandleNamespace(namespace, defaultNamespace))
+			pod, err := client.Kube().CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			agent, err := cniForNode(ctx, client, pod.Spec.NodeName)
+			if err != nil {
+				return err
+			}
+			req := podcapture.Request{Namespace: ns, Name: podName, Duration: duration, Point: podcapture.Point(point)}
+			out, err := client.EnvoyDoWithPort(ctx, agent.Name, agent.Namespace, "POST", strings.TrimPrefix(req.Query(), "/"),
+				cniAdminPort)
+			if err != nil {
+				return fmt.Errorf("failed to start the capture of %s/%s with %s/%s: %v", ns, podName, agent.Namespace, agent.Name, err)
+			}
+			res := &podcapture.Response{}
+			if err := json.Unmarshal(out, res); err != nil {
+				return fmt.Errorf("failed to parse the capture response: %v", err)
+			}
+			w := cmd.OutOrStdout()
+			fmt.Fprintf(w, "Capturing the traffic of %s/%s on node %s for %s to:\n", ns, podName, res.Node, res.Duration)
+			for _, f := range res.Files {
+				fmt.Fprintf(w, "  %s\n", f)
+			}
+			fmt.Fprintf(w, "Once the capture completes, copy the files with: kubectl cp %s/%s:<file> <file>\n", agent.Namespace, agent.Name)
+			return nil
+		},
+	}
+	cmd.PersistentFlags().DurationVar(&duration, "duration", podcapture.DefaultDuration, "How long the traffic is captured")
+	cmd.PersistentFlags().StringVar(&point, "point", string(podcapture.PointAll),
+		"Where the traffic is captured: pod, on the veth of the pod, ztunnel, on the veth of ztunnel, or all")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "The maximum time to wait for the capture to start")
+	return cmd
+}
+
+func bypassCmd() *cobra.Command {
+	var (
+		duration time.Duration
+		restore  bool
+		timeout  time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "bypass <pod>[.<namespace>]",
+		Short: "Temporarily remove the redirection of a pod to ztunnel",
+		Long: fmt.Sprintf(`Temporarily remove the redirection of a pod to ztunnel, to debug application issues suspected to be
+caused by the mesh.
+
+The CNI node agent of the node of the pod removes the pod from the mesh, as if it had opted out, and
+adds it back once the duration elapses, or the bypass is stopped with --restore. The traffic of the pod
+is neither encrypted nor authorized by ztunnel while it is bypassed, and a warning event is recorded on
+the pod. A bypass lasts at most %v, and ends if the node agent restarts.
+
+Only the pods of the namespaces annotated with %s=true can be bypassed.`, podbypass.MaxDuration,
+			cniconstants.BypassAllowedAnnotation),
+		Example: `  # Bypass the redirection of a pod for ten minutes
+  istioctl x ambient bypass productpage-v1-1234567890-abcde.default
+
+  # Bypass the redirection of a pod for a minute
+  istioctl x ambient bypass productpage-v1-1234567890-abcde.default --duration 1m
+
+  # Add a bypassed pod back to the mesh
+  istioctl x ambient bypass productpage-v1-1234567890-abcde.default --restore`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("expected a single pod name")
+			}
+			if duration <= 0 || duration > podbypass.MaxDuration {
+				return fmt.Errorf("the duration must be positive and at most %v", podbypass.MaxDuration)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			podName, ns := handlers.InferPodInfo(args[0], handlers.HandleNamespace(namespace, defaultNamespace))
+			pod, err := client.Kube().CoreV1().Pods(ns).Get(ctx, podName, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			agent, err := cniForNode(ctx, client, pod.Spec.NodeName)
+			if err != nil {
+				return err
+			}
+			req := podbypass.Request{Namespace: ns, Name: podName, Duration: duration}
+			method, action := "POST", "bypass"
+			if restore {
+				method, action = "DELETE", "restore"
+			}
+			out, err := client.EnvoyDoWithPort(ctx, agent.Name, agent.Namespace, method, strings.TrimPrefix(req.Query(), "/"),
+				cniAdminPort)
+			if err != nil {
+				return fmt.Errorf("failed to %s the redirection of %s/%s with %s/%s: %v", action, ns, podName, agent.Namespace, agent.Name, err)
+			}
+			res := &podbypass.Response{}
+			if err := json.Unmarshal(out, res); err != nil {
+				return fmt.Errorf("failed to parse the bypass response: %v", err)
+			}
+			w := cmd.OutOrStdout()
+			if restore {
+				fmt.Fprintf(w, "Restoring the redirection of %s/%s on node %s\n", ns, podName, res.Node)
+				return nil
+			}
+			fmt.Fprintf(w, "Bypassing the redirection of %s/%s on node %s until %s\n", ns, podName, res.Node, res.Expires)
+			fmt.Fprintf(w, "Restore it earlier with: istioctl x ambient bypass %s.%s --restore\n", podName, ns)
+			return nil
+		},
+	}
+	cmd.PersistentFlags().DurationVar(&duration, "duration", podbypass.DefaultDuration, "How long the redirection is bypassed")
+	cmd.PersistentFlags().BoolVar(&restore, "restore", false, "Add the bypassed pod back to the mesh before the bypass expires")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "The maximum time to wait for the node agent")
+	return cmd
+}
+
+func namespacesCmd() *cobra.Command {
+	var (
+		driftOnly bool
+		timeout   time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "namespaces",
+		Short: "List the ambient namespaces and whether their pods are captured",
+		Long: fmt.Sprintf(`List the ambient namespaces and whether their pods are captured.
+
+Each namespace labeled %s=%s, or with pods captured by ztunnel, is listed with the number of
+its pods captured and not captured. Pods are counted as captured when the CNI node agent of their node
+reports their redirection configured in its redirect dump. Pods which cannot be captured, such as pods
+using host networking or opted out of the redirection, are not counted.
+
+A namespace drifts when its label and the capture of its pods diverge: pods of a labeled namespace are not
+captured, or pods of a namespace which is not labeled are still captured. The command fails if any
+namespace drifts. Pods of nodes whose redirect dump cannot be fetched are counted as unknown.`,
+			constants.DataplaneMode, constants.DataplaneModeAmbient),
+		Example: `  # List the ambient namespaces
+  istioctl x ambient namespaces
+
+  # List only the namespaces which drift
+  istioctl x ambient namespaces --drift-only`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			namespaces, err := client.Kube().CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list namespaces: %v", err)
+			}
+			pods, err := client.Kube().CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return fmt.Errorf("failed to list pods: %v", err)
+			}
+			dumps, dumpErrs, err := collectRedirectDumps(ctx, client)
+			if err != nil {
+				return err
+			}
+			res := namespaceCaptures(namespaces.Items, pods.Items, dumps)
+			if drifted := printAmbientNamespaces(cmd.OutOrStdout(), res, dumpErrs, driftOnly); drifted > 0 {
+				return fmt.Errorf("%d namespaces drift from their %s label", drifted, constants.DataplaneMode)
+			}
+			return nil
+		},
+	}
+	cmd.PersistentFlags().BoolVar(&driftOnly, "drift-only", false, "Only list the namespaces which drift")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "The maximum time to wait for the redirect dumps")
+	return cmd
+}
+
+// simulatedPolicies returns the AuthorizationPolicies and PeerAuthentications of the root namespace and of the
+// namespace, overridden by the ones read from the files. Policies from files without a namespace are in defaultNs.
+func simulatedPolicies(ctx context.Context, client kube.CLIClient, rootNs, ns string, files []string, defaultNs string) (
+	[]config.Config, []config.Config, error,
+) {
+	var authzPolicies, peerAuthentications []config.Config
+	for _, n := range sets.SortedList(sets.New(rootNs, ns)) {
+		aps, err := client.Istio().SecurityV1beta1().AuthorizationPolicies(n).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list AuthorizationPolicies in %s: %v", n, err)
+		}
+		for _, ap := range aps.Items {
+			authzPolicies = append(authzPolicies, crdclient.TranslateObject(ap, gvk.AuthorizationPolicy, ""))
+		}
+		pas, err := client.Istio().SecurityV1beta1().PeerAuthentications(n).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list PeerAuthentications in %s: %v", n, err)
+		}
+		for _, pa := range pas.Items {
+			peerAuthentications = append(peerAuthentications, crdclient.TranslateObject(pa, gvk.PeerAuthentication, ""))
+		}
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, nil, err
+		}
+		cfgs, _, err := crd.ParseInputs(string(b))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %v", f, err)
+		}
+		for _, cfg := range cfgs {
+			if cfg.Namespace == "" {
+				cfg.Namespace = defaultNs
+			}
+			switch cfg.GroupVersionKind {
+			case gvk.AuthorizationPolicy:
+				authzPolicies = overrideConfig(authzPolicies, cfg)
+			case gvk.PeerAuthentication:
+				peerAuthentications = overrideConfig(peerAuthentications, cfg)
+			}
+		}
+	}
+	return authzPolicies, peerAuthentications, nil
+}
+
+// overrideConfig replaces the config with the same namespace and name as cfg, or adds cfg.
+func overrideConfig(cfgs []config.Config, cfg config.Config) []config.Config {
+	for i, c := range cfgs {
+		if c.Namespace == cfg.Namespace && c.Name == cfg.Name {
+			cfgs[i] = cfg
+			return cfgs
+		}
+	}
+	return append(cfgs, cfg)
+}
+
+// targetPort returns the port of the pod the Service port targets.
+func targetPort(pod *corev1.Pod, port *corev1.ServicePort) uint32 {
+	switch {
+	case port.TargetPort.Type == intstr.Int && port.TargetPort.IntVal != 0:
+		return uint32(port.TargetPort.IntVal)
+	case port.TargetPort.Type == intstr.String && port.TargetPort.StrVal != "":
+		for _, c := range pod.Spec.Containers {
+			for _, p := range c.Ports {
+				if p.Name == port.TargetPort.StrVal {
+					return uint32(p.ContainerPort)
+				}
+			}
+		}
+	}
+	return uint32(port.Port)
+}
+
+func formatDecision(d authz.AmbientDecision) string {
+	res := "DENY"
+	if d.Allowed {
+		res = "ALLOW"
+	}
+	if d.Policy != "" {
+		return fmt.Sprintf("%s by AuthorizationPolicy %s, rule %d", res, d.Policy, d.Rule)
+	}
+	return res + ": " + d.Reason
+}
+
+// cniForNode returns the CNI node agent running on the node.
+func cniForNode(ctx context.Context, client kube.CLIClient, node string) (*corev1.Pod, error) {
+	pods, err := client.Kube().CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: cniNodeLabel})
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range pods.Items {
+		if p.Spec.NodeName == node {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no CNI node agent found on node %s", node)
+}
+
+// printRedirectDiff prints the missing and extra artifacts of the pods of the dump, or only of the given pod if
+// set, and returns whether they are all in sync. The node artifacts and the unowned artifacts are only printed
+// for the whole node.
+func printRedirectDiff(w io.Writer, dump *redirectdump.Dump, ns, podName string) bool {
+	fmt.Fprintf(w, "Node %s, %s redirection\n", dump.Node, dump.RedirectMode)
+	inSync := true
+	printArtifacts := func(name string, a redirectdump.Artifacts) {
+		missing, extra := a.Diff()
+		if len(missing) == 0 && len(extra) == 0 {
+			fmt.Fprintf(w, "%s: in sync (%d artifacts)\n", name, len(a.Desired))
+			return
+		}
+		inSync = false
+		fmt.Fprintf(w, "%s: out of sync\n", name)
+		for _, m := range missing {
+			fmt.Fprintf(w, "  missing: %s\n", m)
+		}
+		for _, e := range extra {
+			fmt.Fprintf(w, "  extra:   %s\n", e)
+		}
+	}
+	if podName == "" {
+		printArtifacts("node", dump.NodeArtifacts)
+	}
+	found := false
+	for _, p := range dump.Pods {
+		if podName != "" && (p.Name != podName || p.Namespace != ns) {
+			continue
+		}
+		found = true
+		printArtifacts("pod "+p.Namespace+"/"+p.Name, p.Artifacts)
+	}
+	if podName != "" && !found {
+		fmt.Fprintf(w, "pod %s/%s: not in the mesh, and no redirection configured\n", ns, podName)
+	}
+	if podName == "" && len(dump.Unowned) > 0 {
+		inSync = false
+		fmt.Fprintf(w, "unowned artifacts:\n")
+		for _, u := range dump.Unowned {
+			fmt.Fprintf(w, "  extra:   %s\n", u)
+		}
+	}
+	return inSync
+}
+
+// captureIneligibleReason returns why the pod cannot be captured by ztunnel, or an empty string if it should be.
+func captureIneligibleReason(ctx context.Context, client kube.CLIClient, pod *corev1.Pod) string {
+	if pod.Spec.HostNetwork {
+		return "pod uses host networking"
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return fmt.Sprintf("pod is %s", pod.Status.Phase)
+	}
+	if kube.DataplaneModeNone(pod) {
+		return fmt.Sprintf("pod is excluded from any dataplane with %s=%s", constants.DataplaneMode, constants.DataplaneModeNone)
+	}
+	switch pod.Annotations[constants.AmbientRedirection] {
+	case constants.AmbientRedirectionEnabled:
+		return ""
+	case constants.AmbientRedirectionDisabled:
+		return "pod has opted out of ambient redirection"
+	}
+	ns, err := client.Kube().CoreV1().Namespaces().Get(ctx, pod.Namespace, metav1.GetOptions{})
+	if err == nil && ns.Labels[constants.DataplaneMode] != constants.DataplaneModeAmbient {
+		return fmt.Sprintf("namespace is not labeled %s=%s", constants.DataplaneMode, constants.DataplaneModeAmbient)
+	}
+	return "the CNI node agent has not enrolled the pod"
+}
+
+func ztunnelForNode(ctx context.Context, client kube.CLIClient, node string) (*corev1.Pod, error) {
+	pods, err := client.Kube().CoreV1().Pods(istioNamespace).List(ctx, metav1.ListOptions{LabelSelector: "app=ztunnel"})
+	if err != nil {
+		return nil, err
+	}
+	for i, p := range pods.Items {
+		if p.Spec.NodeName == node {
+			return &pods.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no ztunnel found in namespace %s on node %s", istioNamespace, node)
+}
+
+// ztunnelWorkloadConnections returns the number of connections ztunnel opened from the workload of the pod.
+func ztunnelWorkloadConnections(ctx context.Context, client kube.CLIClient, ztunnel, pod *corev1.Pod) (float64, error) {
+	out, err := client.EnvoyDoWithPort(ctx, ztunnel.Name, ztunnel.Namespace, "GET", "stats/prometheus", ztunnelStatsPort)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch metrics from ztunnel %s: %v", ztunnel.Name, err)
+	}
+	families, err := parseMetrics(out)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse ztunnel metrics: %v", err)
+	}
+	return workloadConnections(families, pod), nil
+}
+
+// workloadConnections sums the connections opened from the workload of the pod in the metrics of ztunnel. ztunnel
+// only reports the workload of the connections, which is shared by all the replicas of the pod.
+func workloadConnections(families map[string]*dto.MetricFamily, pod *corev1.Pod) float64 {
+	workload, _ := kube.GetDeployMetaFromPod(pod)
+	return sumMetric(families, ztunnelConnectionsMetric, func(labels map[string]string) bool {
+		return labels["reporter"] == "source" && labels["source_workload_namespace"] == pod.Namespace &&
+			labels["source_workload"] == workload.Name
+	})
+}
+
+func parseMetrics(stats []byte) (map[string]*dto.MetricFamily, error) {
+	parser := expfmt.TextParser{}
+	return parser.TextToMetricFamilies(bytes.NewReader(stats))
+}
+
+// sumMetric sums the samples of the metric whose labels match, or all of them if match is nil.
+func sumMetric(families map[string]*dto.MetricFamily, name string, match func(labels map[string]string) bool) float64 {
+	family := families[name]
+	if family == nil {
+		return 0
+	}
+	total := 0.0
+	for _, m := range family.GetMetric() {
+		if match != nil {
+			labels := map[string]string{}
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if !match(labels) {
+				continue
+			}
+		}
+		// ztunnel uses OpenMetrics naming, where the _total sample does not match the declared family
+		// name, so it is parsed as untyped.
+		switch {
+		case m.GetCounter() != nil:
+			total += m.GetCounter().GetValue()
+		case m.GetGauge() != nil:
+			total += m.GetGauge().GetValue()
+		default:
+			total += m.GetUntyped().GetValue()
+		}
+	}
+	return total
+}
+
+// runCaptureProbe connects to the target from an ephemeral container in the pod, returning the exit code.
+func runCaptureProbe(ctx context.Context, client kube.CLIClient, pod *corev1.Pod, image, target string) (int32, error) {
+	host, port, _ := net.SplitHostPort(target)
+	name := "verify-capture-" + rand.String(5)
+	pod = pod.DeepCopy()
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:    name,
+			Image:   image,
+			Command: []string{"nc", "-z", "-w", "5", host, port},
+		},
+	})
+	pods := client.Kube().CoreV1().Pods(pod.Namespace)
+	if _, err := pods.UpdateEphemeralContainers(ctx, pod.Name, pod, metav1.UpdateOptions{}); err != nil {
+		return 0, fmt.Errorf("failed to add ephemeral container: %v", err)
+	}
+	for {
+		p, err := pods.Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return 0, err
+		}
+		for _, s := range p.Status.EphemeralContainerStatuses {
+			if s.Name == name && s.State.Terminated != nil {
+				return s.State.Terminated.ExitCode, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("timed out waiting for ephemeral container %s to complete", name)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// nodeAmbientStats are the ambient stats of a node, from the metrics of its CNI node agent and ztunnel. The counters
+// are cumulative.
+type nodeAmbientStats struct {
+	node string
+
+	capturedPods    float64
+	reconciles      float64
+	reconcileErrors float64
+	cniErr          error
+
+	connectionsOpened float64
+	connectionsClosed float64
+	bytesSent         float64
+	bytesReceived     float64
+	ztunnelErr        error
+}
+
+// collectAmbientStats fetches the metrics of the CNI node agents and ztunnels, returning the stats of each node
+// sorted by node name. Failures to fetch the metrics of a node are recorded in its stats.
+func collectAmbientStats(ctx context.Context, client kube.CLIClient) ([]nodeAmbientStats, error) {
+	agents, err := client.Kube().CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: cniNodeLabel})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the CNI node agents: %v", err)
+	}
+	ztunnels, err := client.Kube().CoreV1().Pods(istioNamespace).List(ctx, metav1.ListOptions{LabelSelector: "app=ztunnel"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the ztunnels: %v", err)
+	}
+	agentByNode := map[string]*corev1.Pod{}
+	for i, p := range agents.Items {
+		agentByNode[p.Spec.NodeName] = &agents.Items[i]
+	}
+	ztunnelByNode := map[string]*corev1.Pod{}
+	for i, p := range ztunnels.Items {
+		ztunnelByNode[p.Spec.NodeName] = &ztunnels.Items[i]
+	}
+	nodes := sets.New[string]()
+	for n := range agentByNode {
+		nodes.Insert(n)
+	}
+	for n := range ztunnelByNode {
+		nodes.Insert(n)
+	}
+
+	res := make([]nodeAmbientStats, 0, len(nodes))
+	for _, n := range sets.SortedList(nodes) {
+		res = append(res, nodeAmbientStats{node: n})
+	}
+	var wg sync.WaitGroup
+	for i := range res {
+		stats := &res[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if agent := agentByNode[stats.node]; agent != nil {
+				out, err := client.EnvoyDoWithPort(ctx, agent.Name, agent.Namespace, "GET", "metrics", cniMonitoringPort)
+				if err == nil {
+					err = stats.addCNIMetrics(out)
+				}
+				stats.cniErr = err
+			} else {
+				stats.cniErr = fmt.Errorf("no CNI node agent")
+			}
+			if ztunnel := ztunnelByNode[stats.node]; ztunnel != nil {
+				out, err := client.EnvoyDoWithPort(ctx, ztunnel.Name, ztunnel.Namespace, "GET", "stats/prometheus", ztunnelStatsPort)
+				if err == nil {
+					err = stats.addZtunnelMetrics(out)
+				}
+				stats.ztunnelErr = err
+			} else {
+				stats.ztunnelErr = fmt.Errorf("no ztunnel")
+			}
+		}()
+	}
+	wg.Wait()
+	return res, nil
+}
+
+func (s *nodeAmbientStats) addCNIMetrics(out []byte) error {
+	families, err := parseMetrics(out)
+	if err != nil {
+		return fmt.Errorf("failed to parse CNI node agent metrics: %v", err)
+	}
+	s.capturedPods = sumMetric(families, cniCapturedPodsMetric, nil)
+	s.reconciles = sumMetric(families, cniReconcilesMetric, nil)
+	s.reconcileErrors = sumMetric(families, cniReconcilesMetric, func(labels map[string]string) bool {
+		return labels["result"] == "fail"
+	})
+	return nil
+}
+
+func (s *nodeAmbientStats) addZtunnelMetrics(out []byte) error {
+	families, err := parseMetrics(out)
+	if err != nil {
+		return fmt.Errorf("failed to parse ztunnel metrics: %v", err)
+	}
+	s.connectionsOpened = sumMetric(families, ztunnelConnectionsMetric, nil)
+	s.connectionsClosed = sumMetric(families, ztunnelClosedConnectionsMetric, nil)
+	s.bytesSent = sumMetric(families, ztunnelSentBytesMetric, nil)
+	s.bytesReceived = sumMetric(families, ztunnelReceivedBytesMetric, nil)
+	return nil
+}
+
+// printAmbientTop prints the stats of each node, with the rates since the previous stats, which were collected the
+// interval before. Rates are not printed without previous stats, and stats which could not be fetched are printed
+// as "?", with the errors listed after the table.
+func printAmbientTop(w io.Writer, prev, cur []nodeAmbientStats, interval time.Duration) {
+	prevByNode := map[string]nodeAmbientStats{}
+	for _, p := range prev {
+		prevByNode[p.node] = p
+	}
+	rate := func(cur, prev float64, ok bool, err error) string {
+		if err != nil {
+			return "?"
+		}
+		if !ok || cur < prev {
+			// Counters are reset when the pods restart
+			return "-"
+		}
+		return strconv.FormatFloat((cur-prev)/interval.Seconds(), 'f', 1, 64)
+	}
+	byteRate := func(cur, prev float64, ok bool, err error) string {
+		if err != nil {
+			return "?"
+		}
+		if !ok || cur < prev {
+			return "-"
+		}
+		return formatBytes((cur-prev)/interval.Seconds()) + "/s"
+	}
+	value := func(v float64, err error) string {
+		if err != nil {
+			return "?"
+		}
+		return strconv.FormatFloat(v, 'f', 0, 64)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NODE\tCAPTURED PODS\tRECONCILES/S\tERRORS/S\tCONNECTIONS/S\tOPEN CONNECTIONS\tSENT\tRECEIVED")
+	var errs []string
+	for _, c := range cur {
+		p, ok := prevByNode[c.node]
+		ok = ok && p.cniErr == nil && p.ztunnelErr == nil
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", c.node,
+			value(c.capturedPods, c.cniErr),
+			rate(c.reconciles, p.reconciles, ok, c.cniErr),
+			rate(c.reconcileErrors, p.reconcileErrors, ok, c.cniErr),
+			rate(c.connectionsOpened, p.connectionsOpened, ok, c.ztunnelErr),
+			value(c.connectionsOpened-c.connectionsClosed, c.ztunnelErr),
+			byteRate(c.bytesSent, p.bytesSent, ok, c.ztunnelErr),
+			byteRate(c.bytesReceived, p.bytesReceived, ok, c.ztunnelErr))
+		if c.cniErr != nil {
+			errs = append(errs, fmt.Sprintf("%s: CNI node agent: %v", c.node, c.cniErr))
+		}
+		if c.ztunnelErr != nil {
+			errs = append(errs, fmt.Sprintf("%s: ztunnel: %v", c.node, c.ztunnelErr))
+		}
+	}
+	_ = tw.Flush()
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		fmt.Fprintln(w)
+		for _, e := range errs {
+			fmt.Fprintln(w, e)
+		}
+	}
+}
+
+// formatBytes formats a number of bytes with binary units.
+func formatBytes(b float64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	i := 0
+	for b >= 1024 && i < len(units)-1 {
+		b /= 1024
+		i++
+	}
+	if i == 0 {
+		return strconv.FormatFloat(b, 'f', 0, 64) + units[i]
+	}
+	return strconv.FormatFloat(b, 'f', 1, 64) + units[i]
+}
+
+// collectRedirectDumps fetches the redirect dumps of the CNI node agents, keyed by node. Failures to fetch the dump
+// of a node are returned keyed by node, and the node has no dump.
+func collectRedirectDumps(ctx context.Context, client kube.CLIClient) (map[string]*redirectdump.Dump, map[string]error, error) {
+	agents, err := client.Kube().CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: cniNodeLabel})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the CNI node agents: %v", err)
+	}
+	var (
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		dumps       = map[string]*redirectdump.Dump{}
+		errs        = map[string]error{}
+		debugClient = newCNIDebugClient(client)
+	)
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dump := &redirectdump.Dump{}
+			out, err := debugClient.do(ctx, agent, strings.TrimPrefix(redirectdump.Path, "/"))
+			if err == nil {
+				if err = json.Unmarshal(out, dump); err != nil {
+					err = fmt.Errorf("failed to parse the redirect dump: %v", err)
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[agent.Spec.NodeName] = err
+				return
+			}
+			dumps[agent.Spec.NodeName] = dump
+		}()
+	}
+	wg.Wait()
+	return dumps, errs, nil
+}
+
+// namespaceCapture is the capture of the pods of a namespace.
+type namespaceCapture struct {
+	namespace string
+	labeled   bool
+
+	captured   int
+	uncaptured int
+	unknown    int
+}
+
+// drifted returns whether the label of the namespace and the capture of its pods diverge.
+func (n namespaceCapture) drifted() bool {
+	if n.labeled {
+		return n.uncaptured > 0
+	}
+	return n.captured > 0
+}
+
+func (n namespaceCapture) status() string {
+	switch {
+	case n.labeled && n.uncaptured > 0:
+		return fmt.Sprintf("DRIFT: %d pods not captured", n.uncaptured)
+	case !n.labeled && n.captured > 0:
+		return fmt.Sprintf("DRIFT: %d pods captured without the label", n.captured)
+	case n.unknown > 0:
+		return "UNKNOWN"
+	}
+	return "OK"
+}
+
+// namespaceCaptures returns the capture of the pods of each namespace labeled for ambient or with captured pods,
+// sorted by namespace. Pods are captured when the dump of their node reports their redirection configured; pods of
+// nodes without a dump are unknown.
+func namespaceCaptures(namespaces []corev1.Namespace, pods []corev1.Pod, dumps map[string]*redirectdump.Dump) []namespaceCapture {
+	captured := sets.New[types.NamespacedName]()
+	for _, d := range dumps {
+		for _, p := range d.Pods {
+			if missing, _ := p.Diff(); len(missing) == 0 {
+				captured.Insert(types.NamespacedName{Namespace: p.Namespace, Name: p.Name})
+			}
+		}
+	}
+	res := map[string]*namespaceCapture{}
+	for _, ns := range namespaces {
+		if ns.Labels[constants.DataplaneMode] == constants.DataplaneModeAmbient {
+			res[ns.Name] = &namespaceCapture{namespace: ns.Name, labeled: true}
+		}
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.HostNetwork || pod.Status.Phase != corev1.PodRunning || kube.DataplaneModeNone(pod) ||
+			pod.Annotations[constants.AmbientRedirection] == constants.AmbientRedirectionDisabled {
+			continue
+		}
+		isCaptured := captured.Contains(types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name})
+		n := res[pod.Namespace]
+		if n == nil {
+			if !isCaptured {
+				continue
+			}
+			n = &namespaceCapture{namespace: pod.Namespace}
+			res[pod.Namespace] = n
+		}
+		switch {
+		case isCaptured:
+			n.captured++
+		case dumps[pod.Spec.NodeName] == nil:
+			n.unknown++
+		default:
+			n.uncaptured++
+		}
+	}
+	out := make([]namespaceCapture, 0, len(res))
+	for _, n := range res {
+		out = append(out, *n)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].namespace < out[j].namespace
+	})
+	return out
+}
+
+// printAmbientNamespaces prints the capture of each namespace, or only of the ones which drift, followed by the
+// failures to fetch the dumps of nodes, and returns the number of namespaces which drift.
+func printAmbientNamespaces(w io.Writer, namespaces []namespaceCapture, dumpErrs map[string]error, driftOnly bool) int {
+	drifted := 0
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tLABELED\tCAPTURED\tNOT CAPTURED\tUNKNOWN\tSTATUS")
+	for _, n := range namespaces {
+		if n.drifted() {
+			drifted++
+		} else if driftOnly {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%t\t%d\t%d\t%d\t%s\n", n.namespace, n.labeled, n.captured, n.uncaptured, n.unknown, n.status())
+	}
+	_ = tw.Flush()
+	if len(dumpErrs) > 0 {
+		nodes := make([]string, 0, len(dumpErrs))
+		for node := range dumpErrs {
+			nodes = append(nodes, node)
+		}
+		sort.Strings(nodes)
+		fmt.Fprintln(w)
+		for _, node := range nodes {
+			fmt.Fprintf(w, "%s: CNI node agent: %v\n", node, dumpErrs[node])
+		}
+	}
+	return drifted
+}
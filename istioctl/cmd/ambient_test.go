@@ -0,0 +1,477 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"istio.io/istio/cni/pkg/ambient/redirectdump"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestWorkloadConnections(t *testing.T) {
+	stats := `# TYPE istio_tcp_connections_opened counter
+istio_tcp_connections_opened_total{reporter="source",source_workload="productpage-v1",source_workload_namespace="default"} 3
+istio_tcp_connections_opened_total{reporter="destination",source_workload="productpage-v1",source_workload_namespace="default"} 5
+istio_tcp_connections_opened_total{reporter="source",source_workload="productpage-v1",source_workload_namespace="other"} 7
+istio_tcp_connections_opened_total{reporter="source",source_workload="reviews-v1",source_workload_namespace="default"} 11
+`
+	families, err := parseMetrics([]byte(stats))
+	assert.NoError(t, err)
+	controller := true
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "productpage-v1-1234567890-abcde",
+		GenerateName:    "productpage-v1-1234567890-",
+		Namespace:       "default",
+		Labels:          map[string]string{"pod-template-hash": "1234567890"},
+		OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "productpage-v1-1234567890", Controller: &controller}},
+	}}
+	// Only the connections opened from the workload of the pod, in its namespace, are counted
+	assert.Equal(t, workloadConnections(families, pod), 3.0)
+	pod.Namespace = "test"
+	assert.Equal(t, workloadConnections(families, pod), 0.0)
+}
+
+func TestVerifyCaptureNotCaptured(t *testing.T) {
+	cases := []struct {
+		name        string
+		pod         *corev1.Pod
+		nsLabels    map[string]string
+		expectedErr string
+	}{
+		{
+			name: "host network",
+			pod: &corev1.Pod{
+				Spec:   corev1.PodSpec{HostNetwork: true},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			expectedErr: "pod uses host networking",
+		},
+		{
+			name: "namespace not ambient",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			expectedErr: "namespace is not labeled istio.io/dataplane-mode=ambient",
+		},
+		{
+			name: "opted out",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionDisabled}},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			nsLabels:    map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+			expectedErr: "pod has opted out of ambient redirection",
+		},
+		{
+			name: "not enrolled",
+			pod: &corev1.Pod{
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			nsLabels:    map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+			expectedErr: "the CNI node agent has not enrolled the pod",
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kube.NewFakeClient()
+			kubeClient = func(kubeconfig, configContext string) (kube.CLIClient, error) {
+				return client, nil
+			}
+			tt.pod.Name = "pod"
+			tt.pod.Namespace = "test"
+			_, _ = client.Kube().CoreV1().Namespaces().Create(context.Background(),
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: tt.nsLabels}}, metav1.CreateOptions{})
+			_, _ = client.Kube().CoreV1().Pods("test").Create(context.Background(), tt.pod, metav1.CreateOptions{})
+
+			var out bytes.Buffer
+			rootCmd := GetRootCmd(strings.Split("x ambient verify-capture pod.test --to example.com:80", " "))
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&out)
+			err := rootCmd.Execute()
+			assert.Error(t, err)
+			if !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("expected error to contain %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestFlushNodeErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		node        string
+		expectedErr string
+	}{
+		{
+			name:        "unknown node",
+			node:        "missing",
+			expectedErr: `nodes "missing" not found`,
+		},
+		{
+			name:        "no node agent",
+			node:        "worker-1",
+			expectedErr: "no CNI node agent found on node worker-1",
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kube.NewFakeClient(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}})
+			kubeClient = func(kubeconfig, configContext string) (kube.CLIClient, error) {
+				return client, nil
+			}
+
+			var out bytes.Buffer
+			rootCmd := GetRootCmd([]string{"x", "ambient", "flush-node", tt.node, "--skip-confirmation"})
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&out)
+			err := rootCmd.Execute()
+			assert.Error(t, err)
+			if !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("expected error to contain %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestCaptureErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "no pod",
+			args:        []string{},
+			expectedErr: "expected a single pod name",
+		},
+		{
+			name:        "duration too long",
+			args:        []string{"pod.default", "--duration", "1h"},
+			expectedErr: "the duration must be positive and at most 5m0s",
+		},
+		{
+			name:        "unknown point",
+			args:        []string{"pod.default", "--point", "node"},
+			expectedErr: `unknown capture point "node"`,
+		},
+		{
+			name:        "unknown pod",
+			args:        []string{"missing.default"},
+			expectedErr: `pods "missing" not found`,
+		},
+		{
+			name:        "no node agent",
+			args:        []string{"pod.default"},
+			expectedErr: "no CNI node agent found on node worker-1",
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kube.NewFakeClient(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: "worker-1"},
+			})
+			kubeClient = func(kubeconfig, configContext string) (kube.CLIClient, error) {
+				return client, nil
+			}
+
+			var out bytes.Buffer
+			rootCmd := GetRootCmd(append([]string{"x", "ambient", "capture"}, tt.args...))
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&out)
+			err := rootCmd.Execute()
+			assert.Error(t, err)
+			if !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("expected error to contain %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestBypassErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "no pod",
+			args:        []string{},
+			expectedErr: "expected a single pod name",
+		},
+		{
+			name:        "duration too long",
+			args:        []string{"pod.default", "--duration", "2h"},
+			expectedErr: "the duration must be positive and at most 1h0m0s",
+		},
+		{
+			name:        "unknown pod",
+			args:        []string{"missing.default"},
+			expectedErr: `pods "missing" not found`,
+		},
+		{
+			name:        "no node agent",
+			args:        []string{"pod.default", "--restore"},
+			expectedErr: "no CNI node agent found on node worker-1",
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kube.NewFakeClient(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: "worker-1"},
+			})
+			kubeClient = func(kubeconfig, configContext string) (kube.CLIClient, error) {
+				return client, nil
+			}
+
+			var out bytes.Buffer
+			rootCmd := GetRootCmd(append([]string{"x", "ambient", "bypass"}, tt.args...))
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&out)
+			err := rootCmd.Execute()
+			assert.Error(t, err)
+			if !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Fatalf("expected error to contain %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestPrintRedirectDiff(t *testing.T) {
+	dump := &redirectdump.Dump{
+		Node:          "node1",
+		RedirectMode:  "iptables",
+		NodeArtifacts: redirectdump.Artifacts{Desired: []string{"link istioin"}, Actual: []string{"link istioin"}},
+		Pods: []redirectdump.Pod{
+			{
+				Namespace: "default",
+				Name:      "a",
+				Artifacts: redirectdump.Artifacts{
+					Desired: []string{"ipset ztunnel-pods-ips 10.0.0.1", "route table 100 10.0.0.1/32 via 192.168.126.2 dev istioin"},
+					Actual:  []string{"ipset ztunnel-pods-ips 10.0.0.1"},
+				},
+			},
+			{
+				Namespace: "default",
+				Name:      "b",
+				Artifacts: redirectdump.Artifacts{
+					Desired: []string{"ipset ztunnel-pods-ips 10.0.0.2"},
+					Actual:  []string{"ipset ztunnel-pods-ips 10.0.0.2"},
+				},
+			},
+		},
+		Unowned: []string{"ipset ztunnel-pods-ips 10.0.0.3"},
+	}
+
+	out := &bytes.Buffer{}
+	assert.Equal(t, printRedirectDiff(out, dump, "", ""), false)
+	assert.Equal(t, out.String(), `Node node1, iptables redirection
+node: in sync (1 artifacts)
+pod default/a: out of sync
+  missing: route table 100 10.0.0.1/32 via 192.168.126.2 dev istioin
+pod default/b: in sync (1 artifacts)
+unowned artifacts:
+  extra:   ipset ztunnel-pods-ips 10.0.0.3
+`)
+
+	// Unowned artifacts are not reported for a single pod
+	out.Reset()
+	assert.Equal(t, printRedirectDiff(out, dump, "default", "b"), true)
+	assert.Equal(t, out.String(), `Node node1, iptables redirection
+pod default/b: in sync (1 artifacts)
+`)
+}
+
+func TestTargetPort(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+	}}}}
+	cases := []struct {
+		name string
+		port corev1.ServicePort
+		want uint32
+	}{
+		{"unset", corev1.ServicePort{Port: 80}, 80},
+		{"number", corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt(9080)}, 9080},
+		{"name", corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("http")}, 8080},
+		{"unknown name", corev1.ServicePort{Port: 80, TargetPort: intstr.FromString("grpc")}, 80},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, targetPort(pod, &tt.port), tt.want)
+		})
+	}
+}
+
+func TestPrintAmbientTop(t *testing.T) {
+	cni := `# TYPE istio_cni_ambient_namespace_pods_captured gauge
+istio_cni_ambient_namespace_pods_captured{namespace="default"} 2
+istio_cni_ambient_namespace_pods_captured{namespace="bookinfo"} 3
+# TYPE istio_cni_ambient_reconciles_total counter
+istio_cni_ambient_reconciles_total{result="success",type="update"} %d
+istio_cni_ambient_reconciles_total{result="fail",type="update"} %d
+`
+	ztunnel := `# TYPE istio_tcp_connections_opened counter
+istio_tcp_connections_opened_total{reporter="source"} %d
+istio_tcp_connections_opened_total{reporter="destination"} 10
+# TYPE istio_tcp_connections_closed counter
+istio_tcp_connections_closed_total{reporter="source"} 4
+# TYPE istio_tcp_sent_bytes counter
+istio_tcp_sent_bytes_total{reporter="source"} %d
+# TYPE istio_tcp_received_bytes counter
+istio_tcp_received_bytes_total{reporter="source"} 100
+`
+	stats := func(node string, reconciles, errors, connections, sent int) nodeAmbientStats {
+		s := nodeAmbientStats{node: node}
+		assert.NoError(t, s.addCNIMetrics([]byte(fmt.Sprintf(cni, reconciles, errors))))
+		assert.NoError(t, s.addZtunnelMetrics([]byte(fmt.Sprintf(ztunnel, connections, sent))))
+		return s
+	}
+	prev := []nodeAmbientStats{stats("node1", 10, 1, 0, 0)}
+	unavailable := nodeAmbientStats{node: "node3", cniErr: fmt.Errorf("no CNI node agent")}
+	assert.NoError(t, unavailable.addZtunnelMetrics([]byte(fmt.Sprintf(ztunnel, 0, 0))))
+	cur := []nodeAmbientStats{stats("node1", 30, 5, 20, 20480), stats("node2", 1, 0, 0, 0), unavailable}
+
+	out := &bytes.Buffer{}
+	printAmbientTop(out, prev, cur, 2*time.Second)
+	assert.Equal(t, out.String(), `NODE   CAPTURED PODS  RECONCILES/S  ERRORS/S  CONNECTIONS/S  OPEN CONNECTIONS  SENT       RECEIVED
+node1  5              12.0          2.0       10.0           26                10.0KiB/s  0B/s
+node2  5              -             -         -              6                 -          -
+node3  ?              ?             ?         -              6                 -          -
+
+node3: CNI node agent: no CNI node agent
+`)
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, formatBytes(512), "512B")
+	assert.Equal(t, formatBytes(1536), "1.5KiB")
+	assert.Equal(t, formatBytes(3*1024*1024), "3.0MiB")
+}
+
+func TestNamespaceCaptures(t *testing.T) {
+	ambient := map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient}
+	namespaces := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: ambient}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "legacy"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "partial", Labels: ambient}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged"}},
+	}
+	pod := func(ns, name, node string, mutate ...func(*corev1.Pod)) corev1.Pod {
+		p := corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		for _, m := range mutate {
+			m(&p)
+		}
+		return p
+	}
+	pods := []corev1.Pod{
+		pod("default", "a", "node1"),
+		pod("default", "host", "node1", func(p *corev1.Pod) { p.Spec.HostNetwork = true }),
+		pod("default", "opted-out", "node1", func(p *corev1.Pod) {
+			p.Annotations = map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionDisabled}
+		}),
+		pod("legacy", "b", "node1"),
+		pod("partial", "c", "node1"),
+		pod("partial", "missing", "node1"),
+		pod("partial", "d", "node2"),
+		pod("partial", "pending", "node1", func(p *corev1.Pod) { p.Status.Phase = corev1.PodPending }),
+		pod("unmanaged", "e", "node1"),
+	}
+	inSync := redirectdump.Artifacts{Desired: []string{"ipset"}, Actual: []string{"ipset"}}
+	dumps := map[string]*redirectdump.Dump{
+		"node1": {
+			Node: "node1",
+			Pods: []redirectdump.Pod{
+				{Namespace: "default", Name: "a", Artifacts: inSync},
+				{Namespace: "legacy", Name: "b", Artifacts: inSync},
+				{Namespace: "partial", Name: "c", Artifacts: inSync},
+				{Namespace: "partial", Name: "missing", Artifacts: redirectdump.Artifacts{Desired: []string{"ipset"}}},
+			},
+		},
+	}
+
+	got := namespaceCaptures(namespaces, pods, dumps)
+	want := []namespaceCapture{
+		{namespace: "default", labeled: true, captured: 1},
+		{namespace: "legacy", captured: 1},
+		{namespace: "partial", labeled: true, captured: 1, uncaptured: 1, unknown: 1},
+	}
+	assert.Equal(t, fmt.Sprintf("%+v", got), fmt.Sprintf("%+v", want))
+
+	out := &bytes.Buffer{}
+	assert.Equal(t, printAmbientNamespaces(out, got, map[string]error{"node2": fmt.Errorf("connection refused")}, false), 2)
+	assert.Equal(t, out.String(), `NAMESPACE  LABELED  CAPTURED  NOT CAPTURED  UNKNOWN  STATUS
+default    true     1         0             0        OK
+legacy     false    1         0             0        DRIFT: 1 pods captured without the label
+partial    true     1         1             1        DRIFT: 1 pods not captured
+
+node2: CNI node agent: connection refused
+`)
+
+	out.Reset()
+	assert.Equal(t, printAmbientNamespaces(out, got, nil, true), 2)
+	assert.Equal(t, strings.Contains(out.String(), "default"), false)
+}
+
+func TestCNIDebugTLS(t *testing.T) {
+	agent := func(env []corev1.EnvVar, args ...string) *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Env: env, Args: args}}}}
+	}
+	cases := []struct {
+		name    string
+		agent   *corev1.Pod
+		port    int
+		enabled bool
+	}{
+		{name: "plain", agent: agent(nil), port: cniDebugTLSPort},
+		{name: "empty cert", agent: agent([]corev1.EnvVar{{Name: "MONITORING_TLS_CERT_FILE"}}), port: cniDebugTLSPort},
+		{
+			name:    "install-cni",
+			agent:   agent([]corev1.EnvVar{{Name: "MONITORING_TLS_CERT_FILE", Value: "/etc/tls/tls.crt"}}),
+			port:    cniDebugTLSPort,
+			enabled: true,
+		},
+		{
+			name:    "node agent",
+			agent:   agent(nil, "--monitoring-tls-cert-file=/etc/tls/tls.crt", "--monitoring-tls-port=16000"),
+			port:    16000,
+			enabled: true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			port, enabled := cniDebugTLS(tt.agent)
+			assert.Equal(t, port, tt.port)
+			assert.Equal(t, enabled, tt.enabled)
+		})
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pb "istio.io/api/security/v1alpha1"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/spiffe"
+	pkiutil "istio.io/istio/security/pkg/pki/util"
+)
+
+const (
+	// cniDebugTLSPort is the default port the CNI node agent serves its debug handlers on over mTLS, when enabled.
+	cniDebugTLSPort = 15015
+	// istioctlServiceAccount is the ServiceAccount of the Istio namespace the certificates of istioctl are issued for.
+	istioctlServiceAccount = "istioctl"
+	// istiodCAPort is the port of istiod serving the CA.
+	istiodCAPort = 15012
+	// istioctlCertTTL is the lifetime of the certificates issued for istioctl.
+	istioctlCertTTL = 60 * 60
+)
+
+// cniCertDir is the directory holding the client certificate presented to the debug handlers of the CNI node agents
+// served over mTLS, and the root they are verified with.
+var cniCertDir string
+
+// cniDebugClient sends requests to the debug handlers of the CNI node agents, such as the redirect dump. They are
+// served along with the metrics on the monitoring port, unless the node agent serves them over mTLS on their own
+// port, in which case the client certificate is read from cniCertDir, or issued by istiod for the istioctl
+// ServiceAccount of the Istio namespace on first use.
+type cniDebugClient struct {
+	client kube.CLIClient
+
+	once      sync.Once
+	tlsConfig *tls.Config
+	err       error
+}
+
+func newCNIDebugClient(client kube.CLIClient) *cniDebugClient {
+	return &cniDebugClient{client: client}
+}
+
+// do sends a GET request for the path to the debug handlers of the node agent.
+func (c *cniDebugClient) do(ctx context.Context, agent *corev1.Pod, path string) ([]byte, error) {
+	port, ok := cniDebugTLS(agent)
+	if !ok {
+		return c.client.EnvoyDoWithPort(ctx, agent.Name, agent.Namespace, "GET", path, cniMonitoringPort)
+	}
+	c.once.Do(func() {
+		c.tlsConfig, c.err = c.newTLSConfig(ctx)
+	})
+	if c.err != nil {
+		return nil, fmt.Errorf("failed to set up the client certificate of the CNI node agents: %v", c.err)
+	}
+
+	fw, err := c.client.NewPortForwarder(agent.Name, agent.Namespace, "", 0, port)
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Start(); err != nil {
+		return nil, fmt.Errorf("failure running port forward process: %v", err)
+	}
+	defer fw.Close()
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: c.tlsConfig}}
+	defer httpClient.CloseIdleConnections()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("https://%s/%s", fw.Address(), path), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// cniDebugTLS returns the port the node agent serves its debug handlers on over mTLS, if it does, from the
+// configuration of its container.
+func cniDebugTLS(agent *corev1.Pod) (int, bool) {
+	enabled := false
+	port := cniDebugTLSPort
+	for _, c := range agent.Spec.Containers {
+		for _, e := range c.Env {
+			switch e.Name {
+			case "MONITORING_TLS_CERT_FILE":
+				enabled = e.Value != ""
+			case "MONITORING_TLS_PORT":
+				if p, err := strconv.Atoi(e.Value); err == nil {
+					port = p
+				}
+			}
+		}
+		for _, arg := range c.Args {
+			if v, ok := strings.CutPrefix(arg, "--monitoring-tls-cert-file="); ok {
+				enabled = v != ""
+			}
+			if v, ok := strings.CutPrefix(arg, "--monitoring-tls-port="); ok {
+				if p, err := strconv.Atoi(v); err == nil {
+					port = p
+				}
+			}
+		}
+	}
+	return port, enabled
+}
+
+// newTLSConfig returns the TLS config presenting the client certificate of istioctl. The node agent is verified
+// with the root of the certificate, so its own certificate must be issued by the same CA, without verifying its host
+// name as it is reached through a port forward.
+func (c *cniDebugClient) newTLSConfig(ctx context.Context) (*tls.Config, error) {
+	var certChain, key, root []byte
+	var err error
+	if cniCertDir != "" {
+		if certChain, err = os.ReadFile(filepath.Join(cniCertDir, "cert-chain.pem")); err != nil {
+			return nil, err
+		}
+		if key, err = os.ReadFile(filepath.Join(cniCertDir, "key.pem")); err != nil {
+			return nil, err
+		}
+		if root, err = os.ReadFile(filepath.Join(cniCertDir, "root-cert.pem")); err != nil {
+			return nil, err
+		}
+	} else if certChain, key, root, err = c.issueCertificate(ctx); err != nil {
+		return nil, err
+	}
+
+	pair, err := tls.X509KeyPair(certChain, key)
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(root) {
+		return nil, fmt.Errorf("no root certificate found")
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{pair},
+		MinVersion:   tls.VersionTLS12,
+		// The host name of the node agent cannot be verified through the port forward, so only its chain is.
+		InsecureSkipVerify: true, // nolint: gosec
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyCertChain(rawCerts, roots)
+		},
+	}, nil
+}
+
+// verifyCertChain verifies the certificate chain presented by a server against the roots.
+func verifyCertChain(rawCerts [][]byte, roots *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("no server certificate")
+	}
+	intermediates := x509.NewCertPool()
+	var leaf *x509.Certificate
+	for i, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse the server certificate: %v", err)
+		}
+		if i == 0 {
+			leaf = cert
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates}); err != nil {
+		return fmt.Errorf("failed to verify the server certificate: %v", err)
+	}
+	return nil
+}
+
+// issueCertificate requests a certificate from istiod, through a port forward to one of its pods, with a token of
+// the istioctl ServiceAccount of the Istio namespace.
+func (c *cniDebugClient) issueCertificate(ctx context.Context) ([]byte, []byte, []byte, error) {
+	pods, err := c.client.GetIstioPods(ctx, istioNamespace, metav1.ListOptions{
+		LabelSelector: "app=istiod",
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(pods) == 0 {
+		return nil, nil, nil, fmt.Errorf("no running istiod pod found in namespace %s", istioNamespace)
+	}
+	fw, err := c.client.NewPortForwarder(pods[0].Name, pods[0].Namespace, "", 0, istiodCAPort)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := fw.Start(); err != nil {
+		return nil, nil, nil, fmt.Errorf("failure running port forward process: %v", err)
+	}
+	defer fw.Close()
+
+	creds, err := c.client.CreatePerRPCCredentials(ctx, istioNamespace, istioctlServiceAccount, []string{"istio-ca"}, istioctlCertTTL)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create a token for %s/%s: %v", istioNamespace, istioctlServiceAccount, err)
+	}
+	conn, err := grpc.DialContext(ctx, fw.Address(),
+		// istiod is reached through a port forward to its pod, so its certificate is not verified, like for the XDS
+		// requests of istioctl.
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})), // nolint: gosec
+		grpc.WithPerRPCCredentials(creds))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer conn.Close()
+
+	csr, key, err := pkiutil.GenCSR(pkiutil.CertOptions{
+		Host:     spiffe.Identity{TrustDomain: "cluster.local", Namespace: istioNamespace, ServiceAccount: istioctlServiceAccount}.String(),
+		ECSigAlg: pkiutil.EcdsaSigAlg,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	resp, err := pb.NewIstioCertificateServiceClient(conn).CreateCertificate(ctx, &pb.IstioCertificateRequest{
+		Csr:              string(csr),
+		ValidityDuration: istioctlCertTTL,
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to issue a certificate for %s/%s: %v", istioNamespace, istioctlServiceAccount, err)
+	}
+	if len(resp.CertChain) < 2 {
+		return nil, nil, nil, fmt.Errorf("invalid certificate chain issued by istiod")
+	}
+	last := len(resp.CertChain) - 1
+	return []byte(strings.Join(resp.CertChain[:last], "\n")), key, []byte(resp.CertChain[last]), nil
+}
@@ -41,7 +41,9 @@ func statusCommand() *cobra.Command {
 		Use:   "proxy-status [<type>/]<name>[.<namespace>]",
 		Short: "Retrieves the synchronization status of each Envoy in the mesh [kube only]",
 		Long: `
-Retrieves last sent and last acknowledged xDS sync from Istiod to each Envoy in the mesh
+Retrieves last sent and last acknowledged xDS sync from Istiod to each Envoy in the mesh.
+The status of the workloads and policies consumed by ztunnels and waypoints is listed separately, along with
+the node they run on.
 
 `,
 		Example: `  # Retrieve sync status for all Envoys in a mesh
@@ -163,7 +165,9 @@ func xdsStatusCommand() *cobra.Command {
 		Use:   "proxy-status [<type>/]<name>[.<namespace>]",
 		Short: "Retrieves the synchronization status of each Envoy in the mesh",
 		Long: `
-Retrieves last sent and last acknowledged xDS sync from Istiod to each Envoy in the mesh
+Retrieves last sent and last acknowledged xDS sync from Istiod to each Envoy in the mesh.
+The status of the workloads and policies consumed by ztunnels and waypoints is listed separately, along with
+the node they run on.
 `,
 		Example: `  # Retrieve sync status for all Envoys in a mesh
   istioctl x proxy-status
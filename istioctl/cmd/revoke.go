@@ -0,0 +1,178 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	clientsecurityv1beta1 "istio.io/client-go/pkg/apis/security/v1beta1"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/spiffe"
+)
+
+func revokeCmd() *cobra.Command {
+	var undo bool
+	cmd := &cobra.Command{
+		Use:   "revoke [<identity>...]",
+		Short: "Revoke workload identities across the mesh",
+		Long: `Revoke workload identities across the mesh.
+
+Revoked identities are listed in a DENY AuthorizationPolicy in the root namespace of the mesh, which
+istiod distributes to sidecars, ztunnel and waypoints, so the connections of revoked identities are
+denied mesh-wide as soon as the policy is applied, even with unexpired certificates. Istiod also
+refuses to issue certificates for revoked identities, so existing certificates are not renewed.
+
+Without arguments, the currently revoked identities are listed.`,
+		Example: `  # Revoke the identity of the httpbin service account in the default namespace
+  istioctl x revoke cluster.local/ns/default/sa/httpbin
+
+  # Restore a previously revoked identity
+  istioctl x revoke spiffe://cluster.local/ns/default/sa/httpbin --undo
+
+  # List revoked identities
+  istioctl x revoke`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			principals := make([]string, 0, len(args))
+			for _, arg := range args {
+				p, err := revokedPrincipal(arg)
+				if err != nil {
+					return err
+				}
+				principals = append(principals, p)
+			}
+			if undo && len(principals) == 0 {
+				return fmt.Errorf("at least one identity must be specified with --undo")
+			}
+			client, err := kubeClient(kubeconfig, configContext)
+			if err != nil {
+				return fmt.Errorf("failed to create Kubernetes client: %v", err)
+			}
+			// The policy is read by istiod, and applied to the whole mesh, in the root namespace
+			meshCfg, err := getMeshConfig(client)
+			if err != nil {
+				return fmt.Errorf("failed to read the mesh config: %v", err)
+			}
+			rootNamespace := meshCfg.GetRootNamespace()
+			apc := client.Istio().SecurityV1beta1().AuthorizationPolicies(rootNamespace)
+			policy, err := apc.Get(context.Background(), constants.RevokedIdentitiesPolicyName, metav1.GetOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			exists := err == nil
+			var revoked []string
+			if exists {
+				revoked = revokedPrincipals(policy)
+			}
+
+			if len(principals) == 0 {
+				if len(revoked) == 0 {
+					fmt.Fprintln(cmd.OutOrStdout(), "No revoked identities found.")
+					return nil
+				}
+				for _, p := range revoked {
+					fmt.Fprintln(cmd.OutOrStdout(), p)
+				}
+				return nil
+			}
+
+			for _, p := range principals {
+				if undo {
+					if i := slices.Index(revoked, p); i >= 0 {
+						revoked = slices.Delete(revoked, i, i+1)
+					}
+				} else if !slices.Contains(revoked, p) {
+					revoked = append(revoked, p)
+				}
+			}
+			slices.Sort(revoked)
+
+			switch {
+			case !exists && len(revoked) == 0:
+				// Nothing to undo.
+			case len(revoked) == 0:
+				if err := apc.Delete(context.Background(), policy.Name, metav1.DeleteOptions{}); err != nil {
+					return err
+				}
+			case !exists:
+				policy = &clientsecurityv1beta1.AuthorizationPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      constants.RevokedIdentitiesPolicyName,
+						Namespace: rootNamespace,
+					},
+				}
+				setRevokedPrincipals(policy, revoked)
+				if _, err := apc.Create(context.Background(), policy, metav1.CreateOptions{}); err != nil {
+					return err
+				}
+			default:
+				setRevokedPrincipals(policy, revoked)
+				if _, err := apc.Update(context.Background(), policy, metav1.UpdateOptions{}); err != nil {
+					return err
+				}
+			}
+			for _, p := range principals {
+				if undo {
+					fmt.Fprintf(cmd.OutOrStdout(), "identity %v restored\n", p)
+				} else {
+					fmt.Fprintf(cmd.OutOrStdout(), "identity %v revoked\n", p)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.PersistentFlags().BoolVar(&undo, "undo", false, "Restore the given identities instead of revoking them")
+	return cmd
+}
+
+// revokedPrincipal converts an identity, with or without the spiffe:// prefix, to the principal
+// format used by AuthorizationPolicy.
+func revokedPrincipal(identity string) (string, error) {
+	id, err := spiffe.ParseIdentity(spiffe.URIPrefix + strings.TrimPrefix(identity, spiffe.URIPrefix))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimPrefix(id.String(), spiffe.URIPrefix), nil
+}
+
+func revokedPrincipals(policy *clientsecurityv1beta1.AuthorizationPolicy) []string {
+	var res []string
+	for _, rule := range policy.Spec.GetRules() {
+		for _, from := range rule.GetFrom() {
+			res = append(res, from.GetSource().GetPrincipals()...)
+		}
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
+func setRevokedPrincipals(policy *clientsecurityv1beta1.AuthorizationPolicy, principals []string) {
+	policy.Spec = securityv1beta1.AuthorizationPolicy{
+		// No selector is set, so the policy applies to all workloads in the mesh.
+		Action: securityv1beta1.AuthorizationPolicy_DENY,
+		Rules: []*securityv1beta1.Rule{{
+			From: []*securityv1beta1.Rule_From{{
+				Source: &securityv1beta1.Source{Principals: principals},
+			}},
+		}},
+	}
+}
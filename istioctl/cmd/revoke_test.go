@@ -0,0 +1,105 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	securityv1beta1 "istio.io/api/security/v1beta1"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestRevoke(t *testing.T) {
+	// The policy is created in the root namespace of the mesh, which istiod reads it from
+	client := kube.NewFakeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: defaultMeshConfigMapName, Namespace: constants.IstioSystemNamespace},
+		Data:       map[string]string{defaultMeshConfigMapKey: "rootNamespace: istio-config"},
+	})
+	kubeClient = func(kubeconfig, configContext string) (kube.CLIClient, error) {
+		return client, nil
+	}
+	run := func(args string) string {
+		t.Helper()
+		var out bytes.Buffer
+		rootCmd := GetRootCmd(strings.Split(args, " "))
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&out)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+		return out.String()
+	}
+	principals := func() []string {
+		t.Helper()
+		policy, err := client.Istio().SecurityV1beta1().AuthorizationPolicies("istio-config").
+			Get(context.Background(), constants.RevokedIdentitiesPolicyName, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, policy.Spec.Action, securityv1beta1.AuthorizationPolicy_DENY)
+		return revokedPrincipals(policy)
+	}
+
+	assert.Equal(t, run("x revoke"), "No revoked identities found.\n")
+
+	assert.Equal(t, run("x revoke spiffe://cluster.local/ns/default/sa/b cluster.local/ns/default/sa/a"),
+		"identity cluster.local/ns/default/sa/b revoked\nidentity cluster.local/ns/default/sa/a revoked\n")
+	assert.Equal(t, principals(), []string{"cluster.local/ns/default/sa/a", "cluster.local/ns/default/sa/b"})
+
+	// Revoking an already revoked identity is a no-op
+	run("x revoke cluster.local/ns/default/sa/a")
+	assert.Equal(t, principals(), []string{"cluster.local/ns/default/sa/a", "cluster.local/ns/default/sa/b"})
+	assert.Equal(t, run("x revoke"), "cluster.local/ns/default/sa/a\ncluster.local/ns/default/sa/b\n")
+
+	assert.Equal(t, run("x revoke cluster.local/ns/default/sa/a --undo"), "identity cluster.local/ns/default/sa/a restored\n")
+	assert.Equal(t, principals(), []string{"cluster.local/ns/default/sa/b"})
+
+	// Restoring the last identity removes the policy
+	run("x revoke cluster.local/ns/default/sa/b --undo")
+	assert.Equal(t, principals(), nil)
+}
+
+func TestRevokedPrincipal(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want string
+		err  bool
+	}{
+		{in: "cluster.local/ns/default/sa/a", want: "cluster.local/ns/default/sa/a"},
+		{in: "spiffe://example.com/ns/foo/sa/bar", want: "example.com/ns/foo/sa/bar"},
+		{in: "cluster.local/ns/default", err: true},
+		{in: "default/a", err: true},
+	} {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := revokedPrincipal(tt.in)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
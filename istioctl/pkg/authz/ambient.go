@@ -0,0 +1,261 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"istio.io/api/security/v1beta1"
+	authnv1beta1 "istio.io/istio/pilot/pkg/security/authn/v1beta1"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube/controller"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/labels"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// AmbientWorkload is the destination workload of a simulated connection.
+type AmbientWorkload struct {
+	Namespace string
+	Labels    map[string]string
+	IP        netip.Addr
+}
+
+// AmbientConnection is a connection to an ambient workload, as seen by the ztunnel of the destination.
+type AmbientConnection struct {
+	// MutualTLS is set when the source uses mTLS, that is when it is captured by ztunnel or has a sidecar. The
+	// source namespace and principal are only known with mTLS.
+	MutualTLS       bool
+	SourceNamespace string
+	// SourcePrincipal is the identity of the source, without the spiffe:// prefix.
+	SourcePrincipal string
+	SourceIP        netip.Addr
+	DestinationPort uint32
+}
+
+// AmbientDecision is whether a simulated connection is allowed, and why.
+type AmbientDecision struct {
+	Allowed bool
+	// Policy is the namespace/name of the AuthorizationPolicy deciding the connection, if any.
+	Policy string
+	// Rule is the index of the rule of the policy matching the connection, or -1.
+	Rule   int
+	Reason string
+}
+
+// SimulateAmbient evaluates the L4 authorization of a connection to an ambient workload, as ztunnel would: the
+// connection is denied if the workload requires mTLS and the source does not use it, or if a DENY policy matches
+// it. Otherwise it is allowed if no ALLOW policy applies to the workload, or if one matches it.
+//
+// Policies are converted like istiod converts them for ztunnel: rules of ALLOW policies with L7 attributes never
+// match, and L7 attributes of DENY policies are ignored. CUSTOM and AUDIT policies are not enforced by ztunnel.
+func SimulateAmbient(rootNamespace string, authzPolicies, peerAuthentications []config.Config,
+	dst AmbientWorkload, conn AmbientConnection,
+) AmbientDecision {
+	mode := mutualTLSMode(rootNamespace, peerAuthentications, dst, conn.DestinationPort)
+	if mode == v1beta1.PeerAuthentication_MutualTLS_STRICT && !conn.MutualTLS {
+		return AmbientDecision{Rule: -1, Reason: fmt.Sprintf("PeerAuthentication requires mTLS on port %d, but the source "+
+			"does not use mTLS", conn.DestinationPort)}
+	}
+
+	var deny, allow []config.Config
+	for _, p := range authzPolicies {
+		if !policyApplies(rootNamespace, p, dst) {
+			continue
+		}
+		switch p.Spec.(*v1beta1.AuthorizationPolicy).Action {
+		case v1beta1.AuthorizationPolicy_DENY:
+			deny = append(deny, p)
+		case v1beta1.AuthorizationPolicy_ALLOW:
+			allow = append(allow, p)
+		}
+	}
+	sortConfigs(deny)
+	sortConfigs(allow)
+
+	for _, p := range deny {
+		if rule := matchingRule(rootNamespace, p, dst, conn); rule >= 0 {
+			return AmbientDecision{Policy: p.Namespace + "/" + p.Name, Rule: rule, Reason: "a DENY policy matched"}
+		}
+	}
+	if len(allow) == 0 {
+		return AmbientDecision{Allowed: true, Rule: -1, Reason: "no ALLOW policy applies to the workload"}
+	}
+	for _, p := range allow {
+		if rule := matchingRule(rootNamespace, p, dst, conn); rule >= 0 {
+			return AmbientDecision{Allowed: true, Policy: p.Namespace + "/" + p.Name, Rule: rule, Reason: "an ALLOW policy matched"}
+		}
+	}
+	names := make([]string, 0, len(allow))
+	for _, p := range allow {
+		names = append(names, p.Namespace+"/"+p.Name)
+	}
+	return AmbientDecision{Rule: -1, Reason: "no ALLOW policy matched: " + strings.Join(names, ", ")}
+}
+
+func sortConfigs(cfgs []config.Config) {
+	sort.Slice(cfgs, func(i, j int) bool {
+		if cfgs[i].Namespace != cfgs[j].Namespace {
+			return cfgs[i].Namespace < cfgs[j].Namespace
+		}
+		return cfgs[i].Name < cfgs[j].Name
+	})
+}
+
+// mutualTLSMode returns the mTLS mode of the destination port of the workload.
+func mutualTLSMode(rootNamespace string, peerAuthentications []config.Config, dst AmbientWorkload,
+	port uint32,
+) v1beta1.PeerAuthentication_MutualTLS_Mode {
+	var matched []*config.Config
+	for i, pa := range peerAuthentications {
+		if pa.Namespace != rootNamespace && pa.Namespace != dst.Namespace {
+			continue
+		}
+		if labels.Instance(pa.Spec.(*v1beta1.PeerAuthentication).GetSelector().GetMatchLabels()).SubsetOf(dst.Labels) {
+			matched = append(matched, &peerAuthentications[i])
+		}
+	}
+	effective := authnv1beta1.ComposePeerAuthentication(rootNamespace, matched)
+	if mtls, f := effective.PortLevelMtls[port]; f {
+		return mtls.GetMode()
+	}
+	return effective.GetMtls().GetMode()
+}
+
+// policyApplies returns whether the AuthorizationPolicy applies to the workload, like the policies istiod sends
+// to ztunnel for it.
+func policyApplies(rootNamespace string, p config.Config, dst AmbientWorkload) bool {
+	selector := p.Spec.(*v1beta1.AuthorizationPolicy).Selector
+	if selector == nil {
+		return p.Namespace == rootNamespace || p.Namespace == dst.Namespace
+	}
+	if p.Namespace != rootNamespace && p.Namespace != dst.Namespace {
+		return false
+	}
+	return labels.Instance(selector.MatchLabels).SubsetOf(dst.Labels)
+}
+
+// matchingRule returns the index of the first rule of the policy matching the connection, or -1.
+func matchingRule(rootNamespace string, p config.Config, dst AmbientWorkload, conn AmbientConnection) int {
+	spec := p.Spec.(*v1beta1.AuthorizationPolicy)
+	for i, rule := range spec.Rules {
+		// Rules are converted one at a time, as rules which never match are dropped by the conversion
+		single := proto.Clone(spec).(*v1beta1.AuthorizationPolicy)
+		single.Rules = []*v1beta1.Rule{rule}
+		cfg := p
+		cfg.Spec = single
+		converted := controller.ConvertAuthorizationPolicy(rootNamespace, nil, cfg)
+		if converted == nil {
+			return -1
+		}
+		for _, g := range converted.Groups {
+			if groupMatches(g, dst, conn) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func groupMatches(g *workloadapi.Group, dst AmbientWorkload, conn AmbientConnection) bool {
+	for _, rules := range g.Rules {
+		if !rulesMatch(rules, dst, conn) {
+			return false
+		}
+	}
+	return true
+}
+
+func rulesMatch(rules *workloadapi.Rules, dst AmbientWorkload, conn AmbientConnection) bool {
+	if len(rules.Matches) == 0 {
+		return true
+	}
+	for _, m := range rules.Matches {
+		if matchMatches(m, dst, conn) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchMatches(m *workloadapi.Match, dst AmbientWorkload, conn AmbientConnection) bool {
+	namespace, principal := conn.SourceNamespace, conn.SourcePrincipal
+	if !conn.MutualTLS {
+		namespace, principal = "", ""
+	}
+	return matchesAny(m.Namespaces, namespace, stringMatches) &&
+		!matchesSome(m.NotNamespaces, namespace, stringMatches) &&
+		matchesAny(m.Principals, principal, stringMatches) &&
+		!matchesSome(m.NotPrincipals, principal, stringMatches) &&
+		matchesAny(m.SourceIps, conn.SourceIP, addressMatches) &&
+		!matchesSome(m.NotSourceIps, conn.SourceIP, addressMatches) &&
+		matchesAny(m.DestinationIps, dst.IP, addressMatches) &&
+		!matchesSome(m.NotDestinationIps, dst.IP, addressMatches) &&
+		matchesAny(m.DestinationPorts, conn.DestinationPort, portMatches) &&
+		!matchesSome(m.NotDestinationPorts, conn.DestinationPort, portMatches)
+}
+
+// matchesAny returns whether any of the matchers matches the value, or true if there are none.
+func matchesAny[M, V any](matchers []M, v V, f func(M, V) bool) bool {
+	return len(matchers) == 0 || matchesSome(matchers, v, f)
+}
+
+// matchesSome returns whether any of the matchers matches the value.
+func matchesSome[M, V any](matchers []M, v V, f func(M, V) bool) bool {
+	for _, m := range matchers {
+		if f(m, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func stringMatches(m *workloadapi.StringMatch, v string) bool {
+	if v == "" {
+		// Unauthenticated sources have no namespace or principal to match
+		return false
+	}
+	switch t := m.MatchType.(type) {
+	case *workloadapi.StringMatch_Exact:
+		return v == t.Exact
+	case *workloadapi.StringMatch_Prefix:
+		return strings.HasPrefix(v, t.Prefix)
+	case *workloadapi.StringMatch_Suffix:
+		return strings.HasSuffix(v, t.Suffix)
+	case *workloadapi.StringMatch_Presence:
+		return true
+	}
+	return false
+}
+
+func addressMatches(m *workloadapi.Address, v netip.Addr) bool {
+	addr, ok := netip.AddrFromSlice(m.Address)
+	if !ok || !v.IsValid() {
+		return false
+	}
+	prefix, err := addr.Prefix(int(m.Length))
+	if err != nil {
+		return false
+	}
+	return prefix.Contains(v.Unmap())
+}
+
+func portMatches(m uint32, v uint32) bool {
+	return m == v
+}
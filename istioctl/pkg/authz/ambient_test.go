@@ -0,0 +1,138 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"net/netip"
+	"testing"
+
+	"istio.io/api/security/v1beta1"
+	typev1beta1 "istio.io/api/type/v1beta1"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func authorizationPolicy(ns, name string, spec *v1beta1.AuthorizationPolicy) config.Config {
+	return config.Config{
+		Meta: config.Meta{GroupVersionKind: gvk.AuthorizationPolicy, Namespace: ns, Name: name},
+		Spec: spec,
+	}
+}
+
+func peerAuthentication(ns, name string, spec *v1beta1.PeerAuthentication) config.Config {
+	return config.Config{
+		Meta: config.Meta{GroupVersionKind: gvk.PeerAuthentication, Namespace: ns, Name: name},
+		Spec: spec,
+	}
+}
+
+func TestSimulateAmbient(t *testing.T) {
+	dst := AmbientWorkload{Namespace: "ns", Labels: map[string]string{"app": "reviews"}, IP: netip.MustParseAddr("10.0.0.2")}
+	conn := AmbientConnection{
+		MutualTLS:       true,
+		SourceNamespace: "default",
+		SourcePrincipal: "cluster.local/ns/default/sa/productpage",
+		SourceIP:        netip.MustParseAddr("10.0.0.1"),
+		DestinationPort: 9080,
+	}
+	plaintext := conn
+	plaintext.MutualTLS = false
+
+	allowProductpage := authorizationPolicy("ns", "allow-productpage", &v1beta1.AuthorizationPolicy{
+		Selector: &typev1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": "reviews"}},
+		Rules: []*v1beta1.Rule{
+			{From: []*v1beta1.Rule_From{{Source: &v1beta1.Source{Namespaces: []string{"other"}}}}},
+			{From: []*v1beta1.Rule_From{{Source: &v1beta1.Source{Principals: []string{"cluster.local/ns/default/sa/productpage"}}}}},
+		},
+	})
+	allowL7 := authorizationPolicy("ns", "allow-get", &v1beta1.AuthorizationPolicy{
+		Rules: []*v1beta1.Rule{{To: []*v1beta1.Rule_To{{Operation: &v1beta1.Operation{Methods: []string{"GET"}}}}}},
+	})
+	denyPort := authorizationPolicy("istio-system", "deny-port", &v1beta1.AuthorizationPolicy{
+		Action: v1beta1.AuthorizationPolicy_DENY,
+		Rules: []*v1beta1.Rule{{To: []*v1beta1.Rule_To{{Operation: &v1beta1.Operation{
+			Ports:   []string{"9080"},
+			Methods: []string{"POST"},
+		}}}}},
+	})
+	otherWorkload := authorizationPolicy("ns", "other-workload", &v1beta1.AuthorizationPolicy{
+		Selector: &typev1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": "ratings"}},
+	})
+	strict := peerAuthentication("ns", "strict", &v1beta1.PeerAuthentication{
+		Mtls: &v1beta1.PeerAuthentication_MutualTLS{Mode: v1beta1.PeerAuthentication_MutualTLS_STRICT},
+	})
+
+	cases := []struct {
+		name     string
+		policies []config.Config
+		pas      []config.Config
+		conn     AmbientConnection
+		want     AmbientDecision
+	}{
+		{
+			name: "no policies",
+			conn: conn,
+			want: AmbientDecision{Allowed: true, Rule: -1, Reason: "no ALLOW policy applies to the workload"},
+		},
+		{
+			name:     "policies of other workloads",
+			policies: []config.Config{otherWorkload},
+			conn:     conn,
+			want:     AmbientDecision{Allowed: true, Rule: -1, Reason: "no ALLOW policy applies to the workload"},
+		},
+		{
+			name:     "allow rule matches",
+			policies: []config.Config{allowProductpage},
+			conn:     conn,
+			want:     AmbientDecision{Allowed: true, Policy: "ns/allow-productpage", Rule: 1, Reason: "an ALLOW policy matched"},
+		},
+		{
+			name:     "principal unknown without mTLS",
+			policies: []config.Config{allowProductpage},
+			conn:     plaintext,
+			want:     AmbientDecision{Rule: -1, Reason: "no ALLOW policy matched: ns/allow-productpage"},
+		},
+		{
+			name:     "L7 allow rules never match",
+			policies: []config.Config{allowL7},
+			conn:     conn,
+			want:     AmbientDecision{Rule: -1, Reason: "no ALLOW policy matched: ns/allow-get"},
+		},
+		{
+			name:     "L7 attributes of deny rules are ignored",
+			policies: []config.Config{allowProductpage, denyPort},
+			conn:     conn,
+			want:     AmbientDecision{Policy: "istio-system/deny-port", Rule: 0, Reason: "a DENY policy matched"},
+		},
+		{
+			name: "strict mTLS",
+			pas:  []config.Config{strict},
+			conn: plaintext,
+			want: AmbientDecision{Rule: -1, Reason: "PeerAuthentication requires mTLS on port 9080, but the source does not use mTLS"},
+		},
+		{
+			name: "strict mTLS with mTLS",
+			pas:  []config.Config{strict},
+			conn: conn,
+			want: AmbientDecision{Allowed: true, Rule: -1, Reason: "no ALLOW policy applies to the workload"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, SimulateAmbient("istio-system", tt.policies, tt.pas, dst, tt.conn), tt.want)
+		})
+	}
+}
@@ -58,6 +58,23 @@ type xdsWriterStatus struct {
 	routeStatus          string
 	endpointStatus       string
 	extensionconfigStaus string
+	// The following are only set for ambient proxies
+	proxyType           model.NodeType
+	nodeName            string
+	workloadStatus      string
+	authorizationStatus string
+}
+
+// ambientStatus is the status of the state consumed by an ambient proxy.
+type ambientStatus struct {
+	proxyID   string
+	clusterID string
+	nodeName  string
+	proxyType model.NodeType
+	workloads string
+	policies  string
+	istiod    string
+	version   string
 }
 
 // PrintAll takes a slice of Pilot syncz responses and outputs them using a tabwriter
@@ -66,14 +83,61 @@ func (s *StatusWriter) PrintAll(statuses map[string][]byte) error {
 	if err != nil {
 		return err
 	}
+	var ambient []ambientStatus
 	for _, status := range fullStatus {
 		if err := statusPrintln(w, status); err != nil {
 			return err
 		}
+		a := ambientStatus{
+			proxyID:   status.ProxyID,
+			clusterID: status.ClusterID,
+			nodeName:  status.NodeName,
+			proxyType: status.ProxyType,
+			istiod:    status.pilot,
+			version:   status.IstioVersion,
+		}
+		switch status.ProxyType {
+		case model.Ztunnel:
+			a.workloads = syncStatus(status.WorkloadSent, status.WorkloadAcked)
+			a.policies = syncStatus(status.AuthorizationSent, status.AuthorizationAcked)
+		case model.Waypoint:
+			a.workloads = syncStatus(status.EndpointSent, status.EndpointAcked)
+			a.policies = syncStatus(status.ListenerSent, status.ListenerAcked)
+		default:
+			continue
+		}
+		ambient = append(ambient, a)
 	}
+	ambientStatusPrint(w, ambient)
 	return w.Flush()
 }
 
+// ambientStatusPrint prints the workload and policy status of ztunnels and waypoints, along with the node
+// they run on, so drift between nodes is visible. ztunnel receives these through the workload and
+// authorization types, while waypoints receive workloads through EDS and policies through LDS.
+// Certificates are issued to ambient proxies on request, so they have no sync status to report.
+func ambientStatusPrint(w io.Writer, statuses []ambientStatus) {
+	if len(statuses) == 0 {
+		return
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].clusterID != statuses[j].clusterID {
+			return statuses[i].clusterID < statuses[j].clusterID
+		}
+		if statuses[i].nodeName != statuses[j].nodeName {
+			return statuses[i].nodeName < statuses[j].nodeName
+		}
+		return statuses[i].proxyID < statuses[j].proxyID
+	})
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "NAME\tCLUSTER\tNODE\tTYPE\tWORKLOADS\tPOLICIES\tISTIOD\tVERSION")
+	for _, status := range statuses {
+		_, _ = fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+			status.proxyID, status.clusterID, status.nodeName, status.proxyType,
+			status.workloads, status.policies, status.istiod, status.version)
+	}
+}
+
 // PrintSingle takes a slice of Pilot syncz responses and outputs them using a tabwriter filtering for a specific pod
 func (s *StatusWriter) PrintSingle(statuses map[string][]byte, proxyName string) error {
 	w, fullStatus, err := s.setupStatusPrint(statuses)
@@ -137,10 +201,14 @@ func statusPrintln(w io.Writer, status *writerStatus) error {
 const ignoredStatus = "IGNORED"
 
 func xdsStatus(sent, acked string, typ model.NodeType) string {
+	if sent == "" && typ == model.Ztunnel {
+		return ignoredStatus
+	}
+	return syncStatus(sent, acked)
+}
+
+func syncStatus(sent, acked string) string {
 	if sent == "" {
-		if typ == model.Ztunnel {
-			return ignoredStatus
-		}
 		return "NOT SENT"
 	}
 	if sent == acked {
@@ -160,12 +228,31 @@ func (s *XdsStatusWriter) PrintAll(statuses map[string]*discovery.DiscoveryRespo
 	if err != nil {
 		return err
 	}
+	var ambient []ambientStatus
 	for _, status := range fullStatus {
 		if err := xdsStatusPrintln(w, status); err != nil {
 			return err
 		}
+		a := ambientStatus{
+			proxyID:   status.proxyID,
+			clusterID: status.clusterID,
+			nodeName:  status.nodeName,
+			proxyType: status.proxyType,
+			istiod:    status.istiodID,
+			version:   status.istiodVersion,
+		}
+		switch status.proxyType {
+		case model.Ztunnel:
+			a.workloads, a.policies = status.workloadStatus, status.authorizationStatus
+		case model.Waypoint:
+			a.workloads, a.policies = status.endpointStatus, status.listenerStatus
+		default:
+			continue
+		}
+		ambient = append(ambient, a)
 	}
 	if w != nil {
+		ambientStatusPrint(w, ambient)
 		return w.Flush()
 	}
 	return nil
@@ -185,12 +272,13 @@ func (s *XdsStatusWriter) setupStatusPrint(drs map[string]*discovery.DiscoveryRe
 				if err != nil {
 					return nil, nil, fmt.Errorf("could not unmarshal ClientConfig: %w", err)
 				}
-				cds, lds, eds, rds, ecds := getSyncStatus(&clientConfig)
+				cds, lds, eds, rds, ecds, wds, wads := getSyncStatus(&clientConfig)
 				cp := multixds.CpInfo(dr)
-				meta, err := model.ParseMetadata(clientConfig.GetNode().GetMetadata())
+				meta, err := model.ParseBootstrapNodeMetadata(clientConfig.GetNode().GetMetadata())
 				if err != nil {
 					return nil, nil, fmt.Errorf("could not parse node metadata: %w", err)
 				}
+				proxyType, _ := meta.Raw[xds.SyncProxyTypeMetadataKey].(string)
 				fullStatus = append(fullStatus, &xdsWriterStatus{
 					proxyID:              clientConfig.GetNode().GetId(),
 					clusterID:            meta.ClusterID.String(),
@@ -201,6 +289,10 @@ func (s *XdsStatusWriter) setupStatusPrint(drs map[string]*discovery.DiscoveryRe
 					routeStatus:          rds,
 					endpointStatus:       eds,
 					extensionconfigStaus: ecds,
+					proxyType:            model.NodeType(proxyType),
+					nodeName:             meta.NodeName,
+					workloadStatus:       wds,
+					authorizationStatus:  wads,
 				})
 				if len(fullStatus) == 0 {
 					return nil, nil, fmt.Errorf("no proxies found (checked %d istiods)", len(drs))
@@ -246,7 +338,7 @@ func xdsStatusPrintln(w io.Writer, status *xdsWriterStatus) error {
 	return err
 }
 
-func getSyncStatus(clientConfig *xdsstatus.ClientConfig) (cds, lds, eds, rds, ecds string) {
+func getSyncStatus(clientConfig *xdsstatus.ClientConfig) (cds, lds, eds, rds, ecds, wds, wads string) {
 	configs := handleAndGetXdsConfigs(clientConfig)
 	for _, config := range configs {
 		cfgType := config.GetTypeUrl()
@@ -282,6 +374,10 @@ func getSyncStatus(clientConfig *xdsstatus.ClientConfig) (cds, lds, eds, rds, ec
 			} else {
 				ecds = config.GetConfigStatus().String()
 			}
+		case xdsresource.WorkloadType:
+			wds = status.String()
+		case xdsresource.WorkloadAuthorizationType:
+			wads = status.String()
 		default:
 			log.Infof("GenericXdsConfig unexpected type %s\n", xdsresource.GetShortType(cfgType))
 		}
@@ -25,6 +25,7 @@ import (
 	status "github.com/envoyproxy/go-control-plane/envoy/service/status/v3"
 	"github.com/google/uuid"
 	anypb "google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/util/protoconv"
@@ -237,6 +238,26 @@ func statusInput4() []xds.SyncStatus {
 			EndpointAcked: "",
 			RouteSent:     "",
 			RouteAcked:    "",
+			NodeName:      "node1",
+			WorkloadSent:  preDefinedNonce,
+			WorkloadAcked: preDefinedNonce,
+			// Sent but never acknowledged
+			AuthorizationSent: preDefinedNonce,
+		},
+		{
+			ClusterID:     "cluster4",
+			ProxyID:       "waypoint1",
+			IstioVersion:  "1.1",
+			ProxyType:     model.Waypoint,
+			NodeName:      "node2",
+			ClusterSent:   preDefinedNonce,
+			ClusterAcked:  preDefinedNonce,
+			ListenerSent:  preDefinedNonce,
+			ListenerAcked: preDefinedNonce,
+			EndpointSent:  newNonce(),
+			EndpointAcked: preDefinedNonce,
+			RouteSent:     preDefinedNonce,
+			RouteAcked:    preDefinedNonce,
 		},
 	}
 }
@@ -306,11 +327,26 @@ func TestXdsStatusWriter_PrintAll(t *testing.T) {
 					{
 						proxyID:        "proxy4",
 						clusterID:      "cluster4",
+						proxyType:      model.Ztunnel,
+						nodeName:       "node1",
 						cdsSyncStatus:  status.ConfigStatus_UNKNOWN,
 						ldsSyncStatus:  status.ConfigStatus_UNKNOWN,
 						rdsSyncStatus:  status.ConfigStatus_UNKNOWN,
 						edsSyncStatus:  status.ConfigStatus_UNKNOWN,
 						ecdsSyncStatus: status.ConfigStatus_UNKNOWN,
+						wdsSyncStatus:  status.ConfigStatus_SYNCED,
+						wadsSyncStatus: status.ConfigStatus_STALE,
+					},
+					{
+						proxyID:        "waypoint1",
+						clusterID:      "cluster4",
+						proxyType:      model.Waypoint,
+						nodeName:       "node2",
+						cdsSyncStatus:  status.ConfigStatus_SYNCED,
+						ldsSyncStatus:  status.ConfigStatus_SYNCED,
+						rdsSyncStatus:  status.ConfigStatus_SYNCED,
+						edsSyncStatus:  status.ConfigStatus_STALE,
+						ecdsSyncStatus: status.ConfigStatus_NOT_SENT,
 					},
 				}),
 			},
@@ -371,22 +407,30 @@ const clientConfigType = "type.googleapis.com/envoy.service.status.v3.ClientConf
 type clientConfigInput struct {
 	proxyID   string
 	clusterID string
+	proxyType model.NodeType
+	nodeName  string
 
 	cdsSyncStatus  status.ConfigStatus
 	ldsSyncStatus  status.ConfigStatus
 	rdsSyncStatus  status.ConfigStatus
 	edsSyncStatus  status.ConfigStatus
 	ecdsSyncStatus status.ConfigStatus
+	wdsSyncStatus  status.ConfigStatus
+	wadsSyncStatus status.ConfigStatus
 }
 
 func newXdsClientConfig(config clientConfigInput) *status.ClientConfig {
 	meta := model.NodeMetadata{
 		ClusterID: cluster.ID(config.clusterID),
+		NodeName:  config.nodeName,
+	}.ToStruct()
+	if config.proxyType != "" {
+		meta.Fields[xds.SyncProxyTypeMetadataKey] = structpb.NewStringValue(string(config.proxyType))
 	}
-	return &status.ClientConfig{
+	cc := &status.ClientConfig{
 		Node: &core.Node{
 			Id:       config.proxyID,
-			Metadata: meta.ToStruct(),
+			Metadata: meta,
 		},
 		GenericXdsConfigs: []*status.ClientConfig_GenericXdsConfig{
 			{
@@ -411,6 +455,18 @@ func newXdsClientConfig(config clientConfigInput) *status.ClientConfig {
 			},
 		},
 	}
+	if config.proxyType == model.Ztunnel {
+		cc.GenericXdsConfigs = append(cc.GenericXdsConfigs,
+			&status.ClientConfig_GenericXdsConfig{
+				TypeUrl:      v3.WorkloadType,
+				ConfigStatus: config.wdsSyncStatus,
+			},
+			&status.ClientConfig_GenericXdsConfig{
+				TypeUrl:      v3.WorkloadAuthorizationType,
+				ConfigStatus: config.wadsSyncStatus,
+			})
+	}
+	return cc
 }
 
 func xdsResponseInput(istiodID string, configInputs []clientConfigInput) *discovery.DiscoveryResponse {
@@ -519,6 +519,16 @@ func TestManifestGenerateZtunnel(t *testing.T) {
 	})
 }
 
+func TestManifestGenerateCNINodeOverlays(t *testing.T) {
+	runTestGroup(t, testGroup{
+		{
+			desc:        "cni_node_overlays",
+			diffSelect:  "DaemonSet:*:istio-cni-node*, ConfigMap:*:istio-cni-config*",
+			chartSource: liveCharts,
+		},
+	})
+}
+
 // TestManifestGenerateHelmValues tests whether enabling components through the values passthrough interface works as
 // expected i.e. without requiring enablement also in IstioOperator API.
 func TestManifestGenerateHelmValues(t *testing.T) {
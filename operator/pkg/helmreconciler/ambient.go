@@ -0,0 +1,212 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"istio.io/api/operator/v1alpha1"
+	"istio.io/istio/cni/pkg/ambient/redirectdump"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/operator/pkg/object"
+)
+
+// ambientGatePollInterval is how often the ambient verification gates are checked.
+const ambientGatePollInterval = 2 * time.Second
+
+// cniMonitoringPort is the port the CNI node agent serves its debug handlers on, along with its metrics, unless it
+// serves them over mTLS.
+const cniMonitoringPort = "15014"
+
+// verifyAmbient runs the post-install verification gates of an ambient installation, once all components have been
+// applied, if the installation waits for its resources. The installation is only marked HEALTHY once the gates pass;
+// gates still failing after the wait timeout are recorded as errors on the status of the component they verify.
+func (h *HelmReconciler) verifyAmbient(manifests name.ManifestMap, status *v1alpha1.InstallStatus) {
+	if !h.opts.Wait || h.opts.DryRun || TestMode || len(manifests[name.ZtunnelComponentName]) == 0 {
+		return
+	}
+	h.opts.Log.LogAndPrint("Verifying ambient installation...")
+	var res ambientGateResult
+	var err error
+	errPoll := wait.PollUntilContextTimeout(context.Background(), ambientGatePollInterval, h.opts.WaitTimeout, true,
+		func(context.Context) (bool, error) {
+			res, err = ambientGateFailures(h.kubeClient.Kube(), manifests)
+			return err == nil && len(res.failures) == 0, nil
+		})
+	if errPoll == nil {
+		if len(res.unverified) > 0 {
+			h.opts.Log.LogAndPrintf("The capture of the pods of nodes %s could not be verified, as their CNI node agent "+
+				"redirects with eBPF or serves its debug handlers over mTLS", strings.Join(res.unverified, ", "))
+		}
+		return
+	}
+	if err != nil {
+		res.failures = map[name.ComponentName][]string{name.ZtunnelComponentName: {err.Error()}}
+	}
+	for c, f := range res.failures {
+		gateErr := fmt.Errorf("ambient verification failed after %v: %s", h.opts.WaitTimeout, strings.Join(f, "; "))
+		h.opts.Log.LogAndErrorf("%s %v", name.UserFacingComponentName(c), gateErr)
+		setStatus(status.ComponentStatus, c, v1alpha1.InstallStatus_ERROR, gateErr)
+	}
+	status.Status = overallStatus(status.ComponentStatus)
+}
+
+// ambientGateResult is the result of the ambient verification gates.
+type ambientGateResult struct {
+	// failures are the failing gates, by component.
+	failures map[name.ComponentName][]string
+	// unverified are the nodes the capture of the pods of could not be verified on.
+	unverified []string
+}
+
+// ambientGateFailures checks the ambient verification gates:
+//   - ztunnel and, if it is part of the installation, the CNI node agent have a ready, up to date pod on every node
+//     their DaemonSet is scheduled to;
+//   - the redirection of every pod eligible for ambient capture is configured on its node, as reported by the CNI
+//     node agent.
+func ambientGateFailures(cs kubernetes.Interface, manifests name.ManifestMap) (ambientGateResult, error) {
+	res := ambientGateResult{failures: map[name.ComponentName][]string{}}
+	var agents []corev1.Pod
+	for _, c := range []name.ComponentName{name.ZtunnelComponentName, name.CNIComponentName} {
+		objects, err := object.ParseK8sObjectsFromYAMLManifest(name.MergeManifestSlices(manifests[c]))
+		if err != nil {
+			return res, err
+		}
+		for _, o := range objects {
+			if o.Kind != name.DaemonSetStr {
+				continue
+			}
+			ds, err := cs.AppsV1().DaemonSets(o.Namespace).Get(context.TODO(), o.Name, metav1.GetOptions{})
+			if err != nil {
+				return res, err
+			}
+			selector, err := metav1.LabelSelectorAsSelector(ds.Spec.Selector)
+			if err != nil {
+				return res, err
+			}
+			pods, err := getPods(cs, ds.Namespace, selector)
+			if err != nil {
+				return res, err
+			}
+			res.failures[c] = append(res.failures[c], daemonSetNotReadyNodes(ds, pods)...)
+			if c == name.CNIComponentName {
+				agents = append(agents, pods...)
+			}
+		}
+	}
+	if len(manifests[name.CNIComponentName]) != 0 {
+		uncaptured, unverified := uncapturedPods(cs, agents)
+		res.failures[name.CNIComponentName] = append(res.failures[name.CNIComponentName], uncaptured...)
+		res.unverified = unverified
+	}
+	for c, f := range res.failures {
+		if len(f) == 0 {
+			delete(res.failures, c)
+		}
+	}
+	return res, nil
+}
+
+// daemonSetNotReadyNodes returns a failure for every node the DaemonSet is scheduled to but has no ready, up to date
+// pod on.
+func daemonSetNotReadyNodes(ds *appsv1.DaemonSet, pods []corev1.Pod) []string {
+	id := "DaemonSet/" + ds.Namespace + "/" + ds.Name
+	if ds.Status.ObservedGeneration < ds.Generation || ds.Status.UpdatedNumberScheduled < ds.Status.DesiredNumberScheduled {
+		return []string{fmt.Sprintf("%s: rollout in progress, %d out of %d pods updated",
+			id, ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled)}
+	}
+	if ds.Status.NumberReady >= ds.Status.DesiredNumberScheduled {
+		return nil
+	}
+	var res []string
+	for i := range pods {
+		if !isPodReady(&pods[i]) {
+			res = append(res, fmt.Sprintf("%s: pod %s on node %s is not ready", id, pods[i].Name, pods[i].Spec.NodeName))
+		}
+	}
+	if len(res) == 0 {
+		res = append(res, fmt.Sprintf("%s: %d out of %d pods are ready", id, ds.Status.NumberReady, ds.Status.DesiredNumberScheduled))
+	}
+	sort.Strings(res)
+	return res
+}
+
+// uncapturedPods returns a failure for every node whose redirection to ztunnel is not configured, and for every pod
+// which should be redirected to ztunnel but whose redirection is not configured on its node. These are read from the
+// redirect dumps of the CNI node agents, through the API server, which compare the redirection the node agents
+// programmed with what is actually configured on their nodes. The nodes whose node agent redirects with eBPF, or
+// serves its debug handlers over mTLS, cannot be verified this way, and are returned as unverified.
+func uncapturedPods(cs kubernetes.Interface, agents []corev1.Pod) (failures []string, unverified []string) {
+	for i := range agents {
+		agent := &agents[i]
+		node := agent.Spec.NodeName
+		if agent.Status.Phase != corev1.PodRunning || node == "" {
+			continue
+		}
+		if !redirectDumpSupported(agent) {
+			unverified = append(unverified, node)
+			continue
+		}
+		out, err := cs.CoreV1().Pods(agent.Namespace).
+			ProxyGet("http", agent.Name, cniMonitoringPort, redirectdump.Path, nil).DoRaw(context.TODO())
+		dump := &redirectdump.Dump{}
+		if err == nil {
+			err = json.Unmarshal(out, dump)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("node %s: failed to read the redirect dump of %s/%s: %v", node,
+				agent.Namespace, agent.Name, err))
+			continue
+		}
+		if len(dump.NodeArtifacts.Desired) == 0 {
+			failures = append(failures, fmt.Sprintf("node %s is not redirected to ztunnel", node))
+		} else if missing, _ := dump.NodeArtifacts.Diff(); len(missing) > 0 {
+			failures = append(failures, fmt.Sprintf("node %s is missing %d artifacts of its redirection to ztunnel", node, len(missing)))
+		}
+		for _, p := range dump.Pods {
+			if missing, _ := p.Diff(); len(missing) > 0 {
+				failures = append(failures, fmt.Sprintf("pod %s/%s on node %s is not captured", p.Namespace, p.Name, node))
+			}
+		}
+	}
+	sort.Strings(failures)
+	sort.Strings(unverified)
+	return failures, unverified
+}
+
+// redirectDumpSupported returns whether the redirect dump of the CNI node agent can be read through the API server:
+// the eBPF redirection cannot be dumped, and debug handlers served over mTLS cannot be reached without a client
+// certificate.
+func redirectDumpSupported(agent *corev1.Pod) bool {
+	for _, c := range agent.Spec.Containers {
+		for _, e := range c.Env {
+			if (e.Name == "EBPF_ENABLED" && e.Value == "true") || (e.Name == "MONITORING_TLS_CERT_FILE" && e.Value != "") {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,238 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmreconciler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	ktesting "k8s.io/client-go/testing"
+
+	"istio.io/istio/cni/pkg/ambient/redirectdump"
+	"istio.io/istio/operator/pkg/name"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+const ztunnelManifest = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: ztunnel
+  namespace: istio-system
+spec:
+  selector:
+    matchLabels:
+      app: ztunnel
+`
+
+const cniManifest = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: istio-cni-node
+  namespace: kube-system
+spec:
+  selector:
+    matchLabels:
+      k8s-app: istio-cni-node
+`
+
+// fakeResponse is the response of a proxied request to a pod.
+type fakeResponse struct {
+	body []byte
+	err  error
+}
+
+func (r fakeResponse) DoRaw(context.Context) ([]byte, error) {
+	return r.body, r.err
+}
+
+func (r fakeResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(r.body)), r.err
+}
+
+func TestAmbientGateFailures(t *testing.T) {
+	daemonSet := func(namespace, dsName string, selector map[string]string, desired, ready int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: dsName, Namespace: namespace},
+			Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: selector}},
+			Status: appsv1.DaemonSetStatus{
+				DesiredNumberScheduled: desired,
+				UpdatedNumberScheduled: desired,
+				NumberReady:            ready,
+			},
+		}
+	}
+	pod := func(namespace, podName, node string, podLabels map[string]string, ready bool) *corev1.Pod {
+		p := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: podName, Namespace: namespace, Labels: podLabels},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if ready {
+			p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		return p
+	}
+	ztunnelLabels := map[string]string{"app": "ztunnel"}
+	cniLabels := map[string]string{"k8s-app": "istio-cni-node"}
+	agent := func(podName, node string) *corev1.Pod {
+		return pod("kube-system", podName, node, cniLabels, true)
+	}
+	ebpfAgent := agent("istio-cni-node-ebpf", "node3")
+	ebpfAgent.Spec.Containers = []corev1.Container{{Name: "install-cni", Env: []corev1.EnvVar{{Name: "EBPF_ENABLED", Value: "true"}}}}
+	inSync := redirectdump.Artifacts{Desired: []string{"rule"}, Actual: []string{"rule"}}
+	capturedDump := &redirectdump.Dump{
+		Node:          "node1",
+		NodeArtifacts: inSync,
+		Pods:          []redirectdump.Pod{{Namespace: "app", Name: "captured", Artifacts: inSync}},
+	}
+	uncapturedDump := &redirectdump.Dump{
+		Node:          "node2",
+		NodeArtifacts: redirectdump.Artifacts{Desired: []string{"rule", "route"}, Actual: []string{"rule"}},
+		Pods: []redirectdump.Pod{
+			{Namespace: "app", Name: "uncaptured", Artifacts: redirectdump.Artifacts{Desired: []string{"ipset"}}},
+			// Artifacts left over are not a capture failure
+			{Namespace: "app", Name: "removed", Artifacts: redirectdump.Artifacts{Actual: []string{"ipset"}}},
+		},
+	}
+	manifests := name.ManifestMap{
+		name.ZtunnelComponentName: {ztunnelManifest},
+		name.CNIComponentName:     {cniManifest},
+	}
+
+	cases := []struct {
+		name           string
+		manifests      name.ManifestMap
+		objects        []any
+		dumps          map[string]*redirectdump.Dump
+		want           map[name.ComponentName][]string
+		wantUnverified []string
+	}{
+		{
+			name:      "all gates pass",
+			manifests: manifests,
+			objects: []any{
+				daemonSet("istio-system", "ztunnel", ztunnelLabels, 2, 2),
+				daemonSet("kube-system", "istio-cni-node", cniLabels, 2, 2),
+				agent("istio-cni-node-a", "node1"),
+				ebpfAgent,
+			},
+			dumps:          map[string]*redirectdump.Dump{"istio-cni-node-a": capturedDump},
+			want:           map[name.ComponentName][]string{},
+			wantUnverified: []string{"node3"},
+		},
+		{
+			name:      "ztunnel not ready on a node",
+			manifests: manifests,
+			objects: []any{
+				daemonSet("istio-system", "ztunnel", ztunnelLabels, 2, 1),
+				daemonSet("kube-system", "istio-cni-node", cniLabels, 2, 2),
+				pod("istio-system", "ztunnel-a", "node1", ztunnelLabels, true),
+				pod("istio-system", "ztunnel-b", "node2", ztunnelLabels, false),
+			},
+			want: map[name.ComponentName][]string{
+				name.ZtunnelComponentName: {"DaemonSet/istio-system/ztunnel: pod ztunnel-b on node node2 is not ready"},
+			},
+		},
+		{
+			name:      "cni rollout and uncaptured pod",
+			manifests: manifests,
+			objects: []any{
+				daemonSet("istio-system", "ztunnel", ztunnelLabels, 2, 2),
+				func() *appsv1.DaemonSet {
+					ds := daemonSet("kube-system", "istio-cni-node", cniLabels, 2, 2)
+					ds.Status.UpdatedNumberScheduled = 1
+					return ds
+				}(),
+				agent("istio-cni-node-a", "node1"),
+				agent("istio-cni-node-b", "node2"),
+				agent("istio-cni-node-c", "node4"),
+			},
+			dumps: map[string]*redirectdump.Dump{
+				"istio-cni-node-a": capturedDump,
+				"istio-cni-node-b": uncapturedDump,
+				"istio-cni-node-c": {Node: "node4"},
+			},
+			want: map[name.ComponentName][]string{
+				name.CNIComponentName: {
+					"DaemonSet/kube-system/istio-cni-node: rollout in progress, 1 out of 2 pods updated",
+					"node node2 is missing 1 artifacts of its redirection to ztunnel",
+					"node node4 is not redirected to ztunnel",
+					"pod app/uncaptured on node node2 is not captured",
+				},
+			},
+		},
+		{
+			name:      "unreachable node agent",
+			manifests: manifests,
+			objects: []any{
+				daemonSet("istio-system", "ztunnel", ztunnelLabels, 1, 1),
+				daemonSet("kube-system", "istio-cni-node", cniLabels, 1, 1),
+				agent("istio-cni-node-a", "node1"),
+			},
+			want: map[name.ComponentName][]string{
+				name.CNIComponentName: {"node node1: failed to read the redirect dump of kube-system/istio-cni-node-a: unreachable"},
+			},
+		},
+		{
+			name:      "capture is not verified without the CNI component",
+			manifests: name.ManifestMap{name.ZtunnelComponentName: {ztunnelManifest}},
+			objects: []any{
+				daemonSet("istio-system", "ztunnel", ztunnelLabels, 2, 2),
+			},
+			want: map[name.ComponentName][]string{},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cs := fake.NewSimpleClientset()
+			cs.PrependProxyReactor("pods", func(action ktesting.Action) (bool, restclient.ResponseWrapper, error) {
+				get := action.(ktesting.ProxyGetAction)
+				assert.Equal(t, get.GetPort(), cniMonitoringPort)
+				assert.Equal(t, get.GetPath(), redirectdump.Path)
+				dump, f := tt.dumps[get.GetName()]
+				if !f {
+					return true, fakeResponse{err: errors.New("unreachable")}, nil
+				}
+				b, err := json.Marshal(dump)
+				return true, fakeResponse{body: b}, err
+			})
+			for _, o := range tt.objects {
+				var err error
+				switch o := o.(type) {
+				case *appsv1.DaemonSet:
+					_, err = cs.AppsV1().DaemonSets(o.Namespace).Create(context.TODO(), o, metav1.CreateOptions{})
+				case *corev1.Pod:
+					_, err = cs.CoreV1().Pods(o.Namespace).Create(context.TODO(), o, metav1.CreateOptions{})
+				}
+				assert.NoError(t, err)
+			}
+			got, err := ambientGateFailures(cs, tt.manifests)
+			assert.NoError(t, err)
+			assert.Equal(t, got.failures, tt.want)
+			assert.Equal(t, got.unverified, tt.wantUnverified)
+		})
+	}
+}
@@ -165,6 +165,9 @@ func (h *HelmReconciler) Reconcile() (*v1alpha1.InstallStatus, error) {
 		}
 	}
 	status := h.processRecursive(manifestMap)
+	if status.Status == v1alpha1.InstallStatus_HEALTHY {
+		h.verifyAmbient(manifestMap, status)
+	}
 
 	var pruneErr error
 	if !h.opts.SkipPrune {
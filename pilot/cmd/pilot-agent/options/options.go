@@ -141,6 +141,10 @@ var (
 	certSigner = env.Register("ISTIO_META_CERT_SIGNER", "",
 		"The cert signer info for workload cert")
 
+	trustAnchors = env.Register("TRUST_ANCHORS", false,
+		"If enabled, the trust anchors of the mesh, such as the roots of the other clusters of a multi-primary mesh, "+
+			"are requested from the CA along with the workload cert and added to the root certs").Get()
+
 	istiodSAN = env.Register("ISTIOD_SAN", "",
 		"Override the ServerName used to validate Istiod certificate. "+
 			"Can be used as an alternative to setting /etc/hosts for VMs - discovery address will be an IP:port")
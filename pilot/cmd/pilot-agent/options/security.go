@@ -53,6 +53,7 @@ func NewSecurityOptions(proxyConfig *meshconfig.ProxyConfig, stsPort int, tokenM
 		SecretRotationGracePeriodRatio: secretRotationGracePeriodRatioEnv,
 		STSPort:                        stsPort,
 		CertSigner:                     certSigner.Get(),
+		TrustAnchors:                   trustAnchors,
 		CARootPath:                     cafile.CACertFilePath,
 		CertChainFilePath:              security.DefaultCertChainFilePath,
 		KeyFilePath:                    security.DefaultKeyFilePath,
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -33,9 +34,11 @@ import (
 	"istio.io/istio/pilot/pkg/features"
 	securityModel "istio.io/istio/pilot/pkg/security/model"
 	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/jwt"
 	"istio.io/istio/pkg/kube/namespace"
 	"istio.io/istio/pkg/security"
+	"istio.io/istio/pkg/spiffe"
 	"istio.io/istio/security/pkg/cmd"
 	"istio.io/istio/security/pkg/pki/ca"
 	"istio.io/istio/security/pkg/pki/ra"
@@ -171,6 +174,10 @@ func (s *Server) RunCA(grpc *grpc.Server, ca caserver.CertificateAuthority, opts
 	if startErr != nil {
 		log.Fatalf("failed to create istio ca server: %v", startErr)
 	}
+	caServer.IsRevoked = s.isIdentityRevoked
+	if features.MultiRootMesh {
+		caServer.TrustAnchors = s.workloadTrustBundle.GetTrustBundle
+	}
 
 	// TODO: if not set, parse Istiod's own token (if present) and get the issuer. The same issuer is used
 	// for all tokens - no need to configure twice. The token may also include cluster info to auto-configure
@@ -194,6 +201,31 @@ func (s *Server) RunCA(grpc *grpc.Server, ca caserver.CertificateAuthority, opts
 	log.Info("Istiod CA has started")
 }
 
+// isIdentityRevoked reports whether the identity is listed as a denied principal in the revoked
+// identities AuthorizationPolicy in the root namespace.
+func (s *Server) isIdentityRevoked(identity string) bool {
+	if s.configController == nil {
+		return false
+	}
+	cfg := s.configController.Get(gvk.AuthorizationPolicy, constants.RevokedIdentitiesPolicyName, s.environment.Mesh().GetRootNamespace())
+	if cfg == nil {
+		return false
+	}
+	policy, ok := cfg.Spec.(*v1beta1.AuthorizationPolicy)
+	if !ok || policy.GetAction() != v1beta1.AuthorizationPolicy_DENY {
+		return false
+	}
+	principal := strings.TrimPrefix(identity, spiffe.URIPrefix)
+	for _, rule := range policy.GetRules() {
+		for _, from := range rule.GetFrom() {
+			if slices.Contains(from.GetSource().GetPrincipals(), principal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // detectAuthEnv will use the JWT token that is mounted in istiod to set the default audience
 // and trust domain for Istiod, if not explicitly defined.
 // K8S will use the same kind of tokens for the pods, and the value in istiod's own token is
@@ -335,6 +335,12 @@ func NewServer(args *PilotArgs, initFuncs ...func(*Server)) (*Server, error) {
 		if err := s.initConfigValidation(args); err != nil {
 			return nil, fmt.Errorf("error initializing config validator: %v", err)
 		}
+		if features.EnableAmbientControllers {
+			s.httpsMux.HandleFunc(xds.EndpointHealthPath, s.XDSServer.EndpointHealthHandler(args.Namespace))
+			if features.AmbientZtunnelSharding {
+				s.initZtunnelShards(args)
+			}
+		}
 	}
 
 	// This should be called only after controllers are initialized.
@@ -724,6 +730,7 @@ func (s *Server) initGrpcServer(options *istiokeepalive.Options) {
 		prometheus.UnaryServerInterceptor,
 	}
 	grpcOptions := istiogrpc.ServerOptions(options, interceptors...)
+	grpcOptions = append(grpcOptions, grpc.StatsHandler(xds.WorkloadCompressionStatsHandler{}))
 	s.grpcServer = grpc.NewServer(grpcOptions...)
 	s.XDSServer.Register(s.grpcServer)
 	reflection.Register(s.grpcServer)
@@ -770,7 +777,7 @@ func (s *Server) initSecureDiscoveryService(args *PilotArgs) error {
 		prometheus.UnaryServerInterceptor,
 	}
 	opts := istiogrpc.ServerOptions(args.KeepaliveOptions, interceptors...)
-	opts = append(opts, grpc.Creds(tlsCreds))
+	opts = append(opts, grpc.Creds(tlsCreds), grpc.StatsHandler(xds.WorkloadCompressionStatsHandler{}))
 
 	s.secureGrpcServer = grpc.NewServer(opts...)
 	s.XDSServer.Register(s.secureGrpcServer)
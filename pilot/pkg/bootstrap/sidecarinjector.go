@@ -20,11 +20,13 @@ import (
 	"os"
 	"path/filepath"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"istio.io/istio/pilot/pkg/features"
 	"istio.io/istio/pkg/kube/inject"
+	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/webhooks"
 	"istio.io/pkg/env"
 	"istio.io/pkg/log"
@@ -81,6 +83,13 @@ func (s *Server) initSidecarInjector(args *PilotArgs) (*inject.Webhook, error) {
 		Mux:      s.httpsMux,
 		Revision: args.Revision,
 	}
+	if features.EnableAmbientControllers && s.kubeClient != nil {
+		parameters.KubeClient = s.kubeClient
+		parameters.Namespaces = kclient.New[*corev1.Namespace](s.kubeClient)
+		parameters.AmbientNamespacePolicy = inject.AmbientNamespacePolicy(features.AmbientNamespaceInjectionPolicy)
+		parameters.AmbientReadinessGate = features.AmbientReadinessGate
+		parameters.AmbientDrainPeriod = features.AmbientDrainPeriod
+	}
 
 	wh, err := inject.NewWebhook(parameters)
 	if err != nil {
@@ -15,8 +15,11 @@
 package bootstrap
 
 import (
+	corev1 "k8s.io/api/core/v1"
+
 	"istio.io/istio/pilot/pkg/features"
 	"istio.io/istio/pkg/config/schema/collections"
+	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/webhooks/validation/controller"
 	"istio.io/istio/pkg/webhooks/validation/server"
 	"istio.io/pkg/log"
@@ -34,6 +37,9 @@ func (s *Server) initConfigValidation(args *PilotArgs) error {
 		DomainSuffix: args.RegistryOptions.KubeOptions.DomainSuffix,
 		Mux:          s.httpsMux,
 	}
+	if features.EnableAmbientControllers {
+		params.Namespaces = kclient.New[*corev1.Namespace](s.kubeClient)
+	}
 	_, err := server.New(params)
 	if err != nil {
 		return err
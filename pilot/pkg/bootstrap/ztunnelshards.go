@@ -0,0 +1,44 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bootstrap
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+
+	"istio.io/api/label"
+	kubecontroller "istio.io/istio/pilot/pkg/serviceregistry/kube/controller"
+	"istio.io/istio/pkg/kube/kclient"
+)
+
+// initZtunnelShards shards the ztunnels across the ready istiod replicas of the revision.
+func (s *Server) initZtunnelShards(args *PilotArgs) {
+	revision := args.Revision
+	if revision == "" {
+		revision = "default"
+	}
+	pods := kclient.NewFiltered[*corev1.Pod](s.kubeClient, kclient.Filter{
+		LabelSelector: "app=istiod," + label.IoIstioRev.Name + "=" + revision,
+	})
+	s.XDSServer.ShardZtunnels(args.PodName, func() []string {
+		var replicas []string
+		for _, p := range pods.List(args.Namespace, klabels.Everything()) {
+			if p.DeletionTimestamp == nil && kubecontroller.IsPodReady(p) {
+				replicas = append(replicas, p.Name)
+			}
+		}
+		return replicas
+	})
+}
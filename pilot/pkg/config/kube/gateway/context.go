@@ -124,6 +124,16 @@ func (gc GatewayContext) GetService(hostname, namespace string) *model.Service {
 	return gc.ps.ServiceIndex.HostnameAndNamespace[host.Name(hostname)][namespace]
 }
 
+// WaypointWorkloads returns the names of the workloads served by a waypoint of the given namespace. The waypoint serves
+// the workloads of a single service account, or of the whole namespace if serviceAccount is empty.
+func (gc GatewayContext) WaypointWorkloads(namespace, serviceAccount string) []string {
+	res := sets.New[string]()
+	for _, wl := range gc.ps.WorkloadsForWaypoint(model.WaypointScope{Namespace: namespace, ServiceAccount: serviceAccount}) {
+		res.Insert(wl.Name)
+	}
+	return sets.SortedList(res)
+}
+
 func instancesEmpty(m map[int][]*model.ServiceInstance) bool {
 	for _, instances := range m {
 		if len(instances) > 0 {
@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 
 	"istio.io/istio/pilot/pkg/credentials"
 	"istio.io/istio/pilot/pkg/features"
@@ -84,6 +85,11 @@ type Controller struct {
 	statusController *status.Controller
 	statusEnabled    *atomic.Bool
 
+	// waypointWorkloads stores the workloads served by each waypoint Gateway as of the last reconcile, to emit
+	// events when workloads attach to or detach from waypoints. Access is guarded by waypointsMu.
+	waypointWorkloads map[types.NamespacedName]sets.String
+	waypointsMu       sync.Mutex
+
 	waitForCRD func(class config.GroupVersionKind, stop <-chan struct{}) bool
 }
 
@@ -222,6 +228,7 @@ func (c *Controller) Reconcile(ps *model.PushContext) error {
 
 	// Handle all status updates
 	c.QueueStatusUpdates(input)
+	c.reportWaypointEvents(input.Gateway, output.WaypointWorkloads)
 
 	c.stateMu.Lock()
 	defer c.stateMu.Unlock()
@@ -23,6 +23,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	k8s "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	k8sbeta "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -59,6 +60,7 @@ func convertResources(r GatewayResources) IstioResources {
 		GatewayResources:   r,
 		AllowedReferences:  convertReferencePolicies(r),
 		resourceReferences: make(map[model.ConfigKey][]model.ConfigKey),
+		waypointWorkloads:  make(map[types.NamespacedName]sets.String),
 	}
 
 	gw, gwMap, nsReferences := convertGateways(ctx)
@@ -79,6 +81,7 @@ func convertResources(r GatewayResources) IstioResources {
 	result.AllowedReferences = ctx.AllowedReferences
 	result.ReferencedNamespaceKeys = nsReferences
 	result.ResourceReferences = ctx.resourceReferences
+	result.WaypointWorkloads = ctx.waypointWorkloads
 	return result
 }
 
@@ -1209,6 +1212,8 @@ type configContext struct {
 
 	// key: referenced resources(e.g. secrets), value: gateway-api resources(e.g. gateways)
 	resourceReferences map[model.ConfigKey][]model.ConfigKey
+	// waypointWorkloads stores the workloads served by each waypoint Gateway
+	waypointWorkloads map[types.NamespacedName]sets.String
 }
 
 // parentInfo holds info about a "parent" - something that can be referenced as a ParentRef in the API.
@@ -1375,7 +1380,7 @@ func convertGateways(r configContext) ([]config.Config, map[parentKey][]*parentI
 			gwMap[ref] = gwMap[alias]
 		}
 
-		reportGatewayStatus(r, obj, gatewayServices, servers, skippedAddresses)
+		reportGatewayStatus(r, obj, controllerName, gatewayServices, servers, skippedAddresses)
 	}
 	// Insert a parent for Mesh references.
 	gwMap[meshParentKey] = []*parentInfo{
@@ -1417,6 +1422,7 @@ func getListenerNames(obj config.Config) sets.Set[k8s.SectionName] {
 func reportGatewayStatus(
 	r configContext,
 	obj config.Config,
+	controllerName k8s.GatewayController,
 	gatewayServices []string,
 	servers []*istio.Server,
 	skippedAddresses []string,
@@ -1464,6 +1470,9 @@ func reportGatewayStatus(
 			Message: msg,
 		}
 	}
+	if controllerName == constants.ManagedGatewayMeshController {
+		reportWaypointConditions(r, obj, gatewayConditions)
+	}
 	obj.Status.(*kstatus.WrappedStatus).Mutate(func(s config.Status) config.Status {
 		gs := s.(*k8s.GatewayStatus)
 		addressesToReport := external
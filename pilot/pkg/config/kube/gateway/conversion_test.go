@@ -402,6 +402,7 @@ func TestConvertResources(t *testing.T) {
 			output.AllowedReferences = AllowedReferences{} // Not tested here
 			output.ReferencedNamespaceKeys = nil           // Not tested here
 			output.ResourceReferences = nil                // Not tested here
+			output.WaypointWorkloads = nil                 // Not tested here
 
 			// sort virtual services to make the order deterministic
 			sort.Slice(output.VirtualService, func(i, j int) bool {
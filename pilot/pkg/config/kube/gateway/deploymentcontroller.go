@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/exp/maps"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,6 +29,7 @@ import (
 	klabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	gateway "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/yaml"
 
@@ -290,6 +292,13 @@ func (d *DeploymentController) configureIstioGateway(log *istiolog.Scope, gw gat
 	}
 	log.Info("reconciling")
 
+	deploymentPatch := gw.Annotations[gatewayDeploymentPatch]
+	if deploymentPatch != "" {
+		// The patch is applied to the rendered Deployment, rather than copied to it with the other annotations
+		gw.Annotations = maps.Clone(gw.Annotations)
+		delete(gw.Annotations, gatewayDeploymentPatch)
+	}
+
 	defaultName := getDefaultName(gw.Name, &gw.Spec)
 	input := TemplateInput{
 		Gateway:        &gw,
@@ -313,6 +322,13 @@ func (d *DeploymentController) configureIstioGateway(log *istiolog.Scope, gw gat
 	if err != nil {
 		return fmt.Errorf("failed to render template: %v", err)
 	}
+	if deploymentPatch != "" {
+		for i, t := range rendered {
+			if rendered[i], err = patchDeployment(t, deploymentPatch); err != nil {
+				return fmt.Errorf("invalid %s annotation: %v", gatewayDeploymentPatch, err)
+			}
+		}
+	}
 	for _, t := range rendered {
 		if err := d.apply(gi.controller, t); err != nil {
 			return fmt.Errorf("apply failed: %v", err)
@@ -407,6 +423,31 @@ func (d *DeploymentController) render(templateName string, mi TemplateInput) ([]
 	return yml.SplitString(results), nil
 }
 
+// patchDeployment applies a strategic merge patch to a rendered object, if it is a Deployment.
+func patchDeployment(object string, patch string) (string, error) {
+	original, err := yaml.YAMLToJSON([]byte(object))
+	if err != nil {
+		return "", err
+	}
+	meta := metav1.TypeMeta{}
+	if err := json.Unmarshal(original, &meta); err != nil {
+		return "", err
+	}
+	if meta.Kind != gvk.Deployment.Kind {
+		return object, nil
+	}
+	p, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return "", err
+	}
+	patched, err := strategicpatch.StrategicMergePatch(original, p, appsv1.Deployment{})
+	if err != nil {
+		return "", err
+	}
+	// JSON is valid YAML, so the patched Deployment is applied as is
+	return string(patched), nil
+}
+
 func (d *DeploymentController) setGatewayControllerVersion(gws gateway.Gateway) error {
 	patch := fmt.Sprintf(`{"apiVersion":"gateway.networking.k8s.io/v1beta1","kind":"Gateway","metadata":{"annotations":{"%s":"%d"}}}`,
 		ControllerVersionAnnotation, ControllerVersion)
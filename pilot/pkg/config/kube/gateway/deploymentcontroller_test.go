@@ -154,6 +154,42 @@ func TestConfigureIstioGateway(t *testing.T) {
 				},
 			},
 		},
+		{
+			"waypoint-patch",
+			v1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "namespace",
+					Namespace: "default",
+					Annotations: map[string]string{gatewayDeploymentPatch: `
+spec:
+  template:
+    spec:
+      nodeSelector:
+        pool: waypoints
+      tolerations:
+      - key: dedicated
+        operator: Equal
+        value: waypoints
+        effect: NoSchedule
+      containers:
+      - name: istio-proxy
+        resources:
+          requests:
+            cpu: "1"
+      - name: log-shipper
+        image: log-shipper:latest
+`},
+				},
+				Spec: v1beta1.GatewaySpec{
+					GatewayClassName: constants.WaypointGatewayClassName,
+					Listeners: []v1beta1.Listener{{
+						Name:     "mesh",
+						Port:     v1beta1.PortNumber(15008),
+						Protocol: "ALL",
+					}},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -16,6 +16,7 @@ package gateway
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
 	k8s "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	"istio.io/istio/pilot/pkg/credentials"
@@ -32,6 +33,9 @@ const (
 	gatewayTLSTerminateModeKey   = "gateway.istio.io/tls-terminate-mode"
 	gatewayNameOverride          = "gateway.istio.io/name-override"
 	gatewaySAOverride            = "gateway.istio.io/service-account"
+	// gatewayDeploymentPatch is a strategic merge patch, in YAML or JSON, applied to the Deployment of a managed
+	// Gateway, such as to set its resources, node selector or tolerations, or to add containers.
+	gatewayDeploymentPatch = "gateway.istio.io/deployment-patch"
 )
 
 // GatewayResources stores all gateway resources used for our conversion.
@@ -103,6 +107,9 @@ type IstioResources struct {
 	// determine if a resource update could have impacted any Gateways.
 	// key: referenced resources(e.g. secrets), value: gateway-api resources(e.g. gateways)
 	ResourceReferences map[model.ConfigKey][]model.ConfigKey
+
+	// WaypointWorkloads stores the names of the workloads served by each waypoint Gateway.
+	WaypointWorkloads map[types.NamespacedName]sets.String
 }
 
 // Reference stores a reference to a namespaced GVK, as used by ReferencePolicy
@@ -0,0 +1,146 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8sbeta "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/util/sets"
+)
+
+const (
+	// WaypointConditionAttached reports whether a waypoint Gateway serves any workloads.
+	WaypointConditionAttached = "gateway.istio.io/WaypointAttached"
+	// WaypointReasonAttached is the reason of the WaypointAttached condition when the waypoint serves workloads.
+	WaypointReasonAttached = "WorkloadsAttached"
+	// WaypointReasonNoWorkloads is the reason of the WaypointAttached condition when no workload uses the waypoint.
+	WaypointReasonNoWorkloads ConfigErrorReason = "NoWorkloads"
+	// WaypointReasonNotProgrammed is the reason of the WaypointAttached condition when the waypoint is not programmed,
+	// so it cannot serve its workloads.
+	WaypointReasonNotProgrammed ConfigErrorReason = "NotProgrammed"
+
+	// waypointEventAttached and waypointEventDetached are the reasons of the events emitted on a waypoint Gateway when
+	// workloads start or stop using it.
+	waypointEventAttached = "WaypointAttached"
+	waypointEventDetached = "WaypointDetached"
+)
+
+// reportWaypointConditions adds the WaypointAttached condition to the conditions of a waypoint Gateway, and records
+// the workloads it serves. A waypoint which is not programmed does not serve any workload, and reports why.
+func reportWaypointConditions(r configContext, obj config.Config, gatewayConditions map[string]*condition) {
+	sa := obj.Annotations[constants.WaypointServiceAccount]
+	scope := fmt.Sprintf("namespace %q", obj.Namespace)
+	if sa != "" {
+		scope = fmt.Sprintf("service account %q", sa)
+	}
+	attached := &condition{}
+	gatewayConditions[WaypointConditionAttached] = attached
+
+	var workloads []string
+	if err := gatewayConditions[string(k8sbeta.GatewayConditionProgrammed)].error; err != nil {
+		attached.error = &ConfigError{
+			Reason:  WaypointReasonNotProgrammed,
+			Message: fmt.Sprintf("Waypoint does not serve the workloads of %s: %s", scope, err.Message),
+		}
+	} else {
+		workloads = r.Context.WaypointWorkloads(obj.Namespace, sa)
+		if len(workloads) == 0 {
+			attached.error = &ConfigError{
+				Reason:  WaypointReasonNoWorkloads,
+				Message: fmt.Sprintf("No workloads of %s use the waypoint", scope),
+			}
+		} else {
+			attached.reason = WaypointReasonAttached
+			attached.message = fmt.Sprintf("Waypoint serves %d workload(s) of %s", len(workloads), scope)
+		}
+	}
+	r.waypointWorkloads[types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}] = sets.New(workloads...)
+}
+
+// reportWaypointEvents emits events on the waypoint Gateways whose workloads changed since the last reconcile.
+// Events are only emitted by the leader, but the workloads are always tracked, so a new leader only reports changes.
+func (c *Controller) reportWaypointEvents(gateways []config.Config, workloads map[types.NamespacedName]sets.String) {
+	c.waypointsMu.Lock()
+	previous, seeded := c.waypointWorkloads, c.waypointWorkloads != nil
+	c.waypointWorkloads = workloads
+	c.waypointsMu.Unlock()
+	if !seeded || !c.statusEnabled.Load() {
+		return
+	}
+
+	var events []*corev1.Event
+	for _, gw := range gateways {
+		name := types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}
+		cur, f := workloads[name]
+		if !f {
+			continue
+		}
+		old := previous[name]
+		if added := cur.Difference(old); len(added) > 0 {
+			events = append(events, waypointEvent(gw, waypointEventAttached,
+				fmt.Sprintf("Workload(s) %s attached to the waypoint", humanReadableJoin(sets.SortedList(added)))))
+		}
+		if removed := old.Difference(cur); len(removed) > 0 {
+			events = append(events, waypointEvent(gw, waypointEventDetached,
+				fmt.Sprintf("Workload(s) %s detached from the waypoint", humanReadableJoin(sets.SortedList(removed)))))
+		}
+	}
+	if len(events) == 0 {
+		return
+	}
+	// Events are created asynchronously, so the reconcile of the gateways is never blocked on the API server
+	go func() {
+		for _, event := range events {
+			if _, err := c.client.Kube().CoreV1().Events(event.Namespace).Create(context.Background(), event, metav1.CreateOptions{}); err != nil {
+				log.Warnf("failed to create %s event for waypoint %s/%s: %v", event.Reason, event.Namespace, event.InvolvedObject.Name, err)
+			}
+		}
+	}()
+}
+
+func waypointEvent(gw config.Config, reason, msg string) *corev1.Event {
+	now := metav1.Now()
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			// Named like the events of client-go recorders
+			Name:      fmt.Sprintf("%s.%x", gw.Name, now.UnixNano()),
+			Namespace: gw.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      gvk.KubernetesGateway.GroupVersion(),
+			Kind:            gvk.KubernetesGateway.Kind,
+			Name:            gw.Name,
+			Namespace:       gw.Namespace,
+			UID:             types.UID(gw.UID),
+			ResourceVersion: gw.ResourceVersion,
+		},
+		Reason:         reason,
+		Message:        msg,
+		Type:           corev1.EventTypeNormal,
+		Source:         corev1.EventSource{Component: "istiod"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+}
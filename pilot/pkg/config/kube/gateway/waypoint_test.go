@@ -0,0 +1,80 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gateway
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube/controller"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/schema/collections"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/util/sets"
+)
+
+func TestWaypointEvents(t *testing.T) {
+	clientSet := kube.NewFakeClient()
+	store := memory.NewController(memory.Make(collections.All))
+	c := NewController(clientSet, store, AlwaysReady, nil, controller.Options{})
+	c.statusEnabled.Store(true)
+
+	gateways := []config.Config{{
+		Meta: config.Meta{GroupVersionKind: gvk.KubernetesGateway, Name: "waypoint", Namespace: "ns1", UID: "uid"},
+	}}
+	name := types.NamespacedName{Namespace: "ns1", Name: "waypoint"}
+	events := func() []string {
+		l, err := clientSet.Kube().CoreV1().Events("ns1").List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := []string{}
+		for _, e := range l.Items {
+			assert.Equal(t, e.InvolvedObject.Name, "waypoint")
+			assert.Equal(t, string(e.InvolvedObject.UID), "uid")
+			res = append(res, e.Reason+": "+e.Message)
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	// The first reconcile only records the workloads, so restarts do not report all workloads again
+	c.reportWaypointEvents(gateways, map[types.NamespacedName]sets.String{name: sets.New("a")})
+	c.reportWaypointEvents(gateways, map[types.NamespacedName]sets.String{name: sets.New("a", "b", "c")})
+	assert.EventuallyEqual(t, events, []string{
+		"WaypointAttached: Workload(s) b and c attached to the waypoint",
+	})
+
+	c.reportWaypointEvents(gateways, map[types.NamespacedName]sets.String{name: sets.New("c", "d")})
+	assert.EventuallyEqual(t, events, []string{
+		"WaypointAttached: Workload(s) b and c attached to the waypoint",
+		"WaypointAttached: Workload(s) d attached to the waypoint",
+		"WaypointDetached: Workload(s) a and b detached from the waypoint",
+	})
+
+	// Only the leader emits events, but changes are still tracked
+	c.statusEnabled.Store(false)
+	c.reportWaypointEvents(gateways, map[types.NamespacedName]sets.String{name: sets.New[string]()})
+	c.statusEnabled.Store(true)
+	c.reportWaypointEvents(gateways, map[types.NamespacedName]sets.String{name: sets.New[string]()})
+	assert.Equal(t, len(events()), 3)
+}
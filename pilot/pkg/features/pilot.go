@@ -440,6 +440,29 @@ var (
 		return res
 	}()
 
+	CATrustDomainCertSigners = func() map[string]string {
+		signers := env.Register(
+			"CA_TRUST_DOMAIN_CERT_SIGNERS",
+			"",
+			"If set, a comma separated list of trustDomain=signer pairs. Certificates requested by node proxies on behalf of "+
+				"identities in the trust domain will be signed by the given signer, unless the request explicitly sets one. "+
+				"This allows ambient workload identities to be issued by an external CA, such as via Kubernetes CSR.",
+		).Get()
+		res := map[string]string{}
+		if signers == "" {
+			return res
+		}
+		for _, v := range strings.Split(signers, ",") {
+			td, signer, valid := strings.Cut(v, "=")
+			if !valid || td == "" || signer == "" {
+				log.Warnf("Invalid CA_TRUST_DOMAIN_CERT_SIGNERS, ignoring: %v", v)
+				continue
+			}
+			res[td] = signer
+		}
+		return res
+	}()
+
 	EnableServiceEntrySelectPods = env.Register("PILOT_ENABLE_SERVICEENTRY_SELECT_PODS", true,
 		"If enabled, service entries with selectors will select pods from the cluster. "+
 			"It is safe to disable it if you are quite sure you don't need this feature").Get()
@@ -569,6 +592,138 @@ var (
 		false,
 		"If enabled, controllers required for ambient will run. This is required to run ambient mesh.").Get()
 
+	AmbientConsistencyCheckInterval = env.Register(
+		"PILOT_AMBIENT_CONSISTENCY_CHECK_INTERVAL",
+		time.Minute,
+		"The interval at which the pods expected to be captured by ztunnel are compared with the enrollment reported by "+
+			"the CNI node agents. Divergence is reported with metrics and events. Set to 0 to disable the check. "+
+			"Only used if PILOT_ENABLE_AMBIENT_CONTROLLERS is enabled.").Get()
+
+	AmbientWorkloadLabels = func() sets.String {
+		v := env.Register(
+			"PILOT_AMBIENT_WORKLOAD_LABELS",
+			"",
+			"Comma separated list of pod labels, such as `app,version`, sent to ztunnel with the workload so they can be "+
+				"used for telemetry and authorization. Other labels are not sent, to limit the size of workloads.").Get()
+		res := sets.New[string]()
+		for _, l := range strings.Split(v, ",") {
+			if l = strings.TrimSpace(l); l != "" {
+				res.Insert(l)
+			}
+		}
+		return res
+	}()
+
+	AmbientTelemetryExcludedLabels = func() sets.String {
+		v := env.Register(
+			"PILOT_AMBIENT_TELEMETRY_EXCLUDED_LABELS",
+			"",
+			"Comma separated list of labels of the standard metrics, without their source_ or destination_ prefix, which "+
+				"ztunnel reports as unknown to limit the cardinality of metrics in large meshes. istiod does not send the "+
+				"workload fields backing them to ztunnel. Supported labels are canonical_revision, workload and service.").Get()
+		res := sets.New[string]()
+		for _, l := range strings.Split(v, ",") {
+			if l = strings.TrimSpace(l); l != "" {
+				res.Insert(l)
+			}
+		}
+		return res
+	}()
+
+	AmbientWorkloadCompression = env.Register(
+		"PILOT_AMBIENT_WORKLOAD_COMPRESSION",
+		"",
+		"Compression of the xDS responses sent to ztunnel, which carry the workloads of the mesh: gzip or zstd. "+
+			"Responses are only compressed if ztunnel advertises support for it. Compression is disabled if unset.").Get()
+
+	AmbientEgressWaypoint = env.Register(
+		"PILOT_AMBIENT_EGRESS_WAYPOINT",
+		"",
+		"The waypoint Gateway, as namespace/name, which ServiceEntries with wildcard hosts route through when they are not "+
+			"attached to a waypoint with the istio.io/use-waypoint label. If unset, ztunnel sends their traffic directly.").Get()
+
+	AmbientAutoAllocateAddresses = env.Register(
+		"PILOT_AMBIENT_AUTO_ALLOCATE_ADDRESSES",
+		false,
+		"If enabled, addresses are automatically allocated to the hosts of ServiceEntries without addresses, as the DNS "+
+			"proxy of sidecars does, and sent to ztunnel as the virtual IPs of their egress workloads. This lets ztunnel "+
+			"distinguish the traffic to several external hosts resolved with DNS on the same port.").Get()
+
+	AmbientNamespaceInjectionPolicy = env.Register(
+		"PILOT_AMBIENT_NAMESPACE_INJECTION_POLICY",
+		"warn",
+		"What the sidecar injector does with pods to inject in namespaces using ambient mode, which would otherwise run "+
+			"both a sidecar and ztunnel: `warn` injects them and records a warning event, `skip` does not inject them, "+
+			"and `reject` rejects the pods explicitly requesting injection and does not inject the others. "+
+			"Only used if PILOT_ENABLE_AMBIENT_CONTROLLERS is enabled.").Get()
+
+	AmbientReadinessGate = env.Register(
+		"PILOT_AMBIENT_READINESS_GATE",
+		false,
+		"If enabled, the sidecar injector adds the istio.io/ambient-ready readiness gate to the pods of namespaces using "+
+			"ambient mode which are not injected, so they are only ready once their traffic is redirected to ztunnel. "+
+			"Requires the ambient readiness gate of the CNI node agent, which releases it. "+
+			"Only used if PILOT_ENABLE_AMBIENT_CONTROLLERS is enabled.").Get()
+
+	AmbientDrainPeriod = env.Register(
+		"PILOT_AMBIENT_DRAIN_PERIOD",
+		0*time.Second,
+		"If set, the sidecar injector adds a preStop hook sleeping for this period to the containers of the pods of "+
+			"namespaces using ambient mode which are not injected, and extends their termination grace period by as "+
+			"much, so ztunnel drains their connections before they stop. The containers need a sleep binary, and "+
+			"containers with a preStop hook are left untouched. "+
+			"Only used if PILOT_ENABLE_AMBIENT_CONTROLLERS is enabled.").Get()
+
+	AmbientPassiveHealthFailures = env.Register(
+		"PILOT_AMBIENT_PASSIVE_HEALTH_FAILURES",
+		0,
+		"The number of consecutive connection failures to a workload reported by ztunnel, without any connection "+
+			"established in between, after which the workload is sent to ztunnel as unhealthy so it no longer receives "+
+			"traffic, for PILOT_AMBIENT_PASSIVE_HEALTH_EJECTION_TIME. Set to 0 to ignore the reports of ztunnel. "+
+			"Only used if PILOT_ENABLE_AMBIENT_CONTROLLERS is enabled.").Get()
+
+	AmbientPassiveHealthEjectionTime = env.Register(
+		"PILOT_AMBIENT_PASSIVE_HEALTH_EJECTION_TIME",
+		30*time.Second,
+		"How long a workload is sent to ztunnel as unhealthy once ztunnel reported persistent connection failures to "+
+			"it, before it receives traffic again.").Get()
+
+	AmbientPassiveHealthQuorum = env.Register(
+		"PILOT_AMBIENT_PASSIVE_HEALTH_QUORUM",
+		2,
+		"The number of ztunnels which must each report PILOT_AMBIENT_PASSIVE_HEALTH_FAILURES consecutive connection "+
+			"failures to a workload before it is sent to ztunnel as unhealthy, so a single ztunnel cannot eject "+
+			"workloads. Set to 1 in clusters with a single node.").Get()
+
+	AmbientPassiveHealthMaxEjected = env.Register(
+		"PILOT_AMBIENT_PASSIVE_HEALTH_MAX_EJECTED",
+		10,
+		"The maximum number of workloads sent to ztunnel as unhealthy at once by passive health checking, so a "+
+			"widespread failure, such as a network partition, does not eject most workloads. Ejections are tracked by "+
+			"each istiod replica from the reports of the ztunnels connected to it, so the limit applies per replica. Set to 0 for no limit.").Get()
+
+	AmbientZtunnelSharding = env.Register(
+		"PILOT_AMBIENT_ZTUNNEL_SHARDING",
+		false,
+		"If enabled, the ztunnels are sharded across the ready istiod replicas of the revision by consistent hashing of "+
+			"their node, and istiod asks the ztunnels connecting to another replica than theirs to reconnect. Each "+
+			"replica then serves a stable subset of the nodes, and only the nodes of added or removed replicas move. "+
+			"Only used if PILOT_ENABLE_AMBIENT_CONTROLLERS is enabled.").Get()
+
+	AmbientReadinessFastPush = env.Register(
+		"PILOT_AMBIENT_READINESS_FAST_PUSH",
+		true,
+		"If enabled, the workloads whose pods became ready or unready are pushed to ztunnel after "+
+			"PILOT_AMBIENT_READINESS_PUSH_WINDOW, rather than after PILOT_DEBOUNCE_AFTER, so ztunnel stops sending "+
+			"traffic to unready pods sooner.").Get()
+
+	AmbientReadinessPushWindow = env.Register(
+		"PILOT_AMBIENT_READINESS_PUSH_WINDOW",
+		100*time.Millisecond,
+		"The window the readiness changes of ambient workloads are coalesced in, and pushed at most once per, when "+
+			"PILOT_AMBIENT_READINESS_FAST_PUSH is enabled, so a rollout or a node failure changing the readiness of many "+
+			"pods does not trigger a push per pod.").Get()
+
 	// EnableUnsafeAssertions enables runtime checks to test assertions in our code. This should never be enabled in
 	// production; when assertions fail Istio will panic.
 	EnableUnsafeAssertions = env.Register(
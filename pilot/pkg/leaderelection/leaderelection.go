@@ -54,6 +54,8 @@ const (
 	// * This type is per-revision, so it is higher cost. Leases are cheaper
 	// * Other types use "prioritized leader election", which isn't implemented for Lease
 	GatewayDeploymentController = "istio-gateway-deployment"
+	// AmbientConsistencyController compares the ambient capture expected by istiod with the node agent reports.
+	AmbientConsistencyController = "istio-ambient-consistency-leader"
 )
 
 // Leader election key prefix for remote istiod managed clusters
@@ -321,6 +321,10 @@ type Proxy struct {
 	// of configuration.
 	XdsResourceGenerator XdsResourceGenerator
 
+	// WorkloadAPIVersion is the version of the workload API the workloads are sent to ztunnel with, negotiated at
+	// connect time from its metadata.
+	WorkloadAPIVersion int
+
 	// WatchedResources contains the list of watched resources for the proxy, keyed by the DiscoveryRequest TypeUrl.
 	WatchedResources map[string]*WatchedResource
 
@@ -616,6 +620,10 @@ type NodeMetadata struct {
 	// Generator indicates the client wants to use a custom Generator plugin.
 	Generator string `json:"GENERATOR,omitempty"`
 
+	// WorkloadAPIVersion is the version of the workload API supported by ztunnel. Ztunnels which do not set it
+	// are served the current version.
+	WorkloadAPIVersion string `json:"WORKLOAD_API_VERSION,omitempty"`
+
 	// DNSCapture indicates whether the workload has enabled dns capture
 	DNSCapture StringBool `json:"DNS_CAPTURE,omitempty"`
 
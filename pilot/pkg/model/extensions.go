@@ -15,6 +15,7 @@
 package model
 
 import (
+	"fmt"
 	"net/url"
 	"strings"
 	"time"
@@ -32,7 +33,9 @@ import (
 	istionetworking "istio.io/istio/pilot/pkg/networking"
 	"istio.io/istio/pilot/pkg/util/protoconv"
 	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
 	"istio.io/istio/pkg/config/labels"
+	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/util/protomarshal"
 )
 
@@ -70,12 +73,21 @@ type WasmPluginWrapper struct {
 	Name         string
 	Namespace    string
 	ResourceName string
+	// TargetGateway is the name of the Gateway of the namespace the plugin is attached to by its target-ref
+	// annotation, rather than by its selector.
+	TargetGateway string
 
 	WasmExtensionConfig *envoyWasmFilterV3.Wasm
 }
 
 func (p *WasmPluginWrapper) MatchListener(proxyLabels map[string]string, li WasmPluginListenerInfo) bool {
-	workloadMatch := (p.Selector == nil || labels.Instance(p.Selector.MatchLabels).SubsetOf(proxyLabels))
+	var workloadMatch bool
+	if p.TargetGateway != "" {
+		// The proxies deployed for a Gateway, such as waypoints, are labeled with its name
+		workloadMatch = proxyLabels[constants.GatewayNameLabel] == p.TargetGateway
+	} else {
+		workloadMatch = p.Selector == nil || labels.Instance(p.Selector.MatchLabels).SubsetOf(proxyLabels)
+	}
 	return workloadMatch && matchTrafficSelectors(p.Match, li)
 }
 
@@ -148,6 +160,12 @@ func convertToWasmPluginWrapper(originPlugin config.Config) *WasmPluginWrapper {
 		})
 	}
 
+	targetGateway, err := wasmPluginTargetGateway(plugin.Annotations)
+	if err != nil {
+		log.Warnf("wasmplugin %v/%v discarded due to invalid %s annotation: %s", plugin.Namespace, plugin.Name, constants.TargetRef, err)
+		return nil
+	}
+
 	u, err := url.Parse(wasmPlugin.Url)
 	if err != nil {
 		log.Warnf("wasmplugin %v/%v discarded due to failure to parse URL: %s", plugin.Namespace, plugin.Name, err)
@@ -177,11 +195,26 @@ func convertToWasmPluginWrapper(originPlugin config.Config) *WasmPluginWrapper {
 		Name:                plugin.Name,
 		Namespace:           plugin.Namespace,
 		ResourceName:        resourceName,
+		TargetGateway:       targetGateway,
 		WasmPlugin:          wasmPlugin,
 		WasmExtensionConfig: wasmExtensionConfig,
 	}
 }
 
+// wasmPluginTargetGateway returns the name of the Gateway the plugin is attached to by its target-ref annotation, or
+// an empty string if it is not set.
+func wasmPluginTargetGateway(annotations map[string]string) (string, error) {
+	ref, f := annotations[constants.TargetRef]
+	if !f {
+		return "", nil
+	}
+	k, name, ok := strings.Cut(ref, "/")
+	if !ok || k != gvk.KubernetesGateway.Kind || name == "" {
+		return "", fmt.Errorf("expected Gateway/<name>, got %q", ref)
+	}
+	return name, nil
+}
+
 // toSecretResourceName converts a imagePullSecret to a resource name referenced at Wasm SDS.
 // NOTE: the secret referenced by WasmPlugin has to be in the same namespace as the WasmPlugin,
 // so this function makes sure that the secret resource name, which will be used to retrieve secret at
@@ -28,6 +28,7 @@ import (
 	"istio.io/istio/pilot/pkg/model/credentials"
 	"istio.io/istio/pilot/pkg/networking"
 	"istio.io/istio/pkg/cluster"
+	"istio.io/istio/pkg/config/constants"
 	"istio.io/istio/pkg/test/util/assert"
 )
 
@@ -468,6 +469,36 @@ func TestMatchListener(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			desc:        "target gateway",
+			wasmPlugin:  &WasmPluginWrapper{TargetGateway: "waypoint", WasmPlugin: &extensions.WasmPlugin{}},
+			proxyLabels: map[string]string{constants.GatewayNameLabel: "waypoint"},
+			listenerInfo: WasmPluginListenerInfo{
+				Port:  1234,
+				Class: networking.ListenerClassSidecarInbound,
+			},
+			want: true,
+		},
+		{
+			desc:        "mismatched target gateway",
+			wasmPlugin:  &WasmPluginWrapper{TargetGateway: "waypoint", WasmPlugin: &extensions.WasmPlugin{}},
+			proxyLabels: map[string]string{constants.GatewayNameLabel: "other", "a": "b"},
+			listenerInfo: WasmPluginListenerInfo{
+				Port:  1234,
+				Class: networking.ListenerClassSidecarInbound,
+			},
+			want: false,
+		},
+		{
+			desc:        "target gateway of a proxy without gateway",
+			wasmPlugin:  &WasmPluginWrapper{TargetGateway: "waypoint", WasmPlugin: &extensions.WasmPlugin{}},
+			proxyLabels: map[string]string{"a": "b"},
+			listenerInfo: WasmPluginListenerInfo{
+				Port:  1234,
+				Class: networking.ListenerClassSidecarInbound,
+			},
+			want: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -479,3 +510,22 @@ func TestMatchListener(t *testing.T) {
 		})
 	}
 }
+
+func TestWasmPluginTargetGateway(t *testing.T) {
+	cases := []struct {
+		annotations map[string]string
+		want        string
+		wantErr     bool
+	}{
+		{annotations: nil, want: ""},
+		{annotations: map[string]string{constants.TargetRef: "Gateway/waypoint"}, want: "waypoint"},
+		{annotations: map[string]string{constants.TargetRef: "waypoint"}, wantErr: true},
+		{annotations: map[string]string{constants.TargetRef: "Service/waypoint"}, wantErr: true},
+		{annotations: map[string]string{constants.TargetRef: "Gateway/"}, wantErr: true},
+	}
+	for _, tc := range cases {
+		got, err := wasmPluginTargetGateway(tc.annotations)
+		assert.Equal(t, err != nil, tc.wantErr)
+		assert.Equal(t, got, tc.want)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	authpb "istio.io/api/security/v1beta1"
+	"istio.io/istio/pkg/config/labels"
+)
+
+// PolicySelection is a policy or route, with whether it is applied to a proxy and why. It is only computed on demand,
+// to debug why a policy is not applied.
+type PolicySelection struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Selected  bool   `json:"selected"`
+	Reason    string `json:"reason"`
+}
+
+// SelectorMismatch returns why the selector does not select a workload with the labels, or "" if it does.
+func SelectorMismatch(selector, workload labels.Instance) string {
+	keys := make([]string, 0, len(selector))
+	for k := range selector {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var res []string
+	for _, k := range keys {
+		v, f := workload[k]
+		if !f {
+			res = append(res, fmt.Sprintf("label %s is not set", k))
+		} else if v != selector[k] {
+			res = append(res, fmt.Sprintf("label %s is %q, not %q", k, v, selector[k]))
+		}
+	}
+	if len(res) == 0 {
+		return ""
+	}
+	return "selector does not match: " + strings.Join(res, ", ")
+}
+
+// noSelectorReason returns why a policy without selector applies to a proxy.
+func noSelectorReason(namespace, rootNamespace string) string {
+	if namespace == rootNamespace {
+		return "no selector in the root namespace, applies to all the workloads of the mesh"
+	}
+	return "no selector, applies to all the workloads of namespace " + namespace
+}
+
+// ExplainAuthorizationPolicies returns all the authorization policies, with whether they apply to the workload in the
+// given namespace and why, following ListAuthorizationPolicies.
+func (policy *AuthorizationPolicies) ExplainAuthorizationPolicies(namespace string, workload labels.Instance) []PolicySelection {
+	if policy == nil {
+		return nil
+	}
+	namespaces := make([]string, 0, len(policy.NamespaceToPolicies))
+	for ns := range policy.NamespaceToPolicies {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	var res []PolicySelection
+	for _, ns := range namespaces {
+		for _, config := range policy.NamespaceToPolicies[ns] {
+			s := PolicySelection{Name: config.Name, Namespace: config.Namespace}
+			selector := labels.Instance(config.Spec.GetSelector().GetMatchLabels())
+			switch {
+			case ns != policy.RootNamespace && ns != namespace:
+				s.Reason = fmt.Sprintf("policies only apply to the workloads of their namespace, or of the mesh in the root namespace %s",
+					policy.RootNamespace)
+			case SelectorMismatch(selector, workload) != "":
+				s.Reason = SelectorMismatch(selector, workload)
+			case !supportedAction(config.Spec.GetAction()):
+				s.Reason = fmt.Sprintf("unsupported action %s", config.Spec.GetAction())
+			case len(selector) == 0:
+				s.Selected, s.Reason = true, noSelectorReason(ns, policy.RootNamespace)
+			default:
+				s.Selected, s.Reason = true, "selector matches"
+			}
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func supportedAction(action authpb.AuthorizationPolicy_Action) bool {
+	switch action {
+	case authpb.AuthorizationPolicy_ALLOW, authpb.AuthorizationPolicy_DENY, authpb.AuthorizationPolicy_AUDIT,
+		authpb.AuthorizationPolicy_CUSTOM:
+		return true
+	}
+	return false
+}
+
+// ExplainTelemetries returns all the Telemetries, with whether they apply to the proxy and why, following
+// applicableTelemetries.
+func (t *Telemetries) ExplainTelemetries(proxy *Proxy) []PolicySelection {
+	if t == nil {
+		return nil
+	}
+	namespaces := make([]string, 0, len(t.NamespaceToTelemetries))
+	for ns := range t.NamespaceToTelemetries {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	var res []PolicySelection
+	for _, ns := range namespaces {
+		// Only the first Telemetry without selector of the namespace, and the first one selecting the proxy, apply
+		namespaceWide := t.namespaceWideTelemetryConfig(ns)
+		workload := ""
+		for _, telemetry := range t.NamespaceToTelemetries[ns] {
+			s := PolicySelection{Name: telemetry.Name, Namespace: telemetry.Namespace}
+			selector := labels.Instance(telemetry.Spec.GetSelector().GetMatchLabels())
+			switch {
+			case ns != t.RootNamespace && ns != proxy.ConfigNamespace:
+				s.Reason = fmt.Sprintf("Telemetries only apply to the workloads of their namespace, or of the mesh in the root namespace %s",
+					t.RootNamespace)
+			case len(selector) == 0 && telemetry.Name != namespaceWide.Name:
+				s.Reason = fmt.Sprintf("only the first Telemetry without selector of the namespace applies, %s takes precedence",
+					namespaceWide.Name)
+			case len(selector) == 0:
+				s.Selected, s.Reason = true, noSelectorReason(ns, t.RootNamespace)
+			case ns != proxy.ConfigNamespace:
+				s.Reason = "Telemetries with a selector only apply to the workloads of their namespace"
+			case SelectorMismatch(selector, proxy.Labels) != "":
+				s.Reason = SelectorMismatch(selector, proxy.Labels)
+			case workload != "":
+				s.Reason = fmt.Sprintf("only the first Telemetry selecting the workload applies, %s takes precedence", workload)
+			default:
+				workload = telemetry.Name
+				s.Selected, s.Reason = true, "selector matches"
+			}
+			res = append(res, s)
+		}
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	authpb "istio.io/api/security/v1beta1"
+	tpb "istio.io/api/telemetry/v1alpha1"
+	selectorpb "istio.io/api/type/v1beta1"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestExplainAuthorizationPolicies(t *testing.T) {
+	selector := func(l map[string]string) *selectorpb.WorkloadSelector {
+		return &selectorpb.WorkloadSelector{MatchLabels: l}
+	}
+	policies := &AuthorizationPolicies{
+		RootNamespace: "istio-system",
+		NamespaceToPolicies: map[string][]AuthorizationPolicy{
+			"istio-system": {{Name: "global", Namespace: "istio-system", Spec: &authpb.AuthorizationPolicy{}}},
+			"foo": {
+				{Name: "all", Namespace: "foo", Spec: &authpb.AuthorizationPolicy{}},
+				{Name: "waypoint", Namespace: "foo", Spec: &authpb.AuthorizationPolicy{
+					Selector: selector(map[string]string{"istio.io/gateway-name": "waypoint"}),
+				}},
+				{Name: "app", Namespace: "foo", Spec: &authpb.AuthorizationPolicy{
+					Selector: selector(map[string]string{"app": "a", "istio.io/gateway-name": "other"}),
+				}},
+			},
+			"bar": {{Name: "bar", Namespace: "bar", Spec: &authpb.AuthorizationPolicy{}}},
+		},
+	}
+	got := policies.ExplainAuthorizationPolicies("foo", map[string]string{"istio.io/gateway-name": "waypoint"})
+	assert.Equal(t, got, []PolicySelection{
+		{
+			Name: "bar", Namespace: "bar",
+			Reason: "policies only apply to the workloads of their namespace, or of the mesh in the root namespace istio-system",
+		},
+		{Name: "all", Namespace: "foo", Selected: true, Reason: "no selector, applies to all the workloads of namespace foo"},
+		{Name: "waypoint", Namespace: "foo", Selected: true, Reason: "selector matches"},
+		{
+			Name: "app", Namespace: "foo",
+			Reason: `selector does not match: label app is not set, label istio.io/gateway-name is "waypoint", not "other"`,
+		},
+		{
+			Name: "global", Namespace: "istio-system", Selected: true,
+			Reason: "no selector in the root namespace, applies to all the workloads of the mesh",
+		},
+	})
+}
+
+func TestExplainTelemetries(t *testing.T) {
+	selected := &tpb.Telemetry{Selector: &selectorpb.WorkloadSelector{MatchLabels: map[string]string{"app": "a"}}}
+	telemetries := &Telemetries{
+		RootNamespace: "istio-system",
+		NamespaceToTelemetries: map[string][]Telemetry{
+			"istio-system": {
+				{Name: "mesh", Namespace: "istio-system", Spec: &tpb.Telemetry{}},
+				{Name: "root-selector", Namespace: "istio-system", Spec: selected},
+			},
+			"foo": {
+				{Name: "first", Namespace: "foo", Spec: &tpb.Telemetry{}},
+				{Name: "second", Namespace: "foo", Spec: &tpb.Telemetry{}},
+				{Name: "workload", Namespace: "foo", Spec: selected},
+				{Name: "shadowed", Namespace: "foo", Spec: selected},
+			},
+		},
+	}
+	got := telemetries.ExplainTelemetries(&Proxy{ConfigNamespace: "foo", Labels: map[string]string{"app": "a"}})
+	assert.Equal(t, got, []PolicySelection{
+		{Name: "first", Namespace: "foo", Selected: true, Reason: "no selector, applies to all the workloads of namespace foo"},
+		{
+			Name: "second", Namespace: "foo",
+			Reason: "only the first Telemetry without selector of the namespace applies, first takes precedence",
+		},
+		{Name: "workload", Namespace: "foo", Selected: true, Reason: "selector matches"},
+		{
+			Name: "shadowed", Namespace: "foo",
+			Reason: "only the first Telemetry selecting the workload applies, workload takes precedence",
+		},
+		{
+			Name: "mesh", Namespace: "istio-system", Selected: true,
+			Reason: "no selector in the root namespace, applies to all the workloads of the mesh",
+		},
+		{
+			Name: "root-selector", Namespace: "istio-system",
+			Reason: "Telemetries with a selector only apply to the workloads of their namespace",
+		},
+	})
+}
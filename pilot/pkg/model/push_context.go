@@ -403,6 +403,8 @@ const (
 	GlobalUpdate TriggerReason = "global"
 	// AmbientUpdate describes a push triggered by a change to ambient mesh config
 	AmbientUpdate TriggerReason = "ambient"
+	// AmbientReadinessUpdate describes a push triggered by ambient workloads becoming ready or unready
+	AmbientReadinessUpdate TriggerReason = "ambientreadiness"
 	// UnknownTrigger describes a push triggered by an unknown reason
 	UnknownTrigger TriggerReason = "unknown"
 	// DebugTrigger describes a push triggered for debugging
@@ -1200,6 +1202,8 @@ func (ps *PushContext) InitContext(env *Environment, oldPushContext *PushContext
 
 func (ps *PushContext) createNewContext(env *Environment) error {
 	ps.initServiceRegistry(env)
+	// Waypoint status depends on the ambient index, so it must be set before reconciling gateways
+	ps.initAmbient(env)
 
 	if err := ps.initKubernetesGateways(env); err != nil {
 		return err
@@ -1216,7 +1220,6 @@ func (ps *PushContext) createNewContext(env *Environment) error {
 	ps.initWasmPlugins(env)
 	ps.initEnvoyFilters(env)
 	ps.initGateways(env)
-	ps.initAmbient(env)
 
 	// Must be initialized in the end
 	ps.initSidecarScopes(env)
@@ -1274,6 +1277,8 @@ func (ps *PushContext) updateContext(
 		ps.serviceAccounts = oldPushContext.serviceAccounts
 	}
 
+	ps.initAmbient(env)
+
 	if servicesChanged || gatewayAPIChanged {
 		// Gateway status depends on services, so recompute if they change as well
 		if err := ps.initKubernetesGateways(env); err != nil {
@@ -1335,8 +1340,6 @@ func (ps *PushContext) updateContext(
 		ps.gatewayIndex = oldPushContext.gatewayIndex
 	}
 
-	ps.initAmbient(env)
-
 	// Must be initialized in the end
 	// Sidecars need to be updated if services, virtual services, destination rules, or the sidecar configs change
 	if servicesChanged || virtualServicesChanged || destinationRulesChanged || sidecarsChanged {
@@ -1837,6 +1840,10 @@ func (ps *PushContext) WasmPluginsByListenerInfo(proxy *Proxy, info WasmPluginLi
 		// if there is no workload selector, the config applies to all workloads
 		// if there is a workload selector, check for matching workload labels
 		for _, plugin := range ps.wasmPluginsByNamespace[ps.Mesh.RootNamespace] {
+			// Plugins attached to a Gateway only apply to the Gateway of their namespace
+			if plugin.TargetGateway != "" && proxy.ConfigNamespace != ps.Mesh.RootNamespace {
+				continue
+			}
 			if plugin.MatchListener(proxy.Labels, info) {
 				matchedPlugins[plugin.Phase] = append(matchedPlugins[plugin.Phase], plugin)
 			}
@@ -815,6 +815,7 @@ type AmbientIndexes interface {
 	Policies(requested sets.Set[ConfigKey]) []*workloadapi.Authorization
 	Waypoint(scope WaypointScope) []netip.Addr
 	WorkloadsForWaypoint(scope WaypointScope) []*WorkloadInfo
+	UncapturedWorkloads() []UncapturedWorkload
 }
 
 // NoopAmbientIndexes provides an implementation of AmbientIndexes that always returns nil, to easily "skip" it.
@@ -844,18 +845,40 @@ func (u NoopAmbientIndexes) WorkloadsForWaypoint(scope WaypointScope) []*Workloa
 	return nil
 }
 
+func (u NoopAmbientIndexes) UncapturedWorkloads() []UncapturedWorkload {
+	return nil
+}
+
 var _ AmbientIndexes = NoopAmbientIndexes{}
 
+// UncapturedWorkload describes a pod which is expected to be captured by ztunnel, as it is part of an ambient
+// enabled namespace, but has not been reported as enrolled by the CNI node agent.
+type UncapturedWorkload struct {
+	Name      string     `json:"name"`
+	Namespace string     `json:"namespace"`
+	Node      string     `json:"node"`
+	Address   string     `json:"address"`
+	ClusterID cluster.ID `json:"clusterId"`
+}
+
 type WorkloadInfo struct {
 	*workloadapi.Workload
 	// Labels for the workload. Note these are only used internally, not sent over XDS
 	Labels map[string]string
+	// Egress is set for workloads synthesized from ServiceEntry addresses, which route through an egress
+	// waypoint rather than being backed by a pod. Note this is only used internally, not sent over XDS
+	Egress bool
+	// EgressWaypoint is the waypoint Gateway an egress workload routes through, if any. Note this is only used
+	// internally, not sent over XDS
+	EgressWaypoint types.NamespacedName
 }
 
 func (i *WorkloadInfo) Clone() *WorkloadInfo {
 	return &WorkloadInfo{
-		Workload: proto.Clone(i).(*workloadapi.Workload),
-		Labels:   maps.Clone(i.Labels),
+		Workload:       proto.Clone(i).(*workloadapi.Workload),
+		Labels:         maps.Clone(i.Labels),
+		Egress:         i.Egress,
+		EgressWaypoint: i.EgressWaypoint,
 	}
 }
 
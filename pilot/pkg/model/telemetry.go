@@ -40,6 +40,7 @@ import (
 	tpb "istio.io/api/telemetry/v1alpha1"
 	"istio.io/istio/pilot/pkg/networking"
 	"istio.io/istio/pilot/pkg/util/protoconv"
+	"istio.io/istio/pkg/config"
 	"istio.io/istio/pkg/config/labels"
 	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/config/xds"
@@ -107,17 +108,21 @@ type metricsKey struct {
 
 // getTelemetries returns the Telemetry configurations for the given environment.
 func getTelemetries(env *Environment) *Telemetries {
+	return newTelemetries(env.List(gvk.Telemetry, NamespaceAll), env.Mesh())
+}
+
+// newTelemetries organizes the Telemetry configurations by namespace.
+func newTelemetries(cfgs []config.Config, mesh *meshconfig.MeshConfig) *Telemetries {
 	telemetries := &Telemetries{
 		NamespaceToTelemetries: map[string][]Telemetry{},
-		RootNamespace:          env.Mesh().GetRootNamespace(),
-		meshConfig:             env.Mesh(),
+		RootNamespace:          mesh.GetRootNamespace(),
+		meshConfig:             mesh,
 		computedMetricsFilters: map[metricsKey]any{},
 		computedLoggingConfig:  map[loggingKey][]LoggingConfig{},
 	}
 
-	fromEnv := env.List(gvk.Telemetry, NamespaceAll)
-	sortConfigByCreationTime(fromEnv)
-	for _, config := range fromEnv {
+	sortConfigByCreationTime(cfgs)
+	for _, config := range cfgs {
 		telemetry := Telemetry{
 			Name:      config.Name,
 			Namespace: config.Namespace,
@@ -0,0 +1,148 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+	tpb "istio.io/api/telemetry/v1alpha1"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/util/sets"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// ztunnelMetrics are the metrics ztunnel reports. ztunnel only proxies TCP, so the overrides of the other metrics
+// are not sent to it.
+var ztunnelMetrics = sets.New(
+	tpb.MetricSelector_TCP_OPENED_CONNECTIONS.String(),
+	tpb.MetricSelector_TCP_CLOSED_CONNECTIONS.String(),
+	tpb.MetricSelector_TCP_SENT_BYTES.String(),
+	tpb.MetricSelector_TCP_RECEIVED_BYTES.String(),
+)
+
+// ZtunnelTelemetry computes the telemetry ztunnel reports for the connections of a workload from the Telemetry
+// configurations, merged the same way as for sidecars. It returns nil if no metrics or access logging configuration
+// applies to the workload, in which case ztunnel reports its default telemetry. Tracing is not supported by ztunnel.
+func ZtunnelTelemetry(cfgs []config.Config, mesh *meshconfig.MeshConfig, namespace string, labels map[string]string) *workloadapi.Telemetry {
+	return NewZtunnelTelemetries(cfgs, mesh).ZtunnelTelemetry(namespace, labels)
+}
+
+// NewZtunnelTelemetries indexes the Telemetry configurations, so the telemetry of many workloads can be computed
+// from them without indexing them again for each.
+func NewZtunnelTelemetries(cfgs []config.Config, mesh *meshconfig.MeshConfig) *Telemetries {
+	return newTelemetries(cfgs, mesh)
+}
+
+// ZtunnelTelemetry computes the telemetry ztunnel reports for the connections of a workload, as ZtunnelTelemetry.
+func (t *Telemetries) ZtunnelTelemetry(namespace string, labels map[string]string) *workloadapi.Telemetry {
+	ct := t.applicableTelemetries(&Proxy{ConfigNamespace: namespace, Labels: labels})
+	if len(ct.Metrics) == 0 && len(ct.Logging) == 0 {
+		return nil
+	}
+
+	// ztunnel only exports its metrics to Prometheus, so only the configuration of a Prometheus provider applies.
+	var metrics *metricsConfig
+	if len(ct.Metrics) > 0 {
+		merged := mergeMetrics(ct.Metrics, t.meshConfig)
+		names := make([]string, 0, len(merged))
+		for name := range merged {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if _, ok := t.fetchProvider(name).GetProvider().(*meshconfig.MeshConfig_ExtensionProvider_Prometheus); ok {
+				mc := merged[name]
+				metrics = &mc
+				break
+			}
+		}
+	}
+
+	return &workloadapi.Telemetry{
+		Client: t.ztunnelModeTelemetry(ct.Logging, metrics, tpb.WorkloadMode_CLIENT),
+		Server: t.ztunnelModeTelemetry(ct.Logging, metrics, tpb.WorkloadMode_SERVER),
+	}
+}
+
+func (t *Telemetries) ztunnelModeTelemetry(logging []*computedAccessLogging, metrics *metricsConfig,
+	mode tpb.WorkloadMode,
+) *workloadapi.ModeTelemetry {
+	res := &workloadapi.ModeTelemetry{}
+	if metrics != nil {
+		mc := metrics.ServerMetrics
+		if mode == tpb.WorkloadMode_CLIENT {
+			mc = metrics.ClientMetrics
+		}
+		res.Metrics = ztunnelMetricsConfig(mc)
+	}
+	if len(logging) > 0 {
+		providers := []string{}
+		for name, spec := range mergeLogs(logging, t.meshConfig, mode) {
+			if spec.Disabled || t.fetchProvider(name) == nil {
+				continue
+			}
+			providers = append(providers, name)
+		}
+		sort.Strings(providers)
+		res.AccessLogging = &workloadapi.AccessLogging{Providers: providers}
+	}
+	return res
+}
+
+func ztunnelMetricsConfig(mc metricConfig) *workloadapi.MetricsConfig {
+	res := &workloadapi.MetricsConfig{Disabled: mc.Disabled}
+	for _, o := range mc.Overrides {
+		if !ztunnelMetrics.Contains(o.Name) {
+			continue
+		}
+		mo := &workloadapi.MetricOverride{Name: o.Name, Disabled: o.Disabled}
+		for _, tag := range o.Tags {
+			if tag.Remove {
+				mo.RemovedLabels = append(mo.RemovedLabels, tag.Name)
+				continue
+			}
+			// ztunnel does not evaluate CEL expressions, so only the values which are string literals are sent.
+			value, ok := stringLiteral(tag.Value)
+			if !ok {
+				continue
+			}
+			if mo.Labels == nil {
+				mo.Labels = map[string]string{}
+			}
+			mo.Labels[tag.Name] = value
+		}
+		res.Overrides = append(res.Overrides, mo)
+	}
+	return res
+}
+
+// stringLiteral returns the value of an expression which is a single or double quoted string literal.
+func stringLiteral(expr string) (string, bool) {
+	if len(expr) < 2 {
+		return "", false
+	}
+	quote := expr[0]
+	if (quote != '\'' && quote != '"') || expr[len(expr)-1] != quote {
+		return "", false
+	}
+	value := expr[1 : len(expr)-1]
+	for i := 0; i < len(value); i++ {
+		if value[i] == quote || value[i] == '\\' {
+			return "", false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,182 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	wrappers "google.golang.org/protobuf/types/known/wrapperspb"
+
+	tpb "istio.io/api/telemetry/v1alpha1"
+	"istio.io/api/type/v1beta1"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/mesh"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+func TestZtunnelTelemetry(t *testing.T) {
+	prometheus := &tpb.Telemetry{
+		Metrics: []*tpb.Metrics{{
+			Providers: []*tpb.ProviderRef{{Name: "prometheus"}},
+			Overrides: []*tpb.MetricsOverrides{
+				{
+					Match: &tpb.MetricSelector{MetricMatch: &tpb.MetricSelector_Metric{Metric: tpb.MetricSelector_TCP_SENT_BYTES}},
+					TagOverrides: map[string]*tpb.MetricsOverrides_TagOverride{
+						"source_version": {Operation: tpb.MetricsOverrides_TagOverride_REMOVE},
+						"team":           {Value: "'payments'"},
+						"path":           {Value: "request.url_path"},
+					},
+				},
+				{
+					// ztunnel does not report request metrics
+					Match:    &tpb.MetricSelector{MetricMatch: &tpb.MetricSelector_Metric{Metric: tpb.MetricSelector_REQUEST_COUNT}},
+					Disabled: &wrappers.BoolValue{Value: true},
+				},
+				{
+					Match: &tpb.MetricSelector{
+						MetricMatch: &tpb.MetricSelector_Metric{Metric: tpb.MetricSelector_TCP_OPENED_CONNECTIONS},
+						Mode:        tpb.WorkloadMode_SERVER,
+					},
+					Disabled: &wrappers.BoolValue{Value: true},
+				},
+			},
+		}},
+	}
+	stackdriver := &tpb.Telemetry{
+		Metrics: []*tpb.Metrics{{Providers: []*tpb.ProviderRef{{Name: "stackdriver"}}}},
+	}
+	disabledMetrics := &tpb.Telemetry{
+		Metrics: []*tpb.Metrics{{
+			Providers: []*tpb.ProviderRef{{Name: "prometheus"}},
+			Overrides: []*tpb.MetricsOverrides{{
+				Match:    &tpb.MetricSelector{MetricMatch: &tpb.MetricSelector_Metric{Metric: tpb.MetricSelector_ALL_METRICS}},
+				Disabled: &wrappers.BoolValue{Value: true},
+			}},
+		}},
+	}
+	logging := &tpb.Telemetry{
+		AccessLogging: []*tpb.AccessLogging{{Providers: []*tpb.ProviderRef{{Name: "envoy"}, {Name: "unknown"}}}},
+	}
+	serverLoggingDisabled := &tpb.Telemetry{
+		AccessLogging: []*tpb.AccessLogging{{
+			Match:    &tpb.AccessLogging_LogSelector{Mode: tpb.WorkloadMode_SERVER},
+			Disabled: &wrappers.BoolValue{Value: true},
+		}},
+	}
+	tracing := &tpb.Telemetry{
+		Tracing: []*tpb.Tracing{{Providers: []*tpb.ProviderRef{{Name: "zipkin"}}}},
+	}
+	workload := func(spec *tpb.Telemetry) *tpb.Telemetry {
+		spec = proto.Clone(spec).(*tpb.Telemetry)
+		spec.Selector = &v1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": "a"}}
+		return spec
+	}
+
+	tcpSentBytes := &workloadapi.MetricOverride{
+		Name:          "TCP_SENT_BYTES",
+		RemovedLabels: []string{"source_version"},
+		Labels:        map[string]string{"team": "payments"},
+	}
+	tests := []struct {
+		name   string
+		cfgs   []config.Config
+		labels map[string]string
+		want   *workloadapi.Telemetry
+	}{
+		{
+			name: "none",
+			want: nil,
+		},
+		{
+			name: "tracing only",
+			cfgs: []config.Config{newTelemetry("istio-system", tracing)},
+			want: nil,
+		},
+		{
+			name: "prometheus overrides",
+			cfgs: []config.Config{newTelemetry("default", prometheus)},
+			want: &workloadapi.Telemetry{
+				Client: &workloadapi.ModeTelemetry{Metrics: &workloadapi.MetricsConfig{
+					Overrides: []*workloadapi.MetricOverride{tcpSentBytes},
+				}},
+				Server: &workloadapi.ModeTelemetry{Metrics: &workloadapi.MetricsConfig{
+					Overrides: []*workloadapi.MetricOverride{{Name: "TCP_OPENED_CONNECTIONS", Disabled: true}, tcpSentBytes},
+				}},
+			},
+		},
+		{
+			name: "other metrics provider",
+			cfgs: []config.Config{newTelemetry("default", stackdriver)},
+			want: &workloadapi.Telemetry{Client: &workloadapi.ModeTelemetry{}, Server: &workloadapi.ModeTelemetry{}},
+		},
+		{
+			name: "metrics disabled in the root namespace",
+			cfgs: []config.Config{newTelemetry("istio-system", disabledMetrics)},
+			want: &workloadapi.Telemetry{
+				Client: &workloadapi.ModeTelemetry{Metrics: &workloadapi.MetricsConfig{Disabled: true}},
+				Server: &workloadapi.ModeTelemetry{Metrics: &workloadapi.MetricsConfig{Disabled: true}},
+			},
+		},
+		{
+			name: "access logging",
+			cfgs: []config.Config{newTelemetry("istio-system", logging)},
+			want: &workloadapi.Telemetry{
+				Client: &workloadapi.ModeTelemetry{AccessLogging: &workloadapi.AccessLogging{Providers: []string{"envoy"}}},
+				Server: &workloadapi.ModeTelemetry{AccessLogging: &workloadapi.AccessLogging{Providers: []string{"envoy"}}},
+			},
+		},
+		{
+			name:   "server access logging disabled for the workload",
+			cfgs:   []config.Config{newTelemetry("istio-system", logging), newTelemetry("default", workload(serverLoggingDisabled))},
+			labels: map[string]string{"app": "a"},
+			want: &workloadapi.Telemetry{
+				Client: &workloadapi.ModeTelemetry{AccessLogging: &workloadapi.AccessLogging{Providers: []string{"envoy"}}},
+				Server: &workloadapi.ModeTelemetry{AccessLogging: &workloadapi.AccessLogging{Providers: []string{}}},
+			},
+		},
+		{
+			name:   "workload not selected",
+			cfgs:   []config.Config{newTelemetry("default", workload(logging))},
+			labels: map[string]string{"app": "b"},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ZtunnelTelemetry(tt.cfgs, mesh.DefaultMeshConfig(), "default", tt.labels)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestStringLiteral(t *testing.T) {
+	for expr, want := range map[string]string{
+		"'a'":        "a",
+		`"a b"`:      "a b",
+		"''":         "",
+		"a":          "",
+		"'a":         "",
+		`'a"`:        "",
+		"'a' + 'b'":  "",
+		`'a\'b'`:     "",
+		"source.uid": "",
+	} {
+		got, ok := stringLiteral(expr)
+		assert.Equal(t, got, want)
+		assert.Equal(t, ok, want != "" || expr == "''")
+	}
+}
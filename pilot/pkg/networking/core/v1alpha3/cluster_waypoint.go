@@ -23,6 +23,7 @@ import (
 	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	http "github.com/envoyproxy/go-control-plane/envoy/extensions/upstreams/http/v3"
 	matcher "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -113,6 +114,75 @@ func (cb *ClusterBuilder) buildWaypointInboundVIPCluster(svc *model.Service, por
 	return localCluster
 }
 
+// `inbound-vip||hostname|port` for a ServiceEntry. Unlike in-mesh services, traffic is sent directly to the
+// ServiceEntry endpoints rather than tunneled, so the waypoint can act as an egress gateway.
+func (cb *ClusterBuilder) buildWaypointEgressVIPCluster(svc *model.Service, port model.Port, subset string) *MutableCluster {
+	clusterName := model.BuildSubsetKey(model.TrafficDirectionInboundVIP, subset, svc.Hostname, port.Port)
+
+	var lbEndpoints []*endpoint.LocalityLbEndpoints
+	discoveryType := convertResolution(cb.proxyType, svc)
+	switch discoveryType {
+	case cluster.Cluster_EDS:
+		// EDS for inbound VIP clusters is tunneled to pods, so inline the endpoints instead.
+		discoveryType = cluster.Cluster_STATIC
+		lbEndpoints = cb.buildLocalityLbEndpoints(cb.proxyView, svc, port.Port, nil)
+	case cluster.Cluster_STRICT_DNS, cluster.Cluster_LOGICAL_DNS:
+		lbEndpoints = cb.buildLocalityLbEndpoints(cb.proxyView, svc, port.Port, nil)
+	}
+	egressCluster := cb.buildDefaultCluster(clusterName, discoveryType, lbEndpoints, model.TrafficDirectionOutbound, &port, svc, nil)
+	if egressCluster == nil {
+		return nil
+	}
+	if discoveryType == cluster.Cluster_ORIGINAL_DST {
+		egressCluster.cluster.LbPolicy = cluster.Cluster_CLUSTER_PROVIDED
+	}
+	cb.applyWaypointEgressTLS(egressCluster, svc, &port)
+	return egressCluster
+}
+
+// applyWaypointEgressTLS originates TLS from the waypoint to the ServiceEntry endpoints, as configured by the
+// DestinationRule of the ServiceEntry. As the endpoints are outside of the mesh, only SIMPLE and MUTUAL TLS are
+// originated; the client certificate and CA of MUTUAL TLS may be fetched with SDS from the DestinationRule credentialName.
+func (cb *ClusterBuilder) applyWaypointEgressTLS(mc *MutableCluster, svc *model.Service, port *model.Port) {
+	proxy := cb.unsafeWaypointOnlyProxy
+	destRule := proxy.SidecarScope.DestinationRule(model.TrafficDirectionOutbound, proxy, svc.Hostname).GetRule()
+	if destRule == nil {
+		return
+	}
+	destinationRule := CastDestinationRule(destRule)
+	policy := MergeTrafficPolicy(nil, destinationRule.GetTrafficPolicy(), port)
+	_, _, _, tlsSettings := selectTrafficPolicyComponents(policy)
+	if tlsSettings == nil ||
+		(tlsSettings.Mode != networking.ClientTLSSettings_SIMPLE && tlsSettings.Mode != networking.ClientTLSSettings_MUTUAL) {
+		return
+	}
+	opts := &buildClusterOpts{
+		mesh:             cb.req.Push.Mesh,
+		mutable:          mc,
+		policy:           policy,
+		port:             port,
+		clusterMode:      DefaultClusterMode,
+		direction:        model.TrafficDirectionOutbound,
+		meshExternal:     svc.MeshExternal,
+		serviceRegistry:  svc.Attributes.ServiceRegistry,
+		serviceAccounts:  svc.ServiceAccounts,
+		isDrWithSelector: destinationRule.GetWorkloadSelector() != nil,
+	}
+	tlsContext, err := cb.buildUpstreamClusterTLSContext(opts, tlsSettings)
+	if err != nil {
+		log.Errorf("failed to build upstream TLS context for waypoint egress cluster %s: %v", mc.cluster.Name, err)
+		return
+	}
+	if tlsContext == nil {
+		return
+	}
+	mc.cluster.TransportSocket = &core.TransportSocket{
+		Name:       wellknown.TransportSocketTls,
+		ConfigType: &core.TransportSocket_TypedConfig{TypedConfig: protoconv.MessageToAny(tlsContext)},
+	}
+	mc.cluster.Metadata = util.AddConfigInfoMetadata(mc.cluster.Metadata, destRule.Meta)
+}
+
 // `inbound-vip|protocol|hostname|port`. EDS routing to the internal listener for each pod in the VIP.
 func (cb *ClusterBuilder) buildWaypointInboundVIP(svcs map[host.Name]*model.Service) []*cluster.Cluster {
 	clusters := []*cluster.Cluster{}
@@ -122,6 +192,14 @@ func (cb *ClusterBuilder) buildWaypointInboundVIP(svcs map[host.Name]*model.Serv
 			if port.Protocol == protocol.UDP {
 				continue
 			}
+			if svc.MeshExternal {
+				for _, subset := range []string{"tcp", "http"} {
+					if c := cb.buildWaypointEgressVIPCluster(svc, *port, subset); c != nil {
+						clusters = append(clusters, c.build())
+					}
+				}
+				continue
+			}
 			if port.Protocol.IsUnsupported() || port.Protocol.IsTCP() {
 				clusters = append(clusters, cb.buildWaypointInboundVIPCluster(svc, *port, "tcp").build())
 			}
@@ -0,0 +1,139 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha3
+
+import (
+	"testing"
+
+	tls "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/networking/util"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestWaypointEgressTLSOrigination(t *testing.T) {
+	serviceEntry := `
+apiVersion: networking.istio.io/v1alpha3
+kind: ServiceEntry
+metadata:
+  name: external
+  namespace: ns1
+spec:
+  hosts:
+  - example.com
+  ports:
+  - number: 80
+    name: http
+    protocol: HTTP
+  resolution: DNS
+---
+`
+	cases := []struct {
+		name            string
+		destinationRule string
+		// sdsResources are the names of the SDS secrets of the cluster, or nil if TLS is not originated
+		sdsResources []string
+		sni          string
+	}{
+		{
+			name: "no destination rule",
+		},
+		{
+			name: "simple",
+			destinationRule: `
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: external
+  namespace: ns1
+spec:
+  host: example.com
+  trafficPolicy:
+    tls:
+      mode: SIMPLE
+      sni: example.com
+`,
+			sdsResources: []string{},
+			sni:          "example.com",
+		},
+		{
+			name: "mutual with credentialName",
+			destinationRule: `
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: external
+  namespace: ns1
+spec:
+  host: example.com
+  trafficPolicy:
+    portLevelSettings:
+    - port:
+        number: 80
+      tls:
+        mode: MUTUAL
+        credentialName: client-credential
+        sni: example.com
+`,
+			sdsResources: []string{"kubernetes://client-credential"},
+			sni:          "example.com",
+		},
+		{
+			name: "istio mutual is not originated",
+			destinationRule: `
+apiVersion: networking.istio.io/v1alpha3
+kind: DestinationRule
+metadata:
+  name: external
+  namespace: ns1
+spec:
+  host: example.com
+  trafficPolicy:
+    tls:
+      mode: ISTIO_MUTUAL
+`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewConfigGenTest(t, TestOptions{ConfigString: serviceEntry + tt.destinationRule})
+			proxy := cg.SetupProxy(&model.Proxy{Type: model.Waypoint, ConfigNamespace: "ns1"})
+			cb := NewClusterBuilder(proxy, &model.PushRequest{Push: cg.PushContext()}, nil)
+			svc := cg.PushContext().ServiceForHostname(proxy, "example.com")
+			if svc == nil {
+				t.Fatal("service not found")
+			}
+
+			c := cb.buildWaypointEgressVIPCluster(svc, *svc.Ports[0], "http").build()
+			if tt.sdsResources == nil {
+				assert.Equal(t, c.TransportSocket, nil)
+				return
+			}
+			ctx := &tls.UpstreamTlsContext{}
+			if err := c.TransportSocket.GetTypedConfig().UnmarshalTo(ctx); err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, ctx.Sni, tt.sni)
+			resources := []string{}
+			for _, s := range ctx.CommonTlsContext.TlsCertificateSdsSecretConfigs {
+				resources = append(resources, s.Name)
+			}
+			assert.Equal(t, resources, tt.sdsResources)
+			assert.Equal(t, c.Metadata.FilterMetadata[util.IstioMetadataKey].Fields["config"].GetStringValue(),
+				"/apis/networking.istio.io/v1alpha3/namespaces/ns1/destination-rule/external")
+		})
+	}
+}
@@ -258,6 +258,10 @@ func (lb *ListenerBuilder) buildWaypointInternal(wls []WorkloadAndServices, svcs
 			// Workload IP filtering happens here.
 			ipRange := []*xds.CidrRange{}
 			for _, wlx := range wls {
+				if wlx.WorkloadInfo.Egress {
+					// Egress workloads are only reachable through their ServiceEntry VIP.
+					continue
+				}
 				addr, _ := netip.AddrFromSlice(wlx.WorkloadInfo.Address)
 				cidr := util.ConvertAddressToCidr(addr.String())
 				ipRange = append(ipRange, &xds.CidrRange{
@@ -515,7 +519,7 @@ func (lb *ListenerBuilder) translateRoute(
 	} else if in.DirectResponse != nil {
 		istio_route.ApplyDirectResponse(out, in.DirectResponse)
 	} else {
-		lb.routeDestination(out, in, authority, listenPort)
+		lb.routeDestination(out, virtualService, in, authority, listenPort)
 	}
 
 	out.Decorator = &route.Decorator{
@@ -529,7 +533,7 @@ func (lb *ListenerBuilder) translateRoute(
 	return out
 }
 
-func (lb *ListenerBuilder) routeDestination(out *route.Route, in *networking.HTTPRoute, authority string, listenerPort int) {
+func (lb *ListenerBuilder) routeDestination(out *route.Route, virtualService config.Config, in *networking.HTTPRoute, authority string, listenerPort int) {
 	policy := in.Retries
 	if policy == nil {
 		// No VS policy set, use mesh defaults
@@ -549,6 +553,11 @@ func (lb *ListenerBuilder) routeDestination(out *route.Route, in *networking.HTT
 	// nolint: staticcheck
 	action.MaxGrpcTimeout = action.Timeout
 
+	if model.UseGatewaySemantics(virtualService) {
+		// return 500 for invalid backends, as gateways do
+		action.ClusterNotFoundResponseCode = route.RouteAction_INTERNAL_SERVER_ERROR
+	}
+
 	out.Action = &route.Route_Route{Route: action}
 
 	if in.Rewrite != nil {
@@ -0,0 +1,62 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha3
+
+import (
+	"testing"
+
+	route "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestWaypointRouteDeniedBackend(t *testing.T) {
+	cg := NewConfigGenTest(t, TestOptions{})
+	proxy := cg.SetupProxy(&model.Proxy{Type: model.Waypoint, ConfigNamespace: "ns1"})
+	lb := NewListenerBuilder(proxy, cg.PushContext())
+
+	// The Gateway API controller converts the backends a route may not reference, without a ReferenceGrant, to
+	// destinations without host
+	httpRoute := &networking.HTTPRoute{
+		Name:  "denied",
+		Route: []*networking.HTTPRouteDestination{{Destination: &networking.Destination{}}},
+	}
+	virtualService := func(annotations map[string]string) config.Config {
+		return config.Config{
+			Meta: config.Meta{
+				GroupVersionKind: gvk.VirtualService,
+				Name:             "route",
+				Namespace:        "ns1",
+				Annotations:      annotations,
+			},
+			Spec: &networking.VirtualService{Hosts: []string{"a.ns2.svc.cluster.local"}, Http: []*networking.HTTPRoute{httpRoute}},
+		}
+	}
+
+	// As for gateways, requests to routes converted from Gateway API routes fail with a 500 on the missing cluster
+	out := lb.translateRoute(virtualService(map[string]string{constants.InternalRouteSemantics: constants.RouteSemanticsGateway}),
+		httpRoute, nil, 80)
+	assert.Equal(t, out.GetRoute().GetCluster(), "inbound-vip|80|http|")
+	assert.Equal(t, out.GetRoute().GetClusterNotFoundResponseCode(), route.RouteAction_INTERNAL_SERVER_ERROR)
+
+	// VirtualServices keep the default response code
+	out = lb.translateRoute(virtualService(nil), httpRoute, nil, 80)
+	assert.Equal(t, out.GetRoute().GetClusterNotFoundResponseCode(), route.RouteAction_SERVICE_UNAVAILABLE)
+}
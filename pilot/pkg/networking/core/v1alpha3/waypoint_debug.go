@@ -0,0 +1,195 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha3
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	k8s "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/labels"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/config/schema/kind"
+	"istio.io/istio/pkg/ptr"
+)
+
+// WaypointSelection holds the services served by a waypoint, and the authorization policies, Telemetries and
+// HTTPRoutes of the mesh, with whether they are applied by the waypoint and why. It is only computed on demand, to
+// debug why a policy is not applied by a waypoint.
+type WaypointSelection struct {
+	// Services are the hostnames of the services served by the waypoint, whose routes it applies.
+	Services              []string                `json:"services"`
+	AuthorizationPolicies []model.PolicySelection `json:"authorizationPolicies"`
+	Telemetries           []model.PolicySelection `json:"telemetries"`
+	HTTPRoutes            []model.PolicySelection `json:"httpRoutes"`
+}
+
+// ExplainWaypoint evaluates the selection of the policies and routes of the mesh by the waypoint, following the
+// generation of its configuration. routes are the HTTPRoutes of the mesh.
+func ExplainWaypoint(node *model.Proxy, push *model.PushContext, routes []config.Config) WaypointSelection {
+	svcs := findWaypointServices(node, push)
+	res := WaypointSelection{
+		Services:              make([]string, 0, len(svcs)),
+		AuthorizationPolicies: explainWaypointAuthorizationPolicies(node, push),
+		Telemetries:           push.Telemetry.ExplainTelemetries(node),
+	}
+	for h := range svcs {
+		res.Services = append(res.Services, string(h))
+	}
+	sort.Strings(res.Services)
+	var virtualServices []config.Config
+	if node.SidecarScope != nil && len(node.SidecarScope.EgressListeners) > 0 {
+		virtualServices = node.SidecarScope.EgressListeners[0].VirtualServices()
+	}
+	res.HTTPRoutes = explainWaypointRoutes(svcs, virtualServices, routes)
+	return res
+}
+
+// explainWaypointAuthorizationPolicies explains the selection of the authorization policies by the waypoint. Policies
+// are often written to select the workloads behind the waypoint rather than the waypoint itself, which is called out.
+func explainWaypointAuthorizationPolicies(node *model.Proxy, push *model.PushContext) []model.PolicySelection {
+	res := push.AuthzPolicies.ExplainAuthorizationPolicies(node.ConfigNamespace, node.Labels)
+	if len(res) == 0 {
+		return res
+	}
+	workloads := push.WorkloadsForWaypoint(node.WaypointScope())
+	for i, s := range res {
+		if s.Selected {
+			continue
+		}
+		var selector labels.Instance
+		for _, p := range push.AuthzPolicies.NamespaceToPolicies[s.Namespace] {
+			if p.Name == s.Name {
+				selector = p.Spec.GetSelector().GetMatchLabels()
+				break
+			}
+		}
+		if len(selector) == 0 {
+			continue
+		}
+		for _, wl := range workloads {
+			if s.Namespace != push.AuthzPolicies.RootNamespace && s.Namespace != wl.Namespace {
+				continue
+			}
+			if model.SelectorMismatch(selector, wl.Labels) == "" {
+				res[i].Reason = fmt.Sprintf("%s; it selects workload %s/%s behind the waypoint instead, so it is enforced by "+
+					"ztunnel, which only supports L4 rules", s.Reason, wl.Namespace, wl.Name)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// explainWaypointRoutes explains the selection of the HTTPRoutes by a waypoint serving the services. For each service,
+// the waypoint applies the routes of the first VirtualService for its hostname, which is generated from the HTTPRoutes
+// attached to the service in a namespace.
+func explainWaypointRoutes(svcs map[host.Name]*model.Service, virtualServices []config.Config, routes []config.Config) []model.PolicySelection {
+	hostnames := make([]string, 0, len(svcs))
+	for h := range svcs {
+		hostnames = append(hostnames, string(h))
+	}
+	sort.Strings(hostnames)
+	applied := map[types.NamespacedName][]string{}
+	shadowed := map[types.NamespacedName][]string{}
+	for _, h := range hostnames {
+		vss := getConfigsForHost(host.Name(h), virtualServices)
+		for i, vs := range vss {
+			for _, parent := range model.VirtualServiceDependencies(vs) {
+				if parent.Kind != kind.HTTPRoute {
+					continue
+				}
+				nn := types.NamespacedName{Namespace: parent.Namespace, Name: parent.Name}
+				if i == 0 {
+					applied[nn] = append(applied[nn], fmt.Sprintf("attached to service %s served by the waypoint", h))
+				} else {
+					shadowed[nn] = append(shadowed[nn], fmt.Sprintf("the routes of service %s are taken from %s, "+
+						"only the routes of one namespace are applied per service", h, routeOrigin(vss[0])))
+				}
+			}
+		}
+	}
+
+	sorted := make([]config.Config, len(routes))
+	copy(sorted, routes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	res := make([]model.PolicySelection, 0, len(sorted))
+	for _, r := range sorted {
+		nn := types.NamespacedName{Namespace: r.Namespace, Name: r.Name}
+		s := model.PolicySelection{Name: r.Name, Namespace: r.Namespace}
+		switch {
+		case len(applied[nn]) > 0:
+			s.Selected, s.Reason = true, strings.Join(applied[nn], "; ")
+		case len(shadowed[nn]) > 0:
+			s.Reason = strings.Join(shadowed[nn], "; ")
+		default:
+			s.Reason = unattachedRouteReason(r, svcs)
+		}
+		res = append(res, s)
+	}
+	return res
+}
+
+// routeOrigin describes the resources a VirtualService was generated from.
+func routeOrigin(vs config.Config) string {
+	if model.UseGatewaySemantics(vs) {
+		return vs.Annotations[constants.InternalParentNames]
+	}
+	return fmt.Sprintf("VirtualService %s/%s", vs.Namespace, vs.Name)
+}
+
+// unattachedRouteReason returns why an HTTPRoute is not applied to any of the services served by the waypoint.
+func unattachedRouteReason(r config.Config, svcs map[host.Name]*model.Service) string {
+	spec, ok := r.Spec.(*k8s.HTTPRouteSpec)
+	if !ok || len(spec.ParentRefs) == 0 {
+		return "no parentRefs"
+	}
+	var res []string
+	for _, p := range spec.ParentRefs {
+		k := ptr.OrDefault((*string)(p.Kind), gvk.KubernetesGateway.Kind)
+		group := ptr.OrEmpty((*string)(p.Group))
+		ns := ptr.OrDefault((*string)(p.Namespace), r.Namespace)
+		if k != gvk.Service.Kind || (group != gvk.Service.Group && group != gvk.KubernetesGateway.Group) {
+			res = append(res, fmt.Sprintf("parent %s %s/%s is not a Service, its routes are not applied by waypoints", k, ns, p.Name))
+			continue
+		}
+		served := false
+		for _, svc := range svcs {
+			if svc.Attributes.Name == string(p.Name) && svc.Attributes.Namespace == ns {
+				served = true
+				break
+			}
+		}
+		if served {
+			res = append(res, fmt.Sprintf("parent Service %s/%s is served by the waypoint, but the route is not visible to "+
+				"it: check the status of the HTTPRoute", ns, p.Name))
+		} else {
+			res = append(res, fmt.Sprintf("parent Service %s/%s is not served by the waypoint", ns, p.Name))
+		}
+	}
+	return strings.Join(res, "; ")
+}
@@ -0,0 +1,99 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha3
+
+import (
+	"testing"
+
+	k8s "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/ptr"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestExplainWaypointRoutes(t *testing.T) {
+	service := func(name string) *model.Service {
+		return &model.Service{
+			Hostname:   host.Name(name + ".foo.svc.cluster.local"),
+			Attributes: model.ServiceAttributes{Name: name, Namespace: "foo"},
+		}
+	}
+	svcs := map[host.Name]*model.Service{
+		"a.foo.svc.cluster.local": service("a"),
+		"b.foo.svc.cluster.local": service("b"),
+	}
+	virtualService := func(name, parents string) config.Config {
+		return config.Config{
+			Meta: config.Meta{
+				GroupVersionKind: gvk.VirtualService,
+				Name:             name,
+				Namespace:        "foo",
+				Annotations: map[string]string{
+					constants.InternalRouteSemantics: constants.RouteSemanticsGateway,
+					constants.InternalParentNames:    parents,
+				},
+			},
+			Spec: &networking.VirtualService{Hosts: []string{"a.foo.svc.cluster.local"}},
+		}
+	}
+	virtualServices := []config.Config{
+		virtualService("route-a-0", "HTTPRoute/route-a.foo"),
+		virtualService("other-0", "HTTPRoute/other.bar"),
+	}
+	route := func(name, ns string, parents ...k8s.ParentReference) config.Config {
+		return config.Config{
+			Meta: config.Meta{GroupVersionKind: gvk.HTTPRoute, Name: name, Namespace: ns},
+			Spec: &k8s.HTTPRouteSpec{CommonRouteSpec: k8s.CommonRouteSpec{ParentRefs: parents}},
+		}
+	}
+	serviceRef := func(name string) k8s.ParentReference {
+		return k8s.ParentReference{
+			Group:     ptr.Of(k8s.Group("")),
+			Kind:      ptr.Of(k8s.Kind("Service")),
+			Namespace: ptr.Of(k8s.Namespace("foo")),
+			Name:      k8s.ObjectName(name),
+		}
+	}
+	routes := []config.Config{
+		route("route-a", "foo", serviceRef("a")),
+		route("other", "bar", serviceRef("a")),
+		route("gateway", "foo", k8s.ParentReference{Name: "gateway"}),
+		route("hidden", "foo", serviceRef("b")),
+		route("elsewhere", "foo", serviceRef("c")),
+		route("none", "foo"),
+	}
+
+	assert.Equal(t, explainWaypointRoutes(svcs, virtualServices, routes), []model.PolicySelection{
+		{
+			Name: "other", Namespace: "bar",
+			Reason: "the routes of service a.foo.svc.cluster.local are taken from HTTPRoute/route-a.foo, " +
+				"only the routes of one namespace are applied per service",
+		},
+		{Name: "elsewhere", Namespace: "foo", Reason: "parent Service foo/c is not served by the waypoint"},
+		{Name: "gateway", Namespace: "foo", Reason: "parent Gateway foo/gateway is not a Service, its routes are not applied by waypoints"},
+		{
+			Name: "hidden", Namespace: "foo",
+			Reason: "parent Service foo/b is served by the waypoint, but the route is not visible to it: check the status of the HTTPRoute",
+		},
+		{Name: "none", Namespace: "foo", Reason: "no parentRefs"},
+		{Name: "route-a", Namespace: "foo", Selected: true, Reason: "attached to service a.foo.svc.cluster.local served by the waypoint"},
+	})
+}
@@ -78,6 +78,17 @@ func (c *Controller) WorkloadsForWaypoint(scope model.WaypointScope) []*model.Wo
 	return res
 }
 
+func (c *Controller) UncapturedWorkloads() []model.UncapturedWorkload {
+	if !features.EnableAmbientControllers {
+		return nil
+	}
+	var res []model.UncapturedWorkload
+	for _, p := range c.GetRegistries() {
+		res = append(res, p.UncapturedWorkloads()...)
+	}
+	return res
+}
+
 func (c *Controller) AdditionalPodSubscriptions(proxy *model.Proxy, addr, cur sets.Set[types.NamespacedName]) sets.Set[types.NamespacedName] {
 	if !features.EnableAmbientControllers {
 		return nil
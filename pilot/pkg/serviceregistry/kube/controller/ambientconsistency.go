@@ -0,0 +1,191 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/pkg/monitoring"
+)
+
+const (
+	// divergenceUncaptured is a pod which should be captured, but was not enrolled by the node agent.
+	divergenceUncaptured = "uncaptured"
+	// divergenceUnexpected is a pod enrolled by the node agent, which should not be captured.
+	divergenceUnexpected = "unexpected"
+
+	ambientDivergenceReason = "AmbientCaptureDivergence"
+)
+
+var (
+	nodeLabel = monitoring.MustCreateLabel("node")
+
+	ambientCaptureDivergence = monitoring.NewGauge(
+		"pilot_ambient_capture_divergence",
+		"Number of pods whose ambient capture, as reported by the CNI node agent, has diverged from what istiod expects "+
+			"for longer than the consistency check interval.",
+		monitoring.WithLabels(clusterLabel, nodeLabel, typeTag),
+	)
+)
+
+func init() {
+	monitoring.MustRegister(ambientCaptureDivergence)
+}
+
+type divergenceKey struct {
+	node string
+	kind string
+}
+
+// ambientConsistencyChecker periodically compares the pods istiod expects to be captured by ztunnel with the
+// enrollment reported by the CNI node agents, which annotate the pods they enroll. Divergence that persists across
+// two checks is reported with metrics, per node, and with a Warning event on the pod. Divergence found in a
+// single check is expected while the node agent catches up with pod and namespace changes.
+type ambientConsistencyChecker struct {
+	c        *Controller
+	interval time.Duration
+	// pending holds the divergence found in the previous check, which is reported if still present.
+	pending map[types.NamespacedName]string
+	// reported holds the divergence events have been sent for, so they are only sent once.
+	reported map[types.NamespacedName]string
+	// nodes holds the metrics recorded in the previous check, so they can be reset.
+	nodes map[divergenceKey]struct{}
+}
+
+func newAmbientConsistencyChecker(c *Controller, interval time.Duration) *ambientConsistencyChecker {
+	return &ambientConsistencyChecker{
+		c:        c,
+		interval: interval,
+		pending:  map[types.NamespacedName]string{},
+		reported: map[types.NamespacedName]string{},
+		nodes:    map[divergenceKey]struct{}{},
+	}
+}
+
+func (a *ambientConsistencyChecker) Run(stop <-chan struct{}) {
+	log.Infof("starting ambient consistency checker for cluster %s, checking every %v", a.c.Cluster(), a.interval)
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			a.check()
+		}
+	}
+}
+
+// captureDivergence returns how the enrollment of the pod diverges from what is expected, if it does.
+func (c *Controller) captureDivergence(p *v1.Pod) string {
+	enrolled := p.Annotations[constants.AmbientRedirection] == constants.AmbientRedirectionEnabled
+	if c.podCaptureExpected(p) {
+		if !enrolled {
+			return divergenceUncaptured
+		}
+		return ""
+	}
+	// Pods that are not running have no traffic to capture, whatever the annotation says
+	if enrolled && IsPodRunning(p) && p.DeletionTimestamp == nil {
+		return divergenceUnexpected
+	}
+	return ""
+}
+
+func (a *ambientConsistencyChecker) check() {
+	found := map[types.NamespacedName]string{}
+	counts := map[divergenceKey]int{}
+	for _, p := range a.c.podsClient.List(metav1.NamespaceAll, klabels.Everything()) {
+		kind := a.c.captureDivergence(p)
+		if kind == "" {
+			continue
+		}
+		key := config.NamespacedName(p)
+		found[key] = kind
+		if a.pending[key] != kind {
+			// New divergence, give the node agent until the next check to catch up
+			continue
+		}
+		counts[divergenceKey{node: p.Spec.NodeName, kind: kind}]++
+		if a.reported[key] != kind {
+			a.reported[key] = kind
+			a.reportEvent(p, kind)
+		}
+	}
+	for key := range a.reported {
+		if _, f := found[key]; !f {
+			delete(a.reported, key)
+		}
+	}
+	a.pending = found
+
+	cluster := clusterLabel.Value(a.c.Cluster().String())
+	for k := range a.nodes {
+		if _, f := counts[k]; !f {
+			ambientCaptureDivergence.With(cluster, nodeLabel.Value(k.node), typeTag.Value(k.kind)).Record(0)
+		}
+	}
+	a.nodes = map[divergenceKey]struct{}{}
+	for k, n := range counts {
+		a.nodes[k] = struct{}{}
+		ambientCaptureDivergence.With(cluster, nodeLabel.Value(k.node), typeTag.Value(k.kind)).Record(float64(n))
+	}
+}
+
+func (a *ambientConsistencyChecker) reportEvent(p *v1.Pod, kind string) {
+	var msg string
+	switch kind {
+	case divergenceUncaptured:
+		msg = fmt.Sprintf("Pod is expected to be captured by ztunnel, but has not been enrolled by the CNI node agent on node %s",
+			p.Spec.NodeName)
+	case divergenceUnexpected:
+		msg = fmt.Sprintf("Pod is enrolled by the CNI node agent on node %s, but is not expected to be captured by ztunnel",
+			p.Spec.NodeName)
+	}
+	log.Warnf("ambient capture divergence for pod %s/%s: %s", p.Namespace, p.Name, msg)
+	now := metav1.Now()
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: p.Name + ".",
+			Namespace:    p.Namespace,
+		},
+		InvolvedObject: v1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Name:       p.Name,
+			Namespace:  p.Namespace,
+			UID:        p.UID,
+		},
+		Reason:         ambientDivergenceReason,
+		Message:        msg,
+		Type:           v1.EventTypeWarning,
+		Source:         v1.EventSource{Component: "istiod"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if _, err := a.c.client.Kube().CoreV1().Events(p.Namespace).Create(context.Background(), event, metav1.CreateOptions{}); err != nil {
+		log.Warnf("failed to create ambient capture divergence event for pod %s/%s: %v", p.Namespace, p.Name, err)
+	}
+}
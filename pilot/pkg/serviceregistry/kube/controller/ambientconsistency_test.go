@@ -0,0 +1,104 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/mesh"
+	"istio.io/istio/pkg/config/schema/collections"
+	"istio.io/istio/pkg/kube/kclient/clienttest"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+)
+
+func TestAmbientConsistencyChecker(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI)),
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	nc := clienttest.Wrap(t, controller.namespaces)
+	checker := newAmbientConsistencyChecker(controller.Controller, time.Minute)
+	events := func() map[string]string {
+		t.Helper()
+		list, err := controller.client.Kube().CoreV1().Events("ns1").List(context.Background(), metav1.ListOptions{})
+		assert.NoError(t, err)
+		res := map[string]string{}
+		for _, e := range list.Items {
+			assert.Equal(t, e.Reason, ambientDivergenceReason)
+			res[e.InvolvedObject.Name] = e.Message
+		}
+		return res
+	}
+	enrolled := map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled}
+
+	nc.CreateOrUpdate(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient}},
+	})
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "captured", "ns1", "sa1", "node1", nil, enrolled))
+	pc.CreateOrUpdate(generatePod("127.0.0.2", "uncaptured", "ns1", "sa1", "node1", nil, nil))
+	retry.UntilOrFail(t, func() bool {
+		return len(controller.podsClient.List("ns1", klabels.Everything())) == 2
+	}, retry.Timeout(time.Second*3))
+
+	assert.Equal(t, controller.captureDivergence(pc.Get("captured", "ns1")), "")
+	assert.Equal(t, controller.captureDivergence(pc.Get("uncaptured", "ns1")), divergenceUncaptured)
+
+	// Divergence is only reported once it persists across checks
+	checker.check()
+	assert.Equal(t, len(events()), 0)
+	checker.check()
+	assert.Equal(t, len(events()), 1)
+	assert.Equal(t, events()["uncaptured"] != "", true)
+	// Events are only sent once
+	checker.check()
+	assert.Equal(t, len(events()), 1)
+
+	// The node agent catches up
+	pc.Update(generatePod("127.0.0.2", "uncaptured", "ns1", "sa1", "node1", nil, enrolled))
+	retry.UntilOrFail(t, func() bool {
+		return controller.captureDivergence(pc.Get("uncaptured", "ns1")) == ""
+	}, retry.Timeout(time.Second*3))
+	checker.check()
+	assert.Equal(t, len(checker.pending), 0)
+	assert.Equal(t, len(checker.reported), 0)
+
+	// Ambient is disabled for the namespace, but the node agent has not removed the pods
+	nc.Update(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}})
+	retry.UntilOrFail(t, func() bool {
+		return controller.captureDivergence(pc.Get("captured", "ns1")) == divergenceUnexpected
+	}, retry.Timeout(time.Second*3))
+	checker.check()
+	checker.check()
+	assert.Equal(t, checker.reported, map[types.NamespacedName]string{
+		{Namespace: "ns1", Name: "captured"}:   divergenceUnexpected,
+		{Namespace: "ns1", Name: "uncaptured"}: divergenceUnexpected,
+	})
+}
@@ -0,0 +1,444 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/netip"
+	"sort"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry/serviceentry"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/schema/kind"
+	"istio.io/istio/pkg/util/sets"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// gatewayWaypoint tracks the pods of a single waypoint Gateway.
+type gatewayWaypoint struct {
+	scope     model.WaypointScope
+	addresses sets.String
+	// revisions holds the revision of each waypoint pod, by address.
+	revisions map[string]string
+}
+
+// ServiceEntryHandler updates the egress workloads for ServiceEntries, so ztunnel routes their traffic.
+// Only the ServiceEntries routed through an egress waypoint, or labeled with istio.io/dataplane-mode=ambient, are
+// exposed to ztunnel. A ServiceEntry is attached to an egress waypoint with the istio.io/use-waypoint label, naming
+// a waypoint Gateway in the same namespace; ServiceEntries with wildcard hosts otherwise route through the waypoint
+// configured with PILOT_AMBIENT_EGRESS_WAYPOINT, if any. Their workloads then have the waypoint's addresses, so
+// ztunnel sends traffic for the ServiceEntry through the waypoint.
+// Each address of a ServiceEntry is exposed to ztunnel as a workload, unless the ServiceEntry has STATIC resolution,
+// in which case each of its endpoints is exposed as a workload with the ServiceEntry addresses as virtual IPs. With DNS
+// resolution, the workloads list all the endpoints of the ServiceEntry, which ztunnel resolves and balances across.
+// Wildcard hosts have no address unless the ServiceEntry sets one, so they are skipped with a warning otherwise.
+// ServiceEntries without addresses use the addresses automatically allocated to their hosts, if
+// PILOT_AMBIENT_AUTO_ALLOCATE_ADDRESSES is enabled.
+func (c *Controller) ServiceEntryHandler(old config.Config, obj config.Config, ev model.Event) {
+	a := c.ambientIndex
+	a.mu.Lock()
+	name := types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}
+	if ev == model.EventDelete {
+		delete(a.serviceEntries, name)
+	} else {
+		a.serviceEntries[name] = obj
+	}
+	rebuild := sets.New(name)
+	if features.AmbientAutoAllocateAddresses {
+		// Allocations depend on all ServiceEntries, so a change may move the addresses of other ServiceEntries.
+		rebuild.Merge(a.allocateEgressAddresses())
+	}
+	updates := sets.New[model.ConfigKey]()
+	for name := range rebuild {
+		for _, ip := range a.dropEgress(name) {
+			updates.Insert(model.ConfigKey{Kind: kind.Address, Name: ip})
+		}
+		cfg, f := a.serviceEntries[name]
+		if !f {
+			continue
+		}
+		for _, wl := range c.constructEgressWorkloads(cfg) {
+			a.insertEgress(name, wl)
+			for _, ip := range egressAddresses(wl) {
+				updates.Insert(model.ConfigKey{Kind: kind.Address, Name: ip})
+			}
+		}
+	}
+	a.mu.Unlock()
+
+	if len(updates) > 0 {
+		c.opts.XDSUpdater.ConfigUpdate(&model.PushRequest{
+			ConfigsUpdated: updates,
+			Reason:         []model.TriggerReason{model.AmbientUpdate},
+		})
+	}
+}
+
+// allocateEgressAddresses allocates addresses to the hosts of ServiceEntries without addresses, returning the
+// ServiceEntries whose allocated addresses changed.
+func (a *AmbientIndex) allocateEgressAddresses() sets.Set[types.NamespacedName] {
+	configs := make([]config.Config, 0, len(a.serviceEntries))
+	for _, cfg := range a.serviceEntries {
+		configs = append(configs, cfg)
+	}
+	allocated := serviceentry.AutoAllocatedAddresses(configs)
+	changed := sets.New[types.NamespacedName]()
+	for name, cfg := range a.serviceEntries {
+		se, ok := cfg.Spec.(*networking.ServiceEntry)
+		if !ok {
+			continue
+		}
+		for _, h := range se.Hosts {
+			key := cfg.Namespace + "/" + h
+			if !slices.Equal(a.allocatedAddresses[key], allocated[key]) {
+				changed.Insert(name)
+			}
+		}
+	}
+	a.allocatedAddresses = allocated
+	return changed
+}
+
+// insertEgress stores an egress workload of the given ServiceEntry.
+func (a *AmbientIndex) insertEgress(name types.NamespacedName, wl *model.WorkloadInfo) {
+	wls := a.egress[name]
+	if wls == nil {
+		wls = map[string]*model.WorkloadInfo{}
+		a.egress[name] = wls
+	}
+	wls[wl.ResourceName()] = wl
+	for _, addr := range egressAddresses(wl) {
+		ses := a.egressByAddress[addr]
+		if ses == nil {
+			ses = sets.New[types.NamespacedName]()
+			a.egressByAddress[addr] = ses
+		}
+		ses.Insert(name)
+	}
+}
+
+// dropEgress removes all egress workloads for the given ServiceEntry, returning their addresses and virtual IPs.
+func (a *AmbientIndex) dropEgress(name types.NamespacedName) []string {
+	var removed []string
+	for _, wl := range a.egress[name] {
+		for _, addr := range egressAddresses(wl) {
+			removed = append(removed, addr)
+			if ses := a.egressByAddress[addr]; ses != nil {
+				ses.Delete(name)
+				if ses.IsEmpty() {
+					delete(a.egressByAddress, addr)
+				}
+			}
+		}
+	}
+	delete(a.egress, name)
+	return removed
+}
+
+// egressAt returns the egress workload with the given address, if any. If several ServiceEntries have a workload
+// with the address, the one of the first ServiceEntry by namespace and name is returned, as ztunnel only knows one
+// workload per address.
+func (a *AmbientIndex) egressAt(ip string) *model.WorkloadInfo {
+	ses := a.egressByAddress[ip]
+	if len(ses) == 0 {
+		return nil
+	}
+	names := ses.UnsortedList()
+	sort.Slice(names, func(i, j int) bool {
+		if names[i].Namespace != names[j].Namespace {
+			return names[i].Namespace < names[j].Namespace
+		}
+		return names[i].Name < names[j].Name
+	})
+	for _, name := range names {
+		if wl := a.egress[name][ip]; wl != nil {
+			return wl
+		}
+	}
+	return nil
+}
+
+// egressAddresses returns the addresses an egress workload can be looked up by: its own, and its virtual IPs.
+func egressAddresses(wl *model.WorkloadInfo) []string {
+	res := []string{wl.ResourceName()}
+	for vip := range wl.VirtualIps {
+		if vip != wl.ResourceName() {
+			res = append(res, vip)
+		}
+	}
+	return res
+}
+
+// egressByVIP returns the egress workloads with the given virtual IP, which is not itself an egress workload.
+// These are the endpoints of ServiceEntries with STATIC resolution.
+func (a *AmbientIndex) egressByVIP(vip string) []*model.WorkloadInfo {
+	var res []*model.WorkloadInfo
+	for name := range a.egressByAddress[vip] {
+		for ip, wl := range a.egress[name] {
+			if _, f := wl.VirtualIps[vip]; f && ip != vip {
+				res = append(res, wl)
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ResourceName() < res[j].ResourceName()
+	})
+	return res
+}
+
+// updateGatewayWaypoint records a change to a waypoint pod, and updates any egress workloads routed
+// through its Gateway.
+func (a *AmbientIndex) updateGatewayWaypoint(p *v1.Pod, scope model.WaypointScope, isDelete bool) sets.Set[model.ConfigKey] {
+	gwName := p.Labels[constants.GatewayNameLabel]
+	if gwName == "" {
+		return nil
+	}
+	name := types.NamespacedName{Namespace: p.Namespace, Name: gwName}
+	gw := a.gateways[name]
+	ip := p.Status.PodIP
+	if isDelete {
+		if gw == nil || !gw.addresses.Contains(ip) {
+			return nil
+		}
+		gw.addresses.Delete(ip)
+		delete(gw.revisions, ip)
+		if gw.addresses.IsEmpty() {
+			delete(a.gateways, name)
+		}
+	} else {
+		if gw == nil {
+			gw = &gatewayWaypoint{scope: scope, addresses: sets.New[string](), revisions: map[string]string{}}
+			a.gateways[name] = gw
+		}
+		gw.revisions[ip] = waypointRevision(p)
+		if gw.addresses.InsertContains(ip) {
+			return nil
+		}
+	}
+
+	updates := sets.New[model.ConfigKey]()
+	waypoints := a.gatewayWaypointAddresses(name)
+	for _, wls := range a.egress {
+		for ip, wl := range wls {
+			if wl.EgressWaypoint != name {
+				continue
+			}
+			nwl := wl.Clone()
+			nwl.WaypointAddresses = waypoints
+			wls[ip] = nwl
+			for _, addr := range egressAddresses(nwl) {
+				updates.Insert(model.ConfigKey{Kind: kind.Address, Name: addr})
+			}
+		}
+	}
+	return updates
+}
+
+func (a *AmbientIndex) gatewayWaypointAddresses(name types.NamespacedName) [][]byte {
+	gw := a.gateways[name]
+	if gw == nil {
+		return nil
+	}
+	res := make([][]byte, 0, len(gw.addresses))
+	for _, ip := range sets.SortedList(gw.addresses) {
+		res = append(res, netip.MustParseAddr(ip).AsSlice())
+	}
+	return res
+}
+
+// egressForWaypoint returns the egress workloads routed through waypoints in the given scope.
+func (a *AmbientIndex) egressForWaypoint(scope model.WaypointScope) []*model.WorkloadInfo {
+	var res []*model.WorkloadInfo
+	for _, wls := range a.egress {
+		for _, wl := range wls {
+			gw := a.gateways[wl.EgressWaypoint]
+			if gw != nil && gw.scope == scope {
+				res = append(res, wl)
+			}
+		}
+	}
+	return res
+}
+
+// constructEgressWorkloads builds the egress workloads of a ServiceEntry.
+// Only the config cluster handles ServiceEntries, so these are only built once across clusters.
+func (c *Controller) constructEgressWorkloads(cfg config.Config) []*model.WorkloadInfo {
+	se, ok := cfg.Spec.(*networking.ServiceEntry)
+	if !ok {
+		return nil
+	}
+	waypoint := egressWaypoint(cfg, se)
+	// ServiceEntries are only exposed to ztunnel if they opt in, so the ServiceEntries used by sidecars alone do not
+	// add workloads to every ztunnel.
+	if waypoint.Name == "" && cfg.Labels[constants.DataplaneMode] != constants.DataplaneModeAmbient {
+		return nil
+	}
+	var waypoints [][]byte
+	if waypoint.Name != "" {
+		waypoints = c.ambientIndex.gatewayWaypointAddresses(waypoint)
+	}
+	// Explicit addresses are the addresses of the first host.
+	firstHost := ""
+	if len(se.Hosts) > 0 {
+		firstHost = se.Hosts[0]
+	}
+	var vips []egressVIP
+	for _, address := range se.Addresses {
+		// CIDR ranges cannot be represented as a workload address.
+		if ip, err := netip.ParseAddr(address); err == nil {
+			vips = append(vips, egressVIP{ip: ip, host: firstHost})
+		}
+	}
+	if len(se.Addresses) == 0 {
+		for _, h := range se.Hosts {
+			for _, address := range c.ambientIndex.allocatedAddresses[cfg.Namespace+"/"+h] {
+				if ip, err := netip.ParseAddr(address); err == nil {
+					vips = append(vips, egressVIP{ip: ip, host: h})
+				}
+			}
+		}
+	}
+	warnWildcardHosts(cfg, se, vips)
+	build := func(ip netip.Addr, ep *networking.WorkloadEntry, vips []egressVIP) *model.WorkloadInfo {
+		wl := &workloadapi.Workload{
+			Name:              cfg.Name,
+			Namespace:         cfg.Namespace,
+			Address:           ip.AsSlice(),
+			Network:           c.network.String(),
+			WaypointAddresses: waypoints,
+			VirtualIps:        map[string]*workloadapi.PortList{},
+			WorkloadName:      cfg.Name,
+			CanonicalName:     cfg.Name,
+			CanonicalRevision: "latest",
+			Status:            workloadapi.WorkloadStatus_HEALTHY,
+			ClusterId:         c.Cluster().String(),
+		}
+		for _, vip := range vips {
+			wl.VirtualIps[vip.ip.String()] = egressPorts(se, ep, vip.host)
+		}
+		wl.TrustDomain = c.workloadTrustDomain(cfg.Namespace)
+		excludeTelemetryLabels(wl)
+		return &model.WorkloadInfo{
+			Workload:       wl,
+			Labels:         cfg.Labels,
+			Egress:         true,
+			EgressWaypoint: waypoint,
+		}
+	}
+
+	var res []*model.WorkloadInfo
+	if se.Resolution == networking.ServiceEntry_STATIC {
+		// Endpoints are reached directly, with the ServiceEntry addresses as their virtual IPs.
+		for _, ep := range se.Endpoints {
+			// Endpoints may also be Unix domain sockets, which are not reachable through ztunnel.
+			ip, err := netip.ParseAddr(ep.Address)
+			if err != nil {
+				continue
+			}
+			res = append(res, build(ip, ep, vips))
+		}
+		return res
+	}
+	for _, vip := range vips {
+		wl := build(vip.ip, nil, []egressVIP{vip})
+		if se.Resolution == networking.ServiceEntry_DNS {
+			wl.Resolution = workloadapi.Resolution_DNS
+			wl.DnsEndpoints = egressDNSEndpoints(se, vip.host)
+		}
+		res = append(res, wl)
+	}
+	return res
+}
+
+// warnWildcardHosts warns about the wildcard hosts of a ServiceEntry without any address: they cannot be represented as
+// a workload, so ztunnel does not route their traffic.
+func warnWildcardHosts(cfg config.Config, se *networking.ServiceEntry, vips []egressVIP) {
+	for _, h := range se.Hosts {
+		if !host.Name(h).IsWildCarded() {
+			continue
+		}
+		if !slices.ContainsFunc(vips, func(vip egressVIP) bool { return vip.host == h }) {
+			log.Warnf("ServiceEntry %s/%s: wildcard host %s has no address, so it is not exposed to ztunnel", cfg.Namespace, cfg.Name, h)
+		}
+	}
+}
+
+// egressVIP is a virtual IP of a ServiceEntry, with the host it is the address of.
+type egressVIP struct {
+	ip   netip.Addr
+	host string
+}
+
+// egressWaypoint returns the waypoint Gateway a ServiceEntry routes through, if any.
+func egressWaypoint(cfg config.Config, se *networking.ServiceEntry) types.NamespacedName {
+	if gw := cfg.Labels[constants.AmbientUseWaypoint]; gw != "" {
+		return types.NamespacedName{Namespace: cfg.Namespace, Name: gw}
+	}
+	if features.AmbientEgressWaypoint == "" {
+		return types.NamespacedName{}
+	}
+	for _, h := range se.Hosts {
+		if host.Name(h).IsWildCarded() {
+			ns, name, ok := strings.Cut(features.AmbientEgressWaypoint, "/")
+			if !ok {
+				log.Warnf("invalid PILOT_AMBIENT_EGRESS_WAYPOINT %q, expected namespace/name", features.AmbientEgressWaypoint)
+				return types.NamespacedName{}
+			}
+			return types.NamespacedName{Namespace: ns, Name: name}
+		}
+	}
+	return types.NamespacedName{}
+}
+
+// egressPorts returns the ports of a host of a ServiceEntry, targeting the ports of the given endpoint if set.
+func egressPorts(se *networking.ServiceEntry, ep *networking.WorkloadEntry, host string) *workloadapi.PortList {
+	ports := &workloadapi.PortList{Service: host}
+	for _, p := range se.Ports {
+		target := p.TargetPort
+		if ep != nil && ep.Ports[p.Name] != 0 {
+			target = ep.Ports[p.Name]
+		}
+		if target == 0 {
+			target = p.Number
+		}
+		ports.Ports = append(ports.Ports, &workloadapi.Port{
+			ServicePort: p.Number,
+			TargetPort:  target,
+		})
+	}
+	return ports
+}
+
+// egressDNSEndpoints returns the endpoints resolved for the given host of a ServiceEntry with DNS resolution, with the
+// ports of each: the addresses of all its endpoints, or the host itself if it has no endpoints.
+func egressDNSEndpoints(se *networking.ServiceEntry, host string) map[string]*workloadapi.PortList {
+	if len(se.Endpoints) == 0 {
+		return map[string]*workloadapi.PortList{host: egressPorts(se, nil, host)}
+	}
+	res := make(map[string]*workloadapi.PortList, len(se.Endpoints))
+	for _, ep := range se.Endpoints {
+		res[ep.Address] = egressPorts(se, ep, host)
+	}
+	return res
+}
@@ -16,20 +16,27 @@ package controller
 
 import (
 	"bytes"
+	"fmt"
 	"net/netip"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"google.golang.org/protobuf/proto"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/cache"
 
+	"istio.io/api/annotation"
 	"istio.io/api/security/v1beta1"
+	"istio.io/istio/pilot/pkg/features"
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/serviceregistry/kube"
 	"istio.io/istio/pkg/config"
@@ -37,10 +44,10 @@ import (
 	"istio.io/istio/pkg/config/labels"
 	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/config/schema/kind"
+	kubelib "istio.io/istio/pkg/kube"
 	"istio.io/istio/pkg/kube/controllers"
 	"istio.io/istio/pkg/kube/kclient"
 	kubelabels "istio.io/istio/pkg/kube/labels"
-	"istio.io/istio/pkg/spiffe"
 	"istio.io/istio/pkg/util/sets"
 	"istio.io/istio/pkg/workloadapi"
 )
@@ -62,9 +69,31 @@ type AmbientIndex struct {
 	// or a reference to a service.
 	// If its a reference to a Service then we can find the underlying pods in that service, as an optimization.
 	waypoints map[model.WaypointScope]sets.String
+	// gateways indexes waypoint pods by their Gateway, for waypoints which ServiceEntries route egress traffic through.
+	gateways map[types.NamespacedName]*gatewayWaypoint
+	// egress holds the workloads synthesized from the addresses, or endpoints, of ServiceEntries, by ServiceEntry and
+	// address. ServiceEntries may share addresses, so the workloads of each are kept apart.
+	egress map[types.NamespacedName]map[string]*model.WorkloadInfo
+	// egressByAddress indexes the ServiceEntries with egress workloads by the addresses and virtual IPs of these.
+	egressByAddress map[string]sets.Set[types.NamespacedName]
+	// serviceEntries holds the ServiceEntries egress workloads are built from, by name.
+	serviceEntries map[types.NamespacedName]config.Config
+	// allocatedAddresses holds the addresses automatically allocated to the hosts of ServiceEntries without
+	// addresses, by namespace/hostname. Only set if PILOT_AMBIENT_AUTO_ALLOCATE_ADDRESSES is enabled.
+	allocatedAddresses map[string][]string
 
 	// serviceVipIndex maintains an index of VIP -> Service
 	serviceVipIndex *kclient.Index[string, *v1.Service]
+	// podNodeIndex maintains an index of node name -> Pod, to find the node-local workloads of on-demand clients
+	// without going through all workloads on each push.
+	podNodeIndex *kclient.Index[string, *v1.Pod]
+
+	// uncaptured tracks pods which are expected to be captured by ztunnel, but have not been enrolled
+	// by the CNI node agent.
+	uncaptured map[types.NamespacedName]model.UncapturedWorkload
+
+	// interner deduplicates the strings and slices shared by the workloads built from pods.
+	interner *workloadInterner
 }
 
 // Lookup finds a given IP address.
@@ -75,8 +104,14 @@ func (a *AmbientIndex) Lookup(ip string) []*model.WorkloadInfo {
 	if p, f := a.byPod[ip]; f {
 		return []*model.WorkloadInfo{p}
 	}
+	if e := a.egressAt(ip); e != nil {
+		return []*model.WorkloadInfo{e}
+	}
 	// Fallback to service. Note: these IP ranges should be non-overlapping
-	return a.byService[ip]
+	if wls := a.byService[ip]; len(wls) > 0 {
+		return wls
+	}
+	return a.egressByVIP(ip)
 }
 
 func (a *AmbientIndex) dropWorkloadFromService(svcAddress, workloadAddress string) {
@@ -103,7 +138,7 @@ func (a *AmbientIndex) updateWaypoint(scope model.WaypointScope, ipStr string, i
 	addr := netip.MustParseAddr(ipStr).AsSlice()
 	updates := sets.New[model.ConfigKey]()
 	if isDelete {
-		for _, wl := range a.byPod {
+		for ip, wl := range a.byPod {
 			if wl.Labels[constants.ManagedGatewayLabel] == constants.ManagedGatewayMeshControllerLabel {
 				continue
 			}
@@ -120,15 +155,15 @@ func (a *AmbientIndex) updateWaypoint(scope model.WaypointScope, ipStr string, i
 					filtered = true
 				}
 			}
-			wl.WaypointAddresses = addrs
 			if filtered {
 				// If there was a change, also update the VIPs and record for a push
+				wl = a.replaceWaypointAddresses(ip, wl, addrs)
 				updates.Insert(model.ConfigKey{Kind: kind.Address, Name: wl.ResourceName()})
 			}
 			updates.Merge(c.updateEndpointsOnWaypointChange(wl))
 		}
 	} else {
-		for _, wl := range a.byPod {
+		for ip, wl := range a.byPod {
 			if wl.Labels[constants.ManagedGatewayLabel] == constants.ManagedGatewayMeshControllerLabel {
 				continue
 			}
@@ -144,7 +179,7 @@ func (a *AmbientIndex) updateWaypoint(scope model.WaypointScope, ipStr string, i
 				}
 			}
 			if !found {
-				wl.WaypointAddresses = append(wl.WaypointAddresses, addr)
+				wl = a.replaceWaypointAddresses(ip, wl, append(slices.Clone(wl.WaypointAddresses), addr))
 				// If there was a change, also update the VIPs and record for a push
 				updates.Insert(model.ConfigKey{Kind: kind.Address, Name: wl.ResourceName()})
 			}
@@ -154,15 +189,49 @@ func (a *AmbientIndex) updateWaypoint(scope model.WaypointScope, ipStr string, i
 	return updates
 }
 
+// replaceWaypointAddresses stores a copy of wl with the given waypoint addresses. Stored workloads are
+// never mutated in place, as their marshaled form is cached by pointer.
+func (a *AmbientIndex) replaceWaypointAddresses(ip string, wl *model.WorkloadInfo, addrs [][]byte) *model.WorkloadInfo {
+	nwl := wl.Clone()
+	nwl.WaypointAddresses = addrs
+	a.byPod[ip] = nwl
+	for vip := range nwl.VirtualIps {
+		a.insertWorkloadToService(vip, nwl)
+	}
+	return nwl
+}
+
 // All return all known workloads. Result is un-ordered
 func (a *AmbientIndex) All() []*model.WorkloadInfo {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
-	res := make([]*model.WorkloadInfo, 0, len(a.byPod))
+	res := make([]*model.WorkloadInfo, 0, len(a.byPod)+len(a.egress))
 	// byPod will not have any duplicates, so we can just iterate over that.
 	for _, wl := range a.byPod {
 		res = append(res, wl)
 	}
+	for _, wls := range a.egress {
+		for ip, wl := range wls {
+			// ServiceEntries sharing an address only expose the workload of one of them
+			if a.egressAt(ip) == wl {
+				res = append(res, wl)
+			}
+		}
+	}
+	return res
+}
+
+// nodeWorkloads returns the workloads of the pods running on the given node.
+func (a *AmbientIndex) nodeWorkloads(nodeName string) []*model.WorkloadInfo {
+	pods := a.podNodeIndex.Lookup(nodeName)
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	res := make([]*model.WorkloadInfo, 0, len(pods))
+	for _, p := range pods {
+		if wl, f := a.byPod[p.Status.PodIP]; f && wl.Node == nodeName {
+			res = append(res, wl)
+		}
+	}
 	return res
 }
 
@@ -177,7 +246,7 @@ func (c *Controller) WorkloadsForWaypoint(scope model.WaypointScope) []*model.Wo
 			res = append(res, w)
 		}
 	}
-	return res
+	return append(res, a.egressForWaypoint(scope)...)
 }
 
 // Waypoint finds all waypoint IP addresses for a given scope
@@ -243,7 +312,7 @@ func (c *Controller) Policies(requested sets.Set[model.ConfigKey]) []*workloadap
 		if len(requested) > 0 && !requested.Contains(k) {
 			continue
 		}
-		pol := convertAuthorizationPolicy(c.meshWatcher.Mesh().GetRootNamespace(), cfg)
+		pol := ConvertAuthorizationPolicy(c.meshWatcher.Mesh().GetRootNamespace(), c.trustDomain, cfg)
 		if pol == nil {
 			continue
 		}
@@ -337,7 +406,10 @@ func (c *Controller) getPodsInPolicy(ns string, sel map[string]string) []*v1.Pod
 	return c.podsClient.List(ns, klabels.ValidatedSetSelector(sel))
 }
 
-func convertAuthorizationPolicy(rootns string, obj config.Config) *workloadapi.Authorization {
+// ConvertAuthorizationPolicy converts an AuthorizationPolicy to the policy enforced by ztunnel. Policies which ztunnel
+// does not enforce, such as CUSTOM policies, are converted to nil. If set, trustDomain returns the trust domain of the
+// workloads of a namespace, so principals are also matched in the trust domains of the namespaces overriding it.
+func ConvertAuthorizationPolicy(rootns string, trustDomain func(ns string) string, obj config.Config) *workloadapi.Authorization {
 	pol := obj.Spec.(*v1beta1.AuthorizationPolicy)
 
 	scope := workloadapi.Scope_WORKLOAD_SELECTOR
@@ -365,7 +437,7 @@ func convertAuthorizationPolicy(rootns string, obj config.Config) *workloadapi.A
 	}
 
 	for _, rule := range pol.Rules {
-		rules := handleRule(action, rule)
+		rules := handleRule(action, rule, trustDomain)
 		if rules != nil {
 			rg := &workloadapi.Group{
 				Rules: rules,
@@ -386,7 +458,8 @@ func anyNonEmpty[T any](arr ...[]T) bool {
 	return false
 }
 
-func handleRule(action workloadapi.Action, rule *v1beta1.Rule) []*workloadapi.Rules {
+func handleRule(action workloadapi.Action, rule *v1beta1.Rule, trustDomain func(ns string) string) []*workloadapi.Rules {
+	c := &matchConverter{action: action, trustDomain: trustDomain}
 	toMatches := []*workloadapi.Match{}
 	for _, to := range rule.To {
 		op := to.Operation
@@ -396,8 +469,8 @@ func handleRule(action workloadapi.Action, rule *v1beta1.Rule) []*workloadapi.Ru
 			return nil
 		}
 		match := &workloadapi.Match{
-			DestinationPorts:    stringToPort(op.Ports),
-			NotDestinationPorts: stringToPort(op.NotPorts),
+			DestinationPorts:    c.ports(op.Ports),
+			NotDestinationPorts: c.ports(op.NotPorts),
 		}
 		// if !emptyRuleMatch(match) {
 		toMatches = append(toMatches, match)
@@ -406,18 +479,22 @@ func handleRule(action workloadapi.Action, rule *v1beta1.Rule) []*workloadapi.Ru
 	fromMatches := []*workloadapi.Match{}
 	for _, from := range rule.From {
 		op := from.Source
-		if action == workloadapi.Action_ALLOW && anyNonEmpty(op.RemoteIpBlocks, op.NotRemoteIpBlocks, op.RequestPrincipals, op.NotRequestPrincipals) {
+		if action == workloadapi.Action_ALLOW && anyNonEmpty(op.RequestPrincipals, op.NotRequestPrincipals) {
 			// L7 policies never match for ALLOW
 			// For DENY they will always match, so it is more restrictive
 			return nil
 		}
+		sourceIps, ok := sourceIPBlocks(action, op.IpBlocks, op.RemoteIpBlocks)
+		if !ok {
+			return nil
+		}
 		match := &workloadapi.Match{
-			SourceIps:     stringToIP(op.IpBlocks),
-			NotSourceIps:  stringToIP(op.NotIpBlocks),
-			Namespaces:    stringToMatch(op.Namespaces),
-			NotNamespaces: stringToMatch(op.NotNamespaces),
-			Principals:    stringToMatch(op.Principals),
-			NotPrincipals: stringToMatch(op.NotPrincipals),
+			SourceIps:     c.ips(sourceIps),
+			NotSourceIps:  c.ips(append(slices.Clone(op.NotIpBlocks), op.NotRemoteIpBlocks...)),
+			Namespaces:    c.namespaces(op.Namespaces),
+			NotNamespaces: c.notNamespaces(op.NotNamespaces),
+			Principals:    c.principals(op.Principals),
+			NotPrincipals: c.notPrincipals(op.NotPrincipals),
 		}
 		// if !emptyRuleMatch(match) {
 		fromMatches = append(fromMatches, match)
@@ -439,25 +516,148 @@ func handleRule(action workloadapi.Action, rule *v1beta1.Rule) []*workloadapi.Ru
 			return nil
 		}
 		positiveMatch := &workloadapi.Match{
-			Namespaces:       whenMatch("source.namespace", when, false, stringToMatch),
-			Principals:       whenMatch("source.principal", when, false, stringToMatch),
-			SourceIps:        whenMatch("source.ip", when, false, stringToIP),
-			DestinationPorts: whenMatch("destination.port", when, false, stringToPort),
-			DestinationIps:   whenMatch("destination.ip", when, false, stringToIP),
-
-			NotNamespaces:       whenMatch("source.namespace", when, true, stringToMatch),
-			NotPrincipals:       whenMatch("source.principal", when, true, stringToMatch),
-			NotSourceIps:        whenMatch("source.ip", when, true, stringToIP),
-			NotDestinationPorts: whenMatch("destination.port", when, true, stringToPort),
-			NotDestinationIps:   whenMatch("destination.ip", when, true, stringToIP),
+			Namespaces:       whenMatch("source.namespace", when, false, c.namespaces),
+			Principals:       whenMatch("source.principal", when, false, c.principals),
+			SourceIps:        append(whenMatch("source.ip", when, false, c.ips), whenMatch("remote.ip", when, false, c.ips)...),
+			DestinationPorts: whenMatch("destination.port", when, false, c.ports),
+			DestinationIps:   whenMatch("destination.ip", when, false, c.ips),
+
+			NotNamespaces:       whenMatch("source.namespace", when, true, c.notNamespaces),
+			NotPrincipals:       whenMatch("source.principal", when, true, c.notPrincipals),
+			NotSourceIps:        append(whenMatch("source.ip", when, true, c.ips), whenMatch("remote.ip", when, true, c.ips)...),
+			NotDestinationPorts: whenMatch("destination.port", when, true, c.ports),
+			NotDestinationIps:   whenMatch("destination.ip", when, true, c.ips),
 		}
 		rules = append(rules, &workloadapi.Rules{Matches: []*workloadapi.Match{positiveMatch}})
 	}
+	if c.unmatchable {
+		return nil
+	}
 	return rules
 }
 
+// matchConverter converts the values of a rule to ztunnel matches. The values ztunnel cannot match, such as invalid
+// IP blocks or ports, are handled as Envoy RBAC handles them: the ALLOW rules holding them never match, and for DENY
+// they are ignored, so the policy denies more traffic rather than less. Dropping them silently in both cases would
+// make an ALLOW rule match more traffic than its other values allow, or all traffic once all its values are dropped.
+type matchConverter struct {
+	action      workloadapi.Action
+	trustDomain func(ns string) string
+	// unmatchable is set once a value of an ALLOW rule cannot be matched, so the rule must be dropped.
+	unmatchable bool
+}
+
+// unsupported records a value ztunnel cannot match.
+func (c *matchConverter) unsupported() {
+	if c.action == workloadapi.Action_ALLOW {
+		c.unmatchable = true
+	}
+}
+
+// principals converts the principals to match. Envoy matches principals with a single wildcard as a prefix or suffix
+// as ztunnel does, but other wildcards differ, so they are handled as for namespaces.
+func (c *matchConverter) principals(values []string) []*workloadapi.StringMatch {
+	return stringToMatch(expandPrincipals(c.wildcards(values, true), c.trustDomain))
+}
+
+// notPrincipals converts the principals not to match, handled as the namespaces not to match.
+func (c *matchConverter) notPrincipals(values []string) []*workloadapi.StringMatch {
+	return stringToMatch(expandPrincipals(c.wildcards(values, false), c.trustDomain))
+}
+
+// namespaces converts the namespaces to match. Envoy matches namespaces with a regex on the principal, so wildcards
+// may be anywhere in them, while ztunnel only matches a single wildcard as a prefix or suffix. For DENY, such
+// namespaces are widened to the prefix before their first wildcard.
+func (c *matchConverter) namespaces(values []string) []*workloadapi.StringMatch {
+	return stringToMatch(c.wildcards(values, true))
+}
+
+// notNamespaces converts the namespaces not to match. Widening them would exclude more traffic from the policy, so
+// for DENY the namespaces ztunnel cannot match are ignored instead, as for the other values.
+func (c *matchConverter) notNamespaces(values []string) []*workloadapi.StringMatch {
+	return stringToMatch(c.wildcards(values, false))
+}
+
+// wildcards returns the values ztunnel matches as Envoy does. For DENY, the other values are widened to the prefix
+// before their first wildcard if widen is set, and ignored otherwise.
+func (c *matchConverter) wildcards(values []string, widen bool) []string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		if !singleWildcard(v) {
+			c.unsupported()
+			if c.action == workloadapi.Action_ALLOW || !widen {
+				continue
+			}
+			v = v[:strings.Index(v, "*")+1]
+		}
+		res = append(res, v)
+	}
+	return res
+}
+
+func (c *matchConverter) ports(values []string) []uint32 {
+	res := make([]uint32, 0, len(values))
+	for _, m := range values {
+		p, ok := stringToPort(m)
+		if !ok {
+			c.unsupported()
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+func (c *matchConverter) ips(values []string) []*workloadapi.Address {
+	res := make([]*workloadapi.Address, 0, len(values))
+	for _, m := range values {
+		a, ok := stringToIP(m)
+		if !ok {
+			c.unsupported()
+			continue
+		}
+		res = append(res, a)
+	}
+	return res
+}
+
+// singleWildcard returns whether ztunnel matches the value as Envoy does: without a wildcard, with a wildcard only,
+// or with a single wildcard as a prefix or suffix.
+func singleWildcard(v string) bool {
+	switch strings.Count(v, "*") {
+	case 0:
+		return true
+	case 1:
+		return strings.HasPrefix(v, "*") || strings.HasSuffix(v, "*")
+	default:
+		return false
+	}
+}
+
+// sourceIPBlocks determines the source IP blocks to match for a Source.
+// ztunnel evaluates source IPs against the original source address carried through the HBONE tunnel,
+// not the address of the tunnel peer (the client ztunnel or waypoint), and there is no L7 header to
+// derive a separate remote address from. As a result, ipBlocks and remoteIpBlocks both describe the same
+// address for ambient workloads.
+// If both are set, the address must be in both sets, which cannot be represented in a single match. For
+// ALLOW we report the rule as unrepresentable so it never matches; for DENY we fall back to ipBlocks, which
+// matches a superset of traffic and is thus more restrictive.
+func sourceIPBlocks(action workloadapi.Action, ipBlocks, remoteIPBlocks []string) ([]string, bool) {
+	switch {
+	case len(remoteIPBlocks) == 0:
+		return ipBlocks, true
+	case len(ipBlocks) == 0:
+		return remoteIPBlocks, true
+	case action == workloadapi.Action_DENY:
+		return ipBlocks, true
+	default:
+		return nil, false
+	}
+}
+
 var l4WhenAttributes = sets.New(
 	"source.ip",
+	"remote.ip",
 	"source.namespace",
 	"source.principal",
 	"destination.ip",
@@ -499,53 +699,45 @@ func stringToMatch(rules []string) []*workloadapi.StringMatch {
 	return res
 }
 
-func stringToPort(rules []string) []uint32 {
-	res := make([]uint32, 0, len(rules))
-	for _, m := range rules {
-		p, err := strconv.ParseUint(m, 10, 32)
-		if err != nil || p > 65535 {
-			continue
-		}
-		res = append(res, uint32(p))
+func stringToPort(m string) (uint32, bool) {
+	p, err := strconv.ParseUint(m, 10, 32)
+	if err != nil || p > 65535 {
+		return 0, false
 	}
-	return res
+	return uint32(p), true
 }
 
-func stringToIP(rules []string) []*workloadapi.Address {
-	res := make([]*workloadapi.Address, 0, len(rules))
-	for _, m := range rules {
-		if len(m) == 0 {
-			continue
-		}
+func stringToIP(m string) (*workloadapi.Address, bool) {
+	if len(m) == 0 {
+		return nil, false
+	}
 
-		var (
-			ipAddr        netip.Addr
-			maxCidrPrefix uint32
-		)
+	var (
+		ipAddr        netip.Addr
+		maxCidrPrefix uint32
+	)
 
-		if strings.Contains(m, "/") {
-			ipp, err := netip.ParsePrefix(m)
-			if err != nil {
-				continue
-			}
-			ipAddr = ipp.Addr()
-			maxCidrPrefix = uint32(ipp.Bits())
-		} else {
-			ipa, err := netip.ParseAddr(m)
-			if err != nil {
-				continue
-			}
-
-			ipAddr = ipa
-			maxCidrPrefix = uint32(ipAddr.BitLen())
+	if strings.Contains(m, "/") {
+		ipp, err := netip.ParsePrefix(m)
+		if err != nil {
+			return nil, false
+		}
+		ipAddr = ipp.Addr()
+		maxCidrPrefix = uint32(ipp.Bits())
+	} else {
+		ipa, err := netip.ParseAddr(m)
+		if err != nil {
+			return nil, false
 		}
 
-		res = append(res, &workloadapi.Address{
-			Address: ipAddr.AsSlice(),
-			Length:  maxCidrPrefix,
-		})
+		ipAddr = ipa
+		maxCidrPrefix = uint32(ipAddr.BitLen())
 	}
-	return res
+
+	return &workloadapi.Address{
+		Address: ipAddr.AsSlice(),
+		Length:  maxCidrPrefix,
+	}, true
 }
 
 func (c *Controller) extractWorkload(p *v1.Pod) *model.WorkloadInfo {
@@ -564,6 +756,7 @@ func (c *Controller) extractWorkload(p *v1.Pod) *model.WorkloadInfo {
 			// if there are none, check namespace wide waypoints
 			waypoints = c.ambientIndex.waypoints[model.WaypointScope{Namespace: p.Namespace}]
 		}
+		waypoints = c.selectWaypointRevision(p, waypoints)
 	}
 
 	policies := c.selectorAuthorizationPolicies(p.Namespace, p.Labels)
@@ -571,6 +764,7 @@ func (c *Controller) extractWorkload(p *v1.Pod) *model.WorkloadInfo {
 	if wl == nil {
 		return nil
 	}
+	c.ambientIndex.interner.internWorkload(wl)
 	return &model.WorkloadInfo{
 		Workload: wl,
 		Labels:   p.Labels,
@@ -601,6 +795,15 @@ func (c *Controller) setupIndex() *AmbientIndex {
 		byService: map[string][]*model.WorkloadInfo{},
 		byPod:     map[string]*model.WorkloadInfo{},
 		waypoints: map[model.WaypointScope]sets.String{},
+		gateways:  map[types.NamespacedName]*gatewayWaypoint{},
+		egress:    map[types.NamespacedName]map[string]*model.WorkloadInfo{},
+
+		egressByAddress:    map[string]sets.Set[types.NamespacedName]{},
+		serviceEntries:     map[types.NamespacedName]config.Config{},
+		allocatedAddresses: map[string][]string{},
+
+		uncaptured: map[types.NamespacedName]model.UncapturedWorkload{},
+		interner:   newWorkloadInterner(),
 	}
 
 	podHandler := cache.ResourceEventHandlerFuncs{
@@ -616,10 +819,17 @@ func (c *Controller) setupIndex() *AmbientIndex {
 		UpdateFunc: func(oldObj, newObj any) {
 			updates := idx.handlePod(oldObj, newObj, false, c)
 			if len(updates) > 0 {
-				c.opts.XDSUpdater.ConfigUpdate(&model.PushRequest{
+				req := &model.PushRequest{
 					ConfigsUpdated: updates,
 					Reason:         []model.TriggerReason{model.AmbientUpdate},
-				})
+				}
+				old, p := controllers.Extract[*v1.Pod](oldObj), controllers.Extract[*v1.Pod](newObj)
+				if IsPodReady(old) != IsPodReady(p) {
+					// Readiness changes are pushed without waiting for the debounce, see PILOT_AMBIENT_READINESS_FAST_PUSH
+					req.Reason = []model.TriggerReason{model.AmbientReadinessUpdate}
+					req.Start = time.Now()
+				}
+				c.opts.XDSUpdater.ConfigUpdate(req)
 			}
 		},
 		DeleteFunc: func(obj any) {
@@ -633,6 +843,12 @@ func (c *Controller) setupIndex() *AmbientIndex {
 		},
 	}
 	c.podsClient.AddEventHandler(podHandler)
+	idx.podNodeIndex = kclient.CreateIndex[string, *v1.Pod](c.podsClient, func(p *v1.Pod) []string {
+		if p.Spec.NodeName == "" {
+			return nil
+		}
+		return []string{p.Spec.NodeName}
+	})
 
 	serviceHandler := cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj any) {
@@ -680,9 +896,133 @@ func (c *Controller) setupIndex() *AmbientIndex {
 	}
 	c.services.AddEventHandler(serviceHandler)
 	idx.serviceVipIndex = kclient.CreateIndex[string, *v1.Service](c.services, getVIPs)
+
+	// Whether a pod is expected to be captured depends on its namespace, so re-evaluate the namespace's pods
+	// when it is enrolled in or removed from ambient mode.
+	namespaceHandler := cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			ns := controllers.Extract[*v1.Namespace](obj)
+			if ns.Annotations[constants.AmbientTrustDomain] != "" {
+				// The pods of the namespace may have been handled before it
+				idx.handleNamespaceTrustDomain(ns, c)
+			}
+			idx.handleNamespace(ns, c)
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			old := controllers.Extract[*v1.Namespace](oldObj)
+			ns := controllers.Extract[*v1.Namespace](newObj)
+			if old.Annotations[constants.AmbientTrustDomain] != ns.Annotations[constants.AmbientTrustDomain] {
+				idx.handleNamespaceTrustDomain(ns, c)
+			}
+			if old.Labels[constants.DataplaneMode] == ns.Labels[constants.DataplaneMode] {
+				return
+			}
+			idx.handleNamespace(ns, c)
+		},
+	}
+	c.namespaces.AddEventHandler(namespaceHandler)
+
+	// Workloads carry the locality of their node, so they are updated when its topology labels change
+	c.nodes.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			// The pods of the node may have been handled before it
+			idx.handleNodeLocality(controllers.Extract[*v1.Node](obj), c)
+		},
+		UpdateFunc: func(oldObj, newObj any) {
+			old, node := controllers.Extract[*v1.Node](oldObj), controllers.Extract[*v1.Node](newObj)
+			if nodeLocality(old) != nodeLocality(node) {
+				idx.handleNodeLocality(node, c)
+			}
+		},
+	})
+	// The locality load balancing of Services falls back to the localityLbSetting of the mesh config
+	if c.opts.MeshWatcher != nil {
+		meshLocalityLbSetting := c.opts.MeshWatcher.Mesh().GetLocalityLbSetting()
+		c.opts.MeshWatcher.AddMeshHandler(func() {
+			setting := c.opts.MeshWatcher.Mesh().GetLocalityLbSetting()
+			if proto.Equal(meshLocalityLbSetting, setting) {
+				return
+			}
+			meshLocalityLbSetting = setting
+			c.queue.Push(func() error {
+				idx.handlePods(c.podsClient.List(metav1.NamespaceAll, klabels.Everything()), c)
+				return nil
+			})
+		})
+	}
+
+	// Workloads carry the gateway of their network, so they are updated when the gateways change
+	c.AppendNetworkGatewayHandler(func() {
+		c.queue.Push(func() error {
+			idx.handlePods(c.podsClient.List(metav1.NamespaceAll, klabels.Everything()), c)
+			return nil
+		})
+	})
 	return &idx
 }
 
+func (a *AmbientIndex) handleNamespace(ns *v1.Namespace, c *Controller) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, p := range c.podsClient.List(ns.Name, klabels.Everything()) {
+		a.updateUncaptured(p, false, c)
+	}
+}
+
+// updateUncaptured records whether a pod is expected to be captured by ztunnel but has not been enrolled.
+// The CNI node agent reports enrollment by annotating the pod, so that is what we correlate against.
+func (a *AmbientIndex) updateUncaptured(p *v1.Pod, isDelete bool, c *Controller) {
+	key := config.NamespacedName(p)
+	if !isDelete && c.podCaptureExpected(p) && p.Annotations[constants.AmbientRedirection] != constants.AmbientRedirectionEnabled {
+		a.uncaptured[key] = model.UncapturedWorkload{
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			Node:      p.Spec.NodeName,
+			Address:   p.Status.PodIP,
+			ClusterID: c.Cluster(),
+		}
+	} else {
+		delete(a.uncaptured, key)
+	}
+	uncapturedWorkloads.With(clusterLabel.Value(c.Cluster().String())).Record(float64(len(a.uncaptured)))
+}
+
+// podCaptureExpected determines if a pod should be enrolled in ambient mode by the CNI node agent.
+// This mirrors the eligibility checks performed by the node agent.
+func (c *Controller) podCaptureExpected(p *v1.Pod) bool {
+	if !IsPodRunning(p) || p.Spec.HostNetwork || p.Status.PodIP == "" {
+		return false
+	}
+	ns := c.namespaces.Get(p.Namespace, "")
+	if ns == nil || ns.Labels[constants.DataplaneMode] != constants.DataplaneModeAmbient {
+		return false
+	}
+	if kubelib.DataplaneModeNone(p) {
+		// Pods excluded from any dataplane are never captured by ztunnel
+		return false
+	}
+	if _, f := p.Annotations[annotation.SidecarStatus.Name]; f {
+		// Pods with sidecars are never captured by ztunnel
+		return false
+	}
+	return p.Annotations[constants.AmbientRedirection] != constants.AmbientRedirectionDisabled
+}
+
+// UncapturedWorkloads returns all pods in ambient enabled namespaces which have not been enrolled by the CNI node agent.
+func (c *Controller) UncapturedWorkloads() []model.UncapturedWorkload {
+	a := c.ambientIndex
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	res := maps.Values(a.uncaptured)
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Namespace != res[j].Namespace {
+			return res[i].Namespace < res[j].Namespace
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
 func (a *AmbientIndex) handlePod(oldObj, newObj any, isDelete bool, c *Controller) sets.Set[model.ConfigKey] {
 	p := controllers.Extract[*v1.Pod](newObj)
 	old := controllers.Extract[*v1.Pod](oldObj)
@@ -698,6 +1038,7 @@ func (a *AmbientIndex) handlePod(oldObj, newObj any, isDelete bool, c *Controlle
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
+	a.updateUncaptured(p, isDelete, c)
 	updates := sets.New[model.ConfigKey]()
 	// This is a waypoint update
 	if p.Labels[constants.ManagedGatewayLabel] == constants.ManagedGatewayMeshControllerLabel {
@@ -708,6 +1049,7 @@ func (a *AmbientIndex) handlePod(oldObj, newObj any, isDelete bool, c *Controlle
 				sets.DeleteCleanupLast(a.waypoints, scope, ip)
 				updates.Merge(a.updateWaypoint(scope, ip, true, c))
 			}
+			updates.Merge(a.updateGatewayWaypoint(p, scope, true))
 		} else {
 			if _, f := a.waypoints[scope]; !f {
 				a.waypoints[scope] = sets.New[string]()
@@ -715,7 +1057,9 @@ func (a *AmbientIndex) handlePod(oldObj, newObj any, isDelete bool, c *Controlle
 			if !a.waypoints[scope].InsertContains(ip) {
 				updates.Merge(a.updateWaypoint(scope, ip, false, c))
 			}
+			updates.Merge(a.updateGatewayWaypoint(p, scope, false))
 		}
+		updates.Merge(a.shiftWaypointRevisions(p.Namespace, p.Labels[constants.GatewayNameLabel], c))
 	}
 
 	var wl *model.WorkloadInfo
@@ -775,6 +1119,10 @@ func (a *AmbientIndex) handlePods(pods []*v1.Pod, c *Controller) {
 
 func (a *AmbientIndex) handleService(obj any, isDelete bool, c *Controller) map[model.ConfigKey]struct{} {
 	svc := controllers.Extract[*v1.Service](obj)
+	if svc.Spec.Type == v1.ServiceTypeExternalName {
+		// ExternalName Services resolve to an external name, and never select workloads
+		return nil
+	}
 	vips := getVIPs(svc)
 	pods := c.getPodsInService(svc)
 	var wls []*model.WorkloadInfo
@@ -791,6 +1139,9 @@ func (a *AmbientIndex) handleService(obj any, isDelete bool, c *Controller) map[
 
 	// We send an update for each *workload* IP address previously in the service; they may have changed
 	updates := map[model.ConfigKey]struct{}{}
+	if isHeadless(svc) {
+		a.updateHeadlessService(svc, wls, c, updates)
+	}
 	for _, vip := range vips {
 		for _, wl := range a.byService[vip] {
 			updates[model.ConfigKey{Kind: kind.Address, Name: wl.ResourceName()}] = struct{}{}
@@ -815,6 +1166,31 @@ func (a *AmbientIndex) handleService(obj any, isDelete bool, c *Controller) map[
 	return updates
 }
 
+// updateHeadlessService updates the workloads that were, or are now, selected by a headless Service.
+// Headless Services have no VIP to index their workloads by, so the previous workloads are found by hostname.
+func (a *AmbientIndex) updateHeadlessService(svc *v1.Service, selected []*model.WorkloadInfo, c *Controller,
+	updates map[model.ConfigKey]struct{},
+) {
+	current := sets.New[string]()
+	for _, wl := range selected {
+		current.Insert(wl.ResourceName())
+		updates[model.ConfigKey{Kind: kind.Address, Name: wl.ResourceName()}] = struct{}{}
+	}
+	hostname := string(kube.ServiceHostname(svc.Name, svc.Namespace, c.opts.DomainSuffix))
+	for ip, wl := range a.byPod {
+		if _, f := wl.HeadlessServices[hostname]; !f || current.Contains(ip) {
+			continue
+		}
+		// No longer selected by the Service
+		if p := c.podsClient.Get(wl.Name, wl.Namespace); p != nil {
+			if nwl := c.extractWorkload(p); nwl != nil {
+				a.byPod[ip] = nwl
+			}
+		}
+		updates[model.ConfigKey{Kind: kind.Address, Name: ip}] = struct{}{}
+	}
+}
+
 func (c *Controller) getPodsInService(svc *v1.Service) []*v1.Pod {
 	if svc.Spec.Selector == nil {
 		// services with nil selectors match nothing, not everything.
@@ -845,27 +1221,42 @@ func (c *Controller) PodInformation(addresses sets.Set[types.NamespacedName]) ([
 
 func (c *Controller) constructWorkload(pod *v1.Pod, waypoints []string, policies []string) *workloadapi.Workload {
 	vips := map[string]*workloadapi.PortList{}
+	var headless map[string]*workloadapi.PortList
+	hostname := ""
 	allServices := c.services.List(pod.Namespace, klabels.Everything())
 	if services := getPodServices(allServices, pod); len(services) > 0 {
+		proxyProtocolHosts := c.proxyProtocolHosts(pod.Namespace)
+		localityLbSettings := c.localityLbSettings(pod.Namespace)
+		meshLocalityLbSetting := c.meshLocalityLbSetting()
 		for _, svc := range services {
+			if svc.Spec.Type == v1.ServiceTypeExternalName {
+				// ExternalName Services resolve to an external name, so their selector is ignored
+				continue
+			}
+			svcHostname := string(kube.ServiceHostname(svc.Name, svc.Namespace, c.opts.DomainSuffix))
+			if isHeadless(svc) {
+				if headless == nil {
+					headless = map[string]*workloadapi.PortList{}
+				}
+				headless[svcHostname] = servicePorts(pod, svc)
+				headless[svcHostname].ProxyProtocol = proxyProtocol(proxyProtocolHosts, svcHostname)
+				if pod.Spec.Hostname != "" && pod.Spec.Subdomain == svc.Name {
+					hostname = pod.Spec.Hostname + "." + svcHostname
+				}
+				continue
+			}
+			hints := c.zoneHints(svc, pod.Status.PodIP)
 			for _, vip := range getVIPs(svc) {
 				if vips[vip] == nil {
-					vips[vip] = &workloadapi.PortList{}
-				}
-				for _, port := range svc.Spec.Ports {
-					if port.Protocol != v1.ProtocolTCP {
-						continue
-					}
-					targetPort, err := FindPort(pod, &port)
-					if err != nil {
-						log.Debug(err)
-						continue
+					vips[vip] = &workloadapi.PortList{
+						Service:               svcHostname,
+						ProxyProtocol:         proxyProtocol(proxyProtocolHosts, svcHostname),
+						NodeLocal:             vip == svc.Spec.ClusterIP && internalTrafficPolicyLocal(svc),
+						HintsForZones:         hints,
+						LocalityLoadBalancing: localityLoadBalancing(localityLbSettings, meshLocalityLbSetting, svcHostname),
 					}
-					vips[vip].Ports = append(vips[vip].Ports, &workloadapi.Port{
-						ServicePort: uint32(port.Port),
-						TargetPort:  uint32(targetPort),
-					})
 				}
+				vips[vip].Ports = append(vips[vip].Ports, servicePorts(pod, svc).Ports...)
 			}
 		}
 	}
@@ -877,7 +1268,11 @@ func (c *Controller) constructWorkload(pod *v1.Pod, waypoints []string, policies
 		Network:               c.network.String(),
 		ServiceAccount:        pod.Spec.ServiceAccountName,
 		Node:                  pod.Spec.NodeName,
+		Locality:              c.workloadLocality(pod),
+		NetworkGateway:        c.networkGateway(),
 		VirtualIps:            vips,
+		HeadlessServices:      headless,
+		Hostname:              hostname,
 		AuthorizationPolicies: policies,
 		Status:                workloadapi.WorkloadStatus_HEALTHY,
 		ClusterId:             c.Cluster().String(),
@@ -885,9 +1280,7 @@ func (c *Controller) constructWorkload(pod *v1.Pod, waypoints []string, policies
 	if !IsPodReady(pod) {
 		wl.Status = workloadapi.WorkloadStatus_UNHEALTHY
 	}
-	if td := spiffe.GetTrustDomain(); td != "cluster.local" {
-		wl.TrustDomain = td
-	}
+	wl.TrustDomain = c.workloadTrustDomain(pod.Namespace)
 
 	wl.WorkloadName, wl.WorkloadType = workloadNameAndType(pod)
 	wl.CanonicalName, wl.CanonicalRevision = kubelabels.CanonicalService(pod.Labels, wl.WorkloadName)
@@ -909,9 +1302,131 @@ func (c *Controller) constructWorkload(pod *v1.Pod, waypoints []string, policies
 		wl.Protocol = workloadapi.Protocol_HTTP
 		wl.NativeHbone = true
 	}
+	wl.ConnectionLimits = connectionLimits(pod)
+	wl.WaypointRequired = waypointRequired(pod)
+	wl.Labels = workloadLabels(pod.Labels)
+	wl.CaptureMode = captureMode(pod)
+	wl.Telemetry = c.workloadTelemetry(pod)
+	excludeTelemetryLabels(wl)
 	return wl
 }
 
+// excludeTelemetryLabels clears the fields of a workload backing the metric labels excluded with
+// PILOT_AMBIENT_TELEMETRY_EXCLUDED_LABELS. These fields are only used by ztunnel for telemetry.
+func excludeTelemetryLabels(wl *workloadapi.Workload) {
+	excluded := features.AmbientTelemetryExcludedLabels
+	if excluded.Contains("canonical_revision") {
+		wl.CanonicalRevision = ""
+	}
+	if excluded.Contains("workload") {
+		wl.WorkloadName = ""
+	}
+	if excluded.Contains("service") {
+		for _, ports := range wl.VirtualIps {
+			ports.Service = ""
+		}
+	}
+}
+
+// captureMode returns how the traffic of the pod is captured, as reported by the injector and the CNI node agent.
+func captureMode(pod *v1.Pod) workloadapi.CaptureMode {
+	if _, f := pod.Annotations[annotation.SidecarStatus.Name]; f {
+		return workloadapi.CaptureMode_SIDECAR
+	}
+	if pod.Annotations[constants.AmbientRedirection] == constants.AmbientRedirectionEnabled {
+		return workloadapi.CaptureMode_AMBIENT
+	}
+	return workloadapi.CaptureMode_UNCAPTURED
+}
+
+// workloadLabels returns the pod labels configured to be sent to ztunnel.
+func workloadLabels(podLabels map[string]string) map[string]string {
+	var res map[string]string
+	for l := range features.AmbientWorkloadLabels {
+		if v, f := podLabels[l]; f {
+			if res == nil {
+				res = map[string]string{}
+			}
+			res[l] = v
+		}
+	}
+	return res
+}
+
+// connectionLimits builds the limits ztunnel enforces for a pod from its annotations. Invalid values are ignored.
+func connectionLimits(pod *v1.Pod) *workloadapi.ConnectionLimits {
+	limits := &workloadapi.ConnectionLimits{}
+	for name, value := range pod.Annotations {
+		var err error
+		switch name {
+		case constants.AmbientMaxConnections:
+			var n uint64
+			if n, err = strconv.ParseUint(value, 10, 32); err == nil {
+				limits.MaxConnections = uint32(n)
+			}
+		case constants.AmbientMaxConnectionRate:
+			var n uint64
+			if n, err = strconv.ParseUint(value, 10, 32); err == nil {
+				limits.MaxConnectionRate = uint32(n)
+			}
+		case constants.AmbientMaxBandwidth:
+			var q resource.Quantity
+			if q, err = resource.ParseQuantity(value); err == nil && q.Sign() < 0 {
+				err = fmt.Errorf("bandwidth must not be negative")
+			}
+			if err == nil {
+				limits.MaxBandwidth = uint64(q.Value())
+			}
+		}
+		if err != nil {
+			log.Warnf("ignoring invalid %s annotation %q on pod %s/%s: %v", name, value, pod.Namespace, pod.Name, err)
+		}
+	}
+	if proto.Equal(limits, &workloadapi.ConnectionLimits{}) {
+		return nil
+	}
+	return limits
+}
+
+// servicePorts returns the TCP ports of the Service, with the port they target on the pod.
+func servicePorts(pod *v1.Pod, svc *v1.Service) *workloadapi.PortList {
+	ports := &workloadapi.PortList{}
+	for _, port := range svc.Spec.Ports {
+		if port.Protocol != v1.ProtocolTCP {
+			continue
+		}
+		targetPort, err := FindPort(pod, &port)
+		if err != nil {
+			log.Debug(err)
+			continue
+		}
+		ports.Ports = append(ports.Ports, &workloadapi.Port{
+			ServicePort: uint32(port.Port),
+			TargetPort:  uint32(targetPort),
+		})
+	}
+	return ports
+}
+
+func isHeadless(svc *v1.Service) bool {
+	return svc.Spec.ClusterIP == v1.ClusterIPNone
+}
+
+// waypointRequired returns whether the pod requires its traffic to be processed by its waypoint. Invalid values are
+// ignored.
+func waypointRequired(pod *v1.Pod) bool {
+	value, f := pod.Annotations[constants.AmbientWaypointRequired]
+	if !f {
+		return false
+	}
+	required, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Warnf("ignoring invalid %s annotation %q on pod %s/%s: %v", constants.AmbientWaypointRequired, value, pod.Namespace, pod.Name, err)
+		return false
+	}
+	return required
+}
+
 func parseIP(ip string) []byte {
 	addr, err := netip.ParseAddr(ip)
 	if err != nil {
@@ -926,7 +1441,10 @@ func getVIPs(svc *v1.Service) []string {
 		res = append(res, svc.Spec.ClusterIP)
 	}
 	for _, ing := range svc.Status.LoadBalancer.Ingress {
-		res = append(res, ing.IP)
+		// Some load balancers are only reachable by hostname
+		if ing.IP != "" {
+			res = append(res, ing.IP)
+		}
 	}
 	return res
 }
@@ -958,14 +1476,12 @@ func (c *Controller) AdditionalPodSubscriptions(
 
 	// Next, as an optimization, we will send all node-local endpoints
 	if nodeName := proxy.Metadata.NodeName; nodeName != "" {
-		for _, wl := range c.ambientIndex.All() {
-			if wl.Node == nodeName {
-				n := types.NamespacedName{Name: wl.ResourceName()}
-				if currentSubs.Contains(n) {
-					continue
-				}
-				shouldSubscribe.Insert(n)
+		for _, wl := range c.ambientIndex.nodeWorkloads(nodeName) {
+			n := types.NamespacedName{Name: wl.ResourceName()}
+			if currentSubs.Contains(n) {
+				continue
 			}
+			shouldSubscribe.Insert(n)
 		}
 	}
 
@@ -16,22 +16,37 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"net/netip"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	rbacpb "github.com/envoyproxy/go-control-plane/envoy/config/rbac/v3"
+	matcherpb "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	wrappers "google.golang.org/protobuf/types/known/wrapperspb"
 	corev1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
 
+	"istio.io/api/annotation"
+	"istio.io/api/label"
 	meshconfig "istio.io/api/mesh/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
 	authz "istio.io/api/security/v1beta1"
+	tpb "istio.io/api/telemetry/v1alpha1"
 	"istio.io/api/type/v1beta1"
 	"istio.io/istio/pilot/pkg/config/kube/crd"
 	"istio.io/istio/pilot/pkg/config/memory"
 	"istio.io/istio/pilot/pkg/features"
 	"istio.io/istio/pilot/pkg/model"
+	authzmodel "istio.io/istio/pilot/pkg/security/authz/model"
+	"istio.io/istio/pilot/pkg/serviceregistry/serviceentry"
 	"istio.io/istio/pilot/pkg/serviceregistry/util/xdsfake"
 	"istio.io/istio/pilot/test/util"
 	"istio.io/istio/pkg/config"
@@ -40,6 +55,7 @@ import (
 	"istio.io/istio/pkg/config/schema/collections"
 	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/kube/kclient/clienttest"
+	"istio.io/istio/pkg/spiffe"
 	"istio.io/istio/pkg/test"
 	"istio.io/istio/pkg/test/util/assert"
 	"istio.io/istio/pkg/test/util/file"
@@ -222,11 +238,14 @@ func TestAmbientIndex(t *testing.T) {
 	assert.Equal(t, controller.ambientIndex.Lookup("127.0.0.3")[0].WaypointAddresses, [][]byte{netip.MustParseAddr("127.0.0.200").AsSlice()})
 
 	// Add another one, expect the same result
+	previous := controller.ambientIndex.Lookup("127.0.0.3")[0]
 	addPods("127.0.0.201", "waypoint2-ns", "namespace-wide", map[string]string{constants.ManagedGatewayLabel: constants.ManagedGatewayMeshControllerLabel}, nil)
 	assertEvent("127.0.0.1", "127.0.0.2", "127.0.0.201", "127.0.0.3")
 	assert.Equal(t,
 		controller.ambientIndex.Lookup("127.0.0.3")[0].WaypointAddresses,
 		[][]byte{netip.MustParseAddr("127.0.0.200").AsSlice(), netip.MustParseAddr("127.0.0.201").AsSlice()})
+	// Workloads are replaced rather than mutated, as istiod caches their marshaled form
+	assert.Equal(t, previous.WaypointAddresses, [][]byte{netip.MustParseAddr("127.0.0.200").AsSlice()})
 	// Waypoints do not have waypoints
 	assert.Equal(t,
 		controller.ambientIndex.Lookup("127.0.0.200")[0].WaypointAddresses,
@@ -244,16 +263,23 @@ func TestAmbientIndex(t *testing.T) {
 		[][]byte{netip.MustParseAddr("127.0.0.200").AsSlice(), netip.MustParseAddr("127.0.0.201").AsSlice()})
 
 	// Delete a waypoint
+	previous = controller.ambientIndex.Lookup("127.0.0.3")[0]
 	deletePod("waypoint2-ns")
 	assertEvent("127.0.0.1", "127.0.0.2", "127.0.0.201", "127.0.0.3", "svc1.ns1.svc.company.com")
 	// Workload should be updated
 	assert.Equal(t,
 		controller.ambientIndex.Lookup("127.0.0.3")[0].WaypointAddresses,
 		[][]byte{netip.MustParseAddr("127.0.0.200").AsSlice()})
-	// As should workload via Service
+	assert.Equal(t,
+		previous.WaypointAddresses,
+		[][]byte{netip.MustParseAddr("127.0.0.200").AsSlice(), netip.MustParseAddr("127.0.0.201").AsSlice()})
+	// As should workload via Service, which holds the replaced workloads
 	assert.Equal(t,
 		controller.ambientIndex.Lookup("10.0.0.1")[0].WaypointAddresses,
 		[][]byte{netip.MustParseAddr("127.0.0.200").AsSlice()})
+	for _, wl := range controller.ambientIndex.Lookup("10.0.0.1") {
+		assert.Equal(t, wl == controller.ambientIndex.Lookup(wl.ResourceName())[0], true)
+	}
 
 	addPods("127.0.0.201", "waypoint2-sa", "waypoint-sa",
 		map[string]string{constants.ManagedGatewayLabel: constants.ManagedGatewayMeshControllerLabel},
@@ -406,9 +432,12 @@ func TestRBACConvert(t *testing.T) {
 			continue
 		}
 		t.Run(name, func(t *testing.T) {
-			pol, _, err := crd.ParseInputs(file.AsStringOrFail(t, f))
+			// The policies are not validated, as some hold values which are only rejected by the validation webhook
+			var obj crd.IstioKind
+			assert.NoError(t, yaml.Unmarshal([]byte(file.AsStringOrFail(t, f)), &obj))
+			pol, err := crd.ConvertObject(collections.AuthorizationPolicy, &obj, "")
 			assert.NoError(t, err)
-			o := convertAuthorizationPolicy("istio-system", pol[0])
+			o := ConvertAuthorizationPolicy("istio-system", nil, *pol)
 			msg := ""
 			if o != nil {
 				msg, err = protomarshal.ToYAML(o)
@@ -419,3 +448,1162 @@ func TestRBACConvert(t *testing.T) {
 		})
 	}
 }
+
+// TestPrincipalsEnvoyParity checks that ztunnel matches the principals of a policy as the Envoy RBAC built for
+// sidecars and waypoints does: the same identities for the values ztunnel can match, no identity for ALLOW otherwise,
+// and at least the identities matched by Envoy for DENY.
+func TestPrincipalsEnvoyParity(t *testing.T) {
+	identities := []string{
+		"cluster.local/ns/ns1/sa/sa1",
+		"cluster.local/ns/ns1/sa/admin",
+		"cluster.local/ns/ns2/sa/admin",
+		"cluster.local/ns/*/sa/admin",
+		"other.domain/ns/ns1/sa/sa1",
+		"cluster.local/ns/ns1/sa/",
+	}
+	values := []string{
+		"cluster.local/ns/ns1/sa/sa1",
+		"*",
+		"cluster.local/ns/ns1/*",
+		"*/sa/admin",
+		"cluster.local/ns/*/sa/admin",
+		"*/sa/*",
+		"*ns1*",
+	}
+	for _, action := range []authz.AuthorizationPolicy_Action{authz.AuthorizationPolicy_ALLOW, authz.AuthorizationPolicy_DENY} {
+		for _, v := range values {
+			for _, negative := range []bool{false, true} {
+				source := &authz.Source{Principals: []string{v}}
+				if negative {
+					// The policies only holding negative values match all the other identities
+					source = &authz.Source{NotPrincipals: []string{v}}
+				}
+				rule := &authz.Rule{From: []*authz.Rule_From{{Source: source}}}
+				t.Run(fmt.Sprintf("%v/%v/negative=%v", action, v, negative), func(t *testing.T) {
+					m, err := authzmodel.New(rule)
+					assert.NoError(t, err)
+					envoy, err := m.Generate(true, true, rbacpb.RBAC_Action(action))
+					assert.NoError(t, err)
+					ztunnel := ConvertAuthorizationPolicy("istio-system", nil, config.Config{
+						Meta: config.Meta{Name: "policy", Namespace: "ns1"},
+						Spec: &authz.AuthorizationPolicy{Action: action, Rules: []*authz.Rule{rule}},
+					})
+					for _, id := range identities {
+						want := envoyPrincipalsMatch(t, envoy.Principals, spiffe.URIPrefix+id)
+						got := ztunnelPolicyMatches(ztunnel, id)
+						if action == authz.AuthorizationPolicy_DENY {
+							// DENY policies may be widened, never narrowed
+							if want && !got {
+								t.Errorf("%s: denied by Envoy but not by ztunnel", id)
+							}
+							continue
+						}
+						if singleWildcard(v) && got != want {
+							t.Errorf("%s: matched by ztunnel: %v, by Envoy: %v", id, got, want)
+						}
+						if !singleWildcard(v) && got {
+							t.Errorf("%s: allowed by ztunnel for a value it cannot match", id)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// envoyPrincipalsMatch evaluates the Envoy RBAC principals for the authenticated principal.
+func envoyPrincipalsMatch(t *testing.T, principals []*rbacpb.Principal, principal string) bool {
+	for _, p := range principals {
+		if envoyPrincipalMatches(t, p, principal) {
+			return true
+		}
+	}
+	return false
+}
+
+func envoyPrincipalMatches(t *testing.T, p *rbacpb.Principal, principal string) bool {
+	switch id := p.Identifier.(type) {
+	case *rbacpb.Principal_Any:
+		return id.Any
+	case *rbacpb.Principal_OrIds:
+		return envoyPrincipalsMatch(t, id.OrIds.Ids, principal)
+	case *rbacpb.Principal_AndIds:
+		for _, p := range id.AndIds.Ids {
+			if !envoyPrincipalMatches(t, p, principal) {
+				return false
+			}
+		}
+		return true
+	case *rbacpb.Principal_NotId:
+		return !envoyPrincipalMatches(t, id.NotId, principal)
+	case *rbacpb.Principal_Authenticated_:
+		m := id.Authenticated.PrincipalName
+		switch mp := m.MatchPattern.(type) {
+		case *matcherpb.StringMatcher_Exact:
+			return principal == mp.Exact
+		case *matcherpb.StringMatcher_Prefix:
+			return strings.HasPrefix(principal, mp.Prefix)
+		case *matcherpb.StringMatcher_Suffix:
+			return strings.HasSuffix(principal, mp.Suffix)
+		case *matcherpb.StringMatcher_SafeRegex:
+			// Envoy regexes match the whole value
+			return regexp.MustCompile("^(?:" + mp.SafeRegex.Regex + ")$").MatchString(principal)
+		}
+	}
+	t.Fatalf("unexpected principal %v", p)
+	return false
+}
+
+// ztunnelPolicyMatches evaluates the groups of the policy for the identity, as ztunnel does for the source principal.
+func ztunnelPolicyMatches(pol *workloadapi.Authorization, identity string) bool {
+	stringMatches := func(matches []*workloadapi.StringMatch) bool {
+		for _, m := range matches {
+			switch mt := m.MatchType.(type) {
+			case *workloadapi.StringMatch_Exact:
+				if identity == mt.Exact {
+					return true
+				}
+			case *workloadapi.StringMatch_Prefix:
+				if strings.HasPrefix(identity, mt.Prefix) {
+					return true
+				}
+			case *workloadapi.StringMatch_Suffix:
+				if strings.HasSuffix(identity, mt.Suffix) {
+					return true
+				}
+			case *workloadapi.StringMatch_Presence:
+				return true
+			}
+		}
+		return false
+	}
+	for _, g := range pol.GetGroups() {
+		matched := true
+		for _, r := range g.Rules {
+			ruleMatched := false
+			for _, m := range r.Matches {
+				if (len(m.Principals) == 0 || stringMatches(m.Principals)) && !stringMatches(m.NotPrincipals) {
+					ruleMatched = true
+				}
+			}
+			matched = matched && ruleMatched
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func TestConnectionLimits(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		want        *workloadapi.ConnectionLimits
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name: "all limits",
+			annotations: map[string]string{
+				constants.AmbientMaxConnections:    "100",
+				constants.AmbientMaxConnectionRate: "10",
+				constants.AmbientMaxBandwidth:      "10M",
+			},
+			want: &workloadapi.ConnectionLimits{MaxConnections: 100, MaxConnectionRate: 10, MaxBandwidth: 10_000_000},
+		},
+		{
+			name: "invalid values ignored",
+			annotations: map[string]string{
+				constants.AmbientMaxConnections:    "-1",
+				constants.AmbientMaxConnectionRate: "5000000000",
+				constants.AmbientMaxBandwidth:      "1Gi",
+			},
+			want: &workloadapi.ConnectionLimits{MaxBandwidth: 1 << 30},
+		},
+		{
+			name:        "all invalid",
+			annotations: map[string]string{constants.AmbientMaxBandwidth: "-10M"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := generatePod("127.0.0.1", "pod", "ns1", "sa1", "node1", nil, tt.annotations)
+			assert.Equal(t, connectionLimits(pod), tt.want)
+		})
+	}
+}
+
+func TestWaypointRequired(t *testing.T) {
+	cases := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{"no annotation", nil, false},
+		{"required", map[string]string{constants.AmbientWaypointRequired: "true"}, true},
+		{"not required", map[string]string{constants.AmbientWaypointRequired: "false"}, false},
+		{"invalid", map[string]string{constants.AmbientWaypointRequired: "yes"}, false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := generatePod("127.0.0.1", "pod", "ns1", "sa1", "node1", nil, tt.annotations)
+			assert.Equal(t, waypointRequired(pod), tt.want)
+		})
+	}
+}
+
+func TestWorkloadLabels(t *testing.T) {
+	podLabels := map[string]string{"app": "a", "version": "v1", "pod-template-hash": "abc"}
+	assert.Equal(t, workloadLabels(podLabels), nil)
+
+	test.SetForTest(t, &features.AmbientWorkloadLabels, sets.New("app", "version", "team"))
+	assert.Equal(t, workloadLabels(podLabels), map[string]string{"app": "a", "version": "v1"})
+	assert.Equal(t, workloadLabels(nil), nil)
+}
+
+func TestCaptureMode(t *testing.T) {
+	pod := func(annotations map[string]string) *corev1.Pod {
+		return generatePod("127.0.0.1", "pod", "ns1", "sa1", "node1", nil, annotations)
+	}
+	assert.Equal(t, captureMode(pod(nil)), workloadapi.CaptureMode_UNCAPTURED)
+	assert.Equal(t, captureMode(pod(map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled})),
+		workloadapi.CaptureMode_AMBIENT)
+	assert.Equal(t, captureMode(pod(map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionDisabled})),
+		workloadapi.CaptureMode_UNCAPTURED)
+	assert.Equal(t, captureMode(pod(map[string]string{annotation.SidecarStatus.Name: "{}"})), workloadapi.CaptureMode_SIDECAR)
+}
+
+func TestExcludeTelemetryLabels(t *testing.T) {
+	build := func() *workloadapi.Workload {
+		return &workloadapi.Workload{
+			CanonicalName:     "a",
+			CanonicalRevision: "v1",
+			WorkloadName:      "a-v1",
+			VirtualIps:        map[string]*workloadapi.PortList{"10.0.0.1": {Service: "a.ns1.svc.cluster.local"}},
+		}
+	}
+	wl := build()
+	excludeTelemetryLabels(wl)
+	assert.Equal(t, wl, build())
+
+	test.SetForTest(t, &features.AmbientTelemetryExcludedLabels, sets.New("canonical_revision", "workload", "service"))
+	excludeTelemetryLabels(wl)
+	assert.Equal(t, wl, &workloadapi.Workload{
+		CanonicalName: "a",
+		VirtualIps:    map[string]*workloadapi.PortList{"10.0.0.1": {}},
+	})
+}
+
+func TestUncapturedWorkloads(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	nc := clienttest.Wrap(t, controller.namespaces)
+	assertUncaptured := func(names ...string) {
+		t.Helper()
+		assert.EventuallyEqual(t, func() []string {
+			var res []string
+			for _, wl := range controller.UncapturedWorkloads() {
+				res = append(res, wl.Name)
+			}
+			return res
+		}, names, retry.Timeout(time.Second*3))
+	}
+	setNamespace := func(mode string) {
+		t.Helper()
+		nc.CreateOrUpdate(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{constants.DataplaneMode: mode}},
+		})
+	}
+
+	setNamespace("")
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "captured", "ns1", "sa1", "node1", nil,
+		map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled}))
+	pc.CreateOrUpdate(generatePod("127.0.0.2", "uncaptured", "ns1", "sa1", "node1", nil, nil))
+	pc.CreateOrUpdate(generatePod("127.0.0.3", "opt-out", "ns1", "sa1", "node1", nil,
+		map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionDisabled}))
+	pc.CreateOrUpdate(generatePod("127.0.0.5", "dataplane-none", "ns1", "sa1", "node1",
+		map[string]string{constants.DataplaneMode: constants.DataplaneModeNone}, nil))
+	assertUncaptured()
+
+	// Enabling ambient on the namespace should surface the pod that is not enrolled
+	setNamespace(constants.DataplaneModeAmbient)
+	assertUncaptured("uncaptured")
+
+	// Once the node agent enrolls the pod, it is no longer reported
+	pc.Update(generatePod("127.0.0.2", "uncaptured", "ns1", "sa1", "node1", nil,
+		map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionEnabled}))
+	assertUncaptured()
+
+	pc.CreateOrUpdate(generatePod("127.0.0.4", "uncaptured2", "ns1", "sa1", "node1", nil, nil))
+	assertUncaptured("uncaptured2")
+	pc.Delete("uncaptured2", "ns1")
+	assertUncaptured()
+}
+
+func TestAmbientNodeWorkloads(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	assertNode := func(node string, names ...string) {
+		t.Helper()
+		assert.EventuallyEqual(t, func() sets.String {
+			have := sets.New[string]()
+			for _, wl := range controller.ambientIndex.nodeWorkloads(node) {
+				have.Insert(wl.Name)
+			}
+			return have
+		}, sets.New(names...), retry.Timeout(time.Second*3))
+	}
+
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "name1", "ns1", "sa1", "node1", nil, nil))
+	pc.CreateOrUpdate(generatePod("127.0.0.2", "name2", "ns1", "sa1", "node1", nil, nil))
+	pc.CreateOrUpdate(generatePod("127.0.0.3", "name3", "ns1", "sa1", "node2", nil, nil))
+	assertNode("node1", "name1", "name2")
+	assertNode("node2", "name3")
+	assertNode("node3")
+
+	// On-demand ztunnels are subscribed to the workloads of their node they are not subscribed to yet
+	proxy := &model.Proxy{Metadata: &model.NodeMetadata{NodeName: "node1"}}
+	name1 := types.NamespacedName{Name: controller.ambientIndex.Lookup("127.0.0.1")[0].ResourceName()}
+	name2 := types.NamespacedName{Name: controller.ambientIndex.Lookup("127.0.0.2")[0].ResourceName()}
+	assert.Equal(t, controller.AdditionalPodSubscriptions(proxy, sets.New[types.NamespacedName](), sets.New(name1)), sets.New(name2))
+
+	// A pod recreated on another node moves to the workloads of that node
+	pc.Delete("name2", "ns1")
+	pc.CreateOrUpdate(generatePod("127.0.0.4", "name2", "ns1", "sa1", "node2", nil, nil))
+	assertNode("node1", "name1")
+	assertNode("node2", "name2", "name3")
+
+	// The IP of a deleted pod reused by a pod of another node is only a workload of the new node
+	pc.Delete("name1", "ns1")
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "name4", "ns1", "sa1", "node2", nil, nil))
+	assertNode("node1")
+	assertNode("node2", "name2", "name3", "name4")
+
+	pc.Delete("name2", "ns1")
+	pc.Delete("name3", "ns1")
+	pc.Delete("name4", "ns1")
+	assertNode("node2")
+}
+
+func TestAmbientEgressWaypoint(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	cfg.RegisterEventHandler(gvk.ServiceEntry, controller.ServiceEntryHandler)
+	go cfg.Run(test.NewStop(t))
+
+	addWaypoint := func(ip, name string) {
+		t.Helper()
+		pod := generatePod(ip, name, "ns1", "waypoint", "node1", map[string]string{
+			constants.ManagedGatewayLabel: constants.ManagedGatewayMeshControllerLabel,
+			constants.GatewayNameLabel:    "egress",
+		}, nil)
+		pod.Status = corev1.PodStatus{}
+		newPod := pc.Create(pod)
+		setPodReady(newPod)
+		newPod.Status.PodIP = ip
+		newPod.Status.Phase = corev1.PodRunning
+		pc.UpdateStatus(newPod)
+	}
+	serviceEntry := config.Config{
+		Meta: config.Meta{
+			GroupVersionKind: gvk.ServiceEntry,
+			Name:             "external",
+			Namespace:        "ns1",
+			Labels:           map[string]string{constants.AmbientUseWaypoint: "egress"},
+		},
+		Spec: &networking.ServiceEntry{
+			Hosts:     []string{"example.com"},
+			Addresses: []string{"240.240.0.1", "240.240.1.0/24"},
+			Ports:     []*networking.ServicePort{{Number: 443, Name: "tls", Protocol: "TLS"}},
+		},
+	}
+	assertWaypoints := func(want ...string) {
+		t.Helper()
+		assert.EventuallyEqual(t, func() []string {
+			wls := controller.ambientIndex.Lookup("240.240.0.1")
+			if len(wls) != 1 {
+				return nil
+			}
+			res := []string{}
+			for _, a := range wls[0].WaypointAddresses {
+				ip, _ := netip.AddrFromSlice(a)
+				res = append(res, ip.String())
+			}
+			return res
+		}, want, retry.Timeout(time.Second*3))
+	}
+
+	if _, err := cfg.Create(serviceEntry); err != nil {
+		t.Fatal(err)
+	}
+	// No waypoint is running yet
+	assertWaypoints()
+	wl := controller.ambientIndex.Lookup("240.240.0.1")[0]
+	assert.Equal(t, wl.Egress, true)
+	assert.Equal(t, wl.VirtualIps, map[string]*workloadapi.PortList{
+		"240.240.0.1": {Ports: []*workloadapi.Port{{ServicePort: 443, TargetPort: 443}}, Service: "example.com"},
+	})
+
+	addWaypoint("127.0.0.10", "egress-1")
+	assertWaypoints("127.0.0.10")
+	addWaypoint("127.0.0.11", "egress-2")
+	assertWaypoints("127.0.0.10", "127.0.0.11")
+
+	// The waypoint is responsible for the ServiceEntry workload
+	scope := model.WaypointScope{Namespace: "ns1"}
+	assert.EventuallyEqual(t, func() int {
+		n := 0
+		for _, wl := range controller.WorkloadsForWaypoint(scope) {
+			if wl.Egress {
+				n++
+			}
+		}
+		return n
+	}, 1)
+
+	pc.Delete("egress-1", "ns1")
+	assertWaypoints("127.0.0.11")
+
+	// Removing the label detaches the ServiceEntry from the waypoint
+	serviceEntry.Labels = nil
+	if _, err := cfg.Update(serviceEntry); err != nil {
+		t.Fatal(err)
+	}
+	assertWaypoints()
+
+	if err := cfg.Delete(gvk.ServiceEntry, "external", "ns1", nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, func() int { return len(controller.ambientIndex.Lookup("240.240.0.1")) }, 0)
+}
+
+func TestAmbientEgressResolution(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	test.SetForTest(t, &features.AmbientEgressWaypoint, "istio-egress/egress")
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	cfg.RegisterEventHandler(gvk.ServiceEntry, controller.ServiceEntryHandler)
+	go cfg.Run(test.NewStop(t))
+
+	ambient := map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient}
+	createServiceEntry := func(name string, labels map[string]string, se *networking.ServiceEntry) {
+		t.Helper()
+		if _, err := cfg.Create(config.Config{
+			Meta: config.Meta{GroupVersionKind: gvk.ServiceEntry, Name: name, Namespace: "ns1", Labels: labels},
+			Spec: se,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lookup := func(ip string) []*model.WorkloadInfo {
+		t.Helper()
+		var wls []*model.WorkloadInfo
+		retry.UntilOrFail(t, func() bool {
+			wls = controller.ambientIndex.Lookup(ip)
+			return len(wls) > 0
+		}, retry.Timeout(time.Second*3))
+		return wls
+	}
+	ports := func(service string) *workloadapi.PortList {
+		return &workloadapi.PortList{Ports: []*workloadapi.Port{{ServicePort: 443, TargetPort: 8443}}, Service: service}
+	}
+
+	createServiceEntry("dns", ambient, &networking.ServiceEntry{
+		Hosts:      []string{"dns.example.com"},
+		Addresses:  []string{"240.240.0.1"},
+		Ports:      []*networking.ServicePort{{Number: 443, Name: "tls", Protocol: "TLS", TargetPort: 8443}},
+		Resolution: networking.ServiceEntry_DNS,
+	})
+	wl := lookup("240.240.0.1")[0]
+	assert.Equal(t, wl.Resolution, workloadapi.Resolution_DNS)
+	// Without endpoints, the host itself is resolved
+	assert.Equal(t, wl.DnsEndpoints, map[string]*workloadapi.PortList{"dns.example.com": ports("dns.example.com")})
+	assert.Equal(t, wl.VirtualIps, map[string]*workloadapi.PortList{"240.240.0.1": ports("dns.example.com")})
+
+	// Otherwise all the endpoints are resolved, with their own target ports
+	createServiceEntry("dns-endpoints", ambient, &networking.ServiceEntry{
+		Hosts:     []string{"dns-endpoints.example.com"},
+		Addresses: []string{"240.240.0.6"},
+		Ports:     []*networking.ServicePort{{Number: 443, Name: "tls", Protocol: "TLS", TargetPort: 8443}},
+		Endpoints: []*networking.WorkloadEntry{
+			{Address: "a.backend.example.com"},
+			{Address: "b.backend.example.com", Ports: map[string]uint32{"tls": 9443}},
+		},
+		Resolution: networking.ServiceEntry_DNS,
+	})
+	wl = lookup("240.240.0.6")[0]
+	assert.Equal(t, wl.DnsEndpoints, map[string]*workloadapi.PortList{
+		"a.backend.example.com": ports("dns-endpoints.example.com"),
+		"b.backend.example.com": {Ports: []*workloadapi.Port{{ServicePort: 443, TargetPort: 9443}}, Service: "dns-endpoints.example.com"},
+	})
+	assert.Equal(t, wl.VirtualIps, map[string]*workloadapi.PortList{"240.240.0.6": ports("dns-endpoints.example.com")})
+
+	createServiceEntry("static", ambient, &networking.ServiceEntry{
+		Hosts:     []string{"static.example.com"},
+		Addresses: []string{"240.240.0.2"},
+		Ports:     []*networking.ServicePort{{Number: 443, Name: "tls", Protocol: "TLS"}},
+		Endpoints: []*networking.WorkloadEntry{
+			{Address: "2.0.0.1", Ports: map[string]uint32{"tls": 8443}},
+			{Address: "2.0.0.2", Ports: map[string]uint32{"tls": 8443}},
+		},
+		Resolution: networking.ServiceEntry_STATIC,
+	})
+	wl = lookup("2.0.0.1")[0]
+	assert.Equal(t, wl.Resolution, workloadapi.Resolution_STATIC)
+	assert.Equal(t, wl.VirtualIps, map[string]*workloadapi.PortList{"240.240.0.2": ports("static.example.com")})
+	// The virtual IP is not a workload, but resolves to the endpoints
+	vips := []string{}
+	for _, wl := range lookup("240.240.0.2") {
+		vips = append(vips, wl.ResourceName())
+	}
+	assert.Equal(t, vips, []string{"2.0.0.1", "2.0.0.2"})
+
+	// ServiceEntries sharing an endpoint are kept apart
+	createServiceEntry("static2", ambient, &networking.ServiceEntry{
+		Hosts:      []string{"static2.example.com"},
+		Addresses:  []string{"240.240.0.4"},
+		Ports:      []*networking.ServicePort{{Number: 443, Name: "tls", Protocol: "TLS"}},
+		Endpoints:  []*networking.WorkloadEntry{{Address: "2.0.0.1", Ports: map[string]uint32{"tls": 8443}}},
+		Resolution: networking.ServiceEntry_STATIC,
+	})
+	assert.Equal(t, lookup("240.240.0.4")[0].Name, "static2")
+	// The workload of the first ServiceEntry is exposed at the shared address
+	assert.Equal(t, lookup("2.0.0.1")[0].Name, "static")
+	if err := cfg.Delete(gvk.ServiceEntry, "static", "ns1", nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, func() string {
+		wls := controller.ambientIndex.Lookup("2.0.0.1")
+		if len(wls) != 1 {
+			return ""
+		}
+		return wls[0].Name
+	}, "static2")
+	assert.Equal(t, len(controller.ambientIndex.Lookup("240.240.0.2")), 0)
+
+	// ServiceEntries which do not opt in are not exposed to ztunnel
+	createServiceEntry("sidecar", nil, &networking.ServiceEntry{
+		Hosts:      []string{"sidecar.example.com"},
+		Addresses:  []string{"240.240.0.5"},
+		Ports:      []*networking.ServicePort{{Number: 443, Name: "tls", Protocol: "TLS"}},
+		Resolution: networking.ServiceEntry_DNS,
+	})
+
+	// Wildcard hosts route through the default egress waypoint, without opting in
+	createServiceEntry("wildcard", nil, &networking.ServiceEntry{
+		Hosts:      []string{"*.example.com"},
+		Addresses:  []string{"240.240.0.3"},
+		Ports:      []*networking.ServicePort{{Number: 443, Name: "tls", Protocol: "TLS"}},
+		Resolution: networking.ServiceEntry_NONE,
+	})
+	wl = lookup("240.240.0.3")[0]
+	assert.Equal(t, wl.EgressWaypoint, types.NamespacedName{Namespace: "istio-egress", Name: "egress"})
+	assert.Equal(t, len(wl.WaypointAddresses), 0)
+
+	pod := generatePod("127.0.0.10", "egress-1", "istio-egress", "waypoint", "node1", map[string]string{
+		constants.ManagedGatewayLabel: constants.ManagedGatewayMeshControllerLabel,
+		constants.GatewayNameLabel:    "egress",
+	}, nil)
+	pod.Status = corev1.PodStatus{}
+	newPod := pc.Create(pod)
+	setPodReady(newPod)
+	newPod.Status.PodIP = "127.0.0.10"
+	newPod.Status.Phase = corev1.PodRunning
+	pc.UpdateStatus(newPod)
+	assert.EventuallyEqual(t, func() int {
+		return len(controller.ambientIndex.Lookup("240.240.0.3")[0].WaypointAddresses)
+	}, 1)
+	// ServiceEntries without wildcard hosts are not affected
+	assert.Equal(t, len(controller.ambientIndex.Lookup("240.240.0.1")[0].WaypointAddresses), 0)
+	assert.Equal(t, len(controller.ambientIndex.Lookup("240.240.0.5")), 0)
+}
+
+func TestAmbientEgressAutoAllocation(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	test.SetForTest(t, &features.AmbientAutoAllocateAddresses, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	cfg.RegisterEventHandler(gvk.ServiceEntry, controller.ServiceEntryHandler)
+	go cfg.Run(test.NewStop(t))
+
+	serviceEntry := func(host string) config.Config {
+		return config.Config{
+			Meta: config.Meta{
+				GroupVersionKind: gvk.ServiceEntry,
+				Name:             strings.Split(host, ".")[0],
+				Namespace:        "ns1",
+				Labels:           map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+			},
+			Spec: &networking.ServiceEntry{
+				Hosts:      []string{host},
+				Ports:      []*networking.ServicePort{{Number: 443, Name: "tls", Protocol: "TLS"}},
+				Resolution: networking.ServiceEntry_DNS,
+			},
+		}
+	}
+	a, b := serviceEntry("a.example.com"), serviceEntry("b.example.com")
+	for _, se := range []config.Config{a, b} {
+		if _, err := cfg.Create(se); err != nil {
+			t.Fatal(err)
+		}
+	}
+	allocated := serviceentry.AutoAllocatedAddresses([]config.Config{a, b})
+	assert.Equal(t, len(allocated), 2)
+	// Both hosts share a port, but are distinguished by their allocated addresses
+	for _, host := range []string{"a.example.com", "b.example.com"} {
+		for _, ip := range allocated["ns1/"+host] {
+			assert.EventuallyEqual(t, func() string {
+				wls := controller.ambientIndex.Lookup(ip)
+				if len(wls) != 1 || wls[0].VirtualIps[ip] == nil || wls[0].DnsEndpoints[host] == nil {
+					return ""
+				}
+				return wls[0].DnsEndpoints[host].Service + "," + wls[0].VirtualIps[ip].Service
+			}, host+","+host)
+		}
+	}
+
+	if err := cfg.Delete(gvk.ServiceEntry, "b", "ns1", nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, func() int {
+		return len(controller.ambientIndex.Lookup(allocated["ns1/b.example.com"][0]))
+	}, 0)
+	assert.Equal(t, len(controller.ambientIndex.Lookup(allocated["ns1/a.example.com"][0])), 1)
+}
+
+func TestAmbientHeadlessServices(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	sc := clienttest.Wrap(t, controller.services)
+	assertWorkload := func(headless map[string]*workloadapi.PortList, hostname string) {
+		t.Helper()
+		assert.EventuallyEqual(t, func() *workloadapi.Workload {
+			wls := controller.ambientIndex.Lookup("127.0.0.1")
+			if len(wls) != 1 {
+				return nil
+			}
+			return &workloadapi.Workload{HeadlessServices: wls[0].HeadlessServices, Hostname: wls[0].Hostname}
+		}, &workloadapi.Workload{HeadlessServices: headless, Hostname: hostname}, retry.Timeout(time.Second*3))
+	}
+	service := func(name string, svcType corev1.ServiceType, clusterIP string, selector map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			Spec: corev1.ServiceSpec{
+				Type:         svcType,
+				ClusterIP:    clusterIP,
+				ExternalName: "example.com",
+				Ports:        []corev1.ServicePort{{Name: "tcp", Port: 80, TargetPort: intstr.FromInt(8080), Protocol: corev1.ProtocolTCP}},
+				Selector:     selector,
+			},
+		}
+	}
+
+	pod := generatePod("127.0.0.1", "name1", "ns1", "sa1", "node1", map[string]string{"app": "a"}, nil)
+	pod.Spec.Hostname = "db-0"
+	pod.Spec.Subdomain = "db"
+	pc.CreateOrUpdate(pod)
+	assertWorkload(nil, "")
+
+	// ExternalName Services never select workloads, even with a selector
+	sc.CreateOrUpdate(service("external", corev1.ServiceTypeExternalName, "", map[string]string{"app": "a"}))
+	assertWorkload(nil, "")
+
+	sc.CreateOrUpdate(service("db", corev1.ServiceTypeClusterIP, corev1.ClusterIPNone, map[string]string{"app": "a"}))
+	dbPorts := map[string]*workloadapi.PortList{
+		"db.ns1.svc.company.com": {Ports: []*workloadapi.Port{{ServicePort: 80, TargetPort: 8080}}},
+	}
+	assertWorkload(dbPorts, "db-0.db.ns1.svc.company.com")
+
+	// The Service no longer selects the pod
+	sc.CreateOrUpdate(service("db", corev1.ServiceTypeClusterIP, corev1.ClusterIPNone, map[string]string{"app": "b"}))
+	assertWorkload(nil, "")
+
+	sc.CreateOrUpdate(service("db", corev1.ServiceTypeClusterIP, corev1.ClusterIPNone, map[string]string{"app": "a"}))
+	assertWorkload(dbPorts, "db-0.db.ns1.svc.company.com")
+	sc.Delete("db", "ns1")
+	assertWorkload(nil, "")
+}
+
+func TestAmbientProxyProtocol(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	sc := clienttest.Wrap(t, controller.services)
+	cfg.RegisterEventHandler(gvk.DestinationRule, controller.DestinationRuleHandler)
+	go cfg.Run(test.NewStop(t))
+	assertProxyProtocol := func(want bool) {
+		t.Helper()
+		assert.EventuallyEqual(t, func() bool {
+			wls := controller.ambientIndex.Lookup("127.0.0.1")
+			if len(wls) != 1 || wls[0].VirtualIps["10.0.0.10"] == nil {
+				return !want
+			}
+			return wls[0].VirtualIps["10.0.0.10"].ProxyProtocol
+		}, want, retry.Timeout(time.Second*3))
+	}
+	destinationRule := func(ns, host, proxyProtocol string) config.Config {
+		dr := config.Config{
+			Meta: config.Meta{
+				GroupVersionKind: gvk.DestinationRule,
+				Name:             "legacy",
+				Namespace:        ns,
+				Domain:           "company.com",
+				Annotations:      map[string]string{},
+			},
+			Spec: &networking.DestinationRule{Host: host},
+		}
+		if proxyProtocol != "" {
+			dr.Annotations[constants.AmbientProxyProtocol] = proxyProtocol
+		}
+		return dr
+	}
+
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "name1", "ns1", "sa1", "node1", map[string]string{"app": "legacy"}, nil))
+	sc.CreateOrUpdate(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "ns1"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "10.0.0.10",
+			Ports:     []corev1.ServicePort{{Name: "tcp", Port: 80, Protocol: corev1.ProtocolTCP}},
+			Selector:  map[string]string{"app": "legacy"},
+		},
+	})
+	assertProxyProtocol(false)
+
+	// Short names are resolved in the namespace of the DestinationRule
+	if _, err := cfg.Create(destinationRule("ns1", "legacy", constants.AmbientProxyProtocolV2)); err != nil {
+		t.Fatal(err)
+	}
+	assertProxyProtocol(true)
+	// Only v2 is supported
+	if _, err := cfg.Update(destinationRule("ns1", "legacy", "v1")); err != nil {
+		t.Fatal(err)
+	}
+	assertProxyProtocol(false)
+	if err := cfg.Delete(gvk.DestinationRule, "legacy", "ns1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// DestinationRules of the root namespace apply to all namespaces
+	if _, err := cfg.Create(destinationRule("istio-system", "*.ns1.svc.company.com", constants.AmbientProxyProtocolV2)); err != nil {
+		t.Fatal(err)
+	}
+	assertProxyProtocol(true)
+	if err := cfg.Delete(gvk.DestinationRule, "legacy", "istio-system", nil); err != nil {
+		t.Fatal(err)
+	}
+	assertProxyProtocol(false)
+}
+
+func TestAmbientTelemetry(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(mesh.DefaultMeshConfig()),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	cfg.RegisterEventHandler(gvk.Telemetry, controller.TelemetryHandler)
+	go cfg.Run(test.NewStop(t))
+	assertLogging := func(want ...string) {
+		t.Helper()
+		assert.EventuallyEqual(t, func() []string {
+			wls := controller.ambientIndex.Lookup("127.0.0.1")
+			if len(wls) != 1 {
+				return nil
+			}
+			return wls[0].GetTelemetry().GetServer().GetAccessLogging().GetProviders()
+		}, want, retry.Timeout(time.Second*3))
+	}
+	telemetryConfig := func(ns string) config.Config {
+		return config.Config{
+			Meta: config.Meta{GroupVersionKind: gvk.Telemetry, Name: "default", Namespace: ns},
+			Spec: &tpb.Telemetry{
+				AccessLogging: []*tpb.AccessLogging{{Providers: []*tpb.ProviderRef{{Name: "envoy"}}}},
+			},
+		}
+	}
+
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "name1", "ns1", "sa1", "node1", map[string]string{"app": "a"}, nil))
+	assertLogging()
+
+	if _, err := cfg.Create(telemetryConfig("ns1")); err != nil {
+		t.Fatal(err)
+	}
+	assertLogging("envoy")
+	if err := cfg.Delete(gvk.Telemetry, "default", "ns1", nil); err != nil {
+		t.Fatal(err)
+	}
+	assertLogging()
+
+	// Telemetries of the root namespace apply to all namespaces
+	if _, err := cfg.Create(telemetryConfig("istio-system")); err != nil {
+		t.Fatal(err)
+	}
+	assertLogging("envoy")
+	if err := cfg.Delete(gvk.Telemetry, "default", "istio-system", nil); err != nil {
+		t.Fatal(err)
+	}
+	assertLogging()
+}
+
+func TestTelemetryCache(t *testing.T) {
+	var tc telemetryCache
+	builds := 0
+	get := func(ns string, mesh *meshconfig.MeshConfig) *model.Telemetries {
+		return tc.get(ns, mesh, func() *model.Telemetries {
+			builds++
+			return model.NewZtunnelTelemetries(nil, mesh)
+		})
+	}
+	m := mesh.DefaultMeshConfig()
+
+	// The Telemetries of a namespace are indexed once for all its pods
+	first := get("ns1", m)
+	assert.Equal(t, get("ns1", m) == first, true)
+	get("ns2", m)
+	assert.Equal(t, builds, 2)
+
+	tc.invalidate("ns1")
+	get("ns1", m)
+	get("ns2", m)
+	assert.Equal(t, builds, 3)
+
+	tc.invalidate(metav1.NamespaceAll)
+	get("ns1", m)
+	get("ns2", m)
+	assert.Equal(t, builds, 5)
+
+	// The Telemetries depend on the providers of the mesh config
+	get("ns1", mesh.DefaultMeshConfig())
+	assert.Equal(t, builds, 6)
+}
+
+func TestAmbientLocality(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	meshWatcher := mesh.NewTestWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"})
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      meshWatcher,
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	sc := clienttest.Wrap(t, controller.services)
+	nc := clienttest.Wrap(t, controller.nodes)
+	cfg.RegisterEventHandler(gvk.DestinationRule, controller.DestinationRuleHandler)
+	go cfg.Run(test.NewStop(t))
+	loadBalancing := func() *workloadapi.LocalityLoadBalancing {
+		wls := controller.ambientIndex.Lookup("127.0.0.1")
+		if len(wls) != 1 || wls[0].VirtualIps["10.0.0.10"] == nil {
+			return nil
+		}
+		return wls[0].VirtualIps["10.0.0.10"].LocalityLoadBalancing
+	}
+	destinationRule := func(ns string, setting *networking.LocalityLoadBalancerSetting) config.Config {
+		return config.Config{
+			Meta: config.Meta{GroupVersionKind: gvk.DestinationRule, Name: "lb", Namespace: ns, Domain: "company.com"},
+			Spec: &networking.DestinationRule{
+				Host:          "lb.ns1.svc.company.com",
+				TrafficPolicy: &networking.TrafficPolicy{LoadBalancer: &networking.LoadBalancerSettings{LocalityLbSetting: setting}},
+			},
+		}
+	}
+
+	nc.CreateOrUpdate(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{NodeRegionLabelGA: "region", NodeZoneLabelGA: "zone", label.TopologySubzone.Name: "subzone"},
+		},
+	})
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "name1", "ns1", "sa1", "node1", map[string]string{"app": "lb"}, nil))
+	sc.CreateOrUpdate(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "lb", Namespace: "ns1"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "10.0.0.10",
+			Ports:     []corev1.ServicePort{{Name: "tcp", Port: 80, Protocol: corev1.ProtocolTCP}},
+			Selector:  map[string]string{"app": "lb"},
+		},
+	})
+	assert.EventuallyEqual(t, func() bool {
+		wls := controller.ambientIndex.Lookup("127.0.0.1")
+		return len(wls) == 1 && wls[0].VirtualIps["10.0.0.10"] != nil
+	}, true, retry.Timeout(time.Second*3))
+	assert.Equal(t, controller.ambientIndex.Lookup("127.0.0.1")[0].Locality,
+		&workloadapi.Locality{Region: "region", Zone: "zone", Subzone: "subzone"})
+	assert.Equal(t, loadBalancing(), nil)
+
+	// The workloads follow the topology labels of their node
+	nc.CreateOrUpdate(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{NodeRegionLabelGA: "region", NodeZoneLabelGA: "zone-b"},
+		},
+	})
+	assert.EventuallyEqual(t, func() *workloadapi.Locality {
+		return controller.ambientIndex.Lookup("127.0.0.1")[0].Locality
+	}, &workloadapi.Locality{Region: "region", Zone: "zone-b"}, retry.Timeout(time.Second*3))
+
+	if _, err := cfg.Create(destinationRule("ns1", &networking.LocalityLoadBalancerSetting{
+		Failover:         []*networking.LocalityLoadBalancerSetting_Failover{{From: "region", To: "other"}},
+		FailoverPriority: []string{"topology.istio.io/network"},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, loadBalancing, &workloadapi.LocalityLoadBalancing{
+		Failover:         []*workloadapi.LocalityFailover{{From: "region", To: "other"}},
+		FailoverPriority: []string{"topology.istio.io/network"},
+	}, retry.Timeout(time.Second*3))
+	// Explicitly disabled settings are ignored
+	if _, err := cfg.Update(destinationRule("ns1", &networking.LocalityLoadBalancerSetting{Enabled: wrappers.Bool(false)})); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, loadBalancing, nil, retry.Timeout(time.Second*3))
+	if err := cfg.Delete(gvk.DestinationRule, "lb", "ns1", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// DestinationRules of the root namespace apply to all namespaces
+	if _, err := cfg.Create(destinationRule("istio-system", &networking.LocalityLoadBalancerSetting{})); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, loadBalancing, &workloadapi.LocalityLoadBalancing{}, retry.Timeout(time.Second*3))
+	if err := cfg.Delete(gvk.DestinationRule, "lb", "istio-system", nil); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, loadBalancing, nil, retry.Timeout(time.Second*3))
+
+	// Without a DestinationRule, the setting of the mesh config applies
+	if err := meshWatcher.Update(&meshconfig.MeshConfig{
+		RootNamespace:     "istio-system",
+		LocalityLbSetting: &networking.LocalityLoadBalancerSetting{FailoverPriority: []string{"topology.istio.io/network"}},
+	}, 3); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, loadBalancing, &workloadapi.LocalityLoadBalancing{
+		FailoverPriority: []string{"topology.istio.io/network"},
+	}, retry.Timeout(time.Second*3))
+	// Unless a DestinationRule disables it
+	if _, err := cfg.Create(destinationRule("ns1", &networking.LocalityLoadBalancerSetting{Enabled: wrappers.Bool(false)})); err != nil {
+		t.Fatal(err)
+	}
+	assert.EventuallyEqual(t, loadBalancing, nil, retry.Timeout(time.Second*3))
+}
+
+func TestAmbientNetworkGateway(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	controller.setNetworkFromNamespace(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "istio-system", Labels: map[string]string{label.TopologyNetwork.Name: "nw1"}},
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	networkGateway := func() *workloadapi.GatewayAddress {
+		wls := controller.ambientIndex.Lookup("127.0.0.1")
+		if len(wls) != 1 {
+			return nil
+		}
+		return wls[0].NetworkGateway
+	}
+
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "name1", "ns1", "sa1", "node1", map[string]string{"app": "a"}, nil))
+	assert.EventuallyEqual(t, func() int { return len(controller.ambientIndex.Lookup("127.0.0.1")) }, 1, retry.Timeout(time.Second*3))
+	assert.Equal(t, networkGateway(), nil)
+
+	// The workloads are updated once the gateway of their network is known
+	addLabeledServiceGateway(t, controller, "nw1")
+	assert.EventuallyEqual(t, networkGateway, &workloadapi.GatewayAddress{Address: []byte{2, 3, 4, 6}, HboneMtlsPort: 15008},
+		retry.Timeout(time.Second*3))
+
+	removeLabeledServiceGateway(t, controller)
+	assert.EventuallyEqual(t, networkGateway, nil, retry.Timeout(time.Second*3))
+}
+
+func TestAmbientTopology(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI)),
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	sc := clienttest.Wrap(t, controller.services)
+	nc := clienttest.Wrap(t, controller.nodes)
+	ec := clienttest.Wrap(t, controller.endpoints.slices)
+	portList := func(vip string) func() *workloadapi.PortList {
+		return func() *workloadapi.PortList {
+			wls := controller.ambientIndex.Lookup("127.0.0.1")
+			if len(wls) != 1 {
+				return nil
+			}
+			return wls[0].VirtualIps[vip]
+		}
+	}
+	slice := func(hints ...string) *discovery.EndpointSlice {
+		ep := discovery.Endpoint{Addresses: []string{"127.0.0.1"}}
+		if len(hints) > 0 {
+			ep.Hints = &discovery.EndpointHints{}
+			for _, z := range hints {
+				ep.Hints.ForZones = append(ep.Hints.ForZones, discovery.ForZone{Name: z})
+			}
+		}
+		return &discovery.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "local-abc",
+				Namespace: "ns1",
+				Labels:    map[string]string{discovery.LabelServiceName: "local"},
+			},
+			AddressType: discovery.AddressTypeIPv4,
+			Endpoints:   []discovery.Endpoint{ep},
+		}
+	}
+
+	nc.CreateOrUpdate(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{NodeZoneLabelGA: "zone-a"}},
+	})
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "name1", "ns1", "sa1", "node1", map[string]string{"app": "local"}, nil))
+	local := corev1.ServiceInternalTrafficPolicyLocal
+	sc.CreateOrUpdate(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "ns1"},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:             "10.0.0.10",
+			Ports:                 []corev1.ServicePort{{Name: "tcp", Port: 80, Protocol: corev1.ProtocolTCP}},
+			Selector:              map[string]string{"app": "local"},
+			InternalTrafficPolicy: &local,
+		},
+		Status: corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.1.10"}}}},
+	})
+	assert.EventuallyEqual(t, func() bool { return portList("10.0.0.10")() != nil }, true, retry.Timeout(time.Second*3))
+	// The internal traffic policy only applies to the cluster IP
+	assert.Equal(t, portList("10.0.0.10")().NodeLocal, true)
+	assert.Equal(t, portList("10.0.1.10")().NodeLocal, false)
+	assert.Equal(t, controller.ambientIndex.Lookup("127.0.0.1")[0].Locality.GetZone(), "zone-a")
+
+	hints := func() []string {
+		if pl := portList("10.0.0.10")(); pl != nil {
+			return pl.HintsForZones
+		}
+		return nil
+	}
+	ec.CreateOrUpdate(slice("zone-a", "zone-b"))
+	assert.EventuallyEqual(t, hints, []string{"zone-a", "zone-b"}, retry.Timeout(time.Second*3))
+	ec.CreateOrUpdate(slice())
+	assert.EventuallyEqual(t, hints, nil, retry.Timeout(time.Second*3))
+	ec.CreateOrUpdate(slice("zone-b"))
+	assert.EventuallyEqual(t, hints, []string{"zone-b"}, retry.Timeout(time.Second*3))
+	ec.Delete("local-abc", "ns1")
+	assert.EventuallyEqual(t, hints, nil, retry.Timeout(time.Second*3))
+}
+
+func TestAmbientTrustDomain(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+		ConfigCluster:    true,
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	nc := clienttest.Wrap(t, controller.namespaces)
+	setTrustDomain := func(td string) {
+		t.Helper()
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns1"}}
+		if td != "" {
+			ns.Annotations = map[string]string{constants.AmbientTrustDomain: td}
+		}
+		nc.CreateOrUpdate(ns)
+	}
+	trustDomain := func() string {
+		wls := controller.ambientIndex.Lookup("127.0.0.1")
+		if len(wls) != 1 {
+			return "missing"
+		}
+		return wls[0].TrustDomain
+	}
+	principals := func() []string {
+		var res []string
+		for _, p := range controller.Policies(nil) {
+			for _, m := range p.Groups[0].Rules[0].Matches {
+				for _, sm := range m.Principals {
+					res = append(res, sm.GetExact())
+				}
+			}
+		}
+		return res
+	}
+
+	// The pod is handled before its namespace
+	pc.CreateOrUpdate(generatePod("127.0.0.1", "name1", "ns1", "sa1", "node1", nil, nil))
+	assert.EventuallyEqual(t, trustDomain, "", retry.Timeout(time.Second*3))
+	setTrustDomain("example.com")
+	assert.EventuallyEqual(t, trustDomain, "example.com", retry.Timeout(time.Second*3))
+
+	_, err := cfg.Create(config.Config{
+		Meta: config.Meta{
+			GroupVersionKind: gvk.AuthorizationPolicy,
+			Name:             "allow",
+			Namespace:        "ns2",
+		},
+		Spec: &authz.AuthorizationPolicy{
+			Rules: []*authz.Rule{{From: []*authz.Rule_From{{Source: &authz.Source{
+				Principals: []string{"cluster.local/ns/ns1/sa/sa1", "cluster.local/ns/ns2/sa/sa2"},
+			}}}}},
+		},
+	})
+	assert.NoError(t, err)
+	// Principals of the namespace are also matched in its trust domain
+	assert.Equal(t, principals(), []string{
+		"cluster.local/ns/ns1/sa/sa1",
+		"cluster.local/ns/ns2/sa/sa2",
+		"example.com/ns/ns1/sa/sa1",
+	})
+
+	setTrustDomain("")
+	assert.EventuallyEqual(t, trustDomain, "", retry.Timeout(time.Second*3))
+	assert.Equal(t, principals(), []string{"cluster.local/ns/ns1/sa/sa1", "cluster.local/ns/ns2/sa/sa2"})
+}
@@ -0,0 +1,147 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strings"
+	"sync"
+
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// maxInternedValues bounds the values held by a workloadInterner. Interned values are never released, as the
+// workloads sharing them are not tracked, so the interner is reset once it holds that many values, such as after
+// many rollouts changed the labels of the workloads. Workloads keep the values they were interned with.
+const maxInternedValues = 1 << 18
+
+// workloadInterner deduplicates the strings and slices of the workloads of the ambient index. Each workload is built
+// from its own pod, decoded separately by the informer, so without interning the namespace, service account, node,
+// trust domain, labels and services of workloads are held once per workload, although they are shared by most
+// workloads of a namespace. On clusters with tens of thousands of workloads, these copies are a large part of the
+// heap of istiod.
+//
+// The interned values are shared by many workloads, so they must not be modified: the workloads of the index are
+// already replaced rather than modified when they change. The interner stores its own copies of the slices, so the
+// slices of the workloads it is given are never shared with other workloads.
+type workloadInterner struct {
+	mu sync.Mutex
+	// strings holds the interned strings, keyed by themselves.
+	strings map[string]string
+	// policies holds the interned authorization policy lists, keyed by their joined policies.
+	policies map[string][]string
+	// waypoints holds the interned waypoint address lists, keyed by their joined addresses.
+	waypoints map[string][][]byte
+}
+
+func newWorkloadInterner() *workloadInterner {
+	i := &workloadInterner{}
+	i.reset()
+	return i
+}
+
+func (i *workloadInterner) reset() {
+	i.strings = map[string]string{}
+	i.policies = map[string][]string{}
+	i.waypoints = map[string][][]byte{}
+}
+
+// internWorkload replaces the strings and slices of the workload with their interned copies.
+func (i *workloadInterner) internWorkload(wl *workloadapi.Workload) {
+	if i == nil || wl == nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.strings)+len(i.policies)+len(i.waypoints) > maxInternedValues {
+		i.reset()
+	}
+
+	wl.Namespace = i.internString(wl.Namespace)
+	wl.ServiceAccount = i.internString(wl.ServiceAccount)
+	wl.Node = i.internString(wl.Node)
+	wl.Network = i.internString(wl.Network)
+	wl.ClusterId = i.internString(wl.ClusterId)
+	wl.TrustDomain = i.internString(wl.TrustDomain)
+	wl.WorkloadName = i.internString(wl.WorkloadName)
+	wl.CanonicalName = i.internString(wl.CanonicalName)
+	wl.CanonicalRevision = i.internString(wl.CanonicalRevision)
+	if wl.Locality != nil {
+		wl.Locality.Region = i.internString(wl.Locality.Region)
+		wl.Locality.Zone = i.internString(wl.Locality.Zone)
+		wl.Locality.Subzone = i.internString(wl.Locality.Subzone)
+	}
+	for _, ports := range wl.VirtualIps {
+		ports.Service = i.internString(ports.Service)
+	}
+	// The label keys are the keys of PILOT_AMBIENT_WORKLOAD_LABELS, which are already shared
+	if len(wl.Labels) > 0 {
+		labels := make(map[string]string, len(wl.Labels))
+		for k, v := range wl.Labels {
+			labels[k] = i.internString(v)
+		}
+		wl.Labels = labels
+	}
+	wl.AuthorizationPolicies = i.policyList(wl.AuthorizationPolicies)
+	wl.WaypointAddresses = i.waypointList(wl.WaypointAddresses)
+}
+
+func (i *workloadInterner) internString(s string) string {
+	if s == "" {
+		return s
+	}
+	if is, f := i.strings[s]; f {
+		return is
+	}
+	i.strings[s] = s
+	return s
+}
+
+func (i *workloadInterner) policyList(policies []string) []string {
+	if len(policies) == 0 {
+		return policies
+	}
+	key := strings.Join(policies, "\n")
+	if ip, f := i.policies[key]; f {
+		return ip
+	}
+	ip := make([]string, 0, len(policies))
+	for _, p := range policies {
+		ip = append(ip, i.internString(p))
+	}
+	i.policies[key] = ip
+	return ip
+}
+
+func (i *workloadInterner) waypointList(addrs [][]byte) [][]byte {
+	if len(addrs) == 0 {
+		return addrs
+	}
+	var key strings.Builder
+	for _, a := range addrs {
+		// Addresses are prefixed with their length, as IPv4 and IPv6 addresses are mixed
+		key.WriteByte(byte(len(a)))
+		key.Write(a)
+	}
+	k := key.String()
+	if ia, f := i.waypoints[k]; f {
+		return ia
+	}
+	ia := make([][]byte, 0, len(addrs))
+	for _, a := range addrs {
+		ia = append(ia, append([]byte(nil), a...))
+	}
+	i.waypoints[k] = ia
+	return ia
+}
@@ -0,0 +1,208 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	goruntime "runtime"
+	"testing"
+	"time"
+	"unsafe"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	authz "istio.io/api/security/v1beta1"
+	"istio.io/api/type/v1beta1"
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/mesh"
+	"istio.io/istio/pkg/config/schema/collections"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// decodedWorkload builds a workload the way the ambient index builds it from a pod decoded by the informer, with its
+// own copy of each string. The workloads of a namespace share their service account, services and policies.
+func decodedWorkload(i, namespaces int) *workloadapi.Workload {
+	ns := i % namespaces
+	return &workloadapi.Workload{
+		Name:              fmt.Sprintf("app-%d-5d8f7c9b4-%05d", ns, i),
+		Namespace:         fmt.Sprintf("namespace-%d", ns),
+		Address:           []byte{10, byte(i >> 16), byte(i >> 8), byte(i)},
+		Network:           fmt.Sprintf("network-%d", 1),
+		ServiceAccount:    fmt.Sprintf("app-%d", ns),
+		Node:              fmt.Sprintf("node-%d", i%500),
+		Locality:          &workloadapi.Locality{Region: fmt.Sprintf("us-%s", "east"), Zone: fmt.Sprintf("us-east-%d", i%3)},
+		ClusterId:         fmt.Sprintf("cluster-%d", 1),
+		TrustDomain:       fmt.Sprintf("cluster.%s", "local"),
+		WorkloadName:      fmt.Sprintf("app-%d", ns),
+		CanonicalName:     fmt.Sprintf("app-%d", ns),
+		CanonicalRevision: fmt.Sprintf("v%d", 1),
+		Labels:            map[string]string{"app": fmt.Sprintf("app-%d", ns), "version": fmt.Sprintf("v%d", 1)},
+		VirtualIps: map[string]*workloadapi.PortList{
+			"10.96.0.1": {Service: fmt.Sprintf("app-%d.namespace-%d.svc.cluster.local", ns, ns)},
+		},
+		AuthorizationPolicies: []string{fmt.Sprintf("istio-system/%s", "global"), fmt.Sprintf("namespace-%d/allow", ns)},
+		WaypointAddresses:     [][]byte{{10, 0, byte(ns >> 8), byte(ns)}},
+	}
+}
+
+func TestWorkloadInterner(t *testing.T) {
+	i := newWorkloadInterner()
+	a, b, other := decodedWorkload(0, 10), decodedWorkload(10, 10), decodedWorkload(1, 10)
+	i.internWorkload(a)
+	i.internWorkload(b)
+	i.internWorkload(other)
+
+	sameString := func(x, y string) bool {
+		return unsafe.StringData(x) == unsafe.StringData(y)
+	}
+	// The workloads of a namespace share their strings and slices
+	assert.Equal(t, sameString(a.Namespace, b.Namespace), true)
+	assert.Equal(t, sameString(a.ServiceAccount, b.ServiceAccount), true)
+	assert.Equal(t, sameString(a.Labels["app"], b.Labels["app"]), true)
+	assert.Equal(t, sameString(a.VirtualIps["10.96.0.1"].Service, b.VirtualIps["10.96.0.1"].Service), true)
+	assert.Equal(t, &a.AuthorizationPolicies[0] == &b.AuthorizationPolicies[0], true)
+	assert.Equal(t, &a.WaypointAddresses[0] == &b.WaypointAddresses[0], true)
+	// The values shared across namespaces are shared too, but not the lists holding different values
+	assert.Equal(t, sameString(a.TrustDomain, other.TrustDomain), true)
+	assert.Equal(t, sameString(a.AuthorizationPolicies[0], other.AuthorizationPolicies[0]), true)
+	assert.Equal(t, other.AuthorizationPolicies, []string{"istio-system/global", "namespace-1/allow"})
+	assert.Equal(t, other.WaypointAddresses, [][]byte{{10, 0, 0, 1}})
+	// Names are unique to each workload, so they are not interned
+	assert.Equal(t, sameString(a.Name, b.Name), false)
+
+	// The interner keeps its own copies, so the slices and maps it is given are neither modified nor shared
+	policies := []string{"ns/a", "ns/b"}
+	waypoints := [][]byte{{10, 0, 0, 9}}
+	labels := map[string]string{"app": "d"}
+	d := &workloadapi.Workload{AuthorizationPolicies: policies, WaypointAddresses: waypoints, Labels: labels}
+	i.internWorkload(d)
+	assert.Equal(t, &d.AuthorizationPolicies[0] == &policies[0], false)
+	assert.Equal(t, &d.WaypointAddresses[0][0] == &waypoints[0][0], false)
+	policies[0], waypoints[0][3], labels["app"] = "ns/changed", 10, "changed"
+	e := &workloadapi.Workload{AuthorizationPolicies: []string{"ns/a", "ns/b"}, WaypointAddresses: [][]byte{{10, 0, 0, 9}}}
+	i.internWorkload(e)
+	assert.Equal(t, e.AuthorizationPolicies, []string{"ns/a", "ns/b"})
+	assert.Equal(t, e.WaypointAddresses, [][]byte{{10, 0, 0, 9}})
+	assert.Equal(t, d.Labels, map[string]string{"app": "d"})
+
+	// The interner is reset once it holds too many values, without changing the workloads
+	for n := 0; n <= maxInternedValues; n++ {
+		i.internString(fmt.Sprint(n))
+	}
+	c := decodedWorkload(20, 10)
+	i.internWorkload(c)
+	assert.Equal(t, len(i.strings) < maxInternedValues, true)
+	assert.Equal(t, c.Namespace, a.Namespace)
+}
+
+// BenchmarkWorkloadInterner reports the heap held by the workloads of a cluster with 50k workloads in 1000 namespaces,
+// with and without interning.
+func BenchmarkWorkloadInterner(b *testing.B) {
+	const workloads, namespaces = 50000, 1000
+	for _, intern := range []bool{false, true} {
+		b.Run(fmt.Sprintf("intern=%v", intern), func(b *testing.B) {
+			b.ReportAllocs()
+			var heap uint64
+			for n := 0; n < b.N; n++ {
+				var before, after goruntime.MemStats
+				goruntime.GC()
+				goruntime.ReadMemStats(&before)
+				interner := newWorkloadInterner()
+				wls := make([]*workloadapi.Workload, 0, workloads)
+				for i := 0; i < workloads; i++ {
+					wl := decodedWorkload(i, namespaces)
+					if intern {
+						interner.internWorkload(wl)
+					}
+					wls = append(wls, wl)
+				}
+				goruntime.GC()
+				goruntime.ReadMemStats(&after)
+				heap += after.HeapAlloc - before.HeapAlloc
+				goruntime.KeepAlive(wls)
+				goruntime.KeepAlive(interner)
+			}
+			b.ReportMetric(float64(heap)/float64(b.N)/(1<<20), "heap-MiB")
+		})
+	}
+}
+
+// BenchmarkAmbientIndex reports the time to index the workloads of a cluster with 50k pods in 1000 namespaces, each
+// with a Service and an authorization policy, and the heap held by the index.
+func BenchmarkAmbientIndex(b *testing.B) {
+	const pods, namespaces = 50000, 1000
+	test.SetForTest(b, &features.EnableAmbientControllers, true)
+	objects := make([]runtime.Object, 0, pods+namespaces)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	for ns := 0; ns < namespaces; ns++ {
+		name, app := fmt.Sprintf("namespace-%d", ns), fmt.Sprintf("app-%d", ns)
+		objects = append(objects, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: name},
+			Spec: corev1.ServiceSpec{
+				ClusterIP: fmt.Sprintf("10.96.%d.%d", ns>>8, ns&0xff),
+				Ports:     []corev1.ServicePort{{Name: "http", Port: 80, Protocol: corev1.ProtocolTCP}},
+				Selector:  map[string]string{"app": app},
+			},
+		})
+		if _, err := cfg.Create(config.Config{
+			Meta: config.Meta{GroupVersionKind: gvk.AuthorizationPolicy, Name: "allow", Namespace: name},
+			Spec: &authz.AuthorizationPolicy{Selector: &v1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": app}}},
+		}); err != nil {
+			b.Fatal(err)
+		}
+	}
+	for i := 0; i < pods; i++ {
+		ns := i % namespaces
+		objects = append(objects, generatePod(fmt.Sprintf("10.%d.%d.%d", 1+i>>16, (i>>8)&0xff, i&0xff),
+			fmt.Sprintf("app-%d-5d8f7c9b4-%05d", ns, i), fmt.Sprintf("namespace-%d", ns), fmt.Sprintf("app-%d", ns),
+			fmt.Sprintf("node-%d", i%500), map[string]string{"app": fmt.Sprintf("app-%d", ns), "version": "v1"}, nil))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	var heap uint64
+	for n := 0; n < b.N; n++ {
+		var before, after goruntime.MemStats
+		goruntime.GC()
+		goruntime.ReadMemStats(&before)
+		stop := make(chan struct{})
+		controller, _ := NewFakeControllerWithOptions(b, FakeControllerOptions{
+			Client:           kube.NewFakeClient(objects...),
+			ConfigController: cfg,
+			MeshWatcher:      mesh.NewFixedWatcher(mesh.DefaultMeshConfig()),
+			ClusterID:        "cluster0",
+			Stop:             stop,
+		})
+		for len(controller.ambientIndex.All()) < pods {
+			time.Sleep(10 * time.Millisecond)
+		}
+		b.StopTimer()
+		goruntime.GC()
+		goruntime.ReadMemStats(&after)
+		heap += after.HeapAlloc - before.HeapAlloc
+		goruntime.KeepAlive(controller)
+		close(stop)
+		b.StartTimer()
+	}
+	b.ReportMetric(float64(heap)/float64(b.N)/(1<<20), "heap-MiB")
+}
@@ -0,0 +1,124 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+
+	"istio.io/api/label"
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/pilot/pkg/networking/core/v1alpha3/loadbalancer"
+	labelutil "istio.io/istio/pilot/pkg/serviceregistry/util/label"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// localityLbSetting is the localityLbSetting of a DestinationRule, and the host it applies to.
+type localityLbSetting struct {
+	host    host.Name
+	setting *networking.LocalityLoadBalancerSetting
+}
+
+// workloadLocality returns the locality of a pod, or nil if it is unknown.
+func (c *Controller) workloadLocality(pod *v1.Pod) *workloadapi.Locality {
+	locality := c.getPodLocality(pod)
+	if locality == "" {
+		return nil
+	}
+	region, zone, subzone := labelutil.SplitLocalityLabel(locality)
+	return &workloadapi.Locality{Region: region, Zone: zone, Subzone: subzone}
+}
+
+// localityLbSettings returns the localityLbSettings of the DestinationRules for the Services of a namespace, including
+// the disabled ones, which disable the localityLbSetting of the mesh config. These are the DestinationRules of the
+// namespace, followed by those of the root namespace, which they take precedence over.
+func (c *Controller) localityLbSettings(ns string) []localityLbSetting {
+	cfgs := c.configController.List(gvk.DestinationRule, ns)
+	if rootns := c.meshWatcher.Mesh().GetRootNamespace(); rootns != ns {
+		cfgs = append(cfgs, c.configController.List(gvk.DestinationRule, rootns)...)
+	}
+	var settings []localityLbSetting
+	for _, cfg := range cfgs {
+		if s := destinationRuleLocalityLbSetting(cfg); s != nil {
+			settings = append(settings, localityLbSetting{host: destinationRuleHost(cfg), setting: s})
+		}
+	}
+	return settings
+}
+
+// meshLocalityLbSetting returns the localityLbSetting of the mesh config, if any.
+func (c *Controller) meshLocalityLbSetting() *networking.LocalityLoadBalancerSetting {
+	if c.meshWatcher == nil {
+		return nil
+	}
+	return c.meshWatcher.Mesh().GetLocalityLbSetting()
+}
+
+// localityLoadBalancing returns the locality load balancing of a Service, from the first localityLbSetting applying to
+// it, or else from the localityLbSetting of the mesh config, as for sidecars.
+func localityLoadBalancing(settings []localityLbSetting, mesh *networking.LocalityLoadBalancerSetting, hostname string,
+) *workloadapi.LocalityLoadBalancing {
+	var dr *networking.LocalityLoadBalancerSetting
+	for _, s := range settings {
+		if host.Name(hostname).SubsetOf(s.host) {
+			dr = s.setting
+			break
+		}
+	}
+	setting := loadbalancer.GetLocalityLbSetting(mesh, dr)
+	if setting == nil {
+		return nil
+	}
+	res := &workloadapi.LocalityLoadBalancing{FailoverPriority: setting.GetFailoverPriority()}
+	for _, f := range setting.GetFailover() {
+		res.Failover = append(res.Failover, &workloadapi.LocalityFailover{From: f.GetFrom(), To: f.GetTo()})
+	}
+	return res
+}
+
+// handleNodeLocality updates the workloads of the pods of a node whose locality differs from the one of the node, once
+// the node is added or its topology labels changed.
+func (a *AmbientIndex) handleNodeLocality(node *v1.Node, c *Controller) {
+	var pods []*v1.Pod
+	for _, p := range a.podNodeIndex.Lookup(node.Name) {
+		a.mu.RLock()
+		wl := a.byPod[p.Status.PodIP]
+		a.mu.RUnlock()
+		if wl != nil && !proto.Equal(wl.Locality, c.workloadLocality(p)) {
+			pods = append(pods, p)
+		}
+	}
+	if len(pods) > 0 {
+		a.handlePods(pods, c)
+	}
+}
+
+// nodeLocality returns the locality of a node, from its topology labels.
+func nodeLocality(node *v1.Node) string {
+	return getLabelValue(node.ObjectMeta, NodeRegionLabelGA, NodeRegionLabel) + "/" +
+		getLabelValue(node.ObjectMeta, NodeZoneLabelGA, NodeZoneLabel) + "/" +
+		getLabelValue(node.ObjectMeta, label.TopologySubzone.Name, "")
+}
+
+func destinationRuleLocalityLbSetting(cfg config.Config) *networking.LocalityLoadBalancerSetting {
+	dr, ok := cfg.Spec.(*networking.DestinationRule)
+	if !ok {
+		return nil
+	}
+	return dr.GetTrafficPolicy().GetLoadBalancer().GetLocalityLbSetting()
+}
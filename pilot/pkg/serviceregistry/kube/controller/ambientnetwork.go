@@ -0,0 +1,54 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/netip"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// networkGateway returns the gateway of the network of the workloads of the cluster, through which the ztunnels of
+// other networks reach them, such as in multi-primary meshes whose clusters are on different networks. Gateways with
+// a hostname rather than an address are ignored, and the lowest address is picked so each istiod picks the same one.
+// The gateway is set on every workload of the network, including the ones local to the ztunnels they are sent to:
+// ztunnel compares the network of the workload with its own and only uses the gateway for the other networks.
+func (c *Controller) networkGateway() *workloadapi.GatewayAddress {
+	nw := c.networkFromSystemNamespace()
+	if nw == "" {
+		return nil
+	}
+	var gateway netip.Addr
+	for _, gw := range c.NetworkGateways() {
+		if gw.Network != nw {
+			continue
+		}
+		addr, err := netip.ParseAddr(gw.Addr)
+		if err != nil {
+			continue
+		}
+		if !gateway.IsValid() || addr.Less(gateway) {
+			gateway = addr
+		}
+	}
+	if !gateway.IsValid() {
+		return nil
+	}
+	return &workloadapi.GatewayAddress{
+		Address:       gateway.AsSlice(),
+		HboneMtlsPort: model.HBoneInboundListenPort,
+	}
+}
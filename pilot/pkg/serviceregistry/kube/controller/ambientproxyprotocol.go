@@ -0,0 +1,138 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+
+	networking "istio.io/api/networking/v1alpha3"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/serviceregistry/kube"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/host"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/config/schema/kind"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// DestinationRuleHandler updates the workloads of the Services whose DestinationRules enabled or disabled the PROXY
+// protocol, so ztunnel starts or stops prepending it to the connections it opens to them. The PROXY protocol preserves
+// the address of the client for backends outside of the mesh which rely on it. As the DestinationRule API has no
+// field for it, it is enabled with the ambient.istio.io/proxy-protocol annotation. The workloads are also updated when
+// the localityLbSetting of the DestinationRules changed, so ztunnel applies it.
+func (c *Controller) DestinationRuleHandler(old config.Config, obj config.Config, ev model.Event) {
+	if v, f := obj.Annotations[constants.AmbientProxyProtocol]; f && v != constants.AmbientProxyProtocolV2 && ev != model.EventDelete {
+		log.Warnf("ignoring invalid %s annotation %q on DestinationRule %s/%s: only %q is supported",
+			constants.AmbientProxyProtocol, v, obj.Namespace, obj.Name, constants.AmbientProxyProtocolV2)
+	}
+	enabled := ambientDestinationRule(obj)
+	wasEnabled := ev == model.EventUpdate && ambientDestinationRule(old)
+	if !enabled && !wasEnabled {
+		return
+	}
+	if enabled && wasEnabled && destinationRuleHost(old) == destinationRuleHost(obj) &&
+		proxyProtocolEnabled(old) == proxyProtocolEnabled(obj) &&
+		proto.Equal(destinationRuleLocalityLbSetting(old), destinationRuleLocalityLbSetting(obj)) {
+		// The annotation, host and localityLbSetting did not change, so the workloads are the same
+		return
+	}
+
+	cfgs := []config.Config{obj}
+	if wasEnabled {
+		cfgs = append(cfgs, old)
+	}
+	pods := map[string]bool{}
+	updates := sets.New[model.ConfigKey]()
+	for _, cfg := range cfgs {
+		h := destinationRuleHost(cfg)
+		ns := cfg.Namespace
+		if ns == c.meshWatcher.Mesh().GetRootNamespace() {
+			ns = metav1.NamespaceAll
+		}
+		for _, svc := range c.services.List(ns, klabels.Everything()) {
+			if !kube.ServiceHostname(svc.Name, svc.Namespace, c.opts.DomainSuffix).SubsetOf(h) {
+				continue
+			}
+			for _, p := range c.getPodsInService(svc) {
+				if pods[p.Status.PodIP] {
+					continue
+				}
+				pods[p.Status.PodIP] = true
+				newWl := c.extractWorkload(p)
+				if newWl == nil {
+					continue
+				}
+				c.ambientIndex.mu.Lock()
+				c.ambientIndex.byPod[p.Status.PodIP] = newWl
+				c.ambientIndex.mu.Unlock()
+				updates.Insert(model.ConfigKey{Kind: kind.Address, Name: newWl.ResourceName()})
+			}
+		}
+	}
+
+	if len(updates) > 0 {
+		c.opts.XDSUpdater.ConfigUpdate(&model.PushRequest{
+			ConfigsUpdated: updates,
+			Reason:         []model.TriggerReason{model.AmbientUpdate},
+		})
+	}
+}
+
+// proxyProtocolHosts returns the hosts of the DestinationRules enabling the PROXY protocol for the Services of a
+// namespace. These are the DestinationRules of the namespace, and of the root namespace.
+func (c *Controller) proxyProtocolHosts(ns string) []host.Name {
+	cfgs := c.configController.List(gvk.DestinationRule, ns)
+	if rootns := c.meshWatcher.Mesh().GetRootNamespace(); rootns != ns {
+		cfgs = append(cfgs, c.configController.List(gvk.DestinationRule, rootns)...)
+	}
+	var hosts []host.Name
+	for _, cfg := range cfgs {
+		if proxyProtocolEnabled(cfg) {
+			hosts = append(hosts, destinationRuleHost(cfg))
+		}
+	}
+	return hosts
+}
+
+// proxyProtocol returns whether ztunnel prepends the PROXY protocol to the connections to a Service.
+func proxyProtocol(hosts []host.Name, hostname string) bool {
+	for _, h := range hosts {
+		if host.Name(hostname).SubsetOf(h) {
+			return true
+		}
+	}
+	return false
+}
+
+// ambientDestinationRule returns whether a DestinationRule configures ztunnel, with the PROXY protocol or a
+// localityLbSetting.
+func ambientDestinationRule(cfg config.Config) bool {
+	return proxyProtocolEnabled(cfg) || destinationRuleLocalityLbSetting(cfg) != nil
+}
+
+func proxyProtocolEnabled(cfg config.Config) bool {
+	return cfg.Annotations[constants.AmbientProxyProtocol] == constants.AmbientProxyProtocolV2
+}
+
+func destinationRuleHost(cfg config.Config) host.Name {
+	dr, ok := cfg.Spec.(*networking.DestinationRule)
+	if !ok {
+		return ""
+	}
+	return model.ResolveShortnameToFQDN(dr.Host, cfg.Meta)
+}
@@ -0,0 +1,103 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+
+	meshconfig "istio.io/api/mesh/v1alpha1"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// telemetryCache holds the Telemetries of each namespace, along with those of the root namespace, so they are
+// indexed once rather than for each of the pods of the namespace.
+type telemetryCache struct {
+	mu sync.Mutex
+	// mesh is the mesh config the Telemetries were indexed with, as they depend on its providers.
+	mesh        *meshconfig.MeshConfig
+	telemetries map[string]*model.Telemetries
+}
+
+// get returns the Telemetries of the namespace, indexing them with build if they are not cached for the mesh config.
+func (tc *telemetryCache) get(ns string, mesh *meshconfig.MeshConfig, build func() *model.Telemetries) *model.Telemetries {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.mesh != mesh {
+		tc.mesh = mesh
+		tc.telemetries = nil
+	}
+	if t, f := tc.telemetries[ns]; f {
+		return t
+	}
+	t := build()
+	if tc.telemetries == nil {
+		tc.telemetries = map[string]*model.Telemetries{}
+	}
+	tc.telemetries[ns] = t
+	return t
+}
+
+// invalidate drops the Telemetries of the namespace, or of all namespaces if it is empty.
+func (tc *telemetryCache) invalidate(ns string) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if ns == metav1.NamespaceAll {
+		tc.telemetries = nil
+		return
+	}
+	delete(tc.telemetries, ns)
+}
+
+// TelemetryHandler updates the workloads of the namespace of a Telemetry, or of all namespaces for a Telemetry of the
+// root namespace, so ztunnel applies their metrics and access logging overrides. Waypoints are configured from the
+// Telemetries as any other proxy, so they need no update here.
+func (c *Controller) TelemetryHandler(_ config.Config, obj config.Config, _ model.Event) {
+	ns := obj.Namespace
+	if ns == c.meshWatcher.Mesh().GetRootNamespace() {
+		ns = metav1.NamespaceAll
+	}
+	c.telemetries.invalidate(ns)
+	c.ambientIndex.handlePods(c.podsClient.List(ns, klabels.Everything()), c)
+}
+
+// workloadTelemetry returns the telemetry ztunnel reports for the connections of a pod, from the Telemetries of its
+// namespace and of the root namespace.
+func (c *Controller) workloadTelemetry(pod *v1.Pod) *workloadapi.Telemetry {
+	if c.configController == nil {
+		return nil
+	}
+	mesh := c.meshWatcher.Mesh()
+	t := c.telemetries.get(pod.Namespace, mesh, func() *model.Telemetries {
+		cfgs := c.configController.List(gvk.Telemetry, pod.Namespace)
+		if rootns := mesh.GetRootNamespace(); rootns != pod.Namespace {
+			cfgs = append(cfgs, c.configController.List(gvk.Telemetry, rootns)...)
+		}
+		if len(cfgs) == 0 {
+			return nil
+		}
+		return model.NewZtunnelTelemetries(cfgs, mesh)
+	})
+	if t == nil {
+		return nil
+	}
+	return t.ZtunnelTelemetry(pod.Namespace, pod.Labels)
+}
@@ -0,0 +1,101 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// handleEndpointSlice updates the workloads of the pods whose zone hints changed in an EndpointSlice, so ztunnel
+// follows the topology aware hints of their Services. The other fields of EndpointSlices are derived from the pods and
+// Services the workloads are built from.
+func (a *AmbientIndex) handleEndpointSlice(old, ep *discovery.EndpointSlice, event model.Event, c *Controller) {
+	oldHints, newHints := endpointSliceHints(old), endpointSliceHints(ep)
+	if event == model.EventDelete {
+		// Delete events have the deleted EndpointSlice
+		oldHints, newHints = newHints, map[string][]string{}
+	}
+	changed := sets.New[string]()
+	for ip, hints := range newHints {
+		if !slices.Equal(oldHints[ip], hints) {
+			changed.Insert(ip)
+		}
+	}
+	for ip := range oldHints {
+		if _, f := newHints[ip]; !f {
+			changed.Insert(ip)
+		}
+	}
+	var pods []*v1.Pod
+	for ip := range changed {
+		if p := c.pods.getPodByIP(ip); p != nil {
+			pods = append(pods, p)
+		}
+	}
+	if len(pods) > 0 {
+		a.handlePods(pods, c)
+	}
+}
+
+// endpointSliceHints returns the zones hinted for each address of an EndpointSlice.
+func endpointSliceHints(ep *discovery.EndpointSlice) map[string][]string {
+	res := map[string][]string{}
+	if ep == nil {
+		return res
+	}
+	for _, e := range ep.Endpoints {
+		zones := forZones(e.Hints)
+		for _, addr := range e.Addresses {
+			res[addr] = zones
+		}
+	}
+	return res
+}
+
+// zoneHints returns the zones hinted for a pod in the EndpointSlices of a Service.
+func (c *Controller) zoneHints(svc *v1.Service, ip string) []string {
+	eps := c.endpoints.slices.List(svc.Namespace, klabels.SelectorFromSet(map[string]string{discovery.LabelServiceName: svc.Name}))
+	for _, ep := range eps {
+		for _, e := range ep.Endpoints {
+			if slices.Contains(e.Addresses, ip) {
+				return forZones(e.Hints)
+			}
+		}
+	}
+	return nil
+}
+
+func forZones(hints *discovery.EndpointHints) []string {
+	if hints == nil {
+		return nil
+	}
+	var zones []string
+	for _, z := range hints.ForZones {
+		zones = append(zones, z.Name)
+	}
+	return zones
+}
+
+// internalTrafficPolicyLocal returns whether the traffic to the cluster IP of a Service is only sent to the endpoints
+// on the node of the client.
+func internalTrafficPolicyLocal(svc *v1.Service) bool {
+	return svc.Spec.InternalTrafficPolicy != nil && *svc.Spec.InternalTrafficPolicy == v1.ServiceInternalTrafficPolicyLocal
+}
@@ -0,0 +1,91 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"strings"
+
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/config/schema/kind"
+	"istio.io/istio/pkg/spiffe"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// trustDomain returns the trust domain of the identities of the workloads of a namespace. Namespaces may override the
+// trust domain of the mesh with the ambient.istio.io/trust-domain annotation.
+func (c *Controller) trustDomain(ns string) string {
+	if n := c.namespaces.Get(ns, ""); n != nil {
+		if td := n.Annotations[constants.AmbientTrustDomain]; td != "" {
+			return td
+		}
+	}
+	return spiffe.GetTrustDomain()
+}
+
+// workloadTrustDomain returns the trust domain sent to ztunnel for the workloads of a namespace, which is elided if
+// it is the default one.
+func (c *Controller) workloadTrustDomain(ns string) string {
+	if td := c.trustDomain(ns); td != "cluster.local" {
+		return td
+	}
+	return ""
+}
+
+// handleNamespaceTrustDomain updates the workloads of a namespace whose trust domain changed, and the policies, whose
+// principals may name the workloads of the namespace.
+func (a *AmbientIndex) handleNamespaceTrustDomain(ns *v1.Namespace, c *Controller) {
+	a.handlePods(c.podsClient.List(ns.Name, klabels.Everything()), c)
+	updates := sets.New[model.ConfigKey]()
+	for _, cfg := range c.configController.List(gvk.AuthorizationPolicy, metav1.NamespaceAll) {
+		updates.Insert(model.ConfigKey{Kind: kind.AuthorizationPolicy, Name: cfg.Name, Namespace: cfg.Namespace})
+	}
+	if len(updates) > 0 {
+		c.opts.XDSUpdater.ConfigUpdate(&model.PushRequest{
+			ConfigsUpdated: updates,
+			Reason:         []model.TriggerReason{model.AmbientUpdate},
+		})
+	}
+}
+
+// expandPrincipals adds the principals of the namespaces with their own trust domain to the principals of a policy.
+// Policies name principals in the trust domain of the mesh, or in cluster.local, which is an alias of the trust domain
+// of the mesh, so a principal of a namespace overriding its trust domain is also matched in that trust domain.
+func expandPrincipals(principals []string, trustDomain func(ns string) string) []string {
+	if trustDomain == nil {
+		return principals
+	}
+	res := slices.Clone(principals)
+	for _, p := range principals {
+		// Principals are formatted as <trust domain>/ns/<namespace>/sa/<service account>
+		parts := strings.SplitN(p, "/", 4)
+		if len(parts) < 3 || parts[1] != "ns" || strings.Contains(parts[2], "*") {
+			continue
+		}
+		if parts[0] != spiffe.GetTrustDomain() && parts[0] != "cluster.local" {
+			continue
+		}
+		if td := trustDomain(parts[2]); td != spiffe.GetTrustDomain() && td != parts[0] {
+			res = append(res, td+strings.TrimPrefix(p, parts[0]))
+		}
+	}
+	return res
+}
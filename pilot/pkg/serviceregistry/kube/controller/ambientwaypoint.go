@@ -0,0 +1,217 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/slices"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"istio.io/api/label"
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/config/schema/kind"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// revisionWeight is the share of workloads sent to the waypoint pods of a revision.
+type revisionWeight struct {
+	Revision string
+	Weight   uint32
+}
+
+// parseRevisionWeights parses the WaypointRevisionWeights annotation.
+func parseRevisionWeights(s string) ([]revisionWeight, error) {
+	var res []revisionWeight
+	seen := sets.New[string]()
+	for _, entry := range strings.Split(s, ",") {
+		rev, weight, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || rev == "" {
+			return nil, fmt.Errorf("invalid entry %q, expected revision=weight", entry)
+		}
+		w, err := strconv.ParseUint(weight, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight for revision %q: %v", rev, err)
+		}
+		if seen.InsertContains(rev) {
+			return nil, fmt.Errorf("duplicate revision %q", rev)
+		}
+		res = append(res, revisionWeight{Revision: rev, Weight: uint32(w)})
+	}
+	return res, nil
+}
+
+// waypointRevision returns the revision of a waypoint pod.
+func waypointRevision(p *v1.Pod) string {
+	if rev := p.Labels[label.IoIstioRev.Name]; rev != "" {
+		return rev
+	}
+	if rev := p.Annotations[label.IoIstioRev.Name]; rev != "" {
+		return rev
+	}
+	return "default"
+}
+
+// waypointRevisionWeights returns the revision weights configured on a waypoint Gateway, if any.
+func (c *Controller) waypointRevisionWeights(name types.NamespacedName) []revisionWeight {
+	if c.configController == nil {
+		return nil
+	}
+	gw := c.configController.Get(gvk.KubernetesGateway, name.Name, name.Namespace)
+	if gw == nil || gw.Annotations[constants.WaypointRevisionWeights] == "" {
+		return nil
+	}
+	weights, err := parseRevisionWeights(gw.Annotations[constants.WaypointRevisionWeights])
+	if err != nil {
+		log.Warnf("ignoring %s annotation on Gateway %s: %v", constants.WaypointRevisionWeights, name, err)
+		return nil
+	}
+	return weights
+}
+
+// selectWaypointRevision filters the waypoints of a pod down to a single revision for each waypoint Gateway with
+// revision weights. Each pod is consistently sent to the same revision, picked by hashing its UID, so the share of
+// workloads sent to a revision follows its weight. Revisions without ready pods are skipped, so their share is
+// spread over the others.
+func (c *Controller) selectWaypointRevision(p *v1.Pod, waypoints sets.String) sets.String {
+	if len(waypoints) == 0 {
+		return waypoints
+	}
+	res := waypoints
+	copied := false
+	for name, gw := range c.ambientIndex.gateways {
+		if name.Namespace != p.Namespace {
+			continue
+		}
+		weights := c.waypointRevisionWeights(name)
+		if len(weights) == 0 {
+			continue
+		}
+		available := sets.New[string]()
+		for ip := range gw.addresses {
+			if waypoints.Contains(ip) {
+				available.Insert(gw.revisions[ip])
+			}
+		}
+		key := string(p.UID)
+		if key == "" {
+			key = p.Namespace + "/" + p.Name
+		}
+		rev := pickRevision(weights, available, key)
+		if rev == "" {
+			continue
+		}
+		for ip := range gw.addresses {
+			if waypoints.Contains(ip) && gw.revisions[ip] != rev {
+				if !copied {
+					// The waypoints are shared by the index, so never modify them in place
+					res = waypoints.Copy()
+					copied = true
+				}
+				res.Delete(ip)
+			}
+		}
+	}
+	return res
+}
+
+// pickRevision returns the available revision the key falls into, or an empty string if no available revision has
+// any weight.
+func pickRevision(weights []revisionWeight, available sets.String, key string) string {
+	var total uint32
+	for _, w := range weights {
+		if available.Contains(w.Revision) {
+			total += w.Weight
+		}
+	}
+	if total == 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	bucket := h.Sum32() % total
+	for _, w := range weights {
+		if !available.Contains(w.Revision) {
+			continue
+		}
+		if bucket < w.Weight {
+			return w.Revision
+		}
+		bucket -= w.Weight
+	}
+	return ""
+}
+
+// shiftWaypointRevisions recomputes the waypoints of the workloads served by a waypoint Gateway with revision
+// weights, as its pods changing may change the revision each workload is sent to.
+func (a *AmbientIndex) shiftWaypointRevisions(namespace, gateway string, c *Controller) sets.Set[model.ConfigKey] {
+	name := types.NamespacedName{Namespace: namespace, Name: gateway}
+	gw := a.gateways[name]
+	if gw == nil || len(c.waypointRevisionWeights(name)) == 0 {
+		return nil
+	}
+	return a.recomputeWaypoints(gw.scope, c)
+}
+
+// recomputeWaypoints updates the waypoint addresses of all workloads in the scope.
+func (a *AmbientIndex) recomputeWaypoints(scope model.WaypointScope, c *Controller) sets.Set[model.ConfigKey] {
+	updates := sets.New[model.ConfigKey]()
+	for ip, wl := range a.byPod {
+		if wl.Labels[constants.ManagedGatewayLabel] == constants.ManagedGatewayMeshControllerLabel {
+			continue
+		}
+		if wl.Namespace != scope.Namespace || (scope.ServiceAccount != "" && wl.ServiceAccount != scope.ServiceAccount) {
+			continue
+		}
+		nwl := c.extractWorkload(c.podsClient.Get(wl.Name, wl.Namespace))
+		if nwl == nil || slices.EqualFunc(wl.WaypointAddresses, nwl.WaypointAddresses, bytes.Equal) {
+			continue
+		}
+		wl = a.replaceWaypointAddresses(ip, wl, nwl.WaypointAddresses)
+		updates.Insert(model.ConfigKey{Kind: kind.Address, Name: wl.ResourceName()})
+		updates.Merge(c.updateEndpointsOnWaypointChange(wl))
+	}
+	return updates
+}
+
+// WaypointGatewayHandler shifts traffic between waypoint revisions when the revision weights of a waypoint Gateway
+// change.
+func (c *Controller) WaypointGatewayHandler(old config.Config, obj config.Config, _ model.Event) {
+	if old.Annotations[constants.WaypointRevisionWeights] == obj.Annotations[constants.WaypointRevisionWeights] {
+		return
+	}
+	a := c.ambientIndex
+	a.mu.Lock()
+	var updates sets.Set[model.ConfigKey]
+	if gw := a.gateways[types.NamespacedName{Namespace: obj.Namespace, Name: obj.Name}]; gw != nil {
+		updates = a.recomputeWaypoints(gw.scope, c)
+	}
+	a.mu.Unlock()
+
+	if len(updates) > 0 {
+		c.opts.XDSUpdater.ConfigUpdate(&model.PushRequest{
+			ConfigsUpdated: updates,
+			Reason:         []model.TriggerReason{model.AmbientUpdate},
+		})
+	}
+}
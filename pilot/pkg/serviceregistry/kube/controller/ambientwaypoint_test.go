@@ -0,0 +1,192 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sbeta "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"istio.io/api/label"
+	meshconfig "istio.io/api/mesh/v1alpha1"
+	"istio.io/istio/pilot/pkg/config/memory"
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/mesh"
+	"istio.io/istio/pkg/config/schema/collections"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/kube/kclient/clienttest"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+	"istio.io/istio/pkg/util/sets"
+)
+
+func TestParseRevisionWeights(t *testing.T) {
+	weights, err := parseRevisionWeights("stable=90, canary=10")
+	assert.NoError(t, err)
+	assert.Equal(t, weights, []revisionWeight{{Revision: "stable", Weight: 90}, {Revision: "canary", Weight: 10}})
+
+	for _, invalid := range []string{"stable", "=10", "stable=-1", "stable=abc", "stable=10,stable=20"} {
+		_, err := parseRevisionWeights(invalid)
+		assert.Error(t, err)
+	}
+}
+
+func TestPickRevision(t *testing.T) {
+	weights := []revisionWeight{{Revision: "stable", Weight: 75}, {Revision: "canary", Weight: 25}}
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		counts[pickRevision(weights, sets.New("stable", "canary"), fmt.Sprint(i))]++
+	}
+	if counts["canary"] < 150 || counts["canary"] > 350 {
+		t.Fatalf("expected about a quarter of keys to pick canary, got %v", counts)
+	}
+	// The same key always picks the same revision
+	assert.Equal(t, pickRevision(weights, sets.New("stable", "canary"), "key"), pickRevision(weights, sets.New("stable", "canary"), "key"))
+	// Unavailable revisions are skipped
+	assert.Equal(t, pickRevision(weights, sets.New("canary"), "key"), "canary")
+	assert.Equal(t, pickRevision(weights, sets.New("other"), "key"), "")
+	assert.Equal(t, pickRevision([]revisionWeight{{Revision: "stable", Weight: 0}}, sets.New("stable"), "key"), "")
+}
+
+func TestWaypointRevisionShifting(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	cfg := memory.NewSyncController(memory.MakeSkipValidation(collections.PilotGatewayAPI))
+	controller, _ := NewFakeControllerWithOptions(t, FakeControllerOptions{
+		ConfigController: cfg,
+		MeshWatcher:      mesh.NewFixedWatcher(&meshconfig.MeshConfig{RootNamespace: "istio-system"}),
+		ClusterID:        "cluster0",
+	})
+	pc := clienttest.Wrap(t, controller.podsClient)
+	cfg.RegisterEventHandler(gvk.KubernetesGateway, controller.WaypointGatewayHandler)
+	go cfg.Run(test.NewStop(t))
+
+	addPod := func(ip, name string, labels map[string]string) {
+		t.Helper()
+		pod := generatePod(ip, name, "ns1", "sa1", "node1", labels, nil)
+		pod.Status = corev1.PodStatus{}
+		newPod := pc.Create(pod)
+		setPodReady(newPod)
+		newPod.Status.PodIP = ip
+		newPod.Status.Phase = corev1.PodRunning
+		pc.UpdateStatus(newPod)
+	}
+	addWaypoint := func(ip, name, revision string) {
+		t.Helper()
+		addPod(ip, name, map[string]string{
+			constants.ManagedGatewayLabel: constants.ManagedGatewayMeshControllerLabel,
+			constants.GatewayNameLabel:    "waypoint",
+			label.IoIstioRev.Name:         revision,
+		})
+	}
+	setWeights := func(weights string) {
+		t.Helper()
+		gw := config.Config{
+			Meta: config.Meta{
+				GroupVersionKind: gvk.KubernetesGateway,
+				Name:             "waypoint",
+				Namespace:        "ns1",
+				Annotations:      map[string]string{constants.WaypointRevisionWeights: weights},
+			},
+			Spec: &k8sbeta.GatewaySpec{GatewayClassName: constants.WaypointGatewayClassName},
+		}
+		if cfg.Get(gvk.KubernetesGateway, "waypoint", "ns1") == nil {
+			_, err := cfg.Create(gw)
+			assert.NoError(t, err)
+		} else {
+			_, err := cfg.Update(gw)
+			assert.NoError(t, err)
+		}
+	}
+	// waypoints returns, for each workload, its waypoint addresses
+	waypoints := func() map[string][]string {
+		res := map[string][]string{}
+		for i := 0; i < 10; i++ {
+			name := fmt.Sprintf("name%d", i)
+			wls := controller.ambientIndex.Lookup(fmt.Sprintf("127.0.0.%d", i+1))
+			if len(wls) != 1 {
+				continue
+			}
+			addrs := []string{}
+			for _, a := range wls[0].WaypointAddresses {
+				ip, _ := netip.AddrFromSlice(a)
+				addrs = append(addrs, ip.String())
+			}
+			res[name] = addrs
+		}
+		return res
+	}
+	assertAll := func(want ...string) {
+		t.Helper()
+		assert.EventuallyEqual(t, func() bool {
+			wps := waypoints()
+			if len(wps) != 10 {
+				return false
+			}
+			for _, addrs := range wps {
+				if !sets.New(addrs...).Equals(sets.New(want...)) {
+					return false
+				}
+			}
+			return true
+		}, true, retry.Timeout(time.Second*3))
+	}
+
+	addWaypoint("10.0.0.1", "waypoint-stable", "stable")
+	addWaypoint("10.0.0.2", "waypoint-canary", "canary")
+	for i := 0; i < 10; i++ {
+		addPod(fmt.Sprintf("127.0.0.%d", i+1), fmt.Sprintf("name%d", i), map[string]string{"app": "a"})
+	}
+	// Without weights, all waypoint pods are used
+	assertAll("10.0.0.1", "10.0.0.2")
+
+	setWeights("stable=100,canary=0")
+	assertAll("10.0.0.1")
+
+	setWeights("stable=0,canary=100")
+	assertAll("10.0.0.2")
+
+	// Each workload is sent to one of the revisions
+	setWeights("stable=50,canary=50")
+	assert.EventuallyEqual(t, func() bool {
+		revisions := sets.New[string]()
+		wps := waypoints()
+		for _, addrs := range wps {
+			if len(addrs) != 1 {
+				return false
+			}
+			revisions.Insert(addrs[0])
+		}
+		return len(wps) == 10 && revisions.Len() == 2
+	}, true, retry.Timeout(time.Second*3))
+
+	// Revisions without pods are skipped
+	setWeights("stable=0,canary=100")
+	assertAll("10.0.0.2")
+	pc.Delete("waypoint-canary", "ns1")
+	assertAll("10.0.0.1")
+	addWaypoint("10.0.0.2", "waypoint-canary", "canary")
+	assertAll("10.0.0.2")
+
+	// Invalid weights are ignored
+	setWeights("stable=abc")
+	assertAll("10.0.0.1", "10.0.0.2")
+}
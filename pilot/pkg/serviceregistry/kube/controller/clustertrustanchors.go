@@ -0,0 +1,46 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"istio.io/istio/pkg/cluster"
+	"istio.io/istio/pkg/config/constants"
+	kubelib "istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/controllers"
+	"istio.io/istio/pkg/kube/kclient"
+	pkiutil "istio.io/istio/security/pkg/pki/util"
+)
+
+// watchClusterTrustAnchors adds the roots of a remote cluster to the trust bundle of the mesh. The istiod of each
+// primary cluster publishes its roots in the istio-ca-root-cert ConfigMap of its system namespace, so the workloads,
+// and the ztunnels, of a multi-primary mesh whose clusters do not share a root trust the workloads of the other
+// clusters.
+func (m *Multicluster) watchClusterTrustAnchors(client kubelib.Client, clusterID cluster.ID, systemNamespace string) {
+	// The ConfigMaps informer is shared with the namespace controller, so the ConfigMap is filtered on the client side
+	configMaps := kclient.New[*v1.ConfigMap](client)
+	configMaps.AddEventHandler(controllers.FilteredObjectSpecHandler(func(controllers.Object) {
+		var certs []string
+		if cm := configMaps.Get(CACertNamespaceConfigMap, systemNamespace); cm != nil {
+			certs = pkiutil.PemCertBytestoString([]byte(cm.Data[constants.CACertNamespaceConfigMapDataName]))
+		}
+		if err := m.trustBundle.UpdateClusterTrustAnchors(clusterID.String(), certs); err != nil {
+			log.Errorf("failed to update the trust anchors of cluster %s: %v", clusterID, err)
+		}
+	}, func(o controllers.Object) bool {
+		return o.GetName() == CACertNamespaceConfigMap && o.GetNamespace() == systemNamespace
+	}))
+}
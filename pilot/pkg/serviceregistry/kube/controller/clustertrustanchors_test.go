@@ -0,0 +1,69 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/istio/pilot/pkg/trustbundle"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/env"
+	"istio.io/istio/pkg/test/util/retry"
+)
+
+func TestWatchClusterTrustAnchors(t *testing.T) {
+	root, err := os.ReadFile(filepath.Join(env.IstioSrc, "samples/certs/root-cert.pem"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := kube.NewFakeClient(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: CACertNamespaceConfigMap, Namespace: "istio-system"},
+		Data:       map[string]string{constants.CACertNamespaceConfigMapDataName: string(root)},
+	}, &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: CACertNamespaceConfigMap, Namespace: "default"},
+		Data:       map[string]string{constants.CACertNamespaceConfigMapDataName: "ignored"},
+	})
+	tb := trustbundle.NewTrustBundle(nil)
+	m := &Multicluster{trustBundle: tb}
+	m.watchClusterTrustAnchors(client, "remote", "istio-system")
+	client.RunAndWait(test.NewStop(t))
+
+	expectAnchors := func(n int) {
+		t.Helper()
+		retry.UntilSuccessOrFail(t, func() error {
+			if got := len(tb.GetTrustBundle()); got != n {
+				return fmt.Errorf("expected %d trust anchors, got %d", n, got)
+			}
+			return nil
+		})
+	}
+	expectAnchors(1)
+
+	// The roots of the cluster are removed with its ConfigMap
+	err = client.Kube().CoreV1().ConfigMaps("istio-system").Delete(context.Background(), CACertNamespaceConfigMap, metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectAnchors(0)
+}
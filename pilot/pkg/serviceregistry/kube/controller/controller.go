@@ -93,12 +93,21 @@ var (
 		"pilot_k8s_endpoints_pending_pod",
 		"Number of endpoints that do not currently have any corresponding pods.",
 	)
+
+	clusterLabel = monitoring.MustCreateLabel("cluster")
+
+	uncapturedWorkloads = monitoring.NewGauge(
+		"pilot_ambient_uncaptured_workloads",
+		"Number of pods in ambient enabled namespaces which have not been captured by ztunnel.",
+		monitoring.WithLabels(clusterLabel),
+	)
 )
 
 func init() {
 	monitoring.MustRegister(k8sEvents)
 	monitoring.MustRegister(endpointsWithNoPods)
 	monitoring.MustRegister(endpointsPendingPodUpdate)
+	monitoring.MustRegister(uncapturedWorkloads)
 }
 
 func incrementEvent(kind, event string) {
@@ -232,6 +241,8 @@ type Controller struct {
 	ambientIndex     *AmbientIndex
 	configController model.ConfigStoreController
 	configCluster    bool
+	// telemetries caches the Telemetries the telemetry of ambient workloads is computed from, by namespace.
+	telemetries telemetryCache
 }
 
 // NewController creates a new Kubernetes controller
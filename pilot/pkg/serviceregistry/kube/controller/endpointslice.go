@@ -80,9 +80,12 @@ func (esc *endpointSliceController) sync(name, ns string, event model.Event, fil
 	return err.ErrorOrNil()
 }
 
-func (esc *endpointSliceController) onEvent(_, ep *v1.EndpointSlice, event model.Event) error {
+func (esc *endpointSliceController) onEvent(old, ep *v1.EndpointSlice, event model.Event) error {
 	esLabels := ep.GetLabels()
 	if endpointSliceSelector.Matches(klabels.Set(esLabels)) {
+		if esc.c.ambientIndex != nil {
+			esc.c.ambientIndex.handleEndpointSlice(old, ep, event, esc.c)
+		}
 		return esc.processEndpointEvent(serviceNameForEndpointSlice(esLabels), ep.GetNamespace(), event, ep)
 	}
 	return nil
@@ -34,6 +34,7 @@ import (
 	"istio.io/istio/pilot/pkg/server"
 	"istio.io/istio/pilot/pkg/serviceregistry/provider"
 	"istio.io/istio/pilot/pkg/serviceregistry/serviceentry"
+	"istio.io/istio/pilot/pkg/trustbundle"
 	"istio.io/istio/pkg/backoff"
 	"istio.io/istio/pkg/cluster"
 	"istio.io/istio/pkg/config/schema/collection"
@@ -81,6 +82,8 @@ type Multicluster struct {
 	startNsController bool
 	caBundleWatcher   *keycertbundle.Watcher
 	revision          string
+	// trustBundle, if set, is the trust bundle of the mesh the roots of the remote clusters are added to.
+	trustBundle *trustbundle.TrustBundle
 
 	// secretNamespace where we get cluster-access secrets
 	secretNamespace string
@@ -95,6 +98,7 @@ func NewMulticluster(
 	serviceEntryController *serviceentry.Controller,
 	configController model.ConfigStoreController,
 	caBundleWatcher *keycertbundle.Watcher,
+	trustBundle *trustbundle.TrustBundle,
 	revision string,
 	startNsController bool,
 	clusterLocal model.ClusterLocalProvider,
@@ -108,6 +112,7 @@ func NewMulticluster(
 		configController:       configController,
 		startNsController:      startNsController,
 		caBundleWatcher:        caBundleWatcher,
+		trustBundle:            trustBundle,
 		revision:               revision,
 		XDSUpdater:             opts.XDSUpdater,
 		remoteKubeControllers:  remoteKubeController,
@@ -236,6 +241,18 @@ func (m *Multicluster) initializeCluster(cluster *multicluster.Cluster, kubeCont
 	}
 	if m.configController != nil && features.EnableAmbientControllers {
 		m.configController.RegisterEventHandler(gvk.AuthorizationPolicy, kubeRegistry.AuthorizationPolicyHandler)
+		m.configController.RegisterEventHandler(gvk.DestinationRule, kubeRegistry.DestinationRuleHandler)
+		m.configController.RegisterEventHandler(gvk.Telemetry, kubeRegistry.TelemetryHandler)
+		if features.EnableGatewayAPI {
+			m.configController.RegisterEventHandler(gvk.KubernetesGateway, kubeRegistry.WaypointGatewayHandler)
+		}
+		if configCluster {
+			m.configController.RegisterEventHandler(gvk.ServiceEntry, kubeRegistry.ServiceEntryHandler)
+		}
+	}
+
+	if !configCluster && m.trustBundle != nil && features.MultiRootMesh {
+		m.watchClusterTrustAnchors(client, cluster.ID, options.SystemNamespace)
 	}
 
 	if configCluster && m.serviceEntryController != nil && features.EnableEnhancedResourceScoping {
@@ -326,6 +343,20 @@ func (m *Multicluster) initializeCluster(cluster *multicluster.Cluster, kubeCont
 		}
 	}()
 
+	if features.EnableAmbientControllers && features.AmbientConsistencyCheckInterval > 0 {
+		log.Infof("joining leader-election for %s in %s on cluster %s",
+			leaderelection.AmbientConsistencyController, options.SystemNamespace, options.ClusterID)
+		// Block server exit on graceful termination of the leader controller.
+		m.s.RunComponentAsyncAndWait("ambient consistency checker", func(_ <-chan struct{}) error {
+			leaderelection.
+				NewLeaderElectionMulticluster(options.SystemNamespace, m.serverID, leaderelection.AmbientConsistencyController, m.revision, !configCluster, client).
+				AddRunFunction(func(leaderStop <-chan struct{}) {
+					newAmbientConsistencyChecker(kubeRegistry, features.AmbientConsistencyCheckInterval).Run(leaderStop)
+				}).Run(clusterStopCh)
+			return nil
+		})
+	}
+
 	// setting up the serviceexport controller if and only if it is turned on in the meshconfig.
 	if features.EnableMCSAutoExport {
 		log.Infof("joining leader-election for %s in %s on cluster %s",
@@ -411,6 +442,11 @@ func (m *Multicluster) deleteCluster(clusterID cluster.ID) {
 	if err := kc.Cleanup(); err != nil {
 		log.Warnf("failed cleaning up services in %s: %v", clusterID, err)
 	}
+	if m.trustBundle != nil {
+		if err := m.trustBundle.UpdateClusterTrustAnchors(clusterID.String(), nil); err != nil {
+			log.Warnf("failed removing the trust anchors of %s: %v", clusterID, err)
+		}
+	}
 	delete(m.remoteKubeControllers, clusterID)
 }
 
@@ -976,6 +976,24 @@ func autoAllocateIPs(services []*model.Service) []*model.Service {
 	return services
 }
 
+// AutoAllocatedAddresses returns the addresses automatically allocated to the hosts of the given ServiceEntries,
+// by namespace/hostname. The addresses are allocated as for the services of the ServiceEntry registry, as described in
+// autoAllocateIPs, so they match the addresses the DNS proxy of sidecars resolves the hosts to.
+func AutoAllocatedAddresses(serviceEntries []config.Config) map[string][]string {
+	var services []*model.Service
+	for _, cfg := range serviceEntries {
+		services = append(services, convertServices(cfg)...)
+	}
+	autoAllocateIPs(model.SortServicesByCreationTime(services))
+	res := map[string][]string{}
+	for _, svc := range services {
+		if svc.AutoAllocatedIPv4Address != "" {
+			res[makeServiceKey(svc)] = []string{svc.AutoAllocatedIPv4Address, svc.AutoAllocatedIPv6Address}
+		}
+	}
+	return res
+}
+
 func makeServiceKey(svc *model.Service) string {
 	return svc.Attributes.Namespace + "/" + svc.Hostname.String()
 }
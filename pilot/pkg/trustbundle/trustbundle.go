@@ -23,10 +23,13 @@ import (
 	"sync"
 	"time"
 
+	"golang.org/x/exp/maps"
+
 	meshconfig "istio.io/api/mesh/v1alpha1"
 	"istio.io/istio/pkg/spiffe"
 	"istio.io/istio/pkg/util/sets"
 	"istio.io/pkg/log"
+	"istio.io/pkg/monitoring"
 )
 
 // Source is all possible sources of MeshConfig
@@ -42,26 +45,53 @@ type TrustAnchorUpdate struct {
 }
 
 type TrustBundle struct {
-	sourceConfig       map[Source]TrustAnchorConfig
-	mutex              sync.RWMutex
-	mergedCerts        []string
-	updatecb           func()
-	endpointMutex      sync.RWMutex
-	endpoints          []string
-	endpointUpdateChan chan struct{}
-	remoteCaCertPool   *x509.CertPool
+	sourceConfig  map[Source]TrustAnchorConfig
+	mutex         sync.RWMutex
+	mergedCerts   []string
+	updatecb      func()
+	endpointMutex sync.RWMutex
+	endpoints     []string
+	// endpointTrustDomains maps a SPIFFE bundle endpoint to the federated trust domains its bundle is trusted for.
+	// Endpoints without an explicit trust domain serve the bundle for the local trust domain.
+	endpointTrustDomains map[string][]string
+	endpointUpdateChan   chan struct{}
+	remoteCaCertPool     *x509.CertPool
+	// trustDomainCerts are the trust anchors fetched for the federated trust domains, by trust domain.
+	trustDomainCerts map[string][]string
+	// clusterCerts are the trust anchors of the remote clusters of the mesh, by cluster.
+	clusterMutex sync.Mutex
+	clusterCerts map[string][]string
 }
 
 var (
 	trustBundleLog = log.RegisterScope("trustBundle", "Workload mTLS trust bundle logs")
 	remoteTimeout  = 10 * time.Second
+
+	trustDomainTag = monitoring.MustCreateLabel("trust_domain")
+	resultTag      = monitoring.MustCreateLabel("result")
+
+	remoteFetches = monitoring.NewSum(
+		"pilot_trust_bundle_remote_fetches",
+		"Total number of SPIFFE bundle endpoint fetches, by trust domain and result.",
+		monitoring.WithLabels(trustDomainTag, resultTag),
+	)
+
+	trustAnchors = monitoring.NewGauge(
+		"pilot_trust_bundle_anchors",
+		"Number of trust anchors in the workload trust bundle.",
+	)
 )
 
+func init() {
+	monitoring.MustRegister(remoteFetches, trustAnchors)
+}
+
 const (
 	SourceIstioCA Source = iota
 	SourceMeshConfig
 	SourceIstioRA
 	sourceSpiffeEndpoints
+	SourceRemoteClusters
 
 	RemoteDefaultPollPeriod = 30 * time.Minute
 )
@@ -87,11 +117,16 @@ func NewTrustBundle(remoteCaCertPool *x509.CertPool) *TrustBundle {
 			SourceMeshConfig:      {Certs: []string{}},
 			SourceIstioRA:         {Certs: []string{}},
 			sourceSpiffeEndpoints: {Certs: []string{}},
+			SourceRemoteClusters:  {Certs: []string{}},
 		},
 		mergedCerts:        []string{},
 		updatecb:           nil,
 		endpointUpdateChan: make(chan struct{}, 1),
 		endpoints:          []string{},
+
+		endpointTrustDomains: map[string][]string{},
+		trustDomainCerts:     map[string][]string{},
+		clusterCerts:         map[string][]string{},
 	}
 	if remoteCaCertPool == nil {
 		tb.remoteCaCertPool, err = x509.SystemCertPool()
@@ -117,6 +152,19 @@ func (tb *TrustBundle) GetTrustBundle() []string {
 	return trustedCerts
 }
 
+// GetTrustDomainBundles returns the trust anchors of the federated trust domains, by trust domain. These are also part
+// of the trust bundle, but are only trusted for the identities of their trust domains by the proxies consuming them
+// per trust domain, such as ztunnel.
+func (tb *TrustBundle) GetTrustDomainBundles() map[string][]string {
+	tb.mutex.RLock()
+	defer tb.mutex.RUnlock()
+	res := make(map[string][]string, len(tb.trustDomainCerts))
+	for td, certs := range tb.trustDomainCerts {
+		res[td] = append([]string(nil), certs...)
+	}
+	return res
+}
+
 func verifyTrustAnchor(trustAnchor string) error {
 	block, _ := pem.Decode([]byte(trustAnchor))
 	if block == nil {
@@ -148,6 +196,7 @@ func (tb *TrustBundle) mergeInternal() {
 	}
 	tb.mergedCerts = mergeCerts
 	sort.Strings(tb.mergedCerts)
+	trustAnchors.Record(float64(len(tb.mergedCerts)))
 }
 
 // UpdateTrustAnchor : External Function to merge a TrustAnchor config with the existing TrustBundle
@@ -189,17 +238,45 @@ func (tb *TrustBundle) UpdateTrustAnchor(anchorConfig *TrustAnchorUpdate) error
 	return nil
 }
 
-func (tb *TrustBundle) updateRemoteEndpoint(spiffeEndpoints []string) {
+// UpdateClusterTrustAnchors sets the trust anchors of a remote cluster of the mesh, or removes them if certs is empty.
+// The trust anchors of all the remote clusters are merged in the SourceRemoteClusters source, so the workloads of a
+// multi-primary mesh whose clusters do not share a root trust the workloads of the other clusters.
+func (tb *TrustBundle) UpdateClusterTrustAnchors(clusterID string, certs []string) error {
+	for _, cert := range certs {
+		if err := verifyTrustAnchor(cert); err != nil {
+			return err
+		}
+	}
+	tb.clusterMutex.Lock()
+	defer tb.clusterMutex.Unlock()
+	if len(certs) == 0 {
+		delete(tb.clusterCerts, clusterID)
+	} else {
+		tb.clusterCerts[clusterID] = certs
+	}
+	merged := sets.New[string]()
+	for _, c := range tb.clusterCerts {
+		merged.InsertAll(c...)
+	}
+	return tb.UpdateTrustAnchor(&TrustAnchorUpdate{
+		TrustAnchorConfig: TrustAnchorConfig{Certs: sets.SortedList(merged)},
+		Source:            SourceRemoteClusters,
+	})
+}
+
+func (tb *TrustBundle) updateRemoteEndpoint(spiffeEndpoints []string, trustDomains map[string][]string) {
 	tb.endpointMutex.RLock()
 	remoteEndpoints := tb.endpoints
+	remoteTrustDomains := tb.endpointTrustDomains
 	tb.endpointMutex.RUnlock()
 
-	if isEqSliceStr(spiffeEndpoints, remoteEndpoints) {
+	if isEqSliceStr(spiffeEndpoints, remoteEndpoints) && maps.EqualFunc(trustDomains, remoteTrustDomains, isEqSliceStr) {
 		return
 	}
 	trustBundleLog.Infof("updated remote endpoints  :%v", spiffeEndpoints)
 	tb.endpointMutex.Lock()
 	tb.endpoints = spiffeEndpoints
+	tb.endpointTrustDomains = trustDomains
 	tb.endpointMutex.Unlock()
 	tb.endpointUpdateChan <- struct{}{}
 }
@@ -210,12 +287,17 @@ func (tb *TrustBundle) AddMeshConfigUpdate(cfg *meshconfig.MeshConfig) error {
 	if cfg != nil {
 		certs := []string{}
 		endpoints := []string{}
+		trustDomains := map[string][]string{}
 		for _, pemCert := range cfg.GetCaCertificates() {
 			cert := pemCert.GetPem()
 			if cert != "" {
 				certs = append(certs, cert)
-			} else if pemCert.GetSpiffeBundleUrl() != "" {
-				endpoints = append(endpoints, pemCert.GetSpiffeBundleUrl())
+			} else if endpoint := pemCert.GetSpiffeBundleUrl(); endpoint != "" {
+				endpoints = append(endpoints, endpoint)
+				// If trust domains are specified, the bundle is trusted for these federated trust domains.
+				if len(pemCert.GetTrustDomains()) > 0 {
+					trustDomains[endpoint] = pemCert.GetTrustDomains()
+				}
 			}
 		}
 
@@ -228,7 +310,7 @@ func (tb *TrustBundle) AddMeshConfigUpdate(cfg *meshconfig.MeshConfig) error {
 			return err
 		}
 
-		tb.updateRemoteEndpoint(endpoints)
+		tb.updateRemoteEndpoint(endpoints, trustDomains)
 	}
 	return nil
 }
@@ -238,23 +320,54 @@ func (tb *TrustBundle) fetchRemoteTrustAnchors() {
 
 	tb.endpointMutex.RLock()
 	remoteEndpoints := tb.endpoints
+	remoteTrustDomains := tb.endpointTrustDomains
 	tb.endpointMutex.RUnlock()
 	remoteCerts := []string{}
+	trustDomainCerts := map[string][]string{}
 
-	currentTrustDomain := spiffe.GetTrustDomain()
 	for _, endpoint := range remoteEndpoints {
+		// The bundle of an endpoint is fetched once, and trusted for each of its trust domains.
+		trustDomains := remoteTrustDomains[endpoint]
+		if len(trustDomains) == 0 {
+			trustDomains = []string{spiffe.GetTrustDomain()}
+		}
+		trustDomain := trustDomains[0]
 		trustDomainAnchorMap, err := spiffe.RetrieveSpiffeBundleRootCerts(
-			map[string]string{currentTrustDomain: endpoint}, tb.remoteCaCertPool, remoteTimeout)
+			map[string]string{trustDomain: endpoint}, tb.remoteCaCertPool, remoteTimeout)
 		if err != nil {
-			trustBundleLog.Errorf("unable to fetch trust Anchors from endpoint %s: %s", endpoint, err)
+			trustBundleLog.Errorf("unable to fetch trust Anchors for trust domains %v from endpoint %s: %s", trustDomains, endpoint, err)
+			for _, td := range trustDomains {
+				remoteFetches.With(trustDomainTag.Value(td), resultTag.Value("failure")).Increment()
+			}
 			continue
 		}
-		certs := trustDomainAnchorMap[currentTrustDomain]
-		for _, cert := range certs {
+		var certs []string
+		for _, cert := range trustDomainAnchorMap[trustDomain] {
 			certStr := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
 			trustBundleLog.Debugf("from endpoint %v, fetched trust anchor cert: %v", endpoint, certStr)
-			remoteCerts = append(remoteCerts, certStr)
+			certs = append(certs, certStr)
 		}
+		remoteCerts = append(remoteCerts, certs...)
+		for _, td := range trustDomains {
+			remoteFetches.With(trustDomainTag.Value(td), resultTag.Value("success")).Increment()
+			if len(remoteTrustDomains[endpoint]) > 0 {
+				trustDomainCerts[td] = append(trustDomainCerts[td], certs...)
+			}
+		}
+	}
+	for td, certs := range trustDomainCerts {
+		trustDomainCerts[td] = sets.SortedList(sets.New(certs...))
+	}
+
+	tb.mutex.Lock()
+	trustDomainsChanged := !maps.EqualFunc(trustDomainCerts, tb.trustDomainCerts, isEqSliceStr)
+	tb.trustDomainCerts = trustDomainCerts
+	anchorsChanged := !isEqSliceStr(remoteCerts, tb.sourceConfig[sourceSpiffeEndpoints].Certs)
+	tb.mutex.Unlock()
+	// The trust bundle is only pushed if the trust anchors change, which does not cover the federated trust domains
+	// they are trusted for.
+	if trustDomainsChanged && !anchorsChanged && tb.updatecb != nil {
+		tb.updatecb()
 	}
 	err = tb.UpdateTrustAnchor(&TrustAnchorUpdate{
 		TrustAnchorConfig: TrustAnchorConfig{Certs: remoteCerts},
@@ -25,10 +25,14 @@ import (
 	"testing"
 	"time"
 
+	"golang.org/x/exp/maps"
+
 	meshconfig "istio.io/api/mesh/v1alpha1"
 	"istio.io/istio/pkg/test"
 	"istio.io/istio/pkg/test/env"
+	"istio.io/istio/pkg/test/util/assert"
 	"istio.io/istio/pkg/test/util/retry"
+	"istio.io/istio/pkg/util/sets"
 )
 
 func readCertFromFile(filename string) string {
@@ -255,6 +259,36 @@ func TestUpdateTrustAnchor(t *testing.T) {
 	}
 }
 
+func TestUpdateClusterTrustAnchors(t *testing.T) {
+	cbCounter := 0
+	tb := NewTrustBundle(nil)
+	tb.UpdateCb(func() { cbCounter++ })
+
+	if err := tb.UpdateClusterTrustAnchors("cluster-1", []string{rootCACert}); err != nil {
+		t.Fatalf("cluster update failed: %v", err)
+	}
+	// The same root in another cluster is merged
+	if err := tb.UpdateClusterTrustAnchors("cluster-2", []string{rootCACert, intermediateCACert}); err != nil {
+		t.Fatalf("cluster update failed: %v", err)
+	}
+	result := []string{intermediateCACert, rootCACert}
+	sort.Strings(result)
+	if got := tb.GetTrustBundle(); !isEqSliceStr(got, result) || cbCounter != 2 {
+		t.Errorf("unexpected trust bundle %v, callback value is %v", got, cbCounter)
+	}
+	if err := tb.UpdateClusterTrustAnchors("cluster-3", []string{nonCaCert}); err == nil {
+		t.Errorf("expected an error for a non CA cert")
+	}
+
+	// Removing a cluster keeps the roots of the others
+	if err := tb.UpdateClusterTrustAnchors("cluster-2", nil); err != nil {
+		t.Fatalf("cluster removal failed: %v", err)
+	}
+	if got := tb.GetTrustBundle(); !isEqSliceStr(got, []string{rootCACert}) || cbCounter != 3 {
+		t.Errorf("unexpected trust bundle %v, callback value is %v", got, cbCounter)
+	}
+}
+
 func expectTbCount(t *testing.T, tb *TrustBundle, expAnchorCount int, ti time.Duration, strPrefix string) {
 	t.Helper()
 	retry.UntilSuccessOrFail(t, func() error {
@@ -333,3 +367,64 @@ func TestAddMeshConfigUpdate(t *testing.T) {
 	tb.AddMeshConfigUpdate(&meshconfig.MeshConfig{CaCertificates: []*meshconfig.MeshConfig_CertificateData{}})
 	expectTbCount(t, tb, 0, 3*time.Second, "trustAnchor not updated in bundle after meshConfig cleared")
 }
+
+func TestAddMeshConfigUpdateFederatedTrustDomain(t *testing.T) {
+	caCertPool, err := x509.SystemCertPool()
+	if err != nil {
+		t.Fatalf("failed to get SystemCertPool: %v", err)
+	}
+	stop := test.NewStop(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(validSpiffeX509Bundle))
+	}))
+	caCertPool.AddCert(server.Certificate())
+	defer server.Close()
+
+	tb := NewTrustBundle(caCertPool)
+	remoteTimeout = 30 * time.Millisecond
+	go tb.ProcessRemoteTrustAnchors(stop, 200*time.Millisecond)
+
+	endpoint := server.Listener.Addr().String()
+	trustDomains := func() []string {
+		tb.endpointMutex.RLock()
+		defer tb.endpointMutex.RUnlock()
+		return tb.endpointTrustDomains[endpoint]
+	}
+	bundleTrustDomains := func() []string {
+		return sets.SortedList(sets.New(maps.Keys(tb.GetTrustDomainBundles())...))
+	}
+
+	// The bundle is trusted for all the trust domains of the endpoint
+	tb.AddMeshConfigUpdate(&meshconfig.MeshConfig{CaCertificates: []*meshconfig.MeshConfig_CertificateData{
+		{
+			CertificateData: &meshconfig.MeshConfig_CertificateData_SpiffeBundleUrl{SpiffeBundleUrl: endpoint},
+			TrustDomains:    []string{"federated.example", "other.example"},
+		},
+	}})
+	assert.Equal(t, trustDomains(), []string{"federated.example", "other.example"})
+	expectTbCount(t, tb, 1, 3*time.Second, "federated trustAnchor not updated in bundle")
+	assert.EventuallyEqual(t, bundleTrustDomains, []string{"federated.example", "other.example"})
+	bundles := tb.GetTrustDomainBundles()
+	assert.Equal(t, bundles["federated.example"], tb.GetTrustBundle())
+	assert.Equal(t, bundles["other.example"], tb.GetTrustBundle())
+
+	// Removing a trust domain should be treated as an update, even though the endpoint is unchanged
+	tb.AddMeshConfigUpdate(&meshconfig.MeshConfig{CaCertificates: []*meshconfig.MeshConfig_CertificateData{
+		{
+			CertificateData: &meshconfig.MeshConfig_CertificateData_SpiffeBundleUrl{SpiffeBundleUrl: endpoint},
+			TrustDomains:    []string{"federated.example"},
+		},
+	}})
+	assert.Equal(t, trustDomains(), []string{"federated.example"})
+	assert.EventuallyEqual(t, bundleTrustDomains, []string{"federated.example"})
+
+	// Without trust domains, the endpoint serves the local trust domain, which is not federated
+	tb.AddMeshConfigUpdate(&meshconfig.MeshConfig{CaCertificates: []*meshconfig.MeshConfig_CertificateData{
+		{CertificateData: &meshconfig.MeshConfig_CertificateData_SpiffeBundleUrl{SpiffeBundleUrl: endpoint}},
+	}})
+	assert.Equal(t, len(trustDomains()), 0)
+	assert.EventuallyEqual(t, bundleTrustDomains, []string{})
+	expectTbCount(t, tb, 1, 3*time.Second, "trustAnchor not updated in bundle")
+}
@@ -37,6 +37,7 @@ import (
 	"istio.io/istio/pkg/cluster"
 	"istio.io/istio/pkg/config/schema/kind"
 	"istio.io/istio/pkg/util/sets"
+	"istio.io/istio/pkg/workloadapi"
 	"istio.io/pkg/env"
 	istiolog "istio.io/pkg/log"
 )
@@ -543,6 +544,16 @@ func (s *DiscoveryServer) initConnection(node *core.Node, con *Connection, ident
 		return err
 	}
 
+	// Ztunnels connecting to another replica than the one serving their node are asked to reconnect
+	if s.ztunnelShards != nil && proxy.IsZTunnel() {
+		if err := s.ztunnelShards.admit(proxy.Metadata.NodeName); err != nil {
+			return err
+		}
+	}
+
+	// Compression must be set before any response is sent, which happens once the connection is registered
+	setWorkloadCompressor(streamContext(con), con)
+
 	// Register the connection. this allows pushes to be triggered for the proxy. Note: the timing of
 	// this and initializeProxy important. While registering for pushes *after* initialization is complete seems like
 	// a better choice, it introduces a race condition; If we complete initialization of a new push
@@ -596,6 +607,11 @@ func (s *DiscoveryServer) initProxyMetadata(node *core.Node) (*model.Proxy, erro
 	if err != nil {
 		return nil, status.New(codes.InvalidArgument, err.Error()).Err()
 	}
+	if proxy.IsZTunnel() {
+		if proxy.WorkloadAPIVersion, err = workloadapi.NegotiateVersion(meta.WorkloadAPIVersion); err != nil {
+			return nil, status.New(codes.FailedPrecondition, err.Error()).Err()
+		}
+	}
 	// Update the config namespace associated with this proxy
 	proxy.ConfigNamespace = model.GetProxyConfigNamespace(proxy)
 	proxy.XdsNode = node
@@ -684,11 +700,15 @@ func (s *DiscoveryServer) computeProxyState(proxy *model.Proxy, request *model.P
 			switch conf.Kind {
 			case kind.ServiceEntry, kind.DestinationRule, kind.VirtualService, kind.Sidecar, kind.HTTPRoute, kind.TCPRoute:
 				sidecar = true
-			case kind.Gateway, kind.KubernetesGateway, kind.GatewayClass, kind.ReferenceGrant:
+			case kind.Gateway, kind.KubernetesGateway, kind.GatewayClass:
 				gateway = true
 			case kind.Ingress:
 				sidecar = true
 				gateway = true
+			case kind.ReferenceGrant:
+				// ReferenceGrants also change the backends of the routes of the mesh, served by sidecars and waypoints
+				sidecar = true
+				gateway = true
 			}
 			if sidecar && gateway {
 				break
@@ -0,0 +1,197 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	_ "google.golang.org/grpc/encoding/gzip" // register the gzip compressor
+	"google.golang.org/grpc/stats"
+
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pkg/util/sets"
+)
+
+const zstdCompressorName = "zstd"
+
+// workloadCompressors are the compressions supported for the xDS responses sent to ztunnel.
+var workloadCompressors = sets.New("gzip", zstdCompressorName)
+
+func init() {
+	name := features.AmbientWorkloadCompression
+	if !workloadCompressors.Contains(name) {
+		if name != "" {
+			log.Warnf("unsupported PILOT_AMBIENT_WORKLOAD_COMPRESSION %q, compression is disabled", name)
+		}
+		return
+	}
+	// gRPC has no zstd compressor, so registering it does not change the compression of other streams
+	if name == zstdCompressorName {
+		encoding.RegisterCompressor(newZstdCompressor())
+	}
+}
+
+// setWorkloadCompressor compresses the responses of the stream of a ztunnel with the configured compression, if
+// ztunnel supports it. It must be called before any response is sent on the stream.
+func setWorkloadCompressor(ctx context.Context, con *Connection) {
+	name := features.AmbientWorkloadCompression
+	if !con.proxy.IsZTunnel() || !workloadCompressors.Contains(name) {
+		return
+	}
+	supported, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil {
+		log.Debugf("ADS: %s: unable to read the supported compressions: %v", con.conID, err)
+		return
+	}
+	if !sets.New(supported...).Contains(name) {
+		log.Debugf("ADS: %s does not support %s compression", con.conID, name)
+		return
+	}
+	if err := grpc.SetSendCompressor(ctx, name); err != nil {
+		log.Warnf("ADS: %s: failed to set %s compression: %v", con.conID, name, err)
+		return
+	}
+	if c, ok := ctx.Value(workloadCompressionKey{}).(*workloadCompression); ok {
+		c.name.Store(name)
+	}
+}
+
+// streamContext returns the context of the ADS or delta stream of the connection.
+func streamContext(con *Connection) context.Context {
+	if con.deltaStream != nil {
+		return con.deltaStream.Context()
+	}
+	return con.stream.Context()
+}
+
+// workloadCompressionKey is the context key of the compression of the responses of a stream.
+type workloadCompressionKey struct{}
+
+// workloadCompression is the compression of the responses of a stream, set once setWorkloadCompressor compresses
+// them, so only the compressed sizes of the ztunnel streams are recorded.
+type workloadCompression struct {
+	name atomic.String
+}
+
+// WorkloadCompressionStatsHandler records the compressed size of the responses sent to ztunnel. Compressors are
+// shared by all the streams of the process, so the sizes are recorded from the statistics of the ztunnel streams
+// rather than by wrapping the compressors.
+type WorkloadCompressionStatsHandler struct{}
+
+var _ stats.Handler = WorkloadCompressionStatsHandler{}
+
+func (WorkloadCompressionStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, workloadCompressionKey{}, &workloadCompression{})
+}
+
+func (WorkloadCompressionStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	out, ok := s.(*stats.OutPayload)
+	if !ok {
+		return
+	}
+	c, ok := ctx.Value(workloadCompressionKey{}).(*workloadCompression)
+	if !ok {
+		return
+	}
+	if name := c.name.Load(); name != "" {
+		// The wire length includes the 5 bytes prefix of gRPC messages
+		compressedSizeBytes.With(compressorTag.Value(name)).Record(float64(out.WireLength - 5))
+	}
+}
+
+func (WorkloadCompressionStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (WorkloadCompressionStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// zstdCompressor is a gRPC compressor using zstd. Encoders and decoders are pooled, as they are expensive to create.
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func newZstdCompressor() *zstdCompressor {
+	c := &zstdCompressor{}
+	c.encoders.New = func() any {
+		// Options are valid, so this does not fail
+		e, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return e
+	}
+	c.decoders.New = func() any {
+		d, _ := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		return d
+	}
+	return c
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	e := c.encoders.Get().(*zstd.Encoder)
+	e.Reset(w)
+	return &zstdWriter{e: e, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	d := c.decoders.Get().(*zstd.Decoder)
+	if err := d.Reset(r); err != nil {
+		c.decoders.Put(d)
+		return nil, err
+	}
+	return &zstdReader{d: d, pool: &c.decoders}, nil
+}
+
+func (c *zstdCompressor) Name() string {
+	return zstdCompressorName
+}
+
+type zstdWriter struct {
+	e    *zstd.Encoder
+	pool *sync.Pool
+}
+
+func (w *zstdWriter) Write(p []byte) (int, error) {
+	return w.e.Write(p)
+}
+
+func (w *zstdWriter) Close() error {
+	err := w.e.Close()
+	w.e.Reset(nil)
+	w.pool.Put(w.e)
+	return err
+}
+
+type zstdReader struct {
+	d    *zstd.Decoder
+	pool *sync.Pool
+}
+
+func (r *zstdReader) Read(p []byte) (int, error) {
+	if r.d == nil {
+		return 0, io.EOF
+	}
+	n, err := r.d.Read(p)
+	if err == io.EOF {
+		// The decoder is returned to the pool once the message is read
+		r.pool.Put(r.d)
+		r.d = nil
+	}
+	return n, err
+}
@@ -0,0 +1,65 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/stats"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestZstdCompressor(t *testing.T) {
+	var c encoding.Compressor = newZstdCompressor()
+	msg := []byte(strings.Repeat("workload", 1000))
+	// Encoders and decoders are reused across messages
+	for i := 0; i < 3; i++ {
+		buf := &bytes.Buffer{}
+		w, err := c.Compress(buf)
+		assert.NoError(t, err)
+		_, err = w.Write(msg)
+		assert.NoError(t, err)
+		assert.NoError(t, w.Close())
+		if buf.Len() >= len(msg) {
+			t.Fatalf("expected compressed size below %d, got %d", len(msg), buf.Len())
+		}
+
+		r, err := c.Decompress(buf)
+		assert.NoError(t, err)
+		got, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		assert.Equal(t, got, msg)
+	}
+	assert.Equal(t, c.Name(), "zstd")
+}
+
+func TestWorkloadCompressionStatsHandler(t *testing.T) {
+	h := WorkloadCompressionStatsHandler{}
+	ctx := h.TagRPC(context.Background(), &stats.RPCTagInfo{})
+	c := ctx.Value(workloadCompressionKey{}).(*workloadCompression)
+	// Streams are not recorded until their compression is set, which only happens for ztunnel
+	assert.Equal(t, c.name.Load(), "")
+	h.HandleRPC(ctx, &stats.OutPayload{WireLength: 105})
+	c.name.Store(zstdCompressorName)
+	h.HandleRPC(ctx, &stats.OutPayload{WireLength: 105})
+	// Streams not tagged by the handler are ignored
+	h.HandleRPC(context.Background(), &stats.OutPayload{WireLength: 105})
+}
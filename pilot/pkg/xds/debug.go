@@ -38,10 +38,12 @@ import (
 	"istio.io/istio/pilot/pkg/config/kube/crd"
 	"istio.io/istio/pilot/pkg/features"
 	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/networking/core/v1alpha3"
 	"istio.io/istio/pilot/pkg/networking/util"
 	"istio.io/istio/pilot/pkg/util/protoconv"
 	v3 "istio.io/istio/pilot/pkg/xds/v3"
 	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/config/schema/resource"
 	"istio.io/istio/pkg/config/xds"
 	"istio.io/istio/pkg/security"
@@ -129,6 +131,12 @@ type SyncStatus struct {
 	EndpointAcked        string         `json:"endpoint_acked,omitempty"`
 	ExtensionConfigSent  string         `json:"extensionconfig_sent,omitempty"`
 	ExtensionConfigAcked string         `json:"extensionconfig_acked,omitempty"`
+	// NodeName, and the workload and authorization status, are only reported for ambient proxies.
+	NodeName           string `json:"node_name,omitempty"`
+	WorkloadSent       string `json:"workload_sent,omitempty"`
+	WorkloadAcked      string `json:"workload_acked,omitempty"`
+	AuthorizationSent  string `json:"authorization_sent,omitempty"`
+	AuthorizationAcked string `json:"authorization_acked,omitempty"`
 }
 
 // SyncedVersions shows what resourceVersion of a given resource has been acked by Envoy.
@@ -139,6 +147,12 @@ type SyncedVersions struct {
 	RouteVersion    string `json:"route_acked,omitempty"`
 }
 
+// WaypointDistribution shows which policies and routes are applied by a waypoint, and why.
+type WaypointDistribution struct {
+	ProxyID string `json:"proxy"`
+	v1alpha3.WaypointSelection
+}
+
 // InitDebug initializes the debug handlers and adds a debug in-memory registry.
 func (s *DiscoveryServer) InitDebug(
 	mux *http.ServeMux,
@@ -179,6 +193,8 @@ func (s *DiscoveryServer) AddDebugHandlers(mux, internalMux *http.ServeMux, enab
 
 	s.addDebugHandler(mux, internalMux, "/debug/syncz", "Synchronization status of all Envoys connected to this Pilot instance", s.Syncz)
 	s.addDebugHandler(mux, internalMux, "/debug/config_distribution", "Version status of all Envoys connected to this Pilot instance", s.distributedVersions)
+	s.addDebugHandler(mux, internalMux, "/debug/config_distribution?proxyID=",
+		"Policies and routes selected by a waypoint connected to this Pilot instance, and why", s.distributedVersions)
 
 	s.addDebugHandler(mux, internalMux, "/debug/registryz", "Debug support for registry", s.registryz)
 	s.addDebugHandler(mux, internalMux, "/debug/endpointz", "Debug support for endpoints", s.endpointz)
@@ -203,6 +219,8 @@ func (s *DiscoveryServer) AddDebugHandlers(mux, internalMux *http.ServeMux, enab
 	s.addDebugHandler(mux, internalMux, "/debug/clusterz", "List remote clusters where istiod reads endpoints", s.clusterz)
 	s.addDebugHandler(mux, internalMux, "/debug/networkz", "List cross-network gateways", s.networkz)
 	s.addDebugHandler(mux, internalMux, "/debug/mcsz", "List information about Kubernetes MCS services", s.mcsz)
+	s.addDebugHandler(mux, internalMux, "/debug/uncapturedz", "List ambient workloads not captured by any ztunnel", s.uncapturedz)
+	s.addDebugHandler(mux, internalMux, "/debug/workloadsizez", "Sizes of the fields of the workloads pushed to ztunnel", s.workloadsizez)
 
 	s.addDebugHandler(mux, internalMux, "/debug/list", "List all supported debug commands in json", s.list)
 }
@@ -226,21 +244,7 @@ func (s *DiscoveryServer) allowAuthenticatedOrLocalhost(next http.Handler) http.
 			next.ServeHTTP(w, req)
 			return
 		}
-		// Authenticate request with the same method as XDS
-		authFailMsgs := make([]string, 0)
-		var ids []string
-		authRequest := security.AuthContext{Request: req}
-		for _, authn := range s.Authenticators {
-			u, err := authn.Authenticate(authRequest)
-			// If one authenticator passes, return
-			if u != nil && u.Identities != nil && err == nil {
-				ids = u.Identities
-				break
-			}
-			authFailMsgs = append(authFailMsgs, fmt.Sprintf("Authenticator %s: %v", authn.AuthenticatorType(), err))
-		}
-		if ids == nil {
-			istiolog.Errorf("Failed to authenticate %s %v", req.URL, authFailMsgs)
+		if ids := s.authenticateHTTP(req); ids == nil {
 			// Not including detailed info in the response, XDS doesn't either (returns a generic "authentication failure).
 			w.WriteHeader(http.StatusUnauthorized)
 			return
@@ -251,6 +255,33 @@ func (s *DiscoveryServer) allowAuthenticatedOrLocalhost(next http.Handler) http.
 	}
 }
 
+// authenticateHTTP authenticates a request with the same method as XDS, and returns the identities of the caller, or
+// nil if it is not authenticated.
+func (s *DiscoveryServer) authenticateHTTP(req *http.Request) []string {
+	caller := s.authenticateHTTPCaller(req)
+	if caller == nil {
+		return nil
+	}
+	return caller.Identities
+}
+
+// authenticateHTTPCaller returns the caller of the request, along with its Kubernetes information when the
+// authenticator extracted it, or nil if the request could not be authenticated.
+func (s *DiscoveryServer) authenticateHTTPCaller(req *http.Request) *security.Caller {
+	authFailMsgs := make([]string, 0)
+	authRequest := security.AuthContext{Request: req}
+	for _, authn := range s.Authenticators {
+		u, err := authn.Authenticate(authRequest)
+		// If one authenticator passes, return
+		if u != nil && u.Identities != nil && err == nil {
+			return u
+		}
+		authFailMsgs = append(authFailMsgs, fmt.Sprintf("Authenticator %s: %v", authn.AuthenticatorType(), err))
+	}
+	istiolog.Errorf("Failed to authenticate %s %v", req.URL, authFailMsgs)
+	return nil
+}
+
 func isRequestFromLocalhost(r *http.Request) bool {
 	ip, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
@@ -267,7 +298,7 @@ func (s *DiscoveryServer) Syncz(w http.ResponseWriter, req *http.Request) {
 	for _, con := range s.Clients() {
 		node := con.proxy
 		if node != nil {
-			syncz = append(syncz, SyncStatus{
+			status := SyncStatus{
 				ProxyID:              node.ID,
 				ProxyType:            node.Type,
 				ClusterID:            node.Metadata.ClusterID.String(),
@@ -282,7 +313,15 @@ func (s *DiscoveryServer) Syncz(w http.ResponseWriter, req *http.Request) {
 				EndpointAcked:        con.NonceAcked(v3.EndpointType),
 				ExtensionConfigSent:  con.NonceSent(v3.ExtensionConfigurationType),
 				ExtensionConfigAcked: con.NonceAcked(v3.ExtensionConfigurationType),
-			})
+			}
+			if node.IsAmbient() {
+				status.NodeName = node.GetNodeName()
+				status.WorkloadSent = con.NonceSent(v3.WorkloadType)
+				status.WorkloadAcked = con.NonceAcked(v3.WorkloadType)
+				status.AuthorizationSent = con.NonceSent(v3.WorkloadAuthorizationType)
+				status.AuthorizationAcked = con.NonceAcked(v3.WorkloadAuthorizationType)
+			}
+			syncz = append(syncz, status)
 		}
 	}
 	writeJSON(w, syncz, req)
@@ -382,6 +421,11 @@ const DistributionTrackingDisabledMessage = "Pilot Version tracking is disabled.
 	"PILOT_ENABLE_CONFIG_DISTRIBUTION_TRACKING environment variable to true."
 
 func (s *DiscoveryServer) distributedVersions(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Query().Has("proxyID") {
+		// The policies selected by a waypoint are computed from the current state, so they do not need tracking
+		s.waypointDistribution(w, req)
+		return
+	}
 	if !features.EnableDistributionTracking {
 		w.WriteHeader(http.StatusConflict)
 		_, _ = fmt.Fprint(w, DistributionTrackingDisabledMessage)
@@ -417,6 +461,33 @@ func (s *DiscoveryServer) distributedVersions(w http.ResponseWriter, req *http.R
 	}
 }
 
+// waypointDistribution shows which AuthorizationPolicies, Telemetries and HTTPRoutes are selected by a waypoint, and
+// why the others are not, to debug why a policy is not applied.
+func (s *DiscoveryServer) waypointDistribution(w http.ResponseWriter, req *http.Request) {
+	proxyID, con := s.getDebugConnection(req)
+	if con == nil {
+		s.errorHandler(w, proxyID, con)
+		return
+	}
+	if !con.proxy.IsWaypointProxy() {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, "Proxy %s is not a waypoint\n", proxyID)
+		return
+	}
+	var routes []config.Config
+	if s.Env != nil && s.Env.ConfigStore != nil {
+		routes = s.Env.ConfigStore.List(gvk.HTTPRoute, model.NamespaceAll)
+	}
+	con.proxy.RLock()
+	push := con.proxy.LastPushContext
+	if push == nil {
+		push = s.globalPushContext()
+	}
+	selection := v1alpha3.ExplainWaypoint(con.proxy, push, routes)
+	con.proxy.RUnlock()
+	writeJSON(w, WaypointDistribution{ProxyID: con.proxy.ID, WaypointSelection: selection}, req)
+}
+
 // VersionLen is the Config Version and is only used as the nonce prefix, but we can reconstruct
 // it because is is a b64 encoding of a 64 bit array, which will always be 12 chars in length.
 // len = ceil(bitlength/(2^6))+1
@@ -1094,6 +1165,41 @@ func (s *DiscoveryServer) mcsz(w http.ResponseWriter, req *http.Request) {
 	writeJSON(w, svcs, req)
 }
 
+// UncapturedWorkloadDebug holds debug information for a workload that has not been captured by ztunnel.
+type UncapturedWorkloadDebug struct {
+	model.UncapturedWorkload
+	// ZtunnelConnected indicates whether a ztunnel on the workload's node is connected to this istiod.
+	ZtunnelConnected bool `json:"ztunnelConnected"`
+}
+
+// uncapturedz lists pods in ambient enabled namespaces that have not been enrolled by the CNI node agent.
+// It is mapped to /debug/uncapturedz.
+func (s *DiscoveryServer) uncapturedz(w http.ResponseWriter, req *http.Request) {
+	ztunnelNodes := sets.New[string]()
+	for _, con := range s.Clients() {
+		if con.proxy.IsZTunnel() {
+			ztunnelNodes.Insert(con.proxy.Metadata.NodeName)
+		}
+	}
+	res := []UncapturedWorkloadDebug{}
+	for _, wl := range s.Env.UncapturedWorkloads() {
+		res = append(res, UncapturedWorkloadDebug{
+			UncapturedWorkload: wl,
+			ZtunnelConnected:   ztunnelNodes.Contains(wl.Node),
+		})
+	}
+	writeJSON(w, res, req)
+}
+
+func (s *DiscoveryServer) workloadsizez(w http.ResponseWriter, req *http.Request) {
+	gen, ok := s.Generators[v3.WorkloadType].(*WorkloadGenerator)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	writeJSON(w, gen.cache.fieldSizes(), req)
+}
+
 func sortMCSServices(svcs []model.MCSServiceInfo) []model.MCSServiceInfo {
 	sort.Slice(svcs, func(i, j int) bool {
 		if strings.Compare(svcs[i].Cluster.String(), svcs[j].Cluster.String()) < 0 {
@@ -25,6 +25,7 @@ import (
 	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	"github.com/google/uuid"
 	"go.uber.org/atomic"
+	"golang.org/x/exp/slices"
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 
@@ -67,6 +68,14 @@ type debounceOptions struct {
 
 	// enableEDSDebounce indicates whether EDS pushes should be debounced.
 	enableEDSDebounce bool
+
+	// ambientReadinessFastPush indicates whether the pushes of ambient workloads becoming ready or unready bypass
+	// debouncing.
+	ambientReadinessFastPush bool
+
+	// ambientReadinessPushWindow is the window the readiness changes of ambient workloads are coalesced in, when they
+	// bypass debouncing. At most one push of readiness changes is sent per window.
+	ambientReadinessPushWindow time.Duration
 }
 
 // DiscoveryServer is Pilot's gRPC implementation for Envoy's xds APIs
@@ -150,6 +159,12 @@ type DiscoveryServer struct {
 	// ClusterAliases are aliase names for cluster. When a proxy connects with a cluster ID
 	// and if it has a different alias we should use that a cluster ID for proxy.
 	ClusterAliases map[cluster.ID]cluster.ID
+
+	// endpointHealth tracks the connection failures to workloads reported by ztunnel.
+	endpointHealth *endpointHealth
+
+	// ztunnelShards assigns the ztunnels to the istiod replicas, if they are sharded.
+	ztunnelShards *ztunnelShards
 }
 
 // NewDiscoveryServer creates DiscoveryServer that sources data from Pilot's internal mesh data structures
@@ -167,9 +182,11 @@ func NewDiscoveryServer(env *model.Environment, instanceID string, clusterID clu
 		debugHandlers:       map[string]string{},
 		adsClients:          map[string]*Connection{},
 		debounceOptions: debounceOptions{
-			debounceAfter:     features.DebounceAfter,
-			debounceMax:       features.DebounceMax,
-			enableEDSDebounce: features.EnableEDSDebounce,
+			debounceAfter:              features.DebounceAfter,
+			debounceMax:                features.DebounceMax,
+			enableEDSDebounce:          features.EnableEDSDebounce,
+			ambientReadinessFastPush:   features.AmbientReadinessFastPush,
+			ambientReadinessPushWindow: features.AmbientReadinessPushWindow,
 		},
 		Cache:      model.DisabledCache{},
 		instanceID: instanceID,
@@ -182,6 +199,8 @@ func NewDiscoveryServer(env *model.Environment, instanceID string, clusterID clu
 	}
 
 	out.initJwksResolver()
+	out.endpointHealth = newEndpointHealth(features.AmbientPassiveHealthFailures, features.AmbientPassiveHealthQuorum,
+		features.AmbientPassiveHealthMaxEjected, features.AmbientPassiveHealthEjectionTime, out.pushEndpointHealth)
 
 	if features.EnableXDSCaching {
 		out.Cache = model.NewXdsCache()
@@ -341,6 +360,9 @@ func (s *DiscoveryServer) ConfigUpdate(req *model.PushRequest) {
 		// the cache.
 		s.Cache.ClearAll()
 	}
+	if gen, ok := s.Generators[v3.WorkloadType].(*WorkloadGenerator); ok {
+		gen.cache.invalidate(req)
+	}
 	inboundConfigUpdates.Increment()
 	s.InboundUpdates.Inc()
 	s.pushChannel <- req
@@ -367,11 +389,17 @@ func debounce(ch chan *model.PushRequest, stopCh <-chan struct{}, opts debounceO
 	// Keeps track of the push requests. If updates are debounce they will be merged.
 	var req *model.PushRequest
 
+	// Keeps track of the readiness changes of ambient workloads coalesced in the current window, when they bypass
+	// debouncing.
+	var readinessChan <-chan time.Time
+	var readinessReq *model.PushRequest
+	readinessEvents := 0
+
 	free := true
 	freeCh := make(chan struct{}, 1)
 
 	push := func(req *model.PushRequest, debouncedEvents int, startDebounce time.Time) {
-		pushFn(req)
+		recordReadinessPush(req, pushFn)
 		updateSent.Add(int64(debouncedEvents))
 		debounceTime.Record(time.Since(startDebounce).Seconds())
 		freeCh <- struct{}{}
@@ -421,6 +449,16 @@ func debounce(ch chan *model.PushRequest, stopCh <-chan struct{}, opts debounceO
 				}(r)
 				continue
 			}
+			if opts.ambientReadinessFastPush && !r.Full && slices.Contains(r.Reason, model.AmbientReadinessUpdate) {
+				// Push the workloads at the end of the window, so ztunnel stops sending traffic to the unready ones
+				// soon, while the changes of many pods at once are pushed together
+				if readinessReq == nil {
+					readinessChan = time.After(opts.ambientReadinessPushWindow)
+				}
+				readinessReq = readinessReq.Merge(r)
+				readinessEvents++
+				continue
+			}
 
 			lastConfigUpdateTime = time.Now()
 			if debouncedEvents == 0 {
@@ -434,12 +472,30 @@ func debounce(ch chan *model.PushRequest, stopCh <-chan struct{}, opts debounceO
 			if free {
 				pushWorker()
 			}
+		case <-readinessChan:
+			go func(req *model.PushRequest, events int) {
+				recordReadinessPush(req, pushFn)
+				updateSent.Add(int64(events))
+			}(readinessReq, readinessEvents)
+			readinessReq = nil
+			readinessChan = nil
+			readinessEvents = 0
 		case <-stopCh:
 			return
 		}
 	}
 }
 
+// recordReadinessPush pushes a request, recording the delay between istiod observing the readiness changes of ambient
+// workloads it carries and their push, which resets the start time of the request.
+func recordReadinessPush(req *model.PushRequest, pushFn func(req *model.PushRequest)) {
+	start := req.Start
+	pushFn(req)
+	if !start.IsZero() && slices.Contains(req.Reason, model.AmbientReadinessUpdate) {
+		ambientReadinessPushDelay.Record(time.Since(start).Seconds())
+	}
+}
+
 func configsUpdated(req *model.PushRequest) string {
 	configs := ""
 	for key := range req.ConfigsUpdated {
@@ -558,8 +614,9 @@ func (s *DiscoveryServer) InitGenerators(env *model.Environment, systemNameSpace
 	s.Generators[v3.NameTableType] = &NdsGenerator{Server: s}
 	s.Generators[v3.ProxyConfigType] = &PcdsGenerator{Server: s, TrustBundle: env.TrustBundle}
 
-	s.Generators[v3.WorkloadType] = &WorkloadGenerator{s: s}
+	s.Generators[v3.WorkloadType] = &WorkloadGenerator{s: s, cache: newWorkloadCache()}
 	s.Generators[v3.WorkloadAuthorizationType] = &WorkloadRBACGenerator{s: s}
+	s.Generators[v3.WorkloadTrustBundleType] = &TrustBundleGenerator{TrustBundle: env.TrustBundle}
 
 	s.Generators["grpc"] = &grpcgen.GrpcConfigGenerator{}
 	s.Generators["grpc/"+v3.EndpointType] = edsGen
@@ -583,6 +640,12 @@ func (s *DiscoveryServer) Shutdown() {
 	s.pushQueue.ShutDown()
 }
 
+// ShardZtunnels shards the ztunnels across the istiod replicas of the revision by their node: the ztunnels connecting
+// to this replica, named self, are asked to reconnect if their node is assigned to another of the ready replicas.
+func (s *DiscoveryServer) ShardZtunnels(self string, replicas func() []string) {
+	s.ztunnelShards = newZtunnelShards(self, replicas)
+}
+
 // Clients returns all currently connected clients. This method can be safely called concurrently,
 // but care should be taken with the underlying objects (ie model.Proxy) to ensure proper locking.
 // This method returns only fully initialized connections; for all connections, use AllClients
@@ -29,6 +29,7 @@ import (
 
 	"istio.io/istio/pilot/pkg/model"
 	v3 "istio.io/istio/pilot/pkg/xds/v3"
+	"istio.io/istio/pkg/test/util/assert"
 	"istio.io/istio/pkg/test/util/retry"
 )
 
@@ -326,6 +327,38 @@ func TestDebounce(t *testing.T) {
 	}
 }
 
+func TestDebounceAmbientReadiness(t *testing.T) {
+	opts := debounceOptions{
+		debounceAfter:              time.Second * 10,
+		debounceMax:                time.Second * 10,
+		enableEDSDebounce:          true,
+		ambientReadinessFastPush:   true,
+		ambientReadinessPushWindow: time.Millisecond * 100,
+	}
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	updateCh := make(chan *model.PushRequest)
+	pushes := make(chan *model.PushRequest, 10)
+	go debounce(updateCh, stopCh, opts, func(req *model.PushRequest) { pushes <- req }, uatomic.NewInt64(0))
+
+	// Other ambient updates are debounced
+	updateCh <- &model.PushRequest{Reason: []model.TriggerReason{model.AmbientUpdate}}
+	// Readiness changes are pushed at the end of the window, coalesced
+	updateCh <- &model.PushRequest{Reason: []model.TriggerReason{model.AmbientReadinessUpdate}, Start: time.Now()}
+	updateCh <- &model.PushRequest{Reason: []model.TriggerReason{model.AmbientReadinessUpdate}, Start: time.Now()}
+	select {
+	case req := <-pushes:
+		assert.Equal(t, req.Reason, []model.TriggerReason{model.AmbientReadinessUpdate, model.AmbientReadinessUpdate})
+	case <-time.After(time.Second * 5):
+		t.Fatal("readiness change was not pushed")
+	}
+	select {
+	case req := <-pushes:
+		t.Fatalf("unexpected push %v", req.Reason)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
 func TestShouldRespond(t *testing.T) {
 	tests := []struct {
 		name       string
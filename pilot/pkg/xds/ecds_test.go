@@ -26,10 +26,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	extensions "istio.io/api/extensions/v1alpha1"
+	selectorpb "istio.io/api/type/v1beta1"
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/xds"
 	v3 "istio.io/istio/pilot/pkg/xds/v3"
 	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
 	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/config/schema/kind"
 	"istio.io/istio/pkg/spiffe"
@@ -296,3 +298,52 @@ func TestECDSGenerate(t *testing.T) {
 		})
 	}
 }
+
+func TestECDSWaypoint(t *testing.T) {
+	// Plugins are attached to waypoints by referencing their Gateway, or by selecting the name of their Gateway
+	targeted := makeWasmPlugin("targeted-plugin", "default", "")
+	targeted.Annotations = map[string]string{constants.TargetRef: "Gateway/waypoint"}
+	selected := makeWasmPlugin("selected-plugin", "default", "")
+	selected.Spec.(*extensions.WasmPlugin).Selector = &selectorpb.WorkloadSelector{
+		MatchLabels: map[string]string{constants.GatewayNameLabel: "waypoint"},
+	}
+	// Plugins of the root namespace referencing a Gateway only apply to the Gateway of the root namespace
+	root := makeWasmPlugin("root-plugin", "istio-system", "")
+	root.Annotations = map[string]string{constants.TargetRef: "Gateway/waypoint"}
+	s := xds.NewFakeDiscoveryServer(t, xds.FakeOptions{Configs: []config.Config{targeted, selected, root}})
+	gen := s.Discovery.Generators[v3.ExtensionConfigurationType]
+	names := []string{"default.targeted-plugin", "default.selected-plugin", "istio-system.root-plugin"}
+
+	for _, tt := range []struct {
+		name      string
+		namespace string
+		gateway   string
+		want      sets.String
+	}{
+		{name: "waypoint", namespace: "default", gateway: "waypoint", want: sets.New("default.targeted-plugin", "default.selected-plugin")},
+		{name: "other gateway", namespace: "default", gateway: "other", want: sets.New[string]()},
+		{name: "other namespace", namespace: "other", gateway: "waypoint", want: sets.New[string]()},
+		{name: "root namespace", namespace: "istio-system", gateway: "waypoint", want: sets.New("istio-system.root-plugin")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := map[string]string{constants.GatewayNameLabel: tt.gateway}
+			proxy := &model.Proxy{
+				VerifiedIdentity: &spiffe.Identity{Namespace: tt.namespace},
+				Type:             model.Waypoint,
+				Labels:           labels,
+				ConfigNamespace:  tt.namespace,
+				Metadata:         &model.NodeMetadata{ClusterID: "Kubernetes", Labels: labels, Namespace: tt.namespace},
+			}
+			resources, _, _ := gen.Generate(s.SetupProxy(proxy),
+				&model.WatchedResource{ResourceNames: names},
+				&model.PushRequest{Full: true, Push: s.PushContext(), Start: time.Now()})
+			got := sets.New[string]()
+			for _, res := range resources {
+				got.Insert(res.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got extensions %v, want extensions %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/util/protoconv"
+	"istio.io/istio/pkg/config/schema/kind"
+	"istio.io/istio/pkg/spiffe"
+	"istio.io/istio/pkg/util/sets"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// EndpointHealthPath is the path ztunnel reports the connections it opened to workloads on, on the HTTPS port of
+// istiod, so workloads it persistently fails to connect to are sent to ztunnel as unhealthy.
+const EndpointHealthPath = "/ambient/v1/endpoint-health"
+
+// maxEndpointHealthReportSize bounds the size of the reports of ztunnel.
+const maxEndpointHealthReportSize = 1 << 20
+
+// EndpointHealthReport is a report of the connections ztunnel opened to workloads since its previous report.
+type EndpointHealthReport struct {
+	Endpoints []EndpointConnections `json:"endpoints"`
+}
+
+// EndpointConnections counts the connections ztunnel opened to a workload.
+type EndpointConnections struct {
+	// Address is the address of the workload, as the name of its workload resource.
+	Address string `json:"address"`
+	// Failures is the number of connections which could not be established.
+	Failures uint32 `json:"failures,omitempty"`
+	// Successes is the number of connections established.
+	Successes uint32 `json:"successes,omitempty"`
+}
+
+// endpointHealth tracks the connection failures to workloads reported by ztunnel. Workloads are ejected, and sent to
+// ztunnel as unhealthy, once enough consecutive failures are reported for them by a quorum of ztunnels, for the
+// ejection time. The failures are counted per ztunnel pod, so a single ztunnel, which may be partitioned from the
+// workload or misbehave, cannot eject it, and at most maxEjected workloads are ejected at once.
+//
+// The state is local to the istiod replica: each replica only counts the reports of the ztunnels sending them to it,
+// and only pushes its ejections to the ztunnels connected to it.
+type endpointHealth struct {
+	threshold    int
+	quorum       int
+	maxEjected   int
+	ejectionTime time.Duration
+	// push pushes the workloads whose health changed.
+	push func(addresses sets.String)
+
+	mu sync.Mutex
+	// failures are the consecutive connection failures to the workloads which are not ejected, per ztunnel reporting
+	// them, and when the last one was reported, so the workloads which are no longer reported are forgotten.
+	failures map[string]map[string]consecutiveFailures
+	// ejected are the ejected workloads, and when their ejection ends.
+	ejected map[string]time.Time
+}
+
+type consecutiveFailures struct {
+	count int
+	last  time.Time
+}
+
+func newEndpointHealth(threshold, quorum, maxEjected int, ejectionTime time.Duration, push func(addresses sets.String)) *endpointHealth {
+	return &endpointHealth{
+		threshold:    threshold,
+		quorum:       quorum,
+		maxEjected:   maxEjected,
+		ejectionTime: ejectionTime,
+		push:         push,
+		failures:     map[string]map[string]consecutiveFailures{},
+		ejected:      map[string]time.Time{},
+	}
+}
+
+func (h *endpointHealth) enabled() bool {
+	return h.threshold > 0
+}
+
+// report records the connections of a report of a ztunnel, and ejects the workloads reaching the failure threshold
+// for a quorum of ztunnels, unless the maximum number of workloads are already ejected.
+func (h *endpointHealth) report(reporter string, r EndpointHealthReport) {
+	now := time.Now()
+	ejected := sets.New[string]()
+	capped := sets.New[string]()
+	h.mu.Lock()
+	for addr, reporters := range h.failures {
+		for rep, f := range reporters {
+			if now.Sub(f.last) > h.ejectionTime {
+				delete(reporters, rep)
+			}
+		}
+		if len(reporters) == 0 {
+			delete(h.failures, addr)
+		}
+	}
+	for _, e := range r.Endpoints {
+		if _, f := h.ejected[e.Address]; f {
+			// Failures of connections opened before the ejection was pushed
+			continue
+		}
+		reporters := h.failures[e.Address]
+		if e.Successes > 0 {
+			delete(reporters, reporter)
+			if len(reporters) == 0 {
+				delete(h.failures, e.Address)
+			}
+			continue
+		}
+		if e.Failures == 0 {
+			continue
+		}
+		if reporters == nil {
+			reporters = map[string]consecutiveFailures{}
+			h.failures[e.Address] = reporters
+		}
+		f := reporters[reporter]
+		f.count += int(e.Failures)
+		f.last = now
+		reporters[reporter] = f
+		if h.failing(reporters) < h.quorum {
+			continue
+		}
+		if h.maxEjected > 0 && len(h.ejected) >= h.maxEjected {
+			// The failures are kept, so the workload is ejected on a later report once another ejection ends
+			capped.Insert(e.Address)
+			continue
+		}
+		delete(h.failures, e.Address)
+		h.ejected[e.Address] = now.Add(h.ejectionTime)
+		ejected.Insert(e.Address)
+	}
+	unhealthyWorkloads.Record(float64(len(h.ejected)))
+	h.mu.Unlock()
+
+	if len(capped) > 0 {
+		log.Warnf("not ejecting workloads %v reaching the failure threshold, as %d workloads are already ejected",
+			sets.SortedList(capped), h.maxEjected)
+	}
+	if len(ejected) == 0 {
+		return
+	}
+	log.Infof("ejecting workloads %v for %v after %d consecutive connection failures reported by %d ztunnels",
+		sets.SortedList(ejected), h.ejectionTime, h.threshold, h.quorum)
+	for addr := range ejected {
+		addr := addr
+		time.AfterFunc(h.ejectionTime, func() { h.expire(addr) })
+	}
+	h.push(ejected)
+}
+
+// failing returns the number of ztunnels which reported the failure threshold for a workload.
+func (h *endpointHealth) failing(reporters map[string]consecutiveFailures) int {
+	n := 0
+	for _, f := range reporters {
+		if f.count >= h.threshold {
+			n++
+		}
+	}
+	return n
+}
+
+// expire ends the ejection of a workload.
+func (h *endpointHealth) expire(addr string) {
+	h.mu.Lock()
+	until, f := h.ejected[addr]
+	if !f || time.Now().Before(until) {
+		h.mu.Unlock()
+		return
+	}
+	delete(h.ejected, addr)
+	unhealthyWorkloads.Record(float64(len(h.ejected)))
+	h.mu.Unlock()
+	log.Infof("ending the ejection of workload %s", addr)
+	h.push(sets.New(addr))
+}
+
+// unhealthy returns whether a workload is ejected.
+func (h *endpointHealth) unhealthy(addr string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, f := h.ejected[addr]
+	return f
+}
+
+// resource returns the workload resource sent to ztunnel, which is unhealthy if the workload is ejected. Ejected
+// workloads are rare and short-lived, so they are marshaled on each push rather than cached.
+func (h *endpointHealth) resource(name string, wl *workloadapi.Workload, cached *anypb.Any) *anypb.Any {
+	if wl.Status == workloadapi.WorkloadStatus_UNHEALTHY || !h.unhealthy(name) {
+		return cached
+	}
+	unhealthy := proto.Clone(wl).(*workloadapi.Workload)
+	unhealthy.Status = workloadapi.WorkloadStatus_UNHEALTHY
+	return protoconv.MessageToAny(unhealthy)
+}
+
+// pushEndpointHealth pushes the workloads whose health changed to ztunnel.
+func (s *DiscoveryServer) pushEndpointHealth(addresses sets.String) {
+	updates := sets.NewWithLength[model.ConfigKey](len(addresses))
+	for addr := range addresses {
+		updates.Insert(model.ConfigKey{Kind: kind.Address, Name: addr})
+	}
+	s.ConfigUpdate(&model.PushRequest{
+		ConfigsUpdated: updates,
+		Reason:         []model.TriggerReason{model.AmbientUpdate},
+	})
+}
+
+// ztunnelServiceAccount is the service account of ztunnel, in the system namespace.
+const ztunnelServiceAccount = "ztunnel"
+
+// EndpointHealthHandler serves the reports of the connections ztunnel opened to workloads. Reports are only accepted
+// from the identity of ztunnel in the system namespace, with a token bound to the ztunnel pod, which identifies the
+// ztunnel reporting: all ztunnels share the same identity, and their address could be spoofed or shared behind a NAT.
+func (s *DiscoveryServer) EndpointHealthHandler(systemNamespace string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.endpointHealth.enabled() {
+			http.Error(w, "passive health checking is disabled", http.StatusNotImplemented)
+			return
+		}
+		caller := s.authenticateHTTPCaller(req)
+		if caller == nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if !isZtunnelIdentity(caller.Identities, systemNamespace) {
+			http.Error(w, "only ztunnel may report the health of workloads", http.StatusForbidden)
+			return
+		}
+		reporter := caller.KubernetesInfo.PodUID
+		if reporter == "" {
+			http.Error(w, "reports must be authenticated with a token bound to the ztunnel pod", http.StatusForbidden)
+			return
+		}
+		report := EndpointHealthReport{}
+		if err := json.NewDecoder(io.LimitReader(req.Body, maxEndpointHealthReportSize)).Decode(&report); err != nil {
+			http.Error(w, "invalid report: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.endpointHealth.report(reporter, report)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func isZtunnelIdentity(ids []string, namespace string) bool {
+	for _, id := range ids {
+		if i, err := spiffe.ParseIdentity(id); err == nil && i.Namespace == namespace && i.ServiceAccount == ztunnelServiceAccount {
+			return true
+		}
+	}
+	return false
+}
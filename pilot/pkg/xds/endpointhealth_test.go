@@ -0,0 +1,165 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"istio.io/istio/pilot/pkg/model"
+	"istio.io/istio/pilot/pkg/util/protoconv"
+	"istio.io/istio/pkg/security"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+	"istio.io/istio/pkg/util/sets"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+func TestEndpointHealth(t *testing.T) {
+	pushes := make(chan sets.String, 10)
+	h := newEndpointHealth(3, 2, 10, 100*time.Millisecond, func(addresses sets.String) { pushes <- addresses })
+	failures := func(reporter, addr string, failures, successes uint32) {
+		h.report(reporter, EndpointHealthReport{Endpoints: []EndpointConnections{{Address: addr, Failures: failures, Successes: successes}}})
+	}
+
+	failures("ztunnel-a", "10.0.0.1", 2, 0)
+	// Connections established reset the consecutive failures reported by the ztunnel
+	failures("ztunnel-a", "10.0.0.1", 0, 1)
+	failures("ztunnel-a", "10.0.0.1", 3, 0)
+	// A single ztunnel does not eject the workload
+	assert.Equal(t, h.unhealthy("10.0.0.1"), false)
+
+	// The connections established by another ztunnel do not reset the failures reported by the first one
+	failures("ztunnel-b", "10.0.0.1", 0, 1)
+	failures("ztunnel-b", "10.0.0.1", 2, 0)
+	assert.Equal(t, h.unhealthy("10.0.0.1"), false)
+	assert.Equal(t, len(pushes), 0)
+
+	failures("ztunnel-b", "10.0.0.1", 1, 0)
+	assert.Equal(t, h.unhealthy("10.0.0.1"), true)
+	assert.Equal(t, <-pushes, sets.New("10.0.0.1"))
+
+	wl := &model.WorkloadInfo{Workload: &workloadapi.Workload{Name: "pod", Status: workloadapi.WorkloadStatus_HEALTHY}}
+	cached := protoconv.MessageToAny(wl)
+	res := &workloadapi.Workload{}
+	assert.NoError(t, h.resource("10.0.0.1", wl.Workload, cached).UnmarshalTo(res))
+	assert.Equal(t, res.Status, workloadapi.WorkloadStatus_UNHEALTHY)
+	assert.Equal(t, wl.Status, workloadapi.WorkloadStatus_HEALTHY)
+	assert.Equal(t, h.resource("10.0.0.2", wl.Workload, cached), cached)
+
+	// The workload is healthy again once its ejection ends
+	select {
+	case p := <-pushes:
+		assert.Equal(t, p, sets.New("10.0.0.1"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("ejection did not end")
+	}
+	assert.Equal(t, h.unhealthy("10.0.0.1"), false)
+	assert.Equal(t, h.resource("10.0.0.1", wl.Workload, cached), cached)
+}
+
+func TestEndpointHealthMaxEjected(t *testing.T) {
+	pushes := make(chan sets.String, 10)
+	h := newEndpointHealth(1, 1, 1, time.Minute, func(addresses sets.String) { pushes <- addresses })
+	failures := func(addr string) {
+		h.report("ztunnel-a", EndpointHealthReport{Endpoints: []EndpointConnections{{Address: addr, Failures: 1}}})
+	}
+
+	failures("10.0.0.1")
+	assert.Equal(t, h.unhealthy("10.0.0.1"), true)
+	assert.Equal(t, <-pushes, sets.New("10.0.0.1"))
+	// No more workloads are ejected once the maximum is reached
+	failures("10.0.0.2")
+	assert.Equal(t, h.unhealthy("10.0.0.2"), false)
+	assert.Equal(t, len(pushes), 0)
+
+	// The failures are kept, so the workload is ejected once the other ejection ends
+	h.mu.Lock()
+	h.ejected["10.0.0.1"] = time.Now()
+	h.mu.Unlock()
+	h.expire("10.0.0.1")
+	assert.Equal(t, <-pushes, sets.New("10.0.0.1"))
+	failures("10.0.0.2")
+	assert.Equal(t, h.unhealthy("10.0.0.2"), true)
+}
+
+// identityAuthenticator authenticates requests with the identity in their authorization header, and the UID of the
+// pod the token is bound to in their pod header.
+type identityAuthenticator struct{}
+
+func (identityAuthenticator) Authenticate(ctx security.AuthContext) (*security.Caller, error) {
+	id := ctx.Request.Header.Get("Authorization")
+	if id == "" {
+		return nil, errors.New("no identity")
+	}
+	return &security.Caller{
+		Identities:     []string{id},
+		KubernetesInfo: security.KubernetesInfo{PodUID: ctx.Request.Header.Get("Pod")},
+	}, nil
+}
+
+func (identityAuthenticator) AuthenticatorType() string {
+	return "identity"
+}
+
+func TestEndpointHealthHandler(t *testing.T) {
+	pushes := make(chan sets.String, 10)
+	s := &DiscoveryServer{
+		Authenticators: []security.Authenticator{identityAuthenticator{}},
+		endpointHealth: newEndpointHealth(1, 2, 10, time.Minute, func(addresses sets.String) { pushes <- addresses }),
+	}
+	handler := s.EndpointHealthHandler("istio-system")
+	pod := "ztunnel-a"
+	request := func(method, identity, body string) int {
+		req := httptest.NewRequest(method, EndpointHealthPath, strings.NewReader(body))
+		req.RemoteAddr = "10.1.0.1:40000"
+		if identity != "" {
+			req.Header.Set("Authorization", identity)
+		}
+		if pod != "" {
+			req.Header.Set("Pod", pod)
+		}
+		rec := httptest.NewRecorder()
+		handler(rec, req)
+		return rec.Code
+	}
+	ztunnel := "spiffe://cluster.local/ns/istio-system/sa/ztunnel"
+	report := `{"endpoints":[{"address":"10.0.0.1","failures":1}]}`
+
+	assert.Equal(t, request(http.MethodGet, ztunnel, ""), http.StatusMethodNotAllowed)
+	assert.Equal(t, request(http.MethodPost, "", report), http.StatusUnauthorized)
+	assert.Equal(t, request(http.MethodPost, "spiffe://cluster.local/ns/default/sa/default", report), http.StatusForbidden)
+	// Only ztunnel may report, not the other identities of the system namespace
+	assert.Equal(t, request(http.MethodPost, "spiffe://cluster.local/ns/istio-system/sa/istiod", report), http.StatusForbidden)
+	assert.Equal(t, request(http.MethodPost, ztunnel, "{"), http.StatusBadRequest)
+	assert.Equal(t, request(http.MethodPost, ztunnel, report), http.StatusNoContent)
+	// The reports of the same ztunnel pod do not reach the quorum, even from the address of another node
+	assert.Equal(t, request(http.MethodPost, ztunnel, report), http.StatusNoContent)
+	assert.Equal(t, len(pushes), 0)
+	// Reports must identify the ztunnel pod
+	pod = ""
+	assert.Equal(t, request(http.MethodPost, ztunnel, report), http.StatusForbidden)
+	pod = "ztunnel-b"
+	assert.Equal(t, request(http.MethodPost, ztunnel, report), http.StatusNoContent)
+	retry.UntilOrFail(t, func() bool { return len(pushes) == 1 })
+	assert.Equal(t, s.endpointHealth.unhealthy("10.0.0.1"), true)
+
+	s.endpointHealth = newEndpointHealth(0, 2, 10, time.Minute, nil)
+	assert.Equal(t, request(http.MethodPost, ztunnel, report), http.StatusNotImplemented)
+}
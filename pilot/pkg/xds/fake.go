@@ -193,6 +193,13 @@ func NewFakeDiscoveryServer(t test.Failer, opts FakeOptions) *FakeDiscoveryServe
 			ConfigCluster:    k8sCluster == opts.DefaultClusterName,
 			MeshWatcher:      mesh.NewFixedWatcher(m),
 		})
+		if k8sConfig != nil && features.EnableAmbientControllers {
+			// As registered by the multicluster controller
+			k8sConfig.RegisterEventHandler(gvk.AuthorizationPolicy, k8s.AuthorizationPolicyHandler)
+			k8sConfig.RegisterEventHandler(gvk.DestinationRule, k8s.DestinationRuleHandler)
+			k8sConfig.RegisterEventHandler(gvk.Telemetry, k8s.TelemetryHandler)
+			k8sConfig.RegisterEventHandler(gvk.ServiceEntry, k8s.ServiceEntryHandler)
+		}
 		stop := test.NewStop(t)
 		// start default client informers after creating ingress/secret controllers
 		if defaultKubeClient == nil || k8sCluster == opts.DefaultClusterName {
@@ -31,6 +31,8 @@ var (
 	typeTag    = monitoring.MustCreateLabel("type")
 	versionTag = monitoring.MustCreateLabel("version")
 
+	compressorTag = monitoring.MustCreateLabel("compressor")
+
 	// pilot_total_xds_rejects should be used instead. This is for backwards compatibility
 	cdsReject = monitoring.NewGauge(
 		"pilot_xds_cds_reject",
@@ -146,6 +148,12 @@ var (
 		[]float64{.1, .5, 1, 3, 5, 10, 20, 30},
 	)
 
+	ambientReadinessPushDelay = monitoring.NewDistribution(
+		"pilot_ambient_readiness_push_delay",
+		"Delay in seconds between istiod observing ambient workloads becoming ready or unready and their push to ztunnel.",
+		[]float64{.01, .05, .1, .5, 1, 3, 5, 10},
+	)
+
 	pushContextErrors = monitoring.NewSum(
 		"pilot_xds_push_context_errors",
 		"Number of errors (timeouts) initiating push context.",
@@ -182,6 +190,34 @@ var (
 		monitoring.WithLabels(typeTag),
 		monitoring.WithUnit(monitoring.Bytes),
 	)
+
+	compressedSizeBytes = monitoring.NewDistribution(
+		"pilot_xds_compressed_size_bytes",
+		"Distribution of the sizes of compressed responses pushed to clients. Only ztunnel responses are compressed, "+
+			"their uncompressed sizes are recorded by pilot_xds_config_size_bytes.",
+		[]float64{1, 10000, 100000, 1000000, 4000000, 10000000},
+		monitoring.WithLabels(compressorTag),
+		monitoring.WithUnit(monitoring.Bytes),
+	)
+
+	workloadCacheReads = monitoring.NewSum(
+		"pilot_workload_cache_reads",
+		"Total number of reads of marshaled workload resources shared across ztunnel connections.",
+		monitoring.WithLabels(typeTag),
+	)
+
+	workloadCacheSize = monitoring.NewGauge(
+		"pilot_workload_cache_size",
+		"Current number of marshaled workload resources shared across ztunnel connections.",
+	)
+
+	unhealthyWorkloads = monitoring.NewGauge(
+		"pilot_ambient_passive_unhealthy_workloads",
+		"Current number of workloads sent to ztunnel as unhealthy because of the connection failures reported by ztunnel.",
+	)
+
+	workloadCacheHits   = workloadCacheReads.With(typeTag.Value("hit"))
+	workloadCacheMisses = workloadCacheReads.With(typeTag.Value("miss"))
 )
 
 func recordXDSClients(version string, delta float64) {
@@ -293,5 +329,10 @@ func init() {
 		sendTime,
 		pilotSDSCertificateErrors,
 		configSizeBytes,
+		compressedSizeBytes,
+		workloadCacheReads,
+		workloadCacheSize,
+		unhealthyWorkloads,
+		ambientReadinessPushDelay,
 	)
 }
@@ -16,12 +16,15 @@ package xds
 
 import (
 	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"golang.org/x/exp/maps"
 
 	mesh "istio.io/api/mesh/v1alpha1"
 	"istio.io/istio/pilot/pkg/features"
 	"istio.io/istio/pilot/pkg/model"
 	tb "istio.io/istio/pilot/pkg/trustbundle"
 	"istio.io/istio/pilot/pkg/util/protoconv"
+	"istio.io/istio/pkg/util/sets"
+	"istio.io/istio/pkg/workloadapi"
 )
 
 // PcdsGenerator generates proxy configuration for proxies to consume
@@ -67,3 +70,31 @@ func (e *PcdsGenerator) Generate(proxy *model.Proxy, w *model.WatchedResource, r
 	}
 	return model.Resources{&discovery.Resource{Resource: protoconv.MessageToAny(pc)}}, model.DefaultXdsLogDetails, nil
 }
+
+// TrustBundleGenerator generates the trust bundles of the federated trust domains for ztunnel, which only trusts the
+// trust anchors of a federated trust domain for the identities of that trust domain.
+type TrustBundleGenerator struct {
+	TrustBundle *tb.TrustBundle
+}
+
+var _ model.XdsResourceGenerator = &TrustBundleGenerator{}
+
+// Generate returns a TrustBundle for each federated trust domain, named by the trust domain.
+func (e *TrustBundleGenerator) Generate(_ *model.Proxy, _ *model.WatchedResource, req *model.PushRequest) (model.Resources, model.XdsLogDetails, error) {
+	// The trust bundles change along with the workload trust bundle, which is pushed the same way as PCDS
+	if !pcdsNeedsPush(req) {
+		return nil, model.DefaultXdsLogDetails, nil
+	}
+	if e.TrustBundle == nil {
+		return nil, model.DefaultXdsLogDetails, nil
+	}
+	bundles := e.TrustBundle.GetTrustDomainBundles()
+	resources := make(model.Resources, 0, len(bundles))
+	for _, td := range sets.SortedList(sets.New(maps.Keys(bundles)...)) {
+		resources = append(resources, &discovery.Resource{
+			Name:     td,
+			Resource: protoconv.MessageToAny(&workloadapi.TrustBundle{TrustDomain: td, TrustAnchors: bundles[td]}),
+		})
+	}
+	return resources, model.DefaultXdsLogDetails, nil
+}
@@ -22,6 +22,7 @@ import (
 	status "github.com/envoyproxy/go-control-plane/envoy/service/status/v3"
 	"google.golang.org/protobuf/proto"
 	anypb "google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/util/protoconv"
@@ -48,6 +49,10 @@ const (
 	// TypeDebugConfigDump requests Envoy configuration for a proxy without creating one
 	TypeDebugConfigDump = v3.DebugType + "/config_dump"
 
+	// SyncProxyTypeMetadataKey holds the proxy type in the node metadata of ambient proxies, in
+	// TypeDebugSyncronization responses.
+	SyncProxyTypeMetadataKey = "PROXY_TYPE"
+
 	// TODO: TypeURLReady - readiness events for endpoints, agent can propagate
 )
 
@@ -123,13 +128,19 @@ func (sg *StatusGen) debugSyncz() model.Resources {
 		v3.ClusterType,
 		v3.ExtensionConfigurationType,
 	}
+	// ztunnel additionally reports the status of the workload, authorization and trust bundle types it consumes
+	ztunnelTypes := append(append([]string{}, stypes...), v3.WorkloadType, v3.WorkloadAuthorizationType, v3.WorkloadTrustBundleType)
 
 	for _, con := range sg.Server.Clients() {
 		con.proxy.RLock()
 		// Skip "nodes" without metdata (they are probably istioctl queries!)
 		if isProxy(con) || isZtunnel(con) {
 			xdsConfigs := make([]*status.ClientConfig_GenericXdsConfig, 0)
-			for _, stype := range stypes {
+			types := stypes
+			if isZtunnel(con) {
+				types = ztunnelTypes
+			}
+			for _, stype := range types {
 				pxc := &status.ClientConfig_GenericXdsConfig{}
 				if watchedResource, ok := con.proxy.WatchedResources[stype]; ok {
 					pxc.ConfigStatus = debugSyncStatus(watchedResource)
@@ -143,12 +154,23 @@ func (sg *StatusGen) debugSyncz() model.Resources {
 
 				xdsConfigs = append(xdsConfigs, pxc)
 			}
+			meta := model.NodeMetadata{
+				ClusterID: con.proxy.Metadata.ClusterID,
+			}
+			if con.proxy.IsAmbient() {
+				meta.NodeName = con.proxy.GetNodeName()
+			}
+			metaStruct := meta.ToStruct()
+			if con.proxy.IsAmbient() && metaStruct != nil {
+				if metaStruct.Fields == nil {
+					metaStruct.Fields = map[string]*structpb.Value{}
+				}
+				metaStruct.Fields[SyncProxyTypeMetadataKey] = structpb.NewStringValue(string(con.proxy.Type))
+			}
 			clientConfig := &status.ClientConfig{
 				Node: &core.Node{
-					Id: con.proxy.ID,
-					Metadata: model.NodeMetadata{
-						ClusterID: con.proxy.Metadata.ClusterID,
-					}.ToStruct(),
+					Id:       con.proxy.ID,
+					Metadata: metaStruct,
 				},
 				GenericXdsConfigs: xdsConfigs,
 			}
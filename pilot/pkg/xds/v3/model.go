@@ -38,6 +38,7 @@ const (
 	BootstrapType             = resource.APITypePrefix + "envoy.config.bootstrap.v3.Bootstrap"
 	WorkloadType              = resource.APITypePrefix + "istio.workload.Workload"
 	WorkloadAuthorizationType = resource.APITypePrefix + "istio.security.Authorization"
+	WorkloadTrustBundleType   = resource.APITypePrefix + "istio.security.TrustBundle"
 
 	// nolint
 	HttpProtocolOptionsType = "envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
@@ -66,6 +67,8 @@ func GetShortType(typeURL string) string {
 		return "WDS"
 	case WorkloadAuthorizationType:
 		return "WADS"
+	case WorkloadTrustBundleType:
+		return "WTBDS"
 	default:
 		return typeURL
 	}
@@ -96,6 +99,8 @@ func GetMetricType(typeURL string) string {
 		return "wds"
 	case WorkloadAuthorizationType:
 		return "wads"
+	case WorkloadTrustBundleType:
+		return "wtbds"
 	default:
 		return typeURL
 	}
@@ -125,6 +130,8 @@ func GetResourceType(shortType string) string {
 		return WorkloadType
 	case "WADS":
 		return WorkloadAuthorizationType
+	case "WTBDS":
+		return WorkloadTrustBundleType
 	default:
 		return shortType
 	}
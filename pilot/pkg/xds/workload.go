@@ -15,17 +15,162 @@
 package xds
 
 import (
+	"fmt"
+	"sort"
+	"sync"
+
 	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
 	"k8s.io/apimachinery/pkg/types"
 
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/pkg/util/protoconv"
 	"istio.io/istio/pkg/config/schema/kind"
 	"istio.io/istio/pkg/util/sets"
+	"istio.io/istio/pkg/workloadapi"
 )
 
 type WorkloadGenerator struct {
-	s *DiscoveryServer
+	s     *DiscoveryServer
+	cache *workloadCache
+}
+
+// workloadCache holds the marshaled form of each Workload, for each version of the workload API, shared by all
+// ztunnel connections. Without it, every connected ztunnel re-marshals the same workloads on each push.
+// Entries are dropped when a push of the workload is requested, so both updated and removed workloads are
+// invalidated even if no connection generates them again. As the ambient index replaces a Workload rather than
+// mutating it when it changes, an entry built from another Workload pointer is also rebuilt.
+type workloadCache struct {
+	mu      sync.RWMutex
+	entries map[workloadCacheKey]workloadCacheEntry
+}
+
+type workloadCacheKey struct {
+	name    string
+	version int
+}
+
+type workloadCacheEntry struct {
+	workload *workloadapi.Workload
+	// converted is the Workload converted to the version of the entry, or nil if it cannot be represented in it.
+	converted *workloadapi.Workload
+	resource  *anypb.Any
+}
+
+func newWorkloadCache() *workloadCache {
+	return &workloadCache{entries: map[workloadCacheKey]workloadCacheEntry{}}
+}
+
+// get returns the Workload converted to the version of the workload API, and its marshaled form, converting and
+// storing it if it is not already cached. Both are nil if the Workload cannot be represented in the version.
+func (c *workloadCache) get(name string, wl *model.WorkloadInfo, version int) (*workloadapi.Workload, *anypb.Any) {
+	key := workloadCacheKey{name: name, version: version}
+	c.mu.RLock()
+	e, f := c.entries[key]
+	c.mu.RUnlock()
+	if f && e.workload == wl.Workload {
+		workloadCacheHits.Increment()
+		return e.converted, e.resource
+	}
+	workloadCacheMisses.Increment()
+	e = workloadCacheEntry{workload: wl.Workload, converted: convertWorkload(wl.Workload, version)}
+	if e.converted != nil {
+		e.resource = protoconv.MessageToAny(e.converted)
+	}
+	c.mu.Lock()
+	c.entries[key] = e
+	workloadCacheSize.Record(float64(len(c.entries)))
+	c.mu.Unlock()
+	return e.converted, e.resource
+}
+
+// delete drops cached entries for removed resources, in all versions.
+func (c *workloadCache) delete(names ...string) {
+	if len(names) == 0 {
+		return
+	}
+	c.mu.Lock()
+	for _, n := range names {
+		for v := workloadapi.MinVersion; v <= workloadapi.CurrentVersion; v++ {
+			delete(c.entries, workloadCacheKey{name: n, version: v})
+		}
+	}
+	workloadCacheSize.Record(float64(len(c.entries)))
+	c.mu.Unlock()
+}
+
+// WorkloadFieldSize is the marshaled size of a field of the workloads, summed across all workloads.
+type WorkloadFieldSize struct {
+	Field string `json:"field"`
+	Bytes int    `json:"bytes"`
+	// Workloads is how many workloads have the field set.
+	Workloads int `json:"workloads"`
+}
+
+// invalidate drops the entries of the workloads updated by a push request. A full push not listing the updated
+// configs, such as on a trust domain change, may change any workload so it drops all entries.
+func (c *workloadCache) invalidate(req *model.PushRequest) {
+	if req.Full && len(req.ConfigsUpdated) == 0 {
+		c.mu.Lock()
+		c.entries = map[workloadCacheKey]workloadCacheEntry{}
+		workloadCacheSize.Record(0)
+		c.mu.Unlock()
+		return
+	}
+	var names []string
+	for k := range req.ConfigsUpdated {
+		if k.Kind == kind.Address {
+			names = append(names, k.Name)
+		}
+	}
+	c.delete(names...)
+}
+
+// fieldSizes returns the marshaled size of each field of the cached workloads of the current version, largest first.
+// This is used to find the fields making up most of the pushes to ztunnel, which are worth pruning when they are
+// rarely used.
+func (c *workloadCache) fieldSizes() []WorkloadFieldSize {
+	fields := (&workloadapi.Workload{}).ProtoReflect().Descriptor().Fields()
+	sizes := map[protowire.Number]*WorkloadFieldSize{}
+	c.mu.RLock()
+	for k, e := range c.entries {
+		if k.version != workloadapi.CurrentVersion || e.resource == nil {
+			continue
+		}
+		seen := sets.New[protowire.Number]()
+		for b := e.resource.Value; len(b) > 0; {
+			num, _, n := protowire.ConsumeField(b)
+			if n < 0 {
+				break
+			}
+			b = b[n:]
+			fs := sizes[num]
+			if fs == nil {
+				fs = &WorkloadFieldSize{Field: fmt.Sprint(num)}
+				if f := fields.ByNumber(num); f != nil {
+					fs.Field = string(f.Name())
+				}
+				sizes[num] = fs
+			}
+			fs.Bytes += n
+			if !seen.InsertContains(num) {
+				fs.Workloads++
+			}
+		}
+	}
+	c.mu.RUnlock()
+	res := make([]WorkloadFieldSize, 0, len(sizes))
+	for _, fs := range sizes {
+		res = append(res, *fs)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Bytes != res[j].Bytes {
+			return res[i].Bytes > res[j].Bytes
+		}
+		return res[i].Field < res[j].Field
+	})
+	return res
 }
 
 var (
@@ -97,14 +242,23 @@ func (e WorkloadGenerator) GenerateDeltas(
 	// https://www.envoyproxy.io/docs/envoy/latest/api-docs/xds_protocol#id2
 
 	have := sets.New[string]()
+	var unsupported []string
 	for _, wl := range wls {
 		n := wl.ResourceName()
+		converted, res := e.cache.get(n, wl, proxy.WorkloadAPIVersion)
+		if converted == nil {
+			// The workload cannot be represented in the version of the client, so it is removed if it was sent before
+			unsupported = append(unsupported, n)
+			continue
+		}
 		have.Insert(n)
 		resources = append(resources, &discovery.Resource{
 			Name:     n,
-			Resource: protoconv.MessageToAny(wl), // TODO: pre-marshal
+			Resource: e.s.endpointHealth.resource(n, converted, res),
 		})
 	}
+	e.cache.delete(removed...)
+	removed = append(removed, unsupported...)
 
 	if !w.Wildcard {
 		// For on-demand, we may have requested a VIP but gotten Pod IPs back. We need to update
@@ -0,0 +1,110 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	discovery "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"google.golang.org/protobuf/proto"
+
+	"istio.io/istio/pilot/pkg/features"
+	"istio.io/istio/pilot/pkg/model"
+	v3 "istio.io/istio/pilot/pkg/xds/v3"
+	"istio.io/istio/pilot/test/util"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/file"
+	"istio.io/istio/pkg/test/util/yml"
+	"istio.io/istio/pkg/util/protomarshal"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// TestAmbientGolden renders the workloads and authorization policies sent to ztunnel for each cluster of
+// testdata/ambient, and compares them with the golden file of the cluster. The golden files are updated with
+// REFRESH_GOLDEN=true, or make refresh-goldens.
+//
+// A cluster is a YAML file with Kubernetes objects and Istio configs, which are told apart by their API group.
+func TestAmbientGolden(t *testing.T) {
+	test.SetForTest(t, &features.EnableAmbientControllers, true)
+	for _, f := range file.ReadDirOrFail(t, filepath.Join("testdata", "ambient")) {
+		if !strings.HasSuffix(f, ".yaml") || strings.HasSuffix(f, ".golden.yaml") {
+			continue
+		}
+		name := strings.TrimSuffix(filepath.Base(f), ".yaml")
+		t.Run(name, func(t *testing.T) {
+			kubeObjects, configs := splitAmbientCluster(file.AsStringOrFail(t, f))
+			s := NewFakeDiscoveryServer(t, FakeOptions{KubernetesObjectString: kubeObjects, ConfigString: configs})
+			var out []string
+			out = append(out, renderAmbientResources(t, s, v3.WorkloadType, func() proto.Message { return &workloadapi.Workload{} })...)
+			out = append(out, renderAmbientResources(t, s, v3.WorkloadAuthorizationType, func() proto.Message { return &workloadapi.Authorization{} })...)
+			util.CompareContent(t, []byte(strings.Join(out, "---\n")), strings.TrimSuffix(f, ".yaml")+".golden.yaml")
+		})
+	}
+}
+
+var istioAPIVersion = regexp.MustCompile(`(?m)^apiVersion: [a-z.]*istio\.io/`)
+
+// splitAmbientCluster splits the documents of a cluster into Kubernetes objects and Istio configs.
+func splitAmbientCluster(content string) (kubeObjects, configs string) {
+	var k, c []string
+	for _, doc := range yml.SplitString(content) {
+		if istioAPIVersion.MatchString(doc) {
+			c = append(c, doc)
+		} else {
+			k = append(k, doc)
+		}
+	}
+	return yml.JoinString(k...), yml.JoinString(c...)
+}
+
+// renderAmbientResources returns the resources of a type sent to a ztunnel of the current version of the workload API
+// with a wildcard subscription, as YAML documents sorted by name.
+func renderAmbientResources(t *testing.T, s *FakeDiscoveryServer, typeURL string, newMessage func() proto.Message) []string {
+	ads := s.ConnectDeltaADS().WithType(typeURL).WithNodeType(model.Ztunnel).WithMetadata(model.NodeMetadata{
+		NodeName:           "node",
+		WorkloadAPIVersion: strconv.Itoa(workloadapi.CurrentVersion),
+	})
+	ads.Request(&discovery.DeltaDiscoveryRequest{ResourceNamesSubscribe: []string{"*"}})
+	// The response may be empty, which ExpectResponse rejects
+	var resources []*discovery.Resource
+	select {
+	case resp := <-ads.responses:
+		resources = resp.Resources
+	case err := <-ads.error:
+		t.Fatal(err)
+	case <-time.After(ads.timeout):
+		t.Fatalf("did not get %v response in time", typeURL)
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Name < resources[j].Name })
+	var out []string
+	for _, r := range resources {
+		msg := newMessage()
+		if err := r.Resource.UnmarshalTo(msg); err != nil {
+			t.Fatal(err)
+		}
+		y, err := protomarshal.ToYAML(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, "# "+typeURL+" "+r.Name+"\n"+y)
+	}
+	return out
+}
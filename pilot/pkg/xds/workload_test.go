@@ -30,10 +30,12 @@ import (
 	"istio.io/istio/pkg/config"
 	"istio.io/istio/pkg/config/constants"
 	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/config/schema/kind"
 	"istio.io/istio/pkg/kube/kclient/clienttest"
 	"istio.io/istio/pkg/test"
 	"istio.io/istio/pkg/test/util/assert"
 	"istio.io/istio/pkg/util/sets"
+	"istio.io/istio/pkg/workloadapi"
 )
 
 func buildExpect(t *testing.T) func(resp *discovery.DeltaDiscoveryResponse, names ...string) {
@@ -312,3 +314,79 @@ func TestWorkloadRBAC(t *testing.T) {
 	createPod(s, "pod", "sa", "127.0.0.1", "node")
 	ads.ExpectNoResponse()
 }
+
+func TestWorkloadCache(t *testing.T) {
+	c := newWorkloadCache()
+	wl := &model.WorkloadInfo{Workload: &workloadapi.Workload{Name: "pod", Address: []byte{127, 0, 0, 1}}}
+
+	_, first := c.get("127.0.0.1", wl, workloadapi.CurrentVersion)
+	// The same Workload is shared, not re-marshaled
+	_, again := c.get("127.0.0.1", wl, workloadapi.CurrentVersion)
+	assert.Equal(t, again == first, true)
+
+	// A replaced Workload is marshaled again
+	updated := &model.WorkloadInfo{Workload: &workloadapi.Workload{Name: "pod2", Address: []byte{127, 0, 0, 1}}}
+	_, second := c.get("127.0.0.1", updated, workloadapi.CurrentVersion)
+	assert.Equal(t, second == first, false)
+	got := &workloadapi.Workload{}
+	assert.NoError(t, second.UnmarshalTo(got))
+	assert.Equal(t, got.Name, "pod2")
+
+	// Each version is cached separately
+	_, previous := c.get("127.0.0.1", updated, workloadapi.MinVersion)
+	assert.Equal(t, previous == second, false)
+	assert.Equal(t, len(c.entries), 2)
+
+	// Workloads which cannot be represented in a version are cached as such
+	dns := &model.WorkloadInfo{Workload: &workloadapi.Workload{Name: "dns", Hostname: "example.com", Resolution: workloadapi.Resolution_DNS}}
+	converted, res := c.get("example.com", dns, workloadapi.Version1)
+	assert.Equal(t, converted == nil && res == nil, true)
+	c.delete("example.com")
+
+	c.delete("127.0.0.1")
+	assert.Equal(t, len(c.entries), 0)
+}
+
+func TestWorkloadCacheInvalidate(t *testing.T) {
+	c := newWorkloadCache()
+	wl := &model.WorkloadInfo{Workload: &workloadapi.Workload{Name: "pod", Address: []byte{127, 0, 0, 1}}}
+	c.get("127.0.0.1", wl, workloadapi.CurrentVersion)
+	c.get("127.0.0.2", &model.WorkloadInfo{Workload: &workloadapi.Workload{Name: "pod2", Address: []byte{127, 0, 0, 2}}},
+		workloadapi.CurrentVersion)
+
+	// A Workload mutated in place is marshaled again once its push is requested
+	wl.Name = "mutated"
+	c.invalidate(&model.PushRequest{ConfigsUpdated: sets.New(model.ConfigKey{Kind: kind.Address, Name: "127.0.0.1"})})
+	assert.Equal(t, len(c.entries), 1)
+	_, res := c.get("127.0.0.1", wl, workloadapi.CurrentVersion)
+	got := &workloadapi.Workload{}
+	assert.NoError(t, res.UnmarshalTo(got))
+	assert.Equal(t, got.Name, "mutated")
+
+	// Pushes of other kinds keep the entries
+	c.invalidate(&model.PushRequest{Full: true, ConfigsUpdated: sets.New(model.ConfigKey{Kind: kind.ServiceEntry, Name: "se"})})
+	assert.Equal(t, len(c.entries), 2)
+
+	// Removed workloads are evicted even if no connection generates them
+	c.invalidate(&model.PushRequest{ConfigsUpdated: sets.New(model.ConfigKey{Kind: kind.Address, Name: "127.0.0.2"})})
+	assert.Equal(t, len(c.entries), 1)
+
+	// Full pushes not listing their updates drop all entries
+	c.invalidate(&model.PushRequest{Full: true})
+	assert.Equal(t, len(c.entries), 0)
+}
+
+func TestWorkloadCacheFieldSizes(t *testing.T) {
+	c := newWorkloadCache()
+	c.get("127.0.0.1", &model.WorkloadInfo{Workload: &workloadapi.Workload{Name: "pod", Address: []byte{127, 0, 0, 1}}}, workloadapi.CurrentVersion)
+	c.get("127.0.0.2", &model.WorkloadInfo{Workload: &workloadapi.Workload{Name: "pod2", Address: []byte{127, 0, 0, 2}}}, workloadapi.CurrentVersion)
+	c.get("127.0.0.3", &model.WorkloadInfo{Workload: &workloadapi.Workload{Address: []byte{127, 0, 0, 3}}}, workloadapi.CurrentVersion)
+	// Other versions are not counted
+	c.get("127.0.0.3", &model.WorkloadInfo{Workload: &workloadapi.Workload{Address: []byte{127, 0, 0, 3}}}, workloadapi.MinVersion)
+
+	assert.Equal(t, c.fieldSizes(), []WorkloadFieldSize{
+		// Each field has a 1 byte tag and a 1 byte length
+		{Field: "address", Bytes: 18, Workloads: 3},
+		{Field: "name", Bytes: 11, Workloads: 2},
+	})
+}
@@ -0,0 +1,56 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"istio.io/istio/pkg/workloadapi"
+)
+
+// convertWorkload converts a Workload of the current version of the workload API to the given version, returning
+// nil if it cannot be represented in it. The Workload is returned as is for the current version, and is not modified.
+func convertWorkload(wl *workloadapi.Workload, version int) *workloadapi.Workload {
+	if version >= workloadapi.CurrentVersion {
+		return wl
+	}
+	// Version1 clients require an address, and would treat workloads resolved with DNS as having no address
+	if wl.Resolution == workloadapi.Resolution_DNS {
+		return nil
+	}
+	// Version1 clients would send the traffic of workloads requiring a waypoint directly to them, bypassing the policies
+	// enforced by the waypoint, and would dial the workloads of other networks on their unreachable addresses
+	if wl.WaypointRequired || wl.NetworkGateway != nil {
+		return nil
+	}
+	res := proto.Clone(wl).(*workloadapi.Workload)
+	// Drop the fields added in Version2, which Version1 clients would ignore anyway, to keep pushes small
+	res.ConnectionLimits = nil
+	res.HeadlessServices = nil
+	res.Locality = nil
+	res.Hostname = ""
+	res.Labels = nil
+	res.CaptureMode = workloadapi.CaptureMode_UNCAPTURED
+	res.Resolution = workloadapi.Resolution_STATIC
+	res.Telemetry = nil
+	for _, pl := range res.VirtualIps {
+		pl.Service = ""
+		pl.ProxyProtocol = false
+		pl.NodeLocal = false
+		pl.HintsForZones = nil
+		pl.LocalityLoadBalancing = nil
+	}
+	return res
+}
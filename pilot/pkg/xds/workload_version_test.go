@@ -0,0 +1,74 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"fmt"
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/workloadapi"
+)
+
+func TestConvertWorkload(t *testing.T) {
+	wl := &workloadapi.Workload{
+		Name:      "pod",
+		Address:   []byte{10, 0, 0, 1},
+		Hostname:  "pod.example.com",
+		Labels:    map[string]string{"app": "a"},
+		Telemetry: &workloadapi.Telemetry{Client: &workloadapi.ModeTelemetry{}},
+		Locality:  &workloadapi.Locality{Region: "region", Zone: "zone-a"},
+		VirtualIps: map[string]*workloadapi.PortList{
+			"10.1.0.1": {
+				Service:               "ns/svc",
+				NodeLocal:             true,
+				LocalityLoadBalancing: &workloadapi.LocalityLoadBalancing{},
+				Ports:                 []*workloadapi.Port{{ServicePort: 80, TargetPort: 8080}},
+			},
+		},
+	}
+	// The current version is served as is
+	assert.Equal(t, convertWorkload(wl, workloadapi.CurrentVersion) == wl, true)
+
+	got := convertWorkload(wl, workloadapi.Version1)
+	want := &workloadapi.Workload{
+		Name:    "pod",
+		Address: []byte{10, 0, 0, 1},
+		VirtualIps: map[string]*workloadapi.PortList{
+			"10.1.0.1": {Ports: []*workloadapi.Port{{ServicePort: 80, TargetPort: 8080}}},
+		},
+	}
+	assert.Equal(t, fmt.Sprintf("%+v", got), fmt.Sprintf("%+v", want))
+	// The original Workload is not modified
+	assert.Equal(t, wl.Hostname, "pod.example.com")
+	assert.Equal(t, wl.VirtualIps["10.1.0.1"].Service, "ns/svc")
+
+	// Workloads resolved with DNS cannot be represented in Version1
+	dns := &workloadapi.Workload{Name: "dns", Hostname: "example.com", Resolution: workloadapi.Resolution_DNS}
+	assert.Equal(t, convertWorkload(dns, workloadapi.Version1) == nil, true)
+	assert.Equal(t, convertWorkload(dns, workloadapi.Version2) == dns, true)
+
+	// Workloads requiring a waypoint, or reached through a network gateway, are not sent to Version1 clients, which
+	// would bypass the waypoint or dial them directly
+	waypoint := &workloadapi.Workload{Name: "waypoint", Address: []byte{10, 0, 0, 2}, WaypointRequired: true}
+	assert.Equal(t, convertWorkload(waypoint, workloadapi.Version1) == nil, true)
+	remote := &workloadapi.Workload{
+		Name:           "remote",
+		Address:        []byte{10, 3, 0, 1},
+		NetworkGateway: &workloadapi.GatewayAddress{Address: []byte{10, 2, 0, 1}, HboneMtlsPort: 15008},
+	}
+	assert.Equal(t, convertWorkload(remote, workloadapi.Version1) == nil, true)
+	assert.Equal(t, convertWorkload(remote, workloadapi.Version2) == remote, true)
+}
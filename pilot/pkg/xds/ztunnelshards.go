@@ -0,0 +1,114 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"istio.io/istio/pkg/util/hash"
+	"istio.io/istio/pkg/util/sets"
+)
+
+// ztunnelShards assigns the ztunnels to the istiod replicas of the revision by rendezvous hashing of their node, so
+// each replica serves a stable subset of the nodes and only the nodes of an added or removed replica move as the
+// replicas scale. A ztunnel connecting to another replica than its own is asked to reconnect, at most maxRedirects
+// consecutive times, so it is still served when it cannot reach its own replica, such as when it reuses its
+// connection to istiod. The redirects are forgotten after redirectWindow, so only the nodes redirected recently are
+// tracked, rather than every node whose ztunnel ever connected to this replica.
+type ztunnelShards struct {
+	// self is the name of this replica.
+	self string
+	// replicas returns the names of the ready replicas of the revision.
+	replicas       func() []string
+	maxRedirects   int
+	redirectWindow time.Duration
+	now            func() time.Time
+
+	mu sync.Mutex
+	// redirects tracks the consecutive connections of the ztunnel of each node asked to reconnect.
+	redirects map[string]ztunnelRedirects
+}
+
+type ztunnelRedirects struct {
+	count int
+	last  time.Time
+}
+
+const (
+	// ztunnelMaxRedirects is the number of consecutive connections of a ztunnel asked to reconnect to its replica
+	// before it is served by the replica it connects to.
+	ztunnelMaxRedirects = 3
+	// ztunnelRedirectWindow is how long the redirects of a ztunnel are remembered. A ztunnel reconnecting after it is
+	// asked to reconnect does so within seconds, so older redirects are from ztunnels served by their own replica since.
+	ztunnelRedirectWindow = time.Minute
+)
+
+func newZtunnelShards(self string, replicas func() []string) *ztunnelShards {
+	return &ztunnelShards{
+		self:           self,
+		replicas:       replicas,
+		maxRedirects:   ztunnelMaxRedirects,
+		redirectWindow: ztunnelRedirectWindow,
+		now:            time.Now,
+		redirects:      map[string]ztunnelRedirects{},
+	}
+}
+
+// owner returns the replica serving the ztunnel of a node, among the ready replicas and this one.
+func (z *ztunnelShards) owner(node string) string {
+	replicas := sets.New(z.replicas()...).Insert(z.self)
+	owner := ""
+	var best uint64
+	for r := range replicas {
+		h := hash.New()
+		h.Write([]byte(node))
+		h.Write([]byte{'/'})
+		h.Write([]byte(r))
+		score := h.Sum64()
+		// Ties are broken by name, so all the replicas agree on the owner
+		if owner == "" || score > best || (score == best && r < owner) {
+			owner, best = r, score
+		}
+	}
+	return owner
+}
+
+// admit returns an error asking the ztunnel of a node to reconnect if it is served by another replica.
+func (z *ztunnelShards) admit(node string) error {
+	if node == "" {
+		return nil
+	}
+	owner := z.owner(node)
+	now := z.now()
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	for n, r := range z.redirects {
+		if now.Sub(r.last) > z.redirectWindow {
+			delete(z.redirects, n)
+		}
+	}
+	r := z.redirects[node]
+	if owner == z.self || r.count >= z.maxRedirects {
+		delete(z.redirects, node)
+		return nil
+	}
+	z.redirects[node] = ztunnelRedirects{count: r.count + 1, last: now}
+	log.Debugf("asking the ztunnel of node %s to reconnect to istiod %s", node, owner)
+	return status.Errorf(codes.Unavailable, "the ztunnel of node %s is served by istiod %s, reconnect", node, owner)
+}
@@ -0,0 +1,95 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xds
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestZtunnelShardsOwner(t *testing.T) {
+	replicas := []string{"istiod-a", "istiod-b", "istiod-c"}
+	shards := newZtunnelShards("istiod-a", func() []string { return replicas })
+	nodes := map[string]string{}
+	perReplica := map[string]int{}
+	for i := 0; i < 300; i++ {
+		node := fmt.Sprintf("node-%d", i)
+		nodes[node] = shards.owner(node)
+		perReplica[nodes[node]]++
+		// All the replicas agree on the owner
+		other := newZtunnelShards("istiod-c", func() []string { return replicas })
+		assert.Equal(t, other.owner(node), nodes[node])
+	}
+	for _, r := range replicas {
+		if perReplica[r] < 50 {
+			t.Fatalf("replica %s only serves %d nodes of 300: %v", r, perReplica[r], perReplica)
+		}
+	}
+
+	// Only the nodes of a removed replica move
+	replicas = []string{"istiod-a", "istiod-c"}
+	for node, owner := range nodes {
+		if owner != "istiod-b" {
+			assert.Equal(t, shards.owner(node), owner)
+		}
+	}
+	// This replica serves nodes even if it is not yet ready
+	replicas = nil
+	assert.Equal(t, shards.owner("node-0"), "istiod-a")
+}
+
+func TestZtunnelShardsAdmit(t *testing.T) {
+	shards := newZtunnelShards("istiod-a", func() []string { return []string{"istiod-a", "istiod-b"} })
+	var own, other string
+	for i := 0; own == "" || other == ""; i++ {
+		node := fmt.Sprintf("node-%d", i)
+		if shards.owner(node) == "istiod-a" {
+			own = node
+		} else {
+			other = node
+		}
+	}
+
+	assert.NoError(t, shards.admit(own))
+	assert.NoError(t, shards.admit(""))
+	// The ztunnels of other nodes are asked to reconnect, until they are served anyway
+	for i := 0; i < ztunnelMaxRedirects; i++ {
+		err := shards.admit(other)
+		assert.Equal(t, status.Code(err), codes.Unavailable)
+	}
+	assert.NoError(t, shards.admit(other))
+	// and asked again on their next connection
+	assert.Equal(t, status.Code(shards.admit(other)), codes.Unavailable)
+	assert.Equal(t, len(shards.redirects), 1)
+
+	// The redirects are forgotten once the ztunnel stops reconnecting, so only the recent ones are tracked
+	now := time.Now()
+	shards.now = func() time.Time { return now }
+	assert.Equal(t, status.Code(shards.admit(other)), codes.Unavailable)
+	now = now.Add(2 * ztunnelRedirectWindow)
+	assert.NoError(t, shards.admit(own))
+	assert.Equal(t, len(shards.redirects), 0)
+	// and the count starts over
+	for i := 0; i < ztunnelMaxRedirects; i++ {
+		assert.Equal(t, status.Code(shards.admit(other)), codes.Unavailable)
+	}
+	assert.NoError(t, shards.admit(other))
+}
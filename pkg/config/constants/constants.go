@@ -167,6 +167,22 @@ const (
 	// DataplaneMode namespace label for determining ambient mesh behavior
 	DataplaneMode        = "istio.io/dataplane-mode"
 	DataplaneModeAmbient = "ambient"
+	// DataplaneModeNone is the value of the DataplaneMode label or annotation on a pod excluding it from any
+	// dataplane: no sidecar is injected, and its traffic is not redirected to ztunnel, even in ambient namespaces.
+	DataplaneModeNone = "none"
+
+	// AmbientUseWaypoint is the label on a ServiceEntry naming the waypoint Gateway, in the same namespace,
+	// that ambient workloads should route traffic to the ServiceEntry through.
+	AmbientUseWaypoint = "istio.io/use-waypoint"
+
+	// TargetRef is the annotation on a WasmPlugin attaching it to the proxies of a Gateway, such as a waypoint, in
+	// the same namespace, as Gateway/<name>. The WasmPlugin API has no targetRef field yet, so the annotation stands in
+	// for it, and is exclusive with the selector of the plugin.
+	TargetRef = "istio.io/target-ref"
+
+	// WaypointRevisionWeights is the annotation on a waypoint Gateway shifting traffic between its pods of different
+	// revisions, as a comma separated list of revision=weight pairs, such as "stable=90,canary=10".
+	WaypointRevisionWeights = "istio.io/waypoint-revision-weights"
 
 	// AmbientRedirection specifies whether a pod has ambient redirection (to ztunnel) configured.
 	AmbientRedirection = "ambient.istio.io/redirection"
@@ -175,4 +191,36 @@ const (
 	AmbientRedirectionEnabled = "enabled"
 	// AmbientRedirectionDisabled is an opt-out, configured by user.
 	AmbientRedirectionDisabled = "disabled"
+	// AmbientReadinessGate is the pod condition the CNI node agent sets once the traffic of a pod is redirected to
+	// ztunnel. Pods declaring it as a readiness gate are only ready once they are captured.
+	AmbientReadinessGate = "istio.io/ambient-ready"
+	// AmbientIdentity is the pod condition the CNI node agent sets to false while the ServiceAccount of a pod in the
+	// ambient mesh does not exist, so ztunnel cannot issue the identity of the pod.
+	AmbientIdentity = "istio.io/ambient-identity"
+
+	// AmbientWaypointRequired is the pod annotation requiring the traffic to the pod to be processed by its waypoint.
+	// ztunnel then refuses to deliver traffic to the pod directly, including when it has no healthy waypoint.
+	AmbientWaypointRequired = "ambient.istio.io/waypoint-required"
+
+	// AmbientProxyProtocol is the DestinationRule annotation making ztunnel prepend a PROXY protocol header to the
+	// connections it opens to the workloads of the Services of its host. The only supported value is
+	// AmbientProxyProtocolV2.
+	AmbientProxyProtocol   = "ambient.istio.io/proxy-protocol"
+	AmbientProxyProtocolV2 = "v2"
+
+	// AmbientMaxConnections is the pod annotation limiting the concurrent connections ztunnel proxies for the pod.
+	AmbientMaxConnections = "ambient.istio.io/max-connections"
+	// AmbientMaxConnectionRate is the pod annotation limiting the new connections per second ztunnel proxies for the pod.
+	AmbientMaxConnectionRate = "ambient.istio.io/max-connection-rate"
+	// AmbientMaxBandwidth is the pod annotation limiting the bandwidth ztunnel proxies for the pod, as a quantity
+	// of bits per second such as "10M".
+	AmbientMaxBandwidth = "ambient.istio.io/max-bandwidth"
+
+	// AmbientTrustDomain is the namespace annotation overriding the trust domain of the identities of the ambient
+	// workloads of the namespace, which is the trust domain of the mesh by default.
+	AmbientTrustDomain = "ambient.istio.io/trust-domain"
+
+	// RevokedIdentitiesPolicyName is the name of the root namespace DENY AuthorizationPolicy listing
+	// revoked workload identities. Istiod also refuses to issue certificates for identities in this policy.
+	RevokedIdentitiesPolicyName = "istio-revoked-identities"
 )
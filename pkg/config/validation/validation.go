@@ -55,6 +55,7 @@ import (
 	"istio.io/istio/pkg/config/host"
 	"istio.io/istio/pkg/config/labels"
 	"istio.io/istio/pkg/config/protocol"
+	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/config/security"
 	"istio.io/istio/pkg/config/visibility"
 	"istio.io/istio/pkg/config/xds"
@@ -2071,6 +2072,13 @@ var ValidateAuthorizationPolicy = registerValidateFunc("ValidateAuthorizationPol
 					errs = appendErrors(errs, security.CheckEmptyValues("NotNamespaces", src.NotNamespaces))
 					errs = appendErrors(errs, security.CheckEmptyValues("NotIpBlocks", src.NotIpBlocks))
 					errs = appendErrors(errs, security.CheckEmptyValues("NotRemoteIpBlocks", src.NotRemoteIpBlocks))
+					// Waypoints, as sidecars, tell the remote address apart, ztunnel does not
+					if len(src.IpBlocks) > 0 && len(src.RemoteIpBlocks) > 0 && in.GetSelector().GetMatchLabels()[constants.GatewayNameLabel] == "" {
+						warnings = appendErrors(warnings, fmt.Errorf("`ipBlocks` and `remoteIpBlocks` are both set at rule %d; "+
+							"this is supported by sidecars and waypoints, but if the policy applies to workloads in ambient mode, "+
+							"ztunnel matches both against the original source address, so an ALLOW rule will never match and "+
+							"a DENY rule will only consider `ipBlocks`", i))
+					}
 					if src.NotPrincipals != nil || src.Principals != nil || src.IpBlocks != nil ||
 						src.NotIpBlocks != nil || src.Namespaces != nil ||
 						src.NotNamespaces != nil || src.RemoteIpBlocks != nil || src.NotRemoteIpBlocks != nil {
@@ -3928,10 +3936,26 @@ var ValidateWasmPlugin = registerValidateFunc("ValidateWasmPlugin",
 			validateWasmPluginSHA(spec),
 			validateWasmPluginVMConfig(spec.VmConfig),
 			validateWasmPluginMatch(spec.Match),
+			validateWasmPluginTargetRef(cfg.Annotations, spec.Selector),
 		)
 		return errs.Unwrap()
 	})
 
+func validateWasmPluginTargetRef(annotations map[string]string, selector *type_beta.WorkloadSelector) error {
+	ref, f := annotations[constants.TargetRef]
+	if !f {
+		return nil
+	}
+	k, name, ok := strings.Cut(ref, "/")
+	if !ok || k != gvk.KubernetesGateway.Kind || name == "" {
+		return fmt.Errorf("invalid %s annotation %q: expected Gateway/<name>", constants.TargetRef, ref)
+	}
+	if selector != nil {
+		return fmt.Errorf("the %s annotation and the selector are mutually exclusive", constants.TargetRef)
+	}
+	return nil
+}
+
 func validateWasmPluginURL(pluginURL string) error {
 	if pluginURL == "" {
 		return fmt.Errorf("url field needs to be set")
@@ -6073,6 +6073,49 @@ func TestValidateAuthorizationPolicy(t *testing.T) {
 			valid:   true,
 			Warning: false,
 		},
+		{
+			name: "ipBlocks and remoteIpBlocks",
+			in: &security_beta.AuthorizationPolicy{
+				Action: security_beta.AuthorizationPolicy_ALLOW,
+				Rules: []*security_beta.Rule{
+					{
+						From: []*security_beta.Rule_From{
+							{
+								Source: &security_beta.Source{
+									IpBlocks:       []string{"1.2.3.4"},
+									RemoteIpBlocks: []string{"5.6.7.8"},
+								},
+							},
+						},
+					},
+				},
+			},
+			valid:   true,
+			Warning: true,
+		},
+		{
+			name: "ipBlocks and remoteIpBlocks on a waypoint",
+			in: &security_beta.AuthorizationPolicy{
+				Selector: &api.WorkloadSelector{
+					MatchLabels: map[string]string{constants.GatewayNameLabel: "waypoint"},
+				},
+				Action: security_beta.AuthorizationPolicy_ALLOW,
+				Rules: []*security_beta.Rule{
+					{
+						From: []*security_beta.Rule_From{
+							{
+								Source: &security_beta.Source{
+									IpBlocks:       []string{"1.2.3.4"},
+									RemoteIpBlocks: []string{"5.6.7.8"},
+								},
+							},
+						},
+					},
+				},
+			},
+			valid:   true,
+			Warning: false,
+		},
 	}
 
 	for _, c := range cases {
@@ -8362,6 +8405,35 @@ func TestValidateWasmPlugin(t *testing.T) {
 	}
 }
 
+func TestValidateWasmPluginTargetRef(t *testing.T) {
+	selector := &api.WorkloadSelector{MatchLabels: map[string]string{"app": "a"}}
+	tests := []struct {
+		name     string
+		ref      string
+		selector *api.WorkloadSelector
+		out      string
+	}{
+		{"gateway", "Gateway/waypoint", nil, ""},
+		{"no kind", "waypoint", nil, "expected Gateway/<name>"},
+		{"other kind", "Service/waypoint", nil, "expected Gateway/<name>"},
+		{"no name", "Gateway/", nil, "expected Gateway/<name>"},
+		{"with selector", "Gateway/waypoint", selector, "mutually exclusive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warn, err := ValidateWasmPlugin(config.Config{
+				Meta: config.Meta{
+					Name:        someName,
+					Namespace:   someNamespace,
+					Annotations: map[string]string{constants.TargetRef: tt.ref},
+				},
+				Spec: &extensions.WasmPlugin{Url: "http://test.com/test", Selector: tt.selector},
+			})
+			checkValidationMessage(t, warn, err, "", tt.out)
+		})
+	}
+}
+
 func TestRecurseMissingTypedConfig(t *testing.T) {
 	good := &listener.Filter{
 		Name:       wellknown.TCPProxy,
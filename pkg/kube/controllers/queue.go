@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"go.uber.org/atomic"
@@ -38,6 +39,9 @@ type Queue struct {
 	workFn      func(key any) error
 	closed      chan struct{}
 	log         *istiolog.Scope
+	// processing is held while an item is processed, so ShutDownWithDrain can wait for it.
+	processing *sync.Mutex
+	draining   *atomic.Bool
 }
 
 // WithName sets a name for the queue. This is used for logging
@@ -85,6 +89,8 @@ func NewQueue(name string, options ...func(*Queue)) Queue {
 		name:        name,
 		closed:      make(chan struct{}),
 		initialSync: atomic.NewBool(false),
+		processing:  &sync.Mutex{},
+		draining:    atomic.NewBool(false),
 	}
 	for _, o := range options {
 		o(&q)
@@ -124,6 +130,17 @@ func (q Queue) Run(stop <-chan struct{}) {
 	q.log.Infof("stopped")
 }
 
+// ShutDownWithDrain shuts down the queue, and waits until the item being processed, if any, is done. Items which are
+// not processed yet are dropped, and the items added later are ignored. Unlike stopping Run, which returns while an
+// item may still be processed, this lets the caller safely clean up what the items configure once it returns.
+func (q Queue) ShutDownWithDrain() {
+	q.draining.Store(true)
+	q.queue.ShutDown()
+	q.processing.Lock()
+	defer q.processing.Unlock()
+	q.log.Infof("drained")
+}
+
 // syncSignal defines a dummy signal that is enqueued when .Run() is called. This allows us to detect
 // when we have processed all items added to the queue prior to Run().
 type syncSignal struct{}
@@ -151,6 +168,13 @@ func (q Queue) processNextItem() bool {
 		// We are done, signal to exit the queue
 		return false
 	}
+	q.processing.Lock()
+	defer q.processing.Unlock()
+	if q.draining.Load() {
+		// The queue is shutting down with drain, so the items which are not processed yet are dropped
+		q.queue.Done(key)
+		return false
+	}
 
 	// We got the sync signal. This is not a real event, so we exit early after signaling we are synced
 	if key == defaultSyncSignal {
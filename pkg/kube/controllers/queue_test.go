@@ -42,3 +42,36 @@ func TestQueue(t *testing.T) {
 	// event 2 is guaranteed to happen from WaitForClose
 	assert.Equal(t, handles.Load(), 2)
 }
+
+func TestQueueShutDownWithDrain(t *testing.T) {
+	handles := atomic.NewInt32(0)
+	release := make(chan struct{})
+	q := NewQueue("custom", WithReconciler(func(key types.NamespacedName) error {
+		handles.Inc()
+		<-release
+		return nil
+	}))
+	q.Add(types.NamespacedName{Name: "something"})
+	q.Add(types.NamespacedName{Name: "something else"})
+	stop := make(chan struct{})
+	defer close(stop)
+	go q.Run(stop)
+	retry.UntilOrFail(t, func() bool { return handles.Load() == 1 }, retry.Delay(time.Microsecond))
+
+	drained := atomic.NewBool(false)
+	go func() {
+		q.ShutDownWithDrain()
+		drained.Store(true)
+	}()
+	// The item being processed is waited for
+	time.Sleep(10 * time.Millisecond)
+	assert.Equal(t, drained.Load(), false)
+	close(release)
+	retry.UntilOrFail(t, drained.Load, retry.Delay(time.Microsecond))
+	assert.NoError(t, q.WaitForClose(time.Second))
+	// The item which was not processed yet is dropped
+	assert.Equal(t, handles.Load(), 1)
+	// Items added after the shut down are ignored
+	q.Add(types.NamespacedName{Name: "ignored"})
+	assert.Equal(t, handles.Load(), 1)
+}
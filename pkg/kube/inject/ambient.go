@@ -0,0 +1,205 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"istio.io/api/annotation"
+	"istio.io/api/label"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/pkg/log"
+)
+
+// AmbientNamespacePolicy is what the webhook does with pods to inject in namespaces using ambient mode, which would
+// otherwise run both a sidecar and ztunnel.
+type AmbientNamespacePolicy string
+
+const (
+	// AmbientNamespaceWarn injects the pods, recording a warning event.
+	AmbientNamespaceWarn AmbientNamespacePolicy = "warn"
+	// AmbientNamespaceSkip does not inject the pods, which are captured by ztunnel instead.
+	AmbientNamespaceSkip AmbientNamespacePolicy = "skip"
+	// AmbientNamespaceReject rejects the pods explicitly requesting injection, and does not inject the others.
+	AmbientNamespaceReject AmbientNamespacePolicy = "reject"
+)
+
+const ambientInjectionReason = "SidecarInjectedInAmbientNamespace"
+
+// ambientNamespace returns whether the pod is in a namespace using ambient mode, and has not opted out of it.
+func (wh *Webhook) ambientNamespace(pod *corev1.Pod) bool {
+	if wh.namespaces == nil || pod.Annotations[constants.AmbientRedirection] == constants.AmbientRedirectionDisabled {
+		return false
+	}
+	ns := wh.namespaces.Get(pod.Namespace, "")
+	return ns != nil && ns.Labels[constants.DataplaneMode] == constants.DataplaneModeAmbient
+}
+
+// explicitInjection returns whether the pod explicitly requests injection, rather than being injected by default.
+func explicitInjection(metadata metav1.ObjectMeta) bool {
+	selector := metadata.Annotations[annotation.SidecarInject.Name]
+	if lbl, f := metadata.Labels[label.SidecarInject.Name]; f {
+		selector = lbl
+	}
+	switch strings.ToLower(selector) {
+	case "y", "yes", "true", "on":
+		return true
+	}
+	return false
+}
+
+// reportAmbientInjection records a warning event on the owner of the pod, as the pod is not created yet. The event is
+// recorded asynchronously, so it does not delay the admission.
+func (wh *Webhook) reportAmbientInjection(pod *corev1.Pod, msg string) {
+	if wh.kubeClient == nil {
+		return
+	}
+	deploy, typeMeta := kube.GetDeployMetaFromPod(pod)
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: deploy.Name + ".",
+			Namespace:    pod.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: typeMeta.APIVersion,
+			Kind:       typeMeta.Kind,
+			Name:       deploy.Name,
+			Namespace:  pod.Namespace,
+		},
+		Reason:         ambientInjectionReason,
+		Message:        msg,
+		Type:           corev1.EventTypeWarning,
+		Source:         corev1.EventSource{Component: "sidecar-injector"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if _, err := wh.kubeClient.Kube().CoreV1().Events(pod.Namespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+			log.Warnf("failed to create event for %s %s/%s: %v", typeMeta.Kind, pod.Namespace, deploy.Name, err)
+		}
+	}()
+}
+
+// ambient mutates the pods of namespaces using ambient mode which are not injected, as they are captured by ztunnel
+// instead. The pods are allowed even if they cannot be mutated, as they are captured anyway.
+func (wh *Webhook) ambient(ar *kube.AdmissionReview, _ string) *kube.AdmissionResponse {
+	req := ar.Request
+	var pod corev1.Pod
+	if err := json.Unmarshal(req.Object.Raw, &pod); err != nil {
+		handleError(fmt.Sprintf("Could not unmarshal raw object: %v %s", err, string(req.Object.Raw)))
+		return toAdmissionResponse(err)
+	}
+	pod.ManagedFields = nil
+	if pod.ObjectMeta.Namespace == "" {
+		pod.ObjectMeta.Namespace = req.Namespace
+	}
+	if !wh.ambientNamespace(&pod) {
+		return &kube.AdmissionResponse{Allowed: true}
+	}
+	return wh.ambientResponse(&pod)
+}
+
+// ambientResponse returns the admission response of a pod captured by ztunnel, patched with the ambient mutations.
+func (wh *Webhook) ambientResponse(pod *corev1.Pod) *kube.AdmissionResponse {
+	original, err := json.Marshal(pod)
+	if err != nil {
+		handleError(fmt.Sprintf("Could not marshal pod: %v", err))
+		return &kube.AdmissionResponse{Allowed: true}
+	}
+	if !wh.mutateAmbientPod(pod) {
+		return &kube.AdmissionResponse{Allowed: true}
+	}
+	patch, err := createPatch(pod, original)
+	if err != nil {
+		handleError(fmt.Sprintf("Ambient pod mutation failed: %v", err))
+		return &kube.AdmissionResponse{Allowed: true}
+	}
+	pt := "JSONPatch"
+	return &kube.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &pt,
+	}
+}
+
+// mutateAmbientPod applies the ambient mutations to a pod captured by ztunnel, and returns whether it changed.
+func (wh *Webhook) mutateAmbientPod(pod *corev1.Pod) bool {
+	changed := false
+	if wh.ambientReadinessGate && !hasReadinessGate(pod, constants.AmbientReadinessGate) {
+		// Released by the CNI node agent once the traffic of the pod is redirected to ztunnel
+		pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, corev1.PodReadinessGate{
+			ConditionType: constants.AmbientReadinessGate,
+		})
+		changed = true
+	}
+	if wh.ambientDrainPeriod > 0 && addDrainHooks(pod, wh.ambientDrainPeriod) {
+		changed = true
+	}
+	return changed
+}
+
+// addDrainHooks delays the termination of the containers of a pod without a preStop hook by the drain period, so the
+// connections ztunnel proxies to the pod are drained before the application stops. The termination grace period of
+// the pod is extended by the drain period, so the application keeps its time to shut down. The hook requires a sleep
+// binary in the containers: otherwise, the hook fails and the container is terminated without draining.
+func addDrainHooks(pod *corev1.Pod, period time.Duration) bool {
+	seconds := int64(math.Ceil(period.Seconds()))
+	changed := false
+	for i := range pod.Spec.Containers {
+		c := &pod.Spec.Containers[i]
+		if c.Lifecycle != nil && c.Lifecycle.PreStop != nil {
+			continue
+		}
+		if c.Lifecycle == nil {
+			c.Lifecycle = &corev1.Lifecycle{}
+		}
+		c.Lifecycle.PreStop = &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"sleep", strconv.FormatInt(seconds, 10)}},
+		}
+		changed = true
+	}
+	if changed {
+		grace := int64(corev1.DefaultTerminationGracePeriodSeconds)
+		if pod.Spec.TerminationGracePeriodSeconds != nil {
+			grace = *pod.Spec.TerminationGracePeriodSeconds
+		}
+		grace += seconds
+		pod.Spec.TerminationGracePeriodSeconds = &grace
+	}
+	return changed
+}
+
+func hasReadinessGate(pod *corev1.Pod, condition corev1.PodConditionType) bool {
+	for _, g := range pod.Spec.ReadinessGates {
+		if g.ConditionType == condition {
+			return true
+		}
+	}
+	return false
+}
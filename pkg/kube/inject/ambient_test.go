@@ -0,0 +1,212 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inject
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"istio.io/api/label"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+	"istio.io/istio/pkg/test/util/retry"
+)
+
+func TestAmbientNamespacePolicy(t *testing.T) {
+	ambient := map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient}
+	explicit := map[string]string{label.SidecarInject.Name: "true"}
+	cases := []struct {
+		name        string
+		policy      AmbientNamespacePolicy
+		nsLabels    map[string]string
+		podLabels   map[string]string
+		podAnnos    map[string]string
+		wantAllowed bool
+		wantPatch   bool
+		wantEvent   bool
+	}{
+		{
+			name:        "not ambient",
+			policy:      AmbientNamespaceReject,
+			podLabels:   explicit,
+			wantAllowed: true,
+			wantPatch:   true,
+		},
+		{
+			name:        "warn",
+			policy:      AmbientNamespaceWarn,
+			nsLabels:    ambient,
+			wantAllowed: true,
+			wantPatch:   true,
+			wantEvent:   true,
+		},
+		{
+			name:        "skip",
+			policy:      AmbientNamespaceSkip,
+			nsLabels:    ambient,
+			podLabels:   explicit,
+			wantAllowed: true,
+		},
+		{
+			name:      "reject explicit",
+			policy:    AmbientNamespaceReject,
+			nsLabels:  ambient,
+			podLabels: explicit,
+		},
+		{
+			name:        "reject skips default",
+			policy:      AmbientNamespaceReject,
+			nsLabels:    ambient,
+			wantAllowed: true,
+		},
+		{
+			name:        "opted out of ambient",
+			policy:      AmbientNamespaceReject,
+			nsLabels:    ambient,
+			podLabels:   explicit,
+			podAnnos:    map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionDisabled},
+			wantAllowed: true,
+			wantPatch:   true,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kube.NewFakeClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: tt.nsLabels}})
+			wh := createWebhook(t, minimalSidecarTemplate, 0)
+			wh.kubeClient = client
+			wh.namespaces = kclient.New[*corev1.Namespace](client)
+			wh.ambientNamespacePolicy = tt.policy
+			client.RunAndWait(test.NewStop(t))
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "test", Labels: tt.podLabels, Annotations: tt.podAnnos},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			}
+			raw, err := json.Marshal(pod)
+			assert.NoError(t, err)
+			resp := wh.inject(&kube.AdmissionReview{Request: &kube.AdmissionRequest{
+				Namespace: "test",
+				Object:    runtime.RawExtension{Raw: raw},
+			}}, "")
+			assert.Equal(t, resp.Allowed, tt.wantAllowed)
+			assert.Equal(t, len(resp.Patch) > 0, tt.wantPatch)
+			assert.Equal(t, len(resp.Warnings) > 0, tt.wantEvent)
+			if tt.wantEvent {
+				retry.UntilSuccessOrFail(t, func() error {
+					events, err := client.Kube().CoreV1().Events("test").List(context.Background(), metav1.ListOptions{})
+					if err != nil {
+						return err
+					}
+					if len(events.Items) != 1 || events.Items[0].Reason != ambientInjectionReason {
+						return fmt.Errorf("expected a single %s event, got %v", ambientInjectionReason, events.Items)
+					}
+					return nil
+				})
+			}
+		})
+	}
+}
+
+func TestAmbientReadinessGate(t *testing.T) {
+	ambient := map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient}
+	gate := corev1.PodReadinessGate{ConditionType: constants.AmbientReadinessGate}
+	cases := []struct {
+		name     string
+		nsLabels map[string]string
+		podAnnos map[string]string
+		gates    []corev1.PodReadinessGate
+		wantGate bool
+	}{
+		{
+			name:     "ambient",
+			nsLabels: ambient,
+			wantGate: true,
+		},
+		{
+			name: "not ambient",
+		},
+		{
+			name:     "opted out of ambient",
+			nsLabels: ambient,
+			podAnnos: map[string]string{constants.AmbientRedirection: constants.AmbientRedirectionDisabled},
+		},
+		{
+			name:     "already declared",
+			nsLabels: ambient,
+			gates:    []corev1.PodReadinessGate{gate},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			client := kube.NewFakeClient(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test", Labels: tt.nsLabels}})
+			wh := createWebhook(t, minimalSidecarTemplate, 0)
+			wh.namespaces = kclient.New[*corev1.Namespace](client)
+			wh.ambientReadinessGate = true
+			client.RunAndWait(test.NewStop(t))
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Annotations: tt.podAnnos},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}, ReadinessGates: tt.gates},
+			}
+			raw, err := json.Marshal(pod)
+			assert.NoError(t, err)
+			resp := wh.ambient(&kube.AdmissionReview{Request: &kube.AdmissionRequest{
+				Namespace: "test",
+				Object:    runtime.RawExtension{Raw: raw},
+			}}, "/ambient")
+			assert.Equal(t, resp.Allowed, true)
+			assert.Equal(t, len(resp.Patch) > 0, tt.wantGate)
+			if tt.wantGate {
+				var patched corev1.Pod
+				assert.NoError(t, json.Unmarshal(applyJSONPatch(raw, resp.Patch, t), &patched))
+				assert.Equal(t, patched.Spec.ReadinessGates, []corev1.PodReadinessGate{gate})
+			}
+		})
+	}
+}
+
+func TestAddDrainHooks(t *testing.T) {
+	existing := &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/shutdown"}}}}
+	grace := int64(10)
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{Name: "app"},
+		{Name: "hooked", Lifecycle: existing.DeepCopy()},
+	}}}
+
+	// The drain period is rounded up to seconds, and added to the default grace period
+	assert.Equal(t, addDrainHooks(pod, 1500*time.Millisecond), true)
+	assert.Equal(t, pod.Spec.Containers[0].Lifecycle.PreStop.Exec.Command, []string{"sleep", "2"})
+	assert.Equal(t, pod.Spec.Containers[1].Lifecycle, existing)
+	assert.Equal(t, *pod.Spec.TerminationGracePeriodSeconds, corev1.DefaultTerminationGracePeriodSeconds+2)
+
+	// Pods whose containers all have a preStop hook are left untouched
+	pod.Spec.TerminationGracePeriodSeconds = &grace
+	assert.Equal(t, addDrainHooks(pod, 5*time.Second), false)
+	assert.Equal(t, *pod.Spec.TerminationGracePeriodSeconds, int64(10))
+
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "other"})
+	assert.Equal(t, addDrainHooks(pod, 5*time.Second), true)
+	assert.Equal(t, *pod.Spec.TerminationGracePeriodSeconds, int64(15))
+}
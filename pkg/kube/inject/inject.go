@@ -44,6 +44,7 @@ import (
 	proxyConfig "istio.io/api/networking/v1beta1"
 	opconfig "istio.io/istio/operator/pkg/apis/istio/v1alpha1"
 	"istio.io/istio/pkg/config/mesh"
+	"istio.io/istio/pkg/kube"
 	"istio.io/pkg/log"
 )
 
@@ -194,6 +195,11 @@ func injectRequired(ignored []string, config *Config, podSpec *corev1.PodSpec, m
 		return false
 	}
 
+	// Pods excluded from any dataplane are never injected, regardless of the injection policy and selectors
+	if kube.DataplaneModeNone(&metadata) {
+		return false
+	}
+
 	// skip special kubernetes system namespaces
 	for _, namespace := range ignored {
 		if metadata.Namespace == namespace {
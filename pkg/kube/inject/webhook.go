@@ -48,6 +48,7 @@ import (
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pkg/config/mesh"
 	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/util/protomarshal"
 	"istio.io/istio/pkg/util/sets"
 	"istio.io/pkg/log"
@@ -108,6 +109,12 @@ type Webhook struct {
 
 	env      *model.Environment
 	revision string
+
+	kubeClient             kube.Client
+	namespaces             kclient.Reader[*corev1.Namespace]
+	ambientNamespacePolicy AmbientNamespacePolicy
+	ambientReadinessGate   bool
+	ambientDrainPeriod     time.Duration
 }
 
 func (wh *Webhook) GetConfig() WebhookConfig {
@@ -176,6 +183,24 @@ type WebhookParameters struct {
 
 	// The istio.io/rev this injector is responsible for
 	Revision string
+
+	// KubeClient is used to record events about the injection of pods.
+	KubeClient kube.Client
+
+	// Namespaces, if set, is used to apply the AmbientNamespacePolicy to the pods of namespaces using ambient mode.
+	Namespaces kclient.Reader[*corev1.Namespace]
+
+	// AmbientNamespacePolicy is what the webhook does with pods to inject in namespaces using ambient mode.
+	// Defaults to AmbientNamespaceWarn.
+	AmbientNamespacePolicy AmbientNamespacePolicy
+
+	// AmbientReadinessGate adds the ambient readiness gate to the pods of namespaces using ambient mode which are not
+	// injected, so they are only ready once the CNI node agent redirected their traffic to ztunnel.
+	AmbientReadinessGate bool
+
+	// AmbientDrainPeriod, if set, delays the termination of the pods of namespaces using ambient mode which are not
+	// injected with a preStop hook, so ztunnel drains their connections.
+	AmbientDrainPeriod time.Duration
 }
 
 // NewWebhook creates a new instance of a mutating webhook for automatic sidecar injection.
@@ -185,10 +210,23 @@ func NewWebhook(p WebhookParameters) (*Webhook, error) {
 	}
 
 	wh := &Webhook{
-		watcher:    p.Watcher,
-		meshConfig: p.Env.Mesh(),
-		env:        p.Env,
-		revision:   p.Revision,
+		watcher:                p.Watcher,
+		meshConfig:             p.Env.Mesh(),
+		env:                    p.Env,
+		revision:               p.Revision,
+		kubeClient:             p.KubeClient,
+		namespaces:             p.Namespaces,
+		ambientNamespacePolicy: p.AmbientNamespacePolicy,
+		ambientReadinessGate:   p.AmbientReadinessGate,
+		ambientDrainPeriod:     p.AmbientDrainPeriod,
+	}
+	switch wh.ambientNamespacePolicy {
+	case AmbientNamespaceWarn, AmbientNamespaceSkip, AmbientNamespaceReject:
+	case "":
+		wh.ambientNamespacePolicy = AmbientNamespaceWarn
+	default:
+		log.Warnf("invalid ambient namespace injection policy %q, using %q", wh.ambientNamespacePolicy, AmbientNamespaceWarn)
+		wh.ambientNamespacePolicy = AmbientNamespaceWarn
 	}
 
 	mc := NewMulticast(p.Watcher, wh.GetConfig)
@@ -204,6 +242,9 @@ func NewWebhook(p WebhookParameters) (*Webhook, error) {
 
 	p.Mux.HandleFunc("/inject", wh.serveInject)
 	p.Mux.HandleFunc("/inject/", wh.serveInject)
+	if wh.namespaces != nil {
+		p.Mux.HandleFunc("/ambient", wh.serveAmbient)
+	}
 
 	p.Env.Watcher.AddMeshHandler(func() {
 		wh.mu.Lock()
@@ -983,6 +1024,28 @@ func (wh *Webhook) inject(ar *kube.AdmissionReview, path string) *kube.Admission
 		}
 	}
 
+	var warnings []string
+	if wh.ambientNamespace(&pod) {
+		switch {
+		case wh.ambientNamespacePolicy == AmbientNamespaceReject && explicitInjection(pod.ObjectMeta):
+			wh.mu.RUnlock()
+			log.Infof("Rejecting %s/%s requesting injection in ambient namespace", pod.ObjectMeta.Namespace, podName)
+			totalFailedInjections.Increment()
+			return toAdmissionResponse(fmt.Errorf("sidecar injection requested in namespace %s, which uses ambient mode: "+
+				"remove the %s label or opt the pod out of ambient mode", pod.Namespace, label.SidecarInject.Name))
+		case wh.ambientNamespacePolicy != AmbientNamespaceWarn:
+			wh.mu.RUnlock()
+			log.Infof("Skipping %s/%s in ambient namespace", pod.ObjectMeta.Namespace, podName)
+			totalSkippedInjections.Increment()
+			return wh.ambientResponse(&pod)
+		default:
+			msg := fmt.Sprintf("sidecar injected in namespace %s, which uses ambient mode: the pod runs a sidecar and is "+
+				"not captured by ztunnel", pod.Namespace)
+			warnings = append(warnings, msg)
+			wh.reportAmbientInjection(&pod, msg)
+		}
+	}
+
 	proxyConfig := mesh.DefaultProxyConfig()
 	if wh.env.PushContext != nil && wh.env.PushContext.ProxyConfigs != nil {
 		if generatedProxyConfig := wh.env.PushContext.ProxyConfigs.EffectiveProxyConfig(
@@ -1018,8 +1081,9 @@ func (wh *Webhook) inject(ar *kube.AdmissionReview, path string) *kube.Admission
 	}
 
 	reviewResponse := kube.AdmissionResponse{
-		Allowed: true,
-		Patch:   patchBytes,
+		Allowed:  true,
+		Warnings: warnings,
+		Patch:    patchBytes,
 		PatchType: func() *string {
 			pt := "JSONPatch"
 			return &pt
@@ -1033,6 +1097,15 @@ func (wh *Webhook) serveInject(w http.ResponseWriter, r *http.Request) {
 	totalInjections.Increment()
 	t0 := time.Now()
 	defer func() { injectionTime.Record(time.Since(t0).Seconds()) }()
+	wh.serve(w, r, wh.inject)
+}
+
+// serveAmbient mutates the pods of namespaces using ambient mode which are not injected.
+func (wh *Webhook) serveAmbient(w http.ResponseWriter, r *http.Request) {
+	wh.serve(w, r, wh.ambient)
+}
+
+func (wh *Webhook) serve(w http.ResponseWriter, r *http.Request, admit func(ar *kube.AdmissionReview, path string) *kube.AdmissionResponse) {
 	var body []byte
 	if r.Body != nil {
 		if data, err := kube.HTTPConfigReader(r); err == nil {
@@ -1074,7 +1147,7 @@ func (wh *Webhook) serveInject(w http.ResponseWriter, r *http.Request) {
 			handleError(fmt.Sprintf("Could not decode object: %v", err))
 			reviewResponse = toAdmissionResponse(err)
 		} else {
-			reviewResponse = wh.inject(ar, path)
+			reviewResponse = admit(ar, path)
 		}
 	}
 
@@ -52,6 +52,7 @@ import (
 	"istio.io/istio/pilot/pkg/model"
 	"istio.io/istio/pilot/test/util"
 	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
 	"istio.io/istio/pkg/config/mesh"
 	"istio.io/istio/pkg/config/schema/gvk"
 	"istio.io/istio/pkg/test/util/file"
@@ -516,6 +517,30 @@ func TestInjectRequired(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			config: &Config{
+				Policy: InjectionPolicyEnabled,
+			},
+			podSpec: podSpec,
+			meta: metav1.ObjectMeta{
+				Name:      "dataplane-mode-none-label-enabled",
+				Namespace: "test-namespace",
+				Labels:    map[string]string{label.SidecarInject.Name: "true", constants.DataplaneMode: constants.DataplaneModeNone},
+			},
+			want: false,
+		},
+		{
+			config: &Config{
+				Policy: InjectionPolicyEnabled,
+			},
+			podSpec: podSpec,
+			meta: metav1.ObjectMeta{
+				Name:        "dataplane-mode-none-annotation",
+				Namespace:   "test-namespace",
+				Annotations: map[string]string{constants.DataplaneMode: constants.DataplaneModeNone},
+			},
+			want: false,
+		},
 	}
 
 	for _, c := range cases {
@@ -36,6 +36,7 @@ import (
 	"istio.io/istio/pilot/pkg/config/kube/crd"
 	"istio.io/istio/pilot/pkg/features"
 	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
 	istioversion "istio.io/pkg/version"
 )
 
@@ -208,6 +209,16 @@ func CheckPodReady(pod *corev1.Pod) error {
 	}
 }
 
+// DataplaneModeNone returns whether the object opted out of any dataplane with the istio.io/dataplane-mode=none
+// label or annotation. The injection webhook, the CNI node agent and istiod all evaluate it, so a pod can be excluded
+// from the mesh in namespaces with sidecar injection or ambient mode enabled.
+func DataplaneModeNone(obj metav1.Object) bool {
+	if mode, f := obj.GetLabels()[constants.DataplaneMode]; f {
+		return mode == constants.DataplaneModeNone
+	}
+	return obj.GetAnnotations()[constants.DataplaneMode] == constants.DataplaneModeNone
+}
+
 // GetDeployMetaFromPod heuristically derives deployment metadata from the pod spec.
 func GetDeployMetaFromPod(pod *corev1.Pod) (metav1.ObjectMeta, metav1.TypeMeta) {
 	if pod == nil {
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	networkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
+	"istio.io/istio/pkg/config/constants"
 )
 
 func TestBuildClientConfig(t *testing.T) {
@@ -383,3 +384,30 @@ func TestStripUnusedFields(t *testing.T) {
 		})
 	}
 }
+
+func TestDataplaneModeNone(t *testing.T) {
+	cases := []struct {
+		name        string
+		labels      map[string]string
+		annotations map[string]string
+		want        bool
+	}{
+		{name: "unset"},
+		{name: "label", labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeNone}, want: true},
+		{name: "annotation", annotations: map[string]string{constants.DataplaneMode: constants.DataplaneModeNone}, want: true},
+		{name: "ambient label", labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient}},
+		{
+			name:        "label takes precedence",
+			labels:      map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+			annotations: map[string]string{constants.DataplaneMode: constants.DataplaneModeNone},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: tc.labels, Annotations: tc.annotations}}
+			if got := DataplaneModeNone(pod); got != tc.want {
+				t.Errorf("DataplaneModeNone() got %v want %v", got, tc.want)
+			}
+		})
+	}
+}
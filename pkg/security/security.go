@@ -145,6 +145,11 @@ const (
 	// This is constrained to only allow identities in CATrustedNodeAccounts, and only to impersonate identities
 	// on their node.
 	ImpersonatedIdentity = "ImpersonatedIdentity"
+
+	// TrustAnchors requests the trust anchors of the mesh, such as the roots of the other clusters of a multi-primary
+	// mesh, to be appended after the root of the certificate chain. Only clients treating all the certificates after
+	// the issuing chain as roots set it.
+	TrustAnchors = "TrustAnchors"
 )
 
 type ImpersonatedIdentityContextKey struct{}
@@ -246,6 +251,10 @@ type Options struct {
 	// Cert signer info
 	CertSigner string
 
+	// TrustAnchors requests the trust anchors of the mesh, such as the roots of the other clusters of a
+	// multi-primary mesh, along with the workload certificates. They are added to the root certificates.
+	TrustAnchors bool
+
 	// Delay in reading certificates from file after the change is detected. This is useful in cases
 	// where the write operation of key and cert take longer.
 	FileDebounceDuration time.Duration
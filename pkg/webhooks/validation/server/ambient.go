@@ -0,0 +1,120 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"fmt"
+
+	multierror "github.com/hashicorp/go-multierror"
+
+	extensions "istio.io/api/extensions/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
+	telemetry "istio.io/api/telemetry/v1alpha1"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/config/validation"
+)
+
+// ambientWarnings returns warnings for configuration which only applies to sidecars, when it is applied in a namespace
+// using ambient mode. Pods in such namespaces may still have an injected sidecar, so the configuration is not rejected.
+func (wh *Webhook) ambientWarnings(cfg config.Config) validation.Warning {
+	if wh.namespaces == nil {
+		return nil
+	}
+	ns := wh.namespaces.Get(cfg.Namespace, "")
+	if ns == nil || ns.Labels[constants.DataplaneMode] != constants.DataplaneModeAmbient {
+		return nil
+	}
+	var warnings *multierror.Error
+	warn := func(format string, args ...any) {
+		warnings = multierror.Append(warnings,
+			fmt.Errorf("namespace %s uses ambient mode: "+format, append([]any{cfg.Namespace}, args...)...))
+	}
+	switch cfg.GroupVersionKind {
+	case gvk.EnvoyFilter:
+		ef, ok := cfg.Spec.(*networking.EnvoyFilter)
+		if !ok {
+			return nil
+		}
+		for _, patch := range ef.ConfigPatches {
+			if patch.GetMatch().GetContext() == networking.EnvoyFilter_SIDECAR_INBOUND ||
+				patch.GetMatch().GetContext() == networking.EnvoyFilter_SIDECAR_OUTBOUND {
+				warn("patches with the %v context only apply to pods with an injected sidecar, "+
+					"not to ztunnel or waypoints", patch.GetMatch().GetContext())
+				break
+			}
+		}
+	case gvk.WasmPlugin:
+		wp, ok := cfg.Spec.(*extensions.WasmPlugin)
+		if !ok {
+			return nil
+		}
+		if labels := wp.GetSelector().GetMatchLabels(); len(labels) > 0 && labels[constants.GatewayNameLabel] == "" {
+			warn("the plugin only applies to the selected pods with an injected sidecar; "+
+				"select a waypoint with the %s label to apply it to ambient workloads", constants.GatewayNameLabel)
+		}
+	case gvk.Telemetry:
+		tel, ok := cfg.Spec.(*telemetry.Telemetry)
+		if !ok {
+			return nil
+		}
+		if len(tel.Tracing) > 0 {
+			warn("tracing only applies to waypoints and pods with an injected sidecar, not to ztunnel")
+		}
+		for _, l := range tel.AccessLogging {
+			if l.GetFilter() != nil {
+				warn("access logging filters only apply to waypoints and pods with an injected sidecar, not to ztunnel")
+				break
+			}
+		}
+		if hasExpressionTagOverride(tel) {
+			warn("tag overrides whose value is not a string literal only apply to waypoints and pods with an " +
+				"injected sidecar, not to ztunnel")
+		}
+	case gvk.DestinationRule:
+		dr, ok := cfg.Spec.(*networking.DestinationRule)
+		if !ok {
+			return nil
+		}
+		if len(dr.GetTrafficPolicy().GetLoadBalancer().GetLocalityLbSetting().GetDistribute()) > 0 {
+			warn("the distribute setting of localityLbSetting only applies to waypoints and pods with an injected " +
+				"sidecar, not to ztunnel")
+		}
+	case gvk.Sidecar:
+		warn("Sidecar resources only apply to pods with an injected sidecar, not to ztunnel or waypoints")
+	}
+	return warnings.ErrorOrNil()
+}
+
+// hasExpressionTagOverride returns whether a Telemetry sets a metric tag to an expression, rather than a quoted string
+// literal. ztunnel does not evaluate expressions.
+func hasExpressionTagOverride(tel *telemetry.Telemetry) bool {
+	for _, m := range tel.Metrics {
+		for _, o := range m.Overrides {
+			for _, tag := range o.TagOverrides {
+				if tag.GetOperation() != telemetry.MetricsOverrides_TagOverride_UPSERT {
+					continue
+				}
+				v := tag.GetValue()
+				quoted := len(v) >= 2 && v[0] == v[len(v)-1] && (v[0] == '\'' || v[0] == '"')
+				if !quoted {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
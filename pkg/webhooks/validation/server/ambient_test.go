@@ -0,0 +1,118 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	extensions "istio.io/api/extensions/v1alpha1"
+	networking "istio.io/api/networking/v1alpha3"
+	telemetry "istio.io/api/telemetry/v1alpha1"
+	"istio.io/api/type/v1beta1"
+	"istio.io/istio/pkg/config"
+	"istio.io/istio/pkg/config/constants"
+	"istio.io/istio/pkg/config/schema/gvk"
+	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/kclient"
+	"istio.io/istio/pkg/test"
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestAmbientWarnings(t *testing.T) {
+	client := kube.NewFakeClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "ambient",
+			Labels: map[string]string{constants.DataplaneMode: constants.DataplaneModeAmbient},
+		}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	)
+	wh := &Webhook{namespaces: kclient.New[*corev1.Namespace](client)}
+	client.RunAndWait(test.NewStop(t))
+
+	sidecarFilter := &networking.EnvoyFilter{
+		ConfigPatches: []*networking.EnvoyFilter_EnvoyConfigObjectPatch{{
+			ApplyTo: networking.EnvoyFilter_HTTP_FILTER,
+			Match:   &networking.EnvoyFilter_EnvoyConfigObjectMatch{Context: networking.EnvoyFilter_SIDECAR_INBOUND},
+		}},
+	}
+	cases := []struct {
+		name      string
+		namespace string
+		kind      config.GroupVersionKind
+		spec      config.Spec
+		warned    bool
+	}{
+		{"sidecar EnvoyFilter", "ambient", gvk.EnvoyFilter, sidecarFilter, true},
+		{"sidecar EnvoyFilter outside ambient", "default", gvk.EnvoyFilter, sidecarFilter, false},
+		{"gateway EnvoyFilter", "ambient", gvk.EnvoyFilter, &networking.EnvoyFilter{
+			ConfigPatches: []*networking.EnvoyFilter_EnvoyConfigObjectPatch{{
+				ApplyTo: networking.EnvoyFilter_HTTP_FILTER,
+				Match:   &networking.EnvoyFilter_EnvoyConfigObjectMatch{Context: networking.EnvoyFilter_GATEWAY},
+			}},
+		}, false},
+		{"sidecar WasmPlugin", "ambient", gvk.WasmPlugin, &extensions.WasmPlugin{
+			Selector: &v1beta1.WorkloadSelector{MatchLabels: map[string]string{"app": "a"}},
+		}, true},
+		{"waypoint WasmPlugin", "ambient", gvk.WasmPlugin, &extensions.WasmPlugin{
+			Selector: &v1beta1.WorkloadSelector{MatchLabels: map[string]string{constants.GatewayNameLabel: "waypoint"}},
+		}, false},
+		{"namespace WasmPlugin", "ambient", gvk.WasmPlugin, &extensions.WasmPlugin{}, false},
+		{"Sidecar", "ambient", gvk.Sidecar, &networking.Sidecar{}, true},
+		{"Telemetry tracing", "ambient", gvk.Telemetry, &telemetry.Telemetry{Tracing: []*telemetry.Tracing{{}}}, true},
+		{"Telemetry access logging filter", "ambient", gvk.Telemetry, &telemetry.Telemetry{
+			AccessLogging: []*telemetry.AccessLogging{{Filter: &telemetry.AccessLogging_Filter{Expression: "response.code >= 400"}}},
+		}, true},
+		{"Telemetry expression tag", "ambient", gvk.Telemetry, &telemetry.Telemetry{
+			Metrics: []*telemetry.Metrics{{Overrides: []*telemetry.MetricsOverrides{{
+				TagOverrides: map[string]*telemetry.MetricsOverrides_TagOverride{"path": {Value: "request.url_path"}},
+			}}}},
+		}, true},
+		{"Telemetry literal tag", "ambient", gvk.Telemetry, &telemetry.Telemetry{
+			Metrics: []*telemetry.Metrics{{Overrides: []*telemetry.MetricsOverrides{{
+				TagOverrides: map[string]*telemetry.MetricsOverrides_TagOverride{
+					"team":    {Value: "'payments'"},
+					"version": {Operation: telemetry.MetricsOverrides_TagOverride_REMOVE},
+				},
+			}}}},
+		}, false},
+		{"DestinationRule distribute", "ambient", gvk.DestinationRule, &networking.DestinationRule{
+			TrafficPolicy: &networking.TrafficPolicy{LoadBalancer: &networking.LoadBalancerSettings{
+				LocalityLbSetting: &networking.LocalityLoadBalancerSetting{
+					Distribute: []*networking.LocalityLoadBalancerSetting_Distribute{{From: "us-east/*"}},
+				},
+			}},
+		}, true},
+		{"DestinationRule failover", "ambient", gvk.DestinationRule, &networking.DestinationRule{
+			TrafficPolicy: &networking.TrafficPolicy{LoadBalancer: &networking.LoadBalancerSettings{
+				LocalityLbSetting: &networking.LocalityLoadBalancerSetting{
+					Failover: []*networking.LocalityLoadBalancerSetting_Failover{{From: "us-east", To: "us-west"}},
+				},
+			}},
+		}, false},
+		{"VirtualService", "ambient", gvk.VirtualService, &networking.VirtualService{}, false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := wh.ambientWarnings(config.Config{
+				Meta: config.Meta{GroupVersionKind: tt.kind, Name: "cfg", Namespace: tt.namespace},
+				Spec: tt.spec,
+			})
+			assert.Equal(t, warnings != nil, tt.warned)
+		})
+	}
+}
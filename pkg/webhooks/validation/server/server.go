@@ -24,6 +24,7 @@ import (
 	multierror "github.com/hashicorp/go-multierror"
 	admissionv1 "k8s.io/api/admission/v1"
 	kubeApiAdmissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -33,6 +34,7 @@ import (
 	"istio.io/istio/pkg/config/schema/resource"
 	"istio.io/istio/pkg/config/validation"
 	"istio.io/istio/pkg/kube"
+	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/pkg/log"
 )
 
@@ -75,6 +77,9 @@ type Options struct {
 
 	// Use an existing mux instead of creating our own.
 	Mux *http.ServeMux
+
+	// Namespaces, if set, is used to warn about configuration which does not apply in namespaces using ambient mode.
+	Namespaces kclient.Reader[*corev1.Namespace]
 }
 
 // String produces a stringified version of the arguments for debugging.
@@ -99,6 +104,7 @@ type Webhook struct {
 	// pilot
 	schemas      collection.Schemas
 	domainSuffix string
+	namespaces   kclient.Reader[*corev1.Namespace]
 }
 
 // New creates a new instance of the admission webhook server.
@@ -110,6 +116,7 @@ func New(o Options) (*Webhook, error) {
 	wh := &Webhook{
 		schemas:      o.Schemas,
 		domainSuffix: o.DomainSuffix,
+		namespaces:   o.Namespaces,
 	}
 
 	o.Mux.HandleFunc("/validate", wh.serveValidate)
@@ -244,6 +251,10 @@ func (wh *Webhook) validate(request *kube.AdmissionRequest) *kube.AdmissionRespo
 		return toAdmissionResponse(err)
 	}
 
+	if ambient := wh.ambientWarnings(*out); ambient != nil {
+		warnings = multierror.Append(warnings, ambient)
+	}
+
 	reportValidationPass(request)
 	return &kube.AdmissionResponse{Allowed: true, Warnings: toKubeWarnings(warnings)}
 }
@@ -0,0 +1,60 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloadapi
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Versions of the workload API. Fields can be added to the API without a new version, as clients ignore the fields
+// they do not know, but a new version is needed when older clients would misinterpret the workloads, for example when
+// a field changes how the other fields are to be interpreted.
+//
+// Clients may advertise the version they support in the WORKLOAD_API_VERSION node metadata, and are sent the
+// workloads converted to it. Istiod supports the current version and the previous one, so ztunnel can be upgraded
+// after istiod.
+const (
+	// Version1 is the original API.
+	Version1 = 1
+	// Version2 adds workloads resolved with DNS, which have DNS endpoints rather than an address, the headless Services
+	// of workloads, and the fields from connection_limits to dns_endpoints.
+	Version2 = 2
+
+	// CurrentVersion is the version of the API defined in workload.proto.
+	CurrentVersion = Version2
+	// MinVersion is the oldest version served.
+	MinVersion = CurrentVersion - 1
+)
+
+// NegotiateVersion returns the version of the API served to a client advertising the given version. Clients which
+// do not advertise a version, such as the ztunnel released along with istiod, are served the current version, as are
+// clients newer than istiod, which are expected to support it. Clients older than MinVersion are not supported.
+func NegotiateVersion(advertised string) (int, error) {
+	if advertised == "" {
+		return CurrentVersion, nil
+	}
+	v, err := strconv.Atoi(advertised)
+	if err != nil || v < 1 {
+		return 0, fmt.Errorf("invalid workload API version %q", advertised)
+	}
+	if v < MinVersion {
+		return 0, fmt.Errorf("workload API version %d is not supported, the oldest supported version is %d", v, MinVersion)
+	}
+	if v > CurrentVersion {
+		return CurrentVersion, nil
+	}
+	return v, nil
+}
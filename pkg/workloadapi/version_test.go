@@ -0,0 +1,49 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloadapi
+
+import (
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func TestNegotiateVersion(t *testing.T) {
+	cases := []struct {
+		advertised string
+		want       int
+		err        bool
+	}{
+		// Clients which do not advertise a version are served the current version
+		{advertised: "", want: CurrentVersion},
+		{advertised: "1", want: Version1},
+		{advertised: "2", want: Version2},
+		// Clients newer than istiod are served the current version
+		{advertised: "3", want: CurrentVersion},
+		{advertised: "0", err: true},
+		{advertised: "v2", err: true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.advertised, func(t *testing.T) {
+			got, err := NegotiateVersion(tt.advertised)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
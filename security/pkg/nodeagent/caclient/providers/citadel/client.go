@@ -15,10 +15,14 @@
 package caclient
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"sync"
 
 	"go.uber.org/atomic"
 	"google.golang.org/grpc"
@@ -46,6 +50,11 @@ type CitadelClient struct {
 	provider  *caclient.TokenProvider
 	opts      *security.Options
 	usingMtls *atomic.Bool
+
+	// trustAnchors holds the roots returned along with the last certificate chain, when the trust anchors of the
+	// mesh are requested.
+	trustAnchorsMu sync.Mutex
+	trustAnchors   []string
 }
 
 type TLSOptions struct {
@@ -88,6 +97,9 @@ func (c *CitadelClient) CSRSign(csrPEM []byte, certValidTTLInSec int64) ([]strin
 			},
 		},
 	}
+	if c.opts.TrustAnchors {
+		crMetaStruct.Fields[security.TrustAnchors] = structpb.NewBoolValue(true)
+	}
 	req := &pb.IstioCertificateRequest{
 		Csr:              string(csrPEM),
 		ValidityDuration: certValidTTLInSec,
@@ -108,9 +120,35 @@ func (c *CitadelClient) CSRSign(csrPEM []byte, certValidTTLInSec int64) ([]strin
 		return nil, errors.New("invalid empty CertChain")
 	}
 
+	if c.opts.TrustAnchors {
+		certChain, roots := splitTrustAnchors(resp.CertChain)
+		c.trustAnchorsMu.Lock()
+		c.trustAnchors = roots
+		c.trustAnchorsMu.Unlock()
+		return certChain, nil
+	}
 	return resp.CertChain, nil
 }
 
+// splitTrustAnchors splits a certificate chain followed by the trust anchors of the mesh at its root, the first
+// self-signed certificate. The root ends the returned chain and starts the returned roots.
+func splitTrustAnchors(certChain []string) ([]string, []string) {
+	for i, c := range certChain {
+		block, _ := pem.Decode([]byte(c))
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+			return certChain[:i+1], certChain[i:]
+		}
+	}
+	return certChain, nil
+}
+
 func (c *CitadelClient) getTLSOptions() *istiogrpc.TLSOptions {
 	if c.tlsOpts != nil {
 		return &istiogrpc.TLSOptions{
@@ -172,7 +210,10 @@ func (c *CitadelClient) reconnectIfNeeded() error {
 	return nil
 }
 
-// GetRootCertBundle: Citadel (Istiod) CA doesn't publish any endpoint to retrieve CA certs
+// GetRootCertBundle: Citadel (Istiod) CA doesn't publish any endpoint to retrieve CA certs. When the trust anchors
+// of the mesh are requested, the roots returned along with the last certificate chain are used instead.
 func (c *CitadelClient) GetRootCertBundle() ([]string, error) {
-	return []string{}, nil
+	c.trustAnchorsMu.Lock()
+	defer c.trustAnchorsMu.Unlock()
+	return append([]string{}, c.trustAnchors...), nil
 }
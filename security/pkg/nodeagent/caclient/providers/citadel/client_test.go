@@ -59,6 +59,7 @@ var (
 type mockCAServer struct {
 	pb.UnimplementedIstioCertificateServiceServer
 	Certs         []string
+	TrustAnchors  []string
 	Authenticator *security.FakeAuthenticator
 	Err           error
 }
@@ -72,6 +73,9 @@ func (ca *mockCAServer) CreateCertificate(ctx context.Context, in *pb.IstioCerti
 		}
 	}
 	if ca.Err == nil {
+		if in.Metadata.GetFields()[security.TrustAnchors].GetBoolValue() {
+			return &pb.IstioCertificateResponse{CertChain: append(append([]string{}, ca.Certs...), ca.TrustAnchors...)}, nil
+		}
 		return &pb.IstioCertificateResponse{CertChain: ca.Certs}, nil
 	}
 	return nil, ca.Err
@@ -290,6 +294,50 @@ func TestCitadelClient(t *testing.T) {
 	}
 }
 
+func TestCitadelClientTrustAnchors(t *testing.T) {
+	read := func(f string) string {
+		return string(testutil.ReadFile(t, filepath.Join(env.IstioSrc, f)))
+	}
+	leaf := read("tests/testdata/certs/pilot/cert-chain.pem")
+	root := read("tests/testdata/certs/pilot/root-cert.pem")
+	anchor := read("security/pkg/pki/testdata/spiffe-root-cert-1.pem")
+	addr := serve(t, mockCAServer{Certs: []string{leaf, root}, TrustAnchors: []string{anchor}})
+
+	// Without the trust anchors, the chain is returned as is and the root is inferred from it
+	cli, err := NewCitadelClient(&security.Options{CAEndpoint: addr}, nil)
+	if err != nil {
+		t.Fatalf("failed to create ca client: %v", err)
+	}
+	t.Cleanup(cli.Close)
+	resp, err := cli.CSRSign([]byte{0o1}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp, []string{leaf, root}) {
+		t.Errorf("resp: got %+v, expected the chain", resp)
+	}
+	if roots, _ := cli.GetRootCertBundle(); len(roots) != 0 {
+		t.Errorf("roots: got %+v, expected none", roots)
+	}
+
+	// With the trust anchors, the chain ends at its root, and the trust anchors follow the root
+	cli, err = NewCitadelClient(&security.Options{CAEndpoint: addr, TrustAnchors: true}, nil)
+	if err != nil {
+		t.Fatalf("failed to create ca client: %v", err)
+	}
+	t.Cleanup(cli.Close)
+	resp, err = cli.CSRSign([]byte{0o1}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(resp, []string{leaf, root}) {
+		t.Errorf("resp: got %+v, expected the chain", resp)
+	}
+	if roots, _ := cli.GetRootCertBundle(); !reflect.DeepEqual(roots, []string{root, anchor}) {
+		t.Errorf("roots: got %+v, expected the root and the trust anchors", roots)
+	}
+}
+
 type mockTokenCAServer struct {
 	pb.UnimplementedIstioCertificateServiceServer
 	Certs []string
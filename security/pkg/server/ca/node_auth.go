@@ -20,6 +20,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	"istio.io/istio/pkg/config/constants"
 	"istio.io/istio/pkg/kube"
 	"istio.io/istio/pkg/kube/kclient"
 	"istio.io/istio/pkg/security"
@@ -33,6 +34,7 @@ import (
 type NodeAuthorizer struct {
 	trustedNodeAccounts map[types.NamespacedName]struct{}
 	pods                kclient.Client[*v1.Pod]
+	namespaces          kclient.Client[*v1.Namespace]
 	nodeIndex           *kclient.Index[SaNode, *v1.Pod]
 }
 
@@ -59,6 +61,7 @@ func NewNodeAuthorizer(client kube.Client, filter func(t any) bool, trustedNodeA
 	})
 	return &NodeAuthorizer{
 		pods:                pods,
+		namespaces:          kclient.New[*v1.Namespace](client),
 		nodeIndex:           index,
 		trustedNodeAccounts: trustedNodeAccounts,
 	}, nil
@@ -78,6 +81,13 @@ func (na *NodeAuthorizer) authenticateImpersonation(caller security.KubernetesIn
 	if err != nil {
 		return fmt.Errorf("failed to validate impersonated identity %v", requestedIdentityString)
 	}
+	// Namespaces overriding the trust domain of the mesh only have identities in their own trust domain
+	if ns := na.namespaces.Get(requestedIdentity.Namespace, ""); ns != nil {
+		if td := ns.Annotations[constants.AmbientTrustDomain]; td != "" && td != requestedIdentity.TrustDomain {
+			return fmt.Errorf("trust domain of impersonated identity %v does not match the trust domain %q of its namespace",
+				requestedIdentityString, td)
+		}
+	}
 
 	// Finally, we validate the requested identity is running on the same node the caller is on
 	callerPod := na.pods.Get(caller.PodName, caller.PodNamespace)
@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
+	"istio.io/istio/pkg/config/constants"
 	"istio.io/istio/pkg/kube"
 	"istio.io/istio/pkg/security"
 	"istio.io/istio/pkg/spiffe"
@@ -78,7 +79,9 @@ func TestNodeAuthorizer(t *testing.T) {
 		caller                  security.KubernetesInfo
 		requestedIdentityString string
 		trustedAccounts         map[types.NamespacedName]struct{}
-		wantErr                 string
+		// trustDomains are the trust domains overridden by namespaces
+		trustDomains map[string]string
+		wantErr      string
 	}{
 		{
 			name:    "empty allowed identities",
@@ -116,6 +119,24 @@ func TestNodeAuthorizer(t *testing.T) {
 			pods:                    []pod{ztunnelPod, podSameNode, podOtherNode},
 			wantErr:                 "",
 		},
+		{
+			name:                    "namespace trust domain",
+			caller:                  ztunnelCaller,
+			trustedAccounts:         allowZtunnel,
+			requestedIdentityString: "spiffe://example.com/ns/ns-a/sa/sa-a",
+			pods:                    []pod{ztunnelPod, podSameNode},
+			trustDomains:            map[string]string{"ns-a": "example.com"},
+			wantErr:                 "",
+		},
+		{
+			name:                    "mesh trust domain in namespace with its own",
+			caller:                  ztunnelCaller,
+			trustedAccounts:         allowZtunnel,
+			requestedIdentityString: podSameNode.Identity(),
+			pods:                    []pod{ztunnelPod, podSameNode},
+			trustDomains:            map[string]string{"ns-a": "example.com"},
+			wantErr:                 "does not match the trust domain",
+		},
 		{
 			name:                    "invalid requested",
 			caller:                  ztunnelCaller,
@@ -171,13 +192,21 @@ func TestNodeAuthorizer(t *testing.T) {
 					},
 				})
 			}
+			for ns, td := range tt.trustDomains {
+				pods = append(pods, &v1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        ns,
+						Annotations: map[string]string{constants.AmbientTrustDomain: td},
+					},
+				})
+			}
 			c := kube.NewFakeClient(pods...)
 			na, err := NewNodeAuthorizer(c, nil, tt.trustedAccounts)
 			if err != nil {
 				t.Fatal(err)
 			}
 			c.RunAndWait(test.NewStop(t))
-			kube.WaitForCacheSync(test.NewStop(t), na.pods.HasSynced)
+			kube.WaitForCacheSync(test.NewStop(t), na.pods.HasSynced, na.namespaces.HasSynced)
 
 			err = na.authenticateImpersonation(tt.caller, tt.requestedIdentityString)
 			if tt.wantErr == "" && err != nil {
@@ -15,6 +15,7 @@
 package ca
 
 import (
+	"strings"
 	"time"
 
 	"golang.org/x/net/context"
@@ -28,6 +29,7 @@ import (
 	"istio.io/istio/pkg/kube"
 	"istio.io/istio/pkg/kube/namespace"
 	"istio.io/istio/pkg/security"
+	"istio.io/istio/pkg/spiffe"
 	"istio.io/istio/security/pkg/pki/ca"
 	caerror "istio.io/istio/security/pkg/pki/error"
 	"istio.io/istio/security/pkg/pki/util"
@@ -56,6 +58,14 @@ type Server struct {
 	serverCertTTL  time.Duration
 
 	nodeAuthorizer *NodeAuthorizer
+	// IsRevoked, if set, reports whether the given identity has been revoked. Certificates are not issued
+	// for revoked identities, so existing certificates cannot be renewed once they expire.
+	IsRevoked func(identity string) bool
+	// TrustAnchors, if set, returns the trust anchors of the mesh, which are appended to the roots of the responses to
+	// the requests setting the TrustAnchors metadata.
+	TrustAnchors func() []string
+	// trustDomainSigners maps a trust domain to the signer used for impersonated identities in that trust domain.
+	trustDomainSigners map[string]string
 }
 
 type SaNode struct {
@@ -106,8 +116,22 @@ func (s *Server) CreateCertificate(ctx context.Context, request *pb.IstioCertifi
 		// Node is authorized to impersonate; overwrite the SAN to the impersonated identity.
 		sans = []string{impersonatedIdentity}
 	}
+	if s.IsRevoked != nil {
+		for _, san := range sans {
+			if s.IsRevoked(san) {
+				s.monitoring.AuthnError.Increment()
+				serverCaLog.Warnf("refusing to issue certificate for revoked identity %s", san)
+				return nil, status.Error(codes.PermissionDenied, "identity has been revoked")
+			}
+		}
+	}
 	certSigner := crMetadata[security.CertSigner].GetStringValue()
 	serverCaLog.Debugf("cert signer from workload %s", certSigner)
+	if certSigner == "" && impersonatedIdentity != "" {
+		// Node proxies do not set a signer on behalf of the workloads they impersonate, so select one based on trust domain.
+		certSigner = certSignerForIdentity(s.trustDomainSigners, impersonatedIdentity)
+		serverCaLog.Debugf("cert signer for impersonated identity %s: %s", impersonatedIdentity, certSigner)
+	}
 	_, _, certChainBytes, rootCertBytes := s.ca.GetCAKeyCertBundle().GetAll()
 	certOpts := ca.CertOpts{
 		SubjectIDs: sans,
@@ -137,6 +161,9 @@ func (s *Server) CreateCertificate(ctx context.Context, request *pb.IstioCertifi
 	if len(rootCertBytes) != 0 {
 		respCertChain = append(respCertChain, string(rootCertBytes))
 	}
+	if s.TrustAnchors != nil && crMetadata[security.TrustAnchors].GetBoolValue() {
+		respCertChain = appendTrustAnchors(respCertChain, string(rootCertBytes), s.TrustAnchors())
+	}
 	response := &pb.IstioCertificateResponse{
 		CertChain: respCertChain,
 	}
@@ -145,6 +172,28 @@ func (s *Server) CreateCertificate(ctx context.Context, request *pb.IstioCertifi
 	return response, nil
 }
 
+// appendTrustAnchors appends the trust anchors to the certificate chain, except the root it already ends with.
+func appendTrustAnchors(certChain []string, root string, anchors []string) []string {
+	for _, anchor := range anchors {
+		if strings.TrimSpace(anchor) != strings.TrimSpace(root) {
+			certChain = append(certChain, anchor)
+		}
+	}
+	return certChain
+}
+
+// certSignerForIdentity returns the signer configured for the trust domain of the identity, if any.
+func certSignerForIdentity(signers map[string]string, identity string) string {
+	if len(signers) == 0 {
+		return ""
+	}
+	id, err := spiffe.ParseIdentity(identity)
+	if err != nil {
+		return ""
+	}
+	return signers[id.TrustDomain]
+}
+
 func recordCertsExpiry(keyCertBundle *util.KeyCertBundle) {
 	rootCertExpiry, err := keyCertBundle.ExtractRootCertExpiryTimestamp()
 	if err != nil {
@@ -182,10 +231,11 @@ func New(
 	}
 
 	server := &Server{
-		Authenticators: authenticators,
-		serverCertTTL:  ttl,
-		ca:             ca,
-		monitoring:     newMonitoringMetrics(),
+		Authenticators:     authenticators,
+		serverCertTTL:      ttl,
+		ca:                 ca,
+		monitoring:         newMonitoringMetrics(),
+		trustDomainSigners: features.CATrustDomainCertSigners,
 	}
 
 	if len(features.CATrustedNodeAccounts) > 0 && client != nil {
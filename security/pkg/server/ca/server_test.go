@@ -27,6 +27,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	pb "istio.io/api/security/v1alpha1"
 	"istio.io/istio/pkg/security"
@@ -186,6 +187,8 @@ func TestCreateCertificate(t *testing.T) {
 		ca             CertificateAuthority
 		certChain      []string
 		code           codes.Code
+		isRevoked      func(identity string) bool
+		trustAnchors   bool
 	}{
 		"No authenticator": {
 			authenticators: nil,
@@ -228,15 +231,41 @@ func TestCreateCertificate(t *testing.T) {
 			certChain: []string{"cert", "cert_chain", "root_cert"},
 			code:      codes.OK,
 		},
+		"Revoked identity": {
+			authenticators: []security.Authenticator{&mockAuthenticator{identities: []string{"test-identity"}}},
+			ca: &mockca.FakeCA{
+				SignedCert:    []byte("cert"),
+				KeyCertBundle: util.NewKeyCertBundleFromPem(nil, nil, []byte("cert_chain"), []byte("root_cert")),
+			},
+			isRevoked: func(identity string) bool { return identity == "test-identity" },
+			code:      codes.PermissionDenied,
+		},
+		"Trust anchors": {
+			authenticators: []security.Authenticator{&mockAuthenticator{identities: []string{"test-identity"}}},
+			ca: &mockca.FakeCA{
+				SignedCert:    []byte("cert"),
+				KeyCertBundle: util.NewKeyCertBundleFromPem(nil, nil, []byte("cert_chain"), []byte("root_cert")),
+			},
+			trustAnchors: true,
+			certChain:    []string{"cert", "cert_chain", "root_cert", "remote_root_cert"},
+			code:         codes.OK,
+		},
 	}
 
 	for id, c := range testCases {
 		server := &Server{
 			ca:             c.ca,
 			Authenticators: c.authenticators,
+			IsRevoked:      c.isRevoked,
+			TrustAnchors:   func() []string { return []string{"root_cert\n", "remote_root_cert"} },
 			monitoring:     newMonitoringMetrics(),
 		}
 		request := &pb.IstioCertificateRequest{Csr: "dumb CSR"}
+		if c.trustAnchors {
+			request.Metadata = &structpb.Struct{Fields: map[string]*structpb.Value{
+				security.TrustAnchors: structpb.NewBoolValue(true),
+			}}
+		}
 
 		response, err := server.CreateCertificate(context.Background(), request)
 		s, _ := status.FromError(err)
@@ -258,3 +287,44 @@ func TestCreateCertificate(t *testing.T) {
 		}
 	}
 }
+
+func TestCertSignerForIdentity(t *testing.T) {
+	signers := map[string]string{
+		"cluster.local": "ambient",
+		"corp.example":  "corporate-pki",
+	}
+	cases := []struct {
+		name     string
+		signers  map[string]string
+		identity string
+		want     string
+	}{
+		{
+			name:     "no signers",
+			identity: "spiffe://cluster.local/ns/default/sa/default",
+		},
+		{
+			name:     "matching trust domain",
+			signers:  signers,
+			identity: "spiffe://corp.example/ns/default/sa/default",
+			want:     "corporate-pki",
+		},
+		{
+			name:     "unknown trust domain",
+			signers:  signers,
+			identity: "spiffe://other.example/ns/default/sa/default",
+		},
+		{
+			name:     "invalid identity",
+			signers:  signers,
+			identity: "not-spiffe-identity",
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := certSignerForIdentity(tt.signers, tt.identity); got != tt.want {
+				t.Errorf("got signer %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
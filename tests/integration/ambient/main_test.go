@@ -83,6 +83,14 @@ values:
         DNS_PROXY_ADDR: "0.0.0.0:15053"
     accessLogFile: /dev/stdout`
 
+// KubeProxyReplacementValues are added to the ControlPlaneValues on clusters where services are handled by the CNI
+// with eBPF instead of kube-proxy, so pods are captured before their traffic to service VIPs is translated.
+var KubeProxyReplacementValues = `
+  cni:
+    ambient:
+      redirectMode: ebpf
+      kubeProxyReplacement: true`
+
 // TestMain defines the entrypoint for pilot tests using a standard Istio installation.
 // If a test requires a custom install it should go into its own package, otherwise it should go
 // here to reuse a single install across tests.
@@ -100,6 +108,10 @@ func TestMain(m *testing.M) {
 		Setup(istio.Setup(&i, func(ctx resource.Context, cfg *istio.Config) {
 			cfg.DeployEastWestGW = false
 			cfg.ControlPlaneValues = ControlPlaneValues
+			if kubeProxyReplaced(ctx) {
+				scopes.Framework.Infof("kube-proxy not found, enabling kube-proxy replacement compatibility")
+				cfg.ControlPlaneValues += KubeProxyReplacementValues
+			}
 		})).
 		Setup(func(t resource.Context) error {
 			return SetupApps(t, i, apps)
@@ -107,6 +119,13 @@ func TestMain(m *testing.M) {
 		Run()
 }
 
+// kubeProxyReplaced returns whether the cluster runs without kube-proxy, its services being handled by a CNI such as
+// Cilium or Calico eBPF.
+func kubeProxyReplaced(ctx resource.Context) bool {
+	_, err := ctx.Clusters().Default().Kube().AppsV1().DaemonSets("kube-system").Get(context.TODO(), "kube-proxy", metav1.GetOptions{})
+	return kerrors.IsNotFound(err)
+}
+
 const (
 	Waypoint          = "waypoint"
 	Captured          = "captured"
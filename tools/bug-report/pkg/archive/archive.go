@@ -31,6 +31,7 @@ const (
 	clusterInfoSubdir      = "cluster"
 	analyzeSubdir          = "analyze"
 	operatorLogsPathSubdir = "operator"
+	bugReportLog           = "bug-report.log"
 )
 
 var (
@@ -43,6 +44,11 @@ func DirToArchive(rootDir string) string {
 	return filepath.Dir(getRootDir(rootDir))
 }
 
+// BugReportLogPath is the path of the log of the bug report command.
+func BugReportLogPath(rootDir string) string {
+	return filepath.Join(getRootDir(rootDir), bugReportLog)
+}
+
 // OutputRootDir is the root dir of output artifacts.
 func OutputRootDir(rootDir string) string {
 	return getRootDir(rootDir)
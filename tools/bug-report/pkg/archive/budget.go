@@ -0,0 +1,142 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// truncatedNote marks the artifacts truncated to fit the size budget.
+const truncatedNote = "\n========= Truncated to fit the bug report size budget =========\n"
+
+// Artifact is a file to archive, with how it is truncated to fit the size budget of the archive.
+type Artifact struct {
+	// Path is the path of the file.
+	Path string
+	// Tail is whether the end of the file is kept when it is truncated, as for logs whose latest entries matter most.
+	// The beginning of the file is kept otherwise.
+	Tail bool
+}
+
+// Artifacts returns the artifacts in the output dir, sorted by decreasing priority: the versions and the analysis
+// first, then the Istiod and operator information, the cluster resources, the proxy and ztunnel debug information,
+// and finally the proxy logs, by decreasing importance. logImportance is the importance of the proxy logs, keyed by
+// path. The bug report log is not included, so it is always kept.
+func Artifacts(rootDir string, logImportance map[string]int) ([]Artifact, error) {
+	root := getRootDir(rootDir)
+	type ranked struct {
+		Artifact
+		tier       int
+		importance int
+	}
+	var all []ranked
+	err := filepath.Walk(root, func(file string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		subdir, _, _ := strings.Cut(filepath.ToSlash(rel), "/")
+		r := ranked{Artifact: Artifact{Path: file}}
+		switch subdir {
+		case bugReportLog:
+			return nil
+		case istioLogsPathSubdir, operatorLogsPathSubdir:
+			r.tier = 1
+		case clusterInfoSubdir:
+			r.tier = 2
+		case proxyLogsPathSubdir:
+			r.tier = 3
+			if importance, ok := logImportance[file]; ok {
+				r.tier = 4
+				r.importance = importance
+				r.Tail = true
+			}
+		}
+		all = append(all, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].tier != all[j].tier {
+			return all[i].tier < all[j].tier
+		}
+		if all[i].importance != all[j].importance {
+			return all[i].importance > all[j].importance
+		}
+		return all[i].Path < all[j].Path
+	})
+	res := make([]Artifact, 0, len(all))
+	for _, r := range all {
+		res = append(res, r.Artifact)
+	}
+	return res, nil
+}
+
+// ApplyBudget truncates or removes artifacts so their total size does not exceed maxSize bytes. The budget is
+// allocated to the artifacts in order, so they must be sorted by decreasing priority: the first artifact which does
+// not fit in the remaining budget is truncated to it, and the following ones are removed. It returns the paths of the
+// truncated and of the removed artifacts.
+func ApplyBudget(artifacts []Artifact, maxSize int64) (truncated []string, removed []string, err error) {
+	remaining := maxSize
+	for _, a := range artifacts {
+		fi, err := os.Stat(a.Path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if fi.Size() <= remaining {
+			remaining -= fi.Size()
+			continue
+		}
+		if remaining <= int64(len(truncatedNote)) {
+			if err := os.Remove(a.Path); err != nil {
+				return nil, nil, err
+			}
+			removed = append(removed, a.Path)
+			continue
+		}
+		if err := truncate(a, remaining-int64(len(truncatedNote))); err != nil {
+			return nil, nil, err
+		}
+		truncated = append(truncated, a.Path)
+		remaining = 0
+	}
+	return truncated, removed, nil
+}
+
+// truncate truncates an artifact to size bytes, and marks it as truncated.
+func truncate(a Artifact, size int64) error {
+	b, err := os.ReadFile(a.Path)
+	if err != nil {
+		return err
+	}
+	var out []byte
+	if a.Tail {
+		out = append([]byte(truncatedNote), b[int64(len(b))-size:]...)
+	} else {
+		out = append(b[:size:size], truncatedNote...)
+	}
+	return os.WriteFile(a.Path, out, 0o644)
+}
@@ -0,0 +1,88 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package archive
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"istio.io/istio/pkg/test/util/assert"
+)
+
+func writeArtifact(t *testing.T, path, text string) string {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(text), 0o644))
+	return path
+}
+
+func TestArtifacts(t *testing.T) {
+	root := t.TempDir()
+	bugReportLog := writeArtifact(t, BugReportLogPath(root), "log")
+	versions := writeArtifact(t, filepath.Join(root, "versions"), "v")
+	analyze := writeArtifact(t, filepath.Join(AnalyzePath(root, "all"), "all"), "a")
+	istiod := writeArtifact(t, filepath.Join(IstiodPath(root, "istio-system", "istiod"), "discovery.log"), "i")
+	cluster := writeArtifact(t, filepath.Join(ClusterInfoPath(root), "crs"), "c")
+	ztunnel := writeArtifact(t, filepath.Join(ProxyOutputPath(root, "istio-system", "ztunnel"), "config_dump"), "z")
+	lowLog := writeArtifact(t, filepath.Join(ProxyOutputPath(root, "a", "low"), "istio-proxy.log"), "l")
+	highLog := writeArtifact(t, filepath.Join(ProxyOutputPath(root, "b", "high"), "istio-proxy.log"), "h")
+
+	got, err := Artifacts(root, map[string]int{lowLog: 1, highLog: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, got, []Artifact{
+		{Path: analyze},
+		{Path: versions},
+		{Path: istiod},
+		{Path: cluster},
+		{Path: ztunnel},
+		{Path: highLog, Tail: true},
+		{Path: lowLog, Tail: true},
+	})
+	if _, err := os.Stat(bugReportLog); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplyBudget(t *testing.T) {
+	dir := t.TempDir()
+	content := strings.Repeat("0123456789", 10)
+	head := writeArtifact(t, filepath.Join(dir, "head"), content)
+	tail := writeArtifact(t, filepath.Join(dir, "tail"), content)
+	removed := writeArtifact(t, filepath.Join(dir, "removed"), content)
+	maxSize := int64(len(content) + len(truncatedNote) + 10)
+
+	gotTruncated, gotRemoved, err := ApplyBudget([]Artifact{{Path: head}, {Path: tail, Tail: true}, {Path: removed}}, maxSize)
+	assert.NoError(t, err)
+	assert.Equal(t, gotTruncated, []string{tail})
+	assert.Equal(t, gotRemoved, []string{removed})
+
+	b, err := os.ReadFile(head)
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), content)
+	b, err = os.ReadFile(tail)
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), truncatedNote+"0123456789")
+	if _, err := os.Stat(removed); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed, got %v", removed, err)
+	}
+
+	// Truncating from the head keeps the beginning of the file
+	assert.NoError(t, truncate(Artifact{Path: head}, 5))
+	b, err = os.ReadFile(head)
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), "01234"+truncatedNote)
+}
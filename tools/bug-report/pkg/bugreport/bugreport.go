@@ -45,6 +45,7 @@ import (
 	"istio.io/istio/tools/bug-report/pkg/kubeclient"
 	"istio.io/istio/tools/bug-report/pkg/kubectlcmd"
 	"istio.io/istio/tools/bug-report/pkg/processlog"
+	"istio.io/istio/tools/bug-report/pkg/redact"
 	"istio.io/pkg/log"
 	"istio.io/pkg/version"
 )
@@ -97,6 +98,8 @@ var (
 	// Aggregated errors for all fetch operations.
 	gErrors util.Errors
 	lock    = sync.RWMutex{}
+	// Redacts the sensitive content of the artifacts. Nil if redaction is disabled.
+	redactor *redact.Redactor
 )
 
 func runBugReportCommand(_ *cobra.Command, logOpts *log.Options) error {
@@ -109,6 +112,10 @@ func runBugReportCommand(_ *cobra.Command, logOpts *log.Options) error {
 	if err != nil {
 		return err
 	}
+	redactor, err = newRedactor(config)
+	if err != nil {
+		return err
+	}
 	clusterCtxStr := ""
 	if config.Context == "" {
 		var err error
@@ -163,14 +170,22 @@ func runBugReportCommand(_ *cobra.Command, logOpts *log.Options) error {
 		log.Error(gErrors.ToError())
 	}
 
-	// TODO: sort by importance and discard any over the size limit.
+	logImportance := make(map[string]int)
 	for path, text := range logs {
 		namespace, _, pod, _, err := cluster2.ParsePath(path)
 		if err != nil {
 			log.Errorf(err.Error())
 			continue
 		}
-		writeFile(filepath.Join(archive.ProxyOutputPath(tempDir, namespace, pod), common.ProxyContainerName+".log"), text, config.DryRun)
+		logPath := filepath.Join(archive.ProxyOutputPath(tempDir, namespace, pod), common.ProxyContainerName+".log")
+		writeFile(logPath, text, config.DryRun)
+		logImportance[logPath] = importance[path]
+	}
+
+	if !config.DryRun && config.MaxArchiveSizeMb > 0 {
+		if err := applySizeBudget(int64(config.MaxArchiveSizeMb)*1024*1024, logImportance); err != nil {
+			return err
+		}
 	}
 
 	outDir, err := os.Getwd()
@@ -458,6 +473,43 @@ func runAnalyze(config *config.BugReportConfig, params *content.Params, analyzeT
 	writeFiles(archive.AnalyzePath(tempDir, common.StrNamespaceAll), out, config.DryRun)
 }
 
+// newRedactor returns the redactor of the artifacts for the config. The secrets are not redacted if the full
+// secrets are requested.
+func newRedactor(config *config.BugReportConfig) (*redact.Redactor, error) {
+	kinds := config.Redact
+	if config.FullSecrets {
+		kinds = nil
+		for _, k := range config.Redact {
+			if k != redact.Secrets {
+				kinds = append(kinds, k)
+			}
+		}
+		if len(kinds) == 0 {
+			kinds = []string{redact.None}
+		}
+	}
+	return redact.New(kinds)
+}
+
+// applySizeBudget truncates or removes the least important artifacts so their total size does not exceed maxSize
+// bytes.
+func applySizeBudget(maxSize int64, logImportance map[string]int) error {
+	artifacts, err := archive.Artifacts(tempDir, logImportance)
+	if err != nil {
+		return err
+	}
+	truncated, removed, err := archive.ApplyBudget(artifacts, maxSize)
+	if err != nil {
+		return err
+	}
+	if len(truncated) > 0 || len(removed) > 0 {
+		common.LogAndPrintf("\nThe archive exceeds the maximum size of %d MB, truncated %d and removed %d files.\n",
+			maxSize/(1024*1024), len(truncated), len(removed))
+		log.Infof("Truncated files:\n%s\n\nRemoved files:\n%s\n", strings.Join(truncated, "\n"), strings.Join(removed, "\n"))
+	}
+	return nil
+}
+
 func writeFiles(dir string, files map[string]string, dryRun bool) {
 	for fname, text := range files {
 		writeFile(filepath.Join(dir, fname), text, dryRun)
@@ -472,7 +524,7 @@ func writeFile(path, text string, dryRun bool) {
 		return
 	}
 	mkdirOrExit(path)
-	if err := os.WriteFile(path, []byte(text), 0o644); err != nil {
+	if err := os.WriteFile(path, []byte(redactor.Redact(text)), 0o644); err != nil {
 		log.Errorf(err.Error())
 	}
 }
@@ -494,7 +546,7 @@ func appendGlobalErr(err error) {
 }
 
 func configLogs(opt *log.Options) error {
-	logDir := filepath.Join(archive.OutputRootDir(tempDir), "bug-report.log")
+	logDir := archive.BugReportLogPath(tempDir)
 	mkdirOrExit(logDir)
 	f, err := os.Create(logDir)
 	if err != nil {
@@ -26,6 +26,7 @@ import (
 
 	"istio.io/istio/pkg/kube/inject"
 	config2 "istio.io/istio/tools/bug-report/pkg/config"
+	"istio.io/istio/tools/bug-report/pkg/redact"
 )
 
 var (
@@ -55,6 +56,12 @@ func addFlags(cmd *cobra.Command, args *config2.BugReportConfig) {
 	cmd.PersistentFlags().BoolVarP(&args.FullSecrets, "full-secrets", "", false,
 		"If set, secret contents are included in output.")
 
+	// redaction
+	cmd.PersistentFlags().StringSliceVar(&args.Redact, "redact", redact.All,
+		"Kinds of content redacted from the archive, so it can be attached to public issues: secrets (the data of "+
+			"Kubernetes Secrets, unless --full-secrets is set), tokens (bearer tokens and JWTs) and certs (PEM encoded "+
+			"certificates and keys), or none.")
+
 	// istio namespaces
 	cmd.PersistentFlags().StringVar(&args.IstioNamespace, "istio-namespace", bugReportDefaultIstioNamespace,
 		"Namespace where Istio control plane is installed.")
@@ -63,6 +70,10 @@ func addFlags(cmd *cobra.Command, args *config2.BugReportConfig) {
 	cmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", bugReportDefaultTimeout,
 		"Maximum amount of time to spend fetching logs. When timeout is reached "+
 			"only the logs captured so far are saved to the archive.")
+	cmd.PersistentFlags().IntVar(&args.MaxArchiveSizeMb, "max-archive-size-mb", 0,
+		"Maximum size of the archived files in megabytes, before compression. When exceeded, the least important "+
+			"files are truncated or removed: proxy logs with the fewest errors first, then proxy and ztunnel debug "+
+			"information, cluster resources, and Istiod information. The size is not limited if 0.")
 	// include / exclude specs
 	cmd.PersistentFlags().StringSliceVar(&included, "include", bugReportDefaultInclude,
 		"Spec for which pod's proxy logs to include in the archive. See above for format and examples.")
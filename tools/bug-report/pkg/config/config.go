@@ -172,6 +172,13 @@ type BugReportConfig struct {
 
 	// RequestsPerSecondLimit controls the RPS limit to the API server.
 	RequestsPerSecondLimit int `json:"requestsPerSecondLimit,omitempty"`
+
+	// Redact is the list of kinds of content redacted from the archive: secrets, tokens and certs, or none.
+	// The secrets are not redacted if FullSecrets is set.
+	Redact []string `json:"redact,omitempty"`
+	// MaxArchiveSizeMb is the maximum size of the archived files in megabytes, before compression. The least
+	// important files are truncated or removed to fit. The size is not limited if 0.
+	MaxArchiveSizeMb int `json:"maxArchiveSizeMb,omitempty"`
 }
 
 func (b *BugReportConfig) String() string {
@@ -184,6 +191,10 @@ func (b *BugReportConfig) String() string {
 	}
 	out += fmt.Sprintf("istio-namespace: %s\n", b.IstioNamespace)
 	out += fmt.Sprintf("full-secrets: %v\n", b.FullSecrets)
+	out += fmt.Sprintf("redact: %s\n", strings.Join(b.Redact, ","))
+	if b.MaxArchiveSizeMb != 0 {
+		out += fmt.Sprintf("max-archive-size (MB): %d\n", b.MaxArchiveSizeMb)
+	}
 	out += fmt.Sprintf("timeout (mins): %v\n", math.Round(float64(int(b.CommandTimeout))/float64(time.Minute)))
 	out += fmt.Sprintf("include: %s\n", b.Include)
 	out += fmt.Sprintf("exclude: %s\n", b.Exclude)
@@ -0,0 +1,162 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact removes sensitive content from the bug report artifacts, so the archive can be attached to public
+// issues.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"istio.io/istio/pkg/util/sets"
+)
+
+// Kinds of content which can be redacted.
+const (
+	// Secrets are the data of Kubernetes Secrets.
+	Secrets = "secrets"
+	// Tokens are bearer tokens and JWTs, such as service account tokens.
+	Tokens = "tokens"
+	// Certs are PEM encoded certificates and keys, and their base64 encoding.
+	Certs = "certs"
+	// None disables redaction.
+	None = "none"
+
+	// Redacted replaces the redacted content.
+	Redacted = "[redacted]"
+)
+
+// All are all the kinds of content which can be redacted.
+var All = []string{Secrets, Tokens, Certs}
+
+var (
+	bearerPattern    = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	jwtPattern       = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+	pemPattern       = regexp.MustCompile(`-----BEGIN ([A-Z0-9 ]+)-----[\s\S]*?-----END ([A-Z0-9 ]+)-----`)
+	base64PEMPattern = regexp.MustCompile(`LS0tLS1CRUdJTi[A-Za-z0-9+/=]*`)
+)
+
+// Redactor redacts kinds of content from text. A nil Redactor returns the text as is.
+type Redactor struct {
+	kinds sets.String
+}
+
+// New returns a Redactor for the given kinds of content. None, alone, disables redaction.
+func New(kinds []string) (*Redactor, error) {
+	if len(kinds) == 1 && kinds[0] == None {
+		return nil, nil
+	}
+	r := &Redactor{kinds: sets.New[string]()}
+	for _, k := range kinds {
+		if !sets.New(All...).Contains(k) {
+			return nil, fmt.Errorf("unknown kind of content to redact %q, must be one of %s or %s",
+				k, strings.Join(All, ", "), None)
+		}
+		r.kinds.Insert(k)
+	}
+	return r, nil
+}
+
+// Redact returns the text with the content of the kinds of the Redactor replaced with Redacted.
+func (r *Redactor) Redact(text string) string {
+	if r == nil {
+		return text
+	}
+	if r.kinds.Contains(Certs) {
+		text = pemPattern.ReplaceAllString(text, "-----BEGIN $1-----"+Redacted+"-----END $2-----")
+		text = base64PEMPattern.ReplaceAllString(text, Redacted)
+	}
+	if r.kinds.Contains(Tokens) {
+		text = bearerPattern.ReplaceAllString(text, "${1}"+Redacted)
+		text = jwtPattern.ReplaceAllString(text, Redacted)
+	}
+	if r.kinds.Contains(Secrets) {
+		text = redactSecretData(text)
+	}
+	return text
+}
+
+// redactSecretData replaces the values of the data and stringData fields of the Secrets in YAML text, as output by
+// kubectl.
+func redactSecretData(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		indent, key := yamlKey(l)
+		if key != "data:" && key != "stringData:" || !isSecret(lines, i, indent) {
+			continue
+		}
+		childIndent := -1
+		for j := i + 1; j < len(lines); j++ {
+			valueIndent, value := yamlKey(lines[j])
+			if value == "" {
+				continue
+			}
+			if valueIndent <= indent {
+				break
+			}
+			if childIndent < 0 {
+				childIndent = valueIndent
+			}
+			if k, _, f := strings.Cut(value, ":"); f && valueIndent == childIndent {
+				lines[j] = lines[j][:valueIndent] + k + ": " + Redacted
+			} else {
+				// The continuation of a multi-line value
+				lines[j] = lines[j][:valueIndent] + Redacted
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isSecret returns whether the mapping with a key at the given line and indent is a Secret.
+func isSecret(lines []string, line, indent int) bool {
+	// Look for the kind among the sibling keys, before and after the line
+	for _, step := range []int{-1, 1} {
+		for j := line + step; j >= 0 && j < len(lines); j += step {
+			i, key := yamlKey(lines[j])
+			if key == "" || i > indent {
+				continue
+			}
+			if i < indent {
+				break
+			}
+			item := strings.HasPrefix(strings.TrimSpace(lines[j]), "- ")
+			if item && step > 0 {
+				// The next list item
+				break
+			}
+			if key == "kind: Secret" {
+				return true
+			}
+			if item {
+				// The first key of the list item
+				break
+			}
+		}
+	}
+	return false
+}
+
+// yamlKey returns the indent of the content of a YAML line, including the list item markers, and the content.
+func yamlKey(line string) (int, string) {
+	content := strings.TrimLeft(line, " ")
+	indent := len(line) - len(content)
+	for strings.HasPrefix(content, "- ") {
+		content = content[2:]
+		indent += 2
+	}
+	return indent, strings.TrimRight(content, " \r")
+}